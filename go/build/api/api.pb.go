@@ -1247,6 +1247,9 @@ func (b0 ResponseGetServerInfo_builder) Build() *ResponseGetServerInfo {
 	return m0
 }
 
+// ServerConfig is a curated, human-meaningful view of the builder's runtime
+// configuration shown on the info page. It intentionally omits secrets
+// (credentials, tokens, DSN). Durations are pre-formatted strings.
 type ServerConfig struct {
 	state                                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Dev                         bool                   `protobuf:"varint,1,opt,name=dev"`
@@ -2002,14 +2005,16 @@ type ServerConfig_builder struct {
 	GithubAppId                 *int64
 	VaultAddr                   *string
 	DashboardUrl                *string
-	BazelImage                  *string
-	SidecarImage                *string
-	BazelMirrorUrl              *string
-	CentralRegistryMirrorUrl    *string
-	RemoteAssetApi              *bool
-	PullAlways                  *bool
-	GithubInstallationId        *int64
-	GithubAppSecretName         *string
+	// The following fields expose the runtime configuration required to render a
+	// runnable Job manifest (e.g. by buildctl jobs manifest). They are not secrets.
+	BazelImage               *string
+	SidecarImage             *string
+	BazelMirrorUrl           *string
+	CentralRegistryMirrorUrl *string
+	RemoteAssetApi           *bool
+	PullAlways               *bool
+	GithubInstallationId     *int64
+	GithubAppSecretName      *string
 }
 
 func (b0 ServerConfig_builder) Build() *ServerConfig {
@@ -2391,6 +2396,728 @@ func (b0 ResponseListGithubEvents_builder) Build() *ResponseListGithubEvents {
 	return m0
 }
 
+type RequestGetTaskBuildResult struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_TaskId      int32                  `protobuf:"varint,1,opt,name=task_id,json=taskId"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RequestGetTaskBuildResult) Reset() {
+	*x = RequestGetTaskBuildResult{}
+	mi := &file_proto_build_api_api_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestGetTaskBuildResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestGetTaskBuildResult) ProtoMessage() {}
+
+func (x *RequestGetTaskBuildResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_api_api_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RequestGetTaskBuildResult) GetTaskId() int32 {
+	if x != nil {
+		return x.xxx_hidden_TaskId
+	}
+	return 0
+}
+
+func (x *RequestGetTaskBuildResult) SetTaskId(v int32) {
+	x.xxx_hidden_TaskId = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *RequestGetTaskBuildResult) HasTaskId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RequestGetTaskBuildResult) ClearTaskId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_TaskId = 0
+}
+
+type RequestGetTaskBuildResult_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	TaskId *int32
+}
+
+func (b0 RequestGetTaskBuildResult_builder) Build() *RequestGetTaskBuildResult {
+	m0 := &RequestGetTaskBuildResult{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.TaskId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_TaskId = *b.TaskId
+	}
+	return m0
+}
+
+type ResponseGetTaskBuildResult struct {
+	state                     protoimpl.MessageState  `protogen:"opaque.v1"`
+	xxx_hidden_Targets        *[]*model.TargetResult  `protobuf:"bytes,1,rep,name=targets"`
+	xxx_hidden_ActionFailures *[]*model.ActionFailure `protobuf:"bytes,2,rep,name=action_failures,json=actionFailures"`
+	xxx_hidden_Metrics        *model.BuildMetrics     `protobuf:"bytes,3,opt,name=metrics"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *ResponseGetTaskBuildResult) Reset() {
+	*x = ResponseGetTaskBuildResult{}
+	mi := &file_proto_build_api_api_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseGetTaskBuildResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseGetTaskBuildResult) ProtoMessage() {}
+
+func (x *ResponseGetTaskBuildResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_api_api_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ResponseGetTaskBuildResult) GetTargets() []*model.TargetResult {
+	if x != nil {
+		if x.xxx_hidden_Targets != nil {
+			return *x.xxx_hidden_Targets
+		}
+	}
+	return nil
+}
+
+func (x *ResponseGetTaskBuildResult) GetActionFailures() []*model.ActionFailure {
+	if x != nil {
+		if x.xxx_hidden_ActionFailures != nil {
+			return *x.xxx_hidden_ActionFailures
+		}
+	}
+	return nil
+}
+
+func (x *ResponseGetTaskBuildResult) GetMetrics() *model.BuildMetrics {
+	if x != nil {
+		return x.xxx_hidden_Metrics
+	}
+	return nil
+}
+
+func (x *ResponseGetTaskBuildResult) SetTargets(v []*model.TargetResult) {
+	x.xxx_hidden_Targets = &v
+}
+
+func (x *ResponseGetTaskBuildResult) SetActionFailures(v []*model.ActionFailure) {
+	x.xxx_hidden_ActionFailures = &v
+}
+
+func (x *ResponseGetTaskBuildResult) SetMetrics(v *model.BuildMetrics) {
+	x.xxx_hidden_Metrics = v
+}
+
+func (x *ResponseGetTaskBuildResult) HasMetrics() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Metrics != nil
+}
+
+func (x *ResponseGetTaskBuildResult) ClearMetrics() {
+	x.xxx_hidden_Metrics = nil
+}
+
+type ResponseGetTaskBuildResult_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Targets        []*model.TargetResult
+	ActionFailures []*model.ActionFailure
+	// metrics is not set if the task has not reported the metrics.
+	Metrics *model.BuildMetrics
+}
+
+func (b0 ResponseGetTaskBuildResult_builder) Build() *ResponseGetTaskBuildResult {
+	m0 := &ResponseGetTaskBuildResult{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Targets = &b.Targets
+	x.xxx_hidden_ActionFailures = &b.ActionFailures
+	x.xxx_hidden_Metrics = b.Metrics
+	return m0
+}
+
+type RequestListFlakyTests struct {
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_RepositoryId    int32                  `protobuf:"varint,1,opt,name=repository_id,json=repositoryId"`
+	xxx_hidden_QuarantinedOnly bool                   `protobuf:"varint,2,opt,name=quarantined_only,json=quarantinedOnly"`
+	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
+	XXX_presence               [1]uint32
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *RequestListFlakyTests) Reset() {
+	*x = RequestListFlakyTests{}
+	mi := &file_proto_build_api_api_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestListFlakyTests) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestListFlakyTests) ProtoMessage() {}
+
+func (x *RequestListFlakyTests) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_api_api_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RequestListFlakyTests) GetRepositoryId() int32 {
+	if x != nil {
+		return x.xxx_hidden_RepositoryId
+	}
+	return 0
+}
+
+func (x *RequestListFlakyTests) GetQuarantinedOnly() bool {
+	if x != nil {
+		return x.xxx_hidden_QuarantinedOnly
+	}
+	return false
+}
+
+func (x *RequestListFlakyTests) SetRepositoryId(v int32) {
+	x.xxx_hidden_RepositoryId = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *RequestListFlakyTests) SetQuarantinedOnly(v bool) {
+	x.xxx_hidden_QuarantinedOnly = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *RequestListFlakyTests) HasRepositoryId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RequestListFlakyTests) HasQuarantinedOnly() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RequestListFlakyTests) ClearRepositoryId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_RepositoryId = 0
+}
+
+func (x *RequestListFlakyTests) ClearQuarantinedOnly() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_QuarantinedOnly = false
+}
+
+type RequestListFlakyTests_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	RepositoryId *int32
+	// If true, only the quarantined tests are returned.
+	QuarantinedOnly *bool
+}
+
+func (b0 RequestListFlakyTests_builder) Build() *RequestListFlakyTests {
+	m0 := &RequestListFlakyTests{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.RepositoryId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_RepositoryId = *b.RepositoryId
+	}
+	if b.QuarantinedOnly != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_QuarantinedOnly = *b.QuarantinedOnly
+	}
+	return m0
+}
+
+type ResponseListFlakyTests struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Tests *[]*model.FlakyTest    `protobuf:"bytes,1,rep,name=tests"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ResponseListFlakyTests) Reset() {
+	*x = ResponseListFlakyTests{}
+	mi := &file_proto_build_api_api_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseListFlakyTests) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseListFlakyTests) ProtoMessage() {}
+
+func (x *ResponseListFlakyTests) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_api_api_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ResponseListFlakyTests) GetTests() []*model.FlakyTest {
+	if x != nil {
+		if x.xxx_hidden_Tests != nil {
+			return *x.xxx_hidden_Tests
+		}
+	}
+	return nil
+}
+
+func (x *ResponseListFlakyTests) SetTests(v []*model.FlakyTest) {
+	x.xxx_hidden_Tests = &v
+}
+
+type ResponseListFlakyTests_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Tests []*model.FlakyTest
+}
+
+func (b0 ResponseListFlakyTests_builder) Build() *ResponseListFlakyTests {
+	m0 := &ResponseListFlakyTests{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Tests = &b.Tests
+	return m0
+}
+
+type RequestGetRunningTaskLog struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_TaskId      int32                  `protobuf:"varint,1,opt,name=task_id,json=taskId"`
+	xxx_hidden_Offset      int64                  `protobuf:"varint,2,opt,name=offset"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RequestGetRunningTaskLog) Reset() {
+	*x = RequestGetRunningTaskLog{}
+	mi := &file_proto_build_api_api_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestGetRunningTaskLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestGetRunningTaskLog) ProtoMessage() {}
+
+func (x *RequestGetRunningTaskLog) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_api_api_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RequestGetRunningTaskLog) GetTaskId() int32 {
+	if x != nil {
+		return x.xxx_hidden_TaskId
+	}
+	return 0
+}
+
+func (x *RequestGetRunningTaskLog) GetOffset() int64 {
+	if x != nil {
+		return x.xxx_hidden_Offset
+	}
+	return 0
+}
+
+func (x *RequestGetRunningTaskLog) SetTaskId(v int32) {
+	x.xxx_hidden_TaskId = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *RequestGetRunningTaskLog) SetOffset(v int64) {
+	x.xxx_hidden_Offset = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *RequestGetRunningTaskLog) HasTaskId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RequestGetRunningTaskLog) HasOffset() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RequestGetRunningTaskLog) ClearTaskId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_TaskId = 0
+}
+
+func (x *RequestGetRunningTaskLog) ClearOffset() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Offset = 0
+}
+
+type RequestGetRunningTaskLog_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	TaskId *int32
+	// offset is the byte offset of the log to start reading from.
+	Offset *int64
+}
+
+func (b0 RequestGetRunningTaskLog_builder) Build() *RequestGetRunningTaskLog {
+	m0 := &RequestGetRunningTaskLog{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.TaskId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_TaskId = *b.TaskId
+	}
+	if b.Offset != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Offset = *b.Offset
+	}
+	return m0
+}
+
+type ResponseGetRunningTaskLog struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Body        []byte                 `protobuf:"bytes,1,opt,name=body"`
+	xxx_hidden_Offset      int64                  `protobuf:"varint,2,opt,name=offset"`
+	xxx_hidden_Running     bool                   `protobuf:"varint,3,opt,name=running"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ResponseGetRunningTaskLog) Reset() {
+	*x = ResponseGetRunningTaskLog{}
+	mi := &file_proto_build_api_api_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseGetRunningTaskLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseGetRunningTaskLog) ProtoMessage() {}
+
+func (x *ResponseGetRunningTaskLog) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_api_api_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ResponseGetRunningTaskLog) GetBody() []byte {
+	if x != nil {
+		return x.xxx_hidden_Body
+	}
+	return nil
+}
+
+func (x *ResponseGetRunningTaskLog) GetOffset() int64 {
+	if x != nil {
+		return x.xxx_hidden_Offset
+	}
+	return 0
+}
+
+func (x *ResponseGetRunningTaskLog) GetRunning() bool {
+	if x != nil {
+		return x.xxx_hidden_Running
+	}
+	return false
+}
+
+func (x *ResponseGetRunningTaskLog) SetBody(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Body = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *ResponseGetRunningTaskLog) SetOffset(v int64) {
+	x.xxx_hidden_Offset = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *ResponseGetRunningTaskLog) SetRunning(v bool) {
+	x.xxx_hidden_Running = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *ResponseGetRunningTaskLog) HasBody() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ResponseGetRunningTaskLog) HasOffset() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ResponseGetRunningTaskLog) HasRunning() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ResponseGetRunningTaskLog) ClearBody() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Body = nil
+}
+
+func (x *ResponseGetRunningTaskLog) ClearOffset() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Offset = 0
+}
+
+func (x *ResponseGetRunningTaskLog) ClearRunning() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Running = false
+}
+
+type ResponseGetRunningTaskLog_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	// body is the log after the offset. The size of body is limited, so the rest of the log has to be read by the next request.
+	Body []byte
+	// offset is the byte offset of the end of body.
+	Offset *int64
+	// running is false if the task has finished. The whole log is available from the log file after that.
+	Running *bool
+}
+
+func (b0 ResponseGetRunningTaskLog_builder) Build() *ResponseGetRunningTaskLog {
+	m0 := &ResponseGetRunningTaskLog{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Body != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Body = b.Body
+	}
+	if b.Offset != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Offset = *b.Offset
+	}
+	if b.Running != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Running = *b.Running
+	}
+	return m0
+}
+
+type RequestListArtifacts struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_TaskId      int32                  `protobuf:"varint,1,opt,name=task_id,json=taskId"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RequestListArtifacts) Reset() {
+	*x = RequestListArtifacts{}
+	mi := &file_proto_build_api_api_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestListArtifacts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestListArtifacts) ProtoMessage() {}
+
+func (x *RequestListArtifacts) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_api_api_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RequestListArtifacts) GetTaskId() int32 {
+	if x != nil {
+		return x.xxx_hidden_TaskId
+	}
+	return 0
+}
+
+func (x *RequestListArtifacts) SetTaskId(v int32) {
+	x.xxx_hidden_TaskId = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *RequestListArtifacts) HasTaskId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RequestListArtifacts) ClearTaskId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_TaskId = 0
+}
+
+type RequestListArtifacts_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	TaskId *int32
+}
+
+func (b0 RequestListArtifacts_builder) Build() *RequestListArtifacts {
+	m0 := &RequestListArtifacts{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.TaskId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_TaskId = *b.TaskId
+	}
+	return m0
+}
+
+type ResponseListArtifacts struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Artifacts *[]*model.Artifact     `protobuf:"bytes,1,rep,name=artifacts"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ResponseListArtifacts) Reset() {
+	*x = ResponseListArtifacts{}
+	mi := &file_proto_build_api_api_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseListArtifacts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseListArtifacts) ProtoMessage() {}
+
+func (x *ResponseListArtifacts) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_api_api_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ResponseListArtifacts) GetArtifacts() []*model.Artifact {
+	if x != nil {
+		if x.xxx_hidden_Artifacts != nil {
+			return *x.xxx_hidden_Artifacts
+		}
+	}
+	return nil
+}
+
+func (x *ResponseListArtifacts) SetArtifacts(v []*model.Artifact) {
+	x.xxx_hidden_Artifacts = &v
+}
+
+type ResponseListArtifacts_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Artifacts []*model.Artifact
+}
+
+func (b0 ResponseListArtifacts_builder) Build() *ResponseListArtifacts {
+	m0 := &ResponseListArtifacts{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Artifacts = &b.Artifacts
+	return m0
+}
+
 var File_proto_build_api_api_proto protoreflect.FileDescriptor
 
 const file_proto_build_api_api_proto_rawDesc = "" +
@@ -2477,7 +3204,30 @@ const file_proto_build_api_api_proto_rawDesc = "" +
 	"\x17RequestListGithubEvents\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\x05R\aeventId\"Q\n" +
 	"\x18ResponseListGithubEvents\x125\n" +
-	"\x06events\x18\x01 \x03(\v2\x1d.mono.build.model.GithubEventR\x06events2\xd3\a\n" +
+	"\x06events\x18\x01 \x03(\v2\x1d.mono.build.model.GithubEventR\x06events\"4\n" +
+	"\x19RequestGetTaskBuildResult\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x05R\x06taskId\"\xda\x01\n" +
+	"\x1aResponseGetTaskBuildResult\x128\n" +
+	"\atargets\x18\x01 \x03(\v2\x1e.mono.build.model.TargetResultR\atargets\x12H\n" +
+	"\x0faction_failures\x18\x02 \x03(\v2\x1f.mono.build.model.ActionFailureR\x0eactionFailures\x128\n" +
+	"\ametrics\x18\x03 \x01(\v2\x1e.mono.build.model.BuildMetricsR\ametrics\"g\n" +
+	"\x15RequestListFlakyTests\x12#\n" +
+	"\rrepository_id\x18\x01 \x01(\x05R\frepositoryId\x12)\n" +
+	"\x10quarantined_only\x18\x02 \x01(\bR\x0fquarantinedOnly\"K\n" +
+	"\x16ResponseListFlakyTests\x121\n" +
+	"\x05tests\x18\x01 \x03(\v2\x1b.mono.build.model.FlakyTestR\x05tests\"K\n" +
+	"\x18RequestGetRunningTaskLog\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x05R\x06taskId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\"a\n" +
+	"\x19ResponseGetRunningTaskLog\x12\x12\n" +
+	"\x04body\x18\x01 \x01(\fR\x04body\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x18\n" +
+	"\arunning\x18\x03 \x01(\bR\arunning\"/\n" +
+	"\x14RequestListArtifacts\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x05R\x06taskId\"Q\n" +
+	"\x15ResponseListArtifacts\x128\n" +
+	"\tartifacts\x18\x01 \x03(\v2\x1a.mono.build.model.ArtifactR\tartifacts2\xe9\n" +
+	"\n" +
 	"\x03API\x12P\n" +
 	"\tListTasks\x12 .mono.build.api.RequestListTasks\x1a!.mono.build.api.ResponseListTasks\x12e\n" +
 	"\x10ListRepositories\x12'.mono.build.api.RequestListRepositories\x1a(.mono.build.api.ResponseListRepositories\x12_\n" +
@@ -2488,9 +3238,13 @@ const file_proto_build_api_api_proto_rawDesc = "" +
 	"\rForceStopTask\x12$.mono.build.api.RequestForceStopTask\x1a%.mono.build.api.ResponseForceStopTask\x12\\\n" +
 	"\rGetServerInfo\x12$.mono.build.api.RequestGetServerInfo\x1a%.mono.build.api.ResponseGetServerInfo\x12\x86\x01\n" +
 	"\x1bListExternalReleaseTriggers\x122.mono.build.api.RequestListExternalReleaseTriggers\x1a3.mono.build.api.ResponseListExternalReleaseTriggers\x12e\n" +
-	"\x10ListGithubEvents\x12'.mono.build.api.RequestListGithubEvents\x1a(.mono.build.api.ResponseListGithubEventsB'Z\x1dgo.f110.dev/mono/go/build/api\x92\x03\x05\xd2>\x02\x10\x03b\beditionsp\xe8\a"
+	"\x10ListGithubEvents\x12'.mono.build.api.RequestListGithubEvents\x1a(.mono.build.api.ResponseListGithubEvents\x12k\n" +
+	"\x12GetTaskBuildResult\x12).mono.build.api.RequestGetTaskBuildResult\x1a*.mono.build.api.ResponseGetTaskBuildResult\x12_\n" +
+	"\x0eListFlakyTests\x12%.mono.build.api.RequestListFlakyTests\x1a&.mono.build.api.ResponseListFlakyTests\x12h\n" +
+	"\x11GetRunningTaskLog\x12(.mono.build.api.RequestGetRunningTaskLog\x1a).mono.build.api.ResponseGetRunningTaskLog\x12\\\n" +
+	"\rListArtifacts\x12$.mono.build.api.RequestListArtifacts\x1a%.mono.build.api.ResponseListArtifactsB'Z\x1dgo.f110.dev/mono/go/build/api\x92\x03\x05\xd2>\x02\x10\x03b\beditionsp\xe8\a"
 
-var file_proto_build_api_api_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_proto_build_api_api_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_proto_build_api_api_proto_goTypes = []any{
 	(*RequestListTasks)(nil),                    // 0: mono.build.api.RequestListTasks
 	(*ResponseListTasks)(nil),                   // 1: mono.build.api.ResponseListTasks
@@ -2513,46 +3267,72 @@ var file_proto_build_api_api_proto_goTypes = []any{
 	(*ResponseListExternalReleaseTriggers)(nil), // 18: mono.build.api.ResponseListExternalReleaseTriggers
 	(*RequestListGithubEvents)(nil),             // 19: mono.build.api.RequestListGithubEvents
 	(*ResponseListGithubEvents)(nil),            // 20: mono.build.api.ResponseListGithubEvents
-	(*model.Task)(nil),                          // 21: mono.build.model.Task
-	(*model.Repository)(nil),                    // 22: mono.build.model.Repository
-	(*model.Job)(nil),                           // 23: mono.build.model.Job
-	(*model.ExternalReleaseTrigger)(nil),        // 24: mono.build.model.ExternalReleaseTrigger
-	(*model.GithubEvent)(nil),                   // 25: mono.build.model.GithubEvent
+	(*RequestGetTaskBuildResult)(nil),           // 21: mono.build.api.RequestGetTaskBuildResult
+	(*ResponseGetTaskBuildResult)(nil),          // 22: mono.build.api.ResponseGetTaskBuildResult
+	(*RequestListFlakyTests)(nil),               // 23: mono.build.api.RequestListFlakyTests
+	(*ResponseListFlakyTests)(nil),              // 24: mono.build.api.ResponseListFlakyTests
+	(*RequestGetRunningTaskLog)(nil),            // 25: mono.build.api.RequestGetRunningTaskLog
+	(*ResponseGetRunningTaskLog)(nil),           // 26: mono.build.api.ResponseGetRunningTaskLog
+	(*RequestListArtifacts)(nil),                // 27: mono.build.api.RequestListArtifacts
+	(*ResponseListArtifacts)(nil),               // 28: mono.build.api.ResponseListArtifacts
+	(*model.Task)(nil),                          // 29: mono.build.model.Task
+	(*model.Repository)(nil),                    // 30: mono.build.model.Repository
+	(*model.Job)(nil),                           // 31: mono.build.model.Job
+	(*model.ExternalReleaseTrigger)(nil),        // 32: mono.build.model.ExternalReleaseTrigger
+	(*model.GithubEvent)(nil),                   // 33: mono.build.model.GithubEvent
+	(*model.TargetResult)(nil),                  // 34: mono.build.model.TargetResult
+	(*model.ActionFailure)(nil),                 // 35: mono.build.model.ActionFailure
+	(*model.BuildMetrics)(nil),                  // 36: mono.build.model.BuildMetrics
+	(*model.FlakyTest)(nil),                     // 37: mono.build.model.FlakyTest
+	(*model.Artifact)(nil),                      // 38: mono.build.model.Artifact
 }
 var file_proto_build_api_api_proto_depIdxs = []int32{
-	21, // 0: mono.build.api.ResponseListTasks.tasks:type_name -> mono.build.model.Task
-	22, // 1: mono.build.api.ResponseListRepositories.repositories:type_name -> mono.build.model.Repository
-	22, // 2: mono.build.api.RequestSaveRepository.repository:type_name -> mono.build.model.Repository
-	22, // 3: mono.build.api.ResponseSaveRepository.repository:type_name -> mono.build.model.Repository
-	23, // 4: mono.build.api.ResponseListJobs.jobs:type_name -> mono.build.model.Job
+	29, // 0: mono.build.api.ResponseListTasks.tasks:type_name -> mono.build.model.Task
+	30, // 1: mono.build.api.ResponseListRepositories.repositories:type_name -> mono.build.model.Repository
+	30, // 2: mono.build.api.RequestSaveRepository.repository:type_name -> mono.build.model.Repository
+	30, // 3: mono.build.api.ResponseSaveRepository.repository:type_name -> mono.build.model.Repository
+	31, // 4: mono.build.api.ResponseListJobs.jobs:type_name -> mono.build.model.Job
 	16, // 5: mono.build.api.ResponseGetServerInfo.config:type_name -> mono.build.api.ServerConfig
-	24, // 6: mono.build.api.ResponseListExternalReleaseTriggers.triggers:type_name -> mono.build.model.ExternalReleaseTrigger
-	25, // 7: mono.build.api.ResponseListGithubEvents.events:type_name -> mono.build.model.GithubEvent
-	0,  // 8: mono.build.api.API.ListTasks:input_type -> mono.build.api.RequestListTasks
-	2,  // 9: mono.build.api.API.ListRepositories:input_type -> mono.build.api.RequestListRepositories
-	4,  // 10: mono.build.api.API.SaveRepository:input_type -> mono.build.api.RequestSaveRepository
-	6,  // 11: mono.build.api.API.DeleteRepository:input_type -> mono.build.api.RequestDeleteRepository
-	8,  // 12: mono.build.api.API.ListJobs:input_type -> mono.build.api.RequestListJobs
-	10, // 13: mono.build.api.API.InvokeJob:input_type -> mono.build.api.RequestInvokeJob
-	12, // 14: mono.build.api.API.ForceStopTask:input_type -> mono.build.api.RequestForceStopTask
-	14, // 15: mono.build.api.API.GetServerInfo:input_type -> mono.build.api.RequestGetServerInfo
-	17, // 16: mono.build.api.API.ListExternalReleaseTriggers:input_type -> mono.build.api.RequestListExternalReleaseTriggers
-	19, // 17: mono.build.api.API.ListGithubEvents:input_type -> mono.build.api.RequestListGithubEvents
-	1,  // 18: mono.build.api.API.ListTasks:output_type -> mono.build.api.ResponseListTasks
-	3,  // 19: mono.build.api.API.ListRepositories:output_type -> mono.build.api.ResponseListRepositories
-	5,  // 20: mono.build.api.API.SaveRepository:output_type -> mono.build.api.ResponseSaveRepository
-	7,  // 21: mono.build.api.API.DeleteRepository:output_type -> mono.build.api.ResponseDeleteRepository
-	9,  // 22: mono.build.api.API.ListJobs:output_type -> mono.build.api.ResponseListJobs
-	11, // 23: mono.build.api.API.InvokeJob:output_type -> mono.build.api.ResponseInvokeJob
-	13, // 24: mono.build.api.API.ForceStopTask:output_type -> mono.build.api.ResponseForceStopTask
-	15, // 25: mono.build.api.API.GetServerInfo:output_type -> mono.build.api.ResponseGetServerInfo
-	18, // 26: mono.build.api.API.ListExternalReleaseTriggers:output_type -> mono.build.api.ResponseListExternalReleaseTriggers
-	20, // 27: mono.build.api.API.ListGithubEvents:output_type -> mono.build.api.ResponseListGithubEvents
-	18, // [18:28] is the sub-list for method output_type
-	8,  // [8:18] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	32, // 6: mono.build.api.ResponseListExternalReleaseTriggers.triggers:type_name -> mono.build.model.ExternalReleaseTrigger
+	33, // 7: mono.build.api.ResponseListGithubEvents.events:type_name -> mono.build.model.GithubEvent
+	34, // 8: mono.build.api.ResponseGetTaskBuildResult.targets:type_name -> mono.build.model.TargetResult
+	35, // 9: mono.build.api.ResponseGetTaskBuildResult.action_failures:type_name -> mono.build.model.ActionFailure
+	36, // 10: mono.build.api.ResponseGetTaskBuildResult.metrics:type_name -> mono.build.model.BuildMetrics
+	37, // 11: mono.build.api.ResponseListFlakyTests.tests:type_name -> mono.build.model.FlakyTest
+	38, // 12: mono.build.api.ResponseListArtifacts.artifacts:type_name -> mono.build.model.Artifact
+	0,  // 13: mono.build.api.API.ListTasks:input_type -> mono.build.api.RequestListTasks
+	2,  // 14: mono.build.api.API.ListRepositories:input_type -> mono.build.api.RequestListRepositories
+	4,  // 15: mono.build.api.API.SaveRepository:input_type -> mono.build.api.RequestSaveRepository
+	6,  // 16: mono.build.api.API.DeleteRepository:input_type -> mono.build.api.RequestDeleteRepository
+	8,  // 17: mono.build.api.API.ListJobs:input_type -> mono.build.api.RequestListJobs
+	10, // 18: mono.build.api.API.InvokeJob:input_type -> mono.build.api.RequestInvokeJob
+	12, // 19: mono.build.api.API.ForceStopTask:input_type -> mono.build.api.RequestForceStopTask
+	14, // 20: mono.build.api.API.GetServerInfo:input_type -> mono.build.api.RequestGetServerInfo
+	17, // 21: mono.build.api.API.ListExternalReleaseTriggers:input_type -> mono.build.api.RequestListExternalReleaseTriggers
+	19, // 22: mono.build.api.API.ListGithubEvents:input_type -> mono.build.api.RequestListGithubEvents
+	21, // 23: mono.build.api.API.GetTaskBuildResult:input_type -> mono.build.api.RequestGetTaskBuildResult
+	23, // 24: mono.build.api.API.ListFlakyTests:input_type -> mono.build.api.RequestListFlakyTests
+	25, // 25: mono.build.api.API.GetRunningTaskLog:input_type -> mono.build.api.RequestGetRunningTaskLog
+	27, // 26: mono.build.api.API.ListArtifacts:input_type -> mono.build.api.RequestListArtifacts
+	1,  // 27: mono.build.api.API.ListTasks:output_type -> mono.build.api.ResponseListTasks
+	3,  // 28: mono.build.api.API.ListRepositories:output_type -> mono.build.api.ResponseListRepositories
+	5,  // 29: mono.build.api.API.SaveRepository:output_type -> mono.build.api.ResponseSaveRepository
+	7,  // 30: mono.build.api.API.DeleteRepository:output_type -> mono.build.api.ResponseDeleteRepository
+	9,  // 31: mono.build.api.API.ListJobs:output_type -> mono.build.api.ResponseListJobs
+	11, // 32: mono.build.api.API.InvokeJob:output_type -> mono.build.api.ResponseInvokeJob
+	13, // 33: mono.build.api.API.ForceStopTask:output_type -> mono.build.api.ResponseForceStopTask
+	15, // 34: mono.build.api.API.GetServerInfo:output_type -> mono.build.api.ResponseGetServerInfo
+	18, // 35: mono.build.api.API.ListExternalReleaseTriggers:output_type -> mono.build.api.ResponseListExternalReleaseTriggers
+	20, // 36: mono.build.api.API.ListGithubEvents:output_type -> mono.build.api.ResponseListGithubEvents
+	22, // 37: mono.build.api.API.GetTaskBuildResult:output_type -> mono.build.api.ResponseGetTaskBuildResult
+	24, // 38: mono.build.api.API.ListFlakyTests:output_type -> mono.build.api.ResponseListFlakyTests
+	26, // 39: mono.build.api.API.GetRunningTaskLog:output_type -> mono.build.api.ResponseGetRunningTaskLog
+	28, // 40: mono.build.api.API.ListArtifacts:output_type -> mono.build.api.ResponseListArtifacts
+	27, // [27:41] is the sub-list for method output_type
+	13, // [13:27] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_proto_build_api_api_proto_init() }
@@ -2566,7 +3346,7 @@ func file_proto_build_api_api_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_build_api_api_proto_rawDesc), len(file_proto_build_api_api_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   21,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -29,6 +29,10 @@ const (
 	API_GetServerInfo_FullMethodName               = "/mono.build.api.API/GetServerInfo"
 	API_ListExternalReleaseTriggers_FullMethodName = "/mono.build.api.API/ListExternalReleaseTriggers"
 	API_ListGithubEvents_FullMethodName            = "/mono.build.api.API/ListGithubEvents"
+	API_GetTaskBuildResult_FullMethodName          = "/mono.build.api.API/GetTaskBuildResult"
+	API_ListFlakyTests_FullMethodName              = "/mono.build.api.API/ListFlakyTests"
+	API_GetRunningTaskLog_FullMethodName           = "/mono.build.api.API/GetRunningTaskLog"
+	API_ListArtifacts_FullMethodName               = "/mono.build.api.API/ListArtifacts"
 )
 
 // APIClient is the client API for API service.
@@ -45,6 +49,10 @@ type APIClient interface {
 	GetServerInfo(ctx context.Context, in *RequestGetServerInfo, opts ...grpc.CallOption) (*ResponseGetServerInfo, error)
 	ListExternalReleaseTriggers(ctx context.Context, in *RequestListExternalReleaseTriggers, opts ...grpc.CallOption) (*ResponseListExternalReleaseTriggers, error)
 	ListGithubEvents(ctx context.Context, in *RequestListGithubEvents, opts ...grpc.CallOption) (*ResponseListGithubEvents, error)
+	GetTaskBuildResult(ctx context.Context, in *RequestGetTaskBuildResult, opts ...grpc.CallOption) (*ResponseGetTaskBuildResult, error)
+	ListFlakyTests(ctx context.Context, in *RequestListFlakyTests, opts ...grpc.CallOption) (*ResponseListFlakyTests, error)
+	GetRunningTaskLog(ctx context.Context, in *RequestGetRunningTaskLog, opts ...grpc.CallOption) (*ResponseGetRunningTaskLog, error)
+	ListArtifacts(ctx context.Context, in *RequestListArtifacts, opts ...grpc.CallOption) (*ResponseListArtifacts, error)
 }
 
 type aPIClient struct {
@@ -155,6 +163,46 @@ func (c *aPIClient) ListGithubEvents(ctx context.Context, in *RequestListGithubE
 	return out, nil
 }
 
+func (c *aPIClient) GetTaskBuildResult(ctx context.Context, in *RequestGetTaskBuildResult, opts ...grpc.CallOption) (*ResponseGetTaskBuildResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseGetTaskBuildResult)
+	err := c.cc.Invoke(ctx, API_GetTaskBuildResult_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListFlakyTests(ctx context.Context, in *RequestListFlakyTests, opts ...grpc.CallOption) (*ResponseListFlakyTests, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseListFlakyTests)
+	err := c.cc.Invoke(ctx, API_ListFlakyTests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetRunningTaskLog(ctx context.Context, in *RequestGetRunningTaskLog, opts ...grpc.CallOption) (*ResponseGetRunningTaskLog, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseGetRunningTaskLog)
+	err := c.cc.Invoke(ctx, API_GetRunningTaskLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) ListArtifacts(ctx context.Context, in *RequestListArtifacts, opts ...grpc.CallOption) (*ResponseListArtifacts, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResponseListArtifacts)
+	err := c.cc.Invoke(ctx, API_ListArtifacts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // APIServer is the server API for API service.
 // All implementations should embed UnimplementedAPIServer
 // for forward compatibility.
@@ -169,6 +217,10 @@ type APIServer interface {
 	GetServerInfo(context.Context, *RequestGetServerInfo) (*ResponseGetServerInfo, error)
 	ListExternalReleaseTriggers(context.Context, *RequestListExternalReleaseTriggers) (*ResponseListExternalReleaseTriggers, error)
 	ListGithubEvents(context.Context, *RequestListGithubEvents) (*ResponseListGithubEvents, error)
+	GetTaskBuildResult(context.Context, *RequestGetTaskBuildResult) (*ResponseGetTaskBuildResult, error)
+	ListFlakyTests(context.Context, *RequestListFlakyTests) (*ResponseListFlakyTests, error)
+	GetRunningTaskLog(context.Context, *RequestGetRunningTaskLog) (*ResponseGetRunningTaskLog, error)
+	ListArtifacts(context.Context, *RequestListArtifacts) (*ResponseListArtifacts, error)
 }
 
 // UnimplementedAPIServer should be embedded to have
@@ -208,6 +260,18 @@ func (UnimplementedAPIServer) ListExternalReleaseTriggers(context.Context, *Requ
 func (UnimplementedAPIServer) ListGithubEvents(context.Context, *RequestListGithubEvents) (*ResponseListGithubEvents, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListGithubEvents not implemented")
 }
+func (UnimplementedAPIServer) GetTaskBuildResult(context.Context, *RequestGetTaskBuildResult) (*ResponseGetTaskBuildResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskBuildResult not implemented")
+}
+func (UnimplementedAPIServer) ListFlakyTests(context.Context, *RequestListFlakyTests) (*ResponseListFlakyTests, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFlakyTests not implemented")
+}
+func (UnimplementedAPIServer) GetRunningTaskLog(context.Context, *RequestGetRunningTaskLog) (*ResponseGetRunningTaskLog, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRunningTaskLog not implemented")
+}
+func (UnimplementedAPIServer) ListArtifacts(context.Context, *RequestListArtifacts) (*ResponseListArtifacts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArtifacts not implemented")
+}
 func (UnimplementedAPIServer) testEmbeddedByValue() {}
 
 // UnsafeAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -408,6 +472,78 @@ func _API_ListGithubEvents_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_GetTaskBuildResult_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestGetTaskBuildResult)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetTaskBuildResult(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetTaskBuildResult_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetTaskBuildResult(ctx, req.(*RequestGetTaskBuildResult))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListFlakyTests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestListFlakyTests)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListFlakyTests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ListFlakyTests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListFlakyTests(ctx, req.(*RequestListFlakyTests))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetRunningTaskLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestGetRunningTaskLog)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetRunningTaskLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_GetRunningTaskLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetRunningTaskLog(ctx, req.(*RequestGetRunningTaskLog))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_ListArtifacts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestListArtifacts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).ListArtifacts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: API_ListArtifacts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).ListArtifacts(ctx, req.(*RequestListArtifacts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // API_ServiceDesc is the grpc.ServiceDesc for API service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -455,6 +591,22 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListGithubEvents",
 			Handler:    _API_ListGithubEvents_Handler,
 		},
+		{
+			MethodName: "GetTaskBuildResult",
+			Handler:    _API_GetTaskBuildResult_Handler,
+		},
+		{
+			MethodName: "ListFlakyTests",
+			Handler:    _API_ListFlakyTests_Handler,
+		},
+		{
+			MethodName: "GetRunningTaskLog",
+			Handler:    _API_GetRunningTaskLog_Handler,
+		},
+		{
+			MethodName: "ListArtifacts",
+			Handler:    _API_ListArtifacts_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/build/api/api.proto",
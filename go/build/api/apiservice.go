@@ -217,6 +217,7 @@ func (s *apiService) InvokeJob(ctx context.Context, req *RequestInvokeJob) (*Res
 			jobConfiguration.Platforms,
 			"api",
 			false,
+			config.SourceRef{},
 		)
 		if err != nil {
 			slogger.Log.Warn("Failed build job", slogger.E(err))
@@ -268,6 +269,7 @@ func (s *apiService) InvokeJob(ctx context.Context, req *RequestInvokeJob) (*Res
 		job.Platforms,
 		"manual",
 		false,
+		config.SourceRef{Branch: repo.DefaultBranch},
 	)
 	if err != nil {
 		slogger.Log.Warn("Failed to invoke job", slogger.E(err), slog.String("owner", owner), slog.String("repo", repoName), slog.String("job_name", job.Name))
@@ -306,6 +308,31 @@ func (s *apiService) ForceStopTask(ctx context.Context, req *RequestForceStopTas
 	return ResponseForceStopTask_builder{}.Build(), nil
 }
 
+// maxRunningTaskLogSize is the maximum size of the log which is returned by GetRunningTaskLog at once.
+const maxRunningTaskLogSize = 1024 * 1024
+
+func (s *apiService) GetRunningTaskLog(ctx context.Context, req *RequestGetRunningTaskLog) (*ResponseGetRunningTaskLog, error) {
+	log, running, err := s.builder.GetRunningTaskLog(ctx, req.GetTaskId())
+	if err != nil {
+		slogger.Log.Warn("Failed to get the log of the running task", slogger.E(err), slog.Int("task_id", int(req.GetTaskId())))
+		return nil, status.Error(codes.Internal, "failed to get the log")
+	}
+	if !running {
+		return ResponseGetRunningTaskLog_builder{Offset: new(req.GetOffset()), Running: new(false)}.Build(), nil
+	}
+
+	offset := req.GetOffset()
+	if offset < 0 || offset > int64(len(log)) {
+		offset = int64(len(log))
+	}
+	body := log[offset:min(offset+maxRunningTaskLogSize, int64(len(log)))]
+	return ResponseGetRunningTaskLog_builder{
+		Body:    body,
+		Offset:  new(offset + int64(len(body))),
+		Running: new(running),
+	}.Build(), nil
+}
+
 func (s *apiService) GetServerInfo(ctx context.Context, _ *RequestGetServerInfo) (*ResponseGetServerInfo, error) {
 	objs, err := s.stClient.List(ctx, s.bazelMirrorPrefix)
 	if err != nil {
@@ -399,6 +426,134 @@ func (s *apiService) ListGithubEvents(ctx context.Context, req *RequestListGithu
 	return ResponseListGithubEvents_builder{Events: events}.Build(), nil
 }
 
+func (s *apiService) GetTaskBuildResult(ctx context.Context, req *RequestGetTaskBuildResult) (*ResponseGetTaskBuildResult, error) {
+	targets, err := s.dao.TargetResult.ListByTaskId(ctx, req.GetTaskId())
+	if err != nil {
+		slogger.Log.Warn("Failed to list target_result", slogger.E(err), slog.Int("task_id", int(req.GetTaskId())))
+		return nil, status.Error(codes.Internal, "failed to list target_result")
+	}
+	actionFailures, err := s.dao.ActionFailure.ListByTaskId(ctx, req.GetTaskId())
+	if err != nil {
+		slogger.Log.Warn("Failed to list action_failure", slogger.E(err), slog.Int("task_id", int(req.GetTaskId())))
+		return nil, status.Error(codes.Internal, "failed to list action_failure")
+	}
+	metrics, err := s.dao.BuildMetrics.ListByTaskId(ctx, req.GetTaskId())
+	if err != nil {
+		slogger.Log.Warn("Failed to list build_metrics", slogger.E(err), slog.Int("task_id", int(req.GetTaskId())))
+		return nil, status.Error(codes.Internal, "failed to list build_metrics")
+	}
+
+	res := ResponseGetTaskBuildResult_builder{
+		Targets:        enumerable.Map(targets, dbTargetResultToModel),
+		ActionFailures: enumerable.Map(actionFailures, dbActionFailureToModel),
+	}
+	if len(metrics) > 0 {
+		res.Metrics = dbBuildMetricsToModel(metrics[0])
+	}
+	return res.Build(), nil
+}
+
+func (s *apiService) ListFlakyTests(ctx context.Context, req *RequestListFlakyTests) (*ResponseListFlakyTests, error) {
+	flakyTests, err := s.dao.FlakyTest.ListByRepositoryId(ctx, req.GetRepositoryId())
+	if err != nil {
+		slogger.Log.Warn("Failed to list flaky_test", slogger.E(err), slog.Int("repository_id", int(req.GetRepositoryId())))
+		return nil, status.Error(codes.Internal, "failed to list flaky_test")
+	}
+	if req.GetQuarantinedOnly() {
+		flakyTests = enumerable.FindAll(flakyTests, func(v *database.FlakyTest) bool { return v.Quarantined })
+	}
+	// The flakiest test comes first.
+	sort.SliceStable(flakyTests, func(i, j int) bool {
+		return flakyTests[i].Score > flakyTests[j].Score
+	})
+
+	return ResponseListFlakyTests_builder{
+		Tests: enumerable.Map(flakyTests, dbFlakyTestToModel),
+	}.Build(), nil
+}
+
+func (s *apiService) ListArtifacts(ctx context.Context, req *RequestListArtifacts) (*ResponseListArtifacts, error) {
+	artifacts, err := s.dao.Artifact.ListByTaskId(ctx, req.GetTaskId())
+	if err != nil {
+		slogger.Log.Warn("Failed to list artifact", slogger.E(err), slog.Int("task_id", int(req.GetTaskId())))
+		return nil, status.Error(codes.Internal, "failed to list artifact")
+	}
+
+	return ResponseListArtifacts_builder{
+		Artifacts: enumerable.Map(artifacts, dbArtifactToModel),
+	}.Build(), nil
+}
+
+func dbTargetResultToModel(r *database.TargetResult) *model.TargetResult {
+	return model.TargetResult_builder{
+		Label:           new(r.Label),
+		Success:         new(r.Success),
+		FailureCategory: new(r.FailureCategory),
+		FailureMessage:  new(r.FailureMessage),
+	}.Build()
+}
+
+func dbActionFailureToModel(r *database.ActionFailure) *model.ActionFailure {
+	return model.ActionFailure_builder{
+		Label:           new(r.Label),
+		Mnemonic:        new(r.Mnemonic),
+		ExitCode:        new(r.ExitCode),
+		FailureCategory: new(r.FailureCategory),
+		FailureMessage:  new(r.FailureMessage),
+		PrimaryOutput:   new(r.PrimaryOutput),
+	}.Build()
+}
+
+func dbFlakyTestToModel(r *database.FlakyTest) *model.FlakyTest {
+	return model.FlakyTest_builder{
+		RepositoryId:    new(r.RepositoryId),
+		Label:           new(r.Label),
+		Runs:            new(r.Runs),
+		FlakyRuns:       new(r.FlakyRuns),
+		Score:           new(r.Score),
+		Quarantined:     new(r.Quarantined),
+		LastFlakyTaskId: new(r.LastFlakyTaskId),
+	}.Build()
+}
+
+func dbArtifactToModel(r *database.Artifact) *model.Artifact {
+	return model.Artifact_builder{
+		TaskId:     new(r.TaskId),
+		Label:      new(r.Label),
+		Path:       new(r.Path),
+		ObjectName: new(r.ObjectName),
+		Size:       new(r.Size),
+	}.Build()
+}
+
+// dbBuildMetricsToModel converts the metrics and computes the cache hit ratios.
+// The ratio is 0 if there is no action.
+func dbBuildMetricsToModel(r *database.BuildMetrics) *model.BuildMetrics {
+	var remoteCacheHitRatio, actionCacheHitRatio float64
+	if r.ActionsExecuted > 0 {
+		remoteCacheHitRatio = float64(r.RemoteCacheHits) / float64(r.ActionsExecuted)
+	}
+	if total := r.ActionCacheHits + r.ActionCacheMisses; total > 0 {
+		actionCacheHitRatio = float64(r.ActionCacheHits) / float64(total)
+	}
+
+	return model.BuildMetrics_builder{
+		ExitCodeName:         new(r.ExitCodeName),
+		ActionsCreated:       new(r.ActionsCreated),
+		ActionsExecuted:      new(r.ActionsExecuted),
+		RemoteCacheHits:      new(r.RemoteCacheHits),
+		ActionCacheHits:      new(r.ActionCacheHits),
+		ActionCacheMisses:    new(r.ActionCacheMisses),
+		TargetsConfigured:    new(r.TargetsConfigured),
+		WallTimeMs:           new(r.WallTimeMs),
+		CpuTimeMs:            new(r.CpuTimeMs),
+		AnalysisPhaseTimeMs:  new(r.AnalysisPhaseTimeMs),
+		ExecutionPhaseTimeMs: new(r.ExecutionPhaseTimeMs),
+		RemoteCacheHitRatio:  new(remoteCacheHitRatio),
+		ActionCacheHitRatio:  new(actionCacheHitRatio),
+	}.Build()
+}
+
 // dbGithubEventToModel projects the on-disk row into the wire format the
 // dashboard consumes. The proto enum name (e.g. "PENDING") is more useful
 // than the integer to a human reader, and status is sent as a raw JSON
@@ -480,6 +635,7 @@ func (*apiService) dbTaskToAPITask(task *database.Task) *model.Task {
 		finishedAt = timestamppb.New(*task.FinishedAt)
 	}
 	var cpuLimit, memoryLimit string
+	var needs []string
 	if task.JobConfiguration != nil && len(*task.JobConfiguration) > 0 {
 		jobConf := &config.Job{}
 		if err := config.UnmarshalJob([]byte(*task.JobConfiguration), jobConf); err == nil {
@@ -496,9 +652,16 @@ func (*apiService) dbTaskToAPITask(task *database.Task) *model.Task {
 			if err := config.UnmarshalJobV2(task.ParsedJobConfiguration, j, "", ""); err == nil {
 				cpuLimit = j.CPULimit
 				memoryLimit = j.MemoryLimit
+				needs = j.Needs
 			}
 		}
 	}
+	var matrix map[string]string
+	if task.Matrix != "" {
+		if err := json.Unmarshal([]byte(task.Matrix), &matrix); err != nil {
+			slogger.Log.Warn("Failed to decode the matrix", slogger.E(err), slog.Int("task_id", int(task.Id)))
+		}
+	}
 	return model.Task_builder{
 		Id:                  new(task.Id),
 		RepositoryId:        new(task.RepositoryId),
@@ -524,6 +687,12 @@ func (*apiService) dbTaskToAPITask(task *database.Task) *model.Task {
 		FinishedAt:          finishedAt,
 		CreatedAt:           timestamppb.New(task.CreatedAt),
 		UpdatedAt:           timestamppb.New(task.CreatedAt),
+		Skipped:             new(task.Skipped),
+		Needs:               needs,
+		Attempt:             new(task.Attempt),
+		ParentTaskId:        new(task.ParentTaskId),
+		CancelReason:        new(task.CancelReason),
+		Matrix:              matrix,
 	}.Build()
 }
 
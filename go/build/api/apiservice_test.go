@@ -1,8 +1,12 @@
 package api
 
 import (
+	"context"
 	"testing"
 
+	"go.f110.dev/mono/go/build/config"
+	"go.f110.dev/mono/go/build/database"
+	"go.f110.dev/mono/go/build/database/dao"
 	"go.f110.dev/mono/go/testing/assertion"
 )
 
@@ -46,3 +50,61 @@ func TestExtractRepositoryFromPayload(t *testing.T) {
 		})
 	}
 }
+
+func TestDBBuildMetricsToModel(t *testing.T) {
+	m := dbBuildMetricsToModel(&database.BuildMetrics{
+		ActionsExecuted:   10,
+		RemoteCacheHits:   4,
+		ActionCacheHits:   3,
+		ActionCacheMisses: 1,
+	})
+	assertion.Equal(t, m.GetRemoteCacheHitRatio(), 0.4)
+	assertion.Equal(t, m.GetActionCacheHitRatio(), 0.75)
+
+	m = dbBuildMetricsToModel(&database.BuildMetrics{})
+	assertion.Equal(t, m.GetRemoteCacheHitRatio(), 0.0)
+	assertion.Equal(t, m.GetActionCacheHitRatio(), 0.0)
+}
+
+type runningLogBuilder struct {
+	log     []byte
+	running bool
+}
+
+var _ Builder = (*runningLogBuilder)(nil)
+
+func (b *runningLogBuilder) Build(_ context.Context, _ *database.SourceRepository, _ *config.JobV2, _, _, _ string, _, _ []string, _ string, _ bool, _ config.SourceRef) ([]*database.Task, error) {
+	return nil, nil
+}
+
+func (b *runningLogBuilder) ForceStop(_ context.Context, _ int32) error {
+	return nil
+}
+
+func (b *runningLogBuilder) GetRunningTaskLog(_ context.Context, _ int32) ([]byte, bool, error) {
+	return b.log, b.running, nil
+}
+
+func TestGetRunningTaskLog(t *testing.T) {
+	builder := &runningLogBuilder{log: []byte("----- pre-process -----\nCloned\n"), running: true}
+	s := newAPIService(builder, dao.Options{}, nil, nil, nil, "", nil, nil)
+
+	res, err := s.GetRunningTaskLog(context.Background(), RequestGetRunningTaskLog_builder{TaskId: new(int32(1))}.Build())
+	assertion.MustNoError(t, err)
+	assertion.Equal(t, string(res.GetBody()), "----- pre-process -----\nCloned\n")
+	assertion.Equal(t, res.GetOffset(), int64(31))
+	assertion.Equal(t, res.GetRunning(), true)
+
+	builder.log = append(builder.log, []byte("\n----- main -----\n")...)
+	res, err = s.GetRunningTaskLog(context.Background(), RequestGetRunningTaskLog_builder{TaskId: new(int32(1)), Offset: new(res.GetOffset())}.Build())
+	assertion.MustNoError(t, err)
+	assertion.Equal(t, string(res.GetBody()), "\n----- main -----\n")
+	assertion.Equal(t, res.GetOffset(), int64(49))
+
+	builder.log, builder.running = nil, false
+	res, err = s.GetRunningTaskLog(context.Background(), RequestGetRunningTaskLog_builder{TaskId: new(int32(1)), Offset: new(res.GetOffset())}.Build())
+	assertion.MustNoError(t, err)
+	assertion.Len(t, res.GetBody(), 0)
+	assertion.Equal(t, res.GetOffset(), int64(49))
+	assertion.Equal(t, res.GetRunning(), false)
+}
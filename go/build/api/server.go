@@ -34,8 +34,9 @@ const (
 // The webhook ingestion path no longer depends on this — that flow lives in
 // the eventbus package.
 type Builder interface {
-	Build(ctx context.Context, repo *database.SourceRepository, job *config.JobV2, revision, bazelVersion, command string, targets, platforms []string, via string, isMainBranch bool) ([]*database.Task, error)
+	Build(ctx context.Context, repo *database.SourceRepository, job *config.JobV2, revision, bazelVersion, command string, targets, platforms []string, via string, isMainBranch bool, ref config.SourceRef) ([]*database.Task, error)
 	ForceStop(ctx context.Context, taskId int32) error
+	GetRunningTaskLog(ctx context.Context, taskId int32) (log []byte, running bool, err error)
 }
 
 type Api struct {
@@ -57,6 +57,16 @@ const (
 	BFFListExternalReleaseTriggersProcedure = "/mono.build.bff.BFF/ListExternalReleaseTriggers"
 	// BFFListGithubEventsProcedure is the fully-qualified name of the BFF's ListGithubEvents RPC.
 	BFFListGithubEventsProcedure = "/mono.build.bff.BFF/ListGithubEvents"
+	// BFFGetTaskBuildResultProcedure is the fully-qualified name of the BFF's GetTaskBuildResult RPC.
+	BFFGetTaskBuildResultProcedure = "/mono.build.bff.BFF/GetTaskBuildResult"
+	// BFFListFlakyTestsProcedure is the fully-qualified name of the BFF's ListFlakyTests RPC.
+	BFFListFlakyTestsProcedure = "/mono.build.bff.BFF/ListFlakyTests"
+	// BFFTailLogsProcedure is the fully-qualified name of the BFF's TailLogs RPC.
+	BFFTailLogsProcedure = "/mono.build.bff.BFF/TailLogs"
+	// BFFListArtifactsProcedure is the fully-qualified name of the BFF's ListArtifacts RPC.
+	BFFListArtifactsProcedure = "/mono.build.bff.BFF/ListArtifacts"
+	// BFFDownloadArtifactProcedure is the fully-qualified name of the BFF's DownloadArtifact RPC.
+	BFFDownloadArtifactProcedure = "/mono.build.bff.BFF/DownloadArtifact"
 	// BFFListGitDataProcedure is the fully-qualified name of the BFF's ListGitData RPC.
 	BFFListGitDataProcedure = "/mono.build.bff.BFF/ListGitData"
 	// BFFGetGitDataStatisticsProcedure is the fully-qualified name of the BFF's GetGitDataStatistics
@@ -78,6 +88,11 @@ type BFFClient interface {
 	ForceStopTask(context.Context, *connect.Request[RequestForceStopTask]) (*connect.Response[ResponseForceStopTask], error)
 	ListExternalReleaseTriggers(context.Context, *connect.Request[RequestListExternalReleaseTriggers]) (*connect.Response[ResponseListExternalReleaseTriggers], error)
 	ListGithubEvents(context.Context, *connect.Request[RequestListGithubEvents]) (*connect.Response[ResponseListGithubEvents], error)
+	GetTaskBuildResult(context.Context, *connect.Request[RequestGetTaskBuildResult]) (*connect.Response[ResponseGetTaskBuildResult], error)
+	ListFlakyTests(context.Context, *connect.Request[RequestListFlakyTests]) (*connect.Response[ResponseListFlakyTests], error)
+	TailLogs(context.Context, *connect.Request[RequestTailLogs]) (*connect.ServerStreamForClient[ResponseTailLogs], error)
+	ListArtifacts(context.Context, *connect.Request[RequestListArtifacts]) (*connect.Response[ResponseListArtifacts], error)
+	DownloadArtifact(context.Context, *connect.Request[RequestDownloadArtifact]) (*connect.ServerStreamForClient[ResponseDownloadArtifact], error)
 	ListGitData(context.Context, *connect.Request[RequestListGitData]) (*connect.Response[ResponseListGitData], error)
 	GetGitDataStatistics(context.Context, *connect.Request[RequestGetGitDataStatistics]) (*connect.Response[ResponseGetGitDataStatistics], error)
 }
@@ -165,6 +180,36 @@ func NewBFFClient(httpClient connect.HTTPClient, baseURL string, opts ...connect
 			connect.WithSchema(bFFMethods.ByName("ListGithubEvents")),
 			connect.WithClientOptions(opts...),
 		),
+		getTaskBuildResult: connect.NewClient[RequestGetTaskBuildResult, ResponseGetTaskBuildResult](
+			httpClient,
+			baseURL+BFFGetTaskBuildResultProcedure,
+			connect.WithSchema(bFFMethods.ByName("GetTaskBuildResult")),
+			connect.WithClientOptions(opts...),
+		),
+		listFlakyTests: connect.NewClient[RequestListFlakyTests, ResponseListFlakyTests](
+			httpClient,
+			baseURL+BFFListFlakyTestsProcedure,
+			connect.WithSchema(bFFMethods.ByName("ListFlakyTests")),
+			connect.WithClientOptions(opts...),
+		),
+		tailLogs: connect.NewClient[RequestTailLogs, ResponseTailLogs](
+			httpClient,
+			baseURL+BFFTailLogsProcedure,
+			connect.WithSchema(bFFMethods.ByName("TailLogs")),
+			connect.WithClientOptions(opts...),
+		),
+		listArtifacts: connect.NewClient[RequestListArtifacts, ResponseListArtifacts](
+			httpClient,
+			baseURL+BFFListArtifactsProcedure,
+			connect.WithSchema(bFFMethods.ByName("ListArtifacts")),
+			connect.WithClientOptions(opts...),
+		),
+		downloadArtifact: connect.NewClient[RequestDownloadArtifact, ResponseDownloadArtifact](
+			httpClient,
+			baseURL+BFFDownloadArtifactProcedure,
+			connect.WithSchema(bFFMethods.ByName("DownloadArtifact")),
+			connect.WithClientOptions(opts...),
+		),
 		listGitData: connect.NewClient[RequestListGitData, ResponseListGitData](
 			httpClient,
 			baseURL+BFFListGitDataProcedure,
@@ -194,6 +239,11 @@ type bFFClient struct {
 	forceStopTask               *connect.Client[RequestForceStopTask, ResponseForceStopTask]
 	listExternalReleaseTriggers *connect.Client[RequestListExternalReleaseTriggers, ResponseListExternalReleaseTriggers]
 	listGithubEvents            *connect.Client[RequestListGithubEvents, ResponseListGithubEvents]
+	getTaskBuildResult          *connect.Client[RequestGetTaskBuildResult, ResponseGetTaskBuildResult]
+	listFlakyTests              *connect.Client[RequestListFlakyTests, ResponseListFlakyTests]
+	tailLogs                    *connect.Client[RequestTailLogs, ResponseTailLogs]
+	listArtifacts               *connect.Client[RequestListArtifacts, ResponseListArtifacts]
+	downloadArtifact            *connect.Client[RequestDownloadArtifact, ResponseDownloadArtifact]
 	listGitData                 *connect.Client[RequestListGitData, ResponseListGitData]
 	getGitDataStatistics        *connect.Client[RequestGetGitDataStatistics, ResponseGetGitDataStatistics]
 }
@@ -258,6 +308,31 @@ func (c *bFFClient) ListGithubEvents(ctx context.Context, req *connect.Request[R
 	return c.listGithubEvents.CallUnary(ctx, req)
 }
 
+// GetTaskBuildResult calls mono.build.bff.BFF.GetTaskBuildResult.
+func (c *bFFClient) GetTaskBuildResult(ctx context.Context, req *connect.Request[RequestGetTaskBuildResult]) (*connect.Response[ResponseGetTaskBuildResult], error) {
+	return c.getTaskBuildResult.CallUnary(ctx, req)
+}
+
+// ListFlakyTests calls mono.build.bff.BFF.ListFlakyTests.
+func (c *bFFClient) ListFlakyTests(ctx context.Context, req *connect.Request[RequestListFlakyTests]) (*connect.Response[ResponseListFlakyTests], error) {
+	return c.listFlakyTests.CallUnary(ctx, req)
+}
+
+// TailLogs calls mono.build.bff.BFF.TailLogs.
+func (c *bFFClient) TailLogs(ctx context.Context, req *connect.Request[RequestTailLogs]) (*connect.ServerStreamForClient[ResponseTailLogs], error) {
+	return c.tailLogs.CallServerStream(ctx, req)
+}
+
+// ListArtifacts calls mono.build.bff.BFF.ListArtifacts.
+func (c *bFFClient) ListArtifacts(ctx context.Context, req *connect.Request[RequestListArtifacts]) (*connect.Response[ResponseListArtifacts], error) {
+	return c.listArtifacts.CallUnary(ctx, req)
+}
+
+// DownloadArtifact calls mono.build.bff.BFF.DownloadArtifact.
+func (c *bFFClient) DownloadArtifact(ctx context.Context, req *connect.Request[RequestDownloadArtifact]) (*connect.ServerStreamForClient[ResponseDownloadArtifact], error) {
+	return c.downloadArtifact.CallServerStream(ctx, req)
+}
+
 // ListGitData calls mono.build.bff.BFF.ListGitData.
 func (c *bFFClient) ListGitData(ctx context.Context, req *connect.Request[RequestListGitData]) (*connect.Response[ResponseListGitData], error) {
 	return c.listGitData.CallUnary(ctx, req)
@@ -282,6 +357,11 @@ type BFFHandler interface {
 	ForceStopTask(context.Context, *connect.Request[RequestForceStopTask]) (*connect.Response[ResponseForceStopTask], error)
 	ListExternalReleaseTriggers(context.Context, *connect.Request[RequestListExternalReleaseTriggers]) (*connect.Response[ResponseListExternalReleaseTriggers], error)
 	ListGithubEvents(context.Context, *connect.Request[RequestListGithubEvents]) (*connect.Response[ResponseListGithubEvents], error)
+	GetTaskBuildResult(context.Context, *connect.Request[RequestGetTaskBuildResult]) (*connect.Response[ResponseGetTaskBuildResult], error)
+	ListFlakyTests(context.Context, *connect.Request[RequestListFlakyTests]) (*connect.Response[ResponseListFlakyTests], error)
+	TailLogs(context.Context, *connect.Request[RequestTailLogs], *connect.ServerStream[ResponseTailLogs]) error
+	ListArtifacts(context.Context, *connect.Request[RequestListArtifacts]) (*connect.Response[ResponseListArtifacts], error)
+	DownloadArtifact(context.Context, *connect.Request[RequestDownloadArtifact], *connect.ServerStream[ResponseDownloadArtifact]) error
 	ListGitData(context.Context, *connect.Request[RequestListGitData]) (*connect.Response[ResponseListGitData], error)
 	GetGitDataStatistics(context.Context, *connect.Request[RequestGetGitDataStatistics]) (*connect.Response[ResponseGetGitDataStatistics], error)
 }
@@ -365,6 +445,36 @@ func NewBFFHandler(svc BFFHandler, opts ...connect.HandlerOption) (string, http.
 		connect.WithSchema(bFFMethods.ByName("ListGithubEvents")),
 		connect.WithHandlerOptions(opts...),
 	)
+	bFFGetTaskBuildResultHandler := connect.NewUnaryHandler(
+		BFFGetTaskBuildResultProcedure,
+		svc.GetTaskBuildResult,
+		connect.WithSchema(bFFMethods.ByName("GetTaskBuildResult")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bFFListFlakyTestsHandler := connect.NewUnaryHandler(
+		BFFListFlakyTestsProcedure,
+		svc.ListFlakyTests,
+		connect.WithSchema(bFFMethods.ByName("ListFlakyTests")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bFFTailLogsHandler := connect.NewServerStreamHandler(
+		BFFTailLogsProcedure,
+		svc.TailLogs,
+		connect.WithSchema(bFFMethods.ByName("TailLogs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bFFListArtifactsHandler := connect.NewUnaryHandler(
+		BFFListArtifactsProcedure,
+		svc.ListArtifacts,
+		connect.WithSchema(bFFMethods.ByName("ListArtifacts")),
+		connect.WithHandlerOptions(opts...),
+	)
+	bFFDownloadArtifactHandler := connect.NewServerStreamHandler(
+		BFFDownloadArtifactProcedure,
+		svc.DownloadArtifact,
+		connect.WithSchema(bFFMethods.ByName("DownloadArtifact")),
+		connect.WithHandlerOptions(opts...),
+	)
 	bFFListGitDataHandler := connect.NewUnaryHandler(
 		BFFListGitDataProcedure,
 		svc.ListGitData,
@@ -403,6 +513,16 @@ func NewBFFHandler(svc BFFHandler, opts ...connect.HandlerOption) (string, http.
 			bFFListExternalReleaseTriggersHandler.ServeHTTP(w, r)
 		case BFFListGithubEventsProcedure:
 			bFFListGithubEventsHandler.ServeHTTP(w, r)
+		case BFFGetTaskBuildResultProcedure:
+			bFFGetTaskBuildResultHandler.ServeHTTP(w, r)
+		case BFFListFlakyTestsProcedure:
+			bFFListFlakyTestsHandler.ServeHTTP(w, r)
+		case BFFTailLogsProcedure:
+			bFFTailLogsHandler.ServeHTTP(w, r)
+		case BFFListArtifactsProcedure:
+			bFFListArtifactsHandler.ServeHTTP(w, r)
+		case BFFDownloadArtifactProcedure:
+			bFFDownloadArtifactHandler.ServeHTTP(w, r)
 		case BFFListGitDataProcedure:
 			bFFListGitDataHandler.ServeHTTP(w, r)
 		case BFFGetGitDataStatisticsProcedure:
@@ -464,6 +584,26 @@ func (UnimplementedBFFHandler) ListGithubEvents(context.Context, *connect.Reques
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mono.build.bff.BFF.ListGithubEvents is not implemented"))
 }
 
+func (UnimplementedBFFHandler) GetTaskBuildResult(context.Context, *connect.Request[RequestGetTaskBuildResult]) (*connect.Response[ResponseGetTaskBuildResult], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mono.build.bff.BFF.GetTaskBuildResult is not implemented"))
+}
+
+func (UnimplementedBFFHandler) ListFlakyTests(context.Context, *connect.Request[RequestListFlakyTests]) (*connect.Response[ResponseListFlakyTests], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mono.build.bff.BFF.ListFlakyTests is not implemented"))
+}
+
+func (UnimplementedBFFHandler) TailLogs(context.Context, *connect.Request[RequestTailLogs], *connect.ServerStream[ResponseTailLogs]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("mono.build.bff.BFF.TailLogs is not implemented"))
+}
+
+func (UnimplementedBFFHandler) ListArtifacts(context.Context, *connect.Request[RequestListArtifacts]) (*connect.Response[ResponseListArtifacts], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mono.build.bff.BFF.ListArtifacts is not implemented"))
+}
+
+func (UnimplementedBFFHandler) DownloadArtifact(context.Context, *connect.Request[RequestDownloadArtifact], *connect.ServerStream[ResponseDownloadArtifact]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("mono.build.bff.BFF.DownloadArtifact is not implemented"))
+}
+
 func (UnimplementedBFFHandler) ListGitData(context.Context, *connect.Request[RequestListGitData]) (*connect.Response[ResponseListGitData], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("mono.build.bff.BFF.ListGitData is not implemented"))
 }
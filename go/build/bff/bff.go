@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/rs/cors"
@@ -30,7 +31,7 @@ import (
 )
 
 type Builder interface {
-	Build(ctx context.Context, repo *database.SourceRepository, job *config.JobV2, revision, bazelVersion, command string, targets, platforms []string, via string, isMainBranch bool) ([]*database.Task, error)
+	Build(ctx context.Context, repo *database.SourceRepository, job *config.JobV2, revision, bazelVersion, command string, targets, platforms []string, via string, isMainBranch bool, ref config.SourceRef) ([]*database.Task, error)
 }
 
 type BFF struct {
@@ -191,6 +192,12 @@ func (*BFF) apiTaskToBFFTask(repositories map[int32]*model.Repository) func(*mod
 			MemoryLimit:            new(v.GetMemoryLimit()),
 			TestReports:            v.GetTestReports(),
 			Duration:               durationpb.New(v.GetFinishedAt().AsTime().Sub(v.GetStartAt().AsTime())),
+			Skipped:                new(v.GetSkipped()),
+			Needs:                  v.GetNeeds(),
+			Attempt:                new(v.GetAttempt()),
+			ParentTaskId:           new(v.GetParentTaskId()),
+			CancelReason:           new(v.GetCancelReason()),
+			Matrix:                 v.GetMatrix(),
 		}.Build()
 	}
 }
@@ -215,6 +222,85 @@ func (b *BFF) GetLogs(ctx context.Context, req *connect.Request[RequestGetLogs])
 	return connect.NewResponse(ResponseGetLogs_builder{Body: new(string(buf))}.Build()), nil
 }
 
+const (
+	// tailLogsInterval is the interval of polling the log of the running task.
+	tailLogsInterval = 2 * time.Second
+	// tailLogsChunkSize is the size of the log file which is sent by a message.
+	tailLogsChunkSize = 64 * 1024
+)
+
+// TailLogs streams the log of the task from the offset.
+// While the task is running, the log is read from the pod through the API. After the task finished,
+// the rest of the log is read from the log file. Both logs have the same format, so the offset is
+// valid across them and the client can resume the stream from the offset of the last message.
+func (b *BFF) TailLogs(ctx context.Context, req *connect.Request[RequestTailLogs], stream *connect.ServerStream[ResponseTailLogs]) error {
+	offset := req.Msg.GetOffset()
+	for {
+		tasks, err := b.apiClient.ListTasks(ctx, api.RequestListTasks_builder{Ids: []int32{req.Msg.GetTaskId()}}.Build())
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		if len(tasks.GetTasks()) != 1 {
+			return connect.NewError(connect.CodeInvalidArgument, xerrors.New("invalid task id"))
+		}
+		task := tasks.GetTasks()[0]
+		if task.HasFinishedAt() {
+			if task.GetLogFile() == "" {
+				// The task was stopped before the pod was created.
+				return stream.Send(ResponseTailLogs_builder{Offset: new(offset), Finished: new(true)}.Build())
+			}
+			return b.sendLogFile(ctx, stream, task.GetLogFile(), offset)
+		}
+
+		res, err := b.apiClient.GetRunningTaskLog(ctx, api.RequestGetRunningTaskLog_builder{TaskId: new(task.GetId()), Offset: new(offset)}.Build())
+		if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+		if len(res.GetBody()) > 0 {
+			if err := stream.Send(ResponseTailLogs_builder{Body: res.GetBody(), Offset: new(offset)}.Build()); err != nil {
+				return err
+			}
+			offset = res.GetOffset()
+			continue
+		}
+
+		// Wait for the new log. If the task has just finished, wait for the log file to be uploaded.
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(tailLogsInterval):
+		}
+	}
+}
+
+func (b *BFF) sendLogFile(ctx context.Context, stream *connect.ServerStream[ResponseTailLogs], name string, offset int64) error {
+	logObj, err := b.s3.Get(ctx, name)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	defer logObj.Body.Close()
+	if _, err := io.CopyN(io.Discard, logObj.Body, offset); err != nil && err != io.EOF {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	buf := make([]byte, tailLogsChunkSize)
+	for {
+		n, err := io.ReadFull(logObj.Body, buf)
+		if n > 0 {
+			if err := stream.Send(ResponseTailLogs_builder{Body: buf[:n], Offset: new(offset)}.Build()); err != nil {
+				return err
+			}
+			offset += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		} else if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	return stream.Send(ResponseTailLogs_builder{Offset: new(offset), Finished: new(true)}.Build())
+}
+
 func (b *BFF) GetServerInfo(ctx context.Context, _ *connect.Request[RequestGetServerInfo]) (*connect.Response[ResponseGetServerInfo], error) {
 	res, err := b.apiClient.GetServerInfo(ctx, api.RequestGetServerInfo_builder{}.Build())
 	if err != nil {
@@ -350,6 +436,82 @@ func (b *BFF) ListGithubEvents(ctx context.Context, req *connect.Request[Request
 	return connect.NewResponse(ResponseListGithubEvents_builder{Events: events}.Build()), nil
 }
 
+func (b *BFF) GetTaskBuildResult(ctx context.Context, req *connect.Request[RequestGetTaskBuildResult]) (*connect.Response[ResponseGetTaskBuildResult], error) {
+	res, err := b.apiClient.GetTaskBuildResult(ctx, api.RequestGetTaskBuildResult_builder{TaskId: new(req.Msg.GetTaskId())}.Build())
+	if err != nil {
+		slogger.Log.Warn("Failed to get the build result", slogger.E(err), slog.Int("task_id", int(req.Msg.GetTaskId())))
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(ResponseGetTaskBuildResult_builder{
+		Targets:        res.GetTargets(),
+		ActionFailures: res.GetActionFailures(),
+		Metrics:        res.GetMetrics(),
+	}.Build()), nil
+}
+
+func (b *BFF) ListFlakyTests(ctx context.Context, req *connect.Request[RequestListFlakyTests]) (*connect.Response[ResponseListFlakyTests], error) {
+	res, err := b.apiClient.ListFlakyTests(ctx, api.RequestListFlakyTests_builder{
+		RepositoryId:    new(req.Msg.GetRepositoryId()),
+		QuarantinedOnly: new(req.Msg.GetQuarantinedOnly()),
+	}.Build())
+	if err != nil {
+		slogger.Log.Warn("Failed to list flaky tests", slogger.E(err), slog.Int("repository_id", int(req.Msg.GetRepositoryId())))
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(ResponseListFlakyTests_builder{Tests: res.GetTests()}.Build()), nil
+}
+
+func (b *BFF) ListArtifacts(ctx context.Context, req *connect.Request[RequestListArtifacts]) (*connect.Response[ResponseListArtifacts], error) {
+	res, err := b.apiClient.ListArtifacts(ctx, api.RequestListArtifacts_builder{TaskId: new(req.Msg.GetTaskId())}.Build())
+	if err != nil {
+		slogger.Log.Warn("Failed to list artifacts", slogger.E(err), slog.Int("task_id", int(req.Msg.GetTaskId())))
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(ResponseListArtifacts_builder{Artifacts: res.GetArtifacts()}.Build()), nil
+}
+
+// artifactChunkSize is the size of the artifact which is sent by a message.
+const artifactChunkSize = 1024 * 1024
+
+// DownloadArtifact streams the artifact of the task from the object storage.
+func (b *BFF) DownloadArtifact(ctx context.Context, req *connect.Request[RequestDownloadArtifact], stream *connect.ServerStream[ResponseDownloadArtifact]) error {
+	res, err := b.apiClient.ListArtifacts(ctx, api.RequestListArtifacts_builder{TaskId: new(req.Msg.GetTaskId())}.Build())
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	var artifact *model.Artifact
+	for _, v := range res.GetArtifacts() {
+		if v.GetPath() == req.Msg.GetPath() {
+			artifact = v
+			break
+		}
+	}
+	if artifact == nil {
+		return connect.NewError(connect.CodeNotFound, xerrors.New("artifact is not found"))
+	}
+
+	obj, err := b.s3.Get(ctx, artifact.GetObjectName())
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	defer obj.Body.Close()
+
+	buf := make([]byte, artifactChunkSize)
+	for {
+		n, err := io.ReadFull(obj.Body, buf)
+		if n > 0 {
+			if err := stream.Send(ResponseDownloadArtifact_builder{Body: buf[:n]}.Build()); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		} else if err != nil {
+			return connect.NewError(connect.CodeInternal, err)
+		}
+	}
+}
+
 // jsonStatusToYAML converts the reconciler's status JSON to a YAML document
 // for the dashboard, which renders the field as preformatted text. Returns
 // the empty string when the input is empty or not parseable as JSON; the
@@ -701,6 +701,9 @@ func (b0 ResponseGetServerInfo_builder) Build() *ResponseGetServerInfo {
 	return m0
 }
 
+// ServerConfig is a curated, human-meaningful view of the builder's runtime
+// configuration shown on the info page. It intentionally omits secrets
+// (credentials, tokens, DSN). Durations are pre-formatted strings.
 type ServerConfig struct {
 	state                                  protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Dev                         bool                   `protobuf:"varint,1,opt,name=dev"`
@@ -2365,6 +2368,918 @@ func (b0 ResponseListGithubEvents_builder) Build() *ResponseListGithubEvents {
 	return m0
 }
 
+type RequestGetTaskBuildResult struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_TaskId      int32                  `protobuf:"varint,1,opt,name=task_id,json=taskId"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RequestGetTaskBuildResult) Reset() {
+	*x = RequestGetTaskBuildResult{}
+	mi := &file_proto_build_bff_bff_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestGetTaskBuildResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestGetTaskBuildResult) ProtoMessage() {}
+
+func (x *RequestGetTaskBuildResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_bff_bff_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RequestGetTaskBuildResult) GetTaskId() int32 {
+	if x != nil {
+		return x.xxx_hidden_TaskId
+	}
+	return 0
+}
+
+func (x *RequestGetTaskBuildResult) SetTaskId(v int32) {
+	x.xxx_hidden_TaskId = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *RequestGetTaskBuildResult) HasTaskId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RequestGetTaskBuildResult) ClearTaskId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_TaskId = 0
+}
+
+type RequestGetTaskBuildResult_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	TaskId *int32
+}
+
+func (b0 RequestGetTaskBuildResult_builder) Build() *RequestGetTaskBuildResult {
+	m0 := &RequestGetTaskBuildResult{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.TaskId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_TaskId = *b.TaskId
+	}
+	return m0
+}
+
+type ResponseGetTaskBuildResult struct {
+	state                     protoimpl.MessageState  `protogen:"opaque.v1"`
+	xxx_hidden_Targets        *[]*model.TargetResult  `protobuf:"bytes,1,rep,name=targets"`
+	xxx_hidden_ActionFailures *[]*model.ActionFailure `protobuf:"bytes,2,rep,name=action_failures,json=actionFailures"`
+	xxx_hidden_Metrics        *model.BuildMetrics     `protobuf:"bytes,3,opt,name=metrics"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *ResponseGetTaskBuildResult) Reset() {
+	*x = ResponseGetTaskBuildResult{}
+	mi := &file_proto_build_bff_bff_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseGetTaskBuildResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseGetTaskBuildResult) ProtoMessage() {}
+
+func (x *ResponseGetTaskBuildResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_bff_bff_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ResponseGetTaskBuildResult) GetTargets() []*model.TargetResult {
+	if x != nil {
+		if x.xxx_hidden_Targets != nil {
+			return *x.xxx_hidden_Targets
+		}
+	}
+	return nil
+}
+
+func (x *ResponseGetTaskBuildResult) GetActionFailures() []*model.ActionFailure {
+	if x != nil {
+		if x.xxx_hidden_ActionFailures != nil {
+			return *x.xxx_hidden_ActionFailures
+		}
+	}
+	return nil
+}
+
+func (x *ResponseGetTaskBuildResult) GetMetrics() *model.BuildMetrics {
+	if x != nil {
+		return x.xxx_hidden_Metrics
+	}
+	return nil
+}
+
+func (x *ResponseGetTaskBuildResult) SetTargets(v []*model.TargetResult) {
+	x.xxx_hidden_Targets = &v
+}
+
+func (x *ResponseGetTaskBuildResult) SetActionFailures(v []*model.ActionFailure) {
+	x.xxx_hidden_ActionFailures = &v
+}
+
+func (x *ResponseGetTaskBuildResult) SetMetrics(v *model.BuildMetrics) {
+	x.xxx_hidden_Metrics = v
+}
+
+func (x *ResponseGetTaskBuildResult) HasMetrics() bool {
+	if x == nil {
+		return false
+	}
+	return x.xxx_hidden_Metrics != nil
+}
+
+func (x *ResponseGetTaskBuildResult) ClearMetrics() {
+	x.xxx_hidden_Metrics = nil
+}
+
+type ResponseGetTaskBuildResult_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Targets        []*model.TargetResult
+	ActionFailures []*model.ActionFailure
+	// metrics is not set if the task has not reported the metrics.
+	Metrics *model.BuildMetrics
+}
+
+func (b0 ResponseGetTaskBuildResult_builder) Build() *ResponseGetTaskBuildResult {
+	m0 := &ResponseGetTaskBuildResult{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Targets = &b.Targets
+	x.xxx_hidden_ActionFailures = &b.ActionFailures
+	x.xxx_hidden_Metrics = b.Metrics
+	return m0
+}
+
+type RequestListFlakyTests struct {
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_RepositoryId    int32                  `protobuf:"varint,1,opt,name=repository_id,json=repositoryId"`
+	xxx_hidden_QuarantinedOnly bool                   `protobuf:"varint,2,opt,name=quarantined_only,json=quarantinedOnly"`
+	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
+	XXX_presence               [1]uint32
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *RequestListFlakyTests) Reset() {
+	*x = RequestListFlakyTests{}
+	mi := &file_proto_build_bff_bff_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestListFlakyTests) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestListFlakyTests) ProtoMessage() {}
+
+func (x *RequestListFlakyTests) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_bff_bff_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RequestListFlakyTests) GetRepositoryId() int32 {
+	if x != nil {
+		return x.xxx_hidden_RepositoryId
+	}
+	return 0
+}
+
+func (x *RequestListFlakyTests) GetQuarantinedOnly() bool {
+	if x != nil {
+		return x.xxx_hidden_QuarantinedOnly
+	}
+	return false
+}
+
+func (x *RequestListFlakyTests) SetRepositoryId(v int32) {
+	x.xxx_hidden_RepositoryId = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *RequestListFlakyTests) SetQuarantinedOnly(v bool) {
+	x.xxx_hidden_QuarantinedOnly = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *RequestListFlakyTests) HasRepositoryId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RequestListFlakyTests) HasQuarantinedOnly() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RequestListFlakyTests) ClearRepositoryId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_RepositoryId = 0
+}
+
+func (x *RequestListFlakyTests) ClearQuarantinedOnly() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_QuarantinedOnly = false
+}
+
+type RequestListFlakyTests_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	RepositoryId *int32
+	// If true, only the quarantined tests are returned.
+	QuarantinedOnly *bool
+}
+
+func (b0 RequestListFlakyTests_builder) Build() *RequestListFlakyTests {
+	m0 := &RequestListFlakyTests{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.RepositoryId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_RepositoryId = *b.RepositoryId
+	}
+	if b.QuarantinedOnly != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_QuarantinedOnly = *b.QuarantinedOnly
+	}
+	return m0
+}
+
+type ResponseListFlakyTests struct {
+	state            protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Tests *[]*model.FlakyTest    `protobuf:"bytes,1,rep,name=tests"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ResponseListFlakyTests) Reset() {
+	*x = ResponseListFlakyTests{}
+	mi := &file_proto_build_bff_bff_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseListFlakyTests) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseListFlakyTests) ProtoMessage() {}
+
+func (x *ResponseListFlakyTests) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_bff_bff_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ResponseListFlakyTests) GetTests() []*model.FlakyTest {
+	if x != nil {
+		if x.xxx_hidden_Tests != nil {
+			return *x.xxx_hidden_Tests
+		}
+	}
+	return nil
+}
+
+func (x *ResponseListFlakyTests) SetTests(v []*model.FlakyTest) {
+	x.xxx_hidden_Tests = &v
+}
+
+type ResponseListFlakyTests_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Tests []*model.FlakyTest
+}
+
+func (b0 ResponseListFlakyTests_builder) Build() *ResponseListFlakyTests {
+	m0 := &ResponseListFlakyTests{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Tests = &b.Tests
+	return m0
+}
+
+type RequestTailLogs struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_TaskId      int32                  `protobuf:"varint,1,opt,name=task_id,json=taskId"`
+	xxx_hidden_Offset      int64                  `protobuf:"varint,2,opt,name=offset"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RequestTailLogs) Reset() {
+	*x = RequestTailLogs{}
+	mi := &file_proto_build_bff_bff_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestTailLogs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestTailLogs) ProtoMessage() {}
+
+func (x *RequestTailLogs) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_bff_bff_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RequestTailLogs) GetTaskId() int32 {
+	if x != nil {
+		return x.xxx_hidden_TaskId
+	}
+	return 0
+}
+
+func (x *RequestTailLogs) GetOffset() int64 {
+	if x != nil {
+		return x.xxx_hidden_Offset
+	}
+	return 0
+}
+
+func (x *RequestTailLogs) SetTaskId(v int32) {
+	x.xxx_hidden_TaskId = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *RequestTailLogs) SetOffset(v int64) {
+	x.xxx_hidden_Offset = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *RequestTailLogs) HasTaskId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RequestTailLogs) HasOffset() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RequestTailLogs) ClearTaskId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_TaskId = 0
+}
+
+func (x *RequestTailLogs) ClearOffset() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Offset = 0
+}
+
+type RequestTailLogs_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	TaskId *int32
+	// offset is the byte offset of the log to resume from.
+	Offset *int64
+}
+
+func (b0 RequestTailLogs_builder) Build() *RequestTailLogs {
+	m0 := &RequestTailLogs{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.TaskId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_TaskId = *b.TaskId
+	}
+	if b.Offset != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Offset = *b.Offset
+	}
+	return m0
+}
+
+type ResponseTailLogs struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Body        []byte                 `protobuf:"bytes,1,opt,name=body"`
+	xxx_hidden_Offset      int64                  `protobuf:"varint,2,opt,name=offset"`
+	xxx_hidden_Finished    bool                   `protobuf:"varint,3,opt,name=finished"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ResponseTailLogs) Reset() {
+	*x = ResponseTailLogs{}
+	mi := &file_proto_build_bff_bff_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseTailLogs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseTailLogs) ProtoMessage() {}
+
+func (x *ResponseTailLogs) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_bff_bff_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ResponseTailLogs) GetBody() []byte {
+	if x != nil {
+		return x.xxx_hidden_Body
+	}
+	return nil
+}
+
+func (x *ResponseTailLogs) GetOffset() int64 {
+	if x != nil {
+		return x.xxx_hidden_Offset
+	}
+	return 0
+}
+
+func (x *ResponseTailLogs) GetFinished() bool {
+	if x != nil {
+		return x.xxx_hidden_Finished
+	}
+	return false
+}
+
+func (x *ResponseTailLogs) SetBody(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Body = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 3)
+}
+
+func (x *ResponseTailLogs) SetOffset(v int64) {
+	x.xxx_hidden_Offset = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 3)
+}
+
+func (x *ResponseTailLogs) SetFinished(v bool) {
+	x.xxx_hidden_Finished = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 3)
+}
+
+func (x *ResponseTailLogs) HasBody() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ResponseTailLogs) HasOffset() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ResponseTailLogs) HasFinished() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ResponseTailLogs) ClearBody() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Body = nil
+}
+
+func (x *ResponseTailLogs) ClearOffset() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Offset = 0
+}
+
+func (x *ResponseTailLogs) ClearFinished() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Finished = false
+}
+
+type ResponseTailLogs_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Body []byte
+	// offset is the byte offset of body in the log.
+	Offset *int64
+	// finished is true if the task has finished and the whole log has been sent.
+	Finished *bool
+}
+
+func (b0 ResponseTailLogs_builder) Build() *ResponseTailLogs {
+	m0 := &ResponseTailLogs{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Body != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 3)
+		x.xxx_hidden_Body = b.Body
+	}
+	if b.Offset != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 3)
+		x.xxx_hidden_Offset = *b.Offset
+	}
+	if b.Finished != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 3)
+		x.xxx_hidden_Finished = *b.Finished
+	}
+	return m0
+}
+
+type RequestListArtifacts struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_TaskId      int32                  `protobuf:"varint,1,opt,name=task_id,json=taskId"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RequestListArtifacts) Reset() {
+	*x = RequestListArtifacts{}
+	mi := &file_proto_build_bff_bff_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestListArtifacts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestListArtifacts) ProtoMessage() {}
+
+func (x *RequestListArtifacts) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_bff_bff_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RequestListArtifacts) GetTaskId() int32 {
+	if x != nil {
+		return x.xxx_hidden_TaskId
+	}
+	return 0
+}
+
+func (x *RequestListArtifacts) SetTaskId(v int32) {
+	x.xxx_hidden_TaskId = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *RequestListArtifacts) HasTaskId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RequestListArtifacts) ClearTaskId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_TaskId = 0
+}
+
+type RequestListArtifacts_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	TaskId *int32
+}
+
+func (b0 RequestListArtifacts_builder) Build() *RequestListArtifacts {
+	m0 := &RequestListArtifacts{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.TaskId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_TaskId = *b.TaskId
+	}
+	return m0
+}
+
+type ResponseListArtifacts struct {
+	state                protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Artifacts *[]*model.Artifact     `protobuf:"bytes,1,rep,name=artifacts"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ResponseListArtifacts) Reset() {
+	*x = ResponseListArtifacts{}
+	mi := &file_proto_build_bff_bff_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseListArtifacts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseListArtifacts) ProtoMessage() {}
+
+func (x *ResponseListArtifacts) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_bff_bff_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ResponseListArtifacts) GetArtifacts() []*model.Artifact {
+	if x != nil {
+		if x.xxx_hidden_Artifacts != nil {
+			return *x.xxx_hidden_Artifacts
+		}
+	}
+	return nil
+}
+
+func (x *ResponseListArtifacts) SetArtifacts(v []*model.Artifact) {
+	x.xxx_hidden_Artifacts = &v
+}
+
+type ResponseListArtifacts_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Artifacts []*model.Artifact
+}
+
+func (b0 ResponseListArtifacts_builder) Build() *ResponseListArtifacts {
+	m0 := &ResponseListArtifacts{}
+	b, x := &b0, m0
+	_, _ = b, x
+	x.xxx_hidden_Artifacts = &b.Artifacts
+	return m0
+}
+
+type RequestDownloadArtifact struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_TaskId      int32                  `protobuf:"varint,1,opt,name=task_id,json=taskId"`
+	xxx_hidden_Path        *string                `protobuf:"bytes,2,opt,name=path"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *RequestDownloadArtifact) Reset() {
+	*x = RequestDownloadArtifact{}
+	mi := &file_proto_build_bff_bff_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestDownloadArtifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestDownloadArtifact) ProtoMessage() {}
+
+func (x *RequestDownloadArtifact) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_bff_bff_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *RequestDownloadArtifact) GetTaskId() int32 {
+	if x != nil {
+		return x.xxx_hidden_TaskId
+	}
+	return 0
+}
+
+func (x *RequestDownloadArtifact) GetPath() string {
+	if x != nil {
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *RequestDownloadArtifact) SetTaskId(v int32) {
+	x.xxx_hidden_TaskId = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 2)
+}
+
+func (x *RequestDownloadArtifact) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 2)
+}
+
+func (x *RequestDownloadArtifact) HasTaskId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *RequestDownloadArtifact) HasPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *RequestDownloadArtifact) ClearTaskId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_TaskId = 0
+}
+
+func (x *RequestDownloadArtifact) ClearPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Path = nil
+}
+
+type RequestDownloadArtifact_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	TaskId *int32
+	// path is the relative path of the artifact from bazel-bin.
+	Path *string
+}
+
+func (b0 RequestDownloadArtifact_builder) Build() *RequestDownloadArtifact {
+	m0 := &RequestDownloadArtifact{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.TaskId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 2)
+		x.xxx_hidden_TaskId = *b.TaskId
+	}
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 2)
+		x.xxx_hidden_Path = b.Path
+	}
+	return m0
+}
+
+// ResponseDownloadArtifact is a chunk of the artifact.
+type ResponseDownloadArtifact struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Body        []byte                 `protobuf:"bytes,1,opt,name=body"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *ResponseDownloadArtifact) Reset() {
+	*x = ResponseDownloadArtifact{}
+	mi := &file_proto_build_bff_bff_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseDownloadArtifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseDownloadArtifact) ProtoMessage() {}
+
+func (x *ResponseDownloadArtifact) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_bff_bff_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ResponseDownloadArtifact) GetBody() []byte {
+	if x != nil {
+		return x.xxx_hidden_Body
+	}
+	return nil
+}
+
+func (x *ResponseDownloadArtifact) SetBody(v []byte) {
+	if v == nil {
+		v = []byte{}
+	}
+	x.xxx_hidden_Body = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 1)
+}
+
+func (x *ResponseDownloadArtifact) HasBody() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ResponseDownloadArtifact) ClearBody() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Body = nil
+}
+
+type ResponseDownloadArtifact_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Body []byte
+}
+
+func (b0 ResponseDownloadArtifact_builder) Build() *ResponseDownloadArtifact {
+	m0 := &ResponseDownloadArtifact{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Body != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 1)
+		x.xxx_hidden_Body = b.Body
+	}
+	return m0
+}
+
+// GitDataRepository is a lightweight view of a repository served by the
+// git-data-service, used for the list on the Git Data page. Heavier per-repo
+// statistics are fetched lazily via GetGitDataStatistics.
 type GitDataRepository struct {
 	state                    protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Name          *string                `protobuf:"bytes,1,opt,name=name"`
@@ -2378,7 +3293,7 @@ type GitDataRepository struct {
 
 func (x *GitDataRepository) Reset() {
 	*x = GitDataRepository{}
-	mi := &file_proto_build_bff_bff_proto_msgTypes[25]
+	mi := &file_proto_build_bff_bff_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2390,7 +3305,7 @@ func (x *GitDataRepository) String() string {
 func (*GitDataRepository) ProtoMessage() {}
 
 func (x *GitDataRepository) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_bff_bff_proto_msgTypes[25]
+	mi := &file_proto_build_bff_bff_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2517,7 +3432,7 @@ type RequestListGitData struct {
 
 func (x *RequestListGitData) Reset() {
 	*x = RequestListGitData{}
-	mi := &file_proto_build_bff_bff_proto_msgTypes[26]
+	mi := &file_proto_build_bff_bff_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2529,7 +3444,7 @@ func (x *RequestListGitData) String() string {
 func (*RequestListGitData) ProtoMessage() {}
 
 func (x *RequestListGitData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_bff_bff_proto_msgTypes[26]
+	mi := &file_proto_build_bff_bff_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2561,7 +3476,7 @@ type ResponseListGitData struct {
 
 func (x *ResponseListGitData) Reset() {
 	*x = ResponseListGitData{}
-	mi := &file_proto_build_bff_bff_proto_msgTypes[27]
+	mi := &file_proto_build_bff_bff_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2573,7 +3488,7 @@ func (x *ResponseListGitData) String() string {
 func (*ResponseListGitData) ProtoMessage() {}
 
 func (x *ResponseListGitData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_bff_bff_proto_msgTypes[27]
+	mi := &file_proto_build_bff_bff_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2622,7 +3537,7 @@ type RequestGetGitDataStatistics struct {
 
 func (x *RequestGetGitDataStatistics) Reset() {
 	*x = RequestGetGitDataStatistics{}
-	mi := &file_proto_build_bff_bff_proto_msgTypes[28]
+	mi := &file_proto_build_bff_bff_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2634,7 +3549,7 @@ func (x *RequestGetGitDataStatistics) String() string {
 func (*RequestGetGitDataStatistics) ProtoMessage() {}
 
 func (x *RequestGetGitDataStatistics) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_bff_bff_proto_msgTypes[28]
+	mi := &file_proto_build_bff_bff_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2689,6 +3604,9 @@ func (b0 RequestGetGitDataStatistics_builder) Build() *RequestGetGitDataStatisti
 	return m0
 }
 
+// ResponseGetGitDataStatistics flattens the git-data-service statistics for a
+// single repository. head_commit_when is the committer time of the HEAD commit
+// and represents the last update time of the repository.
 type ResponseGetGitDataStatistics struct {
 	state                        protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_HeadCommitSha     *string                `protobuf:"bytes,1,opt,name=head_commit_sha,json=headCommitSha"`
@@ -2703,7 +3621,7 @@ type ResponseGetGitDataStatistics struct {
 
 func (x *ResponseGetGitDataStatistics) Reset() {
 	*x = ResponseGetGitDataStatistics{}
-	mi := &file_proto_build_bff_bff_proto_msgTypes[29]
+	mi := &file_proto_build_bff_bff_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2715,7 +3633,7 @@ func (x *ResponseGetGitDataStatistics) String() string {
 func (*ResponseGetGitDataStatistics) ProtoMessage() {}
 
 func (x *ResponseGetGitDataStatistics) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_bff_bff_proto_msgTypes[29]
+	mi := &file_proto_build_bff_bff_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2889,15 +3807,21 @@ type BFFTask struct {
 	xxx_hidden_MemoryLimit            *string                `protobuf:"bytes,27,opt,name=memory_limit,json=memoryLimit"`
 	xxx_hidden_TestReports            *[]*model.TestReport   `protobuf:"bytes,28,rep,name=test_reports,json=testReports"`
 	xxx_hidden_Duration               *durationpb.Duration   `protobuf:"bytes,29,opt,name=duration"`
+	xxx_hidden_Skipped                bool                   `protobuf:"varint,30,opt,name=skipped"`
+	xxx_hidden_Needs                  []string               `protobuf:"bytes,31,rep,name=needs"`
+	xxx_hidden_Attempt                int32                  `protobuf:"varint,32,opt,name=attempt"`
+	xxx_hidden_ParentTaskId           int32                  `protobuf:"varint,33,opt,name=parent_task_id,json=parentTaskId"`
+	xxx_hidden_CancelReason           *string                `protobuf:"bytes,34,opt,name=cancel_reason,json=cancelReason"`
+	xxx_hidden_Matrix                 map[string]string      `protobuf:"bytes,35,rep,name=matrix" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	XXX_raceDetectHookData            protoimpl.RaceDetectHookData
-	XXX_presence                      [1]uint32
+	XXX_presence                      [2]uint32
 	unknownFields                     protoimpl.UnknownFields
 	sizeCache                         protoimpl.SizeCache
 }
 
 func (x *BFFTask) Reset() {
 	*x = BFFTask{}
-	mi := &file_proto_build_bff_bff_proto_msgTypes[30]
+	mi := &file_proto_build_bff_bff_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2909,7 +3833,7 @@ func (x *BFFTask) String() string {
 func (*BFFTask) ProtoMessage() {}
 
 func (x *BFFTask) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_build_bff_bff_proto_msgTypes[30]
+	mi := &file_proto_build_bff_bff_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3173,9 +4097,54 @@ func (x *BFFTask) GetDuration() *durationpb.Duration {
 	return nil
 }
 
+func (x *BFFTask) GetSkipped() bool {
+	if x != nil {
+		return x.xxx_hidden_Skipped
+	}
+	return false
+}
+
+func (x *BFFTask) GetNeeds() []string {
+	if x != nil {
+		return x.xxx_hidden_Needs
+	}
+	return nil
+}
+
+func (x *BFFTask) GetAttempt() int32 {
+	if x != nil {
+		return x.xxx_hidden_Attempt
+	}
+	return 0
+}
+
+func (x *BFFTask) GetParentTaskId() int32 {
+	if x != nil {
+		return x.xxx_hidden_ParentTaskId
+	}
+	return 0
+}
+
+func (x *BFFTask) GetCancelReason() string {
+	if x != nil {
+		if x.xxx_hidden_CancelReason != nil {
+			return *x.xxx_hidden_CancelReason
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BFFTask) GetMatrix() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Matrix
+	}
+	return nil
+}
+
 func (x *BFFTask) SetId(v int32) {
 	x.xxx_hidden_Id = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 35)
 }
 
 func (x *BFFTask) SetRepository(v *model.Repository) {
@@ -3184,42 +4153,42 @@ func (x *BFFTask) SetRepository(v *model.Repository) {
 
 func (x *BFFTask) SetJobName(v string) {
 	x.xxx_hidden_JobName = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 35)
 }
 
 func (x *BFFTask) SetParsedJobConfiguration(v string) {
 	x.xxx_hidden_ParsedJobConfiguration = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 35)
 }
 
 func (x *BFFTask) SetRevision(v string) {
 	x.xxx_hidden_Revision = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 35)
 }
 
 func (x *BFFTask) SetBazelVersion(v string) {
 	x.xxx_hidden_BazelVersion = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 35)
 }
 
 func (x *BFFTask) SetCommand(v string) {
 	x.xxx_hidden_Command = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 35)
 }
 
 func (x *BFFTask) SetIsTrunk(v bool) {
 	x.xxx_hidden_IsTrunk = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 35)
 }
 
 func (x *BFFTask) SetSuccess(v bool) {
 	x.xxx_hidden_Success = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 35)
 }
 
 func (x *BFFTask) SetLogFile(v string) {
 	x.xxx_hidden_LogFile = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 35)
 }
 
 func (x *BFFTask) SetTargets(v []string) {
@@ -3228,42 +4197,42 @@ func (x *BFFTask) SetTargets(v []string) {
 
 func (x *BFFTask) SetPlatform(v string) {
 	x.xxx_hidden_Platform = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 11, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 11, 35)
 }
 
 func (x *BFFTask) SetVia(v string) {
 	x.xxx_hidden_Via = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 12, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 12, 35)
 }
 
 func (x *BFFTask) SetConfigName(v string) {
 	x.xxx_hidden_ConfigName = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 13, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 13, 35)
 }
 
 func (x *BFFTask) SetNode(v string) {
 	x.xxx_hidden_Node = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 14, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 14, 35)
 }
 
 func (x *BFFTask) SetManifest(v string) {
 	x.xxx_hidden_Manifest = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 15, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 15, 35)
 }
 
 func (x *BFFTask) SetContainer(v string) {
 	x.xxx_hidden_Container = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 16, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 16, 35)
 }
 
 func (x *BFFTask) SetExecutedTestsCount(v int32) {
 	x.xxx_hidden_ExecutedTestsCount = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 17, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 17, 35)
 }
 
 func (x *BFFTask) SetSucceededTestsCount(v int32) {
 	x.xxx_hidden_SucceededTestsCount = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 18, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 18, 35)
 }
 
 func (x *BFFTask) SetStartAt(v *timestamppb.Timestamp) {
@@ -3284,22 +4253,22 @@ func (x *BFFTask) SetUpdatedAt(v *timestamppb.Timestamp) {
 
 func (x *BFFTask) SetRepositoryUrl(v string) {
 	x.xxx_hidden_RepositoryUrl = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 23, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 23, 35)
 }
 
 func (x *BFFTask) SetRevisionUrl(v string) {
 	x.xxx_hidden_RevisionUrl = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 24, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 24, 35)
 }
 
 func (x *BFFTask) SetCpuLimit(v string) {
 	x.xxx_hidden_CpuLimit = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 25, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 25, 35)
 }
 
 func (x *BFFTask) SetMemoryLimit(v string) {
 	x.xxx_hidden_MemoryLimit = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 26, 29)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 26, 35)
 }
 
 func (x *BFFTask) SetTestReports(v []*model.TestReport) {
@@ -3310,6 +4279,34 @@ func (x *BFFTask) SetDuration(v *durationpb.Duration) {
 	x.xxx_hidden_Duration = v
 }
 
+func (x *BFFTask) SetSkipped(v bool) {
+	x.xxx_hidden_Skipped = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 29, 35)
+}
+
+func (x *BFFTask) SetNeeds(v []string) {
+	x.xxx_hidden_Needs = v
+}
+
+func (x *BFFTask) SetAttempt(v int32) {
+	x.xxx_hidden_Attempt = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 31, 35)
+}
+
+func (x *BFFTask) SetParentTaskId(v int32) {
+	x.xxx_hidden_ParentTaskId = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[1]), 32, 35)
+}
+
+func (x *BFFTask) SetCancelReason(v string) {
+	x.xxx_hidden_CancelReason = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[1]), 33, 35)
+}
+
+func (x *BFFTask) SetMatrix(v map[string]string) {
+	x.xxx_hidden_Matrix = v
+}
+
 func (x *BFFTask) HasId() bool {
 	if x == nil {
 		return false
@@ -3499,6 +4496,34 @@ func (x *BFFTask) HasDuration() bool {
 	return x.xxx_hidden_Duration != nil
 }
 
+func (x *BFFTask) HasSkipped() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 29)
+}
+
+func (x *BFFTask) HasAttempt() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 31)
+}
+
+func (x *BFFTask) HasParentTaskId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[1]), 32)
+}
+
+func (x *BFFTask) HasCancelReason() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[1]), 33)
+}
+
 func (x *BFFTask) ClearId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Id = 0
@@ -3628,6 +4653,26 @@ func (x *BFFTask) ClearDuration() {
 	x.xxx_hidden_Duration = nil
 }
 
+func (x *BFFTask) ClearSkipped() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 29)
+	x.xxx_hidden_Skipped = false
+}
+
+func (x *BFFTask) ClearAttempt() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 31)
+	x.xxx_hidden_Attempt = 0
+}
+
+func (x *BFFTask) ClearParentTaskId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[1]), 32)
+	x.xxx_hidden_ParentTaskId = 0
+}
+
+func (x *BFFTask) ClearCancelReason() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[1]), 33)
+	x.xxx_hidden_CancelReason = nil
+}
+
 type BFFTask_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -3660,6 +4705,12 @@ type BFFTask_builder struct {
 	MemoryLimit            *string
 	TestReports            []*model.TestReport
 	Duration               *durationpb.Duration
+	Skipped                *bool
+	Needs                  []string
+	Attempt                *int32
+	ParentTaskId           *int32
+	CancelReason           *string
+	Matrix                 map[string]string
 }
 
 func (b0 BFFTask_builder) Build() *BFFTask {
@@ -3667,73 +4718,73 @@ func (b0 BFFTask_builder) Build() *BFFTask {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Id != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 35)
 		x.xxx_hidden_Id = *b.Id
 	}
 	x.xxx_hidden_Repository = b.Repository
 	if b.JobName != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 35)
 		x.xxx_hidden_JobName = b.JobName
 	}
 	if b.ParsedJobConfiguration != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 35)
 		x.xxx_hidden_ParsedJobConfiguration = b.ParsedJobConfiguration
 	}
 	if b.Revision != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 35)
 		x.xxx_hidden_Revision = b.Revision
 	}
 	if b.BazelVersion != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 35)
 		x.xxx_hidden_BazelVersion = b.BazelVersion
 	}
 	if b.Command != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 35)
 		x.xxx_hidden_Command = b.Command
 	}
 	if b.IsTrunk != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 35)
 		x.xxx_hidden_IsTrunk = *b.IsTrunk
 	}
 	if b.Success != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 35)
 		x.xxx_hidden_Success = *b.Success
 	}
 	if b.LogFile != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 35)
 		x.xxx_hidden_LogFile = b.LogFile
 	}
 	x.xxx_hidden_Targets = b.Targets
 	if b.Platform != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 11, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 11, 35)
 		x.xxx_hidden_Platform = b.Platform
 	}
 	if b.Via != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 12, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 12, 35)
 		x.xxx_hidden_Via = b.Via
 	}
 	if b.ConfigName != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 13, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 13, 35)
 		x.xxx_hidden_ConfigName = b.ConfigName
 	}
 	if b.Node != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 14, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 14, 35)
 		x.xxx_hidden_Node = b.Node
 	}
 	if b.Manifest != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 15, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 15, 35)
 		x.xxx_hidden_Manifest = b.Manifest
 	}
 	if b.Container != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 16, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 16, 35)
 		x.xxx_hidden_Container = b.Container
 	}
 	if b.ExecutedTestsCount != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 17, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 17, 35)
 		x.xxx_hidden_ExecutedTestsCount = *b.ExecutedTestsCount
 	}
 	if b.SucceededTestsCount != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 18, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 18, 35)
 		x.xxx_hidden_SucceededTestsCount = *b.SucceededTestsCount
 	}
 	x.xxx_hidden_StartAt = b.StartAt
@@ -3741,23 +4792,41 @@ func (b0 BFFTask_builder) Build() *BFFTask {
 	x.xxx_hidden_CreatedAt = b.CreatedAt
 	x.xxx_hidden_UpdatedAt = b.UpdatedAt
 	if b.RepositoryUrl != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 23, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 23, 35)
 		x.xxx_hidden_RepositoryUrl = b.RepositoryUrl
 	}
 	if b.RevisionUrl != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 24, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 24, 35)
 		x.xxx_hidden_RevisionUrl = b.RevisionUrl
 	}
 	if b.CpuLimit != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 25, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 25, 35)
 		x.xxx_hidden_CpuLimit = b.CpuLimit
 	}
 	if b.MemoryLimit != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 26, 29)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 26, 35)
 		x.xxx_hidden_MemoryLimit = b.MemoryLimit
 	}
 	x.xxx_hidden_TestReports = &b.TestReports
 	x.xxx_hidden_Duration = b.Duration
+	if b.Skipped != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 29, 35)
+		x.xxx_hidden_Skipped = *b.Skipped
+	}
+	x.xxx_hidden_Needs = b.Needs
+	if b.Attempt != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 31, 35)
+		x.xxx_hidden_Attempt = *b.Attempt
+	}
+	if b.ParentTaskId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[1]), 32, 35)
+		x.xxx_hidden_ParentTaskId = *b.ParentTaskId
+	}
+	if b.CancelReason != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[1]), 33, 35)
+		x.xxx_hidden_CancelReason = b.CancelReason
+	}
+	x.xxx_hidden_Matrix = b.Matrix
 	return m0
 }
 
@@ -3841,7 +4910,34 @@ const file_proto_build_bff_bff_proto_rawDesc = "" +
 	"\x17RequestListGithubEvents\x12\x19\n" +
 	"\bevent_id\x18\x01 \x01(\x05R\aeventId\"Q\n" +
 	"\x18ResponseListGithubEvents\x125\n" +
-	"\x06events\x18\x01 \x03(\v2\x1d.mono.build.model.GithubEventR\x06events\"`\n" +
+	"\x06events\x18\x01 \x03(\v2\x1d.mono.build.model.GithubEventR\x06events\"4\n" +
+	"\x19RequestGetTaskBuildResult\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x05R\x06taskId\"\xda\x01\n" +
+	"\x1aResponseGetTaskBuildResult\x128\n" +
+	"\atargets\x18\x01 \x03(\v2\x1e.mono.build.model.TargetResultR\atargets\x12H\n" +
+	"\x0faction_failures\x18\x02 \x03(\v2\x1f.mono.build.model.ActionFailureR\x0eactionFailures\x128\n" +
+	"\ametrics\x18\x03 \x01(\v2\x1e.mono.build.model.BuildMetricsR\ametrics\"g\n" +
+	"\x15RequestListFlakyTests\x12#\n" +
+	"\rrepository_id\x18\x01 \x01(\x05R\frepositoryId\x12)\n" +
+	"\x10quarantined_only\x18\x02 \x01(\bR\x0fquarantinedOnly\"K\n" +
+	"\x16ResponseListFlakyTests\x121\n" +
+	"\x05tests\x18\x01 \x03(\v2\x1b.mono.build.model.FlakyTestR\x05tests\"B\n" +
+	"\x0fRequestTailLogs\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x05R\x06taskId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\"Z\n" +
+	"\x10ResponseTailLogs\x12\x12\n" +
+	"\x04body\x18\x01 \x01(\fR\x04body\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x1a\n" +
+	"\bfinished\x18\x03 \x01(\bR\bfinished\"/\n" +
+	"\x14RequestListArtifacts\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x05R\x06taskId\"Q\n" +
+	"\x15ResponseListArtifacts\x128\n" +
+	"\tartifacts\x18\x01 \x03(\v2\x1a.mono.build.model.ArtifactR\tartifacts\"F\n" +
+	"\x17RequestDownloadArtifact\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x05R\x06taskId\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\".\n" +
+	"\x18ResponseDownloadArtifact\x12\x12\n" +
+	"\x04body\x18\x01 \x01(\fR\x04body\"`\n" +
 	"\x11GitDataRepository\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
 	"\x0edefault_branch\x18\x02 \x01(\tR\rdefaultBranch\x12\x10\n" +
@@ -3855,7 +4951,8 @@ const file_proto_build_bff_bff_proto_rawDesc = "" +
 	"\x0fhead_commit_sha\x18\x01 \x01(\tR\rheadCommitSha\x12.\n" +
 	"\x13head_commit_message\x18\x02 \x01(\tR\x11headCommitMessage\x12,\n" +
 	"\x12head_commit_author\x18\x03 \x01(\tR\x10headCommitAuthor\x12D\n" +
-	"\x10head_commit_when\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0eheadCommitWhen\"\xe0\b\n" +
+	"\x10head_commit_when\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0eheadCommitWhen\"\xed\n" +
+	"\n" +
 	"\aBFFTask\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12<\n" +
 	"\n" +
@@ -3892,8 +4989,16 @@ const file_proto_build_bff_bff_proto_rawDesc = "" +
 	"\tcpu_limit\x18\x1a \x01(\tR\bcpuLimit\x12!\n" +
 	"\fmemory_limit\x18\x1b \x01(\tR\vmemoryLimit\x12?\n" +
 	"\ftest_reports\x18\x1c \x03(\v2\x1c.mono.build.model.TestReportR\vtestReports\x125\n" +
-	"\bduration\x18\x1d \x01(\v2\x19.google.protobuf.DurationR\bduration2\xc2\n" +
-	"\n" +
+	"\bduration\x18\x1d \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x18\n" +
+	"\askipped\x18\x1e \x01(\bR\askipped\x12\x14\n" +
+	"\x05needs\x18\x1f \x03(\tR\x05needs\x12\x18\n" +
+	"\aattempt\x18  \x01(\x05R\aattempt\x12$\n" +
+	"\x0eparent_task_id\x18! \x01(\x05R\fparentTaskId\x12#\n" +
+	"\rcancel_reason\x18\" \x01(\tR\fcancelReason\x12;\n" +
+	"\x06matrix\x18# \x03(\v2#.mono.build.bff.BFFTask.MatrixEntryR\x06matrix\x1a9\n" +
+	"\vMatrixEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xa8\x0e\n" +
 	"\x03BFF\x12e\n" +
 	"\x10ListRepositories\x12'.mono.build.bff.RequestListRepositories\x1a(.mono.build.bff.ResponseListRepositories\x12P\n" +
 	"\tListTasks\x12 .mono.build.bff.RequestListTasks\x1a!.mono.build.bff.ResponseListTasks\x12J\n" +
@@ -3906,11 +5011,16 @@ const file_proto_build_bff_bff_proto_rawDesc = "" +
 	"\vRestartTask\x12\".mono.build.bff.RequestRestartTask\x1a#.mono.build.bff.ResponseRestartTask\x12\\\n" +
 	"\rForceStopTask\x12$.mono.build.bff.RequestForceStopTask\x1a%.mono.build.bff.ResponseForceStopTask\x12\x86\x01\n" +
 	"\x1bListExternalReleaseTriggers\x122.mono.build.bff.RequestListExternalReleaseTriggers\x1a3.mono.build.bff.ResponseListExternalReleaseTriggers\x12e\n" +
-	"\x10ListGithubEvents\x12'.mono.build.bff.RequestListGithubEvents\x1a(.mono.build.bff.ResponseListGithubEvents\x12V\n" +
+	"\x10ListGithubEvents\x12'.mono.build.bff.RequestListGithubEvents\x1a(.mono.build.bff.ResponseListGithubEvents\x12k\n" +
+	"\x12GetTaskBuildResult\x12).mono.build.bff.RequestGetTaskBuildResult\x1a*.mono.build.bff.ResponseGetTaskBuildResult\x12_\n" +
+	"\x0eListFlakyTests\x12%.mono.build.bff.RequestListFlakyTests\x1a&.mono.build.bff.ResponseListFlakyTests\x12O\n" +
+	"\bTailLogs\x12\x1f.mono.build.bff.RequestTailLogs\x1a .mono.build.bff.ResponseTailLogs0\x01\x12\\\n" +
+	"\rListArtifacts\x12$.mono.build.bff.RequestListArtifacts\x1a%.mono.build.bff.ResponseListArtifacts\x12g\n" +
+	"\x10DownloadArtifact\x12'.mono.build.bff.RequestDownloadArtifact\x1a(.mono.build.bff.ResponseDownloadArtifact0\x01\x12V\n" +
 	"\vListGitData\x12\".mono.build.bff.RequestListGitData\x1a#.mono.build.bff.ResponseListGitData\x12q\n" +
 	"\x14GetGitDataStatistics\x12+.mono.build.bff.RequestGetGitDataStatistics\x1a,.mono.build.bff.ResponseGetGitDataStatisticsB'Z\x1dgo.f110.dev/mono/go/build/bff\x92\x03\x05\xd2>\x02\x10\x03b\beditionsp\xe8\a"
 
-var file_proto_build_bff_bff_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_proto_build_bff_bff_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
 var file_proto_build_bff_bff_proto_goTypes = []any{
 	(*RequestListRepositories)(nil),             // 0: mono.build.bff.RequestListRepositories
 	(*ResponseListRepositories)(nil),            // 1: mono.build.bff.ResponseListRepositories
@@ -3937,71 +5047,103 @@ var file_proto_build_bff_bff_proto_goTypes = []any{
 	(*ResponseListExternalReleaseTriggers)(nil), // 22: mono.build.bff.ResponseListExternalReleaseTriggers
 	(*RequestListGithubEvents)(nil),             // 23: mono.build.bff.RequestListGithubEvents
 	(*ResponseListGithubEvents)(nil),            // 24: mono.build.bff.ResponseListGithubEvents
-	(*GitDataRepository)(nil),                   // 25: mono.build.bff.GitDataRepository
-	(*RequestListGitData)(nil),                  // 26: mono.build.bff.RequestListGitData
-	(*ResponseListGitData)(nil),                 // 27: mono.build.bff.ResponseListGitData
-	(*RequestGetGitDataStatistics)(nil),         // 28: mono.build.bff.RequestGetGitDataStatistics
-	(*ResponseGetGitDataStatistics)(nil),        // 29: mono.build.bff.ResponseGetGitDataStatistics
-	(*BFFTask)(nil),                             // 30: mono.build.bff.BFFTask
-	(*model.Repository)(nil),                    // 31: mono.build.model.Repository
-	(*model.Job)(nil),                           // 32: mono.build.model.Job
-	(*model.ExternalReleaseTrigger)(nil),        // 33: mono.build.model.ExternalReleaseTrigger
-	(*model.GithubEvent)(nil),                   // 34: mono.build.model.GithubEvent
-	(*timestamppb.Timestamp)(nil),               // 35: google.protobuf.Timestamp
-	(*model.TestReport)(nil),                    // 36: mono.build.model.TestReport
-	(*durationpb.Duration)(nil),                 // 37: google.protobuf.Duration
+	(*RequestGetTaskBuildResult)(nil),           // 25: mono.build.bff.RequestGetTaskBuildResult
+	(*ResponseGetTaskBuildResult)(nil),          // 26: mono.build.bff.ResponseGetTaskBuildResult
+	(*RequestListFlakyTests)(nil),               // 27: mono.build.bff.RequestListFlakyTests
+	(*ResponseListFlakyTests)(nil),              // 28: mono.build.bff.ResponseListFlakyTests
+	(*RequestTailLogs)(nil),                     // 29: mono.build.bff.RequestTailLogs
+	(*ResponseTailLogs)(nil),                    // 30: mono.build.bff.ResponseTailLogs
+	(*RequestListArtifacts)(nil),                // 31: mono.build.bff.RequestListArtifacts
+	(*ResponseListArtifacts)(nil),               // 32: mono.build.bff.ResponseListArtifacts
+	(*RequestDownloadArtifact)(nil),             // 33: mono.build.bff.RequestDownloadArtifact
+	(*ResponseDownloadArtifact)(nil),            // 34: mono.build.bff.ResponseDownloadArtifact
+	(*GitDataRepository)(nil),                   // 35: mono.build.bff.GitDataRepository
+	(*RequestListGitData)(nil),                  // 36: mono.build.bff.RequestListGitData
+	(*ResponseListGitData)(nil),                 // 37: mono.build.bff.ResponseListGitData
+	(*RequestGetGitDataStatistics)(nil),         // 38: mono.build.bff.RequestGetGitDataStatistics
+	(*ResponseGetGitDataStatistics)(nil),        // 39: mono.build.bff.ResponseGetGitDataStatistics
+	(*BFFTask)(nil),                             // 40: mono.build.bff.BFFTask
+	nil,                                         // 41: mono.build.bff.BFFTask.MatrixEntry
+	(*model.Repository)(nil),                    // 42: mono.build.model.Repository
+	(*model.Job)(nil),                           // 43: mono.build.model.Job
+	(*model.ExternalReleaseTrigger)(nil),        // 44: mono.build.model.ExternalReleaseTrigger
+	(*model.GithubEvent)(nil),                   // 45: mono.build.model.GithubEvent
+	(*model.TargetResult)(nil),                  // 46: mono.build.model.TargetResult
+	(*model.ActionFailure)(nil),                 // 47: mono.build.model.ActionFailure
+	(*model.BuildMetrics)(nil),                  // 48: mono.build.model.BuildMetrics
+	(*model.FlakyTest)(nil),                     // 49: mono.build.model.FlakyTest
+	(*model.Artifact)(nil),                      // 50: mono.build.model.Artifact
+	(*timestamppb.Timestamp)(nil),               // 51: google.protobuf.Timestamp
+	(*model.TestReport)(nil),                    // 52: mono.build.model.TestReport
+	(*durationpb.Duration)(nil),                 // 53: google.protobuf.Duration
 }
 var file_proto_build_bff_bff_proto_depIdxs = []int32{
-	31, // 0: mono.build.bff.ResponseListRepositories.repositories:type_name -> mono.build.model.Repository
-	30, // 1: mono.build.bff.ResponseListTasks.tasks:type_name -> mono.build.bff.BFFTask
+	42, // 0: mono.build.bff.ResponseListRepositories.repositories:type_name -> mono.build.model.Repository
+	40, // 1: mono.build.bff.ResponseListTasks.tasks:type_name -> mono.build.bff.BFFTask
 	8,  // 2: mono.build.bff.ResponseGetServerInfo.config:type_name -> mono.build.bff.ServerConfig
-	32, // 3: mono.build.bff.ResponseListJobs.jobs:type_name -> mono.build.model.Job
-	31, // 4: mono.build.bff.RequestSaveRepository.repository:type_name -> mono.build.model.Repository
-	31, // 5: mono.build.bff.ResponseSaveRepository.repository:type_name -> mono.build.model.Repository
-	33, // 6: mono.build.bff.ResponseListExternalReleaseTriggers.triggers:type_name -> mono.build.model.ExternalReleaseTrigger
-	34, // 7: mono.build.bff.ResponseListGithubEvents.events:type_name -> mono.build.model.GithubEvent
-	25, // 8: mono.build.bff.ResponseListGitData.repositories:type_name -> mono.build.bff.GitDataRepository
-	35, // 9: mono.build.bff.ResponseGetGitDataStatistics.head_commit_when:type_name -> google.protobuf.Timestamp
-	31, // 10: mono.build.bff.BFFTask.repository:type_name -> mono.build.model.Repository
-	35, // 11: mono.build.bff.BFFTask.start_at:type_name -> google.protobuf.Timestamp
-	35, // 12: mono.build.bff.BFFTask.finished_at:type_name -> google.protobuf.Timestamp
-	35, // 13: mono.build.bff.BFFTask.created_at:type_name -> google.protobuf.Timestamp
-	35, // 14: mono.build.bff.BFFTask.updated_at:type_name -> google.protobuf.Timestamp
-	36, // 15: mono.build.bff.BFFTask.test_reports:type_name -> mono.build.model.TestReport
-	37, // 16: mono.build.bff.BFFTask.duration:type_name -> google.protobuf.Duration
-	0,  // 17: mono.build.bff.BFF.ListRepositories:input_type -> mono.build.bff.RequestListRepositories
-	2,  // 18: mono.build.bff.BFF.ListTasks:input_type -> mono.build.bff.RequestListTasks
-	4,  // 19: mono.build.bff.BFF.GetLogs:input_type -> mono.build.bff.RequestGetLogs
-	6,  // 20: mono.build.bff.BFF.GetServerInfo:input_type -> mono.build.bff.RequestGetServerInfo
-	9,  // 21: mono.build.bff.BFF.ListJobs:input_type -> mono.build.bff.RequestListJobs
-	11, // 22: mono.build.bff.BFF.InvokeJob:input_type -> mono.build.bff.RequestInvokeJob
-	13, // 23: mono.build.bff.BFF.SaveRepository:input_type -> mono.build.bff.RequestSaveRepository
-	15, // 24: mono.build.bff.BFF.RemoveRepository:input_type -> mono.build.bff.RequestRemoveRepository
-	17, // 25: mono.build.bff.BFF.RestartTask:input_type -> mono.build.bff.RequestRestartTask
-	19, // 26: mono.build.bff.BFF.ForceStopTask:input_type -> mono.build.bff.RequestForceStopTask
-	21, // 27: mono.build.bff.BFF.ListExternalReleaseTriggers:input_type -> mono.build.bff.RequestListExternalReleaseTriggers
-	23, // 28: mono.build.bff.BFF.ListGithubEvents:input_type -> mono.build.bff.RequestListGithubEvents
-	26, // 29: mono.build.bff.BFF.ListGitData:input_type -> mono.build.bff.RequestListGitData
-	28, // 30: mono.build.bff.BFF.GetGitDataStatistics:input_type -> mono.build.bff.RequestGetGitDataStatistics
-	1,  // 31: mono.build.bff.BFF.ListRepositories:output_type -> mono.build.bff.ResponseListRepositories
-	3,  // 32: mono.build.bff.BFF.ListTasks:output_type -> mono.build.bff.ResponseListTasks
-	5,  // 33: mono.build.bff.BFF.GetLogs:output_type -> mono.build.bff.ResponseGetLogs
-	7,  // 34: mono.build.bff.BFF.GetServerInfo:output_type -> mono.build.bff.ResponseGetServerInfo
-	10, // 35: mono.build.bff.BFF.ListJobs:output_type -> mono.build.bff.ResponseListJobs
-	12, // 36: mono.build.bff.BFF.InvokeJob:output_type -> mono.build.bff.ResponseInvokeJob
-	14, // 37: mono.build.bff.BFF.SaveRepository:output_type -> mono.build.bff.ResponseSaveRepository
-	16, // 38: mono.build.bff.BFF.RemoveRepository:output_type -> mono.build.bff.ResponseRemoveRepository
-	18, // 39: mono.build.bff.BFF.RestartTask:output_type -> mono.build.bff.ResponseRestartTask
-	20, // 40: mono.build.bff.BFF.ForceStopTask:output_type -> mono.build.bff.ResponseForceStopTask
-	22, // 41: mono.build.bff.BFF.ListExternalReleaseTriggers:output_type -> mono.build.bff.ResponseListExternalReleaseTriggers
-	24, // 42: mono.build.bff.BFF.ListGithubEvents:output_type -> mono.build.bff.ResponseListGithubEvents
-	27, // 43: mono.build.bff.BFF.ListGitData:output_type -> mono.build.bff.ResponseListGitData
-	29, // 44: mono.build.bff.BFF.GetGitDataStatistics:output_type -> mono.build.bff.ResponseGetGitDataStatistics
-	31, // [31:45] is the sub-list for method output_type
-	17, // [17:31] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	43, // 3: mono.build.bff.ResponseListJobs.jobs:type_name -> mono.build.model.Job
+	42, // 4: mono.build.bff.RequestSaveRepository.repository:type_name -> mono.build.model.Repository
+	42, // 5: mono.build.bff.ResponseSaveRepository.repository:type_name -> mono.build.model.Repository
+	44, // 6: mono.build.bff.ResponseListExternalReleaseTriggers.triggers:type_name -> mono.build.model.ExternalReleaseTrigger
+	45, // 7: mono.build.bff.ResponseListGithubEvents.events:type_name -> mono.build.model.GithubEvent
+	46, // 8: mono.build.bff.ResponseGetTaskBuildResult.targets:type_name -> mono.build.model.TargetResult
+	47, // 9: mono.build.bff.ResponseGetTaskBuildResult.action_failures:type_name -> mono.build.model.ActionFailure
+	48, // 10: mono.build.bff.ResponseGetTaskBuildResult.metrics:type_name -> mono.build.model.BuildMetrics
+	49, // 11: mono.build.bff.ResponseListFlakyTests.tests:type_name -> mono.build.model.FlakyTest
+	50, // 12: mono.build.bff.ResponseListArtifacts.artifacts:type_name -> mono.build.model.Artifact
+	35, // 13: mono.build.bff.ResponseListGitData.repositories:type_name -> mono.build.bff.GitDataRepository
+	51, // 14: mono.build.bff.ResponseGetGitDataStatistics.head_commit_when:type_name -> google.protobuf.Timestamp
+	42, // 15: mono.build.bff.BFFTask.repository:type_name -> mono.build.model.Repository
+	51, // 16: mono.build.bff.BFFTask.start_at:type_name -> google.protobuf.Timestamp
+	51, // 17: mono.build.bff.BFFTask.finished_at:type_name -> google.protobuf.Timestamp
+	51, // 18: mono.build.bff.BFFTask.created_at:type_name -> google.protobuf.Timestamp
+	51, // 19: mono.build.bff.BFFTask.updated_at:type_name -> google.protobuf.Timestamp
+	52, // 20: mono.build.bff.BFFTask.test_reports:type_name -> mono.build.model.TestReport
+	53, // 21: mono.build.bff.BFFTask.duration:type_name -> google.protobuf.Duration
+	41, // 22: mono.build.bff.BFFTask.matrix:type_name -> mono.build.bff.BFFTask.MatrixEntry
+	0,  // 23: mono.build.bff.BFF.ListRepositories:input_type -> mono.build.bff.RequestListRepositories
+	2,  // 24: mono.build.bff.BFF.ListTasks:input_type -> mono.build.bff.RequestListTasks
+	4,  // 25: mono.build.bff.BFF.GetLogs:input_type -> mono.build.bff.RequestGetLogs
+	6,  // 26: mono.build.bff.BFF.GetServerInfo:input_type -> mono.build.bff.RequestGetServerInfo
+	9,  // 27: mono.build.bff.BFF.ListJobs:input_type -> mono.build.bff.RequestListJobs
+	11, // 28: mono.build.bff.BFF.InvokeJob:input_type -> mono.build.bff.RequestInvokeJob
+	13, // 29: mono.build.bff.BFF.SaveRepository:input_type -> mono.build.bff.RequestSaveRepository
+	15, // 30: mono.build.bff.BFF.RemoveRepository:input_type -> mono.build.bff.RequestRemoveRepository
+	17, // 31: mono.build.bff.BFF.RestartTask:input_type -> mono.build.bff.RequestRestartTask
+	19, // 32: mono.build.bff.BFF.ForceStopTask:input_type -> mono.build.bff.RequestForceStopTask
+	21, // 33: mono.build.bff.BFF.ListExternalReleaseTriggers:input_type -> mono.build.bff.RequestListExternalReleaseTriggers
+	23, // 34: mono.build.bff.BFF.ListGithubEvents:input_type -> mono.build.bff.RequestListGithubEvents
+	25, // 35: mono.build.bff.BFF.GetTaskBuildResult:input_type -> mono.build.bff.RequestGetTaskBuildResult
+	27, // 36: mono.build.bff.BFF.ListFlakyTests:input_type -> mono.build.bff.RequestListFlakyTests
+	29, // 37: mono.build.bff.BFF.TailLogs:input_type -> mono.build.bff.RequestTailLogs
+	31, // 38: mono.build.bff.BFF.ListArtifacts:input_type -> mono.build.bff.RequestListArtifacts
+	33, // 39: mono.build.bff.BFF.DownloadArtifact:input_type -> mono.build.bff.RequestDownloadArtifact
+	36, // 40: mono.build.bff.BFF.ListGitData:input_type -> mono.build.bff.RequestListGitData
+	38, // 41: mono.build.bff.BFF.GetGitDataStatistics:input_type -> mono.build.bff.RequestGetGitDataStatistics
+	1,  // 42: mono.build.bff.BFF.ListRepositories:output_type -> mono.build.bff.ResponseListRepositories
+	3,  // 43: mono.build.bff.BFF.ListTasks:output_type -> mono.build.bff.ResponseListTasks
+	5,  // 44: mono.build.bff.BFF.GetLogs:output_type -> mono.build.bff.ResponseGetLogs
+	7,  // 45: mono.build.bff.BFF.GetServerInfo:output_type -> mono.build.bff.ResponseGetServerInfo
+	10, // 46: mono.build.bff.BFF.ListJobs:output_type -> mono.build.bff.ResponseListJobs
+	12, // 47: mono.build.bff.BFF.InvokeJob:output_type -> mono.build.bff.ResponseInvokeJob
+	14, // 48: mono.build.bff.BFF.SaveRepository:output_type -> mono.build.bff.ResponseSaveRepository
+	16, // 49: mono.build.bff.BFF.RemoveRepository:output_type -> mono.build.bff.ResponseRemoveRepository
+	18, // 50: mono.build.bff.BFF.RestartTask:output_type -> mono.build.bff.ResponseRestartTask
+	20, // 51: mono.build.bff.BFF.ForceStopTask:output_type -> mono.build.bff.ResponseForceStopTask
+	22, // 52: mono.build.bff.BFF.ListExternalReleaseTriggers:output_type -> mono.build.bff.ResponseListExternalReleaseTriggers
+	24, // 53: mono.build.bff.BFF.ListGithubEvents:output_type -> mono.build.bff.ResponseListGithubEvents
+	26, // 54: mono.build.bff.BFF.GetTaskBuildResult:output_type -> mono.build.bff.ResponseGetTaskBuildResult
+	28, // 55: mono.build.bff.BFF.ListFlakyTests:output_type -> mono.build.bff.ResponseListFlakyTests
+	30, // 56: mono.build.bff.BFF.TailLogs:output_type -> mono.build.bff.ResponseTailLogs
+	32, // 57: mono.build.bff.BFF.ListArtifacts:output_type -> mono.build.bff.ResponseListArtifacts
+	34, // 58: mono.build.bff.BFF.DownloadArtifact:output_type -> mono.build.bff.ResponseDownloadArtifact
+	37, // 59: mono.build.bff.BFF.ListGitData:output_type -> mono.build.bff.ResponseListGitData
+	39, // 60: mono.build.bff.BFF.GetGitDataStatistics:output_type -> mono.build.bff.ResponseGetGitDataStatistics
+	42, // [42:61] is the sub-list for method output_type
+	23, // [23:42] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_proto_build_bff_bff_proto_init() }
@@ -4015,7 +5157,7 @@ func file_proto_build_bff_bff_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_build_bff_bff_proto_rawDesc), len(file_proto_build_bff_bff_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   31,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
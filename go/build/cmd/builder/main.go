@@ -3,8 +3,10 @@ package builder
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -99,14 +101,21 @@ type Options struct {
 	TaskCPULimit                   string
 	TaskMemoryLimit                string
 	WithGC                         bool
+	ArtifactStorageEndpoint        string // The endpoint of MinIO which is accessed from the build pods.
+	ArtifactSecretName             string
+	ArtifactKeepTrunkBuilds        int
+	ArtifactNonTrunkTTL            time.Duration
 	ExcludeNodes                   []string
 	ExternalReleasePollInterval    time.Duration
 	EventReconcileInterval         time.Duration
 	EventMaxProcessingDuration     time.Duration
+	PollRepositories               []string
+	PollInterval                   time.Duration
 
 	GitDataServiceURL                 string
 	CloneFromGitDataService           bool
 	GitDataListen                     string
+	GitDataHTTPListen                 string
 	GitDataStorageEndpoint            string
 	GitDataStorageRegion              string
 	GitDataStorageAccessKey           string
@@ -163,6 +172,8 @@ type process struct {
 	notifier          *webhook.Notifier
 	reconcilers       webhook.Reconcilers
 	gitDataGRPCServer *grpc.Server
+	gitDataHTTPServer *http.Server
+	gitDataService    *git.DataService
 	gitDataUpdater    *git.Updater
 	gitDataPackCache  *git.PackfileCache
 	gitDataConn       *grpc.ClientConn
@@ -368,6 +379,8 @@ func (p *process) setup(_ context.Context) (fsm.State, error) {
 		p.opt.GithubAppSecretName,
 		p.opt.GitDataServiceURL,
 		p.opt.CloneFromGitDataService,
+		p.opt.ArtifactStorageEndpoint,
+		p.opt.ArtifactSecretName,
 	)
 	c, err := coordinator.NewBazelBuilder(
 		p.opt.DashboardUrl,
@@ -451,6 +464,11 @@ func (p *process) startGitDataService(ctx context.Context) (fsm.State, error) {
 	if err != nil {
 		return fsm.Error(err)
 	}
+	service.SetCachePool(cachePool)
+	if err := service.LoadReferenceJournal(ctx, storageClient); err != nil {
+		return fsm.Error(err)
+	}
+	p.gitDataService = service
 	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(grpcutil.WithServerLogging()))
 	git.RegisterGitDataServer(grpcServer, service)
 	healthSvc := health.NewServer()
@@ -469,6 +487,19 @@ func (p *process) startGitDataService(ctx context.Context) (fsm.State, error) {
 		}
 	}()
 
+	if p.opt.GitDataHTTPListen != "" {
+		p.gitDataHTTPServer = &http.Server{
+			Addr:    p.opt.GitDataHTTPListen,
+			Handler: git.NewSmartHTTPServer(service),
+		}
+		slogger.Log.Info("Start git smart HTTP", slog.String("addr", p.opt.GitDataHTTPListen))
+		go func() {
+			if err := p.gitDataHTTPServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				slogger.Log.Error("git smart HTTP returns error", slogger.E(err))
+			}
+		}()
+	}
+
 	updater, err := git.NewUpdater(storageClient, tokenProvider, repositories, p.opt.GitDataLockFilePath, p.opt.GitDataRefreshWorkers)
 	if err != nil {
 		return fsm.Error(err)
@@ -638,7 +669,10 @@ func (p *process) startWorker(_ context.Context) (fsm.State, error) {
 	}
 
 	if p.opt.WithGC {
-		g := gc.NewGC(1*time.Hour, p.dao, p.opt.MinIOBucket, p.storageOpt)
+		g := gc.NewGC(1*time.Hour, p.dao, storage.NewS3(p.opt.MinIOBucket, p.storageOpt), gc.ArtifactRetention{
+			TrunkBuilds: p.opt.ArtifactKeepTrunkBuilds,
+			NonTrunkTTL: p.opt.ArtifactNonTrunkTTL,
+		})
 		go func() {
 			slogger.Log.Info("Start GC")
 			g.Start()
@@ -652,9 +686,21 @@ func (p *process) startWorker(_ context.Context) (fsm.State, error) {
 	manager := releasewatcher.NewManager(p.bazelBuilder, p.dao, p.ghClient, nil, interval)
 	go manager.Start(p.ctx)
 
+	if len(p.opt.PollRepositories) > 0 {
+		var gitSyncer webhook.GitSyncer
+		if p.gitDataUpdater != nil {
+			gitSyncer = p.gitDataUpdater
+		}
+		poller := webhook.NewPoller(p.dao, p.ghClient, p.gitDataClient, gitSyncer, p.notifier, p.opt.PollRepositories, p.opt.PollInterval)
+		go poller.Start(p.ctx)
+	}
+
 	scheduler := webhook.NewScheduler(p.dao, p.reconcilers, p.notifier, p.opt.EventReconcileInterval, p.opt.EventMaxProcessingDuration)
 	go scheduler.Run(p.ctx)
 
+	if p.gitDataService != nil {
+		go p.gitDataService.SyncReferenceJournal(p.ctx, git.DefaultReferenceJournalSyncInterval)
+	}
 	if p.gitDataUpdater != nil && p.opt.GitDataRefreshInterval > 0 {
 		go p.gitDataUpdater.Run(p.ctx)
 	}
@@ -672,6 +718,10 @@ func (p *process) shutdown(ctx context.Context) (fsm.State, error) {
 		p.gitDataGRPCServer.GracefulStop()
 		slogger.Log.Info("Shutdown Git Data GRPC Server")
 	}
+	if p.gitDataHTTPServer != nil {
+		p.gitDataHTTPServer.Shutdown(ctx)
+		slogger.Log.Info("Shutdown Git Data HTTP Server")
+	}
 	if p.gitDataConn != nil {
 		p.gitDataConn.Close()
 	}
@@ -836,13 +886,20 @@ func AddCommand(rootCmd *cli.Command) {
 	fs.String("task-cpu-limit", "Task cpu limit. If the job set the limit, It will used the job defined value.").Var(&opt.TaskCPULimit).Default("1000m")
 	fs.String("task-memory-limit", "Task memory limit. If the job set the limit, It will used the job defined value.").Var(&opt.TaskMemoryLimit).Default("4096Mi")
 	fs.Bool("with-gc", "Enable GC for the job").Var(&opt.WithGC)
+	fs.String("artifact-storage-endpoint", "The endpoint of MinIO which is accessed from the build pods to upload the artifacts").Var(&opt.ArtifactStorageEndpoint)
+	fs.String("artifact-secret-name", "The name of Secret which contains the access key (accesskey) and the secret access key (secretkey) for uploading the artifacts. If empty, the artifacts are not published.").Var(&opt.ArtifactSecretName)
+	fs.Int("artifact-keep-trunk-builds", "The number of the latest successful trunk builds of each job whose artifacts are kept by GC").Var(&opt.ArtifactKeepTrunkBuilds).Default(5)
+	fs.Duration("artifact-non-trunk-ttl", "The period for keeping the artifacts of the builds which are not trunk (e.g. pull requests)").Var(&opt.ArtifactNonTrunkTTL).Default(7 * 24 * time.Hour)
 	fs.StringArray("exclude-nodes", "THe list of node to not assigned job").Var(&opt.ExcludeNodes)
 	fs.Duration("external-release-poll-interval", "Interval between polls of third-party repositories for external_release triggers").Var(&opt.ExternalReleasePollInterval).Default(1 * time.Hour)
 	fs.Duration("event-reconcile-interval", "Interval between scans of the github_event table for PENDING/FAILED rows").Var(&opt.EventReconcileInterval).Default(30 * time.Second)
 	fs.Duration("event-max-processing-duration", "Time after `created_at` at which an unfinished github_event row is moved to EXPIRED").Var(&opt.EventMaxProcessingDuration).Default(30 * time.Minute)
+	fs.StringArray("poll-repository", "The URL of the repository whose default branch is polled instead of receiving the webhook (e.g. https://github.com/f110/mono)").Var(&opt.PollRepositories)
+	fs.Duration("poll-interval", "Interval between polls of the repositories specified by --poll-repository").Var(&opt.PollInterval).Default(1 * time.Minute)
 	fs.String("git-data-service-url", "URL of the git-data-service gRPC endpoint used by reconcilers to read repository data. If empty, reconcilers read from GitHub instead.").Var(&opt.GitDataServiceURL)
 	fs.Bool("clone-from-git-data-service", "Fetch the source tree from git-data-service (--git-data-service-url) in the pre-process container instead of cloning from GitHub. If not set, the source is always cloned from GitHub.").Var(&opt.CloneFromGitDataService)
 	fs.String("git-data-listen", "Listen addr of the embedded git-data-service. If empty, the service is disabled.").Var(&opt.GitDataListen)
+	fs.String("git-data-http-listen", "Listen addr of git smart HTTP of the embedded git-data-service. If empty, git smart HTTP is disabled.").Var(&opt.GitDataHTTPListen)
 	fs.String("git-data-storage-endpoint", "The endpoint of the object storage for git-data-service").Var(&opt.GitDataStorageEndpoint)
 	fs.String("git-data-storage-region", "The region name of the object storage for git-data-service").Var(&opt.GitDataStorageRegion)
 	fs.String("git-data-storage-access-key", "The access key for the git-data-service object storage").Var(&opt.GitDataStorageAccessKey)
@@ -0,0 +1,139 @@
+package sidecar
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"go.f110.dev/xerrors"
+
+	"go.f110.dev/mono/go/bazel/buildeventstream"
+	"go.f110.dev/mono/go/build/config"
+	"go.f110.dev/mono/go/storage"
+)
+
+// Artifact is an output of the build which is uploaded to the object storage.
+type Artifact struct {
+	Label string `json:"label"`
+	// Path is the relative path from bazel-bin.
+	Path       string `json:"path"`
+	ObjectName string `json:"object_name"`
+	Size       int64  `json:"size"`
+}
+
+// targetOutput is a file of the default output group of the target.
+type targetOutput struct {
+	Label string
+	// Name is the relative path from bazel-bin.
+	Name string
+	URI  string
+}
+
+type objectUploader interface {
+	PutReader(ctx context.Context, name string, r io.Reader) error
+}
+
+// targetFileSets is the named sets of files which are referred by the successful target.
+type targetFileSets struct {
+	Label    string
+	FileSets []string
+}
+
+// resolveOutputs expands the named sets of files of the targets.
+// A named set can refer other named sets, so the files are collected recursively.
+func resolveOutputs(targets []targetFileSets, namedSets map[string]*buildeventstream.NamedSetOfFiles) []targetOutput {
+	var outputs []targetOutput
+	for _, t := range targets {
+		visited := make(map[string]struct{})
+		queue := append([]string{}, t.FileSets...)
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			if _, ok := visited[id]; ok {
+				continue
+			}
+			visited[id] = struct{}{}
+
+			set, ok := namedSets[id]
+			if !ok {
+				continue
+			}
+			for _, f := range set.Files {
+				outputs = append(outputs, targetOutput{Label: t.Label, Name: f.Name, URI: f.GetUri()})
+			}
+			for _, v := range set.FileSets {
+				queue = append(queue, v.Id)
+			}
+		}
+	}
+	return outputs
+}
+
+// uploadArtifacts uploads the outputs which match any of patterns.
+// The name of the object is the relative path from bazel-bin prefixed by prefix.
+func uploadArtifacts(ctx context.Context, uploader objectUploader, outputs []targetOutput, patterns []string, prefix string) ([]Artifact, error) {
+	var artifacts []Artifact
+	uploaded := make(map[string]struct{})
+	for _, o := range outputs {
+		if _, ok := uploaded[o.Name]; ok {
+			continue
+		}
+		if !matchAnyArtifact(patterns, o.Label, o.Name) {
+			continue
+		}
+
+		u, err := url.Parse(o.URI)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		if u.Scheme != "file" {
+			// The output which is not downloaded from the remote cache (e.g. --remote_download_minimal) doesn't exist on the local disk.
+			return nil, xerrors.Definef("%s is not on the local disk: %s", o.Name, o.URI).WithStack()
+		}
+		f, err := os.Open(u.Path)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		info, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return nil, xerrors.WithStack(err)
+		}
+		objectName := path.Join(prefix, o.Name)
+		err = uploader.PutReader(ctx, objectName, f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		uploaded[o.Name] = struct{}{}
+		artifacts = append(artifacts, Artifact{Label: o.Label, Path: o.Name, ObjectName: objectName, Size: info.Size()})
+	}
+	return artifacts, nil
+}
+
+func matchAnyArtifact(patterns []string, label, name string) bool {
+	for _, p := range patterns {
+		if config.MatchArtifact(p, label, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// newArtifactStorage returns the client of the object storage. The credentials are read from the files.
+func newArtifactStorage(endpoint, bucket, accessKeyFile, secretAccessKeyFile string) (*storage.S3, error) {
+	accessKey, err := os.ReadFile(accessKeyFile)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	secretAccessKey, err := os.ReadFile(secretAccessKeyFile)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	opt := storage.NewS3OptionToExternal(endpoint, "", strings.TrimSpace(string(accessKey)), strings.TrimSpace(string(secretAccessKey)))
+	return storage.NewS3(bucket, opt), nil
+}
@@ -0,0 +1,63 @@
+package sidecar
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.f110.dev/mono/go/bazel/buildeventstream"
+)
+
+type fakeUploader struct {
+	objects map[string][]byte
+}
+
+func (f *fakeUploader) PutReader(_ context.Context, name string, r io.Reader) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	f.objects[name] = b
+	return nil
+}
+
+func TestUploadArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	for name, body := range map[string]string{"foo": "binary", "image.tar": "tarball"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(body), 0644))
+	}
+	fileURI := func(name string) *buildeventstream.File_Uri {
+		return &buildeventstream.File_Uri{Uri: "file://" + filepath.Join(dir, name)}
+	}
+
+	namedSets := map[string]*buildeventstream.NamedSetOfFiles{
+		"0": {
+			Files:    []*buildeventstream.File{{Name: "cmd/foo/foo_/foo", File: fileURI("foo")}},
+			FileSets: []*buildeventstream.BuildEventId_NamedSetOfFilesId{{Id: "1"}},
+		},
+		"1": {Files: []*buildeventstream.File{{Name: "cmd/foo/image.tar", File: fileURI("image.tar")}}},
+		"2": {Files: []*buildeventstream.File{{Name: "cmd/bar/bar_/bar", File: fileURI("bar")}}},
+	}
+	outputs := resolveOutputs([]targetFileSets{
+		{Label: "//cmd/foo:foo", FileSets: []string{"0"}},
+		{Label: "//cmd/bar:bar", FileSets: []string{"2"}},
+	}, namedSets)
+	assert.Len(t, outputs, 3)
+
+	uploader := &fakeUploader{objects: make(map[string][]byte)}
+	artifacts, err := uploadArtifacts(context.Background(), uploader, outputs, []string{"**/*.tar", "//cmd/foo"}, "artifacts/1")
+	require.NoError(t, err)
+	assert.Equal(t, []Artifact{
+		{Label: "//cmd/foo:foo", Path: "cmd/foo/foo_/foo", ObjectName: "artifacts/1/cmd/foo/foo_/foo", Size: 6},
+		{Label: "//cmd/foo:foo", Path: "cmd/foo/image.tar", ObjectName: "artifacts/1/cmd/foo/image.tar", Size: 7},
+	}, artifacts)
+	assert.Equal(t, map[string][]byte{
+		"artifacts/1/cmd/foo/foo_/foo":  []byte("binary"),
+		"artifacts/1/cmd/foo/image.tar": []byte("tarball"),
+	}, uploader.objects)
+}
@@ -14,6 +14,7 @@ import (
 	"google.golang.org/protobuf/encoding/protodelim"
 
 	"go.f110.dev/mono/go/bazel/buildeventstream"
+	"go.f110.dev/mono/go/bazel/devtools"
 	"go.f110.dev/mono/go/cli"
 	"go.f110.dev/mono/go/file"
 )
@@ -22,6 +23,50 @@ type TestReport struct {
 	Tests []TestSummary `json:"tests"`
 }
 
+// BuildReport is the summary of the build event stream.
+// TestReport is embedded to keep the compatibility of the output.
+type BuildReport struct {
+	TestReport
+	Targets        []TargetResult  `json:"targets,omitempty"`
+	ActionFailures []ActionFailure `json:"action_failures,omitempty"`
+	Metrics        *BuildMetrics   `json:"metrics,omitempty"`
+	Artifacts      []Artifact      `json:"artifacts,omitempty"`
+
+	// outputs is the files of the default output group of the successful targets.
+	outputs []targetOutput
+}
+
+type TargetResult struct {
+	Label           string `json:"label"`
+	Success         bool   `json:"success"`
+	FailureCategory string `json:"failure_category,omitempty"`
+	FailureMessage  string `json:"failure_message,omitempty"`
+}
+
+type ActionFailure struct {
+	Label           string `json:"label"`
+	Mnemonic        string `json:"mnemonic"`
+	ExitCode        int    `json:"exit_code"`
+	FailureCategory string `json:"failure_category,omitempty"`
+	FailureMessage  string `json:"failure_message,omitempty"`
+	PrimaryOutput   string `json:"primary_output,omitempty"`
+}
+
+type BuildMetrics struct {
+	ExitCodeName      string `json:"exit_code_name"`
+	ActionsCreated    int64  `json:"actions_created"`
+	ActionsExecuted   int64  `json:"actions_executed"`
+	RemoteCacheHits   int64  `json:"remote_cache_hits"`
+	ActionCacheHits   int    `json:"action_cache_hits"`
+	ActionCacheMisses int    `json:"action_cache_misses"`
+	TargetsConfigured int64  `json:"targets_configured"`
+	// WallTimeMs, CpuTimeMs, AnalysisPhaseTimeMs and ExecutionPhaseTimeMs are in milliseconds.
+	WallTimeMs           int64 `json:"wall_time_ms"`
+	CpuTimeMs            int64 `json:"cpu_time_ms"`
+	AnalysisPhaseTimeMs  int64 `json:"analysis_phase_time_ms"`
+	ExecutionPhaseTimeMs int64 `json:"execution_phase_time_ms"`
+}
+
 type TestStatus string
 
 const (
@@ -41,6 +86,13 @@ type TestSummary struct {
 type TestReportCommand struct {
 	eventBinaryFile string
 	startUpTimeout  time.Duration
+
+	artifacts                  []string
+	artifactPrefix             string
+	storageEndpoint            string
+	storageBucket              string
+	storageAccessKeyFile       string
+	storageSecretAccessKeyFile string
 }
 
 func NewTestReportCommand() *TestReportCommand {
@@ -54,6 +106,12 @@ func (b *TestReportCommand) Name() string {
 func (b *TestReportCommand) SetFlags(fs *cli.FlagSet) {
 	fs.String("event-binary-file", "The path of the event file").Var(&b.eventBinaryFile)
 	fs.Duration("startup-timeout", "Time of of start-up").Var(&b.startUpTimeout)
+	fs.StringArray("artifact", "The label or the glob pattern of the outputs which are uploaded when the build succeeded").Var(&b.artifacts)
+	fs.String("artifact-prefix", "The prefix of the object name of the artifacts").Var(&b.artifactPrefix)
+	fs.String("storage-endpoint", "The endpoint of the object storage for the artifacts").Var(&b.storageEndpoint)
+	fs.String("storage-bucket", "The bucket name of the artifacts").Var(&b.storageBucket)
+	fs.String("storage-access-key-file", "The file path that contains the access key").Var(&b.storageAccessKeyFile)
+	fs.String("storage-secret-access-key-file", "The file path that contains the secret access key").Var(&b.storageSecretAccessKeyFile)
 }
 
 type testDuration struct {
@@ -64,7 +122,7 @@ type testDuration struct {
 	Start    time.Time
 }
 
-func (b *TestReportCommand) Run(_ context.Context) error {
+func (b *TestReportCommand) Run(ctx context.Context) error {
 	ch := make(chan struct{})
 	go func() {
 		for {
@@ -97,21 +155,59 @@ func (b *TestReportCommand) Run(_ context.Context) error {
 		return err
 	}
 
+	report, err := readBuildEvents(r)
+	if err != nil {
+		return err
+	}
+
+	// The report is written even if the artifacts could not be uploaded.
+	// Otherwise, the result of the build will be lost.
+	publishErr := b.publishArtifacts(ctx, report)
+	if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
+		return err
+	}
+	return publishErr
+}
+
+// publishArtifacts uploads the artifacts if the build succeeded.
+func (b *TestReportCommand) publishArtifacts(ctx context.Context, report *BuildReport) error {
+	if len(b.artifacts) == 0 {
+		return nil
+	}
+	if report.Metrics == nil || report.Metrics.ExitCodeName != "SUCCESS" {
+		return nil
+	}
+
+	s, err := newArtifactStorage(b.storageEndpoint, b.storageBucket, b.storageAccessKeyFile, b.storageSecretAccessKeyFile)
+	if err != nil {
+		return err
+	}
+	artifacts, err := uploadArtifacts(ctx, s, report.outputs, b.artifacts, b.artifactPrefix)
+	if err != nil {
+		return err
+	}
+	report.Artifacts = artifacts
+	return nil
+}
+
+// readBuildEvents reads the build event stream until the last message and
+// summarizes it into BuildReport.
+func readBuildEvents(r protodelim.Reader) (*BuildReport, error) {
+	report := &BuildReport{}
 	summaries := make(map[string]*testDuration)
+	namedSets := make(map[string]*buildeventstream.NamedSetOfFiles)
+	var targetOutputs []targetFileSets
 	var msg buildeventstream.BuildEvent
-Read:
 	for {
 		err := protodelim.UnmarshalFrom(r, &msg)
 		if errors.Is(err, io.EOF) {
 			break
 		}
 		if err != nil {
-			return xerrors.WithStack(err)
+			return nil, xerrors.WithStack(err)
 		}
 
 		switch v := msg.Id.Id.(type) {
-		case *buildeventstream.BuildEventId_BuildFinished:
-			break Read
 		case *buildeventstream.BuildEventId_TestResult:
 			payload := msg.Payload.(*buildeventstream.BuildEvent_TestResult)
 			if _, ok := summaries[v.TestResult.Label]; !ok {
@@ -126,8 +222,94 @@ Read:
 			summaries[v.TestSummary.Label].Duration = payload.TestSummary.TotalRunDuration.AsDuration()
 			summaries[v.TestSummary.Label].Start = payload.TestSummary.FirstStartTime.AsTime().Local()
 			summaries[v.TestSummary.Label].Status = payload.TestSummary.OverallStatus
+		case *buildeventstream.BuildEventId_TargetCompleted:
+			// The aspects applied to the target are reported as a separated event.
+			if v.TargetCompleted.Aspect != "" {
+				break
+			}
+			switch payload := msg.Payload.(type) {
+			case *buildeventstream.BuildEvent_Completed:
+				if payload.Completed.Success {
+					for _, g := range payload.Completed.OutputGroup {
+						if g.Name != "default" {
+							continue
+						}
+						t := targetFileSets{Label: v.TargetCompleted.Label}
+						for _, s := range g.FileSets {
+							t.FileSets = append(t.FileSets, s.Id)
+						}
+						targetOutputs = append(targetOutputs, t)
+					}
+				}
+				category, message := failureDetail(payload.Completed.FailureDetail)
+				report.Targets = append(report.Targets, TargetResult{
+					Label:           v.TargetCompleted.Label,
+					Success:         payload.Completed.Success,
+					FailureCategory: category,
+					FailureMessage:  message,
+				})
+			case *buildeventstream.BuildEvent_Aborted:
+				report.Targets = append(report.Targets, TargetResult{
+					Label:           v.TargetCompleted.Label,
+					FailureCategory: "aborted." + payload.Aborted.Reason.String(),
+					FailureMessage:  payload.Aborted.Description,
+				})
+			}
+		case *buildeventstream.BuildEventId_NamedSet:
+			if payload, ok := msg.Payload.(*buildeventstream.BuildEvent_NamedSetOfFiles); ok {
+				namedSets[v.NamedSet.Id] = payload.NamedSetOfFiles
+			}
+		case *buildeventstream.BuildEventId_ActionCompleted:
+			payload, ok := msg.Payload.(*buildeventstream.BuildEvent_Action)
+			if !ok || payload.Action.Success {
+				break
+			}
+			category, message := failureDetail(payload.Action.FailureDetail)
+			report.ActionFailures = append(report.ActionFailures, ActionFailure{
+				Label:           v.ActionCompleted.Label,
+				Mnemonic:        payload.Action.Type,
+				ExitCode:        int(payload.Action.ExitCode),
+				FailureCategory: category,
+				FailureMessage:  message,
+				PrimaryOutput:   v.ActionCompleted.PrimaryOutput,
+			})
+		case *buildeventstream.BuildEventId_BuildFinished:
+			payload, ok := msg.Payload.(*buildeventstream.BuildEvent_Finished)
+			if !ok {
+				break
+			}
+			if report.Metrics == nil {
+				report.Metrics = &BuildMetrics{}
+			}
+			report.Metrics.ExitCodeName = payload.Finished.GetExitCode().GetName()
+		case *buildeventstream.BuildEventId_BuildMetrics:
+			payload, ok := msg.Payload.(*buildeventstream.BuildEvent_BuildMetrics)
+			if !ok {
+				break
+			}
+			if report.Metrics == nil {
+				report.Metrics = &BuildMetrics{}
+			}
+			m := payload.BuildMetrics
+			report.Metrics.ActionsCreated = m.GetActionSummary().GetActionsCreated()
+			report.Metrics.ActionsExecuted = m.GetActionSummary().GetActionsExecuted()
+			report.Metrics.ActionCacheHits = int(m.GetActionSummary().GetActionCacheStatistics().GetHits())
+			report.Metrics.ActionCacheMisses = int(m.GetActionSummary().GetActionCacheStatistics().GetMisses())
+			report.Metrics.RemoteCacheHits = remoteCacheHits(m.GetActionSummary())
+			report.Metrics.TargetsConfigured = m.GetTargetMetrics().GetTargetsConfigured()
+			report.Metrics.WallTimeMs = m.GetTimingMetrics().GetWallTimeInMs()
+			report.Metrics.CpuTimeMs = m.GetTimingMetrics().GetCpuTimeInMs()
+			report.Metrics.AnalysisPhaseTimeMs = m.GetTimingMetrics().GetAnalysisPhaseTimeInMs()
+			report.Metrics.ExecutionPhaseTimeMs = m.GetTimingMetrics().GetExecutionPhaseTimeInMs()
+		}
+
+		// BuildMetrics is reported after BuildFinished.
+		// Therefore, we have to read the stream until the last message.
+		if msg.LastMessage {
+			break
 		}
 	}
+
 	result := make([]*testDuration, 0, len(summaries))
 	for _, v := range summaries {
 		if v.Cached {
@@ -136,7 +318,6 @@ Read:
 		result = append(result, v)
 	}
 	sort.Slice(result, func(i, j int) bool { return result[i].Label < result[j].Label })
-	var report TestReport
 	for _, v := range result {
 		status := TestStatusFailed
 		switch v.Status {
@@ -147,9 +328,43 @@ Read:
 		}
 		report.Tests = append(report.Tests, TestSummary{Label: v.Label, Status: status, Duration: v.Duration.Milliseconds(), StartAt: v.Start})
 	}
+	sort.Slice(report.Targets, func(i, j int) bool { return report.Targets[i].Label < report.Targets[j].Label })
+	report.outputs = resolveOutputs(targetOutputs, namedSets)
 
-	if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
-		return err
+	return report, nil
+}
+
+// remoteCacheHits returns the number of actions which are served by the remote cache.
+// RemoteCacheHits of ActionSummary is deprecated, so the runner count is preferred.
+func remoteCacheHits(s *buildeventstream.BuildMetrics_ActionSummary) int64 {
+	for _, v := range s.GetRunnerCount() {
+		if v.GetName() == "remote cache hit" {
+			return int64(v.GetCount())
+		}
 	}
-	return nil
+	return s.GetRemoteCacheHits()
+}
+
+// failureDetail returns the category and the message of FailureDetail.
+// The category is formatted as "<category>.<code>" (e.g. "spawn.NON_ZERO_EXIT").
+func failureDetail(d *devtools.FailureDetail) (string, string) {
+	if d == nil {
+		return "", ""
+	}
+
+	m := d.ProtoReflect()
+	f := m.WhichOneof(m.Descriptor().Oneofs().ByName("category"))
+	if f == nil {
+		return "", d.GetMessage()
+	}
+	category := string(f.Name())
+	if f.Message() != nil {
+		if code := f.Message().Fields().ByName("code"); code != nil && code.Enum() != nil {
+			v := m.Get(f).Message().Get(code).Enum()
+			if ev := code.Enum().Values().ByNumber(v); ev != nil {
+				category += "." + string(ev.Name())
+			}
+		}
+	}
+	return category, d.GetMessage()
 }
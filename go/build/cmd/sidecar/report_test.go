@@ -0,0 +1,105 @@
+package sidecar
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protodelim"
+
+	"go.f110.dev/mono/go/bazel/buildeventstream"
+	"go.f110.dev/mono/go/bazel/devtools"
+)
+
+func TestReadBuildEvents(t *testing.T) {
+	events := []*buildeventstream.BuildEvent{
+		{
+			Id: &buildeventstream.BuildEventId{Id: &buildeventstream.BuildEventId_TargetCompleted{
+				TargetCompleted: &buildeventstream.BuildEventId_TargetCompletedId{Label: "//foo:bar"},
+			}},
+			Payload: &buildeventstream.BuildEvent_Completed{Completed: &buildeventstream.TargetComplete{Success: true}},
+		},
+		{
+			Id: &buildeventstream.BuildEventId{Id: &buildeventstream.BuildEventId_ActionCompleted{
+				ActionCompleted: &buildeventstream.BuildEventId_ActionCompletedId{Label: "//foo:baz", PrimaryOutput: "bazel-out/k8-fastbuild/bin/foo/baz.a"},
+			}},
+			Payload: &buildeventstream.BuildEvent_Action{Action: &buildeventstream.ActionExecuted{
+				Type:     "GoCompilePkg",
+				ExitCode: 1,
+				FailureDetail: &devtools.FailureDetail{
+					Message:  "compile failed",
+					Category: &devtools.FailureDetail_Spawn{Spawn: &devtools.Spawn{Code: devtools.Spawn_NON_ZERO_EXIT}},
+				},
+			}},
+		},
+		{
+			Id: &buildeventstream.BuildEventId{Id: &buildeventstream.BuildEventId_TargetCompleted{
+				TargetCompleted: &buildeventstream.BuildEventId_TargetCompletedId{Label: "//foo:baz"},
+			}},
+			Payload: &buildeventstream.BuildEvent_Completed{Completed: &buildeventstream.TargetComplete{
+				FailureDetail: &devtools.FailureDetail{
+					Message:  "compile failed",
+					Category: &devtools.FailureDetail_Spawn{Spawn: &devtools.Spawn{Code: devtools.Spawn_NON_ZERO_EXIT}},
+				},
+			}},
+		},
+		{
+			Id: &buildeventstream.BuildEventId{Id: &buildeventstream.BuildEventId_BuildFinished{BuildFinished: &buildeventstream.BuildEventId_BuildFinishedId{}}},
+			Payload: &buildeventstream.BuildEvent_Finished{Finished: &buildeventstream.BuildFinished{
+				ExitCode: &buildeventstream.BuildFinished_ExitCode{Name: "BUILD_FAILURE", Code: 1},
+			}},
+		},
+		{
+			Id: &buildeventstream.BuildEventId{Id: &buildeventstream.BuildEventId_BuildMetrics{BuildMetrics: &buildeventstream.BuildEventId_BuildMetricsId{}}},
+			Payload: &buildeventstream.BuildEvent_BuildMetrics{BuildMetrics: &buildeventstream.BuildMetrics{
+				ActionSummary: &buildeventstream.BuildMetrics_ActionSummary{
+					ActionsCreated:  20,
+					ActionsExecuted: 10,
+					RunnerCount: []*buildeventstream.BuildMetrics_ActionSummary_RunnerCount{
+						{Name: "total", Count: 10},
+						{Name: "remote cache hit", Count: 7},
+					},
+					ActionCacheStatistics: &devtools.ActionCacheStatistics{Hits: 3, Misses: 10},
+				},
+				TimingMetrics: &buildeventstream.BuildMetrics_TimingMetrics{WallTimeInMs: 1000, CpuTimeInMs: 2000},
+				TargetMetrics: &buildeventstream.BuildMetrics_TargetMetrics{TargetsConfigured: 5},
+			}},
+			LastMessage: true,
+		},
+	}
+	buf := new(bytes.Buffer)
+	for _, v := range events {
+		_, err := protodelim.MarshalTo(buf, v)
+		require.NoError(t, err)
+	}
+
+	report, err := readBuildEvents(buf)
+	require.NoError(t, err)
+	assert.Equal(t, []TargetResult{
+		{Label: "//foo:bar", Success: true},
+		{Label: "//foo:baz", FailureCategory: "spawn.NON_ZERO_EXIT", FailureMessage: "compile failed"},
+	}, report.Targets)
+	assert.Equal(t, []ActionFailure{
+		{
+			Label:           "//foo:baz",
+			Mnemonic:        "GoCompilePkg",
+			ExitCode:        1,
+			FailureCategory: "spawn.NON_ZERO_EXIT",
+			FailureMessage:  "compile failed",
+			PrimaryOutput:   "bazel-out/k8-fastbuild/bin/foo/baz.a",
+		},
+	}, report.ActionFailures)
+	require.NotNil(t, report.Metrics)
+	assert.Equal(t, BuildMetrics{
+		ExitCodeName:      "BUILD_FAILURE",
+		ActionsCreated:    20,
+		ActionsExecuted:   10,
+		RemoteCacheHits:   7,
+		ActionCacheHits:   3,
+		ActionCacheMisses: 10,
+		TargetsConfigured: 5,
+		WallTimeMs:        1000,
+		CpuTimeMs:         2000,
+	}, *report.Metrics)
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"strings"
+	"text/template"
+
+	"go.f110.dev/xerrors"
+)
+
+// ConcurrencyPolicy puts the tasks which have the same group key into a group.
+// Only one task of the group runs at the same time.
+type ConcurrencyPolicy struct {
+	// Group is the template of the group key. The template is rendered with SourceRef.
+	// e.g. "{{ .Repository }}/{{ .Job }}/{{ .PullRequest }}"
+	Group string `yaml:"group" json:"group"`
+	// CancelInProgress stops the running tasks of the group when a newer revision arrives.
+	// If false, the newer task waits until the running task finishes.
+	CancelInProgress bool `yaml:"cancel_in_progress,omitempty" json:"cancel_in_progress,omitempty"`
+}
+
+// SourceRef is the ref which triggers the build.
+type SourceRef struct {
+	// Repository is the name of the repository (owner/name).
+	Repository string
+	Job        string
+	// Branch is the name of the branch. In the case of the pull request, it is the head branch.
+	Branch string
+	// PullRequest is the number of the pull request. Zero if the build isn't triggered by the pull request.
+	PullRequest int
+}
+
+// GroupKey returns the key of the concurrency group of ref.
+// An empty string is returned if the policy is not set.
+func (c *ConcurrencyPolicy) GroupKey(ref SourceRef) (string, error) {
+	if c == nil || c.Group == "" {
+		return "", nil
+	}
+
+	tmpl, err := template.New("").Option("missingkey=error").Parse(c.Group)
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	buf := new(strings.Builder)
+	if err := tmpl.Execute(buf, ref); err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	return buf.String(), nil
+}
+
+func validateConcurrency(jobName string, c *ConcurrencyPolicy) error {
+	if c == nil {
+		return nil
+	}
+	if _, err := c.GroupKey(SourceRef{}); err != nil {
+		return xerrors.Definef("invalid concurrency group at %s: %v", jobName, err).WithStack()
+	}
+	return nil
+}
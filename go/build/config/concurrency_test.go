@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyPolicy_GroupKey(t *testing.T) {
+	policy := &ConcurrencyPolicy{Group: "{{ .Repository }}/{{ .Job }}/{{ if .PullRequest }}pr-{{ .PullRequest }}{{ else }}{{ .Branch }}{{ end }}"}
+
+	key, err := policy.GroupKey(SourceRef{Repository: "f110/mono", Job: "test", Branch: "feature", PullRequest: 12})
+	require.NoError(t, err)
+	assert.Equal(t, "f110/mono/test/pr-12", key)
+
+	key, err = policy.GroupKey(SourceRef{Repository: "f110/mono", Job: "test", Branch: "master"})
+	require.NoError(t, err)
+	assert.Equal(t, "f110/mono/test/master", key)
+
+	var nilPolicy *ConcurrencyPolicy
+	key, err = nilPolicy.GroupKey(SourceRef{Repository: "f110/mono"})
+	require.NoError(t, err)
+	assert.Empty(t, key)
+
+	_, err = (&ConcurrencyPolicy{Group: "{{ .Unknown }}"}).GroupKey(SourceRef{})
+	assert.Error(t, err)
+}
@@ -20,6 +20,7 @@ import (
 func init() {
 	gob.Register(&Secret{})
 	gob.Register(&RegistrySecret{})
+	gob.Register(&Matrix{})
 }
 
 //go:embed config.star
@@ -50,6 +51,40 @@ type ExternalReleaseSource struct {
 	IncludePrerelease bool                      `yaml:"include_prerelease,omitempty" json:"include_prerelease,omitempty"`
 }
 
+type RetryCondition string
+
+const (
+	// RetryOnEviction retries the task if the pod was evicted or the build container was OOMKilled.
+	RetryOnEviction RetryCondition = "eviction"
+	// RetryOnExitCode retries the task if bazel exited with non-zero code except the test failure.
+	RetryOnExitCode RetryCondition = "exit_code"
+	// RetryOnTestFailure retries the task if the build succeeded but some tests failed.
+	RetryOnTestFailure RetryCondition = "test_failure"
+)
+
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts including the first one.
+	MaxAttempts int              `yaml:"max_attempts" json:"max_attempts"`
+	On          []RetryCondition `yaml:"on" json:"on"`
+	// ExitCodes limits the exit codes of bazel which are retried by RetryOnExitCode.
+	// If empty, any non-zero exit code is retried.
+	ExitCodes []int `yaml:"exit_codes,omitempty" json:"exit_codes,omitempty"`
+}
+
+// ShouldRetry returns true if the task which failed by cond at attempt has to be retried.
+func (r *RetryPolicy) ShouldRetry(attempt int, cond RetryCondition, exitCode int) bool {
+	if r == nil || attempt >= r.MaxAttempts {
+		return false
+	}
+	if !slices.Contains(r.On, cond) {
+		return false
+	}
+	if cond == RetryOnExitCode && len(r.ExitCodes) > 0 {
+		return slices.Contains(r.ExitCodes, exitCode)
+	}
+	return true
+}
+
 type Config struct {
 	Jobs            []*JobV2
 	BazelVersion    string
@@ -158,6 +193,13 @@ type Job struct {
 	Schedule string           `attr:"schedule,allowempty"`
 	Secrets  []starlark.Value `attr:"secrets,allowempty"`
 	Env      map[string]any   `attr:"env,allowempty"`
+	// The names of jobs which have to succeed before this job
+	Needs []string `attr:"needs,allowempty"`
+	// Glob patterns of the files which trigger this job
+	Paths       []string `attr:"paths,allowempty"`
+	PathsIgnore []string `attr:"paths_ignore,allowempty"`
+	// The matrix of the bazel versions, configs, platforms and env
+	Matrix starlark.Value `attr:"matrix,allowempty"`
 
 	RepositoryOwner string
 	RepositoryName  string
@@ -183,6 +225,10 @@ func (j *Job) ToV2() *JobV2 {
 			})
 		}
 	}
+	var matrix *Matrix
+	if m, ok := j.Matrix.(*Matrix); ok {
+		matrix = m
+	}
 	return &JobV2{
 		Name:            j.Name,
 		Event:           j.Event,
@@ -200,6 +246,10 @@ func (j *Job) ToV2() *JobV2 {
 		Schedule:        j.Schedule,
 		Env:             j.Env,
 		Secrets:         secrets,
+		Needs:           j.Needs,
+		Paths:           j.Paths,
+		PathsIgnore:     j.PathsIgnore,
+		Matrix:          matrix,
 		RepositoryOwner: j.RepositoryOwner,
 		RepositoryName:  j.RepositoryName,
 	}
@@ -263,6 +313,12 @@ func (j *Job) IsValid() error {
 	if j.Args != nil && j.Command != "run" {
 		return xerrors.Definef("specifying argument is not allowed in %s command", j.Command).WithStack()
 	}
+	if err := validatePathPatterns(j.Name, j.Paths); err != nil {
+		return err
+	}
+	if err := validatePathPatterns(j.Name, j.PathsIgnore); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -377,6 +433,7 @@ func Read(r io.Reader, owner, repo string) (*Config, error) {
 		}
 		return s, nil
 	})
+	mod["matrix"] = starlark.NewBuiltin("matrix", newMatrix)
 	mod["registry_secret"] = starlark.NewBuiltin("registry_secret", func(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
 		s := &RegistrySecret{}
 		err := starlark.UnpackArgs(fn.Name(), args, kwargs, argPairs(s)...)
@@ -390,6 +447,9 @@ func Read(r io.Reader, owner, repo string) (*Config, error) {
 	if err != nil {
 		return nil, xerrors.WithStack(err)
 	}
+	if err := ValidateDependencies(config.Jobs); err != nil {
+		return nil, err
+	}
 	return config, nil
 }
 
@@ -457,6 +517,9 @@ func setValue(ft reflect.Type, fv reflect.Value, val starlark.Value) error {
 		}
 		fv.Set(reflect.ValueOf(m))
 		return nil
+	case reflect.Interface:
+		fv.Set(reflect.ValueOf(val))
+		return nil
 	}
 
 	return xerrors.Definef("unsupported field type: %s", ft.Kind()).WithStack()
@@ -525,11 +588,32 @@ type JobV2 struct {
 	// CacheTestResults allows reusing cached test results. By default the builder passes --cache_test_results=no.
 	// This option is only valid when the command is test.
 	CacheTestResults bool `yaml:"cache_test_results,omitempty" json:"cache_test_results,omitempty"`
+	// IgnoreQuarantinedTests ignores the failures of the tests which are quarantined as flaky.
+	// The quarantined tests still run so that their flakiness can recover. The task succeeds if only they fail.
+	// This option is only valid when the command is test.
+	IgnoreQuarantinedTests bool `yaml:"ignore_quarantined_tests,omitempty" json:"ignore_quarantined_tests,omitempty"`
 	// Job schedule
 	Schedule       string                 `yaml:"schedule,omitempty" json:"schedule,omitempty"`
 	Secrets        []*Secret              `yaml:"secrets,omitempty" json:"secrets,omitempty"`
 	Env            map[string]any         `yaml:"env,omitempty" json:"env,omitempty"`
 	ExternalSource *ExternalReleaseSource `yaml:"external_source,omitempty" json:"external_source,omitempty"`
+	// Needs is the list of job names which have to succeed at the same revision before this job starts.
+	// If any of them fails, this job is skipped.
+	Needs []string `yaml:"needs,omitempty" json:"needs,omitempty"`
+	// Paths is the list of glob patterns of the files. The job is triggered by push and pull_request only if
+	// any changed file matches one of them. "**" matches zero or more directories.
+	Paths []string `yaml:"paths,omitempty" json:"paths,omitempty"`
+	// PathsIgnore is the list of glob patterns of the files which don't trigger the job.
+	PathsIgnore []string `yaml:"paths_ignore,omitempty" json:"paths_ignore,omitempty"`
+	// Retry is the policy for retrying a failed task automatically.
+	Retry *RetryPolicy `yaml:"retry,omitempty" json:"retry,omitempty"`
+	// Artifacts is the list of the outputs which are published when the task succeeded.
+	// An entry is either a label of the target (e.g. "//cmd/foo") or a glob pattern of the path relative to bazel-bin.
+	Artifacts []string `yaml:"artifacts,omitempty" json:"artifacts,omitempty"`
+	// Concurrency limits the tasks which run at the same time by the group key.
+	Concurrency *ConcurrencyPolicy `yaml:"concurrency,omitempty" json:"concurrency,omitempty"`
+	// Matrix expands the job into the tasks of all combinations of the bazel versions, configs, platforms and env.
+	Matrix *Matrix `yaml:"matrix,omitempty" json:"matrix,omitempty"`
 
 	RepositoryOwner string `yaml:"-" json:"-"`
 	RepositoryName  string `yaml:"-" json:"-"`
@@ -118,3 +118,16 @@ func TestUnmarshalJob(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "test_all", decodedJobV2.Name)
 }
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, On: []RetryCondition{RetryOnEviction, RetryOnExitCode}, ExitCodes: []int{34, 36}}
+
+	assert.True(t, policy.ShouldRetry(1, RetryOnEviction, 0))
+	assert.True(t, policy.ShouldRetry(2, RetryOnExitCode, 34))
+	assert.False(t, policy.ShouldRetry(3, RetryOnEviction, 0), "exceeds max attempts")
+	assert.False(t, policy.ShouldRetry(1, RetryOnExitCode, 1), "exit code is not listed")
+	assert.False(t, policy.ShouldRetry(1, RetryOnTestFailure, 3), "condition is not listed")
+
+	var nilPolicy *RetryPolicy
+	assert.False(t, nilPolicy.ShouldRetry(1, RetryOnEviction, 0))
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"slices"
+	"strings"
+
+	"go.f110.dev/xerrors"
+)
+
+// ValidateDependencies checks the dependency graph declared by Needs.
+// It returns an error if a job depends on an unknown job, itself or if the graph has a cycle.
+func ValidateDependencies(jobs []*JobV2) error {
+	byName := make(map[string]*JobV2, len(jobs))
+	for _, j := range jobs {
+		byName[j.Name] = j
+	}
+	for _, j := range jobs {
+		for _, n := range j.Needs {
+			if n == j.Name {
+				return xerrors.Definef("%s depends on itself", j.Name).WithStack()
+			}
+			if _, ok := byName[n]; !ok {
+				return xerrors.Definef("%s depends on unknown job: %s", j.Name, n).WithStack()
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(jobs))
+	var path []string
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			i := slices.Index(path, name)
+			return xerrors.Definef("dependency cycle detected: %s", strings.Join(append(path[i:], name), " -> ")).WithStack()
+		case visited:
+			return nil
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, n := range byName[name].Needs {
+			if err := visit(n); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+		return nil
+	}
+	for _, j := range jobs {
+		if err := visit(j.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SortByDependency returns jobs ordered so that every job comes after the jobs it needs.
+// The order of independent jobs is preserved. The dependencies which are not included in jobs are ignored.
+// jobs must not have a dependency cycle.
+func SortByDependency(jobs []*JobV2) []*JobV2 {
+	byName := make(map[string]*JobV2, len(jobs))
+	for _, j := range jobs {
+		byName[j.Name] = j
+	}
+
+	sorted := make([]*JobV2, 0, len(jobs))
+	added := make(map[string]struct{}, len(jobs))
+	var add func(j *JobV2)
+	add = func(j *JobV2) {
+		if _, ok := added[j.Name]; ok {
+			return
+		}
+		added[j.Name] = struct{}{}
+		for _, n := range j.Needs {
+			if upstream, ok := byName[n]; ok {
+				add(upstream)
+			}
+		}
+		sorted = append(sorted, j)
+	}
+	for _, j := range jobs {
+		add(j)
+	}
+
+	return sorted
+}
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"testing"
+
+	"go.f110.dev/mono/go/enumerable"
+	"go.f110.dev/mono/go/testing/assertion"
+)
+
+func TestSortByDependency(t *testing.T) {
+	jobs := []*JobV2{
+		{Name: "push", Needs: []string{"build", "test"}},
+		{Name: "lint"},
+		{Name: "build", Needs: []string{"test"}},
+		{Name: "test"},
+		{Name: "deploy", Needs: []string{"release"}},
+	}
+
+	sorted := SortByDependency(jobs)
+	assertion.Equal(t, []string{"test", "build", "push", "lint", "deploy"}, enumerable.Map(sorted, func(j *JobV2) string { return j.Name }))
+}
@@ -0,0 +1,228 @@
+package config
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"hash/crc32"
+	"maps"
+	"slices"
+	"strings"
+
+	"go.f110.dev/xerrors"
+	"go.starlark.net/starlark"
+)
+
+const (
+	MatrixAxisBazelVersion = "bazel_version"
+	MatrixAxisConfig       = "config"
+	MatrixAxisPlatform     = "platform"
+	MatrixAxisEnv          = "env"
+)
+
+// Matrix expands a job into the tasks of all combinations of the axes.
+// The axis which is not set is not expanded and the value of the job is used.
+type Matrix struct {
+	BazelVersions []string `yaml:"bazel_versions,omitempty" json:"bazel_versions,omitempty"`
+	// Configs is the list of the names of config which are passed by --config.
+	Configs   []string `yaml:"configs,omitempty" json:"configs,omitempty"`
+	Platforms []string `yaml:"platforms,omitempty" json:"platforms,omitempty"`
+	// Env is the list of the sets of the environment variables.
+	// Each set is added to the env of the job.
+	Env []map[string]string `yaml:"env,omitempty" json:"env,omitempty"`
+}
+
+var _ starlark.Value = (*Matrix)(nil)
+
+func (m *Matrix) String() string {
+	return fmt.Sprintf("matrix(bazel_versions=%v, configs=%v, platforms=%v, env=%v)", m.BazelVersions, m.Configs, m.Platforms, m.Env)
+}
+
+func (m *Matrix) Type() string {
+	return "matrix"
+}
+
+func (m *Matrix) Freeze() {}
+
+func (m *Matrix) Truth() starlark.Bool {
+	return true
+}
+
+func (m *Matrix) Hash() (uint32, error) {
+	buf := new(bytes.Buffer)
+	if err := gob.NewEncoder(buf).Encode(m); err != nil {
+		return 0, err
+	}
+	return crc32.ChecksumIEEE(buf.Bytes()), nil
+}
+
+// MatrixEntry is a combination of the values of the axes.
+type MatrixEntry struct {
+	BazelVersion string
+	ConfigName   string
+	Platform     string
+	Env          map[string]string
+	// Axes is the values of the axes which are expanded by the matrix.
+	Axes map[string]string
+}
+
+// Expand returns all combinations of the axes.
+// bazelVersion, configName and platforms are used for the axes which are not set in the matrix.
+// If m is nil, the entries of platforms are returned.
+func (m *Matrix) Expand(bazelVersion, configName string, platforms []string) []MatrixEntry {
+	bazelVersions, configs, envs := []string{bazelVersion}, []string{configName}, []map[string]string{nil}
+	if m != nil {
+		if len(m.BazelVersions) > 0 {
+			bazelVersions = m.BazelVersions
+		}
+		if len(m.Configs) > 0 {
+			configs = m.Configs
+		}
+		if len(m.Platforms) > 0 {
+			platforms = m.Platforms
+		}
+		if len(m.Env) > 0 {
+			envs = m.Env
+		}
+	}
+
+	var entries []MatrixEntry
+	for _, v := range bazelVersions {
+		for _, c := range configs {
+			for _, p := range platforms {
+				for _, e := range envs {
+					entry := MatrixEntry{BazelVersion: v, ConfigName: c, Platform: p, Env: e}
+					if m != nil {
+						entry.Axes = m.axes(entry)
+					}
+					entries = append(entries, entry)
+				}
+			}
+		}
+	}
+	return entries
+}
+
+func (m *Matrix) axes(e MatrixEntry) map[string]string {
+	axes := make(map[string]string)
+	if len(m.BazelVersions) > 0 {
+		axes[MatrixAxisBazelVersion] = e.BazelVersion
+	}
+	if len(m.Configs) > 0 {
+		axes[MatrixAxisConfig] = e.ConfigName
+	}
+	if len(m.Platforms) > 0 {
+		axes[MatrixAxisPlatform] = e.Platform
+	}
+	if len(m.Env) > 0 {
+		var env []string
+		for _, k := range slices.Sorted(maps.Keys(e.Env)) {
+			env = append(env, k+"="+e.Env[k])
+		}
+		axes[MatrixAxisEnv] = strings.Join(env, " ")
+	}
+	return axes
+}
+
+// StatusContext returns the context of the commit status of GitHub for the matrix cell which has axes.
+// The values of axes are appended to the context so that each cell has its own status.
+func StatusContext(command, jobName string, axes map[string]string) string {
+	c := fmt.Sprintf("%s %s", command, jobName)
+	if len(axes) == 0 {
+		return c
+	}
+	var values []string
+	for _, k := range slices.Sorted(maps.Keys(axes)) {
+		values = append(values, fmt.Sprintf("%s=%s", k, axes[k]))
+	}
+	return fmt.Sprintf("%s (%s)", c, strings.Join(values, ", "))
+}
+
+// ForMatrixEntry returns the copy of the job for e.
+// The matrix of the copy has only the values of e. So the copy is expanded into e again.
+func (j *JobV2) ForMatrixEntry(e MatrixEntry) *JobV2 {
+	n := j.Copy()
+	n.ConfigName = e.ConfigName
+	n.Platforms = []string{e.Platform}
+	if len(e.Env) > 0 {
+		n.Env = make(map[string]any, len(j.Env)+len(e.Env))
+		maps.Copy(n.Env, j.Env)
+		for k, v := range e.Env {
+			n.Env[k] = v
+		}
+	}
+	if j.Matrix != nil {
+		m := &Matrix{}
+		if len(j.Matrix.BazelVersions) > 0 {
+			m.BazelVersions = []string{e.BazelVersion}
+		}
+		if len(j.Matrix.Configs) > 0 {
+			m.Configs = []string{e.ConfigName}
+		}
+		if len(j.Matrix.Platforms) > 0 {
+			m.Platforms = []string{e.Platform}
+		}
+		if len(j.Matrix.Env) > 0 {
+			m.Env = []map[string]string{e.Env}
+		}
+		n.Matrix = m
+	}
+	return n
+}
+
+// newMatrix is the implementation of the matrix function of Starlark.
+func newMatrix(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var bazelVersions, configs, platforms, env *starlark.List
+	err := starlark.UnpackArgs(fn.Name(), args, kwargs,
+		"bazel_versions?", &bazelVersions,
+		"configs?", &configs,
+		"platforms?", &platforms,
+		"env?", &env,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Matrix{}
+	for _, v := range []struct {
+		list *starlark.List
+		dst  *[]string
+	}{
+		{list: bazelVersions, dst: &m.BazelVersions},
+		{list: configs, dst: &m.Configs},
+		{list: platforms, dst: &m.Platforms},
+	} {
+		if v.list == nil {
+			continue
+		}
+		for i := range v.list.Len() {
+			s, ok := v.list.Index(i).(starlark.String)
+			if !ok {
+				return nil, xerrors.Definef("expect starlark.String: %T", v.list.Index(i)).WithStack()
+			}
+			*v.dst = append(*v.dst, s.GoString())
+		}
+	}
+	if env != nil {
+		for i := range env.Len() {
+			d, ok := env.Index(i).(*starlark.Dict)
+			if !ok {
+				return nil, xerrors.Definef("expect *starlark.Dict: %T", env.Index(i)).WithStack()
+			}
+			e := make(map[string]string)
+			for _, t := range d.Items() {
+				k, ok := t.Index(0).(starlark.String)
+				if !ok {
+					return nil, xerrors.Definef("the type of the key is not string: %T", t.Index(0)).WithStack()
+				}
+				v, ok := t.Index(1).(starlark.String)
+				if !ok {
+					return nil, xerrors.Definef("the type of the value is not string: %T", t.Index(1)).WithStack()
+				}
+				e[k.GoString()] = v.GoString()
+			}
+			m.Env = append(m.Env, e)
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatrix_Expand(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		var m *Matrix
+		entries := m.Expand("7.4.0", "ci", []string{"linux_amd64", "linux_arm64"})
+		assert.Equal(t, []MatrixEntry{
+			{BazelVersion: "7.4.0", ConfigName: "ci", Platform: "linux_amd64"},
+			{BazelVersion: "7.4.0", ConfigName: "ci", Platform: "linux_arm64"},
+		}, entries)
+	})
+
+	t.Run("Axes", func(t *testing.T) {
+		m := &Matrix{
+			BazelVersions: []string{"7.4.0", "8.0.0"},
+			Env:           []map[string]string{{"CGO_ENABLED": "0"}, {"CGO_ENABLED": "1", "CC": "clang"}},
+		}
+		entries := m.Expand("7.4.0", "ci", []string{"linux_amd64"})
+		require.Len(t, entries, 4)
+		assert.Equal(t, "8.0.0", entries[3].BazelVersion)
+		assert.Equal(t, "ci", entries[3].ConfigName)
+		assert.Equal(t, map[string]string{"bazel_version": "8.0.0", "env": "CC=clang CGO_ENABLED=1"}, entries[3].Axes)
+	})
+}
+
+func TestJobV2_ForMatrixEntry(t *testing.T) {
+	job := &JobV2{
+		Name:      "test",
+		Platforms: []string{"linux_amd64"},
+		Env:       map[string]any{"FOO": "bar"},
+		Matrix: &Matrix{
+			BazelVersions: []string{"7.4.0", "8.0.0"},
+			Configs:       []string{"ci", "race"},
+			Env:           []map[string]string{{"CGO_ENABLED": "0"}, {"CGO_ENABLED": "1"}},
+		},
+	}
+	entries := job.Matrix.Expand("", "", job.Platforms)
+	require.Len(t, entries, 8)
+
+	cell := job.ForMatrixEntry(entries[7])
+	assert.Equal(t, "race", cell.ConfigName)
+	assert.Equal(t, map[string]any{"FOO": "bar", "CGO_ENABLED": "1"}, cell.Env)
+	assert.Equal(t, map[string]any{"FOO": "bar"}, job.Env)
+	// The copy is expanded into the same entry again.
+	assert.Equal(t, []MatrixEntry{entries[7]}, cell.Matrix.Expand("", "", cell.Platforms))
+}
+
+func TestReadMatrix(t *testing.T) {
+	raw := `job(
+    name = "test_all",
+    command = "test",
+    targets = ["//..."],
+    platforms = ["@rules_go//go/toolchain:linux_amd64"],
+    event = ["push"],
+    matrix = matrix(
+        bazel_versions = ["7.4.0", "8.0.0"],
+        env = [{"CGO_ENABLED": "0"}],
+    ),
+)`
+	config, err := Read(strings.NewReader(raw), "", "")
+	require.NoError(t, err)
+	require.Len(t, config.Jobs, 1)
+	assert.Equal(t, &Matrix{BazelVersions: []string{"7.4.0", "8.0.0"}, Env: []map[string]string{{"CGO_ENABLED": "0"}}}, config.Jobs[0].Matrix)
+
+	encoded, err := MarshalJob(config.Jobs[0])
+	require.NoError(t, err)
+	decoded := &JobV2{}
+	require.NoError(t, UnmarshalJobV2(encoded, decoded, "", ""))
+	assert.Equal(t, config.Jobs[0].Matrix, decoded.Matrix)
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"path"
+	"strings"
+
+	"go.f110.dev/xerrors"
+)
+
+// MatchChangedFiles reports whether the job has to run for the change set.
+// A job without Paths and PathsIgnore matches any change.
+// If files is nil, the change set is regarded as unknown and the job matches.
+func (j *JobV2) MatchChangedFiles(files []string) bool {
+	if len(j.Paths) == 0 && len(j.PathsIgnore) == 0 {
+		return true
+	}
+	if files == nil {
+		return true
+	}
+
+	for _, f := range files {
+		if len(j.Paths) > 0 && !matchAnyPath(j.Paths, f) {
+			continue
+		}
+		if matchAnyPath(j.PathsIgnore, f) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func matchAnyPath(patterns []string, name string) bool {
+	for _, p := range patterns {
+		if MatchPath(p, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchPath reports whether name matches the glob pattern.
+// The pattern syntax is the same as path.Match except "**" which matches zero or more directories.
+// A pattern which ends with "/" matches all files under the directory.
+func MatchPath(pattern, name string) bool {
+	pattern = strings.TrimPrefix(pattern, "/")
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(strings.TrimPrefix(name, "/"), "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			pattern = pattern[1:]
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := range name {
+				if matchSegments(pattern, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+func validatePathPatterns(jobName string, patterns []string) error {
+	for _, p := range patterns {
+		for _, s := range strings.Split(strings.TrimPrefix(p, "/"), "/") {
+			if s == "**" {
+				continue
+			}
+			if _, err := path.Match(s, ""); err != nil {
+				return xerrors.Definef("invalid path pattern at %s: %s", jobName, p).WithStack()
+			}
+		}
+	}
+	return nil
+}
+
+// IsLabel reports whether the entry of JobV2.Artifacts is a label of the target instead of a glob pattern.
+func IsLabel(s string) bool {
+	return strings.HasPrefix(s, "//") || strings.HasPrefix(s, "@")
+}
+
+// MatchArtifact reports whether the output of the target matches the entry of JobV2.Artifacts.
+// name is the relative path of the output from bazel-bin.
+func MatchArtifact(pattern, label, name string) bool {
+	if IsLabel(pattern) {
+		return normalizeLabel(pattern) == normalizeLabel(label)
+	}
+	return MatchPath(pattern, name)
+}
+
+// normalizeLabel removes the repository name of the main repository.
+// The build event stream reports labels as "@@//pkg:target" if Bzlmod is enabled.
+func normalizeLabel(label string) string {
+	if l := strings.TrimLeft(label, "@"); strings.HasPrefix(l, "//") {
+		label = l
+	}
+	if i := strings.LastIndex(label, "/"); i != -1 && !strings.Contains(label[i:], ":") {
+		// "//pkg/foo" is the shorthand of "//pkg/foo:foo".
+		label += ":" + label[i+1:]
+	}
+	return label
+}
+
+func validateArtifacts(jobName string, artifacts []string) error {
+	var patterns []string
+	for _, v := range artifacts {
+		if IsLabel(v) {
+			continue
+		}
+		patterns = append(patterns, v)
+	}
+	return validatePathPatterns(jobName, patterns)
+}
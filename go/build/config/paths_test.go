@@ -0,0 +1,81 @@
+package config
+
+import (
+	"testing"
+
+	"go.f110.dev/mono/go/testing/assertion"
+)
+
+func TestMatchPath(t *testing.T) {
+	cases := []struct {
+		Pattern string
+		Name    string
+		Match   bool
+	}{
+		{Pattern: "docs/**", Name: "docs/index.md", Match: true},
+		{Pattern: "docs/**", Name: "docs/a/b/index.md", Match: true},
+		{Pattern: "docs/", Name: "docs/a/index.md", Match: true},
+		{Pattern: "docs/**", Name: "go/docs/index.md", Match: false},
+		{Pattern: "**/*.md", Name: "README.md", Match: true},
+		{Pattern: "**/*.md", Name: "go/build/README.md", Match: true},
+		{Pattern: "**/*.md", Name: "go/build/main.go", Match: false},
+		{Pattern: "go/*/BUILD.bazel", Name: "go/build/BUILD.bazel", Match: true},
+		{Pattern: "go/*/BUILD.bazel", Name: "go/build/config/BUILD.bazel", Match: false},
+		{Pattern: "go/**/BUILD.bazel", Name: "go/build/config/BUILD.bazel", Match: true},
+		{Pattern: "/go.mod", Name: "go.mod", Match: true},
+		{Pattern: "go.mod", Name: "go/go.mod", Match: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Pattern+" "+tc.Name, func(t *testing.T) {
+			assertion.Equal(t, tc.Match, MatchPath(tc.Pattern, tc.Name))
+		})
+	}
+}
+
+func TestJobV2_MatchChangedFiles(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Paths       []string
+		PathsIgnore []string
+		Files       []string
+		Match       bool
+	}{
+		{Name: "No filter", Files: []string{"docs/index.md"}, Match: true},
+		{Name: "Unknown change set", Paths: []string{"go/**"}, Match: true},
+		{Name: "Empty change set", Paths: []string{"go/**"}, Files: []string{}, Match: false},
+		{Name: "Paths match", Paths: []string{"go/**"}, Files: []string{"docs/index.md", "go/main.go"}, Match: true},
+		{Name: "Paths don't match", Paths: []string{"go/**"}, Files: []string{"docs/index.md"}, Match: false},
+		{Name: "All files are ignored", PathsIgnore: []string{"docs/**"}, Files: []string{"docs/index.md"}, Match: false},
+		{Name: "Some files are not ignored", PathsIgnore: []string{"docs/**"}, Files: []string{"docs/index.md", "go/main.go"}, Match: true},
+		{Name: "Ignore has a priority", Paths: []string{"go/**"}, PathsIgnore: []string{"**/*.md"}, Files: []string{"go/README.md"}, Match: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			j := &JobV2{Name: "test", Paths: tc.Paths, PathsIgnore: tc.PathsIgnore}
+			assertion.Equal(t, tc.Match, j.MatchChangedFiles(tc.Files))
+		})
+	}
+}
+
+func TestMatchArtifact(t *testing.T) {
+	cases := []struct {
+		Pattern string
+		Label   string
+		Name    string
+		Match   bool
+	}{
+		{Pattern: "//cmd/foo", Label: "//cmd/foo:foo", Name: "cmd/foo/foo_/foo", Match: true},
+		{Pattern: "//cmd/foo:foo", Label: "@@//cmd/foo:foo", Name: "cmd/foo/foo_/foo", Match: true},
+		{Pattern: "//cmd/foo", Label: "//cmd/foo:image", Name: "cmd/foo/image.tar", Match: false},
+		{Pattern: "cmd/**/*.tar", Label: "//cmd/foo:image", Name: "cmd/foo/image.tar", Match: true},
+		{Pattern: "cmd/**/*.tar", Label: "//cmd/foo:foo", Name: "cmd/foo/foo_/foo", Match: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Pattern+" "+tc.Label, func(t *testing.T) {
+			assertion.Equal(t, tc.Match, MatchArtifact(tc.Pattern, tc.Label, tc.Name))
+		})
+	}
+}
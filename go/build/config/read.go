@@ -346,6 +346,9 @@ func ReadJobsFromBuildDir(fileProvider Provider) ([]*JobV2, error) {
 		allJobs = append(allJobs, jobs...)
 		f.Close()
 	}
+	if err := ValidateDependencies(allJobs); err != nil {
+		return nil, err
+	}
 
 	return allJobs, nil
 }
@@ -393,5 +396,19 @@ func ParseFile(f fs.File) ([]*JobV2, error) {
 	if err := parsed.Decode(&jobs); err != nil {
 		return nil, xerrors.WithStack(err)
 	}
+	for _, j := range jobs {
+		if err := validatePathPatterns(j.Name, j.Paths); err != nil {
+			return nil, err
+		}
+		if err := validatePathPatterns(j.Name, j.PathsIgnore); err != nil {
+			return nil, err
+		}
+		if err := validateArtifacts(j.Name, j.Artifacts); err != nil {
+			return nil, err
+		}
+		if err := validateConcurrency(j.Name, j.Concurrency); err != nil {
+			return nil, err
+		}
+	}
 	return jobs, nil
 }
@@ -72,6 +72,225 @@ func TestParseFile(t *testing.T) {
 	event: ["manual"]
 	platforms: ["linux_amd64"]
 	cache_test_results: true
+}`,
+		},
+		{
+			Name: "Valid: test with ignore_quarantined_tests",
+			File: `jobs: test: {
+	command: "test"
+	targets: ["//..."]
+	event: ["push"]
+	platforms: ["linux_amd64"]
+	ignore_quarantined_tests: true
+}`,
+			Job: &JobV2{
+				Name:                   "test",
+				Command:                "test",
+				Targets:                []string{"//..."},
+				Event:                  []EventType{EventPush},
+				Platforms:              []string{"linux_amd64"},
+				Args:                   []string{},
+				IgnoreQuarantinedTests: true,
+			},
+		},
+		{
+			Name: "Invalid: run with ignore_quarantined_tests",
+			File: `jobs: test: {
+	command: "run"
+	targets: ["//..."]
+	event: ["manual"]
+	platforms: ["linux_amd64"]
+	ignore_quarantined_tests: true
+}`,
+		},
+		{
+			Name: "Valid: needs",
+			File: `jobs: push: {
+	command: "run"
+	targets: ["//:push"]
+	event: ["push"]
+	platforms: ["linux_amd64"]
+	needs: ["test"]
+}`,
+			Job: &JobV2{
+				Name:      "push",
+				Command:   "run",
+				Targets:   []string{"//:push"},
+				Event:     []EventType{EventPush},
+				Platforms: []string{"linux_amd64"},
+				Needs:     []string{"test"},
+			},
+		},
+		{
+			Name: "Valid: paths",
+			File: `jobs: test: {
+	command: "test"
+	targets: ["//..."]
+	event: ["push", "pull_request"]
+	platforms: ["linux_amd64"]
+	paths: ["go/**"]
+	paths_ignore: ["**/*.md"]
+}`,
+			Job: &JobV2{
+				Name:        "test",
+				Command:     "test",
+				Targets:     []string{"//..."},
+				Event:       []EventType{EventPush, EventPullRequest},
+				Platforms:   []string{"linux_amd64"},
+				Args:        []string{},
+				Paths:       []string{"go/**"},
+				PathsIgnore: []string{"**/*.md"},
+			},
+		},
+		{
+			Name: "Valid: retry",
+			File: `jobs: test: {
+	command: "test"
+	targets: ["//..."]
+	event: ["push"]
+	platforms: ["linux_amd64"]
+	retry: {
+		max_attempts: 3
+		on: ["eviction", "exit_code"]
+		exit_codes: [34]
+	}
+}`,
+			Job: &JobV2{
+				Name:      "test",
+				Command:   "test",
+				Targets:   []string{"//..."},
+				Event:     []EventType{EventPush},
+				Platforms: []string{"linux_amd64"},
+				Args:      []string{},
+				Retry: &RetryPolicy{
+					MaxAttempts: 3,
+					On:          []RetryCondition{RetryOnEviction, RetryOnExitCode},
+					ExitCodes:   []int{34},
+				},
+			},
+		},
+		{
+			Name: "Invalid: unknown retry condition",
+			File: `jobs: test: {
+	command: "test"
+	targets: ["//..."]
+	event: ["push"]
+	platforms: ["linux_amd64"]
+	retry: {
+		max_attempts: 3
+		on: ["always"]
+	}
+}`,
+		},
+		{
+			Name: "Valid: artifacts",
+			File: `jobs: build: {
+	command: "test"
+	targets: ["//..."]
+	event: ["push"]
+	platforms: ["linux_amd64"]
+	artifacts: ["//cmd/foo", "cmd/**/*.tar"]
+}`,
+			Job: &JobV2{
+				Name:      "build",
+				Command:   "test",
+				Targets:   []string{"//..."},
+				Event:     []EventType{EventPush},
+				Platforms: []string{"linux_amd64"},
+				Args:      []string{},
+				Artifacts: []string{"//cmd/foo", "cmd/**/*.tar"},
+			},
+		},
+		{
+			Name: "Invalid: malformed artifact pattern",
+			File: `jobs: build: {
+	command: "test"
+	targets: ["//..."]
+	event: ["push"]
+	platforms: ["linux_amd64"]
+	artifacts: ["cmd/[a-"]
+}`,
+		},
+		{
+			Name: "Valid: concurrency",
+			File: `jobs: test: {
+	command: "test"
+	targets: ["//..."]
+	event: ["pull_request"]
+	platforms: ["linux_amd64"]
+	concurrency: {
+		group: "{{ .Repository }}/{{ .Job }}/{{ .PullRequest }}"
+		cancel_in_progress: true
+	}
+}`,
+			Job: &JobV2{
+				Name:      "test",
+				Command:   "test",
+				Targets:   []string{"//..."},
+				Event:     []EventType{EventPullRequest},
+				Platforms: []string{"linux_amd64"},
+				Args:      []string{},
+				Concurrency: &ConcurrencyPolicy{
+					Group:            "{{ .Repository }}/{{ .Job }}/{{ .PullRequest }}",
+					CancelInProgress: true,
+				},
+			},
+		},
+		{
+			Name: "Valid: matrix",
+			File: `jobs: test: {
+	command: "test"
+	targets: ["//..."]
+	event: ["push"]
+	platforms: ["linux_amd64"]
+	matrix: {
+		bazel_versions: ["7.4.0", "8.0.0"]
+		configs: ["ci"]
+		env: [{CGO_ENABLED: "0"}, {CGO_ENABLED: "1"}]
+	}
+}`,
+			Job: &JobV2{
+				Name:      "test",
+				Command:   "test",
+				Targets:   []string{"//..."},
+				Event:     []EventType{EventPush},
+				Platforms: []string{"linux_amd64"},
+				Args:      []string{},
+				Matrix: &Matrix{
+					BazelVersions: []string{"7.4.0", "8.0.0"},
+					Configs:       []string{"ci"},
+					Env:           []map[string]string{{"CGO_ENABLED": "0"}, {"CGO_ENABLED": "1"}},
+				},
+			},
+		},
+		{
+			Name: "Invalid: empty matrix axis",
+			File: `jobs: test: {
+	command: "test"
+	targets: ["//..."]
+	event: ["push"]
+	platforms: ["linux_amd64"]
+	matrix: bazel_versions: []
+}`,
+		},
+		{
+			Name: "Invalid: unknown field in concurrency group",
+			File: `jobs: test: {
+	command: "test"
+	targets: ["//..."]
+	event: ["pull_request"]
+	platforms: ["linux_amd64"]
+	concurrency: group: "{{ .Ref }}"
+}`,
+		},
+		{
+			Name: "Invalid: malformed path pattern",
+			File: `jobs: test: {
+	command: "test"
+	targets: ["//..."]
+	event: ["push"]
+	platforms: ["linux_amd64"]
+	paths: ["go/[a-"]
 }`,
 		},
 		{
@@ -166,6 +385,103 @@ func TestParseFile(t *testing.T) {
 	}
 }
 
+func TestReadJobsFromBuildDir(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Files map[string]string
+		Err   bool
+	}{
+		{
+			Name: "Dependency across files",
+			Files: map[string]string{
+				".build/test.cue": `jobs: test: {
+	targets: ["//..."]
+	event: ["push"]
+	platforms: ["linux_amd64"]
+}`,
+				".build/push.cue": `jobs: push: {
+	command: "run"
+	targets: ["//:push"]
+	event: ["push"]
+	platforms: ["linux_amd64"]
+	needs: ["test"]
+}`,
+			},
+		},
+		{
+			Name: "Unknown job",
+			Files: map[string]string{
+				".build/push.cue": `jobs: push: {
+	command: "run"
+	targets: ["//:push"]
+	event: ["push"]
+	platforms: ["linux_amd64"]
+	needs: ["test"]
+}`,
+			},
+			Err: true,
+		},
+		{
+			Name: "Self dependency",
+			Files: map[string]string{
+				".build/test.cue": `jobs: test: {
+	targets: ["//..."]
+	event: ["push"]
+	platforms: ["linux_amd64"]
+	needs: ["test"]
+}`,
+			},
+			Err: true,
+		},
+		{
+			Name: "Cycle",
+			Files: map[string]string{
+				".build/test.cue": `jobs: {
+	test: {
+		targets: ["//..."]
+		event: ["push"]
+		platforms: ["linux_amd64"]
+		needs: ["push"]
+	}
+	build: {
+		targets: ["//..."]
+		event: ["push"]
+		platforms: ["linux_amd64"]
+		needs: ["test"]
+	}
+	push: {
+		command: "run"
+		targets: ["//:push"]
+		event: ["push"]
+		platforms: ["linux_amd64"]
+		needs: ["build"]
+	}
+}`,
+			},
+			Err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			mapFS := make(fstest.MapFS)
+			for name, body := range tc.Files {
+				mapFS[name] = &fstest.MapFile{Data: []byte(body)}
+			}
+			jobs, err := ReadJobsFromBuildDir(mapFS)
+			if tc.Err {
+				if testing.Verbose() && err != nil {
+					t.Log(err)
+				}
+				assertion.MustError(t, err)
+				return
+			}
+			assertion.MustNoError(t, err)
+			assertion.Len(t, jobs, len(tc.Files))
+		})
+	}
+}
+
 func TestNormalizeGitPath(t *testing.T) {
 	// git-data-service expects tree paths without a leading slash and "" for
 	// the repository root (ReadDir maps "" to "/"). Anything else makes
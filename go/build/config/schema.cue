@@ -28,6 +28,24 @@ import "list"
 	include_prerelease?: bool | *false
 }
 
+#RetryPolicy: {
+	max_attempts!: int & >=2
+	on!: list.MinItems(1) & [...("eviction" | "exit_code" | "test_failure")]
+	exit_codes?: [...int]
+}
+
+#ConcurrencyPolicy: {
+	group!: string & !=""
+	cancel_in_progress?: bool | *false
+}
+
+#Matrix: {
+	bazel_versions?: list.MinItems(1) & [...string]
+	configs?: list.MinItems(1) & [...string]
+	platforms?: list.MinItems(1) & [...string]
+	env?: list.MinItems(1) & [...{[string]: string}]
+}
+
 #Job: {
 	name?:   string
 	command!: #Command
@@ -45,10 +63,18 @@ import "list"
 	github_status?: bool
 	exclusive?: bool
 	cache_test_results?: bool
+	ignore_quarantined_tests?: bool
 	env?: {
 		[string]: string
 	}
 	external_source?: #ExternalReleaseSource
+	needs?: [...string]
+	paths?: [...string]
+	paths_ignore?: [...string]
+	retry?: #RetryPolicy
+	artifacts?: [...string]
+	concurrency?: #ConcurrencyPolicy
+	matrix?: #Matrix
 }
 
 #Job: {
@@ -66,6 +92,7 @@ import "list"
 		targets: list.MaxItems(1)
 		// cache_test_results is only meaningful for the test command.
 		cache_test_results?: _|_
+		ignore_quarantined_tests?: _|_
 	}
 
 	if list.Contains(event, "external_release") {
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -33,6 +34,7 @@ import (
 	"go.f110.dev/mono/go/build/config"
 	"go.f110.dev/mono/go/build/database"
 	"go.f110.dev/mono/go/build/database/dao"
+	"go.f110.dev/mono/go/build/flaky"
 	"go.f110.dev/mono/go/build/watcher"
 	"go.f110.dev/mono/go/ctxutil"
 	"go.f110.dev/mono/go/enumerable"
@@ -111,9 +113,12 @@ type BazelOptions struct {
 	GithubAppSecretName      string
 	GitDataServiceURL        string
 	CloneFromGitDataService  bool
+	// ArtifactStorageEndpoint is the endpoint of the object storage which is accessed from the build pods.
+	ArtifactStorageEndpoint string
+	ArtifactSecretName      string
 }
 
-func NewBazelOptions(remoteCache string, enableRemoteAssetApi bool, sidecarImage, bazelImage string, useBazelisk bool, defaultVersion, bazelMirrorURL, centralRegistryMirrorURL string, pullAlways bool, githubAppId, githubInstallationId int64, githubAppSecretName, gitDataServiceURL string, cloneFromGitDataService bool) BazelOptions {
+func NewBazelOptions(remoteCache string, enableRemoteAssetApi bool, sidecarImage, bazelImage string, useBazelisk bool, defaultVersion, bazelMirrorURL, centralRegistryMirrorURL string, pullAlways bool, githubAppId, githubInstallationId int64, githubAppSecretName, gitDataServiceURL string, cloneFromGitDataService bool, artifactStorageEndpoint, artifactSecretName string) BazelOptions {
 	return BazelOptions{
 		RemoteCache:              remoteCache,
 		EnableRemoteAssetApi:     enableRemoteAssetApi,
@@ -129,6 +134,8 @@ func NewBazelOptions(remoteCache string, enableRemoteAssetApi bool, sidecarImage
 		GithubAppSecretName:      githubAppSecretName,
 		GitDataServiceURL:        gitDataServiceURL,
 		CloneFromGitDataService:  cloneFromGitDataService,
+		ArtifactStorageEndpoint:  artifactStorageEndpoint,
+		ArtifactSecretName:       artifactSecretName,
 	}
 }
 
@@ -152,6 +159,22 @@ func (tq *taskQueue) EnqueueById(id string, task *database.Task) {
 	tq.queues[id] = append(tq.queues[id], task)
 }
 
+// Remove removes the task from all queues.
+func (tq *taskQueue) Remove(taskId int32) {
+	tq.mu.Lock()
+	defer tq.mu.Unlock()
+
+	for id, q := range tq.queues {
+		tq.queues[id] = slices.DeleteFunc(q, func(t *database.Task) bool { return t.Id == taskId })
+	}
+}
+
+// concurrencyQueueId returns the id of the queue for the concurrency group.
+// The group key is unique in the repository only.
+func concurrencyQueueId(repositoryId int32, group string) string {
+	return fmt.Sprintf("concurrency/%d/%s", repositoryId, group)
+}
+
 func (tq *taskQueue) Dequeue(job *config.JobV2) *database.Task {
 	return tq.DequeueById(job.Identification())
 }
@@ -188,6 +211,7 @@ type BazelBuilder struct {
 	config            *rest.Config
 	vaultClient       *vault.Client
 	jobBuilder        *JobBuilder
+	flakyDetector     *flaky.Detector
 
 	dao                    dao.Options
 	githubClient           *github.Client
@@ -201,6 +225,9 @@ type BazelBuilder struct {
 	dev                    bool
 
 	taskQueue *taskQueue
+
+	runningLogsMu sync.Mutex
+	runningLogs   map[int32]*runningLog
 }
 
 func NewBazelBuilder(
@@ -237,6 +264,8 @@ func NewBazelBuilder(
 		dev:               dev,
 		taskQueue:         newTaskQueue(),
 		jobBuilder:        NewJobBuilder(namespace, bazelImage, sidecarImage, excludeNodes),
+		flakyDetector:     flaky.NewDetector(daoOpt),
+		runningLogs:       make(map[int32]*runningLog),
 	}
 	if kOpt.BatchInformer != nil {
 		b.jobLister = kOpt.BatchInformer.JobLister()
@@ -272,6 +301,9 @@ func NewBazelBuilder(
 	if bazelOpt.CloneFromGitDataService && bazelOpt.GitDataServiceURL != "" {
 		b.jobBuilder.GitDataService(bazelOpt.GitDataServiceURL)
 	}
+	if bazelOpt.ArtifactSecretName != "" {
+		b.jobBuilder.ArtifactStorage(bazelOpt.ArtifactStorageEndpoint, bucket, bazelOpt.ArtifactSecretName)
+	}
 	defaultCPULimit := resource.MustParse(defaultCPULimit)
 	defaultMemoryLimit := resource.MustParse(defaultMemoryLimit)
 	if kOpt.DefaultCPULimit != "" {
@@ -302,13 +334,17 @@ func NewBazelBuilder(
 		return nil, xerrors.WithStack(err)
 	}
 	for _, v := range pendingTasks {
+		if v.ConcurrencyGroup != "" {
+			b.taskQueue.EnqueueById(concurrencyQueueId(v.RepositoryId, v.ConcurrencyGroup), v)
+			continue
+		}
 		b.taskQueue.EnqueueById(v.JobName, v)
 	}
 
 	return b, nil
 }
 
-func (b *BazelBuilder) Build(ctx context.Context, repo *database.SourceRepository, job *config.JobV2, revision, bazelVersion, command string, targets, platforms []string, via string, isMainBranch bool) ([]*database.Task, error) {
+func (b *BazelBuilder) Build(ctx context.Context, repo *database.SourceRepository, job *config.JobV2, revision, bazelVersion, command string, targets, platforms []string, via string, isMainBranch bool, ref config.SourceRef) ([]*database.Task, error) {
 	var tasks []*database.Task
 	defer func() {
 		for _, task := range tasks {
@@ -329,35 +365,100 @@ func (b *BazelBuilder) Build(ctx context.Context, repo *database.SourceRepositor
 		return nil, nil
 	}
 
-	jobConfiguration, err := config.MarshalJob(job)
+	depState, upstream := checkDependencies(taskList, job)
+
+	owner, repoName, err := repositoryOwnerAndName(repo)
+	if err != nil {
+		return nil, err
+	}
+	ref.Repository, ref.Job = owner+"/"+repoName, job.Name
+	group, err := job.Concurrency.GroupKey(ref)
 	if err != nil {
 		return nil, err
 	}
+	var groupIsBusy bool
+	if group != "" {
+		superseded, err := b.tasksOfOtherRevisionInGroup(ctx, repo, group, revision)
+		if err != nil {
+			return nil, err
+		}
+		if job.Concurrency.CancelInProgress {
+			b.cancelTasks(ctx, superseded, fmt.Sprintf("superseded by %s", revision))
+		} else {
+			groupIsBusy = len(superseded) > 0
+		}
+	}
+
 	t := strings.Join(targets, "\n")
-	for _, platform := range platforms {
+	for _, entry := range job.Matrix.Expand(bazelVersion, job.ConfigName, platforms) {
+		cell := job
+		if job.Matrix != nil {
+			cell = job.ForMatrixEntry(entry)
+		}
+		jobConfiguration, err := config.MarshalJob(cell)
+		if err != nil {
+			return nil, err
+		}
+		var matrix string
+		if len(entry.Axes) > 0 {
+			buf, err := json.Marshal(entry.Axes)
+			if err != nil {
+				return nil, xerrors.WithStack(err)
+			}
+			matrix = string(buf)
+		}
 		task, err := b.dao.Task.Create(ctx, &database.Task{
 			RepositoryId:           repo.Id,
 			JobName:                job.Name,
 			ParsedJobConfiguration: jobConfiguration,
 			Revision:               revision,
 			IsTrunk:                isMainBranch,
-			BazelVersion:           bazelVersion,
+			BazelVersion:           entry.BazelVersion,
 			Command:                command,
 			Targets:                t,
-			Platform:               platform,
+			Platform:               entry.Platform,
 			Via:                    via,
-			ConfigName:             job.ConfigName,
+			ConfigName:             entry.ConfigName,
+			Attempt:                1,
+			ConcurrencyGroup:       group,
+			Matrix:                 matrix,
 		})
 		if err != nil {
 			return nil, xerrors.WithStack(err)
 		}
 		tasks = append(tasks, task)
 
-		if err := b.buildJob(ctx, repo, job, task); err != nil {
+		switch depState {
+		case dependencyWaiting:
+			// The task is started by startDownstreamTasks when the upstream tasks finish.
+			slogger.Log.Info("Wait for the upstream job", slog.Int("task.id", int(task.Id)), slog.String("upstream", upstream))
+			if cell.GitHubStatus {
+				if err := b.updateGithubStatus(ctx, repo, cell, task, "pending"); err != nil {
+					slogger.Log.Warn("Failure update the status of github", slogger.E(err), slog.Int("task.id", int(task.Id)))
+				}
+			}
+			continue
+		case dependencyFailed:
+			b.skipTask(ctx, repo, cell, task, upstream)
+			continue
+		}
+		if groupIsBusy {
+			// The task is started by startNextTaskOfGroup when the running task of the group finishes.
+			slogger.Log.Info("Wait for the running task of the concurrency group", slog.Int("task.id", int(task.Id)), slog.String("group", group))
+			b.taskQueue.EnqueueById(concurrencyQueueId(repo.Id, group), task)
+			if cell.GitHubStatus {
+				if err := b.updateGithubStatus(ctx, repo, cell, task, "pending"); err != nil {
+					slogger.Log.Warn("Failure update the status of github", slogger.E(err), slog.Int("task.id", int(task.Id)))
+				}
+			}
+			continue
+		}
+
+		if err := b.buildJob(ctx, repo, cell, task); err != nil {
 			if errors.Is(err, ErrOtherTaskIsRunning) {
 				slogger.Log.Info("Enqueue the task", slog.Int("task.id", int(task.Id)))
-				b.taskQueue.Enqueue(job, task)
-				return tasks, nil
+				b.taskQueue.Enqueue(cell, task)
+				continue
 			}
 
 			task.Success = false
@@ -368,8 +469,8 @@ func (b *BazelBuilder) Build(ctx context.Context, repo *database.SourceRepositor
 			return tasks, xerrors.WithStack(err)
 		}
 
-		if job.GitHubStatus {
-			if err := b.updateGithubStatus(ctx, repo, job, task, "pending"); err != nil {
+		if cell.GitHubStatus {
+			if err := b.updateGithubStatus(ctx, repo, cell, task, "pending"); err != nil {
 				slogger.Log.Warn("Failure update the status of github", slogger.E(err), slog.Int("task.id", int(task.Id)))
 			}
 		}
@@ -378,6 +479,23 @@ func (b *BazelBuilder) Build(ctx context.Context, repo *database.SourceRepositor
 	return tasks, nil
 }
 
+// tasksOfOtherRevisionInGroup returns the unfinished tasks of the concurrency group except the tasks of revision.
+// The tasks of the same revision are the other jobs, the matrix cells or the downstream tasks of the same push,
+// so they don't wait for each other.
+func (b *BazelBuilder) tasksOfOtherRevisionInGroup(ctx context.Context, repo *database.SourceRepository, group, revision string) ([]*database.Task, error) {
+	inProgress, err := b.dao.Task.ListUnfinishedByConcurrencyGroup(ctx, repo.Id, group)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	var tasks []*database.Task
+	for _, v := range inProgress {
+		if v.Revision != revision {
+			tasks = append(tasks, v)
+		}
+	}
+	return tasks, nil
+}
+
 // runningSameJob returns the unfinished task of jobName if it is still running.
 // taskList must be ordered by CreatedAt in descending order. Tasks older than
 // jobTimeout are regarded as not running.
@@ -396,6 +514,168 @@ func runningSameJob(taskList []*database.Task, jobName string) *database.Task {
 	return nil
 }
 
+type dependencyState int
+
+const (
+	dependencySatisfied dependencyState = iota
+	dependencyWaiting
+	dependencyFailed
+)
+
+// checkDependencies returns the state of the upstream jobs of job.
+// taskList must be the tasks of the same revision ordered by id in descending order.
+// The latest task of each matrix cell (the platform, the version of Bazel, the config and the matrix values) is used
+// for the upstream job.
+// An upstream job which doesn't have any task at the revision is regarded as failed.
+// The second return value is the name of the upstream job which is failed or not finished yet.
+func checkDependencies(taskList []*database.Task, job *config.JobV2) (dependencyState, string) {
+	state, pending := dependencySatisfied, ""
+	for _, name := range job.Needs {
+		cells := make(map[matrixCell]struct{})
+		for _, v := range taskList {
+			if v.JobName != name {
+				continue
+			}
+			cell := matrixCellOf(v)
+			if _, ok := cells[cell]; ok {
+				continue
+			}
+			cells[cell] = struct{}{}
+
+			if v.FinishedAt == nil {
+				if state == dependencySatisfied {
+					state, pending = dependencyWaiting, name
+				}
+				continue
+			}
+			if !v.Success {
+				return dependencyFailed, name
+			}
+		}
+		if len(cells) == 0 {
+			return dependencyFailed, name
+		}
+	}
+
+	return state, pending
+}
+
+// matrixCell identifies the task in the tasks of the same job and the same revision.
+type matrixCell struct {
+	Platform     string
+	BazelVersion string
+	ConfigName   string
+	Matrix       string
+}
+
+func matrixCellOf(task *database.Task) matrixCell {
+	return matrixCell{Platform: task.Platform, BazelVersion: task.BazelVersion, ConfigName: task.ConfigName, Matrix: task.Matrix}
+}
+
+// skipTask finishes task without running it because the upstream job didn't succeed.
+// The caller has to persist task.
+func (b *BazelBuilder) skipTask(ctx context.Context, repo *database.SourceRepository, job *config.JobV2, task *database.Task, upstream string) {
+	slogger.Log.Info("Skip the task because the upstream job didn't succeed", slog.Int("task.id", int(task.Id)), slog.String("upstream", upstream))
+	task.Skipped = true
+	task.Success = false
+	task.FinishedAt = new(time.Now())
+
+	if job.GitHubStatus {
+		if err := b.updateGithubStatus(ctx, repo, job, task, "error"); err != nil {
+			slogger.Log.Warn("Failure update the status of github", slogger.E(err), slog.Int("task.id", int(task.Id)))
+		}
+	}
+}
+
+// startDownstreamTasks starts the tasks which are waiting for the job of finished at the same revision.
+// If finished didn't succeed, the waiting tasks are skipped and the tasks depending on them are skipped as well.
+func (b *BazelBuilder) startDownstreamTasks(ctx context.Context, repo *database.SourceRepository, finished *database.Task) error {
+	taskList, err := b.dao.Task.ListByRevision(ctx, repo.Id, finished.Revision, dao.Desc)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	owner, repoName, err := repositoryOwnerAndName(repo)
+	if err != nil {
+		return err
+	}
+
+	finishedJobs := []string{finished.JobName}
+	for len(finishedJobs) > 0 {
+		upstream := finishedJobs[0]
+		finishedJobs = finishedJobs[1:]
+
+		for _, v := range taskList {
+			if v.StartAt != nil || v.FinishedAt != nil {
+				continue
+			}
+			jobConfiguration, err := decodeJobConfiguration(v, owner, repoName)
+			if err != nil {
+				slogger.Log.Warn("Failed to decode json", slog.Int("task.id", int(v.Id)))
+				continue
+			}
+			if !slices.Contains(jobConfiguration.Needs, upstream) {
+				continue
+			}
+
+			state, name := checkDependencies(taskList, jobConfiguration)
+			switch state {
+			case dependencyWaiting:
+				continue
+			case dependencyFailed:
+				b.skipTask(ctx, repo, jobConfiguration, v, name)
+				finishedJobs = append(finishedJobs, v.JobName)
+			case dependencySatisfied:
+				if v.ConcurrencyGroup != "" {
+					others, err := b.tasksOfOtherRevisionInGroup(ctx, repo, v.ConcurrencyGroup, v.Revision)
+					if err != nil {
+						return err
+					}
+					if len(others) > 0 {
+						// The task is started by startNextTaskOfGroup when the running task of the group finishes.
+						slogger.Log.Info("Wait for the running task of the concurrency group", slog.Int("task.id", int(v.Id)), slog.String("group", v.ConcurrencyGroup))
+						b.taskQueue.EnqueueById(concurrencyQueueId(repo.Id, v.ConcurrencyGroup), v)
+						continue
+					}
+				}
+				slogger.Log.Info("Start the downstream task", slog.Int("task.id", int(v.Id)), slog.String("upstream", upstream))
+				if err := b.buildJob(ctx, repo, jobConfiguration, v); err != nil {
+					if errors.Is(err, ErrOtherTaskIsRunning) {
+						slogger.Log.Info("Enqueue the task", slog.Int("task.id", int(v.Id)))
+						b.taskQueue.Enqueue(jobConfiguration, v)
+						continue
+					}
+					slogger.Log.Warn("Failed starting the downstream task", slogger.E(err), slog.Int("task.id", int(v.Id)))
+					v.FinishedAt = new(time.Now())
+					finishedJobs = append(finishedJobs, v.JobName)
+				} else if jobConfiguration.GitHubStatus {
+					if err := b.updateGithubStatus(ctx, repo, jobConfiguration, v, "pending"); err != nil {
+						slogger.Log.Warn("Failure update the status of github", slogger.E(err), slog.Int("task.id", int(v.Id)))
+					}
+				}
+			}
+			if err := b.dao.Task.Update(ctx, v); err != nil {
+				return xerrors.WithStack(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isDependencySatisfied reports whether all upstream jobs of task succeeded at the revision of task.
+func (b *BazelBuilder) isDependencySatisfied(ctx context.Context, repo *database.SourceRepository, job *config.JobV2, task *database.Task) (bool, error) {
+	if len(job.Needs) == 0 {
+		return true, nil
+	}
+
+	taskList, err := b.dao.Task.ListByRevision(ctx, repo.Id, task.Revision, dao.Desc)
+	if err != nil {
+		return false, xerrors.WithStack(err)
+	}
+	state, _ := checkDependencies(taskList, job)
+	return state == dependencySatisfied, nil
+}
+
 func (b *BazelBuilder) ForceStop(ctx context.Context, taskId int32) error {
 	task, err := b.dao.Task.Select(ctx, taskId)
 	if err != nil {
@@ -424,6 +704,65 @@ func (b *BazelBuilder) ForceStop(ctx context.Context, taskId int32) error {
 	return nil
 }
 
+// startNextTaskOfGroup starts the task which is waiting for finished in the same concurrency group.
+func (b *BazelBuilder) startNextTaskOfGroup(ctx context.Context, repo *database.SourceRepository, finished *database.Task) {
+	if finished.ConcurrencyGroup == "" {
+		return
+	}
+	next := b.taskQueue.DequeueById(concurrencyQueueId(repo.Id, finished.ConcurrencyGroup))
+	if next == nil {
+		return
+	}
+	owner, repoName, err := repositoryOwnerAndName(repo)
+	if err != nil {
+		slogger.Log.Warn("Failed to get the name of the repository", slogger.E(err), slog.Int("task.id", int(next.Id)))
+		return
+	}
+	jobConfiguration, err := decodeJobConfiguration(next, owner, repoName)
+	if err != nil {
+		slogger.Log.Warn("Failed to decode json", slog.Int("task.id", int(next.Id)))
+		return
+	}
+
+	slogger.Log.Info("Start the next task of the concurrency group", slog.Int("task.id", int(next.Id)), slog.String("group", finished.ConcurrencyGroup))
+	if err := b.buildJob(ctx, repo, jobConfiguration, next); err != nil {
+		if errors.Is(err, ErrOtherTaskIsRunning) {
+			slogger.Log.Info("Enqueue the task", slog.Int("task.id", int(next.Id)))
+			b.taskQueue.Enqueue(jobConfiguration, next)
+			return
+		}
+		slogger.Log.Warn("Failed starting the next task of the concurrency group", slogger.E(err), slog.Int("task.id", int(next.Id)))
+		return
+	}
+	if err := b.dao.Task.Update(ctx, next); err != nil {
+		slogger.Log.Warn("Failed update the task", slogger.E(err), slog.Int("task.id", int(next.Id)))
+	}
+}
+
+// cancelTasks stops the unfinished tasks and records reason on them.
+// The tasks which haven't started yet are finished without running.
+func (b *BazelBuilder) cancelTasks(ctx context.Context, tasks []*database.Task, reason string) {
+	for _, v := range tasks {
+		slogger.Log.Info("Cancel the task", slog.Int("task.id", int(v.Id)), slog.String("reason", reason))
+		v.CancelReason = reason
+		if v.JobObjectName == "" {
+			b.taskQueue.Remove(v.Id)
+			v.Success = false
+			v.FinishedAt = new(time.Now())
+		}
+		if err := b.dao.Task.Update(ctx, v); err != nil {
+			slogger.Log.Warn("Failed to update the task", slogger.E(err), slog.Int("task.id", int(v.Id)))
+			continue
+		}
+		if v.FinishedAt != nil {
+			continue
+		}
+		if err := b.ForceStop(ctx, v.Id); err != nil {
+			slogger.Log.Warn("Failed to stop the task", slogger.E(err), slog.Int("task.id", int(v.Id)))
+		}
+	}
+}
+
 func (b *BazelBuilder) IsStub() bool {
 	return b.client == nil || b.jobLister == nil || b.podLister == nil
 }
@@ -455,34 +794,19 @@ func (b *BazelBuilder) syncJob(job *batchv1.Job) error {
 		}
 		return xerrors.WithStack(err)
 	}
-	u, err := url.Parse(repo.Url)
+	owner, repoName, err := repositoryOwnerAndName(repo)
 	if err != nil {
-		return xerrors.WithStack(err)
+		return err
 	}
-	p := strings.Split(u.Path, "/")
-	owner, repoName := p[1], p[2]
-	jobConfiguration := &config.JobV2{}
-	if task.JobConfiguration != nil && len(*task.JobConfiguration) > 0 {
-		j := &config.Job{}
-		if err := config.UnmarshalJob([]byte(*task.JobConfiguration), j); err != nil {
-			slogger.Log.Warn("Failed to decode json", slog.Int("task.id", int(task.Id)))
-			return nil
-		}
-		jobConfiguration = j.ToV2()
-	} else if len(task.ParsedJobConfiguration) > 0 {
-		j := &config.Job{}
-		if err := config.UnmarshalJob(task.ParsedJobConfiguration, j); err != nil {
-			if err := config.UnmarshalJobV2(task.ParsedJobConfiguration, jobConfiguration, owner, repoName); err != nil {
-				slogger.Log.Warn("Failed to decode json", slog.Int("task.id", int(task.Id)))
-				return nil
-			}
-		} else {
-			jobConfiguration = j.ToV2()
-		}
+	jobConfiguration, err := decodeJobConfiguration(task, owner, repoName)
+	if err != nil {
+		slogger.Log.Warn("Failed to decode json", slog.Int("task.id", int(task.Id)))
+		return nil
 	}
 
 	if task.FinishedAt != nil {
 		slogger.Log.Debug("task is already finished", slog.String("job.name", job.Name), slog.Int("task_id", int(task.Id)))
+		b.forgetRunningLog(task.Id)
 		if job.DeletionTimestamp.IsZero() {
 			if err := b.teardownJob(ctx, job); err != nil {
 				return xerrors.WithStack(err)
@@ -506,6 +830,7 @@ func (b *BazelBuilder) syncJob(job *batchv1.Job) error {
 			slogger.Log.Info("Force stop job", slog.String("job.name", job.Name), slog.Int("task_id", int(task.Id)))
 		}
 		task.FinishedAt = new(time.Now())
+		b.forgetRunningLog(task.Id)
 		job.Finalizers = enumerable.Delete(job.Finalizers, bazelBuilderControllerFinalizerName)
 		if err := b.dao.Task.Update(context.Background(), task); err != nil {
 			return xerrors.WithStack(err)
@@ -517,12 +842,17 @@ func (b *BazelBuilder) syncJob(job *batchv1.Job) error {
 		if err := b.teardownJob(ctx, job); err != nil {
 			return xerrors.WithStack(err)
 		}
+		if err := b.startDownstreamTasks(ctx, repo, task); err != nil {
+			slogger.Log.Warn("Failed to process the downstream tasks", slogger.E(err), slog.Int("task.id", int(task.Id)))
+		}
+		b.startNextTaskOfGroup(ctx, repo, task)
 		return nil
 	}
 
 	if !job.DeletionTimestamp.IsZero() {
 		// Someone deletes the job manually.
 		task.FinishedAt = new(job.DeletionTimestamp.Time)
+		b.forgetRunningLog(task.Id)
 		job.Finalizers = enumerable.Delete(job.Finalizers, bazelBuilderControllerFinalizerName)
 		if err := b.dao.Task.Update(context.Background(), task); err != nil {
 			return xerrors.WithStack(err)
@@ -530,6 +860,10 @@ func (b *BazelBuilder) syncJob(job *batchv1.Job) error {
 		if _, err := b.client.BatchV1.UpdateJob(ctx, job, metav1.UpdateOptions{}); err != nil {
 			return xerrors.WithStack(err)
 		}
+		if err := b.startDownstreamTasks(ctx, repo, task); err != nil {
+			slogger.Log.Warn("Failed to process the downstream tasks", slogger.E(err), slog.Int("task.id", int(task.Id)))
+		}
+		b.startNextTaskOfGroup(ctx, repo, task)
 		return nil
 	}
 
@@ -562,7 +896,12 @@ func (b *BazelBuilder) syncJob(job *batchv1.Job) error {
 				}
 			}
 			task.FinishedAt = &now
-			slogger.Log.Info("Job was failed", slog.String("job.name", job.Name), slog.Int("task_id", int(task.Id)))
+			if task.Success {
+				// postProcess ignores the failures of the quarantined tests.
+				slogger.Log.Info("Job was failed only by the quarantined tests", slog.String("job.name", job.Name), slog.Int("task_id", int(task.Id)))
+			} else {
+				slogger.Log.Info("Job was failed", slog.String("job.name", job.Name), slog.Int("task_id", int(task.Id)))
+			}
 		}
 	}
 
@@ -573,10 +912,29 @@ func (b *BazelBuilder) syncJob(job *batchv1.Job) error {
 	if _, err := b.client.BatchV1.UpdateJob(ctx, job, metav1.UpdateOptions{}); err != nil {
 		return xerrors.WithStack(err)
 	}
+	if task.FinishedAt != nil {
+		if err := b.startDownstreamTasks(ctx, repo, task); err != nil {
+			slogger.Log.Warn("Failed to process the downstream tasks", slogger.E(err), slog.Int("task.id", int(task.Id)))
+		}
+		b.startNextTaskOfGroup(ctx, repo, task)
+	}
 
 	if followTask := b.taskQueue.DequeueById(task.JobName); followTask != nil {
 		slogger.Log.Info("Dequeue the task", slog.Int("task.id", int(followTask.Id)))
-		if err := b.buildJob(ctx, repo, jobConfiguration, followTask); err != nil {
+		// The tasks of the same job have the different configuration for each matrix cell.
+		followJobConfiguration, err := decodeJobConfiguration(followTask, owner, repoName)
+		if err != nil {
+			slogger.Log.Warn("Failed to decode json", slog.Int("task.id", int(followTask.Id)))
+			return nil
+		}
+		if ok, err := b.isDependencySatisfied(ctx, repo, followJobConfiguration, followTask); err != nil {
+			return xerrors.WithStack(err)
+		} else if !ok {
+			// The task will be started or skipped by startDownstreamTasks when its upstream tasks finish.
+			slogger.Log.Info("The follow task is waiting for the upstream job", slog.Int("task.id", int(followTask.Id)))
+			return nil
+		}
+		if err := b.buildJob(ctx, repo, followJobConfiguration, followTask); err != nil {
 			slogger.Log.Warn("Failed starting follow task. You have to start a task manually", slogger.E(err), slog.String("job.name", task.JobName), slog.Int("task.id", int(task.Id)))
 			return nil
 		}
@@ -588,6 +946,39 @@ func (b *BazelBuilder) syncJob(job *batchv1.Job) error {
 	return nil
 }
 
+// repositoryOwnerAndName returns the owner and the name of repo from its URL.
+func repositoryOwnerAndName(repo *database.SourceRepository) (string, string, error) {
+	u, err := url.Parse(repo.Url)
+	if err != nil {
+		return "", "", xerrors.WithStack(err)
+	}
+	p := strings.Split(u.Path, "/")
+	return p[1], p[2], nil
+}
+
+// decodeJobConfiguration returns the job configuration which task was created from.
+func decodeJobConfiguration(task *database.Task, owner, repoName string) (*config.JobV2, error) {
+	jobConfiguration := &config.JobV2{}
+	if task.JobConfiguration != nil && len(*task.JobConfiguration) > 0 {
+		j := &config.Job{}
+		if err := config.UnmarshalJob([]byte(*task.JobConfiguration), j); err != nil {
+			return nil, err
+		}
+		jobConfiguration = j.ToV2()
+	} else if len(task.ParsedJobConfiguration) > 0 {
+		j := &config.Job{}
+		if err := config.UnmarshalJob(task.ParsedJobConfiguration, j); err != nil {
+			if err := config.UnmarshalJobV2(task.ParsedJobConfiguration, jobConfiguration, owner, repoName); err != nil {
+				return nil, err
+			}
+		} else {
+			jobConfiguration = j.ToV2()
+		}
+	}
+
+	return jobConfiguration, nil
+}
+
 func (b *BazelBuilder) teardownJob(ctx context.Context, job *batchv1.Job) error {
 	if err := b.client.BatchV1.DeleteJob(ctx, job.Namespace, job.Name, metav1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
 		return xerrors.WithStack(err)
@@ -798,52 +1189,263 @@ func (b *BazelBuilder) updateAssignedInfo(ctx context.Context, job *batchv1.Job,
 	return nil
 }
 
-func (b *BazelBuilder) postProcess(ctx context.Context, job *batchv1.Job, repo *database.SourceRepository, jobConfiguration *config.JobV2, task *database.Task, success bool) error {
-	podList, err := b.client.CoreV1.ListPod(ctx, b.Namespace, metav1.ListOptions{LabelSelector: metav1.FormatLabelSelector(job.Spec.Selector)})
+// GetRunningTaskLog returns the log of the running task in the same format as the log file.
+// The log of the container which has not started yet is omitted, so the log returned later always begins with the log returned before.
+// running is false if the task has already finished.
+func (b *BazelBuilder) GetRunningTaskLog(ctx context.Context, taskId int32) (log []byte, running bool, err error) {
+	task, err := b.dao.Task.Select(ctx, taskId)
 	if err != nil {
-		return xerrors.WithStack(err)
+		return nil, false, xerrors.WithStack(err)
 	}
-	if len(podList.Items) == 0 {
-		return nil
+	if task.FinishedAt != nil {
+		b.forgetRunningLog(taskId)
+		return nil, false, nil
 	}
-	if len(podList.Items) != 1 {
-		return xerrors.Define("Target pods not found or found more than 1").WithStack()
+	if task.JobObjectName == "" || b.IsStub() {
+		return nil, true, nil
 	}
-	buildPod := podList.Items[0]
 
+	job, err := b.jobLister.Get(b.Namespace, task.JobObjectName)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			// The job was deleted before the task is finished.
+			b.forgetRunningLog(taskId)
+		}
+		return nil, false, xerrors.WithStack(err)
+	}
+	s, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return nil, false, xerrors.WithStack(err)
+	}
+	pods, err := b.podLister.List(b.Namespace, s)
+	if err != nil {
+		return nil, false, xerrors.WithStack(err)
+	}
+	if len(pods) == 0 {
+		return nil, true, nil
+	}
+
+	b.runningLogsMu.Lock()
+	l, ok := b.runningLogs[taskId]
+	if !ok || l.podName != pods[0].Name {
+		l = &runningLog{podName: pods[0].Name}
+		b.runningLogs[taskId] = l
+	}
+	b.runningLogsMu.Unlock()
+
+	log, err = b.readRunningLog(ctx, l)
+	if err != nil {
+		return nil, false, err
+	}
+	return log, true, nil
+}
+
+func (b *BazelBuilder) forgetRunningLog(taskId int32) {
+	b.runningLogsMu.Lock()
+	delete(b.runningLogs, taskId)
+	b.runningLogsMu.Unlock()
+}
+
+// runningLog is the log of the running task in the same format as the log file.
+// The log of the main container is read incrementally from the timestamp of the last line,
+// so the log which has been read once is not downloaded from the API server again.
+type runningLog struct {
+	podName string
+
+	mu  sync.Mutex
+	log []byte
+	// mainStarted is true if the log of the main container has been read once.
+	// The pre-process container is the init container, so its log doesn't grow after that.
+	mainStarted bool
+	// lastTime is the timestamp of the last line of the main container.
+	lastTime time.Time
+	// lastTimeLines is the number of the lines at lastTime which have been read.
+	// The lines at lastTime are returned again by the next request.
+	lastTimeLines int
+}
+
+// readRunningLog reads the new lines of the log from the pod.
+func (b *BazelBuilder) readRunningLog(ctx context.Context, l *runningLog) ([]byte, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.mainStarted {
+		preProcessLog, err := b.client.CoreV1.GetPodLogs(ctx, b.Namespace, l.podName, &corev1.PodLogOptions{Container: b.jobBuilder.PreProcessContainerName})
+		if err != nil {
+			return nil, nil
+		}
+		buf := new(bytes.Buffer)
+		buf.WriteString("----- pre-process -----\n")
+		buf.Write(preProcessLog)
+		mainLog, err := b.client.CoreV1.GetPodLogs(ctx, b.Namespace, l.podName, &corev1.PodLogOptions{Container: b.jobBuilder.BuildContainerName, Timestamps: true})
+		if err != nil {
+			// The main container has not started yet.
+			return buf.Bytes(), nil
+		}
+		buf.WriteString("\n")
+		buf.WriteString("----- main -----\n")
+		l.log = buf.Bytes()
+		l.mainStarted = true
+		l.append(mainLog)
+	} else {
+		opts := &corev1.PodLogOptions{Container: b.jobBuilder.BuildContainerName, Timestamps: true}
+		if !l.lastTime.IsZero() {
+			opts.SinceTime = &metav1.Time{Time: l.lastTime}
+		}
+		mainLog, err := b.client.CoreV1.GetPodLogs(ctx, b.Namespace, l.podName, opts)
+		if err == nil {
+			l.append(mainLog)
+		}
+	}
+
+	// The caller can't append to the log.
+	return l.log[:len(l.log):len(l.log)], nil
+}
+
+// append appends the lines of the log which have timestamps. The lines which have been read already are skipped.
+// The last line which doesn't end with the line break is left for the next read.
+func (l *runningLog) append(rawLog []byte) {
+	var skipped int
+	for {
+		i := bytes.IndexByte(rawLog, '\n')
+		if i == -1 {
+			return
+		}
+		line := rawLog[:i+1]
+		rawLog = rawLog[i+1:]
+
+		ts, body, ok := bytes.Cut(line, []byte(" "))
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, string(ts))
+		if err != nil {
+			continue
+		}
+		switch {
+		case t.Before(l.lastTime):
+			continue
+		case t.Equal(l.lastTime):
+			if skipped < l.lastTimeLines {
+				skipped++
+				continue
+			}
+			l.lastTimeLines++
+		default:
+			l.lastTime = t
+			l.lastTimeLines = 1
+		}
+		skipped = l.lastTimeLines
+		l.log = append(l.log, body...)
+	}
+}
+
+// podLog concatenates the logs of the pre-process container and the main container of the pod.
+func (b *BazelBuilder) podLog(ctx context.Context, podName string) ([]byte, error) {
 	buf := new(bytes.Buffer)
-	rawLog, err := b.client.CoreV1.GetPodLogs(ctx, b.Namespace, buildPod.Name, &corev1.PodLogOptions{Container: b.jobBuilder.PreProcessContainerName})
+	rawLog, err := b.client.CoreV1.GetPodLogs(ctx, b.Namespace, podName, &corev1.PodLogOptions{Container: b.jobBuilder.PreProcessContainerName})
 	if err != nil {
-		return xerrors.WithStack(err)
+		return nil, xerrors.WithStack(err)
 	}
 	buf.WriteString("----- pre-process -----\n")
 	buf.Write(rawLog)
 
-	rawLog, err = b.client.CoreV1.GetPodLogs(ctx, b.Namespace, buildPod.Name, &corev1.PodLogOptions{Container: b.jobBuilder.BuildContainerName})
+	rawLog, err = b.client.CoreV1.GetPodLogs(ctx, b.Namespace, podName, &corev1.PodLogOptions{Container: b.jobBuilder.BuildContainerName})
 	if err != nil {
-		return xerrors.WithStack(err)
+		return nil, xerrors.WithStack(err)
 	}
 	buf.WriteString("\n")
 	buf.WriteString("----- main -----\n")
 	buf.Write(rawLog)
 
-	var testReport []byte
-	if jobConfiguration.Command == "test" && task.IsTrunk {
-		rawLog, err = b.client.CoreV1.GetPodLogs(ctx, b.Namespace, buildPod.Name, &corev1.PodLogOptions{Container: b.jobBuilder.ReportContainerName})
-		if err != nil {
-			return xerrors.WithStack(err)
+	return buf.Bytes(), nil
+}
+
+func (b *BazelBuilder) postProcess(ctx context.Context, job *batchv1.Job, repo *database.SourceRepository, jobConfiguration *config.JobV2, task *database.Task, success bool) error {
+	b.forgetRunningLog(task.Id)
+	podList, err := b.client.CoreV1.ListPod(ctx, b.Namespace, metav1.ListOptions{LabelSelector: metav1.FormatLabelSelector(job.Spec.Selector)})
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	if len(podList.Items) == 0 {
+		if !success {
+			if _, err := b.retryTask(ctx, repo, jobConfiguration, task, nil); err != nil {
+				slogger.Log.Warn("Failed to retry the task", slogger.E(err), slog.Int("task.id", int(task.Id)))
+			}
 		}
-		testReport = rawLog
+		return nil
+	}
+	if len(podList.Items) != 1 {
+		return xerrors.Define("Target pods not found or found more than 1").WithStack()
+	}
+	buildPod := podList.Items[0]
+
+	podLog, err := b.podLog(ctx, buildPod.Name)
+	if err != nil {
+		return err
+	}
+
+	// The log of the report container is the summary of the build event stream.
+	reportLog, err := b.client.CoreV1.GetPodLogs(ctx, b.Namespace, buildPod.Name, &corev1.PodLogOptions{Container: b.jobBuilder.ReportContainerName})
+	if err != nil {
+		// The pods which are created before the report container is added to all tasks don't have it.
+		slogger.Log.Warn("Failed to get the log of the report container", slogger.E(err), slog.Int("task.id", int(task.Id)))
 	}
 
-	if err := b.storage.Put(context.Background(), job.Name, buf.Bytes()); err != nil {
+	if err := b.storage.Put(context.Background(), job.Name, podLog); err != nil {
 		return xerrors.WithStack(err)
 	}
 	task.LogFile = job.Name
 
-	if len(testReport) > 0 {
-		if err := b.updateTestReport(ctx, testReport, repo, task); err != nil {
+	if len(reportLog) > 0 {
+		var report sidecar.BuildReport
+		// The report is followed by the error message if the report container failed to upload the artifacts.
+		if err := json.NewDecoder(bytes.NewReader(reportLog)).Decode(&report); err != nil {
 			slogger.Log.Warn("Failed to parse the report json", slogger.E(err))
+		} else {
+			// The quarantined labels are read before the flakiness is updated by this report.
+			if !success && jobConfiguration.IgnoreQuarantinedTests {
+				if cond, _ := failureCondition(&buildPod, b.jobBuilder.BuildContainerName); cond == config.RetryOnTestFailure {
+					quarantined, err := b.flakyDetector.QuarantinedLabels(ctx, repo.Id)
+					if err != nil {
+						slogger.Log.Warn("Failed to get the quarantined tests", slogger.E(err), slog.Int("task.id", int(task.Id)))
+					} else if failedOnlyByQuarantinedTests(&report.TestReport, quarantined) {
+						slogger.Log.Info("Ignore the failures of the quarantined tests", slog.Int("task.id", int(task.Id)))
+						success = true
+						task.Success = true
+					}
+				}
+			}
+			if jobConfiguration.Command == "test" && task.IsTrunk {
+				if err := b.updateTestReport(ctx, &report.TestReport, repo, task); err != nil {
+					slogger.Log.Warn("Failed to save the test report", slogger.E(err), slog.Int("task.id", int(task.Id)))
+				} else {
+					labels := make([]string, len(report.Tests))
+					for i, v := range report.Tests {
+						labels[i] = v.Label
+					}
+					if err := b.flakyDetector.Update(ctx, repo.Id, labels); err != nil {
+						slogger.Log.Warn("Failed to update the flakiness of tests", slogger.E(err), slog.Int("task.id", int(task.Id)))
+					}
+				}
+			}
+			if err := b.updateBuildResult(ctx, &report, task); err != nil {
+				slogger.Log.Warn("Failed to save the build result", slogger.E(err), slog.Int("task.id", int(task.Id)))
+			}
+			if err := b.saveArtifacts(ctx, report.Artifacts, task); err != nil {
+				slogger.Log.Warn("Failed to save the artifacts", slogger.E(err), slog.Int("task.id", int(task.Id)))
+			}
+		}
+	}
+
+	if !success {
+		retry, err := b.retryTask(ctx, repo, jobConfiguration, task, &buildPod)
+		if err != nil {
+			slogger.Log.Warn("Failed to retry the task", slogger.E(err), slog.Int("task.id", int(task.Id)))
+		}
+		if retry != nil {
+			// The status of the commit is reported by the retry task.
+			return nil
 		}
 	}
 
@@ -860,12 +1462,122 @@ func (b *BazelBuilder) postProcess(ctx context.Context, job *batchv1.Job, repo *
 	return nil
 }
 
-func (b *BazelBuilder) updateTestReport(ctx context.Context, reportJSON []byte, repo *database.SourceRepository, task *database.Task) error {
-	var report sidecar.TestReport
-	if err := json.Unmarshal(reportJSON, &report); err != nil {
-		return xerrors.WithStack(err)
+// failedOnlyByQuarantinedTests returns true if some tests failed and all of them are quarantined.
+func failedOnlyByQuarantinedTests(report *sidecar.TestReport, quarantined []string) bool {
+	var failed bool
+	for _, v := range report.Tests {
+		if v.Status == sidecar.TestStatusPassed || v.Status == sidecar.TestStatusFlaky {
+			continue
+		}
+		if !slices.Contains(quarantined, v.Label) {
+			return false
+		}
+		failed = true
+	}
+	return failed
+}
+
+// bazelExitCodeTestFailed is the exit code of bazel when the build succeeded but some tests failed.
+const bazelExitCodeTestFailed = 3
+
+// failureCondition classifies the failure of the build pod for the retry policy.
+// The second return value is the exit code of the build container.
+// If pod is nil, the pod has gone before the coordinator observed it. It is regarded as the eviction.
+func failureCondition(pod *corev1.Pod, containerName string) (config.RetryCondition, int) {
+	if pod == nil {
+		return config.RetryOnEviction, 0
+	}
+	if pod.Status == nil {
+		return "", 0
+	}
+	if pod.Status.Reason == "Evicted" {
+		return config.RetryOnEviction, 0
+	}
+	for _, v := range pod.Status.ContainerStatuses {
+		if v.Name != containerName || v.State == nil || v.State.Terminated == nil {
+			continue
+		}
+		terminated := v.State.Terminated
+		switch {
+		case terminated.Reason == "OOMKilled":
+			return config.RetryOnEviction, terminated.ExitCode
+		case terminated.ExitCode == bazelExitCodeTestFailed:
+			return config.RetryOnTestFailure, terminated.ExitCode
+		case terminated.ExitCode != 0:
+			return config.RetryOnExitCode, terminated.ExitCode
+		}
+	}
+	return "", 0
+}
+
+// retryTask creates and starts the next attempt of task if the retry policy of job allows it.
+// The returned task is nil if task is not retried.
+func (b *BazelBuilder) retryTask(ctx context.Context, repo *database.SourceRepository, job *config.JobV2, task *database.Task, pod *corev1.Pod) (*database.Task, error) {
+	if job.Retry == nil {
+		return nil, nil
+	}
+	cond, exitCode := failureCondition(pod, b.jobBuilder.BuildContainerName)
+	// The tasks created before the retry policy was introduced don't have the attempt number.
+	attempt := max(int(task.Attempt), 1)
+	if cond == "" || !job.Retry.ShouldRetry(attempt, cond, exitCode) {
+		return nil, nil
+	}
+
+	newTask, err := b.dao.Task.Create(ctx, &database.Task{
+		RepositoryId:           task.RepositoryId,
+		JobName:                task.JobName,
+		ParsedJobConfiguration: task.ParsedJobConfiguration,
+		Revision:               task.Revision,
+		IsTrunk:                task.IsTrunk,
+		BazelVersion:           task.BazelVersion,
+		Command:                task.Command,
+		Targets:                task.Targets,
+		Platform:               task.Platform,
+		Via:                    task.Via,
+		ConfigName:             task.ConfigName,
+		Attempt:                int32(attempt + 1),
+		ParentTaskId:           task.Id,
+		ConcurrencyGroup:       task.ConcurrencyGroup,
+		Matrix:                 task.Matrix,
+	})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
 	}
+	slogger.Log.Info("Retry the task",
+		slog.Int("task.id", int(task.Id)),
+		slog.Int("retry_task.id", int(newTask.Id)),
+		slog.String("condition", string(cond)),
+		slog.Int("attempt", attempt+1),
+	)
+
+	if err := b.buildJob(ctx, repo, job, newTask); err != nil {
+		if errors.Is(err, ErrOtherTaskIsRunning) {
+			slogger.Log.Info("Enqueue the task", slog.Int("task.id", int(newTask.Id)))
+			b.taskQueue.Enqueue(job, newTask)
+			return newTask, nil
+		}
+
+		newTask.Success = false
+		newTask.FinishedAt = new(time.Now())
+		if err := b.dao.Task.Update(ctx, newTask); err != nil {
+			slogger.Log.Warn("Failed update task", slogger.E(err), slog.Int("task.id", int(newTask.Id)))
+		}
+		// The retry task has failed to start. The original task reports the result.
+		return nil, err
+	}
+	if err := b.dao.Task.Update(ctx, newTask); err != nil {
+		return newTask, xerrors.WithStack(err)
+	}
+
+	if job.GitHubStatus {
+		if err := b.updateGithubStatus(ctx, repo, job, newTask, "pending"); err != nil {
+			slogger.Log.Warn("Failure update the status of github", slogger.E(err), slog.Int("task.id", int(newTask.Id)))
+		}
+	}
+	return newTask, nil
+}
 
+func (b *BazelBuilder) updateTestReport(ctx context.Context, report *sidecar.TestReport, repo *database.SourceRepository, task *database.Task) error {
 	task.ExecutedTestsCount = int32(len(report.Tests))
 	var succeededCount int32
 	for _, s := range report.Tests {
@@ -896,6 +1608,75 @@ func (b *BazelBuilder) updateTestReport(ctx context.Context, reportJSON []byte,
 	return nil
 }
 
+// updateBuildResult saves the outcome of targets, the failed actions and the metrics of the build which are reported by the build event stream.
+func (b *BazelBuilder) updateBuildResult(ctx context.Context, report *sidecar.BuildReport, task *database.Task) error {
+	for _, v := range report.Targets {
+		_, err := b.dao.TargetResult.Create(ctx, &database.TargetResult{
+			TaskId:          task.Id,
+			Label:           v.Label,
+			Success:         v.Success,
+			FailureCategory: v.FailureCategory,
+			FailureMessage:  v.FailureMessage,
+		})
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+	for _, v := range report.ActionFailures {
+		_, err := b.dao.ActionFailure.Create(ctx, &database.ActionFailure{
+			TaskId:          task.Id,
+			Label:           v.Label,
+			Mnemonic:        v.Mnemonic,
+			ExitCode:        int32(v.ExitCode),
+			FailureCategory: v.FailureCategory,
+			FailureMessage:  v.FailureMessage,
+			PrimaryOutput:   v.PrimaryOutput,
+		})
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+	if m := report.Metrics; m != nil {
+		_, err := b.dao.BuildMetrics.Create(ctx, &database.BuildMetrics{
+			TaskId:               task.Id,
+			ExitCodeName:         m.ExitCodeName,
+			ActionsCreated:       m.ActionsCreated,
+			ActionsExecuted:      m.ActionsExecuted,
+			RemoteCacheHits:      m.RemoteCacheHits,
+			ActionCacheHits:      int32(m.ActionCacheHits),
+			ActionCacheMisses:    int32(m.ActionCacheMisses),
+			TargetsConfigured:    m.TargetsConfigured,
+			WallTimeMs:           m.WallTimeMs,
+			CpuTimeMs:            m.CpuTimeMs,
+			AnalysisPhaseTimeMs:  m.AnalysisPhaseTimeMs,
+			ExecutionPhaseTimeMs: m.ExecutionPhaseTimeMs,
+		})
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+// saveArtifacts records the artifacts which are uploaded by the report container.
+func (b *BazelBuilder) saveArtifacts(ctx context.Context, artifacts []sidecar.Artifact, task *database.Task) error {
+	for _, v := range artifacts {
+		_, err := b.dao.Artifact.Create(ctx, &database.Artifact{
+			TaskId:     task.Id,
+			Label:      v.Label,
+			Path:       v.Path,
+			ObjectName: v.ObjectName,
+			Size:       v.Size,
+		})
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
 func (b *BazelBuilder) updateGithubStatus(ctx context.Context, repo *database.SourceRepository, job *config.JobV2, task *database.Task, state string) error {
 	if task.Revision == "" {
 		return nil
@@ -914,7 +1695,7 @@ func (b *BazelBuilder) updateGithubStatus(ctx context.Context, repo *database.So
 	owner, repoName := s[1], s[2]
 
 	targetUrl := ""
-	if state == "success" || state == "failure" {
+	if state == "success" || state == "failure" || state == "error" {
 		targetUrl = fmt.Sprintf("%s/task/%d", b.dashboardUrl, task.Id)
 	}
 
@@ -925,7 +1706,7 @@ func (b *BazelBuilder) updateGithubStatus(ctx context.Context, repo *database.So
 		task.Revision,
 		github.RepoStatus{
 			State:     new(state),
-			Context:   new(fmt.Sprintf("%s %s", task.Command, job.Name)),
+			Context:   new(statusContext(job, task)),
 			TargetURL: new(targetUrl),
 		},
 	)
@@ -936,6 +1717,18 @@ func (b *BazelBuilder) updateGithubStatus(ctx context.Context, repo *database.So
 	return nil
 }
 
+// statusContext returns the context of the commit status of task.
+// The tasks expanded by the matrix have the values of the axes in the context to distinguish them.
+func statusContext(job *config.JobV2, task *database.Task) string {
+	var axes map[string]string
+	if task.Matrix != "" {
+		if err := json.Unmarshal([]byte(task.Matrix), &axes); err != nil {
+			axes = nil
+		}
+	}
+	return config.StatusContext(task.Command, job.Name, axes)
+}
+
 func (b *BazelBuilder) buildJobTemplate(repo *database.SourceRepository, job *config.JobV2, task *database.Task, platform string) ([]runtime.Object, error) {
 	jobBuilder := b.jobBuilder.Clone()
 	builtObjects, err := jobBuilder.
@@ -11,6 +11,8 @@ import (
 	"go.f110.dev/kubeproto/go/k8sclient"
 	fakesecretstoreclient "sigs.k8s.io/secrets-store-csi-driver/pkg/client/clientset/versioned/fake"
 
+	"go.f110.dev/mono/go/build/cmd/sidecar"
+	"go.f110.dev/mono/go/build/config"
 	"go.f110.dev/mono/go/build/database"
 	"go.f110.dev/mono/go/build/database/dao"
 	"go.f110.dev/mono/go/build/database/dao/daotest"
@@ -57,6 +59,7 @@ func TestBazelBuilder_SyncJob(t *testing.T) {
 
 	mockDAO.Repository.RegisterSelect(1, &database.SourceRepository{Id: 1, Url: "https://github.com/f110/mono"})
 	mockDAO.Task.RegisterSelect(1, &database.Task{Id: 1})
+	mockDAO.Task.RegisterListByRevision(1, "", []*database.Task{}, nil)
 	target := k8sfactory.JobFactory(nil,
 		k8sfactory.Namespace(metav1.NamespaceDefault),
 		k8sfactory.Name(t.Name()),
@@ -170,6 +173,7 @@ func TestBazelBuilder_SyncJob(t *testing.T) {
 		})
 
 		mockDAO.Task.RegisterSelect(4, &database.Task{Id: 4, JobObjectName: "force-stopped"})
+		b.runningLogs[4] = &runningLog{podName: "force-stopped"}
 		target := k8sfactory.JobFactory(target,
 			k8sfactory.Name("force-stopped"),
 			k8sfactory.CreatedAt(time.Now().Add(-2*time.Hour)),
@@ -190,12 +194,48 @@ func TestBazelBuilder_SyncJob(t *testing.T) {
 		require.Len(t, called, 1)
 		updated := called[0].Args["task"].(*database.Task)
 		assertion.NotNil(t, updated.FinishedAt)
+		assertion.NotContains(t, b.runningLogs, int32(4))
 		finalizerRemoved := k8sfactory.JobFactory(target, k8sfactory.RemoveFinalizer(bazelBuilderControllerFinalizerName))
 		runner.AssertUpdateAction(t, "", finalizerRemoved)
 		runner.AssertDeleteAction(t, finalizerRemoved)
 		runner.AssertNoUnexpectedAction(t)
 	})
 
+	t.Run("Skip downstream task", func(t *testing.T) {
+		t.Cleanup(func() {
+			mockDAO.Task.Reset()
+			runner.Reset()
+		})
+
+		downstreamConf, err := config.MarshalJob(&config.JobV2{Name: "push", Command: "run", Needs: []string{"test"}})
+		require.NoError(t, err)
+		upstream := &database.Task{Id: 5, JobName: "test", Revision: "abcdef", JobObjectName: "upstream"}
+		mockDAO.Task.RegisterSelect(5, upstream)
+		downstream := &database.Task{Id: 6, JobName: "push", Revision: "abcdef", ParsedJobConfiguration: downstreamConf}
+		downstream.ResetMark()
+		mockDAO.Task.RegisterListByRevision(1, "abcdef", []*database.Task{
+			downstream,
+			{Id: 5, JobName: "test", Revision: "abcdef", FinishedAt: new(time.Now())},
+		}, nil)
+		target := k8sfactory.JobFactory(target,
+			k8sfactory.Name("upstream"),
+			k8sfactory.Label(labelKeyTaskId, "5"),
+			k8sfactory.Label(labelKeyForceStop, "true"),
+			k8sfactory.MatchLabelSelector(map[string]string{labelKeyRepoId: "1", labelKeyTaskId: "5"}),
+		)
+		runner.RegisterFixture(target)
+		err = b.syncJob(target)
+		require.NoError(t, err)
+
+		called := mockDAO.Task.Called("Update")
+		require.Len(t, called, 2)
+		updated := called[1].Args["task"].(*database.Task)
+		assertion.Equal(t, int32(6), updated.Id)
+		assertion.True(t, updated.Skipped)
+		assertion.NotNil(t, updated.FinishedAt)
+		assertion.Nil(t, updated.StartAt)
+	})
+
 	t.Run("Delete Job", func(t *testing.T) {
 		t.Cleanup(func() {
 			mockDAO.Task.Reset()
@@ -203,6 +243,7 @@ func TestBazelBuilder_SyncJob(t *testing.T) {
 		})
 
 		mockDAO.Task.RegisterSelect(3, &database.Task{Id: 3})
+		b.runningLogs[3] = &runningLog{podName: t.Name()}
 		target := k8sfactory.JobFactory(target,
 			k8sfactory.Name(t.Name()),
 			k8sfactory.Delete,
@@ -216,6 +257,7 @@ func TestBazelBuilder_SyncJob(t *testing.T) {
 		require.Len(t, called, 1)
 		updated := called[0].Args["task"].(*database.Task)
 		assertion.NotNil(t, updated.FinishedAt)
+		assertion.NotContains(t, b.runningLogs, int32(3))
 
 		runner.AssertUpdateAction(t, "", k8sfactory.JobFactory(target, k8sfactory.RemoveFinalizer(t.Name())))
 		runner.AssertNoUnexpectedAction(t)
@@ -318,6 +360,186 @@ func TestRunningSameJob(t *testing.T) {
 	}
 }
 
+func TestCheckDependencies(t *testing.T) {
+	now := time.Now()
+	job := &config.JobV2{Name: "push", Needs: []string{"test", "lint"}}
+	cases := []struct {
+		name     string
+		taskList []*database.Task
+		want     dependencyState
+		upstream string
+	}{
+		{
+			name: "All upstream jobs succeeded",
+			taskList: []*database.Task{
+				{Id: 2, JobName: "lint", FinishedAt: &now, Success: true},
+				{Id: 1, JobName: "test", FinishedAt: &now, Success: true},
+			},
+			want: dependencySatisfied,
+		},
+		{
+			name: "Upstream job is running",
+			taskList: []*database.Task{
+				{Id: 2, JobName: "lint", FinishedAt: &now, Success: true},
+				{Id: 1, JobName: "test"},
+			},
+			want:     dependencyWaiting,
+			upstream: "test",
+		},
+		{
+			name: "Upstream job failed",
+			taskList: []*database.Task{
+				{Id: 2, JobName: "lint"},
+				{Id: 1, JobName: "test", FinishedAt: &now},
+			},
+			want:     dependencyFailed,
+			upstream: "test",
+		},
+		{
+			name: "Upstream job didn't run",
+			taskList: []*database.Task{
+				{Id: 1, JobName: "test", FinishedAt: &now, Success: true},
+			},
+			want:     dependencyFailed,
+			upstream: "lint",
+		},
+		{
+			name: "Failed upstream job was retried successfully",
+			taskList: []*database.Task{
+				{Id: 3, JobName: "test", FinishedAt: &now, Success: true},
+				{Id: 2, JobName: "lint", FinishedAt: &now, Success: true},
+				{Id: 1, JobName: "test", FinishedAt: &now},
+			},
+			want: dependencySatisfied,
+		},
+		{
+			name: "One of the platforms failed",
+			taskList: []*database.Task{
+				{Id: 3, JobName: "test", Platform: "linux_arm64", FinishedAt: &now},
+				{Id: 2, JobName: "test", Platform: "linux_amd64", FinishedAt: &now, Success: true},
+				{Id: 1, JobName: "lint", FinishedAt: &now, Success: true},
+			},
+			want:     dependencyFailed,
+			upstream: "test",
+		},
+		{
+			name: "One of the matrix cells on the same platform failed",
+			taskList: []*database.Task{
+				{Id: 3, JobName: "test", Platform: "linux_amd64", BazelVersion: "8.0.0", Matrix: `{"bazel_version":"8.0.0"}`, FinishedAt: &now, Success: true},
+				{Id: 2, JobName: "test", Platform: "linux_amd64", BazelVersion: "7.4.0", Matrix: `{"bazel_version":"7.4.0"}`, FinishedAt: &now},
+				{Id: 1, JobName: "lint", FinishedAt: &now, Success: true},
+			},
+			want:     dependencyFailed,
+			upstream: "test",
+		},
+		{
+			name: "One of the matrix cells on the same platform is running",
+			taskList: []*database.Task{
+				{Id: 3, JobName: "test", Platform: "linux_amd64", ConfigName: "ci", FinishedAt: &now, Success: true},
+				{Id: 2, JobName: "test", Platform: "linux_amd64", ConfigName: "race"},
+				{Id: 1, JobName: "lint", FinishedAt: &now, Success: true},
+			},
+			want:     dependencyWaiting,
+			upstream: "test",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, upstream := checkDependencies(tc.taskList, job)
+			assertion.Equal(t, tc.want, got)
+			assertion.Equal(t, tc.upstream, upstream)
+		})
+	}
+}
+
+func TestFailureCondition(t *testing.T) {
+	terminated := func(reason string, exitCode int) *corev1.Pod {
+		return &corev1.Pod{Status: &corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "sidecar", State: &corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}}},
+				{Name: "main", State: &corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: reason, ExitCode: exitCode}}},
+			},
+		}}
+	}
+	cases := []struct {
+		name     string
+		pod      *corev1.Pod
+		want     config.RetryCondition
+		exitCode int
+	}{
+		{name: "Pod has gone", want: config.RetryOnEviction},
+		{name: "Evicted", pod: &corev1.Pod{Status: &corev1.PodStatus{Reason: "Evicted"}}, want: config.RetryOnEviction},
+		{name: "OOMKilled", pod: terminated("OOMKilled", 137), want: config.RetryOnEviction, exitCode: 137},
+		{name: "Test failed", pod: terminated("Error", 3), want: config.RetryOnTestFailure, exitCode: 3},
+		{name: "Build failed", pod: terminated("Error", 1), want: config.RetryOnExitCode, exitCode: 1},
+		{name: "Remote cache error", pod: terminated("Error", 34), want: config.RetryOnExitCode, exitCode: 34},
+		{name: "Succeeded", pod: terminated("Completed", 0)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, exitCode := failureCondition(tc.pod, "main")
+			assertion.Equal(t, tc.want, got)
+			assertion.Equal(t, tc.exitCode, exitCode)
+		})
+	}
+}
+
+func TestFailedOnlyByQuarantinedTests(t *testing.T) {
+	quarantined := []string{"//go/flaky:flaky_test"}
+	cases := []struct {
+		name  string
+		tests []sidecar.TestSummary
+		want  bool
+	}{
+		{
+			name: "Only quarantined test failed",
+			tests: []sidecar.TestSummary{
+				{Label: "//go/flaky:flaky_test", Status: sidecar.TestStatusFailed},
+				{Label: "//go/stable:stable_test", Status: sidecar.TestStatusPassed},
+			},
+			want: true,
+		},
+		{
+			name: "Not quarantined test failed",
+			tests: []sidecar.TestSummary{
+				{Label: "//go/flaky:flaky_test", Status: sidecar.TestStatusFailed},
+				{Label: "//go/stable:stable_test", Status: sidecar.TestStatusFailed},
+			},
+		},
+		{
+			name: "No test failed",
+			tests: []sidecar.TestSummary{
+				{Label: "//go/flaky:flaky_test", Status: sidecar.TestStatusFlaky},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assertion.Equal(t, tc.want, failedOnlyByQuarantinedTests(&sidecar.TestReport{Tests: tc.tests}, quarantined))
+		})
+	}
+}
+
+func TestRunningLog_Append(t *testing.T) {
+	l := &runningLog{log: []byte("----- main -----\n")}
+	l.append([]byte("2026-01-02T03:04:05.100000000Z Loading\n" +
+		"2026-01-02T03:04:05.200000000Z Analyzing\n" +
+		"2026-01-02T03:04:05.200000000Z Analyzed\n" +
+		"2026-01-02T03:04:06.000000000Z INFO: Build"))
+	assertion.Equal(t, "----- main -----\nLoading\nAnalyzing\nAnalyzed\n", string(l.log))
+
+	// The lines from the beginning of the second of the last line are returned again.
+	l.append([]byte("2026-01-02T03:04:05.100000000Z Loading\n" +
+		"2026-01-02T03:04:05.200000000Z Analyzing\n" +
+		"2026-01-02T03:04:05.200000000Z Analyzed\n" +
+		"2026-01-02T03:04:05.200000000Z Analyzed 1 target\n" +
+		"2026-01-02T03:04:06.000000000Z INFO: Build completed\n"))
+	assertion.Equal(t, "----- main -----\nLoading\nAnalyzing\nAnalyzed\nAnalyzed 1 target\nINFO: Build completed\n", string(l.log))
+}
+
 func TestBazelBuilder_ForceStop(t *testing.T) {
 	runner := controllertest.NewGenericTestRunner[*batchv1.Job]()
 	coreInformer := k8sclient.NewCoreV1Informer(runner.CoreSharedInformerFactory.Cache(), runner.CoreClient.CoreV1, metav1.NamespaceDefault, 30*time.Second)
@@ -372,3 +594,248 @@ func TestBazelBuilder_ForceStop(t *testing.T) {
 	require.NoError(t, err)
 	assertion.Contains(t, updatedJob.GetLabels(), labelKeyForceStop)
 }
+
+func TestBazelBuilder_CancelTasks(t *testing.T) {
+	runner := controllertest.NewGenericTestRunner[*batchv1.Job]()
+	coreInformer := k8sclient.NewCoreV1Informer(runner.CoreSharedInformerFactory.Cache(), runner.CoreClient.CoreV1, metav1.NamespaceDefault, 30*time.Second)
+	batchInformer := k8sclient.NewBatchV1Informer(runner.CoreSharedInformerFactory.Cache(), runner.CoreClient.BatchV1, metav1.NamespaceDefault, 30*time.Second)
+	mockDAO := struct {
+		Repository *daotest.SourceRepository
+		Task       *daotest.Task
+	}{
+		Repository: daotest.NewSourceRepository(),
+		Task:       daotest.NewTask(),
+	}
+	mockDAO.Task.RegisterListPending([]*database.Task{}, nil)
+	b, err := NewBazelBuilder(
+		"",
+		KubernetesOptions{
+			BatchInformer:     batchInformer,
+			CoreInformer:      coreInformer,
+			Client:            &runner.CoreClient.Set,
+			SecretStoreClient: fakesecretstoreclient.NewSimpleClientset(),
+		},
+		dao.Options{
+			Repository: mockDAO.Repository,
+			Task:       mockDAO.Task,
+		},
+		metav1.NamespaceDefault,
+		nil,
+		"foo",
+		storage.S3Options{},
+		BazelOptions{},
+		nil,
+		nil,
+		false,
+	)
+	require.NoError(t, err)
+
+	running := &database.Task{Id: 1, RepositoryId: 1, JobObjectName: t.Name(), ConcurrencyGroup: "f110/mono/test/pr-1"}
+	queued := &database.Task{Id: 2, RepositoryId: 1, ConcurrencyGroup: "f110/mono/test/pr-1"}
+	b.taskQueue.EnqueueById(concurrencyQueueId(1, queued.ConcurrencyGroup), queued)
+	mockDAO.Task.RegisterSelect(1, running)
+	target := k8sfactory.JobFactory(nil,
+		k8sfactory.Namespace(metav1.NamespaceDefault),
+		k8sfactory.Name(t.Name()),
+		k8sfactory.CreatedAt(time.Now().Add(-1*time.Minute)),
+		k8sfactory.Labels(map[string]string{labelKeyRepoId: "1", labelKeyTaskId: "1"}),
+		k8sfactory.Finalizer(bazelBuilderControllerFinalizerName),
+		k8sfactory.MatchLabelSelector(map[string]string{labelKeyRepoId: "1", labelKeyTaskId: "1"}),
+	)
+	runner.RegisterFixture(target)
+
+	b.cancelTasks(t.Context(), []*database.Task{running, queued}, "superseded")
+
+	assertion.Equal(t, "superseded", running.CancelReason)
+	assertion.Nil(t, running.FinishedAt)
+	updatedJob, err := runner.CoreClient.BatchV1.GetJob(t.Context(), metav1.NamespaceDefault, t.Name(), metav1.GetOptions{})
+	require.NoError(t, err)
+	assertion.Contains(t, updatedJob.GetLabels(), labelKeyForceStop)
+
+	assertion.Equal(t, "superseded", queued.CancelReason)
+	assertion.NotNil(t, queued.FinishedAt)
+	assertion.Nil(t, b.taskQueue.DequeueById(concurrencyQueueId(1, queued.ConcurrencyGroup)))
+}
+
+func TestBazelBuilder_BuildConcurrencyGroup(t *testing.T) {
+	newBuilder := func(t *testing.T, sameRevision, inProgress []*database.Task) *BazelBuilder {
+		mockDAO := struct {
+			Repository *daotest.SourceRepository
+			Task       *daotest.Task
+		}{
+			Repository: daotest.NewSourceRepository(),
+			Task:       daotest.NewTask(),
+		}
+		mockDAO.Task.RegisterListPending([]*database.Task{}, nil)
+		mockDAO.Task.RegisterListByRevision(1, "rev2", sameRevision, nil)
+		mockDAO.Task.RegisterListUnfinishedByConcurrencyGroup(1, "f110/mono", inProgress, nil)
+		b, err := NewBazelBuilder(
+			"",
+			KubernetesOptions{},
+			dao.Options{
+				Repository: mockDAO.Repository,
+				Task:       mockDAO.Task,
+			},
+			metav1.NamespaceDefault,
+			nil,
+			"foo",
+			storage.S3Options{},
+			BazelOptions{},
+			nil,
+			nil,
+			false,
+		)
+		require.NoError(t, err)
+		return b
+	}
+	repo := &database.SourceRepository{Id: 1, Url: "https://github.com/f110/mono", CloneUrl: "https://github.com/f110/mono.git"}
+	newJob := func(cancelInProgress bool) *config.JobV2 {
+		return &config.JobV2{
+			Name:            "test",
+			RepositoryOwner: "f110",
+			RepositoryName:  "mono",
+			Command:         "test",
+			Targets:         []string{"//..."},
+			Platforms:       []string{"@rules_go//go/toolchain:linux_amd64"},
+			// The group doesn't have the name of the job, so all jobs of the same push share the group.
+			Concurrency: &config.ConcurrencyPolicy{Group: "{{ .Repository }}", CancelInProgress: cancelInProgress},
+		}
+	}
+
+	t.Run("CancelInProgress", func(t *testing.T) {
+		sibling := &database.Task{Id: 1, RepositoryId: 1, JobName: "lint", Revision: "rev2", CreatedAt: time.Now(), StartAt: new(time.Now()), JobObjectName: "lint", ConcurrencyGroup: "f110/mono"}
+		old := &database.Task{Id: 2, RepositoryId: 1, JobName: "test", Revision: "rev1", ConcurrencyGroup: "f110/mono"}
+		b := newBuilder(t, []*database.Task{sibling}, []*database.Task{sibling, old})
+
+		job := newJob(true)
+		tasks, err := b.Build(t.Context(), repo, job, "rev2", "", "test", job.Targets, job.Platforms, "push", true, config.SourceRef{})
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assertion.NotNil(t, tasks[0].StartAt)
+
+		assertion.Equal(t, "", sibling.CancelReason)
+		assertion.Nil(t, sibling.FinishedAt)
+		assertion.Equal(t, "superseded by rev2", old.CancelReason)
+		assertion.NotNil(t, old.FinishedAt)
+	})
+
+	t.Run("Wait", func(t *testing.T) {
+		sibling := &database.Task{Id: 1, RepositoryId: 1, JobName: "lint", Revision: "rev2", CreatedAt: time.Now(), StartAt: new(time.Now()), JobObjectName: "lint", ConcurrencyGroup: "f110/mono"}
+		b := newBuilder(t, []*database.Task{sibling}, []*database.Task{sibling})
+
+		job := newJob(false)
+		tasks, err := b.Build(t.Context(), repo, job, "rev2", "", "test", job.Targets, job.Platforms, "push", true, config.SourceRef{})
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		// The running task of the same revision doesn't block the task.
+		assertion.NotNil(t, tasks[0].StartAt)
+		assertion.Nil(t, b.taskQueue.DequeueById(concurrencyQueueId(1, "f110/mono")))
+	})
+
+	t.Run("Downstream", func(t *testing.T) {
+		downstreamConf, err := config.MarshalJob(&config.JobV2{Name: "push", Command: "run", Needs: []string{"test"}})
+		require.NoError(t, err)
+		upstream := &database.Task{Id: 1, RepositoryId: 1, JobName: "test", Revision: "rev2", FinishedAt: new(time.Now()), Success: true, ConcurrencyGroup: "f110/mono"}
+		downstream := &database.Task{Id: 2, RepositoryId: 1, JobName: "push", Revision: "rev2", ParsedJobConfiguration: downstreamConf, ConcurrencyGroup: "f110/mono"}
+		running := &database.Task{Id: 3, RepositoryId: 1, JobName: "test", Revision: "rev3", StartAt: new(time.Now()), JobObjectName: "test", ConcurrencyGroup: "f110/mono"}
+		b := newBuilder(t, []*database.Task{downstream, upstream}, []*database.Task{running, downstream})
+
+		require.NoError(t, b.startDownstreamTasks(t.Context(), repo, upstream))
+		// The downstream task waits for the running task of the other revision.
+		assertion.Nil(t, downstream.StartAt)
+		assertion.Nil(t, downstream.FinishedAt)
+		queued := b.taskQueue.DequeueById(concurrencyQueueId(1, "f110/mono"))
+		require.NotNil(t, queued)
+		assertion.Equal(t, int32(2), queued.Id)
+	})
+}
+
+func TestBazelBuilder_RetryTask(t *testing.T) {
+	runner := controllertest.NewGenericTestRunner[*batchv1.Job]()
+	coreInformer := k8sclient.NewCoreV1Informer(runner.CoreSharedInformerFactory.Cache(), runner.CoreClient.CoreV1, metav1.NamespaceDefault, 30*time.Second)
+	batchInformer := k8sclient.NewBatchV1Informer(runner.CoreSharedInformerFactory.Cache(), runner.CoreClient.BatchV1, metav1.NamespaceDefault, 30*time.Second)
+	mockDAO := struct {
+		Repository *daotest.SourceRepository
+		Task       *daotest.Task
+	}{
+		Repository: daotest.NewSourceRepository(),
+		Task:       daotest.NewTask(),
+	}
+	mockDAO.Task.RegisterListPending([]*database.Task{}, nil)
+	b, err := NewBazelBuilder(
+		"",
+		KubernetesOptions{
+			BatchInformer:     batchInformer,
+			CoreInformer:      coreInformer,
+			Client:            &runner.CoreClient.Set,
+			SecretStoreClient: fakesecretstoreclient.NewSimpleClientset(),
+		},
+		dao.Options{
+			Repository: mockDAO.Repository,
+			Task:       mockDAO.Task,
+		},
+		metav1.NamespaceDefault,
+		nil,
+		"foo",
+		storage.S3Options{},
+		BazelOptions{},
+		nil,
+		nil,
+		false,
+	)
+	require.NoError(t, err)
+
+	repo := &database.SourceRepository{Id: 1, Url: "https://github.com/f110/mono", CloneUrl: "https://github.com/f110/mono.git"}
+	jobConfiguration := &config.JobV2{
+		Name:            "test",
+		RepositoryOwner: "f110",
+		RepositoryName:  "mono",
+		Command:         "test",
+		Targets:         []string{"//..."},
+		Platforms:       []string{"@rules_go//go/toolchain:linux_amd64"},
+		Retry:           &config.RetryPolicy{MaxAttempts: 2, On: []config.RetryCondition{config.RetryOnEviction}},
+	}
+	// The build pod of the job has gone. It is regarded as the eviction.
+	job := k8sfactory.JobFactory(nil,
+		k8sfactory.Namespace(metav1.NamespaceDefault),
+		k8sfactory.Name(t.Name()),
+		k8sfactory.MatchLabelSelector(map[string]string{labelKeyRepoId: "1", labelKeyTaskId: "1"}),
+	)
+
+	t.Run("Retry", func(t *testing.T) {
+		mockDAO.Task.Reset()
+		task := &database.Task{Id: 1, RepositoryId: 1, JobName: "test", Revision: "rev1", Command: "test", Targets: "//...", Platform: "@rules_go//go/toolchain:linux_amd64", Attempt: 1}
+		err := b.postProcess(t.Context(), job, repo, jobConfiguration, task, false)
+		require.NoError(t, err)
+
+		created := mockDAO.Task.Called("Create")
+		require.Len(t, created, 1)
+		retry := created[0].Args["task"].(*database.Task)
+		assertion.Equal(t, int32(1), retry.ParentTaskId)
+		assertion.Equal(t, int32(2), retry.Attempt)
+		assertion.Equal(t, "rev1", retry.Revision)
+		assertion.NotNil(t, retry.StartAt)
+	})
+
+	t.Run("ReachedLimit", func(t *testing.T) {
+		mockDAO.Task.Reset()
+		task := &database.Task{Id: 2, RepositoryId: 1, JobName: "test", Revision: "rev1", Command: "test", Targets: "//...", Platform: "@rules_go//go/toolchain:linux_amd64", Attempt: 2, ParentTaskId: 1}
+		err := b.postProcess(t.Context(), job, repo, jobConfiguration, task, false)
+		require.NoError(t, err)
+		assertion.Len(t, mockDAO.Task.Called("Create"), 0)
+
+		retry, err := b.retryTask(t.Context(), repo, jobConfiguration, task, nil)
+		require.NoError(t, err)
+		assertion.Nil(t, retry)
+	})
+}
+
+func TestStatusContext(t *testing.T) {
+	job := &config.JobV2{Name: "test_all"}
+
+	assertion.Equal(t, "test test_all", statusContext(job, &database.Task{Command: "test"}))
+	assertion.Equal(t,
+		"test test_all (bazel_version=8.0.0, config=ci)",
+		statusContext(job, &database.Task{Command: "test", Matrix: `{"config":"ci","bazel_version":"8.0.0"}`}),
+	)
+}
@@ -3,6 +3,7 @@ package coordinator
 import (
 	"fmt"
 	"log/slog"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -43,6 +44,9 @@ type JobBuilder struct {
 	remoteAssetAPI           bool
 	vaultAddr                string
 	gitDataServiceURL        string
+	artifactStorageEndpoint  string
+	artifactBucket           string
+	artifactSecretName       string
 	excludeNodes             []string
 
 	PreProcessContainerName string
@@ -152,6 +156,14 @@ func (j *JobBuilder) GitDataService(url string) {
 	j.gitDataServiceURL = url
 }
 
+// ArtifactStorage configures the report container to upload the artifacts of the job to the bucket.
+// The secret has to contain the access key and the secret access key as "accesskey" and "secretkey".
+func (j *JobBuilder) ArtifactStorage(endpoint, bucket, secretName string) {
+	j.artifactStorageEndpoint = endpoint
+	j.artifactBucket = bucket
+	j.artifactSecretName = secretName
+}
+
 func (j *JobBuilder) Clone() *JobBuilder {
 	return &JobBuilder{
 		namespace:               j.namespace,
@@ -173,6 +185,9 @@ func (j *JobBuilder) Clone() *JobBuilder {
 		remoteAssetAPI:          j.remoteAssetAPI,
 		vaultAddr:               j.vaultAddr,
 		gitDataServiceURL:       j.gitDataServiceURL,
+		artifactStorageEndpoint: j.artifactStorageEndpoint,
+		artifactBucket:          j.artifactBucket,
+		artifactSecretName:      j.artifactSecretName,
 
 		workDirVolume:       j.workDirVolume,
 		mainContainer:       j.mainContainer,
@@ -273,6 +288,10 @@ func (j *JobBuilder) Task(task *database.Task) *JobBuilder {
 		}
 		j.mainContainer = k8sfactory.ContainerFactory(j.mainContainer, k8sfactory.Image(fmt.Sprintf("%s:%s", j.bazelImage, imageTag), nil))
 	}
+	// Bazelisk reads the version from .bazelversion. The version of the matrix has to override it.
+	if j.useBazelisk && j.job.Matrix != nil && len(j.job.Matrix.BazelVersions) > 0 && j.task.BazelVersion != "" {
+		j.mainContainer = k8sfactory.ContainerFactory(j.mainContainer, k8sfactory.EnvVar("USE_BAZEL_VERSION", j.task.BazelVersion))
+	}
 
 	j.buildPod = k8sfactory.PodFactory(j.buildPod, k8sfactory.Labels(map[string]string{labelKeyTaskId: strconv.Itoa(int(j.task.Id))}))
 	return j
@@ -324,7 +343,41 @@ func (j *JobBuilder) Build() ([]runtime.Object, error) {
 	}
 	preProcessContainer := k8sfactory.ContainerFactory(j.preProcessContainer, k8sfactory.Args(preProcessArgs...))
 
-	args := []string{j.task.Command}
+	reportContainer := j.reportContainer
+	buildPod := j.buildPod
+	var args []string
+	if len(j.job.Artifacts) > 0 {
+		if j.artifactSecretName == "" {
+			slogger.Log.Warn("Publishing artifacts is not supported", slog.String("repo", j.repo.Name), slog.String("job", j.job.Name))
+		} else {
+			// The report container reads the outputs from the output base which is shared with the main container.
+			outputVolume := k8sfactory.NewEmptyDirVolumeSource("output", "/output")
+			storageSecretVolume := k8sfactory.NewSecretVolumeSource(
+				"artifact-storage",
+				"/etc/artifact",
+				k8sfactory.SecretFactory(nil, k8sfactory.Name(j.artifactSecretName)),
+			)
+			args = append(args, fmt.Sprintf("--output_base=%s/base", outputVolume.Mount.MountPath))
+			reportArgs := []string{
+				fmt.Sprintf("--artifact-prefix=artifacts/%d", j.task.Id),
+				fmt.Sprintf("--storage-endpoint=%s", j.artifactStorageEndpoint),
+				fmt.Sprintf("--storage-bucket=%s", j.artifactBucket),
+				fmt.Sprintf("--storage-access-key-file=%s/accesskey", storageSecretVolume.Mount.MountPath),
+				fmt.Sprintf("--storage-secret-access-key-file=%s/secretkey", storageSecretVolume.Mount.MountPath),
+			}
+			for _, v := range j.job.Artifacts {
+				reportArgs = append(reportArgs, "--artifact="+v)
+			}
+			reportContainer = k8sfactory.ContainerFactory(reportContainer,
+				k8sfactory.Args(slices.Concat(reportContainer.Args, reportArgs)...),
+				k8sfactory.Volume(outputVolume),
+				k8sfactory.Volume(storageSecretVolume),
+			)
+			j.mainContainer = k8sfactory.ContainerFactory(j.mainContainer, k8sfactory.Volume(outputVolume))
+			buildPod = k8sfactory.PodFactory(buildPod, k8sfactory.Volume(outputVolume), k8sfactory.Volume(storageSecretVolume))
+		}
+	}
+	args = append(args, j.task.Command)
 	if j.remoteCache != "" {
 		args = append(args, fmt.Sprintf("--remote_cache=%s", j.remoteCache))
 		if j.remoteAssetAPI {
@@ -391,11 +444,11 @@ func (j *JobBuilder) Build() ([]runtime.Object, error) {
 			},
 		}),
 		k8sfactory.Pod(
-			k8sfactory.PodFactory(j.buildPod,
+			k8sfactory.PodFactory(buildPod,
 				k8sfactory.InitContainer(preProcessContainer),
 				k8sfactory.InitContainer(j.credentialSetupContainer),
 				k8sfactory.Container(mainContainer),
-				k8sfactory.Container(j.reportContainer),
+				k8sfactory.Container(reportContainer),
 				k8sfactory.SortVolume(),
 			),
 		),
@@ -403,11 +456,10 @@ func (j *JobBuilder) Build() ([]runtime.Object, error) {
 	return builtObjects, nil
 }
 
+// makeReportContainer adds the container which reads the build event stream.
+// The report container is added to every task to collect the result of targets, actions and the metrics of the build.
 func (j *JobBuilder) makeReportContainer() {
-	if j.job == nil || j.job.Command != "test" {
-		return
-	}
-	if j.task == nil || !j.task.IsTrunk {
+	if j.job == nil || j.task == nil {
 		return
 	}
 
@@ -103,9 +103,7 @@ func TestJobBuilder(t *testing.T) {
 			ExpectObjects: []runtime.Object{saObject, jobObject},
 			ObjectMutation: map[runtime.Object][]k8sfactory.Trait{
 				jobObject: {
-					RemoveContainer("report"),
-					RemoveVolume("comm"),
-					k8sfactory.OnContainer("main", k8sfactory.Args("run", "--remote_cache=127.0.0.1:4567", "--experimental_remote_downloader=127.0.0.1:4567", "--platforms=@rules_go//go/toolchain:linux_amd64", "//...")),
+					k8sfactory.OnContainer("main", k8sfactory.Args("run", "--remote_cache=127.0.0.1:4567", "--experimental_remote_downloader=127.0.0.1:4567", "--platforms=@rules_go//go/toolchain:linux_amd64", "--build_event_binary_file=/comm/bep", "//...")),
 				},
 			},
 		},
@@ -118,9 +116,7 @@ func TestJobBuilder(t *testing.T) {
 			ExpectObjects: []runtime.Object{saObject, jobObject},
 			ObjectMutation: map[runtime.Object][]k8sfactory.Trait{
 				jobObject: {
-					RemoveContainer("report"),
-					RemoveVolume("comm"),
-					k8sfactory.OnContainer("main", RemoveArgs("--build_event_binary_file=/comm/bep"), AddArgsBefore("--", "--remote_upload_local_results=false")),
+					k8sfactory.OnContainer("main", AddArgsBefore("--", "--remote_upload_local_results=false")),
 				},
 			},
 		},
@@ -201,6 +197,40 @@ func TestJobBuilder(t *testing.T) {
 				},
 			},
 		},
+		{
+			Mutation: func(j *config.JobV2, r *database.SourceRepository, ta *database.Task) (*config.JobV2, *database.SourceRepository, *database.Task) {
+				j.Artifacts = []string{"//cmd/foo", "**/*.tar"}
+				return j, r, ta
+			},
+			BuilderMutation: func(b *JobBuilder) {
+				b.ArtifactStorage("http://minio.storage.svc:9000", "logs", "artifact-secret")
+			},
+			Platform:      "@rules_go//go/toolchain:linux_amd64",
+			ExpectObjects: []runtime.Object{saObject, jobObject},
+			ObjectMutation: map[runtime.Object][]k8sfactory.Trait{
+				jobObject: {
+					k8sfactory.OnContainer("main",
+						AddArgsBefore("test", "--output_base=/output/base"),
+						k8sfactory.Volume(&k8sfactory.VolumeSource{Mount: corev1.VolumeMount{Name: "output", MountPath: "/output"}}),
+					),
+					k8sfactory.OnContainer("report",
+						AddArgs(
+							"--artifact-prefix=artifacts/100",
+							"--storage-endpoint=http://minio.storage.svc:9000",
+							"--storage-bucket=logs",
+							"--storage-access-key-file=/etc/artifact/accesskey",
+							"--storage-secret-access-key-file=/etc/artifact/secretkey",
+							"--artifact=//cmd/foo",
+							"--artifact=**/*.tar",
+						),
+						k8sfactory.Volume(&k8sfactory.VolumeSource{Mount: corev1.VolumeMount{Name: "output", MountPath: "/output"}}),
+					),
+					AddSecretVolume("report", "artifact-storage", "artifact-secret", "/etc/artifact"),
+					AddEmptyVolume("output"),
+					k8sfactory.SortVolume(),
+				},
+			},
+		},
 		{
 			Mutation: func(j *config.JobV2, r *database.SourceRepository, ta *database.Task) (*config.JobV2, *database.SourceRepository, *database.Task) {
 				j.CacheTestResults = true
@@ -270,6 +300,18 @@ func TestJobBuilder(t *testing.T) {
 				},
 			},
 		},
+		{
+			Mutation: func(j *config.JobV2, r *database.SourceRepository, ta *database.Task) (*config.JobV2, *database.SourceRepository, *database.Task) {
+				j.Matrix = &config.Matrix{BazelVersions: []string{"8.0.0"}}
+				ta.BazelVersion = "8.0.0"
+				return j, r, ta
+			},
+			Platform:      "@rules_go//go/toolchain:linux_amd64",
+			ExpectObjects: []runtime.Object{saObject, jobObject},
+			ObjectMutation: map[runtime.Object][]k8sfactory.Trait{
+				jobObject: {k8sfactory.OnContainer("main", k8sfactory.EnvVar("USE_BAZEL_VERSION", "8.0.0"))},
+			},
+		},
 		{
 			Mutation: func(j *config.JobV2, r *database.SourceRepository, ta *database.Task) (*config.JobV2, *database.SourceRepository, *database.Task) {
 				j.Container = "example.com/bazel:bazelisk"
@@ -292,10 +334,8 @@ func TestJobBuilder(t *testing.T) {
 			ExpectObjects: []runtime.Object{saObject, jobObject},
 			ObjectMutation: map[runtime.Object][]k8sfactory.Trait{
 				jobObject: {
-					RemoveContainer("report"),
-					RemoveVolume("comm"),
 					k8sfactory.OnContainer("main",
-						k8sfactory.Args("run", "--remote_cache=127.0.0.1:4567", "--experimental_remote_downloader=127.0.0.1:4567", "--platforms=@rules_go//go/toolchain:linux_amd64", "//...", "--", "--verbose"),
+						k8sfactory.Args("run", "--remote_cache=127.0.0.1:4567", "--experimental_remote_downloader=127.0.0.1:4567", "--platforms=@rules_go//go/toolchain:linux_amd64", "--build_event_binary_file=/comm/bep", "//...", "--", "--verbose"),
 					),
 				},
 			},
@@ -159,6 +159,15 @@ func (d *Task) RegisterListByRevision(repositoryId int32, revision string, value
 	d.Register("ListByRevision", map[string]any{"repositoryId": repositoryId, "revision": revision}, value, err)
 }
 
+func (d *Task) ListUnfinishedByConcurrencyGroup(ctx context.Context, repositoryId int32, concurrencyGroup string, opt ...dao.ListOption) ([]*database.Task, error) {
+	v, err := d.Call("ListUnfinishedByConcurrencyGroup", map[string]any{"repositoryId": repositoryId, "concurrencyGroup": concurrencyGroup})
+	return v.([]*database.Task), err
+}
+
+func (d *Task) RegisterListUnfinishedByConcurrencyGroup(repositoryId int32, concurrencyGroup string, value []*database.Task, err error) {
+	d.Register("ListUnfinishedByConcurrencyGroup", map[string]any{"repositoryId": repositoryId, "concurrencyGroup": concurrencyGroup}, value, err)
+}
+
 func (d *Task) Create(ctx context.Context, task *database.Task, opt ...dao.ExecOption) (*database.Task, error) {
 	_, _ = d.Call("Create", map[string]any{"task": task})
 	return task, nil
@@ -330,6 +339,15 @@ func (d *TestReport) RegisterListByTaskId(taskId int32, value []*database.TestRe
 	d.Register("ListByTaskId", map[string]any{"taskId": taskId}, value, err)
 }
 
+func (d *TestReport) ListByRepositoryAndLabel(ctx context.Context, repositoryId int32, label string, opt ...dao.ListOption) ([]*database.TestReport, error) {
+	v, err := d.Call("ListByRepositoryAndLabel", map[string]any{"repositoryId": repositoryId, "label": label})
+	return v.([]*database.TestReport), err
+}
+
+func (d *TestReport) RegisterListByRepositoryAndLabel(repositoryId int32, label string, value []*database.TestReport, err error) {
+	d.Register("ListByRepositoryAndLabel", map[string]any{"repositoryId": repositoryId, "label": label}, value, err)
+}
+
 func (d *TestReport) Create(ctx context.Context, testReport *database.TestReport, opt ...dao.ExecOption) (*database.TestReport, error) {
 	_, _ = d.Call("Create", map[string]any{"testReport": testReport})
 	return testReport, nil
@@ -587,3 +605,345 @@ func (d *ExternalReleaseHistory) Update(ctx context.Context, externalReleaseHist
 	_, _ = d.Call("Update", map[string]any{"externalReleaseHistory": externalReleaseHistory})
 	return nil
 }
+
+type TargetResult struct {
+	*mock.Mock
+}
+
+func NewTargetResult() *TargetResult {
+	return &TargetResult{Mock: mock.New()}
+}
+
+func (d *TargetResult) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return nil
+}
+
+func (d *TargetResult) Select(ctx context.Context, id int32) (*database.TargetResult, error) {
+	v, err := d.Call("Select", map[string]any{"id": id})
+	return v.(*database.TargetResult), err
+}
+
+func (d *TargetResult) RegisterSelect(id int32, value *database.TargetResult) {
+	d.Register("Select", map[string]any{"id": id}, value, nil)
+}
+
+func (d *TargetResult) SelectMulti(ctx context.Context, id ...int32) ([]*database.TargetResult, error) {
+	v, err := d.Call("SelectMulti", map[string]any{"id": id})
+	return v.([]*database.TargetResult), err
+}
+
+func (d *TargetResult) RegisterSelectMulti(id []int32, value []*database.TargetResult) {
+	d.Register("SelectMulti", map[string]any{"id": id}, value, nil)
+}
+
+func (d *TargetResult) ListByTaskId(ctx context.Context, taskId int32, opt ...dao.ListOption) ([]*database.TargetResult, error) {
+	v, err := d.Call("ListByTaskId", map[string]any{"taskId": taskId})
+	return v.([]*database.TargetResult), err
+}
+
+func (d *TargetResult) RegisterListByTaskId(taskId int32, value []*database.TargetResult, err error) {
+	d.Register("ListByTaskId", map[string]any{"taskId": taskId}, value, err)
+}
+
+func (d *TargetResult) Create(ctx context.Context, targetResult *database.TargetResult, opt ...dao.ExecOption) (*database.TargetResult, error) {
+	_, _ = d.Call("Create", map[string]any{"targetResult": targetResult})
+	return targetResult, nil
+}
+
+func (d *TargetResult) Delete(ctx context.Context, id int32, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Delete", map[string]any{"id": id})
+	return nil
+}
+
+func (d *TargetResult) Update(ctx context.Context, targetResult *database.TargetResult, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Update", map[string]any{"targetResult": targetResult})
+	return nil
+}
+
+type ActionFailure struct {
+	*mock.Mock
+}
+
+func NewActionFailure() *ActionFailure {
+	return &ActionFailure{Mock: mock.New()}
+}
+
+func (d *ActionFailure) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return nil
+}
+
+func (d *ActionFailure) Select(ctx context.Context, id int32) (*database.ActionFailure, error) {
+	v, err := d.Call("Select", map[string]any{"id": id})
+	return v.(*database.ActionFailure), err
+}
+
+func (d *ActionFailure) RegisterSelect(id int32, value *database.ActionFailure) {
+	d.Register("Select", map[string]any{"id": id}, value, nil)
+}
+
+func (d *ActionFailure) SelectMulti(ctx context.Context, id ...int32) ([]*database.ActionFailure, error) {
+	v, err := d.Call("SelectMulti", map[string]any{"id": id})
+	return v.([]*database.ActionFailure), err
+}
+
+func (d *ActionFailure) RegisterSelectMulti(id []int32, value []*database.ActionFailure) {
+	d.Register("SelectMulti", map[string]any{"id": id}, value, nil)
+}
+
+func (d *ActionFailure) ListByTaskId(ctx context.Context, taskId int32, opt ...dao.ListOption) ([]*database.ActionFailure, error) {
+	v, err := d.Call("ListByTaskId", map[string]any{"taskId": taskId})
+	return v.([]*database.ActionFailure), err
+}
+
+func (d *ActionFailure) RegisterListByTaskId(taskId int32, value []*database.ActionFailure, err error) {
+	d.Register("ListByTaskId", map[string]any{"taskId": taskId}, value, err)
+}
+
+func (d *ActionFailure) Create(ctx context.Context, actionFailure *database.ActionFailure, opt ...dao.ExecOption) (*database.ActionFailure, error) {
+	_, _ = d.Call("Create", map[string]any{"actionFailure": actionFailure})
+	return actionFailure, nil
+}
+
+func (d *ActionFailure) Delete(ctx context.Context, id int32, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Delete", map[string]any{"id": id})
+	return nil
+}
+
+func (d *ActionFailure) Update(ctx context.Context, actionFailure *database.ActionFailure, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Update", map[string]any{"actionFailure": actionFailure})
+	return nil
+}
+
+type BuildMetrics struct {
+	*mock.Mock
+}
+
+func NewBuildMetrics() *BuildMetrics {
+	return &BuildMetrics{Mock: mock.New()}
+}
+
+func (d *BuildMetrics) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return nil
+}
+
+func (d *BuildMetrics) Select(ctx context.Context, id int32) (*database.BuildMetrics, error) {
+	v, err := d.Call("Select", map[string]any{"id": id})
+	return v.(*database.BuildMetrics), err
+}
+
+func (d *BuildMetrics) RegisterSelect(id int32, value *database.BuildMetrics) {
+	d.Register("Select", map[string]any{"id": id}, value, nil)
+}
+
+func (d *BuildMetrics) SelectMulti(ctx context.Context, id ...int32) ([]*database.BuildMetrics, error) {
+	v, err := d.Call("SelectMulti", map[string]any{"id": id})
+	return v.([]*database.BuildMetrics), err
+}
+
+func (d *BuildMetrics) RegisterSelectMulti(id []int32, value []*database.BuildMetrics) {
+	d.Register("SelectMulti", map[string]any{"id": id}, value, nil)
+}
+
+func (d *BuildMetrics) ListByTaskId(ctx context.Context, taskId int32, opt ...dao.ListOption) ([]*database.BuildMetrics, error) {
+	v, err := d.Call("ListByTaskId", map[string]any{"taskId": taskId})
+	return v.([]*database.BuildMetrics), err
+}
+
+func (d *BuildMetrics) RegisterListByTaskId(taskId int32, value []*database.BuildMetrics, err error) {
+	d.Register("ListByTaskId", map[string]any{"taskId": taskId}, value, err)
+}
+
+func (d *BuildMetrics) Create(ctx context.Context, buildMetrics *database.BuildMetrics, opt ...dao.ExecOption) (*database.BuildMetrics, error) {
+	_, _ = d.Call("Create", map[string]any{"buildMetrics": buildMetrics})
+	return buildMetrics, nil
+}
+
+func (d *BuildMetrics) Delete(ctx context.Context, id int32, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Delete", map[string]any{"id": id})
+	return nil
+}
+
+func (d *BuildMetrics) Update(ctx context.Context, buildMetrics *database.BuildMetrics, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Update", map[string]any{"buildMetrics": buildMetrics})
+	return nil
+}
+
+type FlakyTest struct {
+	*mock.Mock
+}
+
+func NewFlakyTest() *FlakyTest {
+	return &FlakyTest{Mock: mock.New()}
+}
+
+func (d *FlakyTest) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return nil
+}
+
+func (d *FlakyTest) Select(ctx context.Context, id int32) (*database.FlakyTest, error) {
+	v, err := d.Call("Select", map[string]any{"id": id})
+	return v.(*database.FlakyTest), err
+}
+
+func (d *FlakyTest) RegisterSelect(id int32, value *database.FlakyTest) {
+	d.Register("Select", map[string]any{"id": id}, value, nil)
+}
+
+func (d *FlakyTest) SelectMulti(ctx context.Context, id ...int32) ([]*database.FlakyTest, error) {
+	v, err := d.Call("SelectMulti", map[string]any{"id": id})
+	return v.([]*database.FlakyTest), err
+}
+
+func (d *FlakyTest) RegisterSelectMulti(id []int32, value []*database.FlakyTest) {
+	d.Register("SelectMulti", map[string]any{"id": id}, value, nil)
+}
+
+func (d *FlakyTest) ListByRepositoryId(ctx context.Context, repositoryId int32, opt ...dao.ListOption) ([]*database.FlakyTest, error) {
+	v, err := d.Call("ListByRepositoryId", map[string]any{"repositoryId": repositoryId})
+	return v.([]*database.FlakyTest), err
+}
+
+func (d *FlakyTest) RegisterListByRepositoryId(repositoryId int32, value []*database.FlakyTest, err error) {
+	d.Register("ListByRepositoryId", map[string]any{"repositoryId": repositoryId}, value, err)
+}
+
+func (d *FlakyTest) ListByRepositoryAndLabel(ctx context.Context, repositoryId int32, label string, opt ...dao.ListOption) ([]*database.FlakyTest, error) {
+	v, err := d.Call("ListByRepositoryAndLabel", map[string]any{"repositoryId": repositoryId, "label": label})
+	return v.([]*database.FlakyTest), err
+}
+
+func (d *FlakyTest) RegisterListByRepositoryAndLabel(repositoryId int32, label string, value []*database.FlakyTest, err error) {
+	d.Register("ListByRepositoryAndLabel", map[string]any{"repositoryId": repositoryId, "label": label}, value, err)
+}
+
+func (d *FlakyTest) Create(ctx context.Context, flakyTest *database.FlakyTest, opt ...dao.ExecOption) (*database.FlakyTest, error) {
+	_, _ = d.Call("Create", map[string]any{"flakyTest": flakyTest})
+	return flakyTest, nil
+}
+
+func (d *FlakyTest) Delete(ctx context.Context, id int32, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Delete", map[string]any{"id": id})
+	return nil
+}
+
+func (d *FlakyTest) Update(ctx context.Context, flakyTest *database.FlakyTest, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Update", map[string]any{"flakyTest": flakyTest})
+	return nil
+}
+
+type Artifact struct {
+	*mock.Mock
+}
+
+func NewArtifact() *Artifact {
+	return &Artifact{Mock: mock.New()}
+}
+
+func (d *Artifact) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return nil
+}
+
+func (d *Artifact) Select(ctx context.Context, id int32) (*database.Artifact, error) {
+	v, err := d.Call("Select", map[string]any{"id": id})
+	return v.(*database.Artifact), err
+}
+
+func (d *Artifact) RegisterSelect(id int32, value *database.Artifact) {
+	d.Register("Select", map[string]any{"id": id}, value, nil)
+}
+
+func (d *Artifact) SelectMulti(ctx context.Context, id ...int32) ([]*database.Artifact, error) {
+	v, err := d.Call("SelectMulti", map[string]any{"id": id})
+	return v.([]*database.Artifact), err
+}
+
+func (d *Artifact) RegisterSelectMulti(id []int32, value []*database.Artifact) {
+	d.Register("SelectMulti", map[string]any{"id": id}, value, nil)
+}
+
+func (d *Artifact) ListAll(ctx context.Context, opt ...dao.ListOption) ([]*database.Artifact, error) {
+	v, err := d.Call("ListAll", map[string]any{})
+	return v.([]*database.Artifact), err
+}
+
+func (d *Artifact) RegisterListAll(value []*database.Artifact, err error) {
+	d.Register("ListAll", map[string]any{}, value, err)
+}
+
+func (d *Artifact) ListByTaskId(ctx context.Context, taskId int32, opt ...dao.ListOption) ([]*database.Artifact, error) {
+	v, err := d.Call("ListByTaskId", map[string]any{"taskId": taskId})
+	return v.([]*database.Artifact), err
+}
+
+func (d *Artifact) RegisterListByTaskId(taskId int32, value []*database.Artifact, err error) {
+	d.Register("ListByTaskId", map[string]any{"taskId": taskId}, value, err)
+}
+
+func (d *Artifact) Create(ctx context.Context, artifact *database.Artifact, opt ...dao.ExecOption) (*database.Artifact, error) {
+	_, _ = d.Call("Create", map[string]any{"artifact": artifact})
+	return artifact, nil
+}
+
+func (d *Artifact) Delete(ctx context.Context, id int32, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Delete", map[string]any{"id": id})
+	return nil
+}
+
+func (d *Artifact) Update(ctx context.Context, artifact *database.Artifact, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Update", map[string]any{"artifact": artifact})
+	return nil
+}
+
+type PolledReference struct {
+	*mock.Mock
+}
+
+func NewPolledReference() *PolledReference {
+	return &PolledReference{Mock: mock.New()}
+}
+
+func (d *PolledReference) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	return nil
+}
+
+func (d *PolledReference) Select(ctx context.Context, id int32) (*database.PolledReference, error) {
+	v, err := d.Call("Select", map[string]any{"id": id})
+	return v.(*database.PolledReference), err
+}
+
+func (d *PolledReference) RegisterSelect(id int32, value *database.PolledReference) {
+	d.Register("Select", map[string]any{"id": id}, value, nil)
+}
+
+func (d *PolledReference) SelectMulti(ctx context.Context, id ...int32) ([]*database.PolledReference, error) {
+	v, err := d.Call("SelectMulti", map[string]any{"id": id})
+	return v.([]*database.PolledReference), err
+}
+
+func (d *PolledReference) RegisterSelectMulti(id []int32, value []*database.PolledReference) {
+	d.Register("SelectMulti", map[string]any{"id": id}, value, nil)
+}
+
+func (d *PolledReference) ListByRepositoryId(ctx context.Context, repositoryId int32, opt ...dao.ListOption) ([]*database.PolledReference, error) {
+	v, err := d.Call("ListByRepositoryId", map[string]any{"repositoryId": repositoryId})
+	return v.([]*database.PolledReference), err
+}
+
+func (d *PolledReference) RegisterListByRepositoryId(repositoryId int32, value []*database.PolledReference, err error) {
+	d.Register("ListByRepositoryId", map[string]any{"repositoryId": repositoryId}, value, err)
+}
+
+func (d *PolledReference) Create(ctx context.Context, polledReference *database.PolledReference, opt ...dao.ExecOption) (*database.PolledReference, error) {
+	_, _ = d.Call("Create", map[string]any{"polledReference": polledReference})
+	return polledReference, nil
+}
+
+func (d *PolledReference) Delete(ctx context.Context, id int32, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Delete", map[string]any{"id": id})
+	return nil
+}
+
+func (d *PolledReference) Update(ctx context.Context, polledReference *database.PolledReference, opt ...dao.ExecOption) error {
+	_, _ = d.Call("Update", map[string]any{"polledReference": polledReference})
+	return nil
+}
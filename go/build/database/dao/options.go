@@ -14,6 +14,12 @@ type Options struct {
 	ExternalReleaseTrigger ExternalReleaseTriggerInterface
 	ExternalReleaseHistory ExternalReleaseHistoryInterface
 	GithubEvent            GithubEventInterface
+	TargetResult           TargetResultInterface
+	ActionFailure          ActionFailureInterface
+	BuildMetrics           BuildMetricsInterface
+	FlakyTest              FlakyTestInterface
+	Artifact               ArtifactInterface
+	PolledReference        PolledReferenceInterface
 
 	RawConnection *sql.DB
 }
@@ -29,6 +35,12 @@ func NewOptions(conn *sql.DB) Options {
 		ExternalReleaseTrigger: NewExternalReleaseTrigger(conn),
 		ExternalReleaseHistory: NewExternalReleaseHistory(conn),
 		GithubEvent:            NewGithubEvent(conn),
+		TargetResult:           NewTargetResult(conn),
+		ActionFailure:          NewActionFailure(conn),
+		BuildMetrics:           NewBuildMetrics(conn),
+		FlakyTest:              NewFlakyTest(conn),
+		Artifact:               NewArtifact(conn),
+		PolledReference:        NewPolledReference(conn),
 		RawConnection:          conn,
 	}
 }
@@ -338,6 +338,7 @@ type TaskInterface interface {
 	ListPending(ctx context.Context, opt ...ListOption) ([]*database.Task, error)
 	ListUniqJobName(ctx context.Context, repositoryId int32, opt ...ListOption) ([]*database.Task, error)
 	ListByRevision(ctx context.Context, repositoryId int32, revision string, opt ...ListOption) ([]*database.Task, error)
+	ListUnfinishedByConcurrencyGroup(ctx context.Context, repositoryId int32, concurrencyGroup string, opt ...ListOption) ([]*database.Task, error)
 	Create(ctx context.Context, task *database.Task, opt ...ExecOption) (*database.Task, error)
 	Update(ctx context.Context, task *database.Task, opt ...ExecOption) error
 	Delete(ctx context.Context, id int32, opt ...ExecOption) error
@@ -375,7 +376,7 @@ func (d *Task) Select(ctx context.Context, id int32) (*database.Task, error) {
 	row := d.conn.QueryRowContext(ctx, "SELECT * FROM `task` WHERE `id` = ?", id)
 
 	v := &database.Task{}
-	if err := row.Scan(&v.Id, &v.RepositoryId, &v.JobName, &v.JobConfiguration, &v.ParsedJobConfiguration, &v.Revision, &v.IsTrunk, &v.BazelVersion, &v.Success, &v.LogFile, &v.Command, &v.Target, &v.Targets, &v.Platform, &v.Via, &v.ConfigName, &v.Node, &v.JobObjectName, &v.Manifest, &v.Container, &v.ExecutedTestsCount, &v.SucceededTestsCount, &v.StartAt, &v.FinishedAt, &v.CreatedAt, &v.UpdatedAt); err != nil {
+	if err := row.Scan(&v.Id, &v.RepositoryId, &v.JobName, &v.JobConfiguration, &v.ParsedJobConfiguration, &v.Revision, &v.IsTrunk, &v.BazelVersion, &v.Success, &v.LogFile, &v.Command, &v.Target, &v.Targets, &v.Platform, &v.Via, &v.ConfigName, &v.Node, &v.JobObjectName, &v.Manifest, &v.Container, &v.ExecutedTestsCount, &v.SucceededTestsCount, &v.StartAt, &v.FinishedAt, &v.Skipped, &v.Attempt, &v.ParentTaskId, &v.ConcurrencyGroup, &v.CancelReason, &v.Matrix, &v.CreatedAt, &v.UpdatedAt); err != nil {
 		return nil, err
 	}
 
@@ -404,7 +405,7 @@ func (d *Task) SelectMulti(ctx context.Context, id ...int32) ([]*database.Task,
 	res := make([]*database.Task, 0, len(id))
 	for rows.Next() {
 		r := &database.Task{}
-		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.JobObjectName, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.JobObjectName, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.Skipped, &r.Attempt, &r.ParentTaskId, &r.ConcurrencyGroup, &r.CancelReason, &r.Matrix, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		res = append(res, r)
@@ -431,7 +432,7 @@ func (d *Task) SelectMulti(ctx context.Context, id ...int32) ([]*database.Task,
 
 func (d *Task) ListAll(ctx context.Context, opt ...ListOption) ([]*database.Task, error) {
 	listOpts := newListOpt(opt...)
-	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `job_object_name`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `created_at`, `updated_at` FROM `task`"
+	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `job_object_name`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `skipped`, `attempt`, `parent_task_id`, `concurrency_group`, `cancel_reason`, `matrix`, `created_at`, `updated_at` FROM `task`"
 	orderCol := "`" + listOpts.sort + "`"
 	if listOpts.sort == "" {
 		orderCol = "`id`"
@@ -456,7 +457,7 @@ func (d *Task) ListAll(ctx context.Context, opt ...ListOption) ([]*database.Task
 	res := make([]*database.Task, 0)
 	for rows.Next() {
 		r := &database.Task{}
-		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.JobObjectName, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.JobObjectName, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.Skipped, &r.Attempt, &r.ParentTaskId, &r.ConcurrencyGroup, &r.CancelReason, &r.Matrix, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		r.ResetMark()
@@ -484,7 +485,7 @@ func (d *Task) ListAll(ctx context.Context, opt ...ListOption) ([]*database.Task
 
 func (d *Task) ListOffsetAll(ctx context.Context, id int32, opt ...ListOption) ([]*database.Task, error) {
 	listOpts := newListOpt(opt...)
-	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `job_object_name`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `created_at`, `updated_at` FROM `task` WHERE `id` <= ?"
+	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `job_object_name`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `skipped`, `attempt`, `parent_task_id`, `concurrency_group`, `cancel_reason`, `matrix`, `created_at`, `updated_at` FROM `task` WHERE `id` <= ?"
 	orderCol := "`" + listOpts.sort + "`"
 	if listOpts.sort == "" {
 		orderCol = "`id`"
@@ -510,7 +511,7 @@ func (d *Task) ListOffsetAll(ctx context.Context, id int32, opt ...ListOption) (
 	res := make([]*database.Task, 0)
 	for rows.Next() {
 		r := &database.Task{}
-		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.JobObjectName, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.JobObjectName, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.Skipped, &r.Attempt, &r.ParentTaskId, &r.ConcurrencyGroup, &r.CancelReason, &r.Matrix, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		r.ResetMark()
@@ -538,7 +539,7 @@ func (d *Task) ListOffsetAll(ctx context.Context, id int32, opt ...ListOption) (
 
 func (d *Task) ListByRepositoryId(ctx context.Context, repositoryId int32, opt ...ListOption) ([]*database.Task, error) {
 	listOpts := newListOpt(opt...)
-	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `job_object_name`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `created_at`, `updated_at` FROM `task` WHERE `repository_id` = ?"
+	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `job_object_name`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `skipped`, `attempt`, `parent_task_id`, `concurrency_group`, `cancel_reason`, `matrix`, `created_at`, `updated_at` FROM `task` WHERE `repository_id` = ?"
 	orderCol := "`" + listOpts.sort + "`"
 	if listOpts.sort == "" {
 		orderCol = "`id`"
@@ -564,7 +565,7 @@ func (d *Task) ListByRepositoryId(ctx context.Context, repositoryId int32, opt .
 	res := make([]*database.Task, 0)
 	for rows.Next() {
 		r := &database.Task{}
-		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.JobObjectName, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.JobObjectName, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.Skipped, &r.Attempt, &r.ParentTaskId, &r.ConcurrencyGroup, &r.CancelReason, &r.Matrix, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		r.ResetMark()
@@ -592,7 +593,7 @@ func (d *Task) ListByRepositoryId(ctx context.Context, repositoryId int32, opt .
 
 func (d *Task) ListPending(ctx context.Context, opt ...ListOption) ([]*database.Task, error) {
 	listOpts := newListOpt(opt...)
-	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `job_object_name`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `created_at`, `updated_at` FROM `task` WHERE `start_at` IS NULL"
+	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `job_object_name`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `skipped`, `attempt`, `parent_task_id`, `concurrency_group`, `cancel_reason`, `matrix`, `created_at`, `updated_at` FROM `task` WHERE `start_at` IS NULL AND `finished_at` IS NULL"
 	orderCol := "`" + listOpts.sort + "`"
 	if listOpts.sort == "" {
 		orderCol = "`id`"
@@ -617,7 +618,7 @@ func (d *Task) ListPending(ctx context.Context, opt ...ListOption) ([]*database.
 	res := make([]*database.Task, 0)
 	for rows.Next() {
 		r := &database.Task{}
-		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.JobObjectName, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.JobObjectName, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.Skipped, &r.Attempt, &r.ParentTaskId, &r.ConcurrencyGroup, &r.CancelReason, &r.Matrix, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		r.ResetMark()
@@ -699,7 +700,7 @@ func (d *Task) ListUniqJobName(ctx context.Context, repositoryId int32, opt ...L
 
 func (d *Task) ListByRevision(ctx context.Context, repositoryId int32, revision string, opt ...ListOption) ([]*database.Task, error) {
 	listOpts := newListOpt(opt...)
-	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `job_object_name`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `created_at`, `updated_at` FROM `task` WHERE `repository_id` = ? AND `revision` = ?"
+	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `job_object_name`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `skipped`, `attempt`, `parent_task_id`, `concurrency_group`, `cancel_reason`, `matrix`, `created_at`, `updated_at` FROM `task` WHERE `repository_id` = ? AND `revision` = ?"
 	orderCol := "`" + listOpts.sort + "`"
 	if listOpts.sort == "" {
 		orderCol = "`id`"
@@ -726,7 +727,62 @@ func (d *Task) ListByRevision(ctx context.Context, repositoryId int32, revision
 	res := make([]*database.Task, 0)
 	for rows.Next() {
 		r := &database.Task{}
-		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.JobObjectName, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.CreatedAt, &r.UpdatedAt); err != nil {
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.JobObjectName, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.Skipped, &r.Attempt, &r.ParentTaskId, &r.ConcurrencyGroup, &r.CancelReason, &r.Matrix, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+	if len(res) > 0 {
+		repositoryPrimaryKeys := make([]int32, len(res))
+		for i, v := range res {
+			repositoryPrimaryKeys[i] = v.RepositoryId
+		}
+		repositoryData := make(map[int32]*database.SourceRepository)
+		{
+			rels, _ := d.sourceRepository.SelectMulti(ctx, repositoryPrimaryKeys...)
+			for _, v := range rels {
+				repositoryData[v.Id] = v
+			}
+		}
+		for _, v := range res {
+			v.Repository = repositoryData[v.RepositoryId]
+		}
+	}
+
+	return res, nil
+}
+
+func (d *Task) ListUnfinishedByConcurrencyGroup(ctx context.Context, repositoryId int32, concurrencyGroup string, opt ...ListOption) ([]*database.Task, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `job_object_name`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `skipped`, `attempt`, `parent_task_id`, `concurrency_group`, `cancel_reason`, `matrix`, `created_at`, `updated_at` FROM `task` WHERE `repository_id` = ? AND `concurrency_group` = ? AND `finished_at` IS NULL"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+		repositoryId,
+		concurrencyGroup,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*database.Task, 0)
+	for rows.Next() {
+		r := &database.Task{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.JobName, &r.JobConfiguration, &r.ParsedJobConfiguration, &r.Revision, &r.IsTrunk, &r.BazelVersion, &r.Success, &r.LogFile, &r.Command, &r.Target, &r.Targets, &r.Platform, &r.Via, &r.ConfigName, &r.Node, &r.JobObjectName, &r.Manifest, &r.Container, &r.ExecutedTestsCount, &r.SucceededTestsCount, &r.StartAt, &r.FinishedAt, &r.Skipped, &r.Attempt, &r.ParentTaskId, &r.ConcurrencyGroup, &r.CancelReason, &r.Matrix, &r.CreatedAt, &r.UpdatedAt); err != nil {
 			return nil, err
 		}
 		r.ResetMark()
@@ -763,8 +819,8 @@ func (d *Task) Create(ctx context.Context, task *database.Task, opt ...ExecOptio
 
 	res, err := conn.ExecContext(
 		ctx,
-		"INSERT INTO `task` (`repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `job_object_name`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `created_at`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
-		task.RepositoryId, task.JobName, task.JobConfiguration, task.ParsedJobConfiguration, task.Revision, task.IsTrunk, task.BazelVersion, task.Success, task.LogFile, task.Command, task.Target, task.Targets, task.Platform, task.Via, task.ConfigName, task.Node, task.JobObjectName, task.Manifest, task.Container, task.ExecutedTestsCount, task.SucceededTestsCount, task.StartAt, task.FinishedAt, time.Now(),
+		"INSERT INTO `task` (`repository_id`, `job_name`, `job_configuration`, `parsed_job_configuration`, `revision`, `is_trunk`, `bazel_version`, `success`, `log_file`, `command`, `target`, `targets`, `platform`, `via`, `config_name`, `node`, `job_object_name`, `manifest`, `container`, `executed_tests_count`, `succeeded_tests_count`, `start_at`, `finished_at`, `skipped`, `attempt`, `parent_task_id`, `concurrency_group`, `cancel_reason`, `matrix`, `created_at`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		task.RepositoryId, task.JobName, task.JobConfiguration, task.ParsedJobConfiguration, task.Revision, task.IsTrunk, task.BazelVersion, task.Success, task.LogFile, task.Command, task.Target, task.Targets, task.Platform, task.Via, task.ConfigName, task.Node, task.JobObjectName, task.Manifest, task.Container, task.ExecutedTestsCount, task.SucceededTestsCount, task.StartAt, task.FinishedAt, task.Skipped, task.Attempt, task.ParentTaskId, task.ConcurrencyGroup, task.CancelReason, task.Matrix, time.Now(),
 	)
 	if err != nil {
 		return nil, err
@@ -1333,6 +1389,7 @@ type TestReportInterface interface {
 	Select(ctx context.Context, id int32) (*database.TestReport, error)
 	SelectMulti(ctx context.Context, id ...int32) ([]*database.TestReport, error)
 	ListByTaskId(ctx context.Context, taskId int32, opt ...ListOption) ([]*database.TestReport, error)
+	ListByRepositoryAndLabel(ctx context.Context, repositoryId int32, label string, opt ...ListOption) ([]*database.TestReport, error)
 	Create(ctx context.Context, testReport *database.TestReport, opt ...ExecOption) (*database.TestReport, error)
 	Update(ctx context.Context, testReport *database.TestReport, opt ...ExecOption) error
 	Delete(ctx context.Context, id int32, opt ...ExecOption) error
@@ -1504,6 +1561,71 @@ func (d *TestReport) ListByTaskId(ctx context.Context, taskId int32, opt ...List
 	return res, nil
 }
 
+func (d *TestReport) ListByRepositoryAndLabel(ctx context.Context, repositoryId int32, label string, opt ...ListOption) ([]*database.TestReport, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `repository_id`, `task_id`, `label`, `status`, `duration`, `start_at` FROM `test_report` WHERE `repository_id` = ? AND `label` = ?"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+		repositoryId,
+		label,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*database.TestReport, 0)
+	for rows.Next() {
+		r := &database.TestReport{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.TaskId, &r.Label, &r.Status, &r.Duration, &r.StartAt); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+	if len(res) > 0 {
+		taskPrimaryKeys := make([]int32, len(res))
+		repositoryPrimaryKeys := make([]int32, len(res))
+		for i, v := range res {
+			taskPrimaryKeys[i] = v.TaskId
+			repositoryPrimaryKeys[i] = v.RepositoryId
+		}
+		taskData := make(map[int32]*database.Task)
+		{
+			rels, _ := d.task.SelectMulti(ctx, taskPrimaryKeys...)
+			for _, v := range rels {
+				taskData[v.Id] = v
+			}
+		}
+		repositoryData := make(map[int32]*database.SourceRepository)
+		{
+			rels, _ := d.sourceRepository.SelectMulti(ctx, repositoryPrimaryKeys...)
+			for _, v := range rels {
+				repositoryData[v.Id] = v
+			}
+		}
+		for _, v := range res {
+			v.Task = taskData[v.TaskId]
+			v.Repository = repositoryData[v.RepositoryId]
+		}
+	}
+
+	return res, nil
+}
+
 func (d *TestReport) Create(ctx context.Context, testReport *database.TestReport, opt ...ExecOption) (*database.TestReport, error) {
 	execOpts := newExecOpt(opt...)
 	var conn execConn
@@ -2668,3 +2790,1365 @@ func (d *ExternalReleaseHistory) Update(ctx context.Context, externalReleaseHist
 	externalReleaseHistory.ResetMark()
 	return nil
 }
+
+type TargetResult struct {
+	conn *sql.DB
+}
+
+type TargetResultInterface interface {
+	Tx(ctx context.Context, fn func(tx *sql.Tx) error) error
+	Select(ctx context.Context, id int32) (*database.TargetResult, error)
+	SelectMulti(ctx context.Context, id ...int32) ([]*database.TargetResult, error)
+	ListByTaskId(ctx context.Context, taskId int32, opt ...ListOption) ([]*database.TargetResult, error)
+	Create(ctx context.Context, targetResult *database.TargetResult, opt ...ExecOption) (*database.TargetResult, error)
+	Update(ctx context.Context, targetResult *database.TargetResult, opt ...ExecOption) error
+	Delete(ctx context.Context, id int32, opt ...ExecOption) error
+}
+
+var _ TargetResultInterface = (*TargetResult)(nil)
+
+func NewTargetResult(conn *sql.DB) *TargetResult {
+	return &TargetResult{
+		conn: conn,
+	}
+}
+
+func (d *TargetResult) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		if rErr := tx.Rollback(); rErr != nil {
+			return rErr
+		}
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *TargetResult) Select(ctx context.Context, id int32) (*database.TargetResult, error) {
+	row := d.conn.QueryRowContext(ctx, "SELECT * FROM `target_result` WHERE `id` = ?", id)
+
+	v := &database.TargetResult{}
+	if err := row.Scan(&v.Id, &v.TaskId, &v.Label, &v.Success, &v.FailureCategory, &v.FailureMessage); err != nil {
+		return nil, err
+	}
+
+	v.ResetMark()
+	return v, nil
+}
+
+func (d *TargetResult) SelectMulti(ctx context.Context, id ...int32) ([]*database.TargetResult, error) {
+	inCause := strings.Repeat("?, ", len(id))
+	args := make([]any, len(id))
+	for i := 0; i < len(id); i++ {
+		args[i] = id[i]
+	}
+	rows, err := d.conn.QueryContext(ctx, fmt.Sprintf("SELECT * FROM `target_result` WHERE `id` IN (%s)", inCause[:len(inCause)-2]), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*database.TargetResult, 0, len(id))
+	for rows.Next() {
+		r := &database.TargetResult{}
+		if err := rows.Scan(&r.Id, &r.TaskId, &r.Label, &r.Success, &r.FailureCategory, &r.FailureMessage); err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (d *TargetResult) ListByTaskId(ctx context.Context, taskId int32, opt ...ListOption) ([]*database.TargetResult, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `task_id`, `label`, `success`, `failure_category`, `failure_message` FROM `target_result` WHERE `task_id` = ?"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+		taskId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*database.TargetResult, 0)
+	for rows.Next() {
+		r := &database.TargetResult{}
+		if err := rows.Scan(&r.Id, &r.TaskId, &r.Label, &r.Success, &r.FailureCategory, &r.FailureMessage); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (d *TargetResult) Create(ctx context.Context, targetResult *database.TargetResult, opt ...ExecOption) (*database.TargetResult, error) {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(
+		ctx,
+		"INSERT INTO `target_result` (`task_id`, `label`, `success`, `failure_category`, `failure_message`) VALUES (?, ?, ?, ?, ?)",
+		targetResult.TaskId, targetResult.Label, targetResult.Success, targetResult.FailureCategory, targetResult.FailureMessage,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	targetResult = targetResult.Copy()
+	insertedId, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	targetResult.Id = int32(insertedId)
+
+	targetResult.ResetMark()
+	return targetResult, nil
+}
+
+func (d *TargetResult) Delete(ctx context.Context, id int32, opt ...ExecOption) error {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(ctx, "DELETE FROM `target_result` WHERE `id` = ?", id)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (d *TargetResult) Update(ctx context.Context, targetResult *database.TargetResult, opt ...ExecOption) error {
+	if !targetResult.IsChanged() {
+		return nil
+	}
+
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	changedColumn := targetResult.ChangedColumn()
+	cols := make([]string, len(changedColumn))
+	values := make([]any, len(changedColumn))
+	for i := range changedColumn {
+		cols[i] = "`" + changedColumn[i].Name + "` = ?"
+		values[i] = changedColumn[i].Value
+	}
+
+	query := fmt.Sprintf("UPDATE `target_result` SET %s WHERE `id` = ?", strings.Join(cols, ", "))
+	res, err := conn.ExecContext(
+		ctx,
+		query,
+		append(values, targetResult.Id)...,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	targetResult.ResetMark()
+	return nil
+}
+
+type ActionFailure struct {
+	conn *sql.DB
+}
+
+type ActionFailureInterface interface {
+	Tx(ctx context.Context, fn func(tx *sql.Tx) error) error
+	Select(ctx context.Context, id int32) (*database.ActionFailure, error)
+	SelectMulti(ctx context.Context, id ...int32) ([]*database.ActionFailure, error)
+	ListByTaskId(ctx context.Context, taskId int32, opt ...ListOption) ([]*database.ActionFailure, error)
+	Create(ctx context.Context, actionFailure *database.ActionFailure, opt ...ExecOption) (*database.ActionFailure, error)
+	Update(ctx context.Context, actionFailure *database.ActionFailure, opt ...ExecOption) error
+	Delete(ctx context.Context, id int32, opt ...ExecOption) error
+}
+
+var _ ActionFailureInterface = (*ActionFailure)(nil)
+
+func NewActionFailure(conn *sql.DB) *ActionFailure {
+	return &ActionFailure{
+		conn: conn,
+	}
+}
+
+func (d *ActionFailure) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		if rErr := tx.Rollback(); rErr != nil {
+			return rErr
+		}
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *ActionFailure) Select(ctx context.Context, id int32) (*database.ActionFailure, error) {
+	row := d.conn.QueryRowContext(ctx, "SELECT * FROM `action_failure` WHERE `id` = ?", id)
+
+	v := &database.ActionFailure{}
+	if err := row.Scan(&v.Id, &v.TaskId, &v.Label, &v.Mnemonic, &v.ExitCode, &v.FailureCategory, &v.FailureMessage, &v.PrimaryOutput); err != nil {
+		return nil, err
+	}
+
+	v.ResetMark()
+	return v, nil
+}
+
+func (d *ActionFailure) SelectMulti(ctx context.Context, id ...int32) ([]*database.ActionFailure, error) {
+	inCause := strings.Repeat("?, ", len(id))
+	args := make([]any, len(id))
+	for i := 0; i < len(id); i++ {
+		args[i] = id[i]
+	}
+	rows, err := d.conn.QueryContext(ctx, fmt.Sprintf("SELECT * FROM `action_failure` WHERE `id` IN (%s)", inCause[:len(inCause)-2]), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*database.ActionFailure, 0, len(id))
+	for rows.Next() {
+		r := &database.ActionFailure{}
+		if err := rows.Scan(&r.Id, &r.TaskId, &r.Label, &r.Mnemonic, &r.ExitCode, &r.FailureCategory, &r.FailureMessage, &r.PrimaryOutput); err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (d *ActionFailure) ListByTaskId(ctx context.Context, taskId int32, opt ...ListOption) ([]*database.ActionFailure, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `task_id`, `label`, `mnemonic`, `exit_code`, `failure_category`, `failure_message`, `primary_output` FROM `action_failure` WHERE `task_id` = ?"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+		taskId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*database.ActionFailure, 0)
+	for rows.Next() {
+		r := &database.ActionFailure{}
+		if err := rows.Scan(&r.Id, &r.TaskId, &r.Label, &r.Mnemonic, &r.ExitCode, &r.FailureCategory, &r.FailureMessage, &r.PrimaryOutput); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (d *ActionFailure) Create(ctx context.Context, actionFailure *database.ActionFailure, opt ...ExecOption) (*database.ActionFailure, error) {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(
+		ctx,
+		"INSERT INTO `action_failure` (`task_id`, `label`, `mnemonic`, `exit_code`, `failure_category`, `failure_message`, `primary_output`) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		actionFailure.TaskId, actionFailure.Label, actionFailure.Mnemonic, actionFailure.ExitCode, actionFailure.FailureCategory, actionFailure.FailureMessage, actionFailure.PrimaryOutput,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	actionFailure = actionFailure.Copy()
+	insertedId, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	actionFailure.Id = int32(insertedId)
+
+	actionFailure.ResetMark()
+	return actionFailure, nil
+}
+
+func (d *ActionFailure) Delete(ctx context.Context, id int32, opt ...ExecOption) error {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(ctx, "DELETE FROM `action_failure` WHERE `id` = ?", id)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (d *ActionFailure) Update(ctx context.Context, actionFailure *database.ActionFailure, opt ...ExecOption) error {
+	if !actionFailure.IsChanged() {
+		return nil
+	}
+
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	changedColumn := actionFailure.ChangedColumn()
+	cols := make([]string, len(changedColumn))
+	values := make([]any, len(changedColumn))
+	for i := range changedColumn {
+		cols[i] = "`" + changedColumn[i].Name + "` = ?"
+		values[i] = changedColumn[i].Value
+	}
+
+	query := fmt.Sprintf("UPDATE `action_failure` SET %s WHERE `id` = ?", strings.Join(cols, ", "))
+	res, err := conn.ExecContext(
+		ctx,
+		query,
+		append(values, actionFailure.Id)...,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	actionFailure.ResetMark()
+	return nil
+}
+
+type BuildMetrics struct {
+	conn *sql.DB
+}
+
+type BuildMetricsInterface interface {
+	Tx(ctx context.Context, fn func(tx *sql.Tx) error) error
+	Select(ctx context.Context, id int32) (*database.BuildMetrics, error)
+	SelectMulti(ctx context.Context, id ...int32) ([]*database.BuildMetrics, error)
+	ListByTaskId(ctx context.Context, taskId int32, opt ...ListOption) ([]*database.BuildMetrics, error)
+	Create(ctx context.Context, buildMetrics *database.BuildMetrics, opt ...ExecOption) (*database.BuildMetrics, error)
+	Update(ctx context.Context, buildMetrics *database.BuildMetrics, opt ...ExecOption) error
+	Delete(ctx context.Context, id int32, opt ...ExecOption) error
+}
+
+var _ BuildMetricsInterface = (*BuildMetrics)(nil)
+
+func NewBuildMetrics(conn *sql.DB) *BuildMetrics {
+	return &BuildMetrics{
+		conn: conn,
+	}
+}
+
+func (d *BuildMetrics) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		if rErr := tx.Rollback(); rErr != nil {
+			return rErr
+		}
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *BuildMetrics) Select(ctx context.Context, id int32) (*database.BuildMetrics, error) {
+	row := d.conn.QueryRowContext(ctx, "SELECT * FROM `build_metrics` WHERE `id` = ?", id)
+
+	v := &database.BuildMetrics{}
+	if err := row.Scan(&v.Id, &v.TaskId, &v.ExitCodeName, &v.ActionsCreated, &v.ActionsExecuted, &v.RemoteCacheHits, &v.ActionCacheHits, &v.ActionCacheMisses, &v.TargetsConfigured, &v.WallTimeMs, &v.CpuTimeMs, &v.AnalysisPhaseTimeMs, &v.ExecutionPhaseTimeMs); err != nil {
+		return nil, err
+	}
+
+	v.ResetMark()
+	return v, nil
+}
+
+func (d *BuildMetrics) SelectMulti(ctx context.Context, id ...int32) ([]*database.BuildMetrics, error) {
+	inCause := strings.Repeat("?, ", len(id))
+	args := make([]any, len(id))
+	for i := 0; i < len(id); i++ {
+		args[i] = id[i]
+	}
+	rows, err := d.conn.QueryContext(ctx, fmt.Sprintf("SELECT * FROM `build_metrics` WHERE `id` IN (%s)", inCause[:len(inCause)-2]), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*database.BuildMetrics, 0, len(id))
+	for rows.Next() {
+		r := &database.BuildMetrics{}
+		if err := rows.Scan(&r.Id, &r.TaskId, &r.ExitCodeName, &r.ActionsCreated, &r.ActionsExecuted, &r.RemoteCacheHits, &r.ActionCacheHits, &r.ActionCacheMisses, &r.TargetsConfigured, &r.WallTimeMs, &r.CpuTimeMs, &r.AnalysisPhaseTimeMs, &r.ExecutionPhaseTimeMs); err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (d *BuildMetrics) ListByTaskId(ctx context.Context, taskId int32, opt ...ListOption) ([]*database.BuildMetrics, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `task_id`, `exit_code_name`, `actions_created`, `actions_executed`, `remote_cache_hits`, `action_cache_hits`, `action_cache_misses`, `targets_configured`, `wall_time_ms`, `cpu_time_ms`, `analysis_phase_time_ms`, `execution_phase_time_ms` FROM `build_metrics` WHERE `task_id` = ?"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+		taskId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*database.BuildMetrics, 0)
+	for rows.Next() {
+		r := &database.BuildMetrics{}
+		if err := rows.Scan(&r.Id, &r.TaskId, &r.ExitCodeName, &r.ActionsCreated, &r.ActionsExecuted, &r.RemoteCacheHits, &r.ActionCacheHits, &r.ActionCacheMisses, &r.TargetsConfigured, &r.WallTimeMs, &r.CpuTimeMs, &r.AnalysisPhaseTimeMs, &r.ExecutionPhaseTimeMs); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (d *BuildMetrics) Create(ctx context.Context, buildMetrics *database.BuildMetrics, opt ...ExecOption) (*database.BuildMetrics, error) {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(
+		ctx,
+		"INSERT INTO `build_metrics` (`task_id`, `exit_code_name`, `actions_created`, `actions_executed`, `remote_cache_hits`, `action_cache_hits`, `action_cache_misses`, `targets_configured`, `wall_time_ms`, `cpu_time_ms`, `analysis_phase_time_ms`, `execution_phase_time_ms`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		buildMetrics.TaskId, buildMetrics.ExitCodeName, buildMetrics.ActionsCreated, buildMetrics.ActionsExecuted, buildMetrics.RemoteCacheHits, buildMetrics.ActionCacheHits, buildMetrics.ActionCacheMisses, buildMetrics.TargetsConfigured, buildMetrics.WallTimeMs, buildMetrics.CpuTimeMs, buildMetrics.AnalysisPhaseTimeMs, buildMetrics.ExecutionPhaseTimeMs,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	buildMetrics = buildMetrics.Copy()
+	insertedId, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	buildMetrics.Id = int32(insertedId)
+
+	buildMetrics.ResetMark()
+	return buildMetrics, nil
+}
+
+func (d *BuildMetrics) Delete(ctx context.Context, id int32, opt ...ExecOption) error {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(ctx, "DELETE FROM `build_metrics` WHERE `id` = ?", id)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (d *BuildMetrics) Update(ctx context.Context, buildMetrics *database.BuildMetrics, opt ...ExecOption) error {
+	if !buildMetrics.IsChanged() {
+		return nil
+	}
+
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	changedColumn := buildMetrics.ChangedColumn()
+	cols := make([]string, len(changedColumn))
+	values := make([]any, len(changedColumn))
+	for i := range changedColumn {
+		cols[i] = "`" + changedColumn[i].Name + "` = ?"
+		values[i] = changedColumn[i].Value
+	}
+
+	query := fmt.Sprintf("UPDATE `build_metrics` SET %s WHERE `id` = ?", strings.Join(cols, ", "))
+	res, err := conn.ExecContext(
+		ctx,
+		query,
+		append(values, buildMetrics.Id)...,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	buildMetrics.ResetMark()
+	return nil
+}
+
+type FlakyTest struct {
+	conn *sql.DB
+}
+
+type FlakyTestInterface interface {
+	Tx(ctx context.Context, fn func(tx *sql.Tx) error) error
+	Select(ctx context.Context, id int32) (*database.FlakyTest, error)
+	SelectMulti(ctx context.Context, id ...int32) ([]*database.FlakyTest, error)
+	ListByRepositoryId(ctx context.Context, repositoryId int32, opt ...ListOption) ([]*database.FlakyTest, error)
+	ListByRepositoryAndLabel(ctx context.Context, repositoryId int32, label string, opt ...ListOption) ([]*database.FlakyTest, error)
+	Create(ctx context.Context, flakyTest *database.FlakyTest, opt ...ExecOption) (*database.FlakyTest, error)
+	Update(ctx context.Context, flakyTest *database.FlakyTest, opt ...ExecOption) error
+	Delete(ctx context.Context, id int32, opt ...ExecOption) error
+}
+
+var _ FlakyTestInterface = (*FlakyTest)(nil)
+
+func NewFlakyTest(conn *sql.DB) *FlakyTest {
+	return &FlakyTest{
+		conn: conn,
+	}
+}
+
+func (d *FlakyTest) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		if rErr := tx.Rollback(); rErr != nil {
+			return rErr
+		}
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *FlakyTest) Select(ctx context.Context, id int32) (*database.FlakyTest, error) {
+	row := d.conn.QueryRowContext(ctx, "SELECT * FROM `flaky_test` WHERE `id` = ?", id)
+
+	v := &database.FlakyTest{}
+	if err := row.Scan(&v.Id, &v.RepositoryId, &v.Label, &v.Runs, &v.FlakyRuns, &v.Score, &v.Quarantined, &v.LastFlakyTaskId, &v.CreatedAt, &v.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	v.ResetMark()
+	return v, nil
+}
+
+func (d *FlakyTest) SelectMulti(ctx context.Context, id ...int32) ([]*database.FlakyTest, error) {
+	inCause := strings.Repeat("?, ", len(id))
+	args := make([]any, len(id))
+	for i := 0; i < len(id); i++ {
+		args[i] = id[i]
+	}
+	rows, err := d.conn.QueryContext(ctx, fmt.Sprintf("SELECT * FROM `flaky_test` WHERE `id` IN (%s)", inCause[:len(inCause)-2]), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*database.FlakyTest, 0, len(id))
+	for rows.Next() {
+		r := &database.FlakyTest{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.Label, &r.Runs, &r.FlakyRuns, &r.Score, &r.Quarantined, &r.LastFlakyTaskId, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (d *FlakyTest) ListByRepositoryId(ctx context.Context, repositoryId int32, opt ...ListOption) ([]*database.FlakyTest, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `repository_id`, `label`, `runs`, `flaky_runs`, `score`, `quarantined`, `last_flaky_task_id`, `created_at`, `updated_at` FROM `flaky_test` WHERE `repository_id` = ?"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+		repositoryId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*database.FlakyTest, 0)
+	for rows.Next() {
+		r := &database.FlakyTest{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.Label, &r.Runs, &r.FlakyRuns, &r.Score, &r.Quarantined, &r.LastFlakyTaskId, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (d *FlakyTest) ListByRepositoryAndLabel(ctx context.Context, repositoryId int32, label string, opt ...ListOption) ([]*database.FlakyTest, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `repository_id`, `label`, `runs`, `flaky_runs`, `score`, `quarantined`, `last_flaky_task_id`, `created_at`, `updated_at` FROM `flaky_test` WHERE `repository_id` = ? AND `label` = ?"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+		repositoryId,
+		label,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*database.FlakyTest, 0)
+	for rows.Next() {
+		r := &database.FlakyTest{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.Label, &r.Runs, &r.FlakyRuns, &r.Score, &r.Quarantined, &r.LastFlakyTaskId, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (d *FlakyTest) Create(ctx context.Context, flakyTest *database.FlakyTest, opt ...ExecOption) (*database.FlakyTest, error) {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(
+		ctx,
+		"INSERT INTO `flaky_test` (`repository_id`, `label`, `runs`, `flaky_runs`, `score`, `quarantined`, `last_flaky_task_id`, `created_at`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		flakyTest.RepositoryId, flakyTest.Label, flakyTest.Runs, flakyTest.FlakyRuns, flakyTest.Score, flakyTest.Quarantined, flakyTest.LastFlakyTaskId, time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	flakyTest = flakyTest.Copy()
+	insertedId, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	flakyTest.Id = int32(insertedId)
+
+	flakyTest.ResetMark()
+	return flakyTest, nil
+}
+
+func (d *FlakyTest) Delete(ctx context.Context, id int32, opt ...ExecOption) error {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(ctx, "DELETE FROM `flaky_test` WHERE `id` = ?", id)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (d *FlakyTest) Update(ctx context.Context, flakyTest *database.FlakyTest, opt ...ExecOption) error {
+	if !flakyTest.IsChanged() {
+		return nil
+	}
+
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	changedColumn := flakyTest.ChangedColumn()
+	cols := make([]string, len(changedColumn)+1)
+	values := make([]any, len(changedColumn)+1)
+	for i := range changedColumn {
+		cols[i] = "`" + changedColumn[i].Name + "` = ?"
+		values[i] = changedColumn[i].Value
+	}
+	cols[len(cols)-1] = "`updated_at` = ?"
+	values[len(values)-1] = time.Now()
+
+	query := fmt.Sprintf("UPDATE `flaky_test` SET %s WHERE `id` = ?", strings.Join(cols, ", "))
+	res, err := conn.ExecContext(
+		ctx,
+		query,
+		append(values, flakyTest.Id)...,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	flakyTest.ResetMark()
+	return nil
+}
+
+type Artifact struct {
+	conn *sql.DB
+}
+
+type ArtifactInterface interface {
+	Tx(ctx context.Context, fn func(tx *sql.Tx) error) error
+	Select(ctx context.Context, id int32) (*database.Artifact, error)
+	SelectMulti(ctx context.Context, id ...int32) ([]*database.Artifact, error)
+	ListAll(ctx context.Context, opt ...ListOption) ([]*database.Artifact, error)
+	ListByTaskId(ctx context.Context, taskId int32, opt ...ListOption) ([]*database.Artifact, error)
+	Create(ctx context.Context, artifact *database.Artifact, opt ...ExecOption) (*database.Artifact, error)
+	Update(ctx context.Context, artifact *database.Artifact, opt ...ExecOption) error
+	Delete(ctx context.Context, id int32, opt ...ExecOption) error
+}
+
+var _ ArtifactInterface = (*Artifact)(nil)
+
+func NewArtifact(conn *sql.DB) *Artifact {
+	return &Artifact{
+		conn: conn,
+	}
+}
+
+func (d *Artifact) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		if rErr := tx.Rollback(); rErr != nil {
+			return rErr
+		}
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *Artifact) Select(ctx context.Context, id int32) (*database.Artifact, error) {
+	row := d.conn.QueryRowContext(ctx, "SELECT * FROM `artifact` WHERE `id` = ?", id)
+
+	v := &database.Artifact{}
+	if err := row.Scan(&v.Id, &v.TaskId, &v.Label, &v.Path, &v.ObjectName, &v.Size, &v.CreatedAt, &v.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	v.ResetMark()
+	return v, nil
+}
+
+func (d *Artifact) SelectMulti(ctx context.Context, id ...int32) ([]*database.Artifact, error) {
+	inCause := strings.Repeat("?, ", len(id))
+	args := make([]any, len(id))
+	for i := 0; i < len(id); i++ {
+		args[i] = id[i]
+	}
+	rows, err := d.conn.QueryContext(ctx, fmt.Sprintf("SELECT * FROM `artifact` WHERE `id` IN (%s)", inCause[:len(inCause)-2]), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*database.Artifact, 0, len(id))
+	for rows.Next() {
+		r := &database.Artifact{}
+		if err := rows.Scan(&r.Id, &r.TaskId, &r.Label, &r.Path, &r.ObjectName, &r.Size, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (d *Artifact) ListAll(ctx context.Context, opt ...ListOption) ([]*database.Artifact, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `task_id`, `label`, `path`, `object_name`, `size`, `created_at`, `updated_at` FROM `artifact`"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*database.Artifact, 0)
+	for rows.Next() {
+		r := &database.Artifact{}
+		if err := rows.Scan(&r.Id, &r.TaskId, &r.Label, &r.Path, &r.ObjectName, &r.Size, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (d *Artifact) ListByTaskId(ctx context.Context, taskId int32, opt ...ListOption) ([]*database.Artifact, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `task_id`, `label`, `path`, `object_name`, `size`, `created_at`, `updated_at` FROM `artifact` WHERE `task_id` = ?"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+		taskId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*database.Artifact, 0)
+	for rows.Next() {
+		r := &database.Artifact{}
+		if err := rows.Scan(&r.Id, &r.TaskId, &r.Label, &r.Path, &r.ObjectName, &r.Size, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (d *Artifact) Create(ctx context.Context, artifact *database.Artifact, opt ...ExecOption) (*database.Artifact, error) {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(
+		ctx,
+		"INSERT INTO `artifact` (`task_id`, `label`, `path`, `object_name`, `size`, `created_at`) VALUES (?, ?, ?, ?, ?, ?)",
+		artifact.TaskId, artifact.Label, artifact.Path, artifact.ObjectName, artifact.Size, time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	artifact = artifact.Copy()
+	insertedId, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	artifact.Id = int32(insertedId)
+
+	artifact.ResetMark()
+	return artifact, nil
+}
+
+func (d *Artifact) Delete(ctx context.Context, id int32, opt ...ExecOption) error {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(ctx, "DELETE FROM `artifact` WHERE `id` = ?", id)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (d *Artifact) Update(ctx context.Context, artifact *database.Artifact, opt ...ExecOption) error {
+	if !artifact.IsChanged() {
+		return nil
+	}
+
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	changedColumn := artifact.ChangedColumn()
+	cols := make([]string, len(changedColumn)+1)
+	values := make([]any, len(changedColumn)+1)
+	for i := range changedColumn {
+		cols[i] = "`" + changedColumn[i].Name + "` = ?"
+		values[i] = changedColumn[i].Value
+	}
+	cols[len(cols)-1] = "`updated_at` = ?"
+	values[len(values)-1] = time.Now()
+
+	query := fmt.Sprintf("UPDATE `artifact` SET %s WHERE `id` = ?", strings.Join(cols, ", "))
+	res, err := conn.ExecContext(
+		ctx,
+		query,
+		append(values, artifact.Id)...,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	artifact.ResetMark()
+	return nil
+}
+
+type PolledReference struct {
+	conn *sql.DB
+}
+
+type PolledReferenceInterface interface {
+	Tx(ctx context.Context, fn func(tx *sql.Tx) error) error
+	Select(ctx context.Context, id int32) (*database.PolledReference, error)
+	SelectMulti(ctx context.Context, id ...int32) ([]*database.PolledReference, error)
+	ListByRepositoryId(ctx context.Context, repositoryId int32, opt ...ListOption) ([]*database.PolledReference, error)
+	Create(ctx context.Context, polledReference *database.PolledReference, opt ...ExecOption) (*database.PolledReference, error)
+	Update(ctx context.Context, polledReference *database.PolledReference, opt ...ExecOption) error
+	Delete(ctx context.Context, id int32, opt ...ExecOption) error
+}
+
+var _ PolledReferenceInterface = (*PolledReference)(nil)
+
+func NewPolledReference(conn *sql.DB) *PolledReference {
+	return &PolledReference{
+		conn: conn,
+	}
+}
+
+func (d *PolledReference) Tx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		if rErr := tx.Rollback(); rErr != nil {
+			return rErr
+		}
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *PolledReference) Select(ctx context.Context, id int32) (*database.PolledReference, error) {
+	row := d.conn.QueryRowContext(ctx, "SELECT * FROM `polled_reference` WHERE `id` = ?", id)
+
+	v := &database.PolledReference{}
+	if err := row.Scan(&v.Id, &v.RepositoryId, &v.Ref, &v.Hash, &v.CreatedAt, &v.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	v.ResetMark()
+	return v, nil
+}
+
+func (d *PolledReference) SelectMulti(ctx context.Context, id ...int32) ([]*database.PolledReference, error) {
+	inCause := strings.Repeat("?, ", len(id))
+	args := make([]any, len(id))
+	for i := 0; i < len(id); i++ {
+		args[i] = id[i]
+	}
+	rows, err := d.conn.QueryContext(ctx, fmt.Sprintf("SELECT * FROM `polled_reference` WHERE `id` IN (%s)", inCause[:len(inCause)-2]), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*database.PolledReference, 0, len(id))
+	for rows.Next() {
+		r := &database.PolledReference{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.Ref, &r.Hash, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (d *PolledReference) ListByRepositoryId(ctx context.Context, repositoryId int32, opt ...ListOption) ([]*database.PolledReference, error) {
+	listOpts := newListOpt(opt...)
+	query := "SELECT `id`, `repository_id`, `ref`, `hash`, `created_at`, `updated_at` FROM `polled_reference` WHERE `repository_id` = ?"
+	orderCol := "`" + listOpts.sort + "`"
+	if listOpts.sort == "" {
+		orderCol = "`id`"
+	}
+	orderDi := "ASC"
+	if listOpts.desc {
+		orderDi = "DESC"
+	}
+	query = query + fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDi)
+	if listOpts.limit > 0 {
+		query = query + fmt.Sprintf(" LIMIT %d", listOpts.limit)
+	}
+	rows, err := d.conn.QueryContext(
+		ctx,
+		query,
+		repositoryId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*database.PolledReference, 0)
+	for rows.Next() {
+		r := &database.PolledReference{}
+		if err := rows.Scan(&r.Id, &r.RepositoryId, &r.Ref, &r.Hash, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return nil, err
+		}
+		r.ResetMark()
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+func (d *PolledReference) Create(ctx context.Context, polledReference *database.PolledReference, opt ...ExecOption) (*database.PolledReference, error) {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(
+		ctx,
+		"INSERT INTO `polled_reference` (`repository_id`, `ref`, `hash`, `created_at`) VALUES (?, ?, ?, ?)",
+		polledReference.RepositoryId, polledReference.Ref, polledReference.Hash, time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if n == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	polledReference = polledReference.Copy()
+	insertedId, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	polledReference.Id = int32(insertedId)
+
+	polledReference.ResetMark()
+	return polledReference, nil
+}
+
+func (d *PolledReference) Delete(ctx context.Context, id int32, opt ...ExecOption) error {
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	res, err := conn.ExecContext(ctx, "DELETE FROM `polled_reference` WHERE `id` = ?", id)
+	if err != nil {
+		return err
+	}
+
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (d *PolledReference) Update(ctx context.Context, polledReference *database.PolledReference, opt ...ExecOption) error {
+	if !polledReference.IsChanged() {
+		return nil
+	}
+
+	execOpts := newExecOpt(opt...)
+	var conn execConn
+	if execOpts.tx != nil {
+		conn = execOpts.tx
+	} else {
+		conn = d.conn
+	}
+
+	changedColumn := polledReference.ChangedColumn()
+	cols := make([]string, len(changedColumn)+1)
+	values := make([]any, len(changedColumn)+1)
+	for i := range changedColumn {
+		cols[i] = "`" + changedColumn[i].Name + "` = ?"
+		values[i] = changedColumn[i].Value
+	}
+	cols[len(cols)-1] = "`updated_at` = ?"
+	values[len(values)-1] = time.Now()
+
+	query := fmt.Sprintf("UPDATE `polled_reference` SET %s WHERE `id` = ?", strings.Join(cols, ", "))
+	res, err := conn.ExecContext(
+		ctx,
+		query,
+		append(values, polledReference.Id)...,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	polledReference.ResetMark()
+	return nil
+}
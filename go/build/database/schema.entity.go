@@ -154,6 +154,12 @@ type Task struct {
 	SucceededTestsCount int32
 	StartAt             *time.Time
 	FinishedAt          *time.Time
+	Skipped             bool
+	Attempt             int32
+	ParentTaskId        int32
+	ConcurrencyGroup    string
+	CancelReason        string
+	Matrix              string
 	CreatedAt           time.Time
 	UpdatedAt           *time.Time
 
@@ -197,6 +203,12 @@ func (e *Task) IsChanged() bool {
 		e.SucceededTestsCount != e.mark.SucceededTestsCount ||
 		((e.StartAt != nil && (e.mark.StartAt == nil || !e.StartAt.Equal(*e.mark.StartAt))) || (e.StartAt == nil && e.mark.StartAt != nil)) ||
 		((e.FinishedAt != nil && (e.mark.FinishedAt == nil || !e.FinishedAt.Equal(*e.mark.FinishedAt))) || (e.FinishedAt == nil && e.mark.FinishedAt != nil)) ||
+		e.Skipped != e.mark.Skipped ||
+		e.Attempt != e.mark.Attempt ||
+		e.ParentTaskId != e.mark.ParentTaskId ||
+		e.ConcurrencyGroup != e.mark.ConcurrencyGroup ||
+		e.CancelReason != e.mark.CancelReason ||
+		e.Matrix != e.mark.Matrix ||
 		!e.CreatedAt.Equal(e.mark.CreatedAt) ||
 		((e.UpdatedAt != nil && (e.mark.UpdatedAt == nil || !e.UpdatedAt.Equal(*e.mark.UpdatedAt))) || (e.UpdatedAt == nil && e.mark.UpdatedAt != nil))
 }
@@ -287,6 +299,24 @@ func (e *Task) ChangedColumn() []ddl.Column {
 			res = append(res, ddl.Column{Name: "finished_at", Value: nil})
 		}
 	}
+	if e.Skipped != e.mark.Skipped {
+		res = append(res, ddl.Column{Name: "skipped", Value: e.Skipped})
+	}
+	if e.Attempt != e.mark.Attempt {
+		res = append(res, ddl.Column{Name: "attempt", Value: e.Attempt})
+	}
+	if e.ParentTaskId != e.mark.ParentTaskId {
+		res = append(res, ddl.Column{Name: "parent_task_id", Value: e.ParentTaskId})
+	}
+	if e.ConcurrencyGroup != e.mark.ConcurrencyGroup {
+		res = append(res, ddl.Column{Name: "concurrency_group", Value: e.ConcurrencyGroup})
+	}
+	if e.CancelReason != e.mark.CancelReason {
+		res = append(res, ddl.Column{Name: "cancel_reason", Value: e.CancelReason})
+	}
+	if e.Matrix != e.mark.Matrix {
+		res = append(res, ddl.Column{Name: "matrix", Value: e.Matrix})
+	}
 	if !e.CreatedAt.Equal(e.mark.CreatedAt) {
 		res = append(res, ddl.Column{Name: "created_at", Value: e.CreatedAt})
 	}
@@ -324,6 +354,12 @@ func (e *Task) Copy() *Task {
 		Container:              e.Container,
 		ExecutedTestsCount:     e.ExecutedTestsCount,
 		SucceededTestsCount:    e.SucceededTestsCount,
+		Skipped:                e.Skipped,
+		Attempt:                e.Attempt,
+		ParentTaskId:           e.ParentTaskId,
+		ConcurrencyGroup:       e.ConcurrencyGroup,
+		CancelReason:           e.CancelReason,
+		Matrix:                 e.Matrix,
 		CreatedAt:              e.CreatedAt,
 	}
 	if e.JobConfiguration != nil {
@@ -923,3 +959,527 @@ func (e *ExternalReleaseHistory) Copy() *ExternalReleaseHistory {
 
 	return n
 }
+
+// TargetResult is the outcome of a target which is reported in the build event stream of the task.
+type TargetResult struct {
+	Id              int32
+	TaskId          int32
+	Label           string
+	Success         bool
+	FailureCategory string
+	FailureMessage  string
+
+	mu   sync.Mutex
+	mark *TargetResult
+}
+
+func (e *TargetResult) ResetMark() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.mark = e.Copy()
+}
+
+func (e *TargetResult) IsChanged() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.TaskId != e.mark.TaskId ||
+		e.Label != e.mark.Label ||
+		e.Success != e.mark.Success ||
+		e.FailureCategory != e.mark.FailureCategory ||
+		e.FailureMessage != e.mark.FailureMessage
+}
+
+func (e *TargetResult) ChangedColumn() []ddl.Column {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	res := make([]ddl.Column, 0)
+	if e.TaskId != e.mark.TaskId {
+		res = append(res, ddl.Column{Name: "task_id", Value: e.TaskId})
+	}
+	if e.Label != e.mark.Label {
+		res = append(res, ddl.Column{Name: "label", Value: e.Label})
+	}
+	if e.Success != e.mark.Success {
+		res = append(res, ddl.Column{Name: "success", Value: e.Success})
+	}
+	if e.FailureCategory != e.mark.FailureCategory {
+		res = append(res, ddl.Column{Name: "failure_category", Value: e.FailureCategory})
+	}
+	if e.FailureMessage != e.mark.FailureMessage {
+		res = append(res, ddl.Column{Name: "failure_message", Value: e.FailureMessage})
+	}
+
+	return res
+}
+
+func (e *TargetResult) Copy() *TargetResult {
+	n := &TargetResult{
+		Id:              e.Id,
+		TaskId:          e.TaskId,
+		Label:           e.Label,
+		Success:         e.Success,
+		FailureCategory: e.FailureCategory,
+		FailureMessage:  e.FailureMessage,
+	}
+
+	return n
+}
+
+// ActionFailure is a failed action which is reported in the build event stream of the task.
+// Only the failed actions are reported by Bazel unless --build_event_publish_all_actions is given.
+type ActionFailure struct {
+	Id              int32
+	TaskId          int32
+	Label           string
+	Mnemonic        string
+	ExitCode        int32
+	FailureCategory string
+	FailureMessage  string
+	PrimaryOutput   string
+
+	mu   sync.Mutex
+	mark *ActionFailure
+}
+
+func (e *ActionFailure) ResetMark() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.mark = e.Copy()
+}
+
+func (e *ActionFailure) IsChanged() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.TaskId != e.mark.TaskId ||
+		e.Label != e.mark.Label ||
+		e.Mnemonic != e.mark.Mnemonic ||
+		e.ExitCode != e.mark.ExitCode ||
+		e.FailureCategory != e.mark.FailureCategory ||
+		e.FailureMessage != e.mark.FailureMessage ||
+		e.PrimaryOutput != e.mark.PrimaryOutput
+}
+
+func (e *ActionFailure) ChangedColumn() []ddl.Column {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	res := make([]ddl.Column, 0)
+	if e.TaskId != e.mark.TaskId {
+		res = append(res, ddl.Column{Name: "task_id", Value: e.TaskId})
+	}
+	if e.Label != e.mark.Label {
+		res = append(res, ddl.Column{Name: "label", Value: e.Label})
+	}
+	if e.Mnemonic != e.mark.Mnemonic {
+		res = append(res, ddl.Column{Name: "mnemonic", Value: e.Mnemonic})
+	}
+	if e.ExitCode != e.mark.ExitCode {
+		res = append(res, ddl.Column{Name: "exit_code", Value: e.ExitCode})
+	}
+	if e.FailureCategory != e.mark.FailureCategory {
+		res = append(res, ddl.Column{Name: "failure_category", Value: e.FailureCategory})
+	}
+	if e.FailureMessage != e.mark.FailureMessage {
+		res = append(res, ddl.Column{Name: "failure_message", Value: e.FailureMessage})
+	}
+	if e.PrimaryOutput != e.mark.PrimaryOutput {
+		res = append(res, ddl.Column{Name: "primary_output", Value: e.PrimaryOutput})
+	}
+
+	return res
+}
+
+func (e *ActionFailure) Copy() *ActionFailure {
+	n := &ActionFailure{
+		Id:              e.Id,
+		TaskId:          e.TaskId,
+		Label:           e.Label,
+		Mnemonic:        e.Mnemonic,
+		ExitCode:        e.ExitCode,
+		FailureCategory: e.FailureCategory,
+		FailureMessage:  e.FailureMessage,
+		PrimaryOutput:   e.PrimaryOutput,
+	}
+
+	return n
+}
+
+// BuildMetrics is the metrics of the build which is reported at the end of the build event stream of the task.
+type BuildMetrics struct {
+	Id                   int32
+	TaskId               int32
+	ExitCodeName         string
+	ActionsCreated       int64
+	ActionsExecuted      int64
+	RemoteCacheHits      int64
+	ActionCacheHits      int32
+	ActionCacheMisses    int32
+	TargetsConfigured    int64
+	WallTimeMs           int64
+	CpuTimeMs            int64
+	AnalysisPhaseTimeMs  int64
+	ExecutionPhaseTimeMs int64
+
+	mu   sync.Mutex
+	mark *BuildMetrics
+}
+
+func (e *BuildMetrics) ResetMark() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.mark = e.Copy()
+}
+
+func (e *BuildMetrics) IsChanged() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.TaskId != e.mark.TaskId ||
+		e.ExitCodeName != e.mark.ExitCodeName ||
+		e.ActionsCreated != e.mark.ActionsCreated ||
+		e.ActionsExecuted != e.mark.ActionsExecuted ||
+		e.RemoteCacheHits != e.mark.RemoteCacheHits ||
+		e.ActionCacheHits != e.mark.ActionCacheHits ||
+		e.ActionCacheMisses != e.mark.ActionCacheMisses ||
+		e.TargetsConfigured != e.mark.TargetsConfigured ||
+		e.WallTimeMs != e.mark.WallTimeMs ||
+		e.CpuTimeMs != e.mark.CpuTimeMs ||
+		e.AnalysisPhaseTimeMs != e.mark.AnalysisPhaseTimeMs ||
+		e.ExecutionPhaseTimeMs != e.mark.ExecutionPhaseTimeMs
+}
+
+func (e *BuildMetrics) ChangedColumn() []ddl.Column {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	res := make([]ddl.Column, 0)
+	if e.TaskId != e.mark.TaskId {
+		res = append(res, ddl.Column{Name: "task_id", Value: e.TaskId})
+	}
+	if e.ExitCodeName != e.mark.ExitCodeName {
+		res = append(res, ddl.Column{Name: "exit_code_name", Value: e.ExitCodeName})
+	}
+	if e.ActionsCreated != e.mark.ActionsCreated {
+		res = append(res, ddl.Column{Name: "actions_created", Value: e.ActionsCreated})
+	}
+	if e.ActionsExecuted != e.mark.ActionsExecuted {
+		res = append(res, ddl.Column{Name: "actions_executed", Value: e.ActionsExecuted})
+	}
+	if e.RemoteCacheHits != e.mark.RemoteCacheHits {
+		res = append(res, ddl.Column{Name: "remote_cache_hits", Value: e.RemoteCacheHits})
+	}
+	if e.ActionCacheHits != e.mark.ActionCacheHits {
+		res = append(res, ddl.Column{Name: "action_cache_hits", Value: e.ActionCacheHits})
+	}
+	if e.ActionCacheMisses != e.mark.ActionCacheMisses {
+		res = append(res, ddl.Column{Name: "action_cache_misses", Value: e.ActionCacheMisses})
+	}
+	if e.TargetsConfigured != e.mark.TargetsConfigured {
+		res = append(res, ddl.Column{Name: "targets_configured", Value: e.TargetsConfigured})
+	}
+	if e.WallTimeMs != e.mark.WallTimeMs {
+		res = append(res, ddl.Column{Name: "wall_time_ms", Value: e.WallTimeMs})
+	}
+	if e.CpuTimeMs != e.mark.CpuTimeMs {
+		res = append(res, ddl.Column{Name: "cpu_time_ms", Value: e.CpuTimeMs})
+	}
+	if e.AnalysisPhaseTimeMs != e.mark.AnalysisPhaseTimeMs {
+		res = append(res, ddl.Column{Name: "analysis_phase_time_ms", Value: e.AnalysisPhaseTimeMs})
+	}
+	if e.ExecutionPhaseTimeMs != e.mark.ExecutionPhaseTimeMs {
+		res = append(res, ddl.Column{Name: "execution_phase_time_ms", Value: e.ExecutionPhaseTimeMs})
+	}
+
+	return res
+}
+
+func (e *BuildMetrics) Copy() *BuildMetrics {
+	n := &BuildMetrics{
+		Id:                   e.Id,
+		TaskId:               e.TaskId,
+		ExitCodeName:         e.ExitCodeName,
+		ActionsCreated:       e.ActionsCreated,
+		ActionsExecuted:      e.ActionsExecuted,
+		RemoteCacheHits:      e.RemoteCacheHits,
+		ActionCacheHits:      e.ActionCacheHits,
+		ActionCacheMisses:    e.ActionCacheMisses,
+		TargetsConfigured:    e.TargetsConfigured,
+		WallTimeMs:           e.WallTimeMs,
+		CpuTimeMs:            e.CpuTimeMs,
+		AnalysisPhaseTimeMs:  e.AnalysisPhaseTimeMs,
+		ExecutionPhaseTimeMs: e.ExecutionPhaseTimeMs,
+	}
+
+	return n
+}
+
+// FlakyTest is the flakiness of a test which is computed from the test reports of the repository.
+type FlakyTest struct {
+	Id              int32
+	RepositoryId    int32
+	Label           string
+	Runs            int32
+	FlakyRuns       int32
+	Score           float64
+	Quarantined     bool
+	LastFlakyTaskId int32
+	CreatedAt       time.Time
+	UpdatedAt       *time.Time
+
+	mu   sync.Mutex
+	mark *FlakyTest
+}
+
+func (e *FlakyTest) ResetMark() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.mark = e.Copy()
+}
+
+func (e *FlakyTest) IsChanged() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.RepositoryId != e.mark.RepositoryId ||
+		e.Label != e.mark.Label ||
+		e.Runs != e.mark.Runs ||
+		e.FlakyRuns != e.mark.FlakyRuns ||
+		e.Score != e.mark.Score ||
+		e.Quarantined != e.mark.Quarantined ||
+		e.LastFlakyTaskId != e.mark.LastFlakyTaskId ||
+		!e.CreatedAt.Equal(e.mark.CreatedAt) ||
+		((e.UpdatedAt != nil && (e.mark.UpdatedAt == nil || !e.UpdatedAt.Equal(*e.mark.UpdatedAt))) || (e.UpdatedAt == nil && e.mark.UpdatedAt != nil))
+}
+
+func (e *FlakyTest) ChangedColumn() []ddl.Column {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	res := make([]ddl.Column, 0)
+	if e.RepositoryId != e.mark.RepositoryId {
+		res = append(res, ddl.Column{Name: "repository_id", Value: e.RepositoryId})
+	}
+	if e.Label != e.mark.Label {
+		res = append(res, ddl.Column{Name: "label", Value: e.Label})
+	}
+	if e.Runs != e.mark.Runs {
+		res = append(res, ddl.Column{Name: "runs", Value: e.Runs})
+	}
+	if e.FlakyRuns != e.mark.FlakyRuns {
+		res = append(res, ddl.Column{Name: "flaky_runs", Value: e.FlakyRuns})
+	}
+	if e.Score != e.mark.Score {
+		res = append(res, ddl.Column{Name: "score", Value: e.Score})
+	}
+	if e.Quarantined != e.mark.Quarantined {
+		res = append(res, ddl.Column{Name: "quarantined", Value: e.Quarantined})
+	}
+	if e.LastFlakyTaskId != e.mark.LastFlakyTaskId {
+		res = append(res, ddl.Column{Name: "last_flaky_task_id", Value: e.LastFlakyTaskId})
+	}
+	if !e.CreatedAt.Equal(e.mark.CreatedAt) {
+		res = append(res, ddl.Column{Name: "created_at", Value: e.CreatedAt})
+	}
+	if (e.UpdatedAt != nil && (e.mark.UpdatedAt == nil || !e.UpdatedAt.Equal(*e.mark.UpdatedAt))) || (e.UpdatedAt == nil && e.mark.UpdatedAt != nil) {
+		if e.UpdatedAt != nil {
+			res = append(res, ddl.Column{Name: "updated_at", Value: *e.UpdatedAt})
+		} else {
+			res = append(res, ddl.Column{Name: "updated_at", Value: nil})
+		}
+	}
+
+	return res
+}
+
+func (e *FlakyTest) Copy() *FlakyTest {
+	n := &FlakyTest{
+		Id:              e.Id,
+		RepositoryId:    e.RepositoryId,
+		Label:           e.Label,
+		Runs:            e.Runs,
+		FlakyRuns:       e.FlakyRuns,
+		Score:           e.Score,
+		Quarantined:     e.Quarantined,
+		LastFlakyTaskId: e.LastFlakyTaskId,
+		CreatedAt:       e.CreatedAt,
+	}
+
+	if e.UpdatedAt != nil {
+		v := *e.UpdatedAt
+		n.UpdatedAt = &v
+	}
+
+	return n
+}
+
+// Artifact is an output of the task which is uploaded to the object storage.
+// Path is the relative path from bazel-bin.
+type Artifact struct {
+	Id         int32
+	TaskId     int32
+	Label      string
+	Path       string
+	ObjectName string
+	Size       int64
+	CreatedAt  time.Time
+	UpdatedAt  *time.Time
+
+	mu   sync.Mutex
+	mark *Artifact
+}
+
+func (e *Artifact) ResetMark() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.mark = e.Copy()
+}
+
+func (e *Artifact) IsChanged() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.TaskId != e.mark.TaskId ||
+		e.Label != e.mark.Label ||
+		e.Path != e.mark.Path ||
+		e.ObjectName != e.mark.ObjectName ||
+		e.Size != e.mark.Size ||
+		!e.CreatedAt.Equal(e.mark.CreatedAt) ||
+		((e.UpdatedAt != nil && (e.mark.UpdatedAt == nil || !e.UpdatedAt.Equal(*e.mark.UpdatedAt))) || (e.UpdatedAt == nil && e.mark.UpdatedAt != nil))
+}
+
+func (e *Artifact) ChangedColumn() []ddl.Column {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	res := make([]ddl.Column, 0)
+	if e.TaskId != e.mark.TaskId {
+		res = append(res, ddl.Column{Name: "task_id", Value: e.TaskId})
+	}
+	if e.Label != e.mark.Label {
+		res = append(res, ddl.Column{Name: "label", Value: e.Label})
+	}
+	if e.Path != e.mark.Path {
+		res = append(res, ddl.Column{Name: "path", Value: e.Path})
+	}
+	if e.ObjectName != e.mark.ObjectName {
+		res = append(res, ddl.Column{Name: "object_name", Value: e.ObjectName})
+	}
+	if e.Size != e.mark.Size {
+		res = append(res, ddl.Column{Name: "size", Value: e.Size})
+	}
+	if !e.CreatedAt.Equal(e.mark.CreatedAt) {
+		res = append(res, ddl.Column{Name: "created_at", Value: e.CreatedAt})
+	}
+	if (e.UpdatedAt != nil && (e.mark.UpdatedAt == nil || !e.UpdatedAt.Equal(*e.mark.UpdatedAt))) || (e.UpdatedAt == nil && e.mark.UpdatedAt != nil) {
+		if e.UpdatedAt != nil {
+			res = append(res, ddl.Column{Name: "updated_at", Value: *e.UpdatedAt})
+		} else {
+			res = append(res, ddl.Column{Name: "updated_at", Value: nil})
+		}
+	}
+
+	return res
+}
+
+func (e *Artifact) Copy() *Artifact {
+	n := &Artifact{
+		Id:         e.Id,
+		TaskId:     e.TaskId,
+		Label:      e.Label,
+		Path:       e.Path,
+		ObjectName: e.ObjectName,
+		Size:       e.Size,
+		CreatedAt:  e.CreatedAt,
+	}
+
+	if e.UpdatedAt != nil {
+		v := *e.UpdatedAt
+		n.UpdatedAt = &v
+	}
+
+	return n
+}
+
+// PolledReference is the last seen hash of the branch of the repository which is polled instead of the webhook.
+type PolledReference struct {
+	Id           int32
+	RepositoryId int32
+	Ref          string
+	Hash         string
+	CreatedAt    time.Time
+	UpdatedAt    *time.Time
+
+	mu   sync.Mutex
+	mark *PolledReference
+}
+
+func (e *PolledReference) ResetMark() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.mark = e.Copy()
+}
+
+func (e *PolledReference) IsChanged() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.RepositoryId != e.mark.RepositoryId ||
+		e.Ref != e.mark.Ref ||
+		e.Hash != e.mark.Hash ||
+		!e.CreatedAt.Equal(e.mark.CreatedAt) ||
+		((e.UpdatedAt != nil && (e.mark.UpdatedAt == nil || !e.UpdatedAt.Equal(*e.mark.UpdatedAt))) || (e.UpdatedAt == nil && e.mark.UpdatedAt != nil))
+}
+
+func (e *PolledReference) ChangedColumn() []ddl.Column {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	res := make([]ddl.Column, 0)
+	if e.RepositoryId != e.mark.RepositoryId {
+		res = append(res, ddl.Column{Name: "repository_id", Value: e.RepositoryId})
+	}
+	if e.Ref != e.mark.Ref {
+		res = append(res, ddl.Column{Name: "ref", Value: e.Ref})
+	}
+	if e.Hash != e.mark.Hash {
+		res = append(res, ddl.Column{Name: "hash", Value: e.Hash})
+	}
+	if !e.CreatedAt.Equal(e.mark.CreatedAt) {
+		res = append(res, ddl.Column{Name: "created_at", Value: e.CreatedAt})
+	}
+	if (e.UpdatedAt != nil && (e.mark.UpdatedAt == nil || !e.UpdatedAt.Equal(*e.mark.UpdatedAt))) || (e.UpdatedAt == nil && e.mark.UpdatedAt != nil) {
+		if e.UpdatedAt != nil {
+			res = append(res, ddl.Column{Name: "updated_at", Value: *e.UpdatedAt})
+		} else {
+			res = append(res, ddl.Column{Name: "updated_at", Value: nil})
+		}
+	}
+
+	return res
+}
+
+func (e *PolledReference) Copy() *PolledReference {
+	n := &PolledReference{
+		Id:           e.Id,
+		RepositoryId: e.RepositoryId,
+		Ref:          e.Ref,
+		Hash:         e.Hash,
+		CreatedAt:    e.CreatedAt,
+	}
+
+	if e.UpdatedAt != nil {
+		v := *e.UpdatedAt
+		n.UpdatedAt = &v
+	}
+
+	return n
+}
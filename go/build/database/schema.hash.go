@@ -1,3 +1,3 @@
 package database
 
-const SchemaHash = "d64ef046a55bbc03a3842237544e62bf06511cc81fa32ac9eca279fbdbda9670"
+const SchemaHash = "787e9424a8a232929973b40f1f945e8e237698a3aa40f962e33a9240b211b983"
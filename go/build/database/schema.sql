@@ -43,6 +43,12 @@ CREATE TABLE `task` (
 	`succeeded_tests_count` INTEGER NOT NULL,
 	`start_at` DATETIME NULL,
 	`finished_at` DATETIME NULL,
+	`skipped` TINYINT(1) NOT NULL,
+	`attempt` INTEGER NOT NULL,
+	`parent_task_id` INTEGER NOT NULL,
+	`concurrency_group` VARCHAR(255) NOT NULL,
+	`cancel_reason` VARCHAR(255) NOT NULL,
+	`matrix` TEXT NOT NULL,
 	`created_at` DATETIME NOT NULL,
 	`updated_at` DATETIME NULL,
 	INDEX `idx_repo` (`repository_id`),
@@ -138,4 +144,91 @@ CREATE TABLE `external_release_history` (
 	PRIMARY KEY(`id`)
 ) Engine=InnoDB;
 
+DROP TABLE IF EXISTS `target_result`;
+CREATE TABLE `target_result` (
+	`id` INTEGER NOT NULL AUTO_INCREMENT,
+	`task_id` INTEGER NOT NULL,
+	`label` VARCHAR(255) NOT NULL,
+	`success` TINYINT(1) NOT NULL,
+	`failure_category` VARCHAR(255) NOT NULL,
+	`failure_message` TEXT NOT NULL,
+	INDEX `idx_task_id` (`task_id`),
+	PRIMARY KEY(`id`)
+) Engine=InnoDB;
+
+DROP TABLE IF EXISTS `action_failure`;
+CREATE TABLE `action_failure` (
+	`id` INTEGER NOT NULL AUTO_INCREMENT,
+	`task_id` INTEGER NOT NULL,
+	`label` VARCHAR(255) NOT NULL,
+	`mnemonic` VARCHAR(255) NOT NULL,
+	`exit_code` INTEGER NOT NULL,
+	`failure_category` VARCHAR(255) NOT NULL,
+	`failure_message` TEXT NOT NULL,
+	`primary_output` TEXT NOT NULL,
+	INDEX `idx_task_id` (`task_id`),
+	PRIMARY KEY(`id`)
+) Engine=InnoDB;
+
+DROP TABLE IF EXISTS `build_metrics`;
+CREATE TABLE `build_metrics` (
+	`id` INTEGER NOT NULL AUTO_INCREMENT,
+	`task_id` INTEGER NOT NULL,
+	`exit_code_name` VARCHAR(255) NOT NULL,
+	`actions_created` BIGINT NOT NULL,
+	`actions_executed` BIGINT NOT NULL,
+	`remote_cache_hits` BIGINT NOT NULL,
+	`action_cache_hits` INTEGER NOT NULL,
+	`action_cache_misses` INTEGER NOT NULL,
+	`targets_configured` BIGINT NOT NULL,
+	`wall_time_ms` BIGINT NOT NULL,
+	`cpu_time_ms` BIGINT NOT NULL,
+	`analysis_phase_time_ms` BIGINT NOT NULL,
+	`execution_phase_time_ms` BIGINT NOT NULL,
+	UNIQUE `uniq_task_id` (`task_id`),
+	PRIMARY KEY(`id`)
+) Engine=InnoDB;
+
+DROP TABLE IF EXISTS `flaky_test`;
+CREATE TABLE `flaky_test` (
+	`id` INTEGER NOT NULL AUTO_INCREMENT,
+	`repository_id` INTEGER NOT NULL,
+	`label` VARCHAR(255) NOT NULL,
+	`runs` INTEGER NOT NULL,
+	`flaky_runs` INTEGER NOT NULL,
+	`score` DOUBLE NOT NULL,
+	`quarantined` TINYINT(1) NOT NULL,
+	`last_flaky_task_id` INTEGER NOT NULL,
+	`created_at` DATETIME NOT NULL,
+	`updated_at` DATETIME NULL,
+	UNIQUE `uniq_repository_label` (`repository_id`, `label`),
+	PRIMARY KEY(`id`)
+) Engine=InnoDB;
+
+DROP TABLE IF EXISTS `artifact`;
+CREATE TABLE `artifact` (
+	`id` INTEGER NOT NULL AUTO_INCREMENT,
+	`task_id` INTEGER NOT NULL,
+	`label` VARCHAR(255) NOT NULL,
+	`path` TEXT NOT NULL,
+	`object_name` TEXT NOT NULL,
+	`size` BIGINT NOT NULL,
+	`created_at` DATETIME NOT NULL,
+	`updated_at` DATETIME NULL,
+	INDEX `idx_task_id` (`task_id`),
+	PRIMARY KEY(`id`)
+) Engine=InnoDB;
+
+DROP TABLE IF EXISTS `polled_reference`;
+CREATE TABLE `polled_reference` (
+	`id` INTEGER NOT NULL AUTO_INCREMENT,
+	`repository_id` INTEGER NOT NULL,
+	`ref` VARCHAR(255) NOT NULL,
+	`hash` VARCHAR(255) NOT NULL,
+	`created_at` DATETIME NOT NULL,
+	`updated_at` DATETIME NULL,
+	UNIQUE `uniq_repository_ref` (`repository_id`, `ref`),
+	PRIMARY KEY(`id`)
+) Engine=InnoDB;
+
 SET foreign_key_checks=1;
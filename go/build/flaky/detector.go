@@ -0,0 +1,168 @@
+package flaky
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"go.f110.dev/xerrors"
+
+	"go.f110.dev/mono/go/build/database"
+	"go.f110.dev/mono/go/build/database/dao"
+	"go.f110.dev/mono/go/logger/slogger"
+)
+
+const (
+	// DefaultWindow is the number of the latest test reports of a label which are analysed.
+	DefaultWindow = 100
+	// DefaultQuarantineScore is the score at which a test is quarantined.
+	DefaultQuarantineScore = 0.1
+	// DefaultMinFlakyRuns is the number of the flaky revisions which are needed to quarantine a test.
+	// It prevents a test which ran only a few times from being quarantined by a single flip.
+	DefaultMinFlakyRuns = 2
+)
+
+// Result is the flakiness of a test.
+type Result struct {
+	// Runs is the number of the revisions at which the test ran.
+	Runs int32
+	// FlakyRuns is the number of the revisions at which the test was flaky.
+	FlakyRuns int32
+	// Score is the ratio of FlakyRuns to Runs.
+	Score float64
+	// LastFlakyTaskId is the id of the latest task which ran the test at a flaky revision.
+	LastFlakyTaskId int32
+}
+
+// Analyze computes the flakiness of a test from its test reports.
+// The reports are grouped by the revision of the task. The test is flaky at a revision if it both passed and failed
+// at the revision (e.g. the task was retried or rebuilt) or if Bazel reported it as flaky.
+func Analyze(reports []*database.TestReport) Result {
+	type revision struct {
+		passed, failed, flaky bool
+		lastTaskId            int32
+	}
+	revisions := make(map[string]*revision)
+	for _, v := range reports {
+		// The task may be already deleted by GC. Such a report can't be compared with the others.
+		key := fmt.Sprintf("task:%d", v.TaskId)
+		if v.Task != nil && v.Task.Revision != "" {
+			key = v.Task.Revision
+		}
+		r, ok := revisions[key]
+		if !ok {
+			r = &revision{}
+			revisions[key] = r
+		}
+		switch v.Status {
+		case database.TestStatusPassed:
+			r.passed = true
+		case database.TestStatusFailed:
+			r.failed = true
+		case database.TestStatusFlaky:
+			r.flaky = true
+		}
+		r.lastTaskId = max(r.lastTaskId, v.TaskId)
+	}
+
+	var res Result
+	for _, r := range revisions {
+		res.Runs++
+		if r.flaky || (r.passed && r.failed) {
+			res.FlakyRuns++
+			res.LastFlakyTaskId = max(res.LastFlakyTaskId, r.lastTaskId)
+		}
+	}
+	if res.Runs > 0 {
+		res.Score = float64(res.FlakyRuns) / float64(res.Runs)
+	}
+	return res
+}
+
+// Detector keeps the flakiness of tests up to date from the test reports of the repository.
+type Detector struct {
+	dao             dao.Options
+	window          int
+	quarantineScore float64
+	minFlakyRuns    int32
+}
+
+func NewDetector(daoOpt dao.Options) *Detector {
+	return &Detector{
+		dao:             daoOpt,
+		window:          DefaultWindow,
+		quarantineScore: DefaultQuarantineScore,
+		minFlakyRuns:    DefaultMinFlakyRuns,
+	}
+}
+
+// Update analyses the test reports of labels and saves the flakiness of them.
+// A test is quarantined while its score is higher than or equal to the quarantine score and it was flaky enough times.
+// The quarantined tests keep running, so the test is released when its score drops by passing stably.
+func (d *Detector) Update(ctx context.Context, repositoryId int32, labels []string) error {
+	for _, label := range labels {
+		reports, err := d.dao.TestReport.ListByRepositoryAndLabel(ctx, repositoryId, label, dao.Desc, dao.Limit(d.window))
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		res := Analyze(reports)
+		quarantined := res.FlakyRuns >= d.minFlakyRuns && res.Score >= d.quarantineScore
+
+		flakyTests, err := d.dao.FlakyTest.ListByRepositoryAndLabel(ctx, repositoryId, label)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		if len(flakyTests) == 0 {
+			if res.FlakyRuns == 0 {
+				continue
+			}
+			_, err := d.dao.FlakyTest.Create(ctx, &database.FlakyTest{
+				RepositoryId:    repositoryId,
+				Label:           label,
+				Runs:            res.Runs,
+				FlakyRuns:       res.FlakyRuns,
+				Score:           res.Score,
+				Quarantined:     quarantined,
+				LastFlakyTaskId: res.LastFlakyTaskId,
+			})
+			if err != nil {
+				return xerrors.WithStack(err)
+			}
+			slogger.Log.Info("Found a flaky test", slog.String("label", label), slog.Float64("score", res.Score))
+			continue
+		}
+
+		flakyTest := flakyTests[0]
+		if !flakyTest.Quarantined && quarantined {
+			slogger.Log.Info("Quarantine the test", slog.String("label", label), slog.Float64("score", res.Score))
+		} else if flakyTest.Quarantined && !quarantined {
+			slogger.Log.Info("Release the test from the quarantine", slog.String("label", label), slog.Float64("score", res.Score))
+		}
+		flakyTest.Runs = res.Runs
+		flakyTest.FlakyRuns = res.FlakyRuns
+		flakyTest.Score = res.Score
+		flakyTest.Quarantined = quarantined
+		flakyTest.LastFlakyTaskId = res.LastFlakyTaskId
+		if err := d.dao.FlakyTest.Update(ctx, flakyTest); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+// QuarantinedLabels returns the labels of the quarantined tests of the repository.
+func (d *Detector) QuarantinedLabels(ctx context.Context, repositoryId int32) ([]string, error) {
+	flakyTests, err := d.dao.FlakyTest.ListByRepositoryId(ctx, repositoryId)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	var labels []string
+	for _, v := range flakyTests {
+		if v.Quarantined {
+			labels = append(labels, v.Label)
+		}
+	}
+	return labels, nil
+}
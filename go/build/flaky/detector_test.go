@@ -0,0 +1,114 @@
+package flaky
+
+import (
+	"fmt"
+	"testing"
+
+	"go.f110.dev/mono/go/build/database"
+	"go.f110.dev/mono/go/build/database/dao"
+	"go.f110.dev/mono/go/build/database/dao/daotest"
+	"go.f110.dev/mono/go/logger/slogger"
+	"go.f110.dev/mono/go/testing/assertion"
+)
+
+func TestAnalyze(t *testing.T) {
+	report := func(taskId int32, revision string, status database.TestStatus) *database.TestReport {
+		r := &database.TestReport{TaskId: taskId, Status: status}
+		if revision != "" {
+			r.Task = &database.Task{Id: taskId, Revision: revision}
+		}
+		return r
+	}
+
+	cases := []struct {
+		Name    string
+		Reports []*database.TestReport
+		Result  Result
+	}{
+		{Name: "No report"},
+		{
+			Name: "Stable",
+			Reports: []*database.TestReport{
+				report(1, "a", database.TestStatusPassed),
+				report(2, "b", database.TestStatusFailed),
+				report(3, "c", database.TestStatusPassed),
+			},
+			Result: Result{Runs: 3},
+		},
+		{
+			Name: "Passed by retry",
+			Reports: []*database.TestReport{
+				report(1, "a", database.TestStatusFailed),
+				report(2, "a", database.TestStatusPassed),
+				report(3, "b", database.TestStatusPassed),
+				report(4, "c", database.TestStatusPassed),
+			},
+			Result: Result{Runs: 3, FlakyRuns: 1, Score: 1.0 / 3.0, LastFlakyTaskId: 2},
+		},
+		{
+			Name: "Reported as flaky by Bazel",
+			Reports: []*database.TestReport{
+				report(1, "a", database.TestStatusFlaky),
+				report(2, "b", database.TestStatusPassed),
+			},
+			Result: Result{Runs: 2, FlakyRuns: 1, Score: 0.5, LastFlakyTaskId: 1},
+		},
+		{
+			Name: "Deleted task",
+			Reports: []*database.TestReport{
+				report(1, "", database.TestStatusFailed),
+				report(2, "", database.TestStatusPassed),
+			},
+			Result: Result{Runs: 2},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertion.Equal(t, Analyze(tc.Reports), tc.Result)
+		})
+	}
+}
+
+func TestDetector_Update(t *testing.T) {
+	slogger.Init()
+	// The test was flaky at 2 revisions.
+	var reports []*database.TestReport
+	for i := range 2 {
+		rev := fmt.Sprintf("flaky-%d", i)
+		reports = append(reports,
+			&database.TestReport{TaskId: int32(i*2 + 1), Status: database.TestStatusFailed, Task: &database.Task{Revision: rev}},
+			&database.TestReport{TaskId: int32(i*2 + 2), Status: database.TestStatusPassed, Task: &database.Task{Revision: rev}},
+		)
+	}
+	update := func(t *testing.T, reports []*database.TestReport, flakyTest *database.FlakyTest) {
+		testReport := daotest.NewTestReport()
+		testReport.RegisterListByRepositoryAndLabel(1, "//go/flaky:flaky_test", reports, nil)
+		flakyTestDAO := daotest.NewFlakyTest()
+		flakyTestDAO.RegisterListByRepositoryAndLabel(1, "//go/flaky:flaky_test", []*database.FlakyTest{flakyTest}, nil)
+		d := NewDetector(dao.Options{TestReport: testReport, FlakyTest: flakyTestDAO})
+
+		err := d.Update(t.Context(), 1, []string{"//go/flaky:flaky_test"})
+		assertion.MustNoError(t, err)
+		assertion.MustLen(t, flakyTestDAO.Called("Update"), 1)
+	}
+
+	t.Run("Quarantine", func(t *testing.T) {
+		flakyTest := &database.FlakyTest{Id: 1, RepositoryId: 1, Label: "//go/flaky:flaky_test", Runs: 1, FlakyRuns: 1, Score: 1}
+		update(t, reports, flakyTest)
+		assertion.True(t, flakyTest.Quarantined)
+		assertion.Equal(t, flakyTest.FlakyRuns, int32(2))
+	})
+
+	t.Run("Recover", func(t *testing.T) {
+		// The quarantined test keeps running and passes at the following revisions.
+		passed := reports
+		for i := range 20 {
+			passed = append(passed, &database.TestReport{TaskId: int32(100 + i), Status: database.TestStatusPassed, Task: &database.Task{Revision: fmt.Sprintf("stable-%d", i)}})
+		}
+		flakyTest := &database.FlakyTest{Id: 1, RepositoryId: 1, Label: "//go/flaky:flaky_test", Runs: 2, FlakyRuns: 2, Score: 1, Quarantined: true}
+		update(t, passed, flakyTest)
+		assertion.False(t, flakyTest.Quarantined)
+		assertion.Equal(t, flakyTest.Runs, int32(22))
+	})
+}
@@ -15,17 +15,61 @@ import (
 	"go.f110.dev/mono/go/storage"
 )
 
+// keepTasks is the number of the latest tasks which are not deleted.
+const keepTasks = 10
+
+// ArtifactRetention is the policy for deleting the artifacts of the tasks.
+type ArtifactRetention struct {
+	// TrunkBuilds is the number of the latest successful trunk builds of each job whose artifacts are kept.
+	TrunkBuilds int
+	// NonTrunkTTL is the period for keeping the artifacts of the tasks which are not trunk builds (e.g. pull requests).
+	NonTrunkTTL time.Duration
+}
+
+// Expired returns the ids of the tasks whose artifacts have to be deleted.
+// tasks have to be sorted by id in descending order.
+func (r ArtifactRetention) Expired(tasks []*database.Task, now time.Time) map[int32]struct{} {
+	expired := make(map[int32]struct{})
+	trunkBuilds := make(map[int32]map[string]int)
+	for _, t := range tasks {
+		if t.FinishedAt == nil {
+			continue
+		}
+		if !t.IsTrunk {
+			if t.FinishedAt.Add(r.NonTrunkTTL).Before(now) {
+				expired[t.Id] = struct{}{}
+			}
+			continue
+		}
+		if !t.Success {
+			expired[t.Id] = struct{}{}
+			continue
+		}
+
+		if _, ok := trunkBuilds[t.RepositoryId]; !ok {
+			trunkBuilds[t.RepositoryId] = make(map[string]int)
+		}
+		trunkBuilds[t.RepositoryId][t.JobName]++
+		if trunkBuilds[t.RepositoryId][t.JobName] > r.TrunkBuilds {
+			expired[t.Id] = struct{}{}
+		}
+	}
+	return expired
+}
+
 type GC struct {
-	interval time.Duration
-	dao      dao.Options
-	storage  *storage.S3
+	interval  time.Duration
+	dao       dao.Options
+	storage   storage.Backend
+	retention ArtifactRetention
 }
 
-func NewGC(interval time.Duration, daoOpt dao.Options, bucket string, storageOpt storage.S3Options) *GC {
+func NewGC(interval time.Duration, daoOpt dao.Options, backend storage.Backend, retention ArtifactRetention) *GC {
 	return &GC{
-		interval: interval,
-		dao:      daoOpt,
-		storage:  storage.NewS3(bucket, storageOpt),
+		interval:  interval,
+		dao:       daoOpt,
+		storage:   backend,
+		retention: retention,
 	}
 }
 
@@ -51,19 +95,59 @@ func (g *GC) sweep(ctx context.Context) {
 		slogger.Log.Warn("Failed to get all tasks", slogger.E(err))
 		return
 	}
+	artifacts, err := g.dao.Artifact.ListAll(ctx)
+	if err != nil {
+		slogger.Log.Warn("Failed to get all artifacts", slogger.E(err))
+		return
+	}
 
 	sort.Slice(tasks, func(i, j int) bool {
 		return tasks[i].Id > tasks[j].Id
 	})
 
-	garbageTasks := tasks[10:]
+	artifactsByTask := make(map[int32][]*database.Artifact)
+	for _, v := range artifacts {
+		artifactsByTask[v.TaskId] = append(artifactsByTask[v.TaskId], v)
+	}
+	for taskId := range g.retention.Expired(tasks, time.Now()) {
+		if len(artifactsByTask[taskId]) == 0 {
+			continue
+		}
+		if err := g.cleanArtifacts(ctx, artifactsByTask[taskId]); err != nil {
+			slogger.Log.Info("Failed to cleanup artifacts", slogger.E(err), slog.Int("task_id", int(taskId)))
+			continue
+		}
+		delete(artifactsByTask, taskId)
+	}
+
+	if len(tasks) <= keepTasks {
+		return
+	}
+	garbageTasks := tasks[keepTasks:]
 	for _, t := range garbageTasks {
+		// The task is kept while the artifacts are retained.
+		if len(artifactsByTask[t.Id]) > 0 {
+			continue
+		}
 		if err := g.cleanTask(ctx, t); err != nil {
 			slogger.Log.Info("Failed to cleanup task", slogger.E(err), slog.Int("task_id", int(t.Id)))
 		}
 	}
 }
 
+func (g *GC) cleanArtifacts(ctx context.Context, artifacts []*database.Artifact) error {
+	for _, v := range artifacts {
+		slogger.Log.Info("Delete artifact from object storage", slog.String("name", v.ObjectName), slog.Int("task_id", int(v.TaskId)))
+		if err := g.storage.Delete(ctx, v.ObjectName); err != nil {
+			return xerrors.WithStack(err)
+		}
+		if err := g.dao.Artifact.Delete(ctx, v.Id); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+	return nil
+}
+
 func (g *GC) cleanTask(ctx context.Context, t *database.Task) error {
 	if t.FinishedAt == nil {
 		return nil
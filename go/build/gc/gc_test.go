@@ -0,0 +1,34 @@
+package gc
+
+import (
+	"testing"
+	"time"
+
+	"go.f110.dev/mono/go/build/database"
+	"go.f110.dev/mono/go/testing/assertion"
+)
+
+func TestArtifactRetention_Expired(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	task := func(id int32, jobName string, trunk, success bool, finishedAt time.Time) *database.Task {
+		t := &database.Task{Id: id, RepositoryId: 1, JobName: jobName, IsTrunk: trunk, Success: success}
+		if !finishedAt.IsZero() {
+			t.FinishedAt = &finishedAt
+		}
+		return t
+	}
+
+	r := ArtifactRetention{TrunkBuilds: 2, NonTrunkTTL: 7 * 24 * time.Hour}
+	expired := r.Expired([]*database.Task{
+		task(9, "build", true, false, time.Time{}),
+		task(8, "build", false, true, now.Add(-24*time.Hour)),
+		task(7, "build", true, true, now.Add(-48*time.Hour)),
+		task(6, "build", true, false, now.Add(-72*time.Hour)),
+		task(5, "release", true, true, now.Add(-96*time.Hour)),
+		task(4, "build", true, true, now.Add(-96*time.Hour)),
+		task(3, "build", true, true, now.Add(-120*time.Hour)),
+		task(2, "build", false, true, now.Add(-8*24*time.Hour)),
+		task(1, "release", true, true, now.Add(-30*24*time.Hour)),
+	}, now)
+	assertion.Equal(t, expired, map[int32]struct{}{6: {}, 3: {}, 2: {}})
+}
@@ -264,11 +264,14 @@ func (x *Repository) ClearHeadRevision() {
 type Repository_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Id           *int32
-	Name         *string
-	Url          *string
-	CloneUrl     *string
-	Private      *bool
+	Id       *int32
+	Name     *string
+	Url      *string
+	CloneUrl *string
+	Private  *bool
+	// Full commit SHA at the tip of default_branch. Populated only when the API
+	// server has access to git-data-service; empty otherwise. Clients shorten
+	// this for display.
 	HeadRevision *string
 }
 
@@ -333,8 +336,14 @@ type Task struct {
 	xxx_hidden_CpuLimit               *string                `protobuf:"bytes,26,opt,name=cpu_limit,json=cpuLimit"`
 	xxx_hidden_MemoryLimit            *string                `protobuf:"bytes,27,opt,name=memory_limit,json=memoryLimit"`
 	xxx_hidden_TestReports            *[]*TestReport         `protobuf:"bytes,28,rep,name=test_reports,json=testReports"`
+	xxx_hidden_Skipped                bool                   `protobuf:"varint,29,opt,name=skipped"`
+	xxx_hidden_Needs                  []string               `protobuf:"bytes,30,rep,name=needs"`
+	xxx_hidden_Attempt                int32                  `protobuf:"varint,31,opt,name=attempt"`
+	xxx_hidden_ParentTaskId           int32                  `protobuf:"varint,32,opt,name=parent_task_id,json=parentTaskId"`
+	xxx_hidden_CancelReason           *string                `protobuf:"bytes,33,opt,name=cancel_reason,json=cancelReason"`
+	xxx_hidden_Matrix                 map[string]string      `protobuf:"bytes,34,rep,name=matrix" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	XXX_raceDetectHookData            protoimpl.RaceDetectHookData
-	XXX_presence                      [1]uint32
+	XXX_presence                      [2]uint32
 	unknownFields                     protoimpl.UnknownFields
 	sizeCache                         protoimpl.SizeCache
 }
@@ -610,54 +619,99 @@ func (x *Task) GetTestReports() []*TestReport {
 	return nil
 }
 
+func (x *Task) GetSkipped() bool {
+	if x != nil {
+		return x.xxx_hidden_Skipped
+	}
+	return false
+}
+
+func (x *Task) GetNeeds() []string {
+	if x != nil {
+		return x.xxx_hidden_Needs
+	}
+	return nil
+}
+
+func (x *Task) GetAttempt() int32 {
+	if x != nil {
+		return x.xxx_hidden_Attempt
+	}
+	return 0
+}
+
+func (x *Task) GetParentTaskId() int32 {
+	if x != nil {
+		return x.xxx_hidden_ParentTaskId
+	}
+	return 0
+}
+
+func (x *Task) GetCancelReason() string {
+	if x != nil {
+		if x.xxx_hidden_CancelReason != nil {
+			return *x.xxx_hidden_CancelReason
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Task) GetMatrix() map[string]string {
+	if x != nil {
+		return x.xxx_hidden_Matrix
+	}
+	return nil
+}
+
 func (x *Task) SetId(v int32) {
 	x.xxx_hidden_Id = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 34)
 }
 
 func (x *Task) SetRepositoryId(v int32) {
 	x.xxx_hidden_RepositoryId = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 34)
 }
 
 func (x *Task) SetJobName(v string) {
 	x.xxx_hidden_JobName = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 34)
 }
 
 func (x *Task) SetParsedJobConfiguration(v string) {
 	x.xxx_hidden_ParsedJobConfiguration = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 34)
 }
 
 func (x *Task) SetRevision(v string) {
 	x.xxx_hidden_Revision = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 34)
 }
 
 func (x *Task) SetBazelVersion(v string) {
 	x.xxx_hidden_BazelVersion = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 34)
 }
 
 func (x *Task) SetCommand(v string) {
 	x.xxx_hidden_Command = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 34)
 }
 
 func (x *Task) SetIsTrunk(v bool) {
 	x.xxx_hidden_IsTrunk = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 34)
 }
 
 func (x *Task) SetSuccess(v bool) {
 	x.xxx_hidden_Success = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 34)
 }
 
 func (x *Task) SetLogFile(v string) {
 	x.xxx_hidden_LogFile = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 34)
 }
 
 func (x *Task) SetTargets(v []string) {
@@ -666,42 +720,42 @@ func (x *Task) SetTargets(v []string) {
 
 func (x *Task) SetPlatform(v string) {
 	x.xxx_hidden_Platform = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 11, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 11, 34)
 }
 
 func (x *Task) SetVia(v string) {
 	x.xxx_hidden_Via = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 12, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 12, 34)
 }
 
 func (x *Task) SetConfigName(v string) {
 	x.xxx_hidden_ConfigName = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 13, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 13, 34)
 }
 
 func (x *Task) SetNode(v string) {
 	x.xxx_hidden_Node = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 14, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 14, 34)
 }
 
 func (x *Task) SetManifest(v string) {
 	x.xxx_hidden_Manifest = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 15, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 15, 34)
 }
 
 func (x *Task) SetContainer(v string) {
 	x.xxx_hidden_Container = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 16, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 16, 34)
 }
 
 func (x *Task) SetExecutedTestsCount(v int32) {
 	x.xxx_hidden_ExecutedTestsCount = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 17, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 17, 34)
 }
 
 func (x *Task) SetSucceededTestsCount(v int32) {
 	x.xxx_hidden_SucceededTestsCount = v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 18, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 18, 34)
 }
 
 func (x *Task) SetStartAt(v *timestamppb.Timestamp) {
@@ -722,28 +776,56 @@ func (x *Task) SetUpdatedAt(v *timestamppb.Timestamp) {
 
 func (x *Task) SetRepositoryUrl(v string) {
 	x.xxx_hidden_RepositoryUrl = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 23, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 23, 34)
 }
 
 func (x *Task) SetRevisionUrl(v string) {
 	x.xxx_hidden_RevisionUrl = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 24, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 24, 34)
 }
 
 func (x *Task) SetCpuLimit(v string) {
 	x.xxx_hidden_CpuLimit = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 25, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 25, 34)
 }
 
 func (x *Task) SetMemoryLimit(v string) {
 	x.xxx_hidden_MemoryLimit = &v
-	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 26, 28)
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 26, 34)
 }
 
 func (x *Task) SetTestReports(v []*TestReport) {
 	x.xxx_hidden_TestReports = &v
 }
 
+func (x *Task) SetSkipped(v bool) {
+	x.xxx_hidden_Skipped = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 28, 34)
+}
+
+func (x *Task) SetNeeds(v []string) {
+	x.xxx_hidden_Needs = v
+}
+
+func (x *Task) SetAttempt(v int32) {
+	x.xxx_hidden_Attempt = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 30, 34)
+}
+
+func (x *Task) SetParentTaskId(v int32) {
+	x.xxx_hidden_ParentTaskId = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 31, 34)
+}
+
+func (x *Task) SetCancelReason(v string) {
+	x.xxx_hidden_CancelReason = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[1]), 32, 34)
+}
+
+func (x *Task) SetMatrix(v map[string]string) {
+	x.xxx_hidden_Matrix = v
+}
+
 func (x *Task) HasId() bool {
 	if x == nil {
 		return false
@@ -926,6 +1008,34 @@ func (x *Task) HasMemoryLimit() bool {
 	return protoimpl.X.Present(&(x.XXX_presence[0]), 26)
 }
 
+func (x *Task) HasSkipped() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 28)
+}
+
+func (x *Task) HasAttempt() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 30)
+}
+
+func (x *Task) HasParentTaskId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 31)
+}
+
+func (x *Task) HasCancelReason() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[1]), 32)
+}
+
 func (x *Task) ClearId() {
 	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
 	x.xxx_hidden_Id = 0
@@ -1052,6 +1162,26 @@ func (x *Task) ClearMemoryLimit() {
 	x.xxx_hidden_MemoryLimit = nil
 }
 
+func (x *Task) ClearSkipped() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 28)
+	x.xxx_hidden_Skipped = false
+}
+
+func (x *Task) ClearAttempt() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 30)
+	x.xxx_hidden_Attempt = 0
+}
+
+func (x *Task) ClearParentTaskId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 31)
+	x.xxx_hidden_ParentTaskId = 0
+}
+
+func (x *Task) ClearCancelReason() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[1]), 32)
+	x.xxx_hidden_CancelReason = nil
+}
+
 type Task_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
@@ -1083,6 +1213,13 @@ type Task_builder struct {
 	CpuLimit               *string
 	MemoryLimit            *string
 	TestReports            []*TestReport
+	Skipped                *bool
+	Needs                  []string
+	Attempt                *int32
+	ParentTaskId           *int32
+	CancelReason           *string
+	// matrix is the values of the axes if the task is expanded by the matrix of the job.
+	Matrix map[string]string
 }
 
 func (b0 Task_builder) Build() *Task {
@@ -1090,76 +1227,76 @@ func (b0 Task_builder) Build() *Task {
 	b, x := &b0, m0
 	_, _ = b, x
 	if b.Id != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 34)
 		x.xxx_hidden_Id = *b.Id
 	}
 	if b.RepositoryId != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 34)
 		x.xxx_hidden_RepositoryId = *b.RepositoryId
 	}
 	if b.JobName != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 34)
 		x.xxx_hidden_JobName = b.JobName
 	}
 	if b.ParsedJobConfiguration != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 34)
 		x.xxx_hidden_ParsedJobConfiguration = b.ParsedJobConfiguration
 	}
 	if b.Revision != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 34)
 		x.xxx_hidden_Revision = b.Revision
 	}
 	if b.BazelVersion != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 34)
 		x.xxx_hidden_BazelVersion = b.BazelVersion
 	}
 	if b.Command != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 34)
 		x.xxx_hidden_Command = b.Command
 	}
 	if b.IsTrunk != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 34)
 		x.xxx_hidden_IsTrunk = *b.IsTrunk
 	}
 	if b.Success != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 34)
 		x.xxx_hidden_Success = *b.Success
 	}
 	if b.LogFile != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 34)
 		x.xxx_hidden_LogFile = b.LogFile
 	}
 	x.xxx_hidden_Targets = b.Targets
 	if b.Platform != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 11, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 11, 34)
 		x.xxx_hidden_Platform = b.Platform
 	}
 	if b.Via != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 12, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 12, 34)
 		x.xxx_hidden_Via = b.Via
 	}
 	if b.ConfigName != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 13, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 13, 34)
 		x.xxx_hidden_ConfigName = b.ConfigName
 	}
 	if b.Node != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 14, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 14, 34)
 		x.xxx_hidden_Node = b.Node
 	}
 	if b.Manifest != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 15, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 15, 34)
 		x.xxx_hidden_Manifest = b.Manifest
 	}
 	if b.Container != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 16, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 16, 34)
 		x.xxx_hidden_Container = b.Container
 	}
 	if b.ExecutedTestsCount != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 17, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 17, 34)
 		x.xxx_hidden_ExecutedTestsCount = *b.ExecutedTestsCount
 	}
 	if b.SucceededTestsCount != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 18, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 18, 34)
 		x.xxx_hidden_SucceededTestsCount = *b.SucceededTestsCount
 	}
 	x.xxx_hidden_StartAt = b.StartAt
@@ -1167,22 +1304,40 @@ func (b0 Task_builder) Build() *Task {
 	x.xxx_hidden_CreatedAt = b.CreatedAt
 	x.xxx_hidden_UpdatedAt = b.UpdatedAt
 	if b.RepositoryUrl != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 23, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 23, 34)
 		x.xxx_hidden_RepositoryUrl = b.RepositoryUrl
 	}
 	if b.RevisionUrl != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 24, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 24, 34)
 		x.xxx_hidden_RevisionUrl = b.RevisionUrl
 	}
 	if b.CpuLimit != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 25, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 25, 34)
 		x.xxx_hidden_CpuLimit = b.CpuLimit
 	}
 	if b.MemoryLimit != nil {
-		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 26, 28)
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 26, 34)
 		x.xxx_hidden_MemoryLimit = b.MemoryLimit
 	}
 	x.xxx_hidden_TestReports = &b.TestReports
+	if b.Skipped != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 28, 34)
+		x.xxx_hidden_Skipped = *b.Skipped
+	}
+	x.xxx_hidden_Needs = b.Needs
+	if b.Attempt != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 30, 34)
+		x.xxx_hidden_Attempt = *b.Attempt
+	}
+	if b.ParentTaskId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 31, 34)
+		x.xxx_hidden_ParentTaskId = *b.ParentTaskId
+	}
+	if b.CancelReason != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[1]), 32, 34)
+		x.xxx_hidden_CancelReason = b.CancelReason
+	}
+	x.xxx_hidden_Matrix = b.Matrix
 	return m0
 }
 
@@ -1434,6 +1589,11 @@ func (b0 TestReport_builder) Build() *TestReport {
 	return m0
 }
 
+// GithubEvent surfaces a row from the `github_event` table for the dashboard.
+// The `state` field carries the proto enum name ("PENDING", "SUCCEEDED", …)
+// so the frontend does not have to know the numeric mapping. `status` is the
+// reconciler's progress JSON serialized as a string — the dashboard renders
+// it raw, since the document's shape depends on event_type.
 type GithubEvent struct {
 	state                    protoimpl.MessageState `protogen:"opaque.v1"`
 	xxx_hidden_Id            int32                  `protobuf:"varint,1,opt,name=id"`
@@ -1765,15 +1925,18 @@ func (x *GithubEvent) ClearRepositoryUrl() {
 type GithubEvent_builder struct {
 	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
 
-	Id            *int32
-	DeliveryId    *string
-	EventType     *string
-	Action        *string
-	State         *string
-	Status        *string
-	LastError     *string
-	CreatedAt     *timestamppb.Timestamp
-	UpdatedAt     *timestamppb.Timestamp
+	Id         *int32
+	DeliveryId *string
+	EventType  *string
+	Action     *string
+	State      *string
+	Status     *string
+	LastError  *string
+	CreatedAt  *timestamppb.Timestamp
+	UpdatedAt  *timestamppb.Timestamp
+	// Repository is the full_name (e.g. "owner/repo") parsed out of the
+	// webhook payload at read time. Empty when the payload has no
+	// repository field or is malformed.
 	Repository    *string
 	RepositoryUrl *string
 }
@@ -2222,95 +2385,1473 @@ func (b0 ExternalReleaseTrigger_builder) Build() *ExternalReleaseTrigger {
 	return m0
 }
 
-var File_proto_build_model_msg_proto protoreflect.FileDescriptor
+// TargetResult is the outcome of a target reported by the build event stream.
+// failure_category is "<category>.<code>" of Bazel's FailureDetail (e.g. "spawn.NON_ZERO_EXIT").
+type TargetResult struct {
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Label           *string                `protobuf:"bytes,1,opt,name=label"`
+	xxx_hidden_Success         bool                   `protobuf:"varint,2,opt,name=success"`
+	xxx_hidden_FailureCategory *string                `protobuf:"bytes,3,opt,name=failure_category,json=failureCategory"`
+	xxx_hidden_FailureMessage  *string                `protobuf:"bytes,4,opt,name=failure_message,json=failureMessage"`
+	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
+	XXX_presence               [1]uint32
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
 
-const file_proto_build_model_msg_proto_rawDesc = "" +
-	"\n" +
-	"\x1bproto/build/model/msg.proto\x12\x10mono.build.model\x1a!google/protobuf/go_features.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9e\x01\n" +
-	"\n" +
-	"Repository\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
-	"\x03url\x18\x03 \x01(\tR\x03url\x12\x1b\n" +
-	"\tclone_url\x18\x04 \x01(\tR\bcloneUrl\x12\x18\n" +
-	"\aprivate\x18\x05 \x01(\bR\aprivate\x12#\n" +
-	"\rhead_revision\x18\a \x01(\tR\fheadRevision\"\x8d\b\n" +
-	"\x04Task\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12#\n" +
-	"\rrepository_id\x18\x02 \x01(\x05R\frepositoryId\x12\x19\n" +
-	"\bjob_name\x18\x03 \x01(\tR\ajobName\x128\n" +
-	"\x18parsed_job_configuration\x18\x04 \x01(\tR\x16parsedJobConfiguration\x12\x1a\n" +
-	"\brevision\x18\x05 \x01(\tR\brevision\x12#\n" +
-	"\rbazel_version\x18\x06 \x01(\tR\fbazelVersion\x12\x18\n" +
-	"\acommand\x18\a \x01(\tR\acommand\x12\x19\n" +
-	"\bis_trunk\x18\b \x01(\bR\aisTrunk\x12\x18\n" +
-	"\asuccess\x18\t \x01(\bR\asuccess\x12\x19\n" +
-	"\blog_file\x18\n" +
-	" \x01(\tR\alogFile\x12\x18\n" +
-	"\atargets\x18\v \x03(\tR\atargets\x12\x1a\n" +
-	"\bplatform\x18\f \x01(\tR\bplatform\x12\x10\n" +
-	"\x03via\x18\r \x01(\tR\x03via\x12\x1f\n" +
-	"\vconfig_name\x18\x0e \x01(\tR\n" +
-	"configName\x12\x12\n" +
-	"\x04node\x18\x0f \x01(\tR\x04node\x12\x1a\n" +
-	"\bmanifest\x18\x10 \x01(\tR\bmanifest\x12\x1c\n" +
-	"\tcontainer\x18\x11 \x01(\tR\tcontainer\x120\n" +
-	"\x14executed_tests_count\x18\x12 \x01(\x05R\x12executedTestsCount\x122\n" +
-	"\x15succeeded_tests_count\x18\x13 \x01(\x05R\x13succeededTestsCount\x125\n" +
-	"\bstart_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x12;\n" +
-	"\vfinished_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"finishedAt\x129\n" +
-	"\n" +
-	"created_at\x18\x16 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\x17 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12%\n" +
-	"\x0erepository_url\x18\x18 \x01(\tR\rrepositoryUrl\x12!\n" +
-	"\frevision_url\x18\x19 \x01(\tR\vrevisionUrl\x12\x1b\n" +
-	"\tcpu_limit\x18\x1a \x01(\tR\bcpuLimit\x12!\n" +
-	"\fmemory_limit\x18\x1b \x01(\tR\vmemoryLimit\x12?\n" +
-	"\ftest_reports\x18\x1c \x03(\v2\x1c.mono.build.model.TestReportR\vtestReports\">\n" +
-	"\x03Job\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
-	"\rrepository_id\x18\x02 \x01(\x05R\frepositoryId\"t\n" +
-	"\n" +
-	"TestReport\x12\x14\n" +
-	"\x05label\x18\x01 \x01(\tR\x05label\x124\n" +
-	"\x06status\x18\x02 \x01(\x0e2\x1c.mono.build.model.TestStatusR\x06status\x12\x1a\n" +
-	"\bduration\x18\x03 \x01(\x03R\bduration\"\xff\x02\n" +
-	"\vGithubEvent\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1f\n" +
-	"\vdelivery_id\x18\x02 \x01(\tR\n" +
-	"deliveryId\x12\x1d\n" +
-	"\n" +
-	"event_type\x18\x03 \x01(\tR\teventType\x12\x16\n" +
-	"\x06action\x18\x04 \x01(\tR\x06action\x12\x14\n" +
-	"\x05state\x18\x05 \x01(\tR\x05state\x12\x16\n" +
-	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1d\n" +
-	"\n" +
-	"last_error\x18\a \x01(\tR\tlastError\x129\n" +
-	"\n" +
-	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1e\n" +
-	"\n" +
-	"repository\x18\n" +
-	" \x01(\tR\n" +
-	"repository\x12%\n" +
-	"\x0erepository_url\x18\v \x01(\tR\rrepositoryUrl\"\x89\x03\n" +
-	"\x16ExternalReleaseTrigger\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12#\n" +
-	"\rrepository_id\x18\x02 \x01(\x05R\frepositoryId\x12'\n" +
-	"\x0frepository_name\x18\x03 \x01(\tR\x0erepositoryName\x12%\n" +
-	"\x0erepository_url\x18\x04 \x01(\tR\rrepositoryUrl\x12\x19\n" +
-	"\bjob_name\x18\x05 \x01(\tR\ajobName\x12\x1a\n" +
-	"\bprovider\x18\x06 \x01(\tR\bprovider\x12#\n" +
-	"\rexternal_repo\x18\a \x01(\tR\fexternalRepo\x12*\n" +
-	"\x11external_repo_url\x18\b \x01(\tR\x0fexternalRepoUrl\x12\x12\n" +
-	"\x04kind\x18\t \x01(\tR\x04kind\x12\x1f\n" +
-	"\vtag_pattern\x18\n" +
-	" \x01(\tR\n" +
-	"tagPattern\x12-\n" +
-	"\x12include_prerelease\x18\v \x01(\bR\x11includePrerelease*S\n" +
+func (x *TargetResult) Reset() {
+	*x = TargetResult{}
+	mi := &file_proto_build_model_msg_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TargetResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TargetResult) ProtoMessage() {}
+
+func (x *TargetResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_model_msg_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *TargetResult) GetLabel() string {
+	if x != nil {
+		if x.xxx_hidden_Label != nil {
+			return *x.xxx_hidden_Label
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TargetResult) GetSuccess() bool {
+	if x != nil {
+		return x.xxx_hidden_Success
+	}
+	return false
+}
+
+func (x *TargetResult) GetFailureCategory() string {
+	if x != nil {
+		if x.xxx_hidden_FailureCategory != nil {
+			return *x.xxx_hidden_FailureCategory
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TargetResult) GetFailureMessage() string {
+	if x != nil {
+		if x.xxx_hidden_FailureMessage != nil {
+			return *x.xxx_hidden_FailureMessage
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *TargetResult) SetLabel(v string) {
+	x.xxx_hidden_Label = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 4)
+}
+
+func (x *TargetResult) SetSuccess(v bool) {
+	x.xxx_hidden_Success = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 4)
+}
+
+func (x *TargetResult) SetFailureCategory(v string) {
+	x.xxx_hidden_FailureCategory = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 4)
+}
+
+func (x *TargetResult) SetFailureMessage(v string) {
+	x.xxx_hidden_FailureMessage = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 4)
+}
+
+func (x *TargetResult) HasLabel() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *TargetResult) HasSuccess() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *TargetResult) HasFailureCategory() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *TargetResult) HasFailureMessage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *TargetResult) ClearLabel() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Label = nil
+}
+
+func (x *TargetResult) ClearSuccess() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Success = false
+}
+
+func (x *TargetResult) ClearFailureCategory() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_FailureCategory = nil
+}
+
+func (x *TargetResult) ClearFailureMessage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_FailureMessage = nil
+}
+
+type TargetResult_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Label           *string
+	Success         *bool
+	FailureCategory *string
+	FailureMessage  *string
+}
+
+func (b0 TargetResult_builder) Build() *TargetResult {
+	m0 := &TargetResult{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Label != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 4)
+		x.xxx_hidden_Label = b.Label
+	}
+	if b.Success != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 4)
+		x.xxx_hidden_Success = *b.Success
+	}
+	if b.FailureCategory != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 4)
+		x.xxx_hidden_FailureCategory = b.FailureCategory
+	}
+	if b.FailureMessage != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 4)
+		x.xxx_hidden_FailureMessage = b.FailureMessage
+	}
+	return m0
+}
+
+type ActionFailure struct {
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_Label           *string                `protobuf:"bytes,1,opt,name=label"`
+	xxx_hidden_Mnemonic        *string                `protobuf:"bytes,2,opt,name=mnemonic"`
+	xxx_hidden_ExitCode        int32                  `protobuf:"varint,3,opt,name=exit_code,json=exitCode"`
+	xxx_hidden_FailureCategory *string                `protobuf:"bytes,4,opt,name=failure_category,json=failureCategory"`
+	xxx_hidden_FailureMessage  *string                `protobuf:"bytes,5,opt,name=failure_message,json=failureMessage"`
+	xxx_hidden_PrimaryOutput   *string                `protobuf:"bytes,6,opt,name=primary_output,json=primaryOutput"`
+	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
+	XXX_presence               [1]uint32
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *ActionFailure) Reset() {
+	*x = ActionFailure{}
+	mi := &file_proto_build_model_msg_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActionFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionFailure) ProtoMessage() {}
+
+func (x *ActionFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_model_msg_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *ActionFailure) GetLabel() string {
+	if x != nil {
+		if x.xxx_hidden_Label != nil {
+			return *x.xxx_hidden_Label
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ActionFailure) GetMnemonic() string {
+	if x != nil {
+		if x.xxx_hidden_Mnemonic != nil {
+			return *x.xxx_hidden_Mnemonic
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ActionFailure) GetExitCode() int32 {
+	if x != nil {
+		return x.xxx_hidden_ExitCode
+	}
+	return 0
+}
+
+func (x *ActionFailure) GetFailureCategory() string {
+	if x != nil {
+		if x.xxx_hidden_FailureCategory != nil {
+			return *x.xxx_hidden_FailureCategory
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ActionFailure) GetFailureMessage() string {
+	if x != nil {
+		if x.xxx_hidden_FailureMessage != nil {
+			return *x.xxx_hidden_FailureMessage
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ActionFailure) GetPrimaryOutput() string {
+	if x != nil {
+		if x.xxx_hidden_PrimaryOutput != nil {
+			return *x.xxx_hidden_PrimaryOutput
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *ActionFailure) SetLabel(v string) {
+	x.xxx_hidden_Label = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 6)
+}
+
+func (x *ActionFailure) SetMnemonic(v string) {
+	x.xxx_hidden_Mnemonic = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 6)
+}
+
+func (x *ActionFailure) SetExitCode(v int32) {
+	x.xxx_hidden_ExitCode = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 6)
+}
+
+func (x *ActionFailure) SetFailureCategory(v string) {
+	x.xxx_hidden_FailureCategory = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 6)
+}
+
+func (x *ActionFailure) SetFailureMessage(v string) {
+	x.xxx_hidden_FailureMessage = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 6)
+}
+
+func (x *ActionFailure) SetPrimaryOutput(v string) {
+	x.xxx_hidden_PrimaryOutput = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 6)
+}
+
+func (x *ActionFailure) HasLabel() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *ActionFailure) HasMnemonic() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *ActionFailure) HasExitCode() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *ActionFailure) HasFailureCategory() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *ActionFailure) HasFailureMessage() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *ActionFailure) HasPrimaryOutput() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *ActionFailure) ClearLabel() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_Label = nil
+}
+
+func (x *ActionFailure) ClearMnemonic() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Mnemonic = nil
+}
+
+func (x *ActionFailure) ClearExitCode() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_ExitCode = 0
+}
+
+func (x *ActionFailure) ClearFailureCategory() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_FailureCategory = nil
+}
+
+func (x *ActionFailure) ClearFailureMessage() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_FailureMessage = nil
+}
+
+func (x *ActionFailure) ClearPrimaryOutput() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_PrimaryOutput = nil
+}
+
+type ActionFailure_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	Label           *string
+	Mnemonic        *string
+	ExitCode        *int32
+	FailureCategory *string
+	FailureMessage  *string
+	PrimaryOutput   *string
+}
+
+func (b0 ActionFailure_builder) Build() *ActionFailure {
+	m0 := &ActionFailure{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.Label != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 6)
+		x.xxx_hidden_Label = b.Label
+	}
+	if b.Mnemonic != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 6)
+		x.xxx_hidden_Mnemonic = b.Mnemonic
+	}
+	if b.ExitCode != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 6)
+		x.xxx_hidden_ExitCode = *b.ExitCode
+	}
+	if b.FailureCategory != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 6)
+		x.xxx_hidden_FailureCategory = b.FailureCategory
+	}
+	if b.FailureMessage != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 6)
+		x.xxx_hidden_FailureMessage = b.FailureMessage
+	}
+	if b.PrimaryOutput != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 6)
+		x.xxx_hidden_PrimaryOutput = b.PrimaryOutput
+	}
+	return m0
+}
+
+// BuildMetrics is the metrics of the build reported by the build event stream.
+// The durations are in milliseconds.
+type BuildMetrics struct {
+	state                           protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_ExitCodeName         *string                `protobuf:"bytes,1,opt,name=exit_code_name,json=exitCodeName"`
+	xxx_hidden_ActionsCreated       int64                  `protobuf:"varint,2,opt,name=actions_created,json=actionsCreated"`
+	xxx_hidden_ActionsExecuted      int64                  `protobuf:"varint,3,opt,name=actions_executed,json=actionsExecuted"`
+	xxx_hidden_RemoteCacheHits      int64                  `protobuf:"varint,4,opt,name=remote_cache_hits,json=remoteCacheHits"`
+	xxx_hidden_ActionCacheHits      int32                  `protobuf:"varint,5,opt,name=action_cache_hits,json=actionCacheHits"`
+	xxx_hidden_ActionCacheMisses    int32                  `protobuf:"varint,6,opt,name=action_cache_misses,json=actionCacheMisses"`
+	xxx_hidden_TargetsConfigured    int64                  `protobuf:"varint,7,opt,name=targets_configured,json=targetsConfigured"`
+	xxx_hidden_WallTimeMs           int64                  `protobuf:"varint,8,opt,name=wall_time_ms,json=wallTimeMs"`
+	xxx_hidden_CpuTimeMs            int64                  `protobuf:"varint,9,opt,name=cpu_time_ms,json=cpuTimeMs"`
+	xxx_hidden_AnalysisPhaseTimeMs  int64                  `protobuf:"varint,10,opt,name=analysis_phase_time_ms,json=analysisPhaseTimeMs"`
+	xxx_hidden_ExecutionPhaseTimeMs int64                  `protobuf:"varint,11,opt,name=execution_phase_time_ms,json=executionPhaseTimeMs"`
+	xxx_hidden_RemoteCacheHitRatio  float64                `protobuf:"fixed64,12,opt,name=remote_cache_hit_ratio,json=remoteCacheHitRatio"`
+	xxx_hidden_ActionCacheHitRatio  float64                `protobuf:"fixed64,13,opt,name=action_cache_hit_ratio,json=actionCacheHitRatio"`
+	XXX_raceDetectHookData          protoimpl.RaceDetectHookData
+	XXX_presence                    [1]uint32
+	unknownFields                   protoimpl.UnknownFields
+	sizeCache                       protoimpl.SizeCache
+}
+
+func (x *BuildMetrics) Reset() {
+	*x = BuildMetrics{}
+	mi := &file_proto_build_model_msg_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildMetrics) ProtoMessage() {}
+
+func (x *BuildMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_model_msg_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *BuildMetrics) GetExitCodeName() string {
+	if x != nil {
+		if x.xxx_hidden_ExitCodeName != nil {
+			return *x.xxx_hidden_ExitCodeName
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *BuildMetrics) GetActionsCreated() int64 {
+	if x != nil {
+		return x.xxx_hidden_ActionsCreated
+	}
+	return 0
+}
+
+func (x *BuildMetrics) GetActionsExecuted() int64 {
+	if x != nil {
+		return x.xxx_hidden_ActionsExecuted
+	}
+	return 0
+}
+
+func (x *BuildMetrics) GetRemoteCacheHits() int64 {
+	if x != nil {
+		return x.xxx_hidden_RemoteCacheHits
+	}
+	return 0
+}
+
+func (x *BuildMetrics) GetActionCacheHits() int32 {
+	if x != nil {
+		return x.xxx_hidden_ActionCacheHits
+	}
+	return 0
+}
+
+func (x *BuildMetrics) GetActionCacheMisses() int32 {
+	if x != nil {
+		return x.xxx_hidden_ActionCacheMisses
+	}
+	return 0
+}
+
+func (x *BuildMetrics) GetTargetsConfigured() int64 {
+	if x != nil {
+		return x.xxx_hidden_TargetsConfigured
+	}
+	return 0
+}
+
+func (x *BuildMetrics) GetWallTimeMs() int64 {
+	if x != nil {
+		return x.xxx_hidden_WallTimeMs
+	}
+	return 0
+}
+
+func (x *BuildMetrics) GetCpuTimeMs() int64 {
+	if x != nil {
+		return x.xxx_hidden_CpuTimeMs
+	}
+	return 0
+}
+
+func (x *BuildMetrics) GetAnalysisPhaseTimeMs() int64 {
+	if x != nil {
+		return x.xxx_hidden_AnalysisPhaseTimeMs
+	}
+	return 0
+}
+
+func (x *BuildMetrics) GetExecutionPhaseTimeMs() int64 {
+	if x != nil {
+		return x.xxx_hidden_ExecutionPhaseTimeMs
+	}
+	return 0
+}
+
+func (x *BuildMetrics) GetRemoteCacheHitRatio() float64 {
+	if x != nil {
+		return x.xxx_hidden_RemoteCacheHitRatio
+	}
+	return 0
+}
+
+func (x *BuildMetrics) GetActionCacheHitRatio() float64 {
+	if x != nil {
+		return x.xxx_hidden_ActionCacheHitRatio
+	}
+	return 0
+}
+
+func (x *BuildMetrics) SetExitCodeName(v string) {
+	x.xxx_hidden_ExitCodeName = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 13)
+}
+
+func (x *BuildMetrics) SetActionsCreated(v int64) {
+	x.xxx_hidden_ActionsCreated = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 13)
+}
+
+func (x *BuildMetrics) SetActionsExecuted(v int64) {
+	x.xxx_hidden_ActionsExecuted = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 13)
+}
+
+func (x *BuildMetrics) SetRemoteCacheHits(v int64) {
+	x.xxx_hidden_RemoteCacheHits = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 13)
+}
+
+func (x *BuildMetrics) SetActionCacheHits(v int32) {
+	x.xxx_hidden_ActionCacheHits = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 13)
+}
+
+func (x *BuildMetrics) SetActionCacheMisses(v int32) {
+	x.xxx_hidden_ActionCacheMisses = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 13)
+}
+
+func (x *BuildMetrics) SetTargetsConfigured(v int64) {
+	x.xxx_hidden_TargetsConfigured = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 13)
+}
+
+func (x *BuildMetrics) SetWallTimeMs(v int64) {
+	x.xxx_hidden_WallTimeMs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 7, 13)
+}
+
+func (x *BuildMetrics) SetCpuTimeMs(v int64) {
+	x.xxx_hidden_CpuTimeMs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 8, 13)
+}
+
+func (x *BuildMetrics) SetAnalysisPhaseTimeMs(v int64) {
+	x.xxx_hidden_AnalysisPhaseTimeMs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 9, 13)
+}
+
+func (x *BuildMetrics) SetExecutionPhaseTimeMs(v int64) {
+	x.xxx_hidden_ExecutionPhaseTimeMs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 10, 13)
+}
+
+func (x *BuildMetrics) SetRemoteCacheHitRatio(v float64) {
+	x.xxx_hidden_RemoteCacheHitRatio = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 11, 13)
+}
+
+func (x *BuildMetrics) SetActionCacheHitRatio(v float64) {
+	x.xxx_hidden_ActionCacheHitRatio = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 12, 13)
+}
+
+func (x *BuildMetrics) HasExitCodeName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *BuildMetrics) HasActionsCreated() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *BuildMetrics) HasActionsExecuted() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *BuildMetrics) HasRemoteCacheHits() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *BuildMetrics) HasActionCacheHits() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *BuildMetrics) HasActionCacheMisses() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *BuildMetrics) HasTargetsConfigured() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *BuildMetrics) HasWallTimeMs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 7)
+}
+
+func (x *BuildMetrics) HasCpuTimeMs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 8)
+}
+
+func (x *BuildMetrics) HasAnalysisPhaseTimeMs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 9)
+}
+
+func (x *BuildMetrics) HasExecutionPhaseTimeMs() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 10)
+}
+
+func (x *BuildMetrics) HasRemoteCacheHitRatio() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 11)
+}
+
+func (x *BuildMetrics) HasActionCacheHitRatio() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 12)
+}
+
+func (x *BuildMetrics) ClearExitCodeName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_ExitCodeName = nil
+}
+
+func (x *BuildMetrics) ClearActionsCreated() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_ActionsCreated = 0
+}
+
+func (x *BuildMetrics) ClearActionsExecuted() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_ActionsExecuted = 0
+}
+
+func (x *BuildMetrics) ClearRemoteCacheHits() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_RemoteCacheHits = 0
+}
+
+func (x *BuildMetrics) ClearActionCacheHits() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_ActionCacheHits = 0
+}
+
+func (x *BuildMetrics) ClearActionCacheMisses() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_ActionCacheMisses = 0
+}
+
+func (x *BuildMetrics) ClearTargetsConfigured() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_TargetsConfigured = 0
+}
+
+func (x *BuildMetrics) ClearWallTimeMs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 7)
+	x.xxx_hidden_WallTimeMs = 0
+}
+
+func (x *BuildMetrics) ClearCpuTimeMs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 8)
+	x.xxx_hidden_CpuTimeMs = 0
+}
+
+func (x *BuildMetrics) ClearAnalysisPhaseTimeMs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 9)
+	x.xxx_hidden_AnalysisPhaseTimeMs = 0
+}
+
+func (x *BuildMetrics) ClearExecutionPhaseTimeMs() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 10)
+	x.xxx_hidden_ExecutionPhaseTimeMs = 0
+}
+
+func (x *BuildMetrics) ClearRemoteCacheHitRatio() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 11)
+	x.xxx_hidden_RemoteCacheHitRatio = 0
+}
+
+func (x *BuildMetrics) ClearActionCacheHitRatio() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 12)
+	x.xxx_hidden_ActionCacheHitRatio = 0
+}
+
+type BuildMetrics_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	ExitCodeName         *string
+	ActionsCreated       *int64
+	ActionsExecuted      *int64
+	RemoteCacheHits      *int64
+	ActionCacheHits      *int32
+	ActionCacheMisses    *int32
+	TargetsConfigured    *int64
+	WallTimeMs           *int64
+	CpuTimeMs            *int64
+	AnalysisPhaseTimeMs  *int64
+	ExecutionPhaseTimeMs *int64
+	// remote_cache_hit_ratio is the ratio of the executed actions which are served by the remote cache.
+	RemoteCacheHitRatio *float64
+	// action_cache_hit_ratio is the hit ratio of the local action cache.
+	ActionCacheHitRatio *float64
+}
+
+func (b0 BuildMetrics_builder) Build() *BuildMetrics {
+	m0 := &BuildMetrics{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.ExitCodeName != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 13)
+		x.xxx_hidden_ExitCodeName = b.ExitCodeName
+	}
+	if b.ActionsCreated != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 13)
+		x.xxx_hidden_ActionsCreated = *b.ActionsCreated
+	}
+	if b.ActionsExecuted != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 13)
+		x.xxx_hidden_ActionsExecuted = *b.ActionsExecuted
+	}
+	if b.RemoteCacheHits != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 13)
+		x.xxx_hidden_RemoteCacheHits = *b.RemoteCacheHits
+	}
+	if b.ActionCacheHits != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 13)
+		x.xxx_hidden_ActionCacheHits = *b.ActionCacheHits
+	}
+	if b.ActionCacheMisses != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 13)
+		x.xxx_hidden_ActionCacheMisses = *b.ActionCacheMisses
+	}
+	if b.TargetsConfigured != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 13)
+		x.xxx_hidden_TargetsConfigured = *b.TargetsConfigured
+	}
+	if b.WallTimeMs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 7, 13)
+		x.xxx_hidden_WallTimeMs = *b.WallTimeMs
+	}
+	if b.CpuTimeMs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 8, 13)
+		x.xxx_hidden_CpuTimeMs = *b.CpuTimeMs
+	}
+	if b.AnalysisPhaseTimeMs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 9, 13)
+		x.xxx_hidden_AnalysisPhaseTimeMs = *b.AnalysisPhaseTimeMs
+	}
+	if b.ExecutionPhaseTimeMs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 10, 13)
+		x.xxx_hidden_ExecutionPhaseTimeMs = *b.ExecutionPhaseTimeMs
+	}
+	if b.RemoteCacheHitRatio != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 11, 13)
+		x.xxx_hidden_RemoteCacheHitRatio = *b.RemoteCacheHitRatio
+	}
+	if b.ActionCacheHitRatio != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 12, 13)
+		x.xxx_hidden_ActionCacheHitRatio = *b.ActionCacheHitRatio
+	}
+	return m0
+}
+
+// FlakyTest is the flakiness of a test computed from the test reports of the repository.
+// The score is the ratio of the flaky revisions to the revisions at which the test ran.
+type FlakyTest struct {
+	state                      protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_RepositoryId    int32                  `protobuf:"varint,1,opt,name=repository_id,json=repositoryId"`
+	xxx_hidden_Label           *string                `protobuf:"bytes,2,opt,name=label"`
+	xxx_hidden_Runs            int32                  `protobuf:"varint,3,opt,name=runs"`
+	xxx_hidden_FlakyRuns       int32                  `protobuf:"varint,4,opt,name=flaky_runs,json=flakyRuns"`
+	xxx_hidden_Score           float64                `protobuf:"fixed64,5,opt,name=score"`
+	xxx_hidden_Quarantined     bool                   `protobuf:"varint,6,opt,name=quarantined"`
+	xxx_hidden_LastFlakyTaskId int32                  `protobuf:"varint,7,opt,name=last_flaky_task_id,json=lastFlakyTaskId"`
+	XXX_raceDetectHookData     protoimpl.RaceDetectHookData
+	XXX_presence               [1]uint32
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *FlakyTest) Reset() {
+	*x = FlakyTest{}
+	mi := &file_proto_build_model_msg_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlakyTest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlakyTest) ProtoMessage() {}
+
+func (x *FlakyTest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_model_msg_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *FlakyTest) GetRepositoryId() int32 {
+	if x != nil {
+		return x.xxx_hidden_RepositoryId
+	}
+	return 0
+}
+
+func (x *FlakyTest) GetLabel() string {
+	if x != nil {
+		if x.xxx_hidden_Label != nil {
+			return *x.xxx_hidden_Label
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *FlakyTest) GetRuns() int32 {
+	if x != nil {
+		return x.xxx_hidden_Runs
+	}
+	return 0
+}
+
+func (x *FlakyTest) GetFlakyRuns() int32 {
+	if x != nil {
+		return x.xxx_hidden_FlakyRuns
+	}
+	return 0
+}
+
+func (x *FlakyTest) GetScore() float64 {
+	if x != nil {
+		return x.xxx_hidden_Score
+	}
+	return 0
+}
+
+func (x *FlakyTest) GetQuarantined() bool {
+	if x != nil {
+		return x.xxx_hidden_Quarantined
+	}
+	return false
+}
+
+func (x *FlakyTest) GetLastFlakyTaskId() int32 {
+	if x != nil {
+		return x.xxx_hidden_LastFlakyTaskId
+	}
+	return 0
+}
+
+func (x *FlakyTest) SetRepositoryId(v int32) {
+	x.xxx_hidden_RepositoryId = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 7)
+}
+
+func (x *FlakyTest) SetLabel(v string) {
+	x.xxx_hidden_Label = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 7)
+}
+
+func (x *FlakyTest) SetRuns(v int32) {
+	x.xxx_hidden_Runs = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 7)
+}
+
+func (x *FlakyTest) SetFlakyRuns(v int32) {
+	x.xxx_hidden_FlakyRuns = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 7)
+}
+
+func (x *FlakyTest) SetScore(v float64) {
+	x.xxx_hidden_Score = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 7)
+}
+
+func (x *FlakyTest) SetQuarantined(v bool) {
+	x.xxx_hidden_Quarantined = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 5, 7)
+}
+
+func (x *FlakyTest) SetLastFlakyTaskId(v int32) {
+	x.xxx_hidden_LastFlakyTaskId = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 6, 7)
+}
+
+func (x *FlakyTest) HasRepositoryId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *FlakyTest) HasLabel() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *FlakyTest) HasRuns() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *FlakyTest) HasFlakyRuns() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *FlakyTest) HasScore() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *FlakyTest) HasQuarantined() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 5)
+}
+
+func (x *FlakyTest) HasLastFlakyTaskId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 6)
+}
+
+func (x *FlakyTest) ClearRepositoryId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_RepositoryId = 0
+}
+
+func (x *FlakyTest) ClearLabel() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Label = nil
+}
+
+func (x *FlakyTest) ClearRuns() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Runs = 0
+}
+
+func (x *FlakyTest) ClearFlakyRuns() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_FlakyRuns = 0
+}
+
+func (x *FlakyTest) ClearScore() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Score = 0
+}
+
+func (x *FlakyTest) ClearQuarantined() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 5)
+	x.xxx_hidden_Quarantined = false
+}
+
+func (x *FlakyTest) ClearLastFlakyTaskId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 6)
+	x.xxx_hidden_LastFlakyTaskId = 0
+}
+
+type FlakyTest_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	RepositoryId    *int32
+	Label           *string
+	Runs            *int32
+	FlakyRuns       *int32
+	Score           *float64
+	Quarantined     *bool
+	LastFlakyTaskId *int32
+}
+
+func (b0 FlakyTest_builder) Build() *FlakyTest {
+	m0 := &FlakyTest{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.RepositoryId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 7)
+		x.xxx_hidden_RepositoryId = *b.RepositoryId
+	}
+	if b.Label != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 7)
+		x.xxx_hidden_Label = b.Label
+	}
+	if b.Runs != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 7)
+		x.xxx_hidden_Runs = *b.Runs
+	}
+	if b.FlakyRuns != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 7)
+		x.xxx_hidden_FlakyRuns = *b.FlakyRuns
+	}
+	if b.Score != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 7)
+		x.xxx_hidden_Score = *b.Score
+	}
+	if b.Quarantined != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 5, 7)
+		x.xxx_hidden_Quarantined = *b.Quarantined
+	}
+	if b.LastFlakyTaskId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 6, 7)
+		x.xxx_hidden_LastFlakyTaskId = *b.LastFlakyTaskId
+	}
+	return m0
+}
+
+// Artifact is an output of the task which is published to the object storage.
+type Artifact struct {
+	state                  protoimpl.MessageState `protogen:"opaque.v1"`
+	xxx_hidden_TaskId      int32                  `protobuf:"varint,1,opt,name=task_id,json=taskId"`
+	xxx_hidden_Label       *string                `protobuf:"bytes,2,opt,name=label"`
+	xxx_hidden_Path        *string                `protobuf:"bytes,3,opt,name=path"`
+	xxx_hidden_ObjectName  *string                `protobuf:"bytes,4,opt,name=object_name,json=objectName"`
+	xxx_hidden_Size        int64                  `protobuf:"varint,5,opt,name=size"`
+	XXX_raceDetectHookData protoimpl.RaceDetectHookData
+	XXX_presence           [1]uint32
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Artifact) Reset() {
+	*x = Artifact{}
+	mi := &file_proto_build_model_msg_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Artifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Artifact) ProtoMessage() {}
+
+func (x *Artifact) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_build_model_msg_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+func (x *Artifact) GetTaskId() int32 {
+	if x != nil {
+		return x.xxx_hidden_TaskId
+	}
+	return 0
+}
+
+func (x *Artifact) GetLabel() string {
+	if x != nil {
+		if x.xxx_hidden_Label != nil {
+			return *x.xxx_hidden_Label
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Artifact) GetPath() string {
+	if x != nil {
+		if x.xxx_hidden_Path != nil {
+			return *x.xxx_hidden_Path
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Artifact) GetObjectName() string {
+	if x != nil {
+		if x.xxx_hidden_ObjectName != nil {
+			return *x.xxx_hidden_ObjectName
+		}
+		return ""
+	}
+	return ""
+}
+
+func (x *Artifact) GetSize() int64 {
+	if x != nil {
+		return x.xxx_hidden_Size
+	}
+	return 0
+}
+
+func (x *Artifact) SetTaskId(v int32) {
+	x.xxx_hidden_TaskId = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 0, 5)
+}
+
+func (x *Artifact) SetLabel(v string) {
+	x.xxx_hidden_Label = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 1, 5)
+}
+
+func (x *Artifact) SetPath(v string) {
+	x.xxx_hidden_Path = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 2, 5)
+}
+
+func (x *Artifact) SetObjectName(v string) {
+	x.xxx_hidden_ObjectName = &v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 3, 5)
+}
+
+func (x *Artifact) SetSize(v int64) {
+	x.xxx_hidden_Size = v
+	protoimpl.X.SetPresent(&(x.XXX_presence[0]), 4, 5)
+}
+
+func (x *Artifact) HasTaskId() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 0)
+}
+
+func (x *Artifact) HasLabel() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 1)
+}
+
+func (x *Artifact) HasPath() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 2)
+}
+
+func (x *Artifact) HasObjectName() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 3)
+}
+
+func (x *Artifact) HasSize() bool {
+	if x == nil {
+		return false
+	}
+	return protoimpl.X.Present(&(x.XXX_presence[0]), 4)
+}
+
+func (x *Artifact) ClearTaskId() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 0)
+	x.xxx_hidden_TaskId = 0
+}
+
+func (x *Artifact) ClearLabel() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 1)
+	x.xxx_hidden_Label = nil
+}
+
+func (x *Artifact) ClearPath() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 2)
+	x.xxx_hidden_Path = nil
+}
+
+func (x *Artifact) ClearObjectName() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 3)
+	x.xxx_hidden_ObjectName = nil
+}
+
+func (x *Artifact) ClearSize() {
+	protoimpl.X.ClearPresent(&(x.XXX_presence[0]), 4)
+	x.xxx_hidden_Size = 0
+}
+
+type Artifact_builder struct {
+	_ [0]func() // Prevents comparability and use of unkeyed literals for the builder.
+
+	TaskId *int32
+	Label  *string
+	// path is the relative path from bazel-bin.
+	Path       *string
+	ObjectName *string
+	Size       *int64
+}
+
+func (b0 Artifact_builder) Build() *Artifact {
+	m0 := &Artifact{}
+	b, x := &b0, m0
+	_, _ = b, x
+	if b.TaskId != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 0, 5)
+		x.xxx_hidden_TaskId = *b.TaskId
+	}
+	if b.Label != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 1, 5)
+		x.xxx_hidden_Label = b.Label
+	}
+	if b.Path != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 2, 5)
+		x.xxx_hidden_Path = b.Path
+	}
+	if b.ObjectName != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 3, 5)
+		x.xxx_hidden_ObjectName = b.ObjectName
+	}
+	if b.Size != nil {
+		protoimpl.X.SetPresentNonAtomic(&(x.XXX_presence[0]), 4, 5)
+		x.xxx_hidden_Size = *b.Size
+	}
+	return m0
+}
+
+var File_proto_build_model_msg_proto protoreflect.FileDescriptor
+
+const file_proto_build_model_msg_proto_rawDesc = "" +
+	"\n" +
+	"\x1bproto/build/model/msg.proto\x12\x10mono.build.model\x1a!google/protobuf/go_features.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9e\x01\n" +
+	"\n" +
+	"Repository\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x1b\n" +
+	"\tclone_url\x18\x04 \x01(\tR\bcloneUrl\x12\x18\n" +
+	"\aprivate\x18\x05 \x01(\bR\aprivate\x12#\n" +
+	"\rhead_revision\x18\a \x01(\tR\fheadRevision\"\x99\n" +
+	"\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12#\n" +
+	"\rrepository_id\x18\x02 \x01(\x05R\frepositoryId\x12\x19\n" +
+	"\bjob_name\x18\x03 \x01(\tR\ajobName\x128\n" +
+	"\x18parsed_job_configuration\x18\x04 \x01(\tR\x16parsedJobConfiguration\x12\x1a\n" +
+	"\brevision\x18\x05 \x01(\tR\brevision\x12#\n" +
+	"\rbazel_version\x18\x06 \x01(\tR\fbazelVersion\x12\x18\n" +
+	"\acommand\x18\a \x01(\tR\acommand\x12\x19\n" +
+	"\bis_trunk\x18\b \x01(\bR\aisTrunk\x12\x18\n" +
+	"\asuccess\x18\t \x01(\bR\asuccess\x12\x19\n" +
+	"\blog_file\x18\n" +
+	" \x01(\tR\alogFile\x12\x18\n" +
+	"\atargets\x18\v \x03(\tR\atargets\x12\x1a\n" +
+	"\bplatform\x18\f \x01(\tR\bplatform\x12\x10\n" +
+	"\x03via\x18\r \x01(\tR\x03via\x12\x1f\n" +
+	"\vconfig_name\x18\x0e \x01(\tR\n" +
+	"configName\x12\x12\n" +
+	"\x04node\x18\x0f \x01(\tR\x04node\x12\x1a\n" +
+	"\bmanifest\x18\x10 \x01(\tR\bmanifest\x12\x1c\n" +
+	"\tcontainer\x18\x11 \x01(\tR\tcontainer\x120\n" +
+	"\x14executed_tests_count\x18\x12 \x01(\x05R\x12executedTestsCount\x122\n" +
+	"\x15succeeded_tests_count\x18\x13 \x01(\x05R\x13succeededTestsCount\x125\n" +
+	"\bstart_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\astartAt\x12;\n" +
+	"\vfinished_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\x129\n" +
+	"\n" +
+	"created_at\x18\x16 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x17 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12%\n" +
+	"\x0erepository_url\x18\x18 \x01(\tR\rrepositoryUrl\x12!\n" +
+	"\frevision_url\x18\x19 \x01(\tR\vrevisionUrl\x12\x1b\n" +
+	"\tcpu_limit\x18\x1a \x01(\tR\bcpuLimit\x12!\n" +
+	"\fmemory_limit\x18\x1b \x01(\tR\vmemoryLimit\x12?\n" +
+	"\ftest_reports\x18\x1c \x03(\v2\x1c.mono.build.model.TestReportR\vtestReports\x12\x18\n" +
+	"\askipped\x18\x1d \x01(\bR\askipped\x12\x14\n" +
+	"\x05needs\x18\x1e \x03(\tR\x05needs\x12\x18\n" +
+	"\aattempt\x18\x1f \x01(\x05R\aattempt\x12$\n" +
+	"\x0eparent_task_id\x18  \x01(\x05R\fparentTaskId\x12#\n" +
+	"\rcancel_reason\x18! \x01(\tR\fcancelReason\x12:\n" +
+	"\x06matrix\x18\" \x03(\v2\".mono.build.model.Task.MatrixEntryR\x06matrix\x1a9\n" +
+	"\vMatrixEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\">\n" +
+	"\x03Job\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
+	"\rrepository_id\x18\x02 \x01(\x05R\frepositoryId\"t\n" +
+	"\n" +
+	"TestReport\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x124\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1c.mono.build.model.TestStatusR\x06status\x12\x1a\n" +
+	"\bduration\x18\x03 \x01(\x03R\bduration\"\xff\x02\n" +
+	"\vGithubEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1f\n" +
+	"\vdelivery_id\x18\x02 \x01(\tR\n" +
+	"deliveryId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tR\teventType\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x14\n" +
+	"\x05state\x18\x05 \x01(\tR\x05state\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\a \x01(\tR\tlastError\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1e\n" +
+	"\n" +
+	"repository\x18\n" +
+	" \x01(\tR\n" +
+	"repository\x12%\n" +
+	"\x0erepository_url\x18\v \x01(\tR\rrepositoryUrl\"\x89\x03\n" +
+	"\x16ExternalReleaseTrigger\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12#\n" +
+	"\rrepository_id\x18\x02 \x01(\x05R\frepositoryId\x12'\n" +
+	"\x0frepository_name\x18\x03 \x01(\tR\x0erepositoryName\x12%\n" +
+	"\x0erepository_url\x18\x04 \x01(\tR\rrepositoryUrl\x12\x19\n" +
+	"\bjob_name\x18\x05 \x01(\tR\ajobName\x12\x1a\n" +
+	"\bprovider\x18\x06 \x01(\tR\bprovider\x12#\n" +
+	"\rexternal_repo\x18\a \x01(\tR\fexternalRepo\x12*\n" +
+	"\x11external_repo_url\x18\b \x01(\tR\x0fexternalRepoUrl\x12\x12\n" +
+	"\x04kind\x18\t \x01(\tR\x04kind\x12\x1f\n" +
+	"\vtag_pattern\x18\n" +
+	" \x01(\tR\n" +
+	"tagPattern\x12-\n" +
+	"\x12include_prerelease\x18\v \x01(\bR\x11includePrerelease\"\x92\x01\n" +
+	"\fTargetResult\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12)\n" +
+	"\x10failure_category\x18\x03 \x01(\tR\x0ffailureCategory\x12'\n" +
+	"\x0ffailure_message\x18\x04 \x01(\tR\x0efailureMessage\"\xd9\x01\n" +
+	"\rActionFailure\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12\x1a\n" +
+	"\bmnemonic\x18\x02 \x01(\tR\bmnemonic\x12\x1b\n" +
+	"\texit_code\x18\x03 \x01(\x05R\bexitCode\x12)\n" +
+	"\x10failure_category\x18\x04 \x01(\tR\x0ffailureCategory\x12'\n" +
+	"\x0ffailure_message\x18\x05 \x01(\tR\x0efailureMessage\x12%\n" +
+	"\x0eprimary_output\x18\x06 \x01(\tR\rprimaryOutput\"\xd7\x04\n" +
+	"\fBuildMetrics\x12$\n" +
+	"\x0eexit_code_name\x18\x01 \x01(\tR\fexitCodeName\x12'\n" +
+	"\x0factions_created\x18\x02 \x01(\x03R\x0eactionsCreated\x12)\n" +
+	"\x10actions_executed\x18\x03 \x01(\x03R\x0factionsExecuted\x12*\n" +
+	"\x11remote_cache_hits\x18\x04 \x01(\x03R\x0fremoteCacheHits\x12*\n" +
+	"\x11action_cache_hits\x18\x05 \x01(\x05R\x0factionCacheHits\x12.\n" +
+	"\x13action_cache_misses\x18\x06 \x01(\x05R\x11actionCacheMisses\x12-\n" +
+	"\x12targets_configured\x18\a \x01(\x03R\x11targetsConfigured\x12 \n" +
+	"\fwall_time_ms\x18\b \x01(\x03R\n" +
+	"wallTimeMs\x12\x1e\n" +
+	"\vcpu_time_ms\x18\t \x01(\x03R\tcpuTimeMs\x123\n" +
+	"\x16analysis_phase_time_ms\x18\n" +
+	" \x01(\x03R\x13analysisPhaseTimeMs\x125\n" +
+	"\x17execution_phase_time_ms\x18\v \x01(\x03R\x14executionPhaseTimeMs\x123\n" +
+	"\x16remote_cache_hit_ratio\x18\f \x01(\x01R\x13remoteCacheHitRatio\x123\n" +
+	"\x16action_cache_hit_ratio\x18\r \x01(\x01R\x13actionCacheHitRatio\"\xde\x01\n" +
+	"\tFlakyTest\x12#\n" +
+	"\rrepository_id\x18\x01 \x01(\x05R\frepositoryId\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x12\n" +
+	"\x04runs\x18\x03 \x01(\x05R\x04runs\x12\x1d\n" +
+	"\n" +
+	"flaky_runs\x18\x04 \x01(\x05R\tflakyRuns\x12\x14\n" +
+	"\x05score\x18\x05 \x01(\x01R\x05score\x12 \n" +
+	"\vquarantined\x18\x06 \x01(\bR\vquarantined\x12+\n" +
+	"\x12last_flaky_task_id\x18\a \x01(\x05R\x0flastFlakyTaskId\"\x82\x01\n" +
+	"\bArtifact\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x05R\x06taskId\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12\x1f\n" +
+	"\vobject_name\x18\x04 \x01(\tR\n" +
+	"objectName\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size*S\n" +
 	"\n" +
 	"TestStatus\x12\x16\n" +
 	"\x12TEST_STATUS_PASSED\x10\x00\x12\x15\n" +
@@ -2318,7 +3859,7 @@ const file_proto_build_model_msg_proto_rawDesc = "" +
 	"\x12TEST_STATUS_FAILED\x10\x02B)Z\x1fgo.f110.dev/mono/go/build/model\x92\x03\x05\xd2>\x02\x10\x03b\beditionsp\xe8\a"
 
 var file_proto_build_model_msg_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_build_model_msg_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_build_model_msg_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_proto_build_model_msg_proto_goTypes = []any{
 	(TestStatus)(0),                // 0: mono.build.model.TestStatus
 	(*Repository)(nil),             // 1: mono.build.model.Repository
@@ -2327,22 +3868,29 @@ var file_proto_build_model_msg_proto_goTypes = []any{
 	(*TestReport)(nil),             // 4: mono.build.model.TestReport
 	(*GithubEvent)(nil),            // 5: mono.build.model.GithubEvent
 	(*ExternalReleaseTrigger)(nil), // 6: mono.build.model.ExternalReleaseTrigger
-	(*timestamppb.Timestamp)(nil),  // 7: google.protobuf.Timestamp
+	(*TargetResult)(nil),           // 7: mono.build.model.TargetResult
+	(*ActionFailure)(nil),          // 8: mono.build.model.ActionFailure
+	(*BuildMetrics)(nil),           // 9: mono.build.model.BuildMetrics
+	(*FlakyTest)(nil),              // 10: mono.build.model.FlakyTest
+	(*Artifact)(nil),               // 11: mono.build.model.Artifact
+	nil,                            // 12: mono.build.model.Task.MatrixEntry
+	(*timestamppb.Timestamp)(nil),  // 13: google.protobuf.Timestamp
 }
 var file_proto_build_model_msg_proto_depIdxs = []int32{
-	7, // 0: mono.build.model.Task.start_at:type_name -> google.protobuf.Timestamp
-	7, // 1: mono.build.model.Task.finished_at:type_name -> google.protobuf.Timestamp
-	7, // 2: mono.build.model.Task.created_at:type_name -> google.protobuf.Timestamp
-	7, // 3: mono.build.model.Task.updated_at:type_name -> google.protobuf.Timestamp
-	4, // 4: mono.build.model.Task.test_reports:type_name -> mono.build.model.TestReport
-	0, // 5: mono.build.model.TestReport.status:type_name -> mono.build.model.TestStatus
-	7, // 6: mono.build.model.GithubEvent.created_at:type_name -> google.protobuf.Timestamp
-	7, // 7: mono.build.model.GithubEvent.updated_at:type_name -> google.protobuf.Timestamp
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	13, // 0: mono.build.model.Task.start_at:type_name -> google.protobuf.Timestamp
+	13, // 1: mono.build.model.Task.finished_at:type_name -> google.protobuf.Timestamp
+	13, // 2: mono.build.model.Task.created_at:type_name -> google.protobuf.Timestamp
+	13, // 3: mono.build.model.Task.updated_at:type_name -> google.protobuf.Timestamp
+	4,  // 4: mono.build.model.Task.test_reports:type_name -> mono.build.model.TestReport
+	12, // 5: mono.build.model.Task.matrix:type_name -> mono.build.model.Task.MatrixEntry
+	0,  // 6: mono.build.model.TestReport.status:type_name -> mono.build.model.TestStatus
+	13, // 7: mono.build.model.GithubEvent.created_at:type_name -> google.protobuf.Timestamp
+	13, // 8: mono.build.model.GithubEvent.updated_at:type_name -> google.protobuf.Timestamp
+	9,  // [9:9] is the sub-list for method output_type
+	9,  // [9:9] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_proto_build_model_msg_proto_init() }
@@ -2356,7 +3904,7 @@ func file_proto_build_model_msg_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_build_model_msg_proto_rawDesc), len(file_proto_build_model_msg_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   6,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -24,7 +24,7 @@ import (
 // Builder is the subset of coordinator.BazelBuilder used to dispatch a task.
 // Defined here to avoid a cyclic import with the coordinator package.
 type Builder interface {
-	Build(ctx context.Context, repo *database.SourceRepository, job *config.JobV2, revision, bazelVersion, command string, targets, platforms []string, via string, isMainBranch bool) ([]*database.Task, error)
+	Build(ctx context.Context, repo *database.SourceRepository, job *config.JobV2, revision, bazelVersion, command string, targets, platforms []string, via string, isMainBranch bool, ref config.SourceRef) ([]*database.Task, error)
 }
 
 // trigger is the in-memory representation of one external release trigger.
@@ -225,7 +225,7 @@ func (m *Manager) dispatch(ctx context.Context, repo *database.SourceRepository,
 	jobCopy.Env["EXTERNAL_RELEASE_REPO"] = t.ExternalRepo
 	jobCopy.Env["EXTERNAL_RELEASE_PRERELEASE"] = fmt.Sprintf("%t", item.Prerelease)
 
-	tasks, err := m.builder.Build(ctx, repo, jobCopy, revision, conf.BazelVersion, jobCopy.Command, jobCopy.Targets, jobCopy.Platforms, "external_release", false)
+	tasks, err := m.builder.Build(ctx, repo, jobCopy, revision, conf.BazelVersion, jobCopy.Command, jobCopy.Targets, jobCopy.Platforms, "external_release", false, config.SourceRef{})
 	if err != nil {
 		return xerrors.WithStack(err)
 	}
@@ -0,0 +1,163 @@
+package webhook
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/google/go-github/v85/github"
+	"go.f110.dev/xerrors"
+
+	"go.f110.dev/mono/go/build/config"
+	"go.f110.dev/mono/go/git"
+	"go.f110.dev/mono/go/logger/slogger"
+)
+
+// pathFilterSkipDescription is the description of the commit status posted
+// for a job which is not triggered because no changed file matched its paths.
+const pathFilterSkipDescription = "Skipped: no changes matched the paths of the job"
+
+// changedFiles returns the paths which differ between base and head. When
+// gitDataClient is non-nil, the trees are compared by git-data-service;
+// otherwise it falls back to the GitHub compare API.
+//
+// A nil slice means the change set is unknown (e.g. the push created the
+// branch) and every job should be triggered.
+func changedFiles(ctx context.Context, gh *github.Client, gitDataClient git.GitDataClient, owner, repoName, base, head string) ([]string, error) {
+	if base == "" || strings.Trim(base, "0") == "" || head == "" {
+		return nil, nil
+	}
+
+	if gitDataClient != nil {
+		return changedFilesFromGitData(ctx, gitDataClient, repoName, base, head)
+	}
+
+	var files []string
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		comp, res, err := gh.Repositories.CompareCommits(ctx, owner, repoName, base, head, opt)
+		if err != nil {
+			return nil, xerrors.WithMessagef(err, "failed to compare %s...%s", base, head)
+		}
+		for _, f := range comp.Files {
+			files = append(files, f.GetFilename())
+			if f.GetPreviousFilename() != "" {
+				files = append(files, f.GetPreviousFilename())
+			}
+		}
+		if res.NextPage == 0 {
+			break
+		}
+		opt.Page = res.NextPage
+	}
+	if files == nil {
+		files = []string{}
+	}
+	return files, nil
+}
+
+func changedFilesFromGitData(ctx context.Context, client git.GitDataClient, repoName, base, head string) ([]string, error) {
+	res, err := client.Compare(ctx, &git.RequestCompare{Repo: repoName, Base: base, Head: head})
+	if err != nil {
+		return nil, xerrors.WithMessagef(err, "failed to compare %s...%s", base, head)
+	}
+
+	files := make([]string, 0, len(res.GetFiles()))
+	for _, f := range res.GetFiles() {
+		files = append(files, f.GetPath())
+		if f.GetPreviousPath() != "" {
+			files = append(files, f.GetPreviousPath())
+		}
+	}
+	return files, nil
+}
+
+// filterJobsByPaths splits jobs into the jobs which have to run for files and
+// the jobs which are filtered out by their paths. A filtered out job is
+// dropped from Needs of the matched jobs so that it doesn't block them.
+func filterJobsByPaths(jobs []*config.JobV2, files []string) (matched, filtered []*config.JobV2) {
+	filteredNames := make(map[string]struct{})
+	for _, j := range jobs {
+		if j.MatchChangedFiles(files) {
+			matched = append(matched, j)
+		} else {
+			filtered = append(filtered, j)
+			filteredNames[j.Name] = struct{}{}
+		}
+	}
+	if len(filtered) == 0 {
+		return matched, nil
+	}
+
+	for i, j := range matched {
+		var needs []string
+		changed := false
+		for _, n := range j.Needs {
+			if _, ok := filteredNames[n]; ok {
+				changed = true
+				continue
+			}
+			needs = append(needs, n)
+		}
+		if changed {
+			c := j.Copy()
+			c.Needs = needs
+			matched[i] = c
+		}
+	}
+	return matched, filtered
+}
+
+// selectJobsByChangedFiles narrows jobs down to the jobs whose paths match
+// the files changed between base and head. If the change set can't be
+// computed, all jobs are returned so that a build is never lost.
+func selectJobsByChangedFiles(ctx context.Context, gh *github.Client, gitDataClient git.GitDataClient, owner, repoName string, jobs []*config.JobV2, base, head string) (matched, filtered []*config.JobV2) {
+	needFilter := false
+	for _, j := range jobs {
+		if len(j.Paths) > 0 || len(j.PathsIgnore) > 0 {
+			needFilter = true
+			break
+		}
+	}
+	if !needFilter {
+		return jobs, nil
+	}
+
+	files, err := changedFiles(ctx, gh, gitDataClient, owner, repoName, base, head)
+	if err != nil {
+		slogger.Log.Warn("Failed to get changed files. All jobs will be triggered", slogger.E(err), slog.String("repo", repoName), slog.String("base", base), slog.String("head", head))
+		return jobs, nil
+	}
+	return filterJobsByPaths(jobs, files)
+}
+
+// reportFilteredJobs posts a successful commit status for each filtered out
+// job which reports its status to GitHub. GitHub's commit status has no
+// neutral state, so success is used to keep branch protection from waiting
+// for a build which never runs. The job which has the matrix reports the
+// status of each cell like the coordinator does.
+func reportFilteredJobs(ctx context.Context, gh *github.Client, owner, repoName, revision string, jobs []*config.JobV2) error {
+	for _, j := range jobs {
+		if !j.GitHubStatus {
+			continue
+		}
+		seen := make(map[string]struct{})
+		for _, entry := range j.Matrix.Expand("", j.ConfigName, j.Platforms) {
+			statusContext := config.StatusContext(j.Command, j.Name, entry.Axes)
+			if _, ok := seen[statusContext]; ok {
+				continue
+			}
+			seen[statusContext] = struct{}{}
+
+			_, _, err := gh.Repositories.CreateStatus(ctx, owner, repoName, revision, github.RepoStatus{
+				State:       new("success"),
+				Context:     new(statusContext),
+				Description: new(pathFilterSkipDescription),
+			})
+			if err != nil {
+				return xerrors.WithStack(err)
+			}
+		}
+	}
+	return nil
+}
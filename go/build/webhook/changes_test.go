@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v85/github"
+	"google.golang.org/grpc"
+
+	"go.f110.dev/mono/go/build/config"
+	"go.f110.dev/mono/go/enumerable"
+	"go.f110.dev/mono/go/git"
+	"go.f110.dev/mono/go/testing/assertion"
+)
+
+// compareGitDataClient serves Compare from the changes keyed by "base...head".
+type compareGitDataClient struct {
+	git.GitDataClient
+	changes map[string][]*git.FileChange
+}
+
+func (c *compareGitDataClient) Compare(_ context.Context, in *git.RequestCompare, _ ...grpc.CallOption) (*git.ResponseCompare, error) {
+	return &git.ResponseCompare{Base: in.Base, Head: in.Head, Files: c.changes[in.Base+"..."+in.Head]}, nil
+}
+
+func TestChangedFiles(t *testing.T) {
+	client := &compareGitDataClient{changes: map[string][]*git.FileChange{
+		"base...head": {
+			{Status: git.FileChangeStatus_FILE_CHANGE_STATUS_MODIFIED, Path: "docs/index.md"},
+			{Status: git.FileChangeStatus_FILE_CHANGE_STATUS_ADDED, Path: "docs/new.md"},
+			{Status: git.FileChangeStatus_FILE_CHANGE_STATUS_DELETED, Path: "README.md"},
+			{Status: git.FileChangeStatus_FILE_CHANGE_STATUS_RENAMED, Path: "go/main.go", PreviousPath: "cmd/main.go"},
+		},
+	}}
+
+	files, err := changedFiles(context.Background(), nil, client, "f110", "ops", "base", "head")
+	assertion.MustNoError(t, err)
+	slices.Sort(files)
+	// The renamed file matches the paths of both sides.
+	assertion.Equal(t, []string{"README.md", "cmd/main.go", "docs/index.md", "docs/new.md", "go/main.go"}, files)
+
+	// The push which creates the branch doesn't have the before revision.
+	files, err = changedFiles(context.Background(), nil, client, "f110", "ops", "0000000000000000000000000000000000000000", "head")
+	assertion.MustNoError(t, err)
+	assertion.Nil(t, files)
+}
+
+func TestFilterJobsByPaths(t *testing.T) {
+	jobs := []*config.JobV2{
+		{Name: "test", Paths: []string{"go/**"}},
+		{Name: "docs", Paths: []string{"docs/**"}},
+		{Name: "lint"},
+		{Name: "publish", Needs: []string{"test", "lint"}},
+	}
+
+	matched, filtered := filterJobsByPaths(jobs, []string{"docs/index.md"})
+	assertion.Equal(t, []string{"docs", "lint", "publish"}, enumerable.Map(matched, func(j *config.JobV2) string { return j.Name }))
+	assertion.Equal(t, []string{"test"}, enumerable.Map(filtered, func(j *config.JobV2) string { return j.Name }))
+	// The filtered job must not block the job which needs it.
+	assertion.Equal(t, []string{"lint"}, matched[2].Needs)
+	assertion.Equal(t, []string{"test", "lint"}, jobs[3].Needs)
+
+	matched, filtered = filterJobsByPaths(jobs, nil)
+	assertion.Len(t, matched, 4)
+	assertion.Len(t, filtered, 0)
+}
+
+// statusRecorder records the contexts of the commit statuses which are created.
+type statusRecorder struct {
+	contexts []string
+}
+
+func (r *statusRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	status := &github.RepoStatus{}
+	if err := json.NewDecoder(req.Body).Decode(status); err != nil {
+		return nil, err
+	}
+	r.contexts = append(r.contexts, status.GetContext())
+	return &http.Response{StatusCode: http.StatusCreated, Body: io.NopCloser(strings.NewReader("{}")), Request: req}, nil
+}
+
+func TestReportFilteredJobs(t *testing.T) {
+	jobs := []*config.JobV2{
+		{Name: "test", Command: "test", GitHubStatus: true, Platforms: []string{"linux_amd64", "linux_arm64"}},
+		{
+			Name:         "matrix",
+			Command:      "test",
+			GitHubStatus: true,
+			Platforms:    []string{"linux_amd64"},
+			Matrix:       &config.Matrix{BazelVersions: []string{"7.6.0", "8.0.0"}},
+		},
+		{Name: "lint", Command: "run"},
+	}
+	recorder := &statusRecorder{}
+	gh := github.NewClient(&http.Client{Transport: recorder})
+
+	err := reportFilteredJobs(context.Background(), gh, "f110", "mono", "abcdef", jobs)
+	assertion.MustNoError(t, err)
+	// The matrix job reports the status of each cell.
+	assertion.Equal(t, []string{
+		"test test",
+		"test matrix (bazel_version=7.6.0)",
+		"test matrix (bazel_version=8.0.0)",
+	}, recorder.contexts)
+}
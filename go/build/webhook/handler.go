@@ -129,7 +129,7 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	if err := h.insert(req.Context(), deliveryID, eventType, m.Action, payload); err != nil {
+	if err := insertEvent(req.Context(), h.dao, deliveryID, eventType, m.Action, payload); err != nil {
 		if isDuplicateEntry(err) {
 			slogger.Log.Info("Duplicate webhook delivery", slog.String("delivery_id", deliveryID))
 			w.WriteHeader(http.StatusOK)
@@ -144,8 +144,10 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
-func (h *Handler) insert(ctx context.Context, deliveryID, eventType, action string, payload []byte) error {
-	_, err := h.dao.GithubEvent.Create(ctx, &database.GithubEvent{
+// insertEvent persists a PENDING github_event row. The poller uses this too so
+// that the synthesized deliveries go through the same reconcilers.
+func insertEvent(ctx context.Context, daos dao.Options, deliveryID, eventType, action string, payload []byte) error {
+	_, err := daos.GithubEvent.Create(ctx, &database.GithubEvent{
 		DeliveryId: deliveryID,
 		EventType:  eventType,
 		Action:     action,
@@ -86,19 +86,20 @@ func fetchDefaultBranchConfig(ctx context.Context, gh *github.Client, gitDataCli
 // dispatchBuilds runs the legacy a.build loop: filters jobs to known commands
 // and asks the Builder to schedule each one. Returns the tasks the builder
 // produced so the caller can checkpoint them.
-func dispatchBuilds(ctx context.Context, builder Builder, owner, repoName string, repo *database.SourceRepository, jobs []*config.JobV2, bazelVersion, revision, via string, isMainBranch bool) ([]*database.Task, error) {
+func dispatchBuilds(ctx context.Context, builder Builder, owner, repoName string, repo *database.SourceRepository, jobs []*config.JobV2, bazelVersion, revision, via string, isMainBranch bool, ref config.SourceRef) ([]*database.Task, error) {
 	if repo == nil {
 		return nil, nil
 	}
 	var dispatched []*database.Task
-	for _, v := range jobs {
+	// Upstream jobs go first so that their tasks exist when the downstream jobs check the dependencies.
+	for _, v := range config.SortByDependency(jobs) {
 		switch v.Command {
 		case "build", "test", "run":
 		default:
 			slogger.Log.Warn("Skip creating job", slog.String("command", v.Command))
 			continue
 		}
-		tasks, err := builder.Build(ctx, repo, v, revision, bazelVersion, v.Command, v.Targets, v.Platforms, via, isMainBranch)
+		tasks, err := builder.Build(ctx, repo, v, revision, bazelVersion, v.Command, v.Targets, v.Platforms, via, isMainBranch, ref)
 		// Build persists the task rows before it may fail to launch the
 		// underlying job, so record whatever it created even on error. The
 		// caller checkpoints these ids so a retry does not duplicate them.
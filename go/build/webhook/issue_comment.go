@@ -137,7 +137,7 @@ func (r *IssueCommentReconciler) Reconcile(ctx context.Context, ev *database.Git
 		}
 
 		jobs := conf.Job(config.EventPullRequest)
-		tasks, err := dispatchBuilds(ctx, r.builder, owner, repoName, repo, jobs, conf.BazelVersion, revision, "pr", false)
+		tasks, err := dispatchBuilds(ctx, r.builder, owner, repoName, repo, jobs, conf.BazelVersion, revision, "pr", false, config.SourceRef{Branch: pr.GetHead().GetRef(), PullRequest: number})
 		// Checkpoint the created task ids even on a partial failure so a retry
 		// resumes instead of dispatching the same jobs again.
 		if ids := TaskIDs(tasks); len(ids) > 0 {
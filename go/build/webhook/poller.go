@@ -0,0 +1,296 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v85/github"
+	"go.f110.dev/xerrors"
+
+	"go.f110.dev/mono/go/build/database"
+	"go.f110.dev/mono/go/build/database/dao"
+	"go.f110.dev/mono/go/ctxutil"
+	"go.f110.dev/mono/go/git"
+	"go.f110.dev/mono/go/logger/slogger"
+)
+
+// ReferenceSource reads the branches and the commits of a repository for the
+// Poller.
+type ReferenceSource interface {
+	ListBranches(ctx context.Context, repo *database.SourceRepository) ([]*git.Reference, error)
+	CommitMessage(ctx context.Context, repo *database.SourceRepository, sha string) (string, error)
+}
+
+// Poller is the substitute for the webhook for repositories whose host cannot
+// reach our endpoint. It polls the tracked branches of the repositories and
+// inserts a synthesized `push` row into github_event when a branch moves, so
+// the new commit is built by PushReconciler exactly like a delivered push.
+//
+// The last seen hash of each branch is persisted in polled_reference. The
+// first observation of a branch only records the hash; builds are dispatched
+// for the commits pushed after that.
+type Poller struct {
+	dao          dao.Options
+	source       ReferenceSource
+	gitSyncer    GitSyncer
+	notifier     *Notifier
+	repositories []string
+	interval     time.Duration
+}
+
+// NewPoller returns a Poller for repositories (the url of source_repository).
+// The branches are read from git-data-service if gitDataClient is not nil,
+// otherwise from GitHub.
+func NewPoller(daos dao.Options, gh *github.Client, gitDataClient git.GitDataClient, gitSyncer GitSyncer, notifier *Notifier, repositories []string, interval time.Duration) *Poller {
+	var source ReferenceSource
+	if gitDataClient != nil {
+		source = &gitDataReferenceSource{client: gitDataClient}
+	} else {
+		source = &githubReferenceSource{client: gh}
+	}
+	return &Poller{
+		dao:          daos,
+		source:       source,
+		gitSyncer:    gitSyncer,
+		notifier:     notifier,
+		repositories: repositories,
+		interval:     interval,
+	}
+}
+
+// Start runs the polling loop until ctx is cancelled. Like the Scheduler, it
+// has to run only on the elected leader.
+func (p *Poller) Start(ctx context.Context) {
+	slogger.Log.Info("Start poller", slog.Duration("interval", p.interval), slog.Int("repositories", len(p.repositories)))
+	t := time.NewTicker(p.interval)
+	defer t.Stop()
+
+	p.tickWithTimeout(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			p.tickWithTimeout(ctx)
+		}
+	}
+}
+
+func (p *Poller) tickWithTimeout(parent context.Context) {
+	ctx, cancel := ctxutil.WithTimeout(parent, 5*time.Minute)
+	defer cancel()
+	p.tick(ctx)
+}
+
+func (p *Poller) tick(ctx context.Context) {
+	var inserted bool
+	for _, u := range p.repositories {
+		repo, err := FindRepository(ctx, p.dao, u)
+		if err != nil {
+			slogger.Log.Warn("Failed to look up repository for polling", slog.String("repository", u), slogger.E(err))
+			continue
+		}
+		if repo == nil {
+			slogger.Log.Warn("Skip polling unmanaged repository", slog.String("repository", u))
+			continue
+		}
+		n, err := p.poll(ctx, repo)
+		if err != nil {
+			slogger.Log.Warn("Failed to poll repository", slog.String("repository", u), slogger.E(err))
+		}
+		if n > 0 {
+			inserted = true
+		}
+	}
+	if inserted {
+		p.notifier.Notify()
+	}
+}
+
+// poll compares the branches of repo with the last seen hashes and inserts a
+// push event for each branch which has moved. It returns the number of the
+// inserted events.
+func (p *Poller) poll(ctx context.Context, repo *database.SourceRepository) (int, error) {
+	if p.gitSyncer != nil {
+		if err := p.gitSyncer.Sync(ctx, repo.CloneUrl); err != nil && !errors.Is(err, git.ErrRepositoryNotTracked) {
+			// The branches are still readable even though they may be stale.
+			slogger.Log.Warn("Failed to sync repository", slog.String("repo", repo.CloneUrl), slogger.E(err))
+		}
+	}
+
+	branches, err := p.source.ListBranches(ctx, repo)
+	if err != nil {
+		return 0, err
+	}
+	polled, err := p.dao.PolledReference.ListByRepositoryId(ctx, repo.Id)
+	if err != nil {
+		return 0, xerrors.WithStack(err)
+	}
+	lastSeen := make(map[string]*database.PolledReference)
+	for _, v := range polled {
+		lastSeen[v.Ref] = v
+	}
+
+	var inserted int
+	for _, b := range branches {
+		if !p.isTracked(repo, b.Name) || b.Hash == "" {
+			continue
+		}
+		seen, ok := lastSeen[b.Name]
+		if !ok {
+			if _, err := p.dao.PolledReference.Create(ctx, &database.PolledReference{RepositoryId: repo.Id, Ref: b.Name, Hash: b.Hash}); err != nil {
+				return inserted, xerrors.WithStack(err)
+			}
+			slogger.Log.Info("Start tracking branch", slog.String("repo", repo.Name), slog.String("ref", b.Name), slog.String("hash", b.Hash))
+			continue
+		}
+		if seen.Hash == b.Hash {
+			continue
+		}
+
+		if err := p.insertPushEvent(ctx, repo, b, seen.Hash); err != nil {
+			return inserted, err
+		}
+		inserted++
+		// The event is inserted before the hash is updated. If the update
+		// fails, the next tick inserts the same delivery again and it is
+		// dropped as a duplicate.
+		seen.Hash = b.Hash
+		if err := p.dao.PolledReference.Update(ctx, seen); err != nil {
+			return inserted, xerrors.WithStack(err)
+		}
+	}
+	return inserted, nil
+}
+
+// isTracked reports whether ref is polled. Only the default branch is tracked
+// since PushReconciler skips the pushes to the other branches.
+func (*Poller) isTracked(repo *database.SourceRepository, ref string) bool {
+	return ref == "refs/heads/"+repo.DefaultBranch
+}
+
+func (p *Poller) insertPushEvent(ctx context.Context, repo *database.SourceRepository, ref *git.Reference, before string) error {
+	message, err := p.source.CommitMessage(ctx, repo, ref.Hash)
+	if err != nil {
+		return err
+	}
+	payload, err := polledPushPayload(repo, ref, before, message)
+	if err != nil {
+		return err
+	}
+
+	deliveryID := fmt.Sprintf("poll-%d-%s", repo.Id, ref.Hash)
+	if err := insertEvent(ctx, p.dao, deliveryID, "push", "", payload); err != nil {
+		if isDuplicateEntry(err) {
+			return nil
+		}
+		return err
+	}
+	slogger.Log.Info("Detected new commit by polling", slog.String("repo", repo.Name), slog.String("ref", ref.Name), slog.String("before", before), slog.String("after", ref.Hash))
+	return nil
+}
+
+// polledPushPayload builds the payload of a push event with the fields which
+// PushReconciler reads.
+func polledPushPayload(repo *database.SourceRepository, ref *git.Reference, before, message string) ([]byte, error) {
+	owner, name, err := ownerAndName(repo.Url)
+	if err != nil {
+		return nil, err
+	}
+	event := &github.PushEvent{
+		Ref:    new(ref.Name),
+		Before: new(before),
+		After:  new(ref.Hash),
+		HeadCommit: &github.HeadCommit{
+			ID:      new(ref.Hash),
+			Message: new(message),
+		},
+		Repo: &github.PushEventRepository{
+			Name:          new(name),
+			FullName:      new(owner + "/" + name),
+			Owner:         &github.User{Login: new(owner)},
+			HTMLURL:       new(repo.Url),
+			CloneURL:      new(repo.CloneUrl),
+			DefaultBranch: new(repo.DefaultBranch),
+			MasterBranch:  new(repo.DefaultBranch),
+		},
+	}
+	b, err := json.Marshal(event)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	return b, nil
+}
+
+func ownerAndName(repoURL string) (string, string, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", "", xerrors.WithStack(err)
+	}
+	s := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(s) != 2 {
+		return "", "", xerrors.Definef("invalid repository url: %s", repoURL).WithStack()
+	}
+	return s[0], s[1], nil
+}
+
+type gitDataReferenceSource struct {
+	client git.GitDataClient
+}
+
+var _ ReferenceSource = (*gitDataReferenceSource)(nil)
+
+func (s *gitDataReferenceSource) ListBranches(ctx context.Context, repo *database.SourceRepository) ([]*git.Reference, error) {
+	res, err := s.client.ListBranch(ctx, &git.RequestListBranch{Repo: repo.Name})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	return res.GetBranches(), nil
+}
+
+func (s *gitDataReferenceSource) CommitMessage(ctx context.Context, repo *database.SourceRepository, sha string) (string, error) {
+	res, err := s.client.GetCommit(ctx, &git.RequestGetCommit{Repo: repo.Name, Sha: sha})
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	return res.GetCommit().GetMessage(), nil
+}
+
+type githubReferenceSource struct {
+	client *github.Client
+}
+
+var _ ReferenceSource = (*githubReferenceSource)(nil)
+
+func (s *githubReferenceSource) ListBranches(ctx context.Context, repo *database.SourceRepository) ([]*git.Reference, error) {
+	owner, name, err := ownerAndName(repo.Url)
+	if err != nil {
+		return nil, err
+	}
+	var refs []*git.Reference
+	for b, err := range s.client.Repositories.ListBranchesIter(ctx, owner, name, &github.BranchListOptions{ListOptions: github.ListOptions{PerPage: 100}}) {
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		refs = append(refs, &git.Reference{Name: "refs/heads/" + b.GetName(), Hash: b.GetCommit().GetSHA()})
+	}
+	return refs, nil
+}
+
+func (s *githubReferenceSource) CommitMessage(ctx context.Context, repo *database.SourceRepository, sha string) (string, error) {
+	owner, name, err := ownerAndName(repo.Url)
+	if err != nil {
+		return "", err
+	}
+	c, _, err := s.client.Git.GetCommit(ctx, owner, name, sha)
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	return c.GetMessage(), nil
+}
@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-github/v85/github"
+
+	"go.f110.dev/mono/go/build/database"
+	"go.f110.dev/mono/go/git"
+	"go.f110.dev/mono/go/logger"
+	"go.f110.dev/mono/go/logger/slogger"
+	"go.f110.dev/mono/go/testing/assertion"
+)
+
+type fakeReferenceSource struct {
+	branches []*git.Reference
+	messages map[string]string
+}
+
+var _ ReferenceSource = (*fakeReferenceSource)(nil)
+
+func (s *fakeReferenceSource) ListBranches(_ context.Context, _ *database.SourceRepository) ([]*git.Reference, error) {
+	return s.branches, nil
+}
+
+func (s *fakeReferenceSource) CommitMessage(_ context.Context, _ *database.SourceRepository, sha string) (string, error) {
+	return s.messages[sha], nil
+}
+
+func TestPoller(t *testing.T) {
+	logger.SetLogLevel("debug")
+	slogger.Init()
+
+	const opsURL = "https://github.com/f110/ops"
+	repo := repoFixture(opsURL, "ops")
+	repo.DefaultBranch = "main"
+	source := &fakeReferenceSource{
+		branches: []*git.Reference{
+			{Name: "refs/heads/main", Hash: "bbb"},
+			{Name: "refs/heads/feature", Hash: "ccc"},
+		},
+		messages: map[string]string{"bbb": "Update README"},
+	}
+
+	t.Run("first observation records the hash only", func(t *testing.T) {
+		d := newTestDAO()
+		d.Repository.RegisterListByUrl(opsURL, []*database.SourceRepository{repo}, nil)
+		d.PolledReference.RegisterListByRepositoryId(repo.Id, nil, nil)
+		kick := make(chan struct{}, 1)
+		notifier := NewNotifier()
+		notifier.Register(kick)
+		p := &Poller{dao: d.toOptions(), source: source, notifier: notifier, repositories: []string{opsURL}}
+
+		p.tick(context.Background())
+
+		created := d.PolledReference.Called("Create")
+		assertion.MustLen(t, created, 1)
+		ref := created[0].Args["polledReference"].(*database.PolledReference)
+		assertion.Equal(t, ref.Ref, "refs/heads/main")
+		assertion.Equal(t, ref.Hash, "bbb")
+		assertion.MustLen(t, d.GithubEvent.Called("Create"), 0)
+		assertion.MustLen(t, kick, 0)
+	})
+
+	t.Run("moved branch inserts a push event", func(t *testing.T) {
+		d := newTestDAO()
+		d.Repository.RegisterListByUrl(opsURL, []*database.SourceRepository{repo}, nil)
+		d.PolledReference.RegisterListByRepositoryId(repo.Id, []*database.PolledReference{
+			{Id: 1, RepositoryId: repo.Id, Ref: "refs/heads/main", Hash: "aaa"},
+		}, nil)
+		kick := make(chan struct{}, 1)
+		notifier := NewNotifier()
+		notifier.Register(kick)
+		p := &Poller{dao: d.toOptions(), source: source, notifier: notifier, repositories: []string{opsURL}}
+
+		p.tick(context.Background())
+
+		called := d.GithubEvent.Called("Create")
+		assertion.MustLen(t, called, 1)
+		ev := called[0].Args["githubEvent"].(*database.GithubEvent)
+		assertion.Equal(t, ev.EventType, "push")
+		assertion.Equal(t, ev.DeliveryId, "poll-1-bbb")
+		assertion.Equal(t, ev.State, database.GithubEventStatePending)
+
+		var event github.PushEvent
+		assertion.MustNoError(t, json.Unmarshal(ev.Payload, &event))
+		assertion.Equal(t, event.GetRef(), "refs/heads/main")
+		assertion.Equal(t, event.GetBefore(), "aaa")
+		assertion.Equal(t, event.GetHeadCommit().GetID(), "bbb")
+		assertion.Equal(t, event.GetHeadCommit().GetMessage(), "Update README")
+		assertion.Equal(t, event.GetRepo().GetOwner().GetLogin(), "f110")
+		assertion.Equal(t, event.GetRepo().GetName(), "ops")
+		assertion.Equal(t, event.GetRepo().GetHTMLURL(), opsURL)
+		assertion.True(t, IsMainBranch(event.GetRef(), event.GetRepo().GetMasterBranch()))
+
+		updated := d.PolledReference.Called("Update")
+		assertion.MustLen(t, updated, 1)
+		assertion.Equal(t, updated[0].Args["polledReference"].(*database.PolledReference).Hash, "bbb")
+		assertion.MustLen(t, kick, 1)
+	})
+
+	t.Run("unchanged branch does nothing", func(t *testing.T) {
+		d := newTestDAO()
+		d.Repository.RegisterListByUrl(opsURL, []*database.SourceRepository{repo}, nil)
+		d.PolledReference.RegisterListByRepositoryId(repo.Id, []*database.PolledReference{
+			{Id: 1, RepositoryId: repo.Id, Ref: "refs/heads/main", Hash: "bbb"},
+		}, nil)
+		p := &Poller{dao: d.toOptions(), source: source, notifier: NewNotifier(), repositories: []string{opsURL}}
+
+		p.tick(context.Background())
+
+		assertion.MustLen(t, d.GithubEvent.Called("Create"), 0)
+		assertion.MustLen(t, d.PolledReference.Called("Update"), 0)
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"go.f110.dev/mono/go/build/config"
 	"go.f110.dev/mono/go/build/database"
 	"go.f110.dev/mono/go/build/database/dao"
+	"go.f110.dev/mono/go/enumerable"
 	"go.f110.dev/mono/go/git"
 	"go.f110.dev/mono/go/logger/slogger"
 )
@@ -134,8 +135,21 @@ func (r *PullRequestReconciler) handleOpenedOrSynchronize(ctx context.Context, e
 	}
 
 	if status.DispatchedTaskIDs == nil {
-		jobs := conf.Job(config.EventPullRequest)
-		tasks, err := dispatchBuilds(ctx, r.builder, owner, repoName, repo, jobs, conf.BazelVersion, revision, "pull_request", false)
+		// The whole change of the pull request decides the jobs rather than the last push to it.
+		// Otherwise a job which has run for an earlier push would be left without a status.
+		base := event.GetPullRequest().GetBase().GetSHA()
+		jobs, filtered := selectJobsByChangedFiles(ctx, r.githubClient, r.gitDataClient, owner, repoName, conf.Job(config.EventPullRequest), base, revision)
+		if len(filtered) > 0 && status.FilteredJobs == nil {
+			if err := reportFilteredJobs(ctx, r.githubClient, owner, repoName, revision, filtered); err != nil {
+				_ = WriteStatus(ev, status)
+				return err
+			}
+			status.FilteredJobs = enumerable.Map(filtered, func(j *config.JobV2) string { return j.Name })
+		}
+		tasks, err := dispatchBuilds(ctx, r.builder, owner, repoName, repo, jobs, conf.BazelVersion, revision, "pull_request", false, config.SourceRef{
+			Branch:      event.GetPullRequest().GetHead().GetRef(),
+			PullRequest: event.GetPullRequest().GetNumber(),
+		})
 		// Checkpoint the created task ids even on a partial failure so a retry
 		// resumes instead of dispatching the same jobs again.
 		if ids := TaskIDs(tasks); len(ids) > 0 {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v85/github"
@@ -12,6 +13,7 @@ import (
 	"go.f110.dev/mono/go/build/config"
 	"go.f110.dev/mono/go/build/database"
 	"go.f110.dev/mono/go/build/database/dao"
+	"go.f110.dev/mono/go/enumerable"
 	"go.f110.dev/mono/go/git"
 	"go.f110.dev/mono/go/logger/slogger"
 )
@@ -152,8 +154,15 @@ func (r *PushReconciler) Reconcile(ctx context.Context, ev *database.GithubEvent
 	}
 
 	if status.DispatchedTaskIDs == nil {
-		jobs := conf.Job(config.EventPush)
-		tasks, err := dispatchBuilds(ctx, r.builder, owner, repoName, repo, jobs, conf.BazelVersion, revision, "push", true)
+		jobs, filtered := selectJobsByChangedFiles(ctx, r.githubClient, r.gitDataClient, owner, repoName, conf.Job(config.EventPush), event.GetBefore(), revision)
+		if len(filtered) > 0 && status.FilteredJobs == nil {
+			if err := reportFilteredJobs(ctx, r.githubClient, owner, repoName, revision, filtered); err != nil {
+				_ = WriteStatus(ev, &status)
+				return err
+			}
+			status.FilteredJobs = enumerable.Map(filtered, func(j *config.JobV2) string { return j.Name })
+		}
+		tasks, err := dispatchBuilds(ctx, r.builder, owner, repoName, repo, jobs, conf.BazelVersion, revision, "push", true, config.SourceRef{Branch: strings.TrimPrefix(event.GetRef(), "refs/heads/")})
 		// Checkpoint the created task ids even on a partial failure so a retry
 		// resumes instead of dispatching the same jobs again.
 		if ids := TaskIDs(tasks); len(ids) > 0 {
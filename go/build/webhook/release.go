@@ -93,7 +93,7 @@ func (r *ReleaseReconciler) Reconcile(ctx context.Context, ev *database.GithubEv
 
 	if status.DispatchedTaskIDs == nil {
 		jobs := conf.Job(config.EventRelease)
-		tasks, err := dispatchBuilds(ctx, r.builder, owner, repoName, repo, jobs, conf.BazelVersion, revision, "release", false)
+		tasks, err := dispatchBuilds(ctx, r.builder, owner, repoName, repo, jobs, conf.BazelVersion, revision, "release", false, config.SourceRef{})
 		// Checkpoint the created task ids even on a partial failure so a retry
 		// resumes instead of dispatching the same jobs again.
 		if ids := TaskIDs(tasks); len(ids) > 0 {
@@ -13,18 +13,20 @@ type PushStatus struct {
 	JobsReconciledAt     *time.Time `json:"jobs_reconciled_at,omitempty"`
 	GitSyncedAt          *time.Time `json:"git_synced_at,omitempty"`
 	GitSyncError         string     `json:"git_sync_error,omitempty"`
+	FilteredJobs         []string   `json:"filtered_jobs,omitempty"`
 	DispatchedTaskIDs    []int32    `json:"dispatched_task_ids,omitempty"`
 }
 
 // PullRequestStatus is the progress checkpoint for a `pull_request` event.
 type PullRequestStatus struct {
-	Skipped           bool    `json:"skipped,omitempty"`
-	SkipReason        string  `json:"skip_reason,omitempty"`
-	NotAllowed        bool    `json:"not_allowed,omitempty"`
-	CommentPosted     bool    `json:"comment_posted,omitempty"`
-	DispatchedTaskIDs []int32 `json:"dispatched_task_ids,omitempty"`
-	ConfigValidated   bool    `json:"config_validated,omitempty"`
-	PermitDeletedId   int32   `json:"permit_deleted_id,omitempty"`
+	Skipped           bool     `json:"skipped,omitempty"`
+	SkipReason        string   `json:"skip_reason,omitempty"`
+	NotAllowed        bool     `json:"not_allowed,omitempty"`
+	CommentPosted     bool     `json:"comment_posted,omitempty"`
+	FilteredJobs      []string `json:"filtered_jobs,omitempty"`
+	DispatchedTaskIDs []int32  `json:"dispatched_task_ids,omitempty"`
+	ConfigValidated   bool     `json:"config_validated,omitempty"`
+	PermitDeletedId   int32    `json:"permit_deleted_id,omitempty"`
 }
 
 // ReleaseStatus is the progress checkpoint for a `release` event.
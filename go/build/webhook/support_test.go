@@ -25,6 +25,7 @@ type testDAO struct {
 	GithubEvent            *daotest.GithubEvent
 	ExternalReleaseTrigger *daotest.ExternalReleaseTrigger
 	Job                    *daotest.Job
+	PolledReference        *daotest.PolledReference
 }
 
 func newTestDAO() *testDAO {
@@ -36,6 +37,7 @@ func newTestDAO() *testDAO {
 		GithubEvent:            daotest.NewGithubEvent(),
 		ExternalReleaseTrigger: daotest.NewExternalReleaseTrigger(),
 		Job:                    daotest.NewJob(),
+		PolledReference:        daotest.NewPolledReference(),
 	}
 }
 
@@ -48,6 +50,7 @@ func (d *testDAO) toOptions() dao.Options {
 		GithubEvent:            d.GithubEvent,
 		ExternalReleaseTrigger: d.ExternalReleaseTrigger,
 		Job:                    d.Job,
+		PolledReference:        d.PolledReference,
 	}
 }
 
@@ -64,7 +67,7 @@ type recBuilder struct {
 
 var _ Builder = (*recBuilder)(nil)
 
-func (m *recBuilder) Build(_ context.Context, _ *database.SourceRepository, job *config.JobV2, _, _, _ string, _, _ []string, _ string, _ bool) ([]*database.Task, error) {
+func (m *recBuilder) Build(_ context.Context, _ *database.SourceRepository, job *config.JobV2, _, _, _ string, _, _ []string, _ string, _ bool, _ config.SourceRef) ([]*database.Task, error) {
 	m.called = true
 	m.jobNames = append(m.jobNames, job.Name)
 	t := &database.Task{Id: int32(len(m.jobNames))}
@@ -35,7 +35,7 @@ const AllowCommand = "/allow-build"
 // coordinator.BazelBuilder needed by reconcilers and is declared here to avoid
 // an import cycle.
 type Builder interface {
-	Build(ctx context.Context, repo *database.SourceRepository, job *config.JobV2, revision, bazelVersion, command string, targets, platforms []string, via string, isMainBranch bool) ([]*database.Task, error)
+	Build(ctx context.Context, repo *database.SourceRepository, job *config.JobV2, revision, bazelVersion, command string, targets, platforms []string, via string, isMainBranch bool, ref config.SourceRef) ([]*database.Task, error)
 }
 
 // Reconciler processes a single GitHub event row to completion.
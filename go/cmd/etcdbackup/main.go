@@ -26,6 +26,7 @@ func etcdBackup(args []string) error {
 	var bucket string
 	var pathPrefix string
 	var credentialFile string
+	var partSize, retries int
 
 	fs := pflag.NewFlagSet("etcdbackup", pflag.ContinueOnError)
 	fs.StringArrayVar(&endpoints, "endpoints", []string{}, "Endpoints of etcd")
@@ -35,6 +36,8 @@ func etcdBackup(args []string) error {
 	fs.StringVar(&bucket, "bucket", "", "Bucket name")
 	fs.StringVar(&pathPrefix, "path-prefix", "", "Prefix")
 	fs.StringVar(&credentialFile, "credential", "", "Credential file")
+	fs.IntVar(&partSize, "part-size", 16*1024*1024, "The size of each chunk of the upload")
+	fs.IntVar(&retries, "retries", 3, "The number of attempts for each chunk")
 	logger.Flags(fs)
 	if err := fs.Parse(args); err != nil {
 		return xerrors.WithStack(err)
@@ -80,7 +83,7 @@ func etcdBackup(args []string) error {
 		return xerrors.WithStack(err)
 	}
 
-	up := storage.NewGCS(credential, bucket, storage.GCSOptions{})
+	up := storage.NewGCS(credential, bucket, storage.GCSOptions{Retries: retries, PartSize: partSize})
 	path := filepath.Join(pathPrefix, bu.Time().In(loc).Format("2006-01-02_15.zlib"))
 	if err := up.PutReader(context.Background(), path, compressed); err != nil {
 		return xerrors.WithStack(err)
@@ -28,8 +28,10 @@ import (
 type gitDataServiceCommand struct {
 	*fsm.FSM
 	grpcServer    *grpc.Server
+	dataService   *git.DataService
 	updater       *git.Updater
 	webhookServer *http.Server
+	httpServer    *http.Server
 	packCache     *git.PackfileCache
 
 	Listen                string
@@ -42,12 +44,16 @@ type gitDataServiceCommand struct {
 	StorageSecretAccessKey     string
 	StorageSecretAccessKeyFile string
 	StorageCAFile              string
+	StorageDir                 string
 	MemcachedEndpoint          string
 	ListenWebhookReceiver      string
+	ListenHTTP                 string
 
 	Bucket string
 
 	Repositories           []string
+	RepositoryCredentials  []string
+	WebhookSecrets         []string
 	LockFilePath           string
 	RefreshInterval        time.Duration
 	RefreshTimeout         time.Duration
@@ -57,6 +63,12 @@ type gitDataServiceCommand struct {
 	PackfileCacheSize int64
 	PackfileCacheTTL  time.Duration
 
+	MaintenanceInterval time.Duration
+	PruneGracePeriod    time.Duration
+	SmallPackSize       int64
+
+	ReferenceJournalSyncInterval time.Duration
+
 	repositories []*git.RepositoryConfig
 }
 
@@ -93,13 +105,27 @@ func (c *gitDataServiceCommand) Flags(fs *cli.FlagSet) {
 	fs.String("storage-secret-access-key", "The secret access key for the object storage").Var(&c.StorageSecretAccessKey)
 	fs.String("storage-secret-access-key-file", "The file path that containing the secret access key for the object storage").Var(&c.StorageSecretAccessKeyFile)
 	fs.String("storage-ca-file", "File path that contains CA certificate").Var(&c.StorageCAFile)
+	fs.String("storage-dir", "The directory which stores the objects instead of the object storage. If set the value, the options for the object storage are ignored.").Var(&c.StorageDir)
 	fs.String("memcached-endpoint", "The endpoint of memcached").Var(&c.MemcachedEndpoint)
 	fs.String("listen-webhook-receiver", "Listen addr of webhook receiver.").Var(&c.ListenWebhookReceiver)
+	fs.String("listen-http", "Listen addr of git smart HTTP. If not set the value, git smart HTTP is disabled.").Var(&c.ListenHTTP)
 
 	fs.StringArray("repository", "The repository name that will be served."+
 		"The value consists three elements separated by a vertical bar. The first element is the repository name. "+
 		"The second element is a url for the repository. "+
 		"The third element is a prefix in an object storage. (e.g. go|https://github.com/golang/go.git|golang/go)").Var(&c.Repositories)
+	fs.StringArray("repository-credential", "The credential for fetching the repository. "+
+		"The value consists the elements separated by a vertical bar. The first element is the repository name. The second element is the type of the credential. "+
+		"token: The token in the file is used as the password (e.g. gitea|token|/etc/gitea/token or gitlab|token|/etc/gitlab/token|oauth2). "+
+		"basic: The file contains username:password (e.g. gitea|basic|/etc/gitea/credential). "+
+		"ssh: The private key and known_hosts (e.g. gitea|ssh|/etc/ssh/id_ed25519|/etc/ssh/known_hosts). "+
+		"none: No credential is sent (e.g. gitea|none). "+
+		"github: The token of GitHub is used for GitHub Enterprise Server (e.g. ghe|github). "+
+		"If not set the value, the token of GitHub is used for github.com and no credential is sent to the other host.").Var(&c.RepositoryCredentials)
+	fs.StringArray("webhook-secret", "The secret for verifying the webhook of the host. "+
+		"The value consists three elements separated by a vertical bar. The first element is the hostname. "+
+		"The second element is the provider (github, gitea or gitlab). "+
+		"The third element is the file path that contains the secret. (e.g. gitea.example.com|gitea|/etc/gitea/webhook-secret)").Var(&c.WebhookSecrets)
 	fs.String("lock-file-path", "The path of the lock file.  If not set the value, don't get the lock").Var(&c.LockFilePath)
 	fs.Duration("refresh-interval", "The interval time for updating the repository"+
 		"If set zero, interval updating is disabled.").Var(&c.RefreshInterval)
@@ -111,6 +137,14 @@ func (c *gitDataServiceCommand) Flags(fs *cli.FlagSet) {
 		"If set zero, the in-memory packfile cache is disabled.").Var(&c.PackfileCacheSize)
 	fs.Duration("packfile-cache-ttl", "TTL for in-memory cached packfiles").Var(&c.PackfileCacheTTL).Default(10 * time.Minute)
 
+	fs.Duration("maintenance-interval", "The interval time for the maintenance of the repositories. "+
+		"If set zero, the periodic maintenance is disabled.").Var(&c.MaintenanceInterval)
+	fs.Duration("prune-grace-period", "Unreachable objects newer than this period are not pruned by the maintenance").Var(&c.PruneGracePeriod).Default(git.DefaultPruneGracePeriod)
+	fs.Int64("small-pack-size", "Packfiles smaller than this size are consolidated by the maintenance").Var(&c.SmallPackSize).Default(git.DefaultSmallPackSize)
+
+	fs.Duration("reference-journal-sync-interval", "The interval time for reading the updates of the references "+
+		"which are recorded by the other replica").Var(&c.ReferenceJournalSyncInterval).Default(git.DefaultReferenceJournalSyncInterval)
+
 	fs.Duration("repository-init-timeout", "The duration for timeout to initializing repository").Var(&c.RepositoryInitTimeout).Default(5 * time.Minute)
 }
 
@@ -128,26 +162,137 @@ func (c *gitDataServiceCommand) ValidateFlagValue() error {
 	}
 	c.repositories = repositories
 
+	for _, v := range c.RepositoryCredentials {
+		s := strings.Split(v, "|")
+		var repo *git.RepositoryConfig
+		for _, r := range repositories {
+			if r.Name == s[0] {
+				repo = r
+				break
+			}
+		}
+		if repo == nil {
+			return xerrors.Definef("--repository-credential=%s: repository %s is not found", v, s[0]).WithStack()
+		}
+		cred, err := parseCredential(s[1:])
+		if err != nil {
+			return xerrors.WithMessagef(err, "--repository-credential=%s is invalid", v)
+		}
+		repo.Credential = cred
+	}
+	for _, v := range c.WebhookSecrets {
+		s := strings.Split(v, "|")
+		if len(s) != 3 {
+			return xerrors.Definef("--webhook-secret=%s is invalid", v).WithStack()
+		}
+		switch git.WebhookProvider(s[1]) {
+		case git.WebhookProviderGitHub, git.WebhookProviderGitea, git.WebhookProviderGitLab:
+		default:
+			return xerrors.Definef("--webhook-secret=%s: unknown provider %s", v, s[1]).WithStack()
+		}
+	}
+
 	return nil
 }
 
-func (c *gitDataServiceCommand) init(ctx context.Context) (fsm.State, error) {
-	if err := c.GitHubClient.Init(); err != nil {
-		return fsm.Error(err)
+func parseCredential(s []string) (git.CredentialProvider, error) {
+	if len(s) == 0 {
+		return nil, xerrors.Define("the type of the credential is required").WithStack()
+	}
+	switch s[0] {
+	case "token":
+		if len(s) != 2 && len(s) != 3 {
+			return nil, xerrors.Define("token requires the file path").WithStack()
+		}
+		b, err := os.ReadFile(s[1])
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		cred := &git.TokenCredential{Token: strings.TrimSpace(string(b))}
+		if len(s) == 3 {
+			cred.Username = s[2]
+		}
+		return cred, nil
+	case "basic":
+		if len(s) != 2 {
+			return nil, xerrors.Define("basic requires the file path").WithStack()
+		}
+		return &git.BasicAuthFileCredential{Path: s[1]}, nil
+	case "ssh":
+		if len(s) != 3 {
+			return nil, xerrors.Define("ssh requires the private key and known_hosts").WithStack()
+		}
+		return &git.SSHKeyCredential{PrivateKeyFile: s[1], KnownHostsFile: s[2]}, nil
+	case "none":
+		return git.AnonymousCredential{}, nil
+	case "github":
+		return &git.GitHubAppCredential{}, nil
+	default:
+		return nil, xerrors.Definef("unknown credential type: %s", s[0]).WithStack()
+	}
+}
+
+func (c *gitDataServiceCommand) newStorageClient() (storage.Backend, error) {
+	if c.StorageDir != "" {
+		l, err := storage.NewLocal(c.StorageDir)
+		if err != nil {
+			return nil, err
+		}
+		return l, nil
 	}
 
 	secretAccessKey := c.StorageSecretAccessKey
 	if c.StorageSecretAccessKeyFile != "" {
 		b, err := os.ReadFile(c.StorageSecretAccessKeyFile)
 		if err != nil {
-			return fsm.Error(err)
+			return nil, xerrors.WithStack(err)
 		}
 		secretAccessKey = strings.TrimSpace(string(b))
 	}
 	opt := storage.NewS3OptionToExternal(c.StorageEndpoint, c.StorageRegion, c.StorageAccessKey, secretAccessKey)
 	opt.PathStyle = true
 	opt.CACertFile = c.StorageCAFile
-	storageClient := storage.NewS3(c.Bucket, opt)
+	return storage.NewS3(c.Bucket, opt), nil
+}
+
+func (c *gitDataServiceCommand) maintenanceOptions() git.MaintenanceOptions {
+	return git.MaintenanceOptions{PruneGracePeriod: c.PruneGracePeriod, SmallPackSize: c.SmallPackSize}
+}
+
+// Maintain runs the maintenance of the repositories once. It fails if the running git-data-service holds
+// the lock of the updater, so it doesn't run concurrently with the updater. The running git-data-service
+// maintains the repositories by itself if --maintenance-interval is set.
+func (c *gitDataServiceCommand) Maintain(ctx context.Context) error {
+	if err := c.GitHubClient.Init(); err != nil {
+		return err
+	}
+	storageClient, err := c.newStorageClient()
+	if err != nil {
+		return err
+	}
+	for _, v := range c.repositories {
+		// The packfiles are not inflated since the maintenance packs the objects again.
+		if err := v.Open(ctx, storageClient, nil, nil, c.GitHubClient.TokenProvider, c.RepositoryInitTimeout, true); err != nil {
+			return err
+		}
+	}
+	u, err := git.NewUpdater(storageClient, c.GitHubClient.TokenProvider, c.repositories, c.LockFilePath, c.RefreshWorkers)
+	if err != nil {
+		return err
+	}
+	u.SetMaintenance(0, c.maintenanceOptions())
+	return u.Maintain(ctx)
+}
+
+func (c *gitDataServiceCommand) init(ctx context.Context) (fsm.State, error) {
+	if err := c.GitHubClient.Init(); err != nil {
+		return fsm.Error(err)
+	}
+
+	storageClient, err := c.newStorageClient()
+	if err != nil {
+		return fsm.Error(err)
+	}
 
 	var cachePool *client.SinglePool
 	if c.MemcachedEndpoint != "" {
@@ -177,12 +322,23 @@ func (c *gitDataServiceCommand) init(ctx context.Context) (fsm.State, error) {
 	if err != nil {
 		return fsm.Error(err)
 	}
+	service.SetCachePool(cachePool)
+	if err := service.LoadReferenceJournal(ctx, storageClient); err != nil {
+		return fsm.Error(err)
+	}
+	c.dataService = service
 	s := grpc.NewServer()
 	git.RegisterGitDataServer(s, service)
 	healthSvc := health.NewServer()
 	healthSvc.SetServingStatus("git-data", healthpb.HealthCheckResponse_SERVING)
 	healthpb.RegisterHealthServer(s, healthSvc)
 	c.grpcServer = s
+	if c.ListenHTTP != "" {
+		c.httpServer = &http.Server{
+			Addr:    c.ListenHTTP,
+			Handler: git.NewSmartHTTPServer(service),
+		}
+	}
 
 	if c.RefreshInterval > 0 {
 		u, err := git.NewUpdater(storageClient, c.GitHubClient.TokenProvider, c.repositories, c.LockFilePath, c.RefreshWorkers)
@@ -195,7 +351,16 @@ func (c *gitDataServiceCommand) init(ctx context.Context) (fsm.State, error) {
 			SetPackCache(c.packCache).
 			SetInitTimeout(c.RepositoryInitTimeout).
 			SetDisableInflatePackFile(c.DisableInflatePackFile).
+			SetMaintenance(c.MaintenanceInterval, c.maintenanceOptions()).
 			SetDataService(service)
+		for _, v := range c.WebhookSecrets {
+			s := strings.Split(v, "|")
+			secret, err := os.ReadFile(s[2])
+			if err != nil {
+				return fsm.Error(xerrors.WithStack(err))
+			}
+			u.AddWebhookSecret(s[0], git.WebhookProvider(s[1]), []byte(strings.TrimSpace(string(secret))))
+		}
 		c.updater = u
 	}
 
@@ -214,6 +379,17 @@ func (c *gitDataServiceCommand) startService(ctx context.Context) (fsm.State, er
 		}
 	}()
 
+	if c.httpServer != nil {
+		slogger.Log.Info("Start git smart HTTP", slog.String("addr", c.ListenHTTP))
+		go func() {
+			if err := c.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				slogger.Log.Info("Stop git smart HTTP", slogger.E(err))
+			}
+		}()
+	}
+
+	go c.dataService.SyncReferenceJournal(ctx, c.ReferenceJournalSyncInterval)
+
 	if c.updater != nil {
 		go c.updater.Run(ctx)
 
@@ -243,6 +419,9 @@ func (c *gitDataServiceCommand) shuttingDown(ctx context.Context) (fsm.State, er
 	if c.webhookServer != nil {
 		c.webhookServer.Shutdown(ctx)
 	}
+	if c.httpServer != nil {
+		c.httpServer.Shutdown(ctx)
+	}
 	if c.packCache != nil {
 		c.packCache.Close()
 	}
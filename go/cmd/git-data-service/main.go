@@ -22,6 +22,18 @@ func runCommand() error {
 	c.Flags(cmd.Flags())
 	c.GitHubClient.Flags(cmd.Flags())
 
+	maintenanceCmd := &cli.Command{
+		Use:   "maintenance",
+		Short: "Consolidate the objects and prune the unreachable objects of the repositories",
+		Run: func(ctx context.Context, _ *cli.Command, _ []string) error {
+			if err := c.ValidateFlagValue(); err != nil {
+				return err
+			}
+			return c.Maintain(ctx)
+		},
+	}
+	cmd.AddCommand(maintenanceCmd)
+
 	return cmd.Execute(os.Args)
 }
 
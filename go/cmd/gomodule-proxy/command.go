@@ -13,6 +13,8 @@ import (
 	"time"
 
 	"go.f110.dev/go-memcached/client"
+	"go.f110.dev/xerrors"
+	"golang.org/x/mod/sumdb/note"
 
 	"go.f110.dev/mono/go/cli"
 	"go.f110.dev/mono/go/ctxutil"
@@ -20,6 +22,7 @@ import (
 	"go.f110.dev/mono/go/githubutil"
 	"go.f110.dev/mono/go/gomodule"
 	"go.f110.dev/mono/go/logger/slogger"
+	"go.f110.dev/mono/go/storage"
 )
 
 type goModuleProxyCommand struct {
@@ -42,6 +45,11 @@ type goModuleProxyCommand struct {
 
 	MemcachedServers []string
 
+	SumDBURL                 string
+	SumDBKey                 string
+	ChecksumDBSigningKeyFile string
+	ChecksumDBStorageDir     string
+
 	upstream   *url.URL
 	config     gomodule.Config
 	cache      *gomodule.ModuleCache
@@ -62,6 +70,8 @@ func newGoModuleProxyCommand() *goModuleProxyCommand {
 	c := &goModuleProxyCommand{
 		Addr:                ":7589",
 		UpstreamURL:         "https://proxy.golang.org",
+		SumDBURL:            gomodule.DefaultSumDBURL,
+		SumDBKey:            gomodule.DefaultSumDBKey,
 		githubClientFactory: githubutil.NewGitHubClientFactory("gomodule-proxy", false),
 	}
 	c.FSM = fsm.NewFSM(
@@ -93,6 +103,10 @@ func (c *goModuleProxyCommand) Flags(fs *cli.FlagSet) {
 	fs.String("storage-ca-file", "File path that contains the certificate of CA").Var(&c.StorageCACertFile).Default(c.StorageCACertFile)
 	fs.StringArray("memcached-servers", "Memcached server name and address for the metadata cache").Var(&c.MemcachedServers)
 	fs.String("signing-key-file", "A file path that contains signing key").Var(&c.SigningKeyFile)
+	fs.String("sumdb-url", "The URL of the upstream checksum database. If empty, the checksum database is not proxied").Var(&c.SumDBURL).Default(c.SumDBURL)
+	fs.String("sumdb-key", "The verifier key of the upstream checksum database").Var(&c.SumDBKey).Default(c.SumDBKey)
+	fs.String("checksum-db-signing-key-file", "A file path that contains the signer key of the checksum database for the proxied modules").Var(&c.ChecksumDBSigningKeyFile)
+	fs.String("checksum-db-storage-dir", "The directory which stores the checksum database instead of the object storage").Var(&c.ChecksumDBStorageDir)
 	fs.Bool("remove-bazel-file", "Remove bazel related files. CAUTION: This flag may cause a checksum mismatch").Var(&c.RemoveBazelFile)
 
 	c.githubClientFactory.Flags(fs)
@@ -127,6 +141,7 @@ func (c *goModuleProxyCommand) init(_ context.Context) (fsm.State, error) {
 		c.caBundle = b
 	}
 
+	var proxyOpts []gomodule.ProxyServerOption
 	if c.StorageEndpoint != "" && c.StorageRegion != "" &&
 		c.StorageBucket != "" && c.StorageAccessKey != "" && c.StorageSecretAccessKey != "" && len(c.MemcachedServers) > 0 {
 		var servers []client.Server
@@ -143,11 +158,13 @@ func (c *goModuleProxyCommand) init(_ context.Context) (fsm.State, error) {
 			return fsm.Error(err)
 		}
 		c.cache = gomodule.NewModuleCache(cachePool, c.StorageEndpoint, c.StorageRegion, c.StorageBucket, c.StorageAccessKey, c.StorageSecretAccessKey, c.StorageCACertFile)
+		// The files of the public modules are stored to the same bucket. So the proxy can serve them even if
+		// the upstream is down.
+		proxyOpts = append(proxyOpts, gomodule.WithModuleMirror(gomodule.NewModuleMirror(c.upstream, c.cache)))
 	} else {
 		slogger.Log.Debug("Disable cache")
 	}
 
-	var proxyOpts []gomodule.ProxyServerOption
 	if c.SigningKeyFile != "" {
 		buf, err := os.ReadFile(c.SigningKeyFile)
 		if err != nil {
@@ -167,12 +184,66 @@ func (c *goModuleProxyCommand) init(_ context.Context) (fsm.State, error) {
 		moduleProxyOpts = append(moduleProxyOpts, gomodule.RemoveBazelFiles(true))
 	}
 
+	var checksumDB *gomodule.ChecksumDatabase
+	if c.ChecksumDBSigningKeyFile != "" {
+		db, err := c.newChecksumDatabase()
+		if err != nil {
+			return fsm.Error(err)
+		}
+		checksumDB = db
+		moduleProxyOpts = append(moduleProxyOpts, gomodule.WithChecksumDatabase(db))
+	}
+
+	if c.SumDBURL != "" {
+		u, err := url.Parse(c.SumDBURL)
+		if err != nil {
+			return fsm.Error(err)
+		}
+		sumDBProxy := gomodule.NewSumDBProxy(u, c.SumDBKey, c.cache)
+		proxyOpts = append(proxyOpts, gomodule.WithSumDBProxy(sumDBProxy))
+		if checksumDB != nil {
+			// The public modules are also recorded to the checksum database after verifying by the upstream.
+			// So the client can use one GOSUMDB for all modules.
+			checksumDB.AddSource(sumDBProxy)
+		}
+	}
+
 	proxy := gomodule.NewModuleProxy(c.config, c.ModuleDir, c.cache, c.githubClientFactory.REST, c.githubClientFactory.TokenProvider, c.caBundle, moduleProxyOpts...)
 	c.server = gomodule.NewProxyServer(c.Addr, c.upstream, proxy, c.githubClientFactory, proxyOpts...)
 
 	return fsm.Next(stateStartServer)
 }
 
+func (c *goModuleProxyCommand) newChecksumDatabase() (*gomodule.ChecksumDatabase, error) {
+	key, err := os.ReadFile(c.ChecksumDBSigningKeyFile)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	signer, err := note.NewSigner(strings.TrimSpace(string(key)))
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	var backend storage.Backend
+	switch {
+	case c.ChecksumDBStorageDir != "":
+		l, err := storage.NewLocal(c.ChecksumDBStorageDir)
+		if err != nil {
+			return nil, err
+		}
+		backend = l
+	case c.StorageEndpoint != "" && c.StorageBucket != "":
+		opt := storage.NewS3OptionToExternal(c.StorageEndpoint, c.StorageRegion, c.StorageAccessKey, c.StorageSecretAccessKey)
+		opt.PathStyle = true
+		opt.CACertFile = c.StorageCACertFile
+		backend = storage.NewS3(c.StorageBucket, opt)
+	default:
+		return nil, xerrors.Define("the checksum database requires --checksum-db-storage-dir or the object storage").WithStack()
+	}
+
+	return gomodule.NewChecksumDatabase(signer, backend), nil
+}
+
 func (c *goModuleProxyCommand) startServer(_ context.Context) (fsm.State, error) {
 	go func() {
 		if err := c.server.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
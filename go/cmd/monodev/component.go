@@ -24,6 +24,7 @@ import (
 	gitHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	_ "github.com/go-sql-driver/mysql"
 	"go.f110.dev/go-memcached/client"
+	"go.f110.dev/xerrors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
@@ -59,6 +60,12 @@ var minio = &simpleCommandComponent{
 	WithoutLogPrefix: true,
 }
 
+// objectStorage is the object storage for the services. The objects are stored in MinIO or the local directory.
+var objectStorage = &objectStorageComponent{
+	MinIO: minio,
+	Dir:   filepath.Join(os.Getenv("BUILD_WORKING_DIRECTORY"), ".storage_data"),
+}
+
 var etcd = &simpleCommandComponent{
 	Name:          "etcd",
 	Args:          []string{"--data-dir", filepath.Join(os.Getenv("BUILD_WORKING_DIRECTORY"), ".etcd_data")},
@@ -69,18 +76,15 @@ var etcd = &simpleCommandComponent{
 var gitDataService = &grpcServerComponent{
 	Name:   "git-data-service",
 	Listen: 9010,
-	Deps:   []component{minio, memcached, gitDataServiceBucket, kepData},
+	Deps:   []component{objectStorage, memcached, gitDataServiceBucket, kepData},
 	Register: func(ctx context.Context, s *grpc.Server) {
 		repositories := []*bucketData{kepData}
 
-		opt := storage.NewS3OptionToExternal(
-			fmt.Sprintf("http://127.0.0.1:%d", minio.Ports.GetNumber("minio")),
-			"US",
-			"minioadmin",
-			"minioadmin",
-		)
-		opt.PathStyle = true
-		storageClient := storage.NewS3("git-data-service", opt)
+		storageClient, err := objectStorage.Backend("git-data-service")
+		if err != nil {
+			slogger.Log.Error("Failed to create the backend", slogger.E(err))
+			return
+		}
 
 		memcachedServer, err := client.NewServerWithMetaProtocol(
 			ctx,
@@ -118,16 +122,13 @@ var gitDataService = &grpcServerComponent{
 var docSearchService = &grpcServerComponent{
 	Name:   "doc-search-service",
 	Listen: 9011,
-	Deps:   []component{minio, gitDataService},
+	Deps:   []component{objectStorage, gitDataService},
 	Register: func(ctx context.Context, s *grpc.Server) {
-		opt := storage.NewS3OptionToExternal(
-			fmt.Sprintf("http://127.0.0.1:%d", minio.Ports.GetNumber("minio")),
-			"US",
-			"minioadmin",
-			"minioadmin",
-		)
-		opt.PathStyle = true
-		storageClient := storage.NewS3("git-data-service", opt)
+		storageClient, err := objectStorage.Backend("git-data-service")
+		if err != nil {
+			slogger.Log.Error("Failed to create the backend", slogger.E(err))
+			return
+		}
 
 		grpcConn, err := grpc.Dial(fmt.Sprintf("127.0.0.1:%d", gitDataService.Listen),
 			grpc.WithTransportCredentials(insecure.NewCredentials()),
@@ -158,13 +159,13 @@ var kepRepository = &gitDataDirectory{
 var gitDataServiceBucket = &minioBucket{
 	Name:     "git-data-service",
 	Bucket:   "git-data-service",
-	Instance: minio,
+	Instance: objectStorage,
 }
 
 var kepData = &bucketData{
 	Name:     "kep",
 	Prefix:   "kep",
-	Instance: minio,
+	Instance: objectStorage,
 	Data:     kepRepository,
 }
 
@@ -417,6 +418,75 @@ func (c *gitDataDirectory) OutputDir() string {
 	return filepath.Join(os.Getenv("BUILD_WORKING_DIRECTORY"), c.Dir, ".git")
 }
 
+type objectStorageComponent struct {
+	MinIO *simpleCommandComponent
+	// Dir is the directory for the objects if Local is true. Each bucket is the subdirectory.
+	Dir   string
+	Local bool
+}
+
+var _ component = &objectStorageComponent{}
+
+func (c *objectStorageComponent) GetName() string {
+	return "object-storage"
+}
+
+func (c *objectStorageComponent) GetType() componentType {
+	return componentTypeOneshot
+}
+
+func (c *objectStorageComponent) GetDeps() []component {
+	if c.Local {
+		return nil
+	}
+	return []component{c.MinIO}
+}
+
+func (c *objectStorageComponent) Run(_ context.Context) {
+	if c.Local {
+		slogger.Log.Info("Use the local directory as the object storage", slog.String("dir", c.Dir))
+	}
+}
+
+func (c *objectStorageComponent) Flags(fs *cli.FlagSet) {
+	fs.Bool("local-storage", "Store the objects in the local directory instead of MinIO").Var(&c.Local)
+}
+
+func (c *objectStorageComponent) Backend(bucket string) (storage.Backend, error) {
+	if c.Local {
+		l, err := storage.NewLocal(filepath.Join(c.Dir, bucket))
+		if err != nil {
+			return nil, err
+		}
+		return l, nil
+	}
+	return newMinIOBackend(c.MinIO, bucket), nil
+}
+
+// newBucketBackend returns the backend of the bucket. instance is MinIO or objectStorage.
+func newBucketBackend(instance component, bucket string) (storage.Backend, error) {
+	switch v := instance.(type) {
+	case *objectStorageComponent:
+		return v.Backend(bucket)
+	case *simpleCommandComponent:
+		if v.GetName() == "minio" {
+			return newMinIOBackend(v, bucket), nil
+		}
+	}
+	return nil, xerrors.Definef("%s is not the object storage", instance.GetName()).WithStack()
+}
+
+func newMinIOBackend(c *simpleCommandComponent, bucket string) *storage.S3 {
+	opt := storage.NewS3OptionToExternal(
+		fmt.Sprintf("http://127.0.0.1:%d", c.Ports.GetNumber("minio")),
+		"US",
+		"minioadmin",
+		"minioadmin",
+	)
+	opt.PathStyle = true
+	return storage.NewS3(bucket, opt)
+}
+
 type minioBucket struct {
 	Name     string
 	Bucket   string
@@ -434,20 +504,16 @@ func (c *minioBucket) GetType() componentType {
 }
 
 func (c *minioBucket) Run(ctx context.Context) {
-	if c.Instance.GetName() != "minio" {
-		slogger.Log.Error("instance is not MinIO")
+	backend, err := newBucketBackend(c.Instance, c.Bucket)
+	if err != nil {
+		slogger.Log.Error("Failed to create the backend", slogger.E(err))
+		return
+	}
+	storageClient, ok := backend.(*storage.S3)
+	if !ok {
+		// The directory of the bucket is created by the backend.
 		return
 	}
-
-	port := c.Instance.(*simpleCommandComponent).Ports.GetNumber("minio")
-	opt := storage.NewS3OptionToExternal(
-		fmt.Sprintf("http://127.0.0.1:%d", port),
-		"US",
-		"minioadmin",
-		"minioadmin",
-	)
-	opt.PathStyle = true
-	storageClient := storage.NewS3(c.Bucket, opt)
 
 	if storageClient.ExistBucket(ctx, c.Bucket) {
 		slogger.Log.Info("the bucket is found")
@@ -486,21 +552,12 @@ func (c *bucketData) GetDeps() []component {
 }
 
 func (c *bucketData) Run(ctx context.Context) {
-	if c.Instance.GetName() != "minio" {
-		slogger.Log.Error("instance is not MinIO")
+	storageClient, err := newBucketBackend(c.Instance, c.Name)
+	if err != nil {
+		slogger.Log.Error("Failed to create the backend", slogger.E(err))
 		return
 	}
 
-	port := c.Instance.(*simpleCommandComponent).Ports.GetNumber("minio")
-	opt := storage.NewS3OptionToExternal(
-		fmt.Sprintf("http://127.0.0.1:%d", port),
-		"US",
-		"minioadmin",
-		"minioadmin",
-	)
-	opt.PathStyle = true
-	storageClient := storage.NewS3(c.Name, opt)
-
 	var dir string
 	if x, ok := c.Data.(interface{ OutputDir() string }); ok {
 		dir = x.OutputDir()
@@ -511,7 +568,7 @@ func (c *bucketData) Run(ctx context.Context) {
 	}
 
 	slogger.Log.Debug("Walk directory", slog.String("path", dir))
-	err := filepath.Walk(dir, func(p string, info fs.FileInfo, err error) error {
+	err = filepath.Walk(dir, func(p string, info fs.FileInfo, err error) error {
 		if info.IsDir() {
 			return nil
 		}
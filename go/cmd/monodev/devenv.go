@@ -29,7 +29,7 @@ func goModuleProxy(cmd *cli.Command) {
 func repoDoc(cmd *cli.Command) {
 	repoDocCmd := &cli.Command{
 		Use:   "repo-doc",
-		Short: "Start minio and git-data-service",
+		Short: "Start minio (or the local directory) and git-data-service",
 		Run: func(ctx context.Context, _ *cli.Command, _ []string) error {
 			m := newComponentManager()
 			m.AddComponent(docSearchService)
@@ -37,6 +37,7 @@ func repoDoc(cmd *cli.Command) {
 			return m.Run(ctx)
 		},
 	}
+	objectStorage.Flags(repoDocCmd.Flags())
 
 	cmd.AddCommand(repoDocCmd)
 }
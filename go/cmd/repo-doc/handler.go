@@ -12,6 +12,8 @@ import (
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"go.f110.dev/xerrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"go.f110.dev/mono/go/docutil"
 	"go.f110.dev/mono/go/git"
@@ -78,6 +80,10 @@ func (h *httpHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		h.readiness(w, req)
 		return
 	}
+	if req.URL.Path == "/_/search" {
+		h.serveSearch(w, req)
+		return
+	}
 
 	if strings.Index(req.URL.Path, pathSeparator) == -1 {
 		if req.URL.Path == "/" {
@@ -158,6 +164,37 @@ func (h *httpHandler) serveRepositoryIndex(w http.ResponseWriter, req *http.Requ
 	h.renderer.RenderRepositories(w, repositories.Repositories)
 }
 
+func (h *httpHandler) serveSearch(w http.ResponseWriter, req *http.Request) {
+	query := req.URL.Query().Get("q")
+	repo := req.URL.Query().Get("repo")
+	pathPrefix := req.URL.Query().Get("path")
+
+	repositories, err := h.docSearch.ListRepository(req.Context(), &docutil.RequestListRepository{})
+	if err != nil {
+		slogger.Log.Error("Failed to get repositories", slogger.E(err))
+		http.Error(w, "Failed to get repositories", http.StatusInternalServerError)
+		return
+	}
+	sort.Slice(repositories.Repositories, func(i, j int) bool {
+		return repositories.Repositories[i].Name < repositories.Repositories[j].Name
+	})
+
+	var res *docutil.ResponseSearch
+	if query != "" {
+		res, err = h.docSearch.Search(req.Context(), &docutil.RequestSearch{Query: query, Repo: repo, PathPrefix: pathPrefix})
+		if status.Code(err) == codes.InvalidArgument {
+			// The query doesn't have any searchable term.
+			res = &docutil.ResponseSearch{}
+		} else if err != nil {
+			slogger.Log.Error("Failed to search", slogger.E(err))
+			http.Error(w, "Failed to search", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	h.renderer.RenderSearch(w, query, repo, pathPrefix, repositories.Repositories, res)
+}
+
 func (h *httpHandler) serveDocumentFile(ctx context.Context, w http.ResponseWriter, file *git.ResponseGetFile, repo *docutil.Repository, repoName, rawRef string, commit *git.Commit, blobPath string) {
 	var doc *document
 	switch filepath.Ext(blobPath) {
@@ -63,6 +63,19 @@ func TestServeHTTP(t *testing.T) {
 	assert.Equal(t, http.StatusOK, recorder.Code)
 }
 
+func TestServeSearch(t *testing.T) {
+	h, err := newHttpHandler(context.Background(), &stubGitDataClient{}, &stubDocSearchClient{}, "repo-doc", "", 0)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/_/search?q=world", nil)
+	h.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `<a class="header" href="/test/_/docs/README.md">Document title</a>`)
+	assert.Contains(t, recorder.Body.String(), "Hello <mark>&lt;World&gt;</mark>!")
+}
+
 type stubGitDataClient struct{}
 
 var _ git.GitDataClient = &stubGitDataClient{}
@@ -98,6 +111,31 @@ func (s *stubGitDataClient) GetCommit(ctx context.Context, in *git.RequestGetCom
 	}, nil
 }
 
+func (s *stubGitDataClient) ListCommits(ctx context.Context, in *git.RequestListCommits, opts ...grpc.CallOption) (*git.ResponseListCommits, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (s *stubGitDataClient) Compare(ctx context.Context, in *git.RequestCompare, opts ...grpc.CallOption) (*git.ResponseCompare, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (s *stubGitDataClient) GetBlame(ctx context.Context, in *git.RequestGetBlame, opts ...grpc.CallOption) (*git.ResponseGetBlame, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (s *stubGitDataClient) WatchReferences(ctx context.Context, in *git.RequestWatchReferences, opts ...grpc.CallOption) (git.GitData_WatchReferencesClient, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (s *stubGitDataClient) GetArchive(ctx context.Context, in *git.RequestGetArchive, opts ...grpc.CallOption) (git.GitData_GetArchiveClient, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (s *stubGitDataClient) GetTree(_ context.Context, in *git.RequestGetTree, opts ...grpc.CallOption) (*git.ResponseGetTree, error) {
 	return &git.ResponseGetTree{
 		Tree: []*git.TreeEntry{
@@ -170,3 +208,19 @@ func (s *stubDocSearchClient) PageLink(ctx context.Context, in *docutil.RequestP
 func (s *stubDocSearchClient) GetDirectory(ctx context.Context, in *docutil.RequestGetDirectory, opts ...grpc.CallOption) (*docutil.ResponseGetDirectory, error) {
 	return &docutil.ResponseGetDirectory{}, nil
 }
+
+func (s *stubDocSearchClient) Search(ctx context.Context, in *docutil.RequestSearch, opts ...grpc.CallOption) (*docutil.ResponseSearch, error) {
+	return &docutil.ResponseSearch{
+		Total: 1,
+		Results: []*docutil.SearchResult{
+			{
+				Repo:  "test",
+				Path:  "docs/README.md",
+				Title: "Document title",
+				Snippets: []*docutil.Snippet{
+					{Text: "Hello <World>!", Highlights: []*docutil.Highlight{{Start: 6, End: 13}}},
+				},
+			},
+		},
+	}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"embed"
 	"fmt"
+	"html"
 	"html/template"
 	"net/http"
 	"path/filepath"
@@ -14,7 +15,7 @@ import (
 	"go.f110.dev/mono/go/logger/slogger"
 )
 
-//go:embed doc.tmpl directory.tmpl index.tmpl
+//go:embed doc.tmpl directory.tmpl index.tmpl search.tmpl
 var templateFiles embed.FS
 
 var (
@@ -157,6 +158,70 @@ func (r *Renderer) RenderDirectoryIndex(w http.ResponseWriter, repo *docutil.Rep
 	}
 }
 
+type searchResult struct {
+	Repo     string
+	Path     string
+	Title    string
+	Snippets []template.HTML
+}
+
+func (r *Renderer) RenderSearch(w http.ResponseWriter, query, repo, pathPrefix string, repos []*docutil.Repository, res *docutil.ResponseSearch) {
+	var results []*searchResult
+	var total int32
+	if res != nil {
+		total = res.Total
+		for _, v := range res.Results {
+			result := &searchResult{Repo: v.Repo, Path: v.Path, Title: v.Title}
+			for _, s := range v.Snippets {
+				result.Snippets = append(result.Snippets, highlightSnippet(s))
+			}
+			results = append(results, result)
+		}
+	}
+
+	err := pageTemplate.ExecuteTemplate(w, "search.tmpl", struct {
+		Title        string
+		Query        string
+		Repo         string
+		Path         string
+		Repositories []*docutil.Repository
+		Total        int32
+		Results      []*searchResult
+	}{
+		Title:        r.Title,
+		Query:        query,
+		Repo:         repo,
+		Path:         pathPrefix,
+		Repositories: repos,
+		Total:        total,
+		Results:      results,
+	})
+	if err != nil {
+		slogger.Log.Error("Failed to render page", slogger.E(err))
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// highlightSnippet returns the escaped text of the snippet. The highlighted parts are surrounded by mark element.
+func highlightSnippet(s *docutil.Snippet) template.HTML {
+	var buf strings.Builder
+	offset := 0
+	for _, v := range s.Highlights {
+		start, end := int(v.Start), int(v.End)
+		if start < offset || end < start || end > len(s.Text) {
+			continue
+		}
+		buf.WriteString(html.EscapeString(s.Text[offset:start]))
+		buf.WriteString("<mark>")
+		buf.WriteString(html.EscapeString(s.Text[start:end]))
+		buf.WriteString("</mark>")
+		offset = end
+	}
+	buf.WriteString(html.EscapeString(s.Text[offset:]))
+	return template.HTML(buf.String())
+}
+
 type pageTemplateVar struct {
 	Title               string
 	PageTitle           string
@@ -9,14 +9,15 @@ import (
 	"go.f110.dev/xerrors"
 
 	"go.f110.dev/mono/go/logger/slogger"
+	"go.f110.dev/mono/go/storage"
 )
 
 type IndexGC struct {
-	backend StorageClient
+	backend storage.Backend
 	bucket  string
 }
 
-func NewIndexGC(s StorageClient, bucket string) *IndexGC {
+func NewIndexGC(s storage.Backend, bucket string) *IndexGC {
 	return &IndexGC{
 		backend: s,
 		bucket:  bucket,
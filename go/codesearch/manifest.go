@@ -14,6 +14,7 @@ import (
 	"go.f110.dev/xerrors"
 
 	"go.f110.dev/mono/go/logger/slogger"
+	"go.f110.dev/mono/go/storage"
 )
 
 type Manifest struct {
@@ -38,10 +39,10 @@ func NewManifest(executionKey uint64, indexes map[string]string, totalSize uint6
 }
 
 type ManifestManager struct {
-	backend StorageClient
+	backend storage.Backend
 }
 
-func NewManifestManager(backend StorageClient) *ManifestManager {
+func NewManifestManager(backend storage.Backend) *ManifestManager {
 	return &ManifestManager{backend: backend}
 }
 
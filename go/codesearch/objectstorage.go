@@ -19,22 +19,13 @@ import (
 type ObjectStorageIndexManager struct {
 	executionKey     int64
 	bucket           string
-	backend          StorageClient
+	backend          storage.Backend
 	stripPrefixSlash bool
 
 	uploadedFiles []string
 }
 
-type StorageClient interface {
-	Name() string
-	Get(context.Context, string) (*storage.Object, error)
-	Delete(context.Context, string) error
-	Put(context.Context, string, []byte) error
-	PutReader(context.Context, string, io.Reader) error
-	List(context.Context, string) ([]*storage.Object, error)
-}
-
-func NewObjectStorageIndexManager(s StorageClient, bucket string) *ObjectStorageIndexManager {
+func NewObjectStorageIndexManager(s storage.Backend, bucket string) *ObjectStorageIndexManager {
 	stripPrefixSlash := false
 	switch s.(type) {
 	case *storage.MinIO:
@@ -49,6 +49,8 @@ type IndexerCommand struct {
 	S3SecretAccessKey           string
 	S3CACertFile                string
 	S3PartSize                  uint64
+	S3UploadConcurrency         int
+	StorageDir                  string
 	DisableObjectStorageCleanup bool
 
 	NATSURL        string
@@ -85,6 +87,7 @@ func NewIndexerCommand() *IndexerCommand {
 		NATSStreamName:      "repoindexer",
 		NATSSubject:         "notify",
 		S3PartSize:          1 * 1024 * 1024 * 1024, // 1GiB
+		S3UploadConcurrency: 2,
 		githubClientFactory: githubutil.NewGitHubClientFactory("repo-indexer", false),
 	}
 }
@@ -113,6 +116,8 @@ func (r *IndexerCommand) Flags(fs *cli.FlagSet) {
 	fs.String("s3-secret-access-key", "The secret access key for S3 API").Var(&r.S3SecretAccessKey)
 	fs.String("s3-ca-file", "File path that contains the certificate of CA").Var(&r.S3CACertFile)
 	fs.Uint64("s3-part-size", "Part size").Var(&r.S3PartSize)
+	fs.Int("s3-upload-concurrency", "The number of parts which are uploaded at the same time").Var(&r.S3UploadConcurrency).Default(r.S3UploadConcurrency)
+	fs.String("storage-dir", "The directory which stores the objects instead of the object storage").Var(&r.StorageDir)
 	fs.String("bucket", "The bucket name").Var(&r.Bucket)
 	fs.String("nats-url", "The URL for nats-server").Var(&r.NATSURL)
 	fs.String("nats-stream-name", "The name of stream for JetStream").Var(&r.NATSStreamName).Default(r.NATSStreamName)
@@ -239,7 +244,7 @@ func (r *IndexerCommand) run() error {
 			}
 		}
 	} else {
-		slogger.Log.Debug("Disable upload", slog.Bool("can_use_minio", r.canUseMinIO()), slog.Bool("can_use_s3", r.canUseS3()))
+		slogger.Log.Debug("Disable upload", slog.Bool("can_use_minio", r.canUseMinIO()), slog.Bool("can_use_s3", r.canUseS3()), slog.String("storage_dir", r.StorageDir))
 	}
 	if !r.DisableCleanup {
 		if err := r.indexer.Cleanup(ctx); err != nil {
@@ -363,7 +368,7 @@ func (r *IndexerCommand) gc() error {
 
 func (r *IndexerCommand) enableUpload() bool {
 	return r.Bucket != "" &&
-		(r.canUseMinIO() || r.canUseS3())
+		(r.StorageDir != "" || r.canUseMinIO() || r.canUseS3())
 }
 
 func (r *IndexerCommand) canUseMinIO() bool {
@@ -374,7 +379,14 @@ func (r *IndexerCommand) canUseS3() bool {
 	return r.S3Endpoint != "" && r.S3AccessKey != "" && r.S3SecretAccessKey != "" && r.S3Region != ""
 }
 
-func (r *IndexerCommand) newStorageClient() (StorageClient, error) {
+func (r *IndexerCommand) newStorageClient() (storage.Backend, error) {
+	if r.StorageDir != "" {
+		l, err := storage.NewLocal(r.StorageDir)
+		if err != nil {
+			return nil, err
+		}
+		return l, nil
+	}
 	if r.canUseMinIO() {
 		secretAccessKey := r.MinIOSecretAccessKey
 		if r.MinIOSecretAccessKeyFile != "" {
@@ -406,6 +418,7 @@ func (r *IndexerCommand) newStorageClient() (StorageClient, error) {
 		opt.PathStyle = true
 		opt.CACertFile = r.S3CACertFile
 		opt.PartSize = r.S3PartSize
+		opt.Concurrency = r.S3UploadConcurrency
 		return storage.NewS3(r.Bucket, opt), nil
 	}
 
@@ -37,6 +37,7 @@ type UpdaterCommand struct {
 	S3AccessKey              string
 	S3SecretAccessKey        string
 	S3CACertFile             string
+	StorageDir               string
 
 	NATSURL        string
 	NATSStreamName string
@@ -85,6 +86,7 @@ func (u *UpdaterCommand) Flags(fs *pflag.FlagSet) {
 	fs.StringVar(&u.S3AccessKey, "s3-access-key", u.S3AccessKey, "The access key for S3 API")
 	fs.StringVar(&u.S3SecretAccessKey, "s3-secret-access-key", u.S3SecretAccessKey, "The secret access key for S3 API")
 	fs.StringVar(&u.S3CACertFile, "s3-ca-file", u.S3CACertFile, "File path that contains the certificate of CA")
+	fs.StringVar(&u.StorageDir, "storage-dir", u.StorageDir, "The directory which stores the objects instead of the object storage")
 	fs.StringVar(&u.Bucket, "bucket", u.Bucket, "The bucket name")
 	fs.StringVar(&u.NATSURL, "nats-url", u.NATSURL, "The URL for nats-server")
 	fs.StringVar(&u.NATSStreamName, "nats-stream-name", u.NATSStreamName, "The name of stream for JetStream")
@@ -247,7 +249,14 @@ func (u *UpdaterCommand) downloadIndex(m Manifest) error {
 	return nil
 }
 
-func (u *UpdaterCommand) newStorageClient() (StorageClient, error) {
+func (u *UpdaterCommand) newStorageClient() (storage.Backend, error) {
+	if u.StorageDir != "" {
+		l, err := storage.NewLocal(u.StorageDir)
+		if err != nil {
+			return nil, err
+		}
+		return l, nil
+	}
 	if u.canUseMinIO() {
 		secretAccessKey := u.MinIOSecretAccessKey
 		if u.MinIOSecretAccessKeyFile != "" {
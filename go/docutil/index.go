@@ -0,0 +1,314 @@
+package docutil
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go.f110.dev/xerrors"
+
+	"go.f110.dev/mono/go/storage"
+)
+
+const (
+	searchTitleWeight   = 3
+	searchHeadingWeight = 2
+
+	// Parameters of BM25
+	searchK1 = 1.2
+	searchB  = 0.75
+
+	// snippetLength is the number of characters of the snippet.
+	snippetLength = 120
+	// snippetContext is the number of characters before the first highlight.
+	snippetContext = 30
+	maxSnippets    = 3
+)
+
+// searchIndex is the inverted index of the pages in the repository.
+type searchIndex struct {
+	Ref       string             `json:"ref"`
+	Documents []*indexedDocument `json:"documents"`
+	// Postings maps the term to the documents which contain the term.
+	Postings map[string][]*posting `json:"postings"`
+}
+
+type indexedDocument struct {
+	Path     string   `json:"path"`
+	Title    string   `json:"title"`
+	Headings []string `json:"headings,omitempty"`
+	Body     string   `json:"body"`
+	// Length is the number of terms in the document.
+	Length int `json:"length"`
+}
+
+// posting has the frequency of the term in each field of the document.
+type posting struct {
+	Doc     int `json:"d"`
+	Title   int `json:"t,omitempty"`
+	Heading int `json:"h,omitempty"`
+	Body    int `json:"b,omitempty"`
+}
+
+func (p *posting) frequency() float64 {
+	return float64(p.Title*searchTitleWeight + p.Heading*searchHeadingWeight + p.Body)
+}
+
+// newSearchIndex makes the index from docs. Length of docs is counted by newSearchIndex.
+func newSearchIndex(ref string, docs []*indexedDocument) *searchIndex {
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Path < docs[j].Path })
+
+	idx := &searchIndex{Ref: ref, Postings: make(map[string][]*posting)}
+	for _, doc := range docs {
+		docID := len(idx.Documents)
+		idx.Documents = append(idx.Documents, doc)
+
+		postings := make(map[string]*posting)
+		count := func(s string, f func(*posting)) {
+			for _, t := range tokenize(s, true) {
+				v, ok := postings[t.Term]
+				if !ok {
+					v = &posting{Doc: docID}
+					postings[t.Term] = v
+				}
+				f(v)
+				doc.Length++
+			}
+		}
+		count(doc.Title, func(p *posting) { p.Title++ })
+		for _, v := range doc.Headings {
+			count(v, func(p *posting) { p.Heading++ })
+		}
+		count(doc.Body, func(p *posting) { p.Body++ })
+
+		for term, v := range postings {
+			idx.Postings[term] = append(idx.Postings[term], v)
+		}
+	}
+
+	return idx
+}
+
+// match returns the documents which contain all terms. The value of the map is the posting of each term.
+func (idx *searchIndex) match(terms []string, pathPrefix string) map[int][]*posting {
+	var res map[int][]*posting
+	for i, term := range terms {
+		next := make(map[int][]*posting)
+		for _, p := range idx.Postings[term] {
+			if i == 0 {
+				if !strings.HasPrefix(idx.Documents[p.Doc].Path, pathPrefix) {
+					continue
+				}
+				next[p.Doc] = make([]*posting, len(terms))
+				next[p.Doc][0] = p
+			} else if v, ok := res[p.Doc]; ok {
+				v[i] = p
+				next[p.Doc] = v
+			}
+		}
+		if len(next) == 0 {
+			return nil
+		}
+		res = next
+	}
+
+	return res
+}
+
+func searchIndexObjectName(repo, ref string) string {
+	return fmt.Sprintf("search_index/%s/%s.json", repo, ref)
+}
+
+func loadSearchIndex(ctx context.Context, b storage.Backend, repo, ref string) (*searchIndex, error) {
+	obj, err := b.Get(ctx, searchIndexObjectName(repo, ref))
+	if err != nil {
+		return nil, err
+	}
+	defer obj.Body.Close()
+
+	idx := &searchIndex{}
+	if err := json.NewDecoder(obj.Body).Decode(idx); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	if idx.Ref != ref {
+		return nil, xerrors.Definef("the index is made from %s", idx.Ref).WithStack()
+	}
+	return idx, nil
+}
+
+func saveSearchIndex(ctx context.Context, b storage.Backend, repo string, idx *searchIndex) error {
+	buf, err := json.Marshal(idx)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	return b.Put(ctx, searchIndexObjectName(repo, idx.Ref), buf)
+}
+
+// bm25 returns the score of the term.
+// n is the number of all documents and df is the number of documents which contain the term.
+func bm25(freq float64, n, df int, docLength, avgLength float64) float64 {
+	idf := math.Log(1 + (float64(n-df)+0.5)/(float64(df)+0.5))
+	return idf * freq * (searchK1 + 1) / (freq + searchK1*(1-searchB+searchB*docLength/avgLength))
+}
+
+type token struct {
+	Term string
+	// Start and End are byte offsets of the original text.
+	Start int
+	End   int
+}
+
+// tokenize splits s into terms.
+// Words are split by non-alphanumeric characters and lowercased.
+// Japanese doesn't have any separator between words, so the sequence of Han, Hiragana and Katakana is split into
+// bi-grams. If unigram is true, each character is also returned as the term so that the query which has only one
+// character can be matched.
+func tokenize(s string, unigram bool) []*token {
+	var tokens []*token
+	var word strings.Builder
+	wordStart := -1
+	var cjk []int // byte offsets of the characters
+	flush := func(end int) {
+		if wordStart != -1 {
+			tokens = append(tokens, &token{Term: word.String(), Start: wordStart, End: end})
+			word.Reset()
+			wordStart = -1
+		}
+		if len(cjk) > 0 {
+			cjk = append(cjk, end)
+			for i := 0; i < len(cjk)-1; i++ {
+				if unigram || len(cjk) == 2 {
+					tokens = append(tokens, &token{Term: s[cjk[i]:cjk[i+1]], Start: cjk[i], End: cjk[i+1]})
+				}
+				if i+2 < len(cjk) {
+					tokens = append(tokens, &token{Term: s[cjk[i]:cjk[i+2]], Start: cjk[i], End: cjk[i+2]})
+				}
+			}
+			cjk = cjk[:0]
+		}
+	}
+
+	for i, r := range s {
+		r = foldRune(r)
+		switch {
+		case isCJK(r):
+			if wordStart != -1 {
+				flush(i)
+			}
+			cjk = append(cjk, i)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if len(cjk) > 0 {
+				flush(i)
+			}
+			if wordStart == -1 {
+				wordStart = i
+			}
+			word.WriteRune(unicode.ToLower(r))
+		default:
+			flush(i)
+		}
+	}
+	flush(len(s))
+
+	return tokens
+}
+
+// queryTerms returns the unique terms of the query.
+func queryTerms(q string) []string {
+	var terms []string
+	seen := make(map[string]struct{})
+	for _, v := range tokenize(q, false) {
+		if _, ok := seen[v.Term]; ok {
+			continue
+		}
+		seen[v.Term] = struct{}{}
+		terms = append(terms, v.Term)
+	}
+	return terms
+}
+
+// foldRune converts the full-width alphanumeric character to ASCII.
+func foldRune(r rune) rune {
+	if r >= 0xFF01 && r <= 0xFF5E {
+		return r - 0xFEE0
+	}
+	return r
+}
+
+func isCJK(r rune) bool {
+	// U+30FC (Katakana-Hiragana prolonged sound mark) is not in the Katakana table.
+	return r == 'ー' || unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// makeSnippets returns the parts of text which contain terms.
+func makeSnippets(text string, terms []string) []*Snippet {
+	if text == "" {
+		return nil
+	}
+	termSet := make(map[string]struct{}, len(terms))
+	for _, v := range terms {
+		termSet[v] = struct{}{}
+	}
+
+	// Find the ranges of the matched terms. The bi-grams of the term overlap, so they are merged.
+	var ranges []*Highlight
+	for _, t := range tokenize(text, true) {
+		if _, ok := termSet[t.Term]; !ok {
+			continue
+		}
+		if len(ranges) > 0 && int(ranges[len(ranges)-1].End) >= t.Start {
+			last := ranges[len(ranges)-1]
+			last.End = max(last.End, int32(t.End))
+			continue
+		}
+		ranges = append(ranges, &Highlight{Start: int32(t.Start), End: int32(t.End)})
+	}
+	if len(ranges) == 0 {
+		end := runeOffset(text, 0, snippetLength)
+		return []*Snippet{{Text: snippetText(text[:end])}}
+	}
+
+	var snippets []*Snippet
+	for i := 0; i < len(ranges) && len(snippets) < maxSnippets; {
+		start := runeOffset(text, int(ranges[i].Start), -snippetContext)
+		end := runeOffset(text, start, snippetLength)
+		snippet := &Snippet{Text: snippetText(text[start:end])}
+		for ; i < len(ranges) && int(ranges[i].Start) < end; i++ {
+			snippet.Highlights = append(snippet.Highlights, &Highlight{
+				Start: ranges[i].Start - int32(start),
+				End:   min(ranges[i].End, int32(end)) - int32(start),
+			})
+		}
+		snippets = append(snippets, snippet)
+	}
+
+	return snippets
+}
+
+// runeOffset returns the byte offset which is moved n characters from offset. The offset doesn't exceed the edge of s.
+func runeOffset(s string, offset, n int) int {
+	for ; n > 0 && offset < len(s); n-- {
+		_, size := utf8.DecodeRuneInString(s[offset:])
+		offset += size
+	}
+	for ; n < 0 && offset > 0; n++ {
+		_, size := utf8.DecodeLastRuneInString(s[:offset])
+		offset -= size
+	}
+	return offset
+}
+
+// snippetText replaces the line breaks with the space. The length of text is not changed.
+func snippetText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\r' || r == '\t' {
+			return ' '
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,50 @@
+package docutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	cases := []struct {
+		In      string
+		Unigram bool
+		Out     []string
+	}{
+		{In: "Hello, World! go_test v1.2", Out: []string{"hello", "world", "go", "test", "v1", "2"}},
+		{In: "ＦＵＬＬ ｗｉｄｔｈ", Out: []string{"full", "width"}},
+		{In: "全文検索", Out: []string{"全文", "文検", "検索"}},
+		{In: "全文検索", Unigram: true, Out: []string{"全", "全文", "文", "文検", "検", "検索", "索"}},
+		{In: "本", Out: []string{"本"}},
+		{In: "Goのコード", Out: []string{"go", "のコ", "コー", "ード"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.In, func(t *testing.T) {
+			var terms []string
+			for _, v := range tokenize(tc.In, tc.Unigram) {
+				terms = append(terms, v.Term)
+			}
+			assert.Equal(t, tc.Out, terms)
+		})
+	}
+}
+
+func TestMakeSnippets(t *testing.T) {
+	snippets := makeSnippets("ドキュメントの全文検索を提供します。\nThe search is fast.", queryTerms("全文検索 SEARCH"))
+	if assert.Len(t, snippets, 1) {
+		s := snippets[0]
+		assert.Equal(t, "ドキュメントの全文検索を提供します。 The search is fast.", s.Text)
+		if assert.Len(t, s.Highlights, 2) {
+			assert.Equal(t, "全文検索", s.Text[s.Highlights[0].Start:s.Highlights[0].End])
+			assert.Equal(t, "search", s.Text[s.Highlights[1].Start:s.Highlights[1].End])
+		}
+	}
+
+	snippets = makeSnippets("no match", []string{"foo"})
+	if assert.Len(t, snippets, 1) {
+		assert.Equal(t, "no match", snippets[0].Text)
+		assert.Empty(t, snippets[0].Highlights)
+	}
+}
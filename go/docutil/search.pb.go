@@ -913,6 +913,309 @@ func (x *DirectoryEntry) GetIsDir() bool {
 	return false
 }
 
+type RequestSearch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Query string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// repo is the name of the repository. If repo is empty, all repositories are searched.
+	Repo          string `protobuf:"bytes,2,opt,name=repo,proto3" json:"repo,omitempty"`
+	PathPrefix    string `protobuf:"bytes,3,opt,name=path_prefix,json=pathPrefix,proto3" json:"path_prefix,omitempty"`
+	Limit         int32  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestSearch) Reset() {
+	*x = RequestSearch{}
+	mi := &file_proto_docutil_search_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestSearch) ProtoMessage() {}
+
+func (x *RequestSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_docutil_search_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestSearch.ProtoReflect.Descriptor instead.
+func (*RequestSearch) Descriptor() ([]byte, []int) {
+	return file_proto_docutil_search_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RequestSearch) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *RequestSearch) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *RequestSearch) GetPathPrefix() string {
+	if x != nil {
+		return x.PathPrefix
+	}
+	return ""
+}
+
+func (x *RequestSearch) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ResponseSearch struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Results []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// total is the number of the matched pages. It may be larger than the length of results.
+	Total         int32 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseSearch) Reset() {
+	*x = ResponseSearch{}
+	mi := &file_proto_docutil_search_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseSearch) ProtoMessage() {}
+
+func (x *ResponseSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_docutil_search_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseSearch.ProtoReflect.Descriptor instead.
+func (*ResponseSearch) Descriptor() ([]byte, []int) {
+	return file_proto_docutil_search_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ResponseSearch) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ResponseSearch) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type SearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Repo          string                 `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Score         float64                `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`
+	Snippets      []*Snippet             `protobuf:"bytes,5,rep,name=snippets,proto3" json:"snippets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_proto_docutil_search_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_docutil_search_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_proto_docutil_search_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SearchResult) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *SearchResult) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *SearchResult) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SearchResult) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchResult) GetSnippets() []*Snippet {
+	if x != nil {
+		return x.Snippets
+	}
+	return nil
+}
+
+type Snippet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Highlights    []*Highlight           `protobuf:"bytes,2,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Snippet) Reset() {
+	*x = Snippet{}
+	mi := &file_proto_docutil_search_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Snippet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snippet) ProtoMessage() {}
+
+func (x *Snippet) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_docutil_search_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snippet.ProtoReflect.Descriptor instead.
+func (*Snippet) Descriptor() ([]byte, []int) {
+	return file_proto_docutil_search_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Snippet) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Snippet) GetHighlights() []*Highlight {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+// Highlight is the range of the matched text in Snippet. start and end are byte offsets.
+type Highlight struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         int32                  `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           int32                  `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Highlight) Reset() {
+	*x = Highlight{}
+	mi := &file_proto_docutil_search_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Highlight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Highlight) ProtoMessage() {}
+
+func (x *Highlight) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_docutil_search_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Highlight.ProtoReflect.Descriptor instead.
+func (*Highlight) Descriptor() ([]byte, []int) {
+	return file_proto_docutil_search_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Highlight) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *Highlight) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
 var File_proto_docutil_search_proto protoreflect.FileDescriptor
 
 const file_proto_docutil_search_proto_rawDesc = "" +
@@ -969,20 +1272,44 @@ const file_proto_docutil_search_proto_rawDesc = "" +
 	"\x0eDirectoryEntry\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
 	"\x04path\x18\x02 \x01(\tR\x04path\x12\x15\n" +
-	"\x06is_dir\x18\x03 \x01(\bR\x05isDir*\"\n" +
+	"\x06is_dir\x18\x03 \x01(\bR\x05isDir\"p\n" +
+	"\rRequestSearch\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04repo\x18\x02 \x01(\tR\x04repo\x12\x1f\n" +
+	"\vpath_prefix\x18\x03 \x01(\tR\n" +
+	"pathPrefix\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\\\n" +
+	"\x0eResponseSearch\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.mono.docutil.SearchResultR\aresults\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"\x95\x01\n" +
+	"\fSearchResult\x12\x12\n" +
+	"\x04repo\x18\x01 \x01(\tR\x04repo\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05score\x121\n" +
+	"\bsnippets\x18\x05 \x03(\v2\x15.mono.docutil.SnippetR\bsnippets\"V\n" +
+	"\aSnippet\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x127\n" +
+	"\n" +
+	"highlights\x18\x02 \x03(\v2\x17.mono.docutil.HighlightR\n" +
+	"highlights\"3\n" +
+	"\tHighlight\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\x05R\x03end*\"\n" +
 	"\bFileType\x12\x16\n" +
 	"\x12FILE_TYPE_MARKDOWN\x10\x00*b\n" +
 	"\bLinkType\x12\x16\n" +
 	"\x12LINK_TYPE_EXTERNAL\x10\x00\x12\x1b\n" +
 	"\x17LINK_TYPE_IN_REPOSITORY\x10\x01\x12!\n" +
-	"\x1dLINK_TYPE_NEIGHBOR_REPOSITORY\x10\x022\x92\x04\n" +
+	"\x1dLINK_TYPE_NEIGHBOR_REPOSITORY\x10\x022\xd7\x04\n" +
 	"\tDocSearch\x12d\n" +
 	"\x11AvailableFeatures\x12&.mono.docutil.RequestAvailableFeatures\x1a'.mono.docutil.ResponseAvailableFeatures\x12[\n" +
 	"\x0eListRepository\x12#.mono.docutil.RequestListRepository\x1a$.mono.docutil.ResponseListRepository\x12X\n" +
 	"\rGetRepository\x12\".mono.docutil.RequestGetRepository\x1a#.mono.docutil.ResponseGetRepository\x12F\n" +
 	"\aGetPage\x12\x1c.mono.docutil.RequestGetPage\x1a\x1d.mono.docutil.ResponseGetPage\x12I\n" +
 	"\bPageLink\x12\x1d.mono.docutil.RequestPageLink\x1a\x1e.mono.docutil.ResponsePageLink\x12U\n" +
-	"\fGetDirectory\x12!.mono.docutil.RequestGetDirectory\x1a\".mono.docutil.ResponseGetDirectoryB\x1dZ\x1bgo.f110.dev/mono/go/docutilb\x06proto3"
+	"\fGetDirectory\x12!.mono.docutil.RequestGetDirectory\x1a\".mono.docutil.ResponseGetDirectory\x12C\n" +
+	"\x06Search\x12\x1b.mono.docutil.RequestSearch\x1a\x1c.mono.docutil.ResponseSearchB\x1dZ\x1bgo.f110.dev/mono/go/docutilb\x06proto3"
 
 var (
 	file_proto_docutil_search_proto_rawDescOnce sync.Once
@@ -997,7 +1324,7 @@ func file_proto_docutil_search_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_docutil_search_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_docutil_search_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_proto_docutil_search_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_proto_docutil_search_proto_goTypes = []any{
 	(FileType)(0),                     // 0: mono.docutil.FileType
 	(LinkType)(0),                     // 1: mono.docutil.LinkType
@@ -1016,6 +1343,11 @@ var file_proto_docutil_search_proto_goTypes = []any{
 	(*RequestGetDirectory)(nil),       // 14: mono.docutil.RequestGetDirectory
 	(*ResponseGetDirectory)(nil),      // 15: mono.docutil.ResponseGetDirectory
 	(*DirectoryEntry)(nil),            // 16: mono.docutil.DirectoryEntry
+	(*RequestSearch)(nil),             // 17: mono.docutil.RequestSearch
+	(*ResponseSearch)(nil),            // 18: mono.docutil.ResponseSearch
+	(*SearchResult)(nil),              // 19: mono.docutil.SearchResult
+	(*Snippet)(nil),                   // 20: mono.docutil.Snippet
+	(*Highlight)(nil),                 // 21: mono.docutil.Highlight
 }
 var file_proto_docutil_search_proto_depIdxs = []int32{
 	0,  // 0: mono.docutil.ResponseAvailableFeatures.supported_file_type:type_name -> mono.docutil.FileType
@@ -1027,23 +1359,28 @@ var file_proto_docutil_search_proto_depIdxs = []int32{
 	11, // 6: mono.docutil.ResponsePageLink.in:type_name -> mono.docutil.PageLink
 	11, // 7: mono.docutil.ResponsePageLink.out:type_name -> mono.docutil.PageLink
 	16, // 8: mono.docutil.ResponseGetDirectory.entries:type_name -> mono.docutil.DirectoryEntry
-	2,  // 9: mono.docutil.DocSearch.AvailableFeatures:input_type -> mono.docutil.RequestAvailableFeatures
-	4,  // 10: mono.docutil.DocSearch.ListRepository:input_type -> mono.docutil.RequestListRepository
-	6,  // 11: mono.docutil.DocSearch.GetRepository:input_type -> mono.docutil.RequestGetRepository
-	9,  // 12: mono.docutil.DocSearch.GetPage:input_type -> mono.docutil.RequestGetPage
-	12, // 13: mono.docutil.DocSearch.PageLink:input_type -> mono.docutil.RequestPageLink
-	14, // 14: mono.docutil.DocSearch.GetDirectory:input_type -> mono.docutil.RequestGetDirectory
-	3,  // 15: mono.docutil.DocSearch.AvailableFeatures:output_type -> mono.docutil.ResponseAvailableFeatures
-	5,  // 16: mono.docutil.DocSearch.ListRepository:output_type -> mono.docutil.ResponseListRepository
-	7,  // 17: mono.docutil.DocSearch.GetRepository:output_type -> mono.docutil.ResponseGetRepository
-	10, // 18: mono.docutil.DocSearch.GetPage:output_type -> mono.docutil.ResponseGetPage
-	13, // 19: mono.docutil.DocSearch.PageLink:output_type -> mono.docutil.ResponsePageLink
-	15, // 20: mono.docutil.DocSearch.GetDirectory:output_type -> mono.docutil.ResponseGetDirectory
-	15, // [15:21] is the sub-list for method output_type
-	9,  // [9:15] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	19, // 9: mono.docutil.ResponseSearch.results:type_name -> mono.docutil.SearchResult
+	20, // 10: mono.docutil.SearchResult.snippets:type_name -> mono.docutil.Snippet
+	21, // 11: mono.docutil.Snippet.highlights:type_name -> mono.docutil.Highlight
+	2,  // 12: mono.docutil.DocSearch.AvailableFeatures:input_type -> mono.docutil.RequestAvailableFeatures
+	4,  // 13: mono.docutil.DocSearch.ListRepository:input_type -> mono.docutil.RequestListRepository
+	6,  // 14: mono.docutil.DocSearch.GetRepository:input_type -> mono.docutil.RequestGetRepository
+	9,  // 15: mono.docutil.DocSearch.GetPage:input_type -> mono.docutil.RequestGetPage
+	12, // 16: mono.docutil.DocSearch.PageLink:input_type -> mono.docutil.RequestPageLink
+	14, // 17: mono.docutil.DocSearch.GetDirectory:input_type -> mono.docutil.RequestGetDirectory
+	17, // 18: mono.docutil.DocSearch.Search:input_type -> mono.docutil.RequestSearch
+	3,  // 19: mono.docutil.DocSearch.AvailableFeatures:output_type -> mono.docutil.ResponseAvailableFeatures
+	5,  // 20: mono.docutil.DocSearch.ListRepository:output_type -> mono.docutil.ResponseListRepository
+	7,  // 21: mono.docutil.DocSearch.GetRepository:output_type -> mono.docutil.ResponseGetRepository
+	10, // 22: mono.docutil.DocSearch.GetPage:output_type -> mono.docutil.ResponseGetPage
+	13, // 23: mono.docutil.DocSearch.PageLink:output_type -> mono.docutil.ResponsePageLink
+	15, // 24: mono.docutil.DocSearch.GetDirectory:output_type -> mono.docutil.ResponseGetDirectory
+	18, // 25: mono.docutil.DocSearch.Search:output_type -> mono.docutil.ResponseSearch
+	19, // [19:26] is the sub-list for method output_type
+	12, // [12:19] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_proto_docutil_search_proto_init() }
@@ -1057,7 +1394,7 @@ func file_proto_docutil_search_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_docutil_search_proto_rawDesc), len(file_proto_docutil_search_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   15,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1089,6 +1426,7 @@ type DocSearchClient interface {
 	GetPage(ctx context.Context, in *RequestGetPage, opts ...grpc.CallOption) (*ResponseGetPage, error)
 	PageLink(ctx context.Context, in *RequestPageLink, opts ...grpc.CallOption) (*ResponsePageLink, error)
 	GetDirectory(ctx context.Context, in *RequestGetDirectory, opts ...grpc.CallOption) (*ResponseGetDirectory, error)
+	Search(ctx context.Context, in *RequestSearch, opts ...grpc.CallOption) (*ResponseSearch, error)
 }
 
 type docSearchClient struct {
@@ -1153,6 +1491,15 @@ func (c *docSearchClient) GetDirectory(ctx context.Context, in *RequestGetDirect
 	return out, nil
 }
 
+func (c *docSearchClient) Search(ctx context.Context, in *RequestSearch, opts ...grpc.CallOption) (*ResponseSearch, error) {
+	out := new(ResponseSearch)
+	err := c.cc.Invoke(ctx, "/mono.docutil.DocSearch/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DocSearchServer is the server API for DocSearch service.
 type DocSearchServer interface {
 	AvailableFeatures(context.Context, *RequestAvailableFeatures) (*ResponseAvailableFeatures, error)
@@ -1161,6 +1508,7 @@ type DocSearchServer interface {
 	GetPage(context.Context, *RequestGetPage) (*ResponseGetPage, error)
 	PageLink(context.Context, *RequestPageLink) (*ResponsePageLink, error)
 	GetDirectory(context.Context, *RequestGetDirectory) (*ResponseGetDirectory, error)
+	Search(context.Context, *RequestSearch) (*ResponseSearch, error)
 }
 
 // UnimplementedDocSearchServer can be embedded to have forward compatible implementations.
@@ -1185,6 +1533,9 @@ func (*UnimplementedDocSearchServer) PageLink(context.Context, *RequestPageLink)
 func (*UnimplementedDocSearchServer) GetDirectory(context.Context, *RequestGetDirectory) (*ResponseGetDirectory, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDirectory not implemented")
 }
+func (*UnimplementedDocSearchServer) Search(context.Context, *RequestSearch) (*ResponseSearch, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
 
 func RegisterDocSearchServer(s *grpc.Server, srv DocSearchServer) {
 	s.RegisterService(&_DocSearch_serviceDesc, srv)
@@ -1298,6 +1649,24 @@ func _DocSearch_GetDirectory_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DocSearch_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestSearch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DocSearchServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mono.docutil.DocSearch/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DocSearchServer).Search(ctx, req.(*RequestSearch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DocSearch_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "mono.docutil.DocSearch",
 	HandlerType: (*DocSearchServer)(nil),
@@ -1326,6 +1695,10 @@ var _DocSearch_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetDirectory",
 			Handler:    _DocSearch_GetDirectory_Handler,
 		},
+		{
+			MethodName: "Search",
+			Handler:    _DocSearch_Search_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/docutil/search.proto",
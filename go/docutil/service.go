@@ -7,13 +7,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"log/slog"
 	"maps"
 	"net/http"
 	"net/url"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -38,13 +38,6 @@ import (
 	"go.f110.dev/mono/go/storage"
 )
 
-type ObjectStorageInterface interface {
-	PutReader(ctx context.Context, name string, data io.Reader) error
-	Delete(ctx context.Context, name string) error
-	Get(ctx context.Context, name string) (*storage.Object, error)
-	List(ctx context.Context, prefix string) ([]*storage.Object, error)
-}
-
 // pages represents links that had the page.
 // The key of map is a file path.
 type pages map[string]*page
@@ -69,7 +62,7 @@ var docSearchServiceSupportFileTypes = []FileType{FileType_FILE_TYPE_MARKDOWN}
 
 type DocSearchService struct {
 	client         git.GitDataClient
-	storage        ObjectStorageInterface
+	storage        storage.Backend
 	markdownParser parser.Parser
 	httpClient     *http.Client
 
@@ -79,9 +72,13 @@ type DocSearchService struct {
 
 	mu          sync.Mutex
 	titleCaches map[string]*titleCache
+
+	indexMu sync.RWMutex
+	// indexes is the search index of each repository. The key of map is a name of the repository.
+	indexes map[string]*searchIndex
 }
 
-func NewDocSearchService(client git.GitDataClient, b ObjectStorageInterface) *DocSearchService {
+func NewDocSearchService(client git.GitDataClient, b storage.Backend) *DocSearchService {
 	g := goldmark.New(
 		goldmark.WithExtensions(
 			extension.GFM,
@@ -98,6 +95,7 @@ func NewDocSearchService(client git.GitDataClient, b ObjectStorageInterface) *Do
 		data:           make(map[string]*docSet),
 		httpClient:     &http.Client{Transport: transport},
 		titleCaches:    make(map[string]*titleCache),
+		indexes:        make(map[string]*searchIndex),
 	}
 }
 
@@ -106,6 +104,7 @@ var _ DocSearchServer = &DocSearchService{}
 func (d *DocSearchService) AvailableFeatures(_ context.Context, _ *RequestAvailableFeatures) (*ResponseAvailableFeatures, error) {
 	return &ResponseAvailableFeatures{
 		PageLink:          true,
+		FullTextSearch:    true,
 		SupportedFileType: docSearchServiceSupportFileTypes,
 	}, nil
 }
@@ -221,6 +220,82 @@ func (d *DocSearchService) GetDirectory(_ context.Context, req *RequestGetDirect
 	return &ResponseGetDirectory{Entries: entries}, nil
 }
 
+func (d *DocSearchService) Search(_ context.Context, req *RequestSearch) (*ResponseSearch, error) {
+	terms := queryTerms(req.Query)
+	if len(terms) == 0 {
+		detail := &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "query", Description: "query doesn't have any term"},
+			},
+		}
+		st := status.New(codes.InvalidArgument, "query is empty")
+		if rpcErr, err := st.WithDetails(detail); err != nil {
+			return nil, status.Error(codes.Internal, "")
+		} else {
+			return nil, rpcErr.Err()
+		}
+	}
+	limit := int(req.Limit)
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	type hit struct {
+		repo     string
+		doc      *indexedDocument
+		postings []*posting
+		score    float64
+	}
+	var hits []*hit
+	var numOfDocs, totalLength int
+	df := make([]int, len(terms))
+	d.indexMu.RLock()
+	for name, idx := range d.indexes {
+		if req.Repo != "" && req.Repo != name {
+			continue
+		}
+		numOfDocs += len(idx.Documents)
+		for _, v := range idx.Documents {
+			totalLength += v.Length
+		}
+		for i, term := range terms {
+			df[i] += len(idx.Postings[term])
+		}
+		for docID, postings := range idx.match(terms, req.PathPrefix) {
+			hits = append(hits, &hit{repo: name, doc: idx.Documents[docID], postings: postings})
+		}
+	}
+	d.indexMu.RUnlock()
+
+	avgLength := float64(totalLength) / float64(max(numOfDocs, 1))
+	for _, v := range hits {
+		for i, p := range v.postings {
+			v.score += bm25(p.frequency(), numOfDocs, df[i], float64(v.doc.Length), avgLength)
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		if hits[i].repo != hits[j].repo {
+			return hits[i].repo < hits[j].repo
+		}
+		return hits[i].doc.Path < hits[j].doc.Path
+	})
+
+	res := &ResponseSearch{Total: int32(len(hits))}
+	for _, v := range hits[:min(len(hits), limit)] {
+		res.Results = append(res.Results, &SearchResult{
+			Repo:     v.repo,
+			Path:     v.doc.Path,
+			Title:    v.doc.Title,
+			Score:    v.score,
+			Snippets: makeSnippets(v.doc.Body, terms),
+		})
+	}
+	return res, nil
+}
+
 func (d *DocSearchService) Initialize(ctx context.Context, workers, maxConns int) error {
 	q := queue.NewSimple[*pageLinkItem]()
 
@@ -449,10 +524,30 @@ func (d *DocSearchService) scanRepositories(ctx context.Context, workers int) er
 }
 
 func (d *DocSearchService) scanRepository(ctx context.Context, repo *git.Repository, workers int) error {
-	var mu sync.Mutex
 	docs := &docSet{Pages: make(pages), Repository: repo}
 	d.data[repo.Name] = docs
 
+	tree, err := d.client.GetTree(ctx, &git.RequestGetTree{
+		Repo:      repo.Name,
+		Ref:       plumbing.NewBranchReferenceName(repo.DefaultBranch).String(),
+		Path:      "/",
+		Recursive: true,
+	})
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	docs.Ref = plumbing.NewHash(tree.Sha)
+
+	// If the index of the same commit is stored, the search is available before scanning the pages
+	// and the plain text of the pages isn't made.
+	idx := d.loadSearchIndex(ctx, repo.Name, docs.Ref.String())
+	if idx != nil {
+		d.setSearchIndex(repo.Name, idx)
+	}
+	indexing := idx == nil
+
+	var mu sync.Mutex
+	var indexedDocs []*indexedDocument
 	ch := make(chan *git.TreeEntry, workers)
 	var wg sync.WaitGroup
 	for range workers {
@@ -463,29 +558,21 @@ func (d *DocSearchService) scanRepository(ctx context.Context, repo *git.Reposit
 				if !ok {
 					break
 				}
-				page, err := d.makePage(ctx, repo, entry.Path, entry.Sha)
+				page, doc, err := d.makePage(ctx, repo, entry.Path, entry.Sha, indexing)
 				if err != nil {
 					slogger.Log.Error("Failed to make page", slogger.E(err))
 				} else {
 					mu.Lock()
 					docs.Pages[entry.Path] = page
+					if doc != nil {
+						indexedDocs = append(indexedDocs, doc)
+					}
 					mu.Unlock()
 				}
 			}
 		})
 	}
 
-	tree, err := d.client.GetTree(ctx, &git.RequestGetTree{
-		Repo:      repo.Name,
-		Ref:       plumbing.NewBranchReferenceName(repo.DefaultBranch).String(),
-		Path:      "/",
-		Recursive: true,
-	})
-	if err != nil {
-		return xerrors.WithStack(err)
-	}
-	docs.Ref = plumbing.NewHash(tree.Sha)
-
 	for _, v := range tree.Tree {
 		switch filepath.Ext(v.Path) {
 		case ".md":
@@ -511,23 +598,63 @@ func (d *DocSearchService) scanRepository(ctx context.Context, repo *git.Reposit
 	case <-timeout:
 		return xerrors.New("timed out to scan the repository")
 	case <-done:
+	}
+
+	if indexing {
+		idx = newSearchIndex(docs.Ref.String(), indexedDocs)
+		if d.storage != nil {
+			if err := saveSearchIndex(ctx, d.storage, repo.Name, idx); err != nil {
+				slogger.Log.Warn("Failed to save the search index", slogger.E(err), slog.String("repo", repo.Name))
+			}
+		}
+		d.setSearchIndex(repo.Name, idx)
+	}
+	return nil
+}
+
+// loadSearchIndex returns the index of the commit which is stored in the object storage.
+// If the index is not stored, loadSearchIndex returns nil.
+func (d *DocSearchService) loadSearchIndex(ctx context.Context, repo, ref string) *searchIndex {
+	if d.storage == nil {
+		return nil
+	}
+	idx, err := loadSearchIndex(ctx, d.storage, repo, ref)
+	if err != nil {
+		if !errors.Is(err, storage.ErrObjectNotFound) {
+			slogger.Log.Warn("Failed to load the search index", slogger.E(err), slog.String("repo", repo))
+		}
 		return nil
 	}
+	slogger.Log.Debug("Use the stored search index", slog.String("repo", repo), slog.String("ref", ref))
+	return idx
+}
+
+func (d *DocSearchService) setSearchIndex(repo string, idx *searchIndex) {
+	d.indexMu.Lock()
+	d.indexes[repo] = idx
+	d.indexMu.Unlock()
 }
 
-func (d *DocSearchService) makePage(ctx context.Context, repo *git.Repository, path, sha string) (*page, error) {
+// makePage makes the page of the file. If indexing is true, makePage also returns the document
+// for the search index.
+func (d *DocSearchService) makePage(ctx context.Context, repo *git.Repository, path, sha string, indexing bool) (*page, *indexedDocument, error) {
 	blob, err := d.client.GetBlob(ctx, &git.RequestGetBlob{Repo: repo.Name, Sha: sha})
 	if err != nil {
-		return nil, xerrors.WithMessage(err, "Failed to get blob")
+		return nil, nil, xerrors.WithMessage(err, "Failed to get blob")
 	}
 
 	rootNode := d.markdownParser.Parse(text.NewReader(blob.Content))
 	page, err := d.makePageFromMarkdownAST(rootNode, repo, path, blob.Content)
 	if err != nil {
-		return nil, xerrors.WithMessage(err, "Failed to parse markdown")
+		return nil, nil, xerrors.WithMessage(err, "Failed to parse markdown")
+	}
+	if !indexing {
+		return page, nil, nil
 	}
 
-	return page, nil
+	doc := &indexedDocument{Path: path, Title: page.Title}
+	doc.Headings, doc.Body = plainText(rootNode, blob.Content)
+	return page, doc, nil
 }
 
 func (d *DocSearchService) makePageFromMarkdownAST(rootNode ast.Node, repo *git.Repository, pagePath string, raw []byte) (*page, error) {
@@ -631,6 +758,69 @@ func (d *DocSearchService) makePageFromMarkdownAST(rootNode ast.Node, repo *git.
 	return page, nil
 }
 
+// plainText returns the text of headings and the text of other blocks without the markup.
+func plainText(rootNode ast.Node, raw []byte) ([]string, string) {
+	var headings []string
+	body := new(strings.Builder)
+	firstHeading := true
+	_ = ast.Walk(rootNode, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch v := n.(type) {
+		case *ast.Heading:
+			buf := new(strings.Builder)
+			inlineText(buf, v, raw)
+			// The first heading of level 1 is the title of the page. It is indexed as the title.
+			isTitle := firstHeading && v.Parent() == rootNode && v.Level == 1
+			if v.Parent() == rootNode {
+				firstHeading = false
+			}
+			if !isTitle {
+				headings = append(headings, buf.String())
+			}
+			return ast.WalkSkipChildren, nil
+		case *ast.CodeBlock, *ast.FencedCodeBlock:
+			lines := v.Lines()
+			for i := 0; i < lines.Len(); i++ {
+				segment := lines.At(i)
+				body.Write(segment.Value(raw))
+			}
+			return ast.WalkSkipChildren, nil
+		case *ast.HTMLBlock:
+			return ast.WalkSkipChildren, nil
+		}
+		if n.Type() == ast.TypeBlock && n.FirstChild() != nil && n.FirstChild().Type() == ast.TypeInline {
+			inlineText(body, n, raw)
+			body.WriteByte('\n')
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+
+	return headings, body.String()
+}
+
+func inlineText(buf *strings.Builder, n ast.Node, raw []byte) {
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch v := c.(type) {
+		case *ast.Text:
+			buf.Write(v.Segment.Value(raw))
+			if v.SoftLineBreak() || v.HardLineBreak() {
+				buf.WriteByte(' ')
+			}
+		case *ast.String:
+			buf.Write(v.Value)
+		case *ast.AutoLink:
+			buf.Write(v.Label(raw))
+		case *ast.RawHTML:
+		default:
+			inlineText(buf, c, raw)
+		}
+	}
+}
+
 type seenKey struct {
 	Type        LinkType
 	Destination string
@@ -702,10 +892,10 @@ type titleCache struct {
 	changed bool
 
 	docs    *docSet
-	storage ObjectStorageInterface
+	storage storage.Backend
 }
 
-func newTitleCache(ctx context.Context, storage ObjectStorageInterface, docs *docSet) *titleCache {
+func newTitleCache(ctx context.Context, storage storage.Backend, docs *docSet) *titleCache {
 	externalLinkTitleCache := make(map[string]string)
 	buf, err := storage.Get(
 		ctx,
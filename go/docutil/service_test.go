@@ -11,8 +11,11 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.f110.dev/xerrors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"go.f110.dev/mono/go/git"
+	"go.f110.dev/mono/go/storage"
 )
 
 func TestDocSearchService(t *testing.T) {
@@ -60,10 +63,83 @@ func TestCitedLink(t *testing.T) {
 	assert.Len(t, service.data["mono"].Pages["docs/page1.md"].LinkIn, 1)
 }
 
+func TestSearch(t *testing.T) {
+	blobs := map[string]string{
+		"README.md": `# 全文検索
+ドキュメントを検索できます。
+`,
+		"docs/search.md": `# Search
+## Ranking
+The search result is sorted by the score.
+`,
+		"docs/other.md": "# Other\nThis page mentions search in the body.\n",
+	}
+	b := storage.NewMock()
+	service := NewDocSearchService(&mockGitClient{Blobs: blobs}, b)
+	repo := &git.Repository{Name: "mono", DefaultBranch: "master"}
+	require.NoError(t, service.scanRepository(context.Background(), repo, 1))
+
+	res, err := service.Search(context.Background(), &RequestSearch{Query: "search"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, res.Total)
+	if assert.Len(t, res.Results, 2) {
+		// The page which has the term in the title is ranked higher.
+		assert.Equal(t, "docs/search.md", res.Results[0].Path)
+		assert.Equal(t, "Search", res.Results[0].Title)
+		assert.Equal(t, "docs/other.md", res.Results[1].Path)
+		if assert.Len(t, res.Results[1].Snippets, 1) {
+			s := res.Results[1].Snippets[0]
+			assert.Equal(t, "search", s.Text[s.Highlights[0].Start:s.Highlights[0].End])
+		}
+	}
+
+	res, err = service.Search(context.Background(), &RequestSearch{Query: "検索"})
+	require.NoError(t, err)
+	if assert.Len(t, res.Results, 1) {
+		assert.Equal(t, "README.md", res.Results[0].Path)
+	}
+
+	res, err = service.Search(context.Background(), &RequestSearch{Query: "search score"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, res.Total)
+
+	res, err = service.Search(context.Background(), &RequestSearch{Query: "search", PathPrefix: "docs/search"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, res.Total)
+	res, err = service.Search(context.Background(), &RequestSearch{Query: "search", Repo: "unknown"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, res.Total)
+
+	_, err = service.Search(context.Background(), &RequestSearch{Query: "!?"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The index is stored and used when the same commit is scanned again.
+	stored, err := loadSearchIndex(context.Background(), b, "mono", service.data["mono"].Ref.String())
+	require.NoError(t, err)
+	assert.Len(t, stored.Documents, 3)
+	stored.Documents[0].Title = "Stored"
+	require.NoError(t, saveSearchIndex(context.Background(), b, "mono", stored))
+	client := &mockGitClient{Blobs: blobs}
+	service = NewDocSearchService(client, b)
+	// The stored index is available before scanning the pages.
+	client.onGetBlob = func() {
+		service.indexMu.RLock()
+		defer service.indexMu.RUnlock()
+		assert.Contains(t, service.indexes, "mono")
+	}
+	require.NoError(t, service.scanRepository(context.Background(), repo, 1))
+	res, err = service.Search(context.Background(), &RequestSearch{Query: "検索"})
+	require.NoError(t, err)
+	if assert.Len(t, res.Results, 1) {
+		assert.Equal(t, "Stored", res.Results[0].Title)
+	}
+}
+
 type mockGitClient struct {
 	Blobs map[string]string
 
 	treeEntry []*git.TreeEntry
+	onGetBlob func()
 }
 
 func (m *mockGitClient) ListRepositories(ctx context.Context, in *git.RequestListRepositories, opts ...grpc.CallOption) (*git.ResponseListRepositories, error) {
@@ -91,6 +167,31 @@ func (m *mockGitClient) GetCommit(ctx context.Context, in *git.RequestGetCommit,
 	panic("implement me")
 }
 
+func (m *mockGitClient) ListCommits(ctx context.Context, in *git.RequestListCommits, opts ...grpc.CallOption) (*git.ResponseListCommits, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (m *mockGitClient) Compare(ctx context.Context, in *git.RequestCompare, opts ...grpc.CallOption) (*git.ResponseCompare, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (m *mockGitClient) GetBlame(ctx context.Context, in *git.RequestGetBlame, opts ...grpc.CallOption) (*git.ResponseGetBlame, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (m *mockGitClient) WatchReferences(ctx context.Context, in *git.RequestWatchReferences, opts ...grpc.CallOption) (git.GitData_WatchReferencesClient, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
+func (m *mockGitClient) GetArchive(ctx context.Context, in *git.RequestGetArchive, opts ...grpc.CallOption) (git.GitData_GetArchiveClient, error) {
+	//TODO implement me
+	panic("implement me")
+}
+
 func (m *mockGitClient) GetTree(ctx context.Context, in *git.RequestGetTree, opts ...grpc.CallOption) (*git.ResponseGetTree, error) {
 	if m.treeEntry != nil {
 		return &git.ResponseGetTree{Tree: m.treeEntry}, nil
@@ -113,6 +214,9 @@ func (m *mockGitClient) GetTree(ctx context.Context, in *git.RequestGetTree, opt
 }
 
 func (m *mockGitClient) GetBlob(ctx context.Context, in *git.RequestGetBlob, opts ...grpc.CallOption) (*git.ResponseGetBlob, error) {
+	if m.onGetBlob != nil {
+		m.onGetBlob()
+	}
 	for _, v := range m.treeEntry {
 		if v.Sha == in.Sha {
 			return &git.ResponseGetBlob{Content: []byte(m.Blobs[v.Path])}, nil
@@ -0,0 +1,306 @@
+package git
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"go.f110.dev/xerrors"
+
+	"go.f110.dev/mono/go/logger/slogger"
+)
+
+var (
+	ErrRevisionNotFound    = xerrors.Define("revision is not found")
+	ErrArchivePathNotFound = xerrors.Define("path is not found")
+	ErrInvalidExclude      = xerrors.Define("invalid exclude pattern")
+)
+
+var archiveExtensions = []struct {
+	Ext         string
+	Format      ArchiveFormat
+	ContentType string
+}{
+	{Ext: ".tar.gz", Format: ArchiveFormat_ARCHIVE_FORMAT_TAR_GZ, ContentType: "application/gzip"},
+	{Ext: ".tgz", Format: ArchiveFormat_ARCHIVE_FORMAT_TAR_GZ, ContentType: "application/gzip"},
+	{Ext: ".zip", Format: ArchiveFormat_ARCHIVE_FORMAT_ZIP, ContentType: "application/zip"},
+}
+
+type archiveOptions struct {
+	Format ArchiveFormat
+	// Path is the directory which is archived.
+	Path string
+	// Excludes is the patterns of path.Match which are matched against the path from the root.
+	Excludes []string
+	// Prefix is prepended to the name of the entries.
+	Prefix string
+}
+
+// archive writes the tree of the commit as tar.gz or zip. The objects are read from the
+// storer directly, so the working copy is not needed.
+type archive struct {
+	commit *object.Commit
+	tree   *object.Tree
+	opt    archiveOptions
+	// mtime is the modification time of all entries. git archive uses the committer time too.
+	mtime time.Time
+}
+
+func newArchive(commit *object.Commit, opt archiveOptions) (*archive, error) {
+	for _, v := range opt.Excludes {
+		if _, err := path.Match(v, ""); err != nil {
+			return nil, xerrors.WithMessagef(ErrInvalidExclude.WithStack(), "%s", v)
+		}
+	}
+	opt.Path = strings.Trim(opt.Path, "/")
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	if opt.Path != "" {
+		t, err := tree.Tree(opt.Path)
+		if err != nil {
+			return nil, xerrors.WithMessagef(ErrArchivePathNotFound.WithStack(), "%s", opt.Path)
+		}
+		tree = t
+	}
+	return &archive{commit: commit, tree: tree, opt: opt, mtime: commit.Committer.When}, nil
+}
+
+func (a *archive) Write(w io.Writer) error {
+	switch a.opt.Format {
+	case ArchiveFormat_ARCHIVE_FORMAT_ZIP:
+		zw := zip.NewWriter(w)
+		if err := zw.SetComment(a.commit.Hash.String()); err != nil {
+			return xerrors.WithStack(err)
+		}
+		if err := a.walk(a.tree, "", &zipArchiveWriter{w: zw, mtime: a.mtime}); err != nil {
+			return err
+		}
+		return xerrors.WithStack(zw.Close())
+	default:
+		gw := gzip.NewWriter(w)
+		tw := tar.NewWriter(gw)
+		// git archive stores the commit hash in the pax global header too.
+		err := tw.WriteHeader(&tar.Header{
+			Typeflag:   tar.TypeXGlobalHeader,
+			PAXRecords: map[string]string{"comment": a.commit.Hash.String()},
+		})
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		if err := a.walk(a.tree, "", &tarArchiveWriter{w: tw, mtime: a.mtime}); err != nil {
+			return err
+		}
+		if err := tw.Close(); err != nil {
+			return xerrors.WithStack(err)
+		}
+		return xerrors.WithStack(gw.Close())
+	}
+}
+
+type archiveEntryWriter interface {
+	Dir(name string) error
+	File(name string, mode filemode.FileMode, size int64, r io.Reader) error
+	Symlink(name, target string) error
+}
+
+func (a *archive) walk(tree *object.Tree, dir string, w archiveEntryWriter) error {
+	for _, e := range tree.Entries {
+		name := path.Join(dir, e.Name)
+		if a.excluded(name) {
+			continue
+		}
+
+		switch e.Mode {
+		case filemode.Dir:
+			t, err := tree.Tree(e.Name)
+			if err != nil {
+				return xerrors.WithStack(err)
+			}
+			if err := w.Dir(a.opt.Prefix + name + "/"); err != nil {
+				return err
+			}
+			if err := a.walk(t, name, w); err != nil {
+				return err
+			}
+		case filemode.Submodule:
+			// The commit of the submodule is in another repository. git archive doesn't include it too.
+		default:
+			f, err := tree.TreeEntryFile(&e)
+			if err != nil {
+				return xerrors.WithStack(err)
+			}
+			r, err := f.Reader()
+			if err != nil {
+				return xerrors.WithStack(err)
+			}
+			if e.Mode == filemode.Symlink {
+				target, err := io.ReadAll(r)
+				r.Close()
+				if err != nil {
+					return xerrors.WithStack(err)
+				}
+				if err := w.Symlink(a.opt.Prefix+name, string(target)); err != nil {
+					return err
+				}
+				continue
+			}
+			err = w.File(a.opt.Prefix+name, e.Mode, f.Size, r)
+			r.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// excluded reports whether name matches the exclude patterns. name is relative to Path.
+func (a *archive) excluded(name string) bool {
+	p := path.Join(a.opt.Path, name)
+	for _, v := range a.opt.Excludes {
+		if ok, _ := path.Match(strings.Trim(v, "/"), p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+type tarArchiveWriter struct {
+	w     *tar.Writer
+	mtime time.Time
+}
+
+func (t *tarArchiveWriter) Dir(name string) error {
+	return xerrors.WithStack(t.w.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: name, Mode: 0755, ModTime: t.mtime}))
+}
+
+func (t *tarArchiveWriter) File(name string, mode filemode.FileMode, size int64, r io.Reader) error {
+	err := t.w.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Mode: archiveFileMode(mode), Size: size, ModTime: t.mtime})
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	if _, err := io.Copy(t.w, r); err != nil {
+		return xerrors.WithStack(err)
+	}
+	return nil
+}
+
+func (t *tarArchiveWriter) Symlink(name, target string) error {
+	return xerrors.WithStack(t.w.WriteHeader(&tar.Header{Typeflag: tar.TypeSymlink, Name: name, Linkname: target, Mode: 0777, ModTime: t.mtime}))
+}
+
+type zipArchiveWriter struct {
+	w     *zip.Writer
+	mtime time.Time
+}
+
+func (z *zipArchiveWriter) Dir(name string) error {
+	h := &zip.FileHeader{Name: name, Modified: z.mtime}
+	h.SetMode(os.ModeDir | 0755)
+	_, err := z.w.CreateHeader(h)
+	return xerrors.WithStack(err)
+}
+
+func (z *zipArchiveWriter) File(name string, mode filemode.FileMode, _ int64, r io.Reader) error {
+	h := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: z.mtime}
+	h.SetMode(os.FileMode(archiveFileMode(mode)))
+	fw, err := z.w.CreateHeader(h)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	if _, err := io.Copy(fw, r); err != nil {
+		return xerrors.WithStack(err)
+	}
+	return nil
+}
+
+func (z *zipArchiveWriter) Symlink(name, target string) error {
+	h := &zip.FileHeader{Name: name, Modified: z.mtime}
+	h.SetMode(os.ModeSymlink | 0777)
+	fw, err := z.w.CreateHeader(h)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	_, err = io.WriteString(fw, target)
+	return xerrors.WithStack(err)
+}
+
+func archiveFileMode(mode filemode.FileMode) int64 {
+	if mode == filemode.Executable {
+		return 0755
+	}
+	return 0644
+}
+
+// splitArchivePath splits /<repo>/archive/<ref>.<ext> into the repository name, the ref and the format.
+// The ref may have slashes.
+func splitArchivePath(p string) (string, string, ArchiveFormat, bool) {
+	p = strings.TrimPrefix(p, "/")
+	name, rest, ok := strings.Cut(p, "/archive/")
+	if !ok || name == "" {
+		return "", "", ArchiveFormat_ARCHIVE_FORMAT_UNSPECIFIED, false
+	}
+	for _, v := range archiveExtensions {
+		if rev, ok := strings.CutSuffix(rest, v.Ext); ok && rev != "" {
+			return strings.TrimSuffix(name, ".git"), rev, v.Format, true
+		}
+	}
+	return "", "", ArchiveFormat_ARCHIVE_FORMAT_UNSPECIFIED, false
+}
+
+// serveArchive writes the archive. The query parameters are path, exclude (multiple) and prefix,
+// which are the same as RequestGetArchive.
+func (s *SmartHTTPServer) serveArchive(w http.ResponseWriter, req *http.Request, name, rev string, format ArchiveFormat) {
+	if req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	repo, ok := s.service.lookup(name)
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	commit, err := resolveCommit(repo, rev)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	q := req.URL.Query()
+	a, err := newArchive(commit, archiveOptions{Format: format, Path: q.Get("path"), Excludes: q["exclude"], Prefix: q.Get("prefix")})
+	if err != nil {
+		if errors.Is(err, ErrArchivePathNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	var ext, contentType string
+	for _, v := range archiveExtensions {
+		if v.Format == format {
+			ext, contentType = v.Ext, v.ContentType
+			break
+		}
+	}
+	filename := path.Base(name) + "-" + strings.ReplaceAll(rev, "/", "-") + ext
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	if err := a.Write(w); err != nil {
+		slogger.Log.Info("Failed to write archive", slog.String("repo", name), slog.String("ref", rev), slogger.E(err))
+	}
+}
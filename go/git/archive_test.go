@@ -0,0 +1,122 @@
+package git
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go.f110.dev/mono/go/logger"
+	"go.f110.dev/mono/go/logger/slogger"
+	"go.f110.dev/mono/go/storage"
+)
+
+func TestGetArchive(t *testing.T) {
+	logger.SetLogLevel("debug")
+	logger.Init()
+	slogger.Init()
+
+	repo := makeSourceRepository(t)
+	head := addCommit(t, repo, "docs/design/draft.md", "draft")
+
+	t.Run("gRPC", func(t *testing.T) {
+		conn := startServer(t, storage.NewMock(), map[string]*goGit.Repository{"test/test1": repo})
+		client := NewGitDataClient(conn)
+
+		stream, err := client.GetArchive(context.Background(), &RequestGetArchive{
+			Repo:     "test1",
+			Ref:      "refs/heads/master",
+			Path:     "docs",
+			Excludes: []string{"docs/design/draft.md"},
+			Prefix:   "docs-archive/",
+		})
+		require.NoError(t, err)
+		buf := new(bytes.Buffer)
+		var sha string
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			if res.Sha != "" {
+				sha = res.Sha
+			}
+			buf.Write(res.Data)
+		}
+		assert.Equal(t, head.String(), sha)
+
+		gr, err := gzip.NewReader(buf)
+		require.NoError(t, err)
+		tr := tar.NewReader(gr)
+		files := make(map[string]string)
+		var names []string
+		for {
+			h, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			if h.Typeflag == tar.TypeXGlobalHeader {
+				assert.Equal(t, head.String(), h.PAXRecords["comment"])
+				continue
+			}
+			names = append(names, h.Name)
+			b, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			files[h.Name] = string(b)
+		}
+		assert.Equal(t, []string{"docs-archive/README.md", "docs-archive/design/", "docs-archive/design/README.md"}, names)
+		assert.Equal(t, "docs", files["docs-archive/README.md"])
+
+		stream, err = client.GetArchive(context.Background(), &RequestGetArchive{Repo: "test1", Ref: "master", Path: "not-found"})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("HTTP", func(t *testing.T) {
+		mockStorage := storage.NewMock()
+		registerToStorage(t, mockStorage, repo, "test")
+		// The archive is read from the object storage.
+		stored, err := goGit.Open(NewObjectStorageStorer(mockStorage, "test", nil, nil), nil)
+		require.NoError(t, err)
+		svc, err := NewDataServiceWithGoGit(map[string]*goGit.Repository{"test": stored})
+		require.NoError(t, err)
+		s := httptest.NewServer(NewSmartHTTPServer(svc))
+		t.Cleanup(s.Close)
+
+		res, err := http.Get(s.URL + "/test.git/archive/master.zip?exclude=docs/design")
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "application/zip", res.Header.Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=test-master.zip`, res.Header.Get("Content-Disposition"))
+		b, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+		require.NoError(t, err)
+		assert.Equal(t, head.String(), zr.Comment)
+		var names []string
+		for _, f := range zr.File {
+			names = append(names, f.Name)
+		}
+		assert.Equal(t, []string{"README.md", "docs/", "docs/README.md"}, names)
+
+		res, err = http.Get(s.URL + "/test/archive/unknown.tar.gz")
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	})
+}
@@ -0,0 +1,298 @@
+package git
+
+import (
+	"bytes"
+	"container/heap"
+	"io"
+	"log/slog"
+	"time"
+
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	commitgraphFormat "github.com/go-git/go-git/v5/plumbing/format/commitgraph/v2"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/object/commitgraph"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"go.f110.dev/xerrors"
+
+	"go.f110.dev/mono/go/logger/slogger"
+)
+
+const (
+	// commitGraphPath is the same path as git's commit-graph file.
+	commitGraphPath = "objects/info/commit-graph"
+	// commitGraphTTL is the duration for which a loaded commit-graph is reused.
+	// The other replicas may rewrite the file, so it is loaded again after the TTL.
+	commitGraphTTL = 5 * time.Minute
+)
+
+// commitGraphStorer is implemented by the storers which can persist the commit-graph.
+type commitGraphStorer interface {
+	CommitGraph() (commitgraphFormat.Index, error)
+	SetCommitGraph(idx commitgraphFormat.Index) error
+}
+
+// commitNodeIndex returns the CommitNodeIndex of repo. It reads the commits from the
+// commit-graph if the storer has it. The commits which are not in the commit-graph yet
+// are read from the object.
+func commitNodeIndex(repo *goGit.Repository) commitgraph.CommitNodeIndex {
+	if s, ok := repo.Storer.(commitGraphStorer); ok {
+		idx, err := s.CommitGraph()
+		if err != nil {
+			slogger.Log.Warn("Failed to load commit-graph", slogger.E(err))
+		}
+		if idx != nil {
+			return commitgraph.NewGraphCommitNodeIndex(idx, repo.Storer)
+		}
+	}
+	return commitgraph.NewObjectCommitNodeIndex(repo.Storer)
+}
+
+// UpdateCommitGraph writes the commit-graph which has all commits reachable from the
+// references of repo. The commits in the current commit-graph are not read again, so
+// updating after a fetch reads only the fetched commits.
+// It does nothing if the storer of repo can't persist the commit-graph.
+func UpdateCommitGraph(repo *goGit.Repository) error {
+	s, ok := repo.Storer.(commitGraphStorer)
+	if !ok {
+		return nil
+	}
+	current, err := s.CommitGraph()
+	if err != nil {
+		return err
+	}
+
+	tips, err := referencedCommits(repo.Storer)
+	if err != nil {
+		return err
+	}
+	idx, added, err := buildCommitGraph(repo.Storer, tips, current)
+	if err != nil {
+		return err
+	}
+	if added == 0 {
+		return nil
+	}
+	if err := s.SetCommitGraph(idx); err != nil {
+		return err
+	}
+	slogger.Log.Debug("Updated commit-graph", slog.Int("added", added), slog.Int("commits", len(idx.Hashes())))
+	return nil
+}
+
+// referencedCommits returns the commits pointed by the references. Annotated tags are peeled.
+func referencedCommits(st storer.Storer) ([]plumbing.Hash, error) {
+	iter, err := st.IterReferences()
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	defer iter.Close()
+
+	var commits []plumbing.Hash
+	for {
+		ref, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		if ref.Type() != plumbing.HashReference {
+			continue
+		}
+		h := ref.Hash()
+		for {
+			tag, err := object.GetTag(st, h)
+			if err != nil {
+				break
+			}
+			h = tag.Target
+		}
+		if _, err := object.GetCommit(st, h); err != nil {
+			continue
+		}
+		commits = append(commits, h)
+	}
+	return commits, nil
+}
+
+// buildCommitGraph returns the commit-graph which has the commits of base and the commits
+// reachable from tips. The second return value is the number of the commits which are not in base.
+func buildCommitGraph(st storer.EncodedObjectStorer, tips []plumbing.Hash, base commitgraphFormat.Index) (*commitgraphFormat.MemoryIndex, int, error) {
+	idx := commitgraphFormat.NewMemoryIndex()
+	data := make(map[plumbing.Hash]*commitgraphFormat.CommitData)
+	if base != nil {
+		for _, h := range base.Hashes() {
+			i, err := base.GetIndexByHash(h)
+			if err != nil {
+				return nil, 0, xerrors.WithStack(err)
+			}
+			d, err := base.GetCommitDataByIndex(i)
+			if err != nil {
+				return nil, 0, xerrors.WithStack(err)
+			}
+			data[h] = &commitgraphFormat.CommitData{
+				TreeHash:     d.TreeHash,
+				ParentHashes: d.ParentHashes,
+				Generation:   d.Generation,
+				GenerationV2: d.GenerationV2,
+				When:         d.When,
+			}
+		}
+	}
+	baseLen := len(data)
+
+	// The generation of a commit is computed after all of its parents, so the commits are
+	// visited in the post-order.
+	commits := make(map[plumbing.Hash]*object.Commit)
+	stack := append([]plumbing.Hash{}, tips...)
+	for len(stack) > 0 {
+		h := stack[len(stack)-1]
+		if _, ok := data[h]; ok {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		c, ok := commits[h]
+		if !ok {
+			commit, err := object.GetCommit(st, h)
+			if err != nil {
+				return nil, 0, xerrors.WithMessagef(err, "failed to read commit %s", h)
+			}
+			commits[h] = commit
+			c = commit
+		}
+
+		var pending bool
+		for _, p := range c.ParentHashes {
+			if _, ok := data[p]; !ok {
+				stack = append(stack, p)
+				pending = true
+			}
+		}
+		if pending {
+			continue
+		}
+		stack = stack[:len(stack)-1]
+
+		d := &commitgraphFormat.CommitData{
+			TreeHash:     c.TreeHash,
+			ParentHashes: c.ParentHashes,
+			Generation:   1,
+			GenerationV2: uint64(c.Committer.When.Unix()),
+			When:         c.Committer.When,
+		}
+		for _, p := range c.ParentHashes {
+			d.Generation = max(d.Generation, data[p].Generation+1)
+			d.GenerationV2 = max(d.GenerationV2, data[p].GenerationV2+1)
+		}
+		data[h] = d
+	}
+
+	for h, d := range data {
+		idx.Add(h, d)
+	}
+	return idx, len(data) - baseLen, nil
+}
+
+func encodeCommitGraph(idx commitgraphFormat.Index) (*bytes.Buffer, error) {
+	buf := new(bytes.Buffer)
+	if err := commitgraphFormat.NewEncoder(buf).Encode(idx); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	return buf, nil
+}
+
+func decodeCommitGraph(b []byte) (commitgraphFormat.Index, error) {
+	idx, err := commitgraphFormat.OpenFileIndex(bytesReaderAt{Reader: bytes.NewReader(b)})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	return idx, nil
+}
+
+type bytesReaderAt struct {
+	*bytes.Reader
+}
+
+func (bytesReaderAt) Close() error {
+	return nil
+}
+
+// commitWalker walks the commits from the newest commit time like git log.
+// The commits which have been found but not walked yet are the frontier. The walk can be resumed from the frontier.
+type commitWalker struct {
+	queue commitNodeQueue
+	seen  map[plumbing.Hash]struct{}
+}
+
+func newCommitWalker(frontier ...commitgraph.CommitNode) *commitWalker {
+	w := &commitWalker{seen: make(map[plumbing.Hash]struct{})}
+	for _, v := range frontier {
+		w.push(v)
+	}
+	return w
+}
+
+// Peek returns the newest commit of the frontier. It returns nil if all commits have been walked.
+func (w *commitWalker) Peek() commitgraph.CommitNode {
+	if len(w.queue) == 0 {
+		return nil
+	}
+	return w.queue[0]
+}
+
+// Pop removes the newest commit from the frontier and adds its parents to the frontier.
+func (w *commitWalker) Pop() error {
+	node := heap.Pop(&w.queue).(commitgraph.CommitNode)
+	for i := range node.NumParents() {
+		parent, err := node.ParentNode(i)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		w.push(parent)
+	}
+	return nil
+}
+
+// Frontier returns the hashes of the commits which have not been walked yet.
+func (w *commitWalker) Frontier() []plumbing.Hash {
+	hashes := make([]plumbing.Hash, len(w.queue))
+	for i, v := range w.queue {
+		hashes[i] = v.ID()
+	}
+	return hashes
+}
+
+func (w *commitWalker) push(node commitgraph.CommitNode) {
+	if _, ok := w.seen[node.ID()]; ok {
+		return
+	}
+	w.seen[node.ID()] = struct{}{}
+	heap.Push(&w.queue, node)
+}
+
+// commitNodeQueue is the priority queue of the commits. The newest commit comes first.
+type commitNodeQueue []commitgraph.CommitNode
+
+func (q commitNodeQueue) Len() int { return len(q) }
+
+func (q commitNodeQueue) Less(i, j int) bool {
+	if !q[i].CommitTime().Equal(q[j].CommitTime()) {
+		return q[i].CommitTime().After(q[j].CommitTime())
+	}
+	// The order of the commits at the same time has to be stable to resume the walk.
+	a, b := q[i].ID(), q[j].ID()
+	return bytes.Compare(a[:], b[:]) < 0
+}
+
+func (q commitNodeQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *commitNodeQueue) Push(x any) { *q = append(*q, x.(commitgraph.CommitNode)) }
+
+func (q *commitNodeQueue) Pop() any {
+	old := *q
+	n := len(old)
+	v := old[n-1]
+	*q = old[:n-1]
+	return v
+}
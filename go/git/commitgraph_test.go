@@ -0,0 +1,65 @@
+package git
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.f110.dev/mono/go/logger"
+	"go.f110.dev/mono/go/logger/slogger"
+	"go.f110.dev/mono/go/storage"
+)
+
+func TestUpdateCommitGraph(t *testing.T) {
+	logger.SetLogLevel("debug")
+	logger.Init()
+	slogger.Init()
+
+	sourceRepo := makeSourceRepository(t)
+	now := time.Now()
+	first := addCommitAt(t, sourceRepo, "CHANGELOG.md", "v1", "Alice", now.Add(time.Hour))
+	mockStorage := storage.NewMock()
+	registerToStorage(t, mockStorage, sourceRepo, "test")
+
+	repo, err := goGit.Open(NewObjectStorageStorer(mockStorage, "test", nil, nil), nil)
+	require.NoError(t, err)
+	require.NoError(t, UpdateCommitGraph(repo))
+	_, err = mockStorage.Get(context.Background(), "test/"+commitGraphPath)
+	require.NoError(t, err)
+
+	idx, err := repo.Storer.(*ObjectStorageStorer).CommitGraph()
+	require.NoError(t, err)
+	require.NotNil(t, idx)
+	assert.Len(t, idx.Hashes(), 2)
+	i, err := idx.GetIndexByHash(first)
+	require.NoError(t, err)
+	data, err := idx.GetCommitDataByIndex(i)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, data.Generation)
+
+	// Only the new commit is added to the current commit-graph.
+	second := addCommitAt(t, sourceRepo, "CHANGELOG.md", "v2", "Bob", now.Add(2*time.Hour))
+	registerToStorage(t, mockStorage, sourceRepo, "test")
+	repo, err = goGit.Open(NewObjectStorageStorer(mockStorage, "test", nil, nil), nil)
+	require.NoError(t, err)
+	require.NoError(t, UpdateCommitGraph(repo))
+	idx, err = repo.Storer.(*ObjectStorageStorer).CommitGraph()
+	require.NoError(t, err)
+	assert.Len(t, idx.Hashes(), 3)
+
+	// ListCommits reads the commit-graph.
+	svc, err := NewDataServiceWithGoGit(map[string]*goGit.Repository{"test": repo})
+	require.NoError(t, err)
+	res, err := svc.ListCommits(context.Background(), &RequestListCommits{Repo: "test", Ref: "HEAD", Path: "CHANGELOG.md"})
+	require.NoError(t, err)
+	var shas []plumbing.Hash
+	for _, v := range res.Commits {
+		shas = append(shas, plumbing.NewHash(v.Sha))
+	}
+	assert.Equal(t, []plumbing.Hash{second, first}, shas)
+}
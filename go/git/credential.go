@@ -0,0 +1,121 @@
+package git
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	gitHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"go.f110.dev/xerrors"
+
+	"go.f110.dev/mono/go/githubutil"
+)
+
+// CredentialProvider returns the credential for the upstream of a repository.
+// AuthMethod is called for every fetch, so the provider can return the rotated credential.
+type CredentialProvider interface {
+	AuthMethod(ctx context.Context) (transport.AuthMethod, error)
+}
+
+// GitHubAppCredential is the credential from the installation token of GitHub App or
+// the personal access token. This is used if RepositoryConfig of the repository on github.com
+// doesn't have the credential. If TokenProvider is nil, the token provider of Updater is used.
+type GitHubAppCredential struct {
+	TokenProvider *githubutil.TokenProvider
+}
+
+var _ CredentialProvider = (*GitHubAppCredential)(nil)
+
+func (c *GitHubAppCredential) AuthMethod(ctx context.Context) (transport.AuthMethod, error) {
+	if c.TokenProvider == nil {
+		return nil, nil
+	}
+	v, err := c.TokenProvider.Token(ctx)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	return &gitHttp.BasicAuth{Username: "octocat", Password: v}, nil
+}
+
+// AnonymousCredential doesn't send any credential. This is used for the public repository
+// which is not hosted on GitHub so that the token of GitHub is not sent to the other host.
+type AnonymousCredential struct{}
+
+var _ CredentialProvider = AnonymousCredential{}
+
+func (AnonymousCredential) AuthMethod(_ context.Context) (transport.AuthMethod, error) {
+	return nil, nil
+}
+
+// TokenCredential sends the static token as the password of the basic authentication.
+// Gitea accepts any username with the access token, and GitLab requires "oauth2".
+type TokenCredential struct {
+	Username string
+	Token    string
+}
+
+var _ CredentialProvider = (*TokenCredential)(nil)
+
+func (c *TokenCredential) AuthMethod(_ context.Context) (transport.AuthMethod, error) {
+	username := c.Username
+	if username == "" {
+		username = "git"
+	}
+	return &gitHttp.BasicAuth{Username: username, Password: c.Token}, nil
+}
+
+// BasicAuthFileCredential reads the username and the password from the file. The file has
+// "username:password" in the first line. The file is read every time, so the rotated
+// password is used without restarting.
+type BasicAuthFileCredential struct {
+	Path string
+}
+
+var _ CredentialProvider = (*BasicAuthFileCredential)(nil)
+
+func (c *BasicAuthFileCredential) AuthMethod(_ context.Context) (transport.AuthMethod, error) {
+	b, err := os.ReadFile(c.Path)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	line, _, _ := strings.Cut(string(b), "\n")
+	username, password, ok := strings.Cut(strings.TrimSpace(line), ":")
+	if !ok {
+		return nil, xerrors.Definef("%s doesn't have username:password", c.Path).WithStack()
+	}
+	return &gitHttp.BasicAuth{Username: username, Password: password}, nil
+}
+
+// SSHKeyCredential authenticates with the private key. The host key is verified by
+// KnownHostsFile. The connection to the host which is not in KnownHostsFile is refused.
+type SSHKeyCredential struct {
+	// User is the login user. "git" is used if empty.
+	User           string
+	PrivateKeyFile string
+	Passphrase     string
+	KnownHostsFile string
+}
+
+var _ CredentialProvider = (*SSHKeyCredential)(nil)
+
+func (c *SSHKeyCredential) AuthMethod(_ context.Context) (transport.AuthMethod, error) {
+	if c.KnownHostsFile == "" {
+		return nil, xerrors.Define("known_hosts is required for SSH").WithStack()
+	}
+	user := c.User
+	if user == "" {
+		user = "git"
+	}
+	auth, err := ssh.NewPublicKeysFromFile(user, c.PrivateKeyFile, c.Passphrase)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	cb, err := ssh.NewKnownHostsCallback(c.KnownHostsFile)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	auth.HostKeyCallback = cb
+	return auth, nil
+}
@@ -26,6 +26,110 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type FileChangeStatus int32
+
+const (
+	FileChangeStatus_FILE_CHANGE_STATUS_UNSPECIFIED FileChangeStatus = 0
+	FileChangeStatus_FILE_CHANGE_STATUS_ADDED       FileChangeStatus = 1
+	FileChangeStatus_FILE_CHANGE_STATUS_MODIFIED    FileChangeStatus = 2
+	FileChangeStatus_FILE_CHANGE_STATUS_RENAMED     FileChangeStatus = 3
+	FileChangeStatus_FILE_CHANGE_STATUS_DELETED     FileChangeStatus = 4
+)
+
+// Enum value maps for FileChangeStatus.
+var (
+	FileChangeStatus_name = map[int32]string{
+		0: "FILE_CHANGE_STATUS_UNSPECIFIED",
+		1: "FILE_CHANGE_STATUS_ADDED",
+		2: "FILE_CHANGE_STATUS_MODIFIED",
+		3: "FILE_CHANGE_STATUS_RENAMED",
+		4: "FILE_CHANGE_STATUS_DELETED",
+	}
+	FileChangeStatus_value = map[string]int32{
+		"FILE_CHANGE_STATUS_UNSPECIFIED": 0,
+		"FILE_CHANGE_STATUS_ADDED":       1,
+		"FILE_CHANGE_STATUS_MODIFIED":    2,
+		"FILE_CHANGE_STATUS_RENAMED":     3,
+		"FILE_CHANGE_STATUS_DELETED":     4,
+	}
+)
+
+func (x FileChangeStatus) Enum() *FileChangeStatus {
+	p := new(FileChangeStatus)
+	*p = x
+	return p
+}
+
+func (x FileChangeStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FileChangeStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_git_data_proto_enumTypes[0].Descriptor()
+}
+
+func (FileChangeStatus) Type() protoreflect.EnumType {
+	return &file_proto_git_data_proto_enumTypes[0]
+}
+
+func (x FileChangeStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FileChangeStatus.Descriptor instead.
+func (FileChangeStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{0}
+}
+
+type ArchiveFormat int32
+
+const (
+	ArchiveFormat_ARCHIVE_FORMAT_UNSPECIFIED ArchiveFormat = 0
+	ArchiveFormat_ARCHIVE_FORMAT_TAR_GZ      ArchiveFormat = 1
+	ArchiveFormat_ARCHIVE_FORMAT_ZIP         ArchiveFormat = 2
+)
+
+// Enum value maps for ArchiveFormat.
+var (
+	ArchiveFormat_name = map[int32]string{
+		0: "ARCHIVE_FORMAT_UNSPECIFIED",
+		1: "ARCHIVE_FORMAT_TAR_GZ",
+		2: "ARCHIVE_FORMAT_ZIP",
+	}
+	ArchiveFormat_value = map[string]int32{
+		"ARCHIVE_FORMAT_UNSPECIFIED": 0,
+		"ARCHIVE_FORMAT_TAR_GZ":      1,
+		"ARCHIVE_FORMAT_ZIP":         2,
+	}
+)
+
+func (x ArchiveFormat) Enum() *ArchiveFormat {
+	p := new(ArchiveFormat)
+	*p = x
+	return p
+}
+
+func (x ArchiveFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ArchiveFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_git_data_proto_enumTypes[1].Descriptor()
+}
+
+func (ArchiveFormat) Type() protoreflect.EnumType {
+	return &file_proto_git_data_proto_enumTypes[1]
+}
+
+func (x ArchiveFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ArchiveFormat.Descriptor instead.
+func (ArchiveFormat) EnumDescriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{1}
+}
+
 type Reference struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -298,6 +402,127 @@ func (x *Signature) GetWhen() *timestamppb.Timestamp {
 	return nil
 }
 
+type FileChange struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Status FileChangeStatus       `protobuf:"varint,1,opt,name=status,proto3,enum=mono.git.FileChangeStatus" json:"status,omitempty"`
+	// path is the path in head. The path in base for the deleted file.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// previous_path is the path in base. It is set for the renamed file.
+	PreviousPath string `protobuf:"bytes,3,opt,name=previous_path,json=previousPath,proto3" json:"previous_path,omitempty"`
+	Sha          string `protobuf:"bytes,4,opt,name=sha,proto3" json:"sha,omitempty"`
+	PreviousSha  string `protobuf:"bytes,5,opt,name=previous_sha,json=previousSha,proto3" json:"previous_sha,omitempty"`
+	// additions, deletions and patch are set when the patch is requested.
+	Additions int32  `protobuf:"varint,6,opt,name=additions,proto3" json:"additions,omitempty"`
+	Deletions int32  `protobuf:"varint,7,opt,name=deletions,proto3" json:"deletions,omitempty"`
+	Patch     string `protobuf:"bytes,8,opt,name=patch,proto3" json:"patch,omitempty"`
+	// patch_truncated is true if the patch is larger than the limit. The patch
+	// isn't computed for the blob which is too large.
+	PatchTruncated bool `protobuf:"varint,9,opt,name=patch_truncated,json=patchTruncated,proto3" json:"patch_truncated,omitempty"`
+	Binary         bool `protobuf:"varint,10,opt,name=binary,proto3" json:"binary,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FileChange) Reset() {
+	*x = FileChange{}
+	mi := &file_proto_git_data_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileChange) ProtoMessage() {}
+
+func (x *FileChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChange.ProtoReflect.Descriptor instead.
+func (*FileChange) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FileChange) GetStatus() FileChangeStatus {
+	if x != nil {
+		return x.Status
+	}
+	return FileChangeStatus_FILE_CHANGE_STATUS_UNSPECIFIED
+}
+
+func (x *FileChange) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileChange) GetPreviousPath() string {
+	if x != nil {
+		return x.PreviousPath
+	}
+	return ""
+}
+
+func (x *FileChange) GetSha() string {
+	if x != nil {
+		return x.Sha
+	}
+	return ""
+}
+
+func (x *FileChange) GetPreviousSha() string {
+	if x != nil {
+		return x.PreviousSha
+	}
+	return ""
+}
+
+func (x *FileChange) GetAdditions() int32 {
+	if x != nil {
+		return x.Additions
+	}
+	return 0
+}
+
+func (x *FileChange) GetDeletions() int32 {
+	if x != nil {
+		return x.Deletions
+	}
+	return 0
+}
+
+func (x *FileChange) GetPatch() string {
+	if x != nil {
+		return x.Patch
+	}
+	return ""
+}
+
+func (x *FileChange) GetPatchTruncated() bool {
+	if x != nil {
+		return x.PatchTruncated
+	}
+	return false
+}
+
+func (x *FileChange) GetBinary() bool {
+	if x != nil {
+		return x.Binary
+	}
+	return false
+}
+
 type Repository struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -310,7 +535,7 @@ type Repository struct {
 
 func (x *Repository) Reset() {
 	*x = Repository{}
-	mi := &file_proto_git_data_proto_msgTypes[4]
+	mi := &file_proto_git_data_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -322,7 +547,7 @@ func (x *Repository) String() string {
 func (*Repository) ProtoMessage() {}
 
 func (x *Repository) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[4]
+	mi := &file_proto_git_data_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -335,7 +560,7 @@ func (x *Repository) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Repository.ProtoReflect.Descriptor instead.
 func (*Repository) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{4}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Repository) GetName() string {
@@ -374,7 +599,7 @@ type RequestListRepositories struct {
 
 func (x *RequestListRepositories) Reset() {
 	*x = RequestListRepositories{}
-	mi := &file_proto_git_data_proto_msgTypes[5]
+	mi := &file_proto_git_data_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -386,7 +611,7 @@ func (x *RequestListRepositories) String() string {
 func (*RequestListRepositories) ProtoMessage() {}
 
 func (x *RequestListRepositories) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[5]
+	mi := &file_proto_git_data_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -399,7 +624,7 @@ func (x *RequestListRepositories) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestListRepositories.ProtoReflect.Descriptor instead.
 func (*RequestListRepositories) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{5}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{6}
 }
 
 type ResponseListRepositories struct {
@@ -411,7 +636,7 @@ type ResponseListRepositories struct {
 
 func (x *ResponseListRepositories) Reset() {
 	*x = ResponseListRepositories{}
-	mi := &file_proto_git_data_proto_msgTypes[6]
+	mi := &file_proto_git_data_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -423,7 +648,7 @@ func (x *ResponseListRepositories) String() string {
 func (*ResponseListRepositories) ProtoMessage() {}
 
 func (x *ResponseListRepositories) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[6]
+	mi := &file_proto_git_data_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -436,7 +661,7 @@ func (x *ResponseListRepositories) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseListRepositories.ProtoReflect.Descriptor instead.
 func (*ResponseListRepositories) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{6}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ResponseListRepositories) GetRepositories() []*Repository {
@@ -455,7 +680,7 @@ type RequestListReferences struct {
 
 func (x *RequestListReferences) Reset() {
 	*x = RequestListReferences{}
-	mi := &file_proto_git_data_proto_msgTypes[7]
+	mi := &file_proto_git_data_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -467,7 +692,7 @@ func (x *RequestListReferences) String() string {
 func (*RequestListReferences) ProtoMessage() {}
 
 func (x *RequestListReferences) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[7]
+	mi := &file_proto_git_data_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -480,7 +705,7 @@ func (x *RequestListReferences) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestListReferences.ProtoReflect.Descriptor instead.
 func (*RequestListReferences) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{7}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RequestListReferences) GetRepo() string {
@@ -499,7 +724,7 @@ type ResponseListReferences struct {
 
 func (x *ResponseListReferences) Reset() {
 	*x = ResponseListReferences{}
-	mi := &file_proto_git_data_proto_msgTypes[8]
+	mi := &file_proto_git_data_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -511,7 +736,7 @@ func (x *ResponseListReferences) String() string {
 func (*ResponseListReferences) ProtoMessage() {}
 
 func (x *ResponseListReferences) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[8]
+	mi := &file_proto_git_data_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -524,7 +749,7 @@ func (x *ResponseListReferences) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseListReferences.ProtoReflect.Descriptor instead.
 func (*ResponseListReferences) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{8}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ResponseListReferences) GetRefs() []*Reference {
@@ -543,7 +768,7 @@ type RequestGetRepository struct {
 
 func (x *RequestGetRepository) Reset() {
 	*x = RequestGetRepository{}
-	mi := &file_proto_git_data_proto_msgTypes[9]
+	mi := &file_proto_git_data_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -555,7 +780,7 @@ func (x *RequestGetRepository) String() string {
 func (*RequestGetRepository) ProtoMessage() {}
 
 func (x *RequestGetRepository) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[9]
+	mi := &file_proto_git_data_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -568,7 +793,7 @@ func (x *RequestGetRepository) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestGetRepository.ProtoReflect.Descriptor instead.
 func (*RequestGetRepository) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{9}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *RequestGetRepository) GetRepo() string {
@@ -589,7 +814,7 @@ type ResponseGetRepository struct {
 
 func (x *ResponseGetRepository) Reset() {
 	*x = ResponseGetRepository{}
-	mi := &file_proto_git_data_proto_msgTypes[10]
+	mi := &file_proto_git_data_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -601,7 +826,7 @@ func (x *ResponseGetRepository) String() string {
 func (*ResponseGetRepository) ProtoMessage() {}
 
 func (x *ResponseGetRepository) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[10]
+	mi := &file_proto_git_data_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -614,7 +839,7 @@ func (x *ResponseGetRepository) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseGetRepository.ProtoReflect.Descriptor instead.
 func (*ResponseGetRepository) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{10}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ResponseGetRepository) GetName() string {
@@ -648,7 +873,7 @@ type RequestGetReference struct {
 
 func (x *RequestGetReference) Reset() {
 	*x = RequestGetReference{}
-	mi := &file_proto_git_data_proto_msgTypes[11]
+	mi := &file_proto_git_data_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -660,7 +885,7 @@ func (x *RequestGetReference) String() string {
 func (*RequestGetReference) ProtoMessage() {}
 
 func (x *RequestGetReference) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[11]
+	mi := &file_proto_git_data_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -673,7 +898,7 @@ func (x *RequestGetReference) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestGetReference.ProtoReflect.Descriptor instead.
 func (*RequestGetReference) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{11}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *RequestGetReference) GetRepo() string {
@@ -699,7 +924,7 @@ type ResponseGetReference struct {
 
 func (x *ResponseGetReference) Reset() {
 	*x = ResponseGetReference{}
-	mi := &file_proto_git_data_proto_msgTypes[12]
+	mi := &file_proto_git_data_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -711,7 +936,7 @@ func (x *ResponseGetReference) String() string {
 func (*ResponseGetReference) ProtoMessage() {}
 
 func (x *ResponseGetReference) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[12]
+	mi := &file_proto_git_data_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -724,7 +949,7 @@ func (x *ResponseGetReference) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseGetReference.ProtoReflect.Descriptor instead.
 func (*ResponseGetReference) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{12}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ResponseGetReference) GetRef() *Reference {
@@ -745,7 +970,7 @@ type RequestGetCommit struct {
 
 func (x *RequestGetCommit) Reset() {
 	*x = RequestGetCommit{}
-	mi := &file_proto_git_data_proto_msgTypes[13]
+	mi := &file_proto_git_data_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -757,7 +982,7 @@ func (x *RequestGetCommit) String() string {
 func (*RequestGetCommit) ProtoMessage() {}
 
 func (x *RequestGetCommit) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[13]
+	mi := &file_proto_git_data_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -770,7 +995,7 @@ func (x *RequestGetCommit) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestGetCommit.ProtoReflect.Descriptor instead.
 func (*RequestGetCommit) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{13}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *RequestGetCommit) GetRepo() string {
@@ -803,7 +1028,7 @@ type ResponseGetCommit struct {
 
 func (x *ResponseGetCommit) Reset() {
 	*x = ResponseGetCommit{}
-	mi := &file_proto_git_data_proto_msgTypes[14]
+	mi := &file_proto_git_data_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -815,7 +1040,7 @@ func (x *ResponseGetCommit) String() string {
 func (*ResponseGetCommit) ProtoMessage() {}
 
 func (x *ResponseGetCommit) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[14]
+	mi := &file_proto_git_data_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -828,7 +1053,7 @@ func (x *ResponseGetCommit) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseGetCommit.ProtoReflect.Descriptor instead.
 func (*ResponseGetCommit) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{14}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ResponseGetCommit) GetCommit() *Commit {
@@ -838,32 +1063,40 @@ func (x *ResponseGetCommit) GetCommit() *Commit {
 	return nil
 }
 
-type RequestGetTree struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Repo          string                 `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
-	Sha           string                 `protobuf:"bytes,2,opt,name=sha,proto3" json:"sha,omitempty"`
-	Ref           string                 `protobuf:"bytes,3,opt,name=ref,proto3" json:"ref,omitempty"`
-	Path          string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
-	Recursive     bool                   `protobuf:"varint,5,opt,name=recursive,proto3" json:"recursive,omitempty"`
+type RequestListCommits struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Repo  string                 `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	// The history is walked from sha or ref. One of them is required.
+	Sha string `protobuf:"bytes,2,opt,name=sha,proto3" json:"sha,omitempty"`
+	Ref string `protobuf:"bytes,3,opt,name=ref,proto3" json:"ref,omitempty"`
+	// path limits the commits to the ones which changed the file or the directory.
+	Path string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	// author matches a part of the name or the email of the author. Case insensitive.
+	Author string                 `protobuf:"bytes,5,opt,name=author,proto3" json:"author,omitempty"`
+	Since  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=since,proto3" json:"since,omitempty"`
+	Until  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=until,proto3" json:"until,omitempty"`
+	// page_size is 30 by default. The maximum is 100.
+	PageSize      int32  `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RequestGetTree) Reset() {
-	*x = RequestGetTree{}
-	mi := &file_proto_git_data_proto_msgTypes[15]
+func (x *RequestListCommits) Reset() {
+	*x = RequestListCommits{}
+	mi := &file_proto_git_data_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RequestGetTree) String() string {
+func (x *RequestListCommits) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RequestGetTree) ProtoMessage() {}
+func (*RequestListCommits) ProtoMessage() {}
 
-func (x *RequestGetTree) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[15]
+func (x *RequestListCommits) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -874,69 +1107,98 @@ func (x *RequestGetTree) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RequestGetTree.ProtoReflect.Descriptor instead.
-func (*RequestGetTree) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use RequestListCommits.ProtoReflect.Descriptor instead.
+func (*RequestListCommits) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *RequestGetTree) GetRepo() string {
+func (x *RequestListCommits) GetRepo() string {
 	if x != nil {
 		return x.Repo
 	}
 	return ""
 }
 
-func (x *RequestGetTree) GetSha() string {
+func (x *RequestListCommits) GetSha() string {
 	if x != nil {
 		return x.Sha
 	}
 	return ""
 }
 
-func (x *RequestGetTree) GetRef() string {
+func (x *RequestListCommits) GetRef() string {
 	if x != nil {
 		return x.Ref
 	}
 	return ""
 }
 
-func (x *RequestGetTree) GetPath() string {
+func (x *RequestListCommits) GetPath() string {
 	if x != nil {
 		return x.Path
 	}
 	return ""
 }
 
-func (x *RequestGetTree) GetRecursive() bool {
+func (x *RequestListCommits) GetAuthor() string {
 	if x != nil {
-		return x.Recursive
+		return x.Author
 	}
-	return false
+	return ""
 }
 
-type ResponseGetTree struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sha           string                 `protobuf:"bytes,1,opt,name=sha,proto3" json:"sha,omitempty"`
-	Tree          []*TreeEntry           `protobuf:"bytes,2,rep,name=tree,proto3" json:"tree,omitempty"`
+func (x *RequestListCommits) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *RequestListCommits) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *RequestListCommits) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *RequestListCommits) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ResponseListCommits struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// commits are ordered by the commit time. The newest one comes first.
+	Commits       []*Commit `protobuf:"bytes,1,rep,name=commits,proto3" json:"commits,omitempty"`
+	NextPageToken string    `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ResponseGetTree) Reset() {
-	*x = ResponseGetTree{}
-	mi := &file_proto_git_data_proto_msgTypes[16]
+func (x *ResponseListCommits) Reset() {
+	*x = ResponseListCommits{}
+	mi := &file_proto_git_data_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResponseGetTree) String() string {
+func (x *ResponseListCommits) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResponseGetTree) ProtoMessage() {}
+func (*ResponseListCommits) ProtoMessage() {}
 
-func (x *ResponseGetTree) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[16]
+func (x *ResponseListCommits) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -947,36 +1209,318 @@ func (x *ResponseGetTree) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResponseGetTree.ProtoReflect.Descriptor instead.
-func (*ResponseGetTree) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use ResponseListCommits.ProtoReflect.Descriptor instead.
+func (*ResponseListCommits) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *ResponseGetTree) GetSha() string {
+func (x *ResponseListCommits) GetCommits() []*Commit {
 	if x != nil {
-		return x.Sha
+		return x.Commits
 	}
-	return ""
+	return nil
 }
 
-func (x *ResponseGetTree) GetTree() []*TreeEntry {
+func (x *ResponseListCommits) GetNextPageToken() string {
 	if x != nil {
-		return x.Tree
+		return x.NextPageToken
 	}
-	return nil
+	return ""
 }
 
-type RequestGetBlob struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Repo          string                 `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
-	Sha           string                 `protobuf:"bytes,2,opt,name=sha,proto3" json:"sha,omitempty"`
+type RequestCompare struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Repo  string                 `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	// base and head are a commit hash or a ref name. The trees of the two commits are
+	// compared directly. Not from the merge base.
+	Base string `protobuf:"bytes,2,opt,name=base,proto3" json:"base,omitempty"`
+	Head string `protobuf:"bytes,3,opt,name=head,proto3" json:"head,omitempty"`
+	// include_patch makes FileChange have the unified diff.
+	IncludePatch bool `protobuf:"varint,4,opt,name=include_patch,json=includePatch,proto3" json:"include_patch,omitempty"`
+	// max_patch_bytes limits the size of the patch of each file. 64KiB by default.
+	MaxPatchBytes int64 `protobuf:"varint,5,opt,name=max_patch_bytes,json=maxPatchBytes,proto3" json:"max_patch_bytes,omitempty"`
+	// max_total_patch_bytes limits the sum of the size of the patches. The patches of the
+	// rest of files are omitted after the limit. 1MiB by default.
+	MaxTotalPatchBytes int64 `protobuf:"varint,6,opt,name=max_total_patch_bytes,json=maxTotalPatchBytes,proto3" json:"max_total_patch_bytes,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RequestCompare) Reset() {
+	*x = RequestCompare{}
+	mi := &file_proto_git_data_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestCompare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestCompare) ProtoMessage() {}
+
+func (x *RequestCompare) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestCompare.ProtoReflect.Descriptor instead.
+func (*RequestCompare) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RequestCompare) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *RequestCompare) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *RequestCompare) GetHead() string {
+	if x != nil {
+		return x.Head
+	}
+	return ""
+}
+
+func (x *RequestCompare) GetIncludePatch() bool {
+	if x != nil {
+		return x.IncludePatch
+	}
+	return false
+}
+
+func (x *RequestCompare) GetMaxPatchBytes() int64 {
+	if x != nil {
+		return x.MaxPatchBytes
+	}
+	return 0
+}
+
+func (x *RequestCompare) GetMaxTotalPatchBytes() int64 {
+	if x != nil {
+		return x.MaxTotalPatchBytes
+	}
+	return 0
+}
+
+type ResponseCompare struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// base and head are the resolved commit hashes.
+	Base          string        `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Head          string        `protobuf:"bytes,2,opt,name=head,proto3" json:"head,omitempty"`
+	Files         []*FileChange `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseCompare) Reset() {
+	*x = ResponseCompare{}
+	mi := &file_proto_git_data_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseCompare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseCompare) ProtoMessage() {}
+
+func (x *ResponseCompare) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseCompare.ProtoReflect.Descriptor instead.
+func (*ResponseCompare) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ResponseCompare) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *ResponseCompare) GetHead() string {
+	if x != nil {
+		return x.Head
+	}
+	return ""
+}
+
+func (x *ResponseCompare) GetFiles() []*FileChange {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type RequestGetTree struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Repo  string                 `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	// sha is a tree hash. not a commit hash.
+	Sha           string `protobuf:"bytes,2,opt,name=sha,proto3" json:"sha,omitempty"`
+	Ref           string `protobuf:"bytes,3,opt,name=ref,proto3" json:"ref,omitempty"`
+	Path          string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	Recursive     bool   `protobuf:"varint,5,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestGetTree) Reset() {
+	*x = RequestGetTree{}
+	mi := &file_proto_git_data_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestGetTree) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestGetTree) ProtoMessage() {}
+
+func (x *RequestGetTree) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestGetTree.ProtoReflect.Descriptor instead.
+func (*RequestGetTree) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RequestGetTree) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *RequestGetTree) GetSha() string {
+	if x != nil {
+		return x.Sha
+	}
+	return ""
+}
+
+func (x *RequestGetTree) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *RequestGetTree) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RequestGetTree) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
+}
+
+type ResponseGetTree struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// sha is a tree hash.
+	Sha           string       `protobuf:"bytes,1,opt,name=sha,proto3" json:"sha,omitempty"`
+	Tree          []*TreeEntry `protobuf:"bytes,2,rep,name=tree,proto3" json:"tree,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseGetTree) Reset() {
+	*x = ResponseGetTree{}
+	mi := &file_proto_git_data_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseGetTree) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseGetTree) ProtoMessage() {}
+
+func (x *ResponseGetTree) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseGetTree.ProtoReflect.Descriptor instead.
+func (*ResponseGetTree) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ResponseGetTree) GetSha() string {
+	if x != nil {
+		return x.Sha
+	}
+	return ""
+}
+
+func (x *ResponseGetTree) GetTree() []*TreeEntry {
+	if x != nil {
+		return x.Tree
+	}
+	return nil
+}
+
+type RequestGetBlob struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Repo  string                 `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	// sha is a blob object hash. not a commit hash.
+	Sha           string `protobuf:"bytes,2,opt,name=sha,proto3" json:"sha,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *RequestGetBlob) Reset() {
 	*x = RequestGetBlob{}
-	mi := &file_proto_git_data_proto_msgTypes[17]
+	mi := &file_proto_git_data_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -988,7 +1532,7 @@ func (x *RequestGetBlob) String() string {
 func (*RequestGetBlob) ProtoMessage() {}
 
 func (x *RequestGetBlob) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[17]
+	mi := &file_proto_git_data_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1001,7 +1545,7 @@ func (x *RequestGetBlob) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestGetBlob.ProtoReflect.Descriptor instead.
 func (*RequestGetBlob) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{17}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *RequestGetBlob) GetRepo() string {
@@ -1029,7 +1573,7 @@ type ResponseGetBlob struct {
 
 func (x *ResponseGetBlob) Reset() {
 	*x = ResponseGetBlob{}
-	mi := &file_proto_git_data_proto_msgTypes[18]
+	mi := &file_proto_git_data_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1040,8 +1584,510 @@ func (x *ResponseGetBlob) String() string {
 
 func (*ResponseGetBlob) ProtoMessage() {}
 
-func (x *ResponseGetBlob) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[18]
+func (x *ResponseGetBlob) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseGetBlob.ProtoReflect.Descriptor instead.
+func (*ResponseGetBlob) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ResponseGetBlob) GetSha() string {
+	if x != nil {
+		return x.Sha
+	}
+	return ""
+}
+
+func (x *ResponseGetBlob) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ResponseGetBlob) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type RequestGetFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Repo          string                 `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	Ref           string                 `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestGetFile) Reset() {
+	*x = RequestGetFile{}
+	mi := &file_proto_git_data_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestGetFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestGetFile) ProtoMessage() {}
+
+func (x *RequestGetFile) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestGetFile.ProtoReflect.Descriptor instead.
+func (*RequestGetFile) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RequestGetFile) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *RequestGetFile) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *RequestGetFile) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type ResponseGetFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       []byte                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	RawUrl        string                 `protobuf:"bytes,2,opt,name=raw_url,json=rawUrl,proto3" json:"raw_url,omitempty"`
+	EditUrl       string                 `protobuf:"bytes,3,opt,name=edit_url,json=editUrl,proto3" json:"edit_url,omitempty"`
+	Sha           string                 `protobuf:"bytes,4,opt,name=sha,proto3" json:"sha,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseGetFile) Reset() {
+	*x = ResponseGetFile{}
+	mi := &file_proto_git_data_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseGetFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseGetFile) ProtoMessage() {}
+
+func (x *ResponseGetFile) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseGetFile.ProtoReflect.Descriptor instead.
+func (*ResponseGetFile) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ResponseGetFile) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ResponseGetFile) GetRawUrl() string {
+	if x != nil {
+		return x.RawUrl
+	}
+	return ""
+}
+
+func (x *ResponseGetFile) GetEditUrl() string {
+	if x != nil {
+		return x.EditUrl
+	}
+	return ""
+}
+
+func (x *ResponseGetFile) GetSha() string {
+	if x != nil {
+		return x.Sha
+	}
+	return ""
+}
+
+type RequestGetBlame struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Repo  string                 `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	// ref is a commit hash or a ref name.
+	Ref           string `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	Path          string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestGetBlame) Reset() {
+	*x = RequestGetBlame{}
+	mi := &file_proto_git_data_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestGetBlame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestGetBlame) ProtoMessage() {}
+
+func (x *RequestGetBlame) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestGetBlame.ProtoReflect.Descriptor instead.
+func (*RequestGetBlame) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RequestGetBlame) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *RequestGetBlame) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *RequestGetBlame) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type ResponseGetBlame struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// sha is the resolved commit hash.
+	Sha           string        `protobuf:"bytes,1,opt,name=sha,proto3" json:"sha,omitempty"`
+	BlobSha       string        `protobuf:"bytes,2,opt,name=blob_sha,json=blobSha,proto3" json:"blob_sha,omitempty"`
+	Ranges        []*BlameRange `protobuf:"bytes,3,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseGetBlame) Reset() {
+	*x = ResponseGetBlame{}
+	mi := &file_proto_git_data_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseGetBlame) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseGetBlame) ProtoMessage() {}
+
+func (x *ResponseGetBlame) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseGetBlame.ProtoReflect.Descriptor instead.
+func (*ResponseGetBlame) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ResponseGetBlame) GetSha() string {
+	if x != nil {
+		return x.Sha
+	}
+	return ""
+}
+
+func (x *ResponseGetBlame) GetBlobSha() string {
+	if x != nil {
+		return x.BlobSha
+	}
+	return ""
+}
+
+func (x *ResponseGetBlame) GetRanges() []*BlameRange {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+// BlameRange is the consecutive lines which were last modified by the same commit.
+type BlameRange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// start_line is 1-origin.
+	StartLine     int32      `protobuf:"varint,1,opt,name=start_line,json=startLine,proto3" json:"start_line,omitempty"`
+	LineCount     int32      `protobuf:"varint,2,opt,name=line_count,json=lineCount,proto3" json:"line_count,omitempty"`
+	Sha           string     `protobuf:"bytes,3,opt,name=sha,proto3" json:"sha,omitempty"`
+	Author        *Signature `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlameRange) Reset() {
+	*x = BlameRange{}
+	mi := &file_proto_git_data_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlameRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlameRange) ProtoMessage() {}
+
+func (x *BlameRange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlameRange.ProtoReflect.Descriptor instead.
+func (*BlameRange) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *BlameRange) GetStartLine() int32 {
+	if x != nil {
+		return x.StartLine
+	}
+	return 0
+}
+
+func (x *BlameRange) GetLineCount() int32 {
+	if x != nil {
+		return x.LineCount
+	}
+	return 0
+}
+
+func (x *BlameRange) GetSha() string {
+	if x != nil {
+		return x.Sha
+	}
+	return ""
+}
+
+func (x *BlameRange) GetAuthor() *Signature {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+type RequestWatchReferences struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// repos is the names of the watched repositories. All repositories are watched if empty.
+	Repos []string `protobuf:"bytes,1,rep,name=repos,proto3" json:"repos,omitempty"`
+	// cursor is the cursor of the last received response. If empty, only the events after
+	// the call are sent.
+	Cursor        string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestWatchReferences) Reset() {
+	*x = RequestWatchReferences{}
+	mi := &file_proto_git_data_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestWatchReferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestWatchReferences) ProtoMessage() {}
+
+func (x *RequestWatchReferences) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestWatchReferences.ProtoReflect.Descriptor instead.
+func (*RequestWatchReferences) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RequestWatchReferences) GetRepos() []string {
+	if x != nil {
+		return x.Repos
+	}
+	return nil
+}
+
+func (x *RequestWatchReferences) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ResponseWatchReferences struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Events []*ReferenceEvent      `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// cursor is passed to RequestWatchReferences for resuming after the reconnection.
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// resync is true when the events after the requested cursor are not available.
+	// The consumer should read all references again by ListReferences.
+	Resync        bool `protobuf:"varint,3,opt,name=resync,proto3" json:"resync,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResponseWatchReferences) Reset() {
+	*x = ResponseWatchReferences{}
+	mi := &file_proto_git_data_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseWatchReferences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseWatchReferences) ProtoMessage() {}
+
+func (x *ResponseWatchReferences) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseWatchReferences.ProtoReflect.Descriptor instead.
+func (*ResponseWatchReferences) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ResponseWatchReferences) GetEvents() []*ReferenceEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ResponseWatchReferences) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ResponseWatchReferences) GetResync() bool {
+	if x != nil {
+		return x.Resync
+	}
+	return false
+}
+
+// ReferenceEvent is the update of the reference by fetching from the upstream.
+type ReferenceEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Repo  string                 `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	Ref   string                 `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	// old_sha is empty if the reference is created.
+	OldSha string `protobuf:"bytes,3,opt,name=old_sha,json=oldSha,proto3" json:"old_sha,omitempty"`
+	// new_sha is empty if the reference is deleted.
+	NewSha        string `protobuf:"bytes,4,opt,name=new_sha,json=newSha,proto3" json:"new_sha,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReferenceEvent) Reset() {
+	*x = ReferenceEvent{}
+	mi := &file_proto_git_data_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReferenceEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReferenceEvent) ProtoMessage() {}
+
+func (x *ReferenceEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1052,56 +2098,73 @@ func (x *ResponseGetBlob) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResponseGetBlob.ProtoReflect.Descriptor instead.
-func (*ResponseGetBlob) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ReferenceEvent.ProtoReflect.Descriptor instead.
+func (*ReferenceEvent) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *ResponseGetBlob) GetSha() string {
+func (x *ReferenceEvent) GetRepo() string {
 	if x != nil {
-		return x.Sha
+		return x.Repo
 	}
 	return ""
 }
 
-func (x *ResponseGetBlob) GetSize() int64 {
+func (x *ReferenceEvent) GetRef() string {
 	if x != nil {
-		return x.Size
+		return x.Ref
 	}
-	return 0
+	return ""
 }
 
-func (x *ResponseGetBlob) GetContent() []byte {
+func (x *ReferenceEvent) GetOldSha() string {
 	if x != nil {
-		return x.Content
+		return x.OldSha
 	}
-	return nil
+	return ""
 }
 
-type RequestGetFile struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Repo          string                 `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
-	Ref           string                 `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
-	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+func (x *ReferenceEvent) GetNewSha() string {
+	if x != nil {
+		return x.NewSha
+	}
+	return ""
+}
+
+type RequestGetArchive struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Repo  string                 `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	// ref is a commit hash or a ref name.
+	Ref string `protobuf:"bytes,2,opt,name=ref,proto3" json:"ref,omitempty"`
+	// format is tar.gz if unspecified.
+	Format ArchiveFormat `protobuf:"varint,3,opt,name=format,proto3,enum=mono.git.ArchiveFormat" json:"format,omitempty"`
+	// path is the directory which is archived. The whole tree is archived if empty.
+	// The entries in the archive are relative to path.
+	Path string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	// excludes is the patterns of path.Match. The pattern is matched against the path from the
+	// root of the repository, and the directory which matches is excluded with all files under it.
+	Excludes []string `protobuf:"bytes,5,rep,name=excludes,proto3" json:"excludes,omitempty"`
+	// prefix is prepended to the entries in the archive like git archive --prefix.
+	Prefix        string `protobuf:"bytes,6,opt,name=prefix,proto3" json:"prefix,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RequestGetFile) Reset() {
-	*x = RequestGetFile{}
-	mi := &file_proto_git_data_proto_msgTypes[19]
+func (x *RequestGetArchive) Reset() {
+	*x = RequestGetArchive{}
+	mi := &file_proto_git_data_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RequestGetFile) String() string {
+func (x *RequestGetArchive) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RequestGetFile) ProtoMessage() {}
+func (*RequestGetArchive) ProtoMessage() {}
 
-func (x *RequestGetFile) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[19]
+func (x *RequestGetArchive) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1112,57 +2175,77 @@ func (x *RequestGetFile) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RequestGetFile.ProtoReflect.Descriptor instead.
-func (*RequestGetFile) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use RequestGetArchive.ProtoReflect.Descriptor instead.
+func (*RequestGetArchive) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *RequestGetFile) GetRepo() string {
+func (x *RequestGetArchive) GetRepo() string {
 	if x != nil {
 		return x.Repo
 	}
 	return ""
 }
 
-func (x *RequestGetFile) GetRef() string {
+func (x *RequestGetArchive) GetRef() string {
 	if x != nil {
 		return x.Ref
 	}
 	return ""
 }
 
-func (x *RequestGetFile) GetPath() string {
+func (x *RequestGetArchive) GetFormat() ArchiveFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ArchiveFormat_ARCHIVE_FORMAT_UNSPECIFIED
+}
+
+func (x *RequestGetArchive) GetPath() string {
 	if x != nil {
 		return x.Path
 	}
 	return ""
 }
 
-type ResponseGetFile struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       []byte                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
-	RawUrl        string                 `protobuf:"bytes,2,opt,name=raw_url,json=rawUrl,proto3" json:"raw_url,omitempty"`
-	EditUrl       string                 `protobuf:"bytes,3,opt,name=edit_url,json=editUrl,proto3" json:"edit_url,omitempty"`
-	Sha           string                 `protobuf:"bytes,4,opt,name=sha,proto3" json:"sha,omitempty"`
+func (x *RequestGetArchive) GetExcludes() []string {
+	if x != nil {
+		return x.Excludes
+	}
+	return nil
+}
+
+func (x *RequestGetArchive) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+type ResponseGetArchive struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// sha is the resolved commit hash. It is set only in the first response.
+	Sha           string `protobuf:"bytes,1,opt,name=sha,proto3" json:"sha,omitempty"`
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ResponseGetFile) Reset() {
-	*x = ResponseGetFile{}
-	mi := &file_proto_git_data_proto_msgTypes[20]
+func (x *ResponseGetArchive) Reset() {
+	*x = ResponseGetArchive{}
+	mi := &file_proto_git_data_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResponseGetFile) String() string {
+func (x *ResponseGetArchive) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResponseGetFile) ProtoMessage() {}
+func (*ResponseGetArchive) ProtoMessage() {}
 
-func (x *ResponseGetFile) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[20]
+func (x *ResponseGetArchive) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_git_data_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1173,37 +2256,23 @@ func (x *ResponseGetFile) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResponseGetFile.ProtoReflect.Descriptor instead.
-func (*ResponseGetFile) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{20}
-}
-
-func (x *ResponseGetFile) GetContent() []byte {
-	if x != nil {
-		return x.Content
-	}
-	return nil
-}
-
-func (x *ResponseGetFile) GetRawUrl() string {
-	if x != nil {
-		return x.RawUrl
-	}
-	return ""
+// Deprecated: Use ResponseGetArchive.ProtoReflect.Descriptor instead.
+func (*ResponseGetArchive) Descriptor() ([]byte, []int) {
+	return file_proto_git_data_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *ResponseGetFile) GetEditUrl() string {
+func (x *ResponseGetArchive) GetSha() string {
 	if x != nil {
-		return x.EditUrl
+		return x.Sha
 	}
 	return ""
 }
 
-func (x *ResponseGetFile) GetSha() string {
+func (x *ResponseGetArchive) GetData() []byte {
 	if x != nil {
-		return x.Sha
+		return x.Data
 	}
-	return ""
+	return nil
 }
 
 type RequestStat struct {
@@ -1217,7 +2286,7 @@ type RequestStat struct {
 
 func (x *RequestStat) Reset() {
 	*x = RequestStat{}
-	mi := &file_proto_git_data_proto_msgTypes[21]
+	mi := &file_proto_git_data_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1229,7 +2298,7 @@ func (x *RequestStat) String() string {
 func (*RequestStat) ProtoMessage() {}
 
 func (x *RequestStat) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[21]
+	mi := &file_proto_git_data_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1242,7 +2311,7 @@ func (x *RequestStat) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestStat.ProtoReflect.Descriptor instead.
 func (*RequestStat) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{21}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *RequestStat) GetRepo() string {
@@ -1277,7 +2346,7 @@ type ResponseStat struct {
 
 func (x *ResponseStat) Reset() {
 	*x = ResponseStat{}
-	mi := &file_proto_git_data_proto_msgTypes[22]
+	mi := &file_proto_git_data_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1289,7 +2358,7 @@ func (x *ResponseStat) String() string {
 func (*ResponseStat) ProtoMessage() {}
 
 func (x *ResponseStat) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[22]
+	mi := &file_proto_git_data_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1302,7 +2371,7 @@ func (x *ResponseStat) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseStat.ProtoReflect.Descriptor instead.
 func (*ResponseStat) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{22}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *ResponseStat) GetName() string {
@@ -1335,7 +2404,7 @@ type RequestListTag struct {
 
 func (x *RequestListTag) Reset() {
 	*x = RequestListTag{}
-	mi := &file_proto_git_data_proto_msgTypes[23]
+	mi := &file_proto_git_data_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1347,7 +2416,7 @@ func (x *RequestListTag) String() string {
 func (*RequestListTag) ProtoMessage() {}
 
 func (x *RequestListTag) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[23]
+	mi := &file_proto_git_data_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1360,7 +2429,7 @@ func (x *RequestListTag) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestListTag.ProtoReflect.Descriptor instead.
 func (*RequestListTag) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{23}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *RequestListTag) GetRepo() string {
@@ -1379,7 +2448,7 @@ type ResponseListTag struct {
 
 func (x *ResponseListTag) Reset() {
 	*x = ResponseListTag{}
-	mi := &file_proto_git_data_proto_msgTypes[24]
+	mi := &file_proto_git_data_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1391,7 +2460,7 @@ func (x *ResponseListTag) String() string {
 func (*ResponseListTag) ProtoMessage() {}
 
 func (x *ResponseListTag) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[24]
+	mi := &file_proto_git_data_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1404,7 +2473,7 @@ func (x *ResponseListTag) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseListTag.ProtoReflect.Descriptor instead.
 func (*ResponseListTag) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{24}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *ResponseListTag) GetTags() []*Reference {
@@ -1423,7 +2492,7 @@ type RequestListBranch struct {
 
 func (x *RequestListBranch) Reset() {
 	*x = RequestListBranch{}
-	mi := &file_proto_git_data_proto_msgTypes[25]
+	mi := &file_proto_git_data_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1435,7 +2504,7 @@ func (x *RequestListBranch) String() string {
 func (*RequestListBranch) ProtoMessage() {}
 
 func (x *RequestListBranch) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[25]
+	mi := &file_proto_git_data_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1448,7 +2517,7 @@ func (x *RequestListBranch) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestListBranch.ProtoReflect.Descriptor instead.
 func (*RequestListBranch) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{25}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *RequestListBranch) GetRepo() string {
@@ -1467,7 +2536,7 @@ type ResponseListBranch struct {
 
 func (x *ResponseListBranch) Reset() {
 	*x = ResponseListBranch{}
-	mi := &file_proto_git_data_proto_msgTypes[26]
+	mi := &file_proto_git_data_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1479,7 +2548,7 @@ func (x *ResponseListBranch) String() string {
 func (*ResponseListBranch) ProtoMessage() {}
 
 func (x *ResponseListBranch) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[26]
+	mi := &file_proto_git_data_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1492,7 +2561,7 @@ func (x *ResponseListBranch) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseListBranch.ProtoReflect.Descriptor instead.
 func (*ResponseListBranch) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{26}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *ResponseListBranch) GetBranches() []*Reference {
@@ -1511,7 +2580,7 @@ type RequestGetRepositoryStatistics struct {
 
 func (x *RequestGetRepositoryStatistics) Reset() {
 	*x = RequestGetRepositoryStatistics{}
-	mi := &file_proto_git_data_proto_msgTypes[27]
+	mi := &file_proto_git_data_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1523,7 +2592,7 @@ func (x *RequestGetRepositoryStatistics) String() string {
 func (*RequestGetRepositoryStatistics) ProtoMessage() {}
 
 func (x *RequestGetRepositoryStatistics) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[27]
+	mi := &file_proto_git_data_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1536,7 +2605,7 @@ func (x *RequestGetRepositoryStatistics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestGetRepositoryStatistics.ProtoReflect.Descriptor instead.
 func (*RequestGetRepositoryStatistics) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{27}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *RequestGetRepositoryStatistics) GetRepo() string {
@@ -1547,15 +2616,17 @@ func (x *RequestGetRepositoryStatistics) GetRepo() string {
 }
 
 type ResponseGetRepositoryStatistics struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	HeadCommit    *Commit                `protobuf:"bytes,1,opt,name=head_commit,json=headCommit,proto3" json:"head_commit,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// head_commit is the commit pointed by HEAD. The last update time of the
+	// repository can be derived from head_commit.committer.when.
+	HeadCommit    *Commit `protobuf:"bytes,1,opt,name=head_commit,json=headCommit,proto3" json:"head_commit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ResponseGetRepositoryStatistics) Reset() {
 	*x = ResponseGetRepositoryStatistics{}
-	mi := &file_proto_git_data_proto_msgTypes[28]
+	mi := &file_proto_git_data_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1567,7 +2638,7 @@ func (x *ResponseGetRepositoryStatistics) String() string {
 func (*ResponseGetRepositoryStatistics) ProtoMessage() {}
 
 func (x *ResponseGetRepositoryStatistics) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_git_data_proto_msgTypes[28]
+	mi := &file_proto_git_data_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1580,7 +2651,7 @@ func (x *ResponseGetRepositoryStatistics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseGetRepositoryStatistics.ProtoReflect.Descriptor instead.
 func (*ResponseGetRepositoryStatistics) Descriptor() ([]byte, []int) {
-	return file_proto_git_data_proto_rawDescGZIP(), []int{28}
+	return file_proto_git_data_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *ResponseGetRepositoryStatistics) GetHeadCommit() *Commit {
@@ -1614,7 +2685,20 @@ const file_proto_git_data_proto_rawDesc = "" +
 	"\tSignature\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
 	"\x05email\x18\x02 \x01(\tR\x05email\x12.\n" +
-	"\x04when\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04when\"r\n" +
+	"\x04when\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04when\"\xc1\x02\n" +
+	"\n" +
+	"FileChange\x122\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x1a.mono.git.FileChangeStatusR\x06status\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12#\n" +
+	"\rprevious_path\x18\x03 \x01(\tR\fpreviousPath\x12\x10\n" +
+	"\x03sha\x18\x04 \x01(\tR\x03sha\x12!\n" +
+	"\fprevious_sha\x18\x05 \x01(\tR\vpreviousSha\x12\x1c\n" +
+	"\tadditions\x18\x06 \x01(\x05R\tadditions\x12\x1c\n" +
+	"\tdeletions\x18\a \x01(\x05R\tdeletions\x12\x14\n" +
+	"\x05patch\x18\b \x01(\tR\x05patch\x12'\n" +
+	"\x0fpatch_truncated\x18\t \x01(\bR\x0epatchTruncated\x12\x16\n" +
+	"\x06binary\x18\n" +
+	" \x01(\bR\x06binary\"r\n" +
 	"\n" +
 	"Repository\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
@@ -1644,7 +2728,32 @@ const file_proto_git_data_proto_rawDesc = "" +
 	"\x03sha\x18\x02 \x01(\tR\x03sha\x12\x10\n" +
 	"\x03ref\x18\x03 \x01(\tR\x03ref\"=\n" +
 	"\x11ResponseGetCommit\x12(\n" +
-	"\x06commit\x18\x01 \x01(\v2\x10.mono.git.CommitR\x06commit\"z\n" +
+	"\x06commit\x18\x01 \x01(\v2\x10.mono.git.CommitR\x06commit\"\x98\x02\n" +
+	"\x12RequestListCommits\x12\x12\n" +
+	"\x04repo\x18\x01 \x01(\tR\x04repo\x12\x10\n" +
+	"\x03sha\x18\x02 \x01(\tR\x03sha\x12\x10\n" +
+	"\x03ref\x18\x03 \x01(\tR\x03ref\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x12\x16\n" +
+	"\x06author\x18\x05 \x01(\tR\x06author\x120\n" +
+	"\x05since\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x1b\n" +
+	"\tpage_size\x18\b \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\t \x01(\tR\tpageToken\"i\n" +
+	"\x13ResponseListCommits\x12*\n" +
+	"\acommits\x18\x01 \x03(\v2\x10.mono.git.CommitR\acommits\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xcc\x01\n" +
+	"\x0eRequestCompare\x12\x12\n" +
+	"\x04repo\x18\x01 \x01(\tR\x04repo\x12\x12\n" +
+	"\x04base\x18\x02 \x01(\tR\x04base\x12\x12\n" +
+	"\x04head\x18\x03 \x01(\tR\x04head\x12#\n" +
+	"\rinclude_patch\x18\x04 \x01(\bR\fincludePatch\x12&\n" +
+	"\x0fmax_patch_bytes\x18\x05 \x01(\x03R\rmaxPatchBytes\x121\n" +
+	"\x15max_total_patch_bytes\x18\x06 \x01(\x03R\x12maxTotalPatchBytes\"e\n" +
+	"\x0fResponseCompare\x12\x12\n" +
+	"\x04base\x18\x01 \x01(\tR\x04base\x12\x12\n" +
+	"\x04head\x18\x02 \x01(\tR\x04head\x12*\n" +
+	"\x05files\x18\x03 \x03(\v2\x14.mono.git.FileChangeR\x05files\"z\n" +
 	"\x0eRequestGetTree\x12\x12\n" +
 	"\x04repo\x18\x01 \x01(\tR\x04repo\x12\x10\n" +
 	"\x03sha\x18\x02 \x01(\tR\x03sha\x12\x10\n" +
@@ -1669,7 +2778,45 @@ const file_proto_git_data_proto_rawDesc = "" +
 	"\acontent\x18\x01 \x01(\fR\acontent\x12\x17\n" +
 	"\araw_url\x18\x02 \x01(\tR\x06rawUrl\x12\x19\n" +
 	"\bedit_url\x18\x03 \x01(\tR\aeditUrl\x12\x10\n" +
-	"\x03sha\x18\x04 \x01(\tR\x03sha\"G\n" +
+	"\x03sha\x18\x04 \x01(\tR\x03sha\"K\n" +
+	"\x0fRequestGetBlame\x12\x12\n" +
+	"\x04repo\x18\x01 \x01(\tR\x04repo\x12\x10\n" +
+	"\x03ref\x18\x02 \x01(\tR\x03ref\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\"m\n" +
+	"\x10ResponseGetBlame\x12\x10\n" +
+	"\x03sha\x18\x01 \x01(\tR\x03sha\x12\x19\n" +
+	"\bblob_sha\x18\x02 \x01(\tR\ablobSha\x12,\n" +
+	"\x06ranges\x18\x03 \x03(\v2\x14.mono.git.BlameRangeR\x06ranges\"\x89\x01\n" +
+	"\n" +
+	"BlameRange\x12\x1d\n" +
+	"\n" +
+	"start_line\x18\x01 \x01(\x05R\tstartLine\x12\x1d\n" +
+	"\n" +
+	"line_count\x18\x02 \x01(\x05R\tlineCount\x12\x10\n" +
+	"\x03sha\x18\x03 \x01(\tR\x03sha\x12+\n" +
+	"\x06author\x18\x04 \x01(\v2\x13.mono.git.SignatureR\x06author\"F\n" +
+	"\x16RequestWatchReferences\x12\x14\n" +
+	"\x05repos\x18\x01 \x03(\tR\x05repos\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\"{\n" +
+	"\x17ResponseWatchReferences\x120\n" +
+	"\x06events\x18\x01 \x03(\v2\x18.mono.git.ReferenceEventR\x06events\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x16\n" +
+	"\x06resync\x18\x03 \x01(\bR\x06resync\"h\n" +
+	"\x0eReferenceEvent\x12\x12\n" +
+	"\x04repo\x18\x01 \x01(\tR\x04repo\x12\x10\n" +
+	"\x03ref\x18\x02 \x01(\tR\x03ref\x12\x17\n" +
+	"\aold_sha\x18\x03 \x01(\tR\x06oldSha\x12\x17\n" +
+	"\anew_sha\x18\x04 \x01(\tR\x06newSha\"\xb2\x01\n" +
+	"\x11RequestGetArchive\x12\x12\n" +
+	"\x04repo\x18\x01 \x01(\tR\x04repo\x12\x10\n" +
+	"\x03ref\x18\x02 \x01(\tR\x03ref\x12/\n" +
+	"\x06format\x18\x03 \x01(\x0e2\x17.mono.git.ArchiveFormatR\x06format\x12\x12\n" +
+	"\x04path\x18\x04 \x01(\tR\x04path\x12\x1a\n" +
+	"\bexcludes\x18\x05 \x03(\tR\bexcludes\x12\x16\n" +
+	"\x06prefix\x18\x06 \x01(\tR\x06prefix\":\n" +
+	"\x12ResponseGetArchive\x12\x10\n" +
+	"\x03sha\x18\x01 \x01(\tR\x03sha\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"G\n" +
 	"\vRequestStat\x12\x12\n" +
 	"\x04repo\x18\x01 \x01(\tR\x04repo\x12\x10\n" +
 	"\x03ref\x18\x02 \x01(\tR\x03ref\x12\x12\n" +
@@ -1690,21 +2837,38 @@ const file_proto_git_data_proto_rawDesc = "" +
 	"\x04repo\x18\x01 \x01(\tR\x04repo\"T\n" +
 	"\x1fResponseGetRepositoryStatistics\x121\n" +
 	"\vhead_commit\x18\x01 \x01(\v2\x10.mono.git.CommitR\n" +
-	"headCommit2\x90\a\n" +
+	"headCommit*\xb5\x01\n" +
+	"\x10FileChangeStatus\x12\"\n" +
+	"\x1eFILE_CHANGE_STATUS_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18FILE_CHANGE_STATUS_ADDED\x10\x01\x12\x1f\n" +
+	"\x1bFILE_CHANGE_STATUS_MODIFIED\x10\x02\x12\x1e\n" +
+	"\x1aFILE_CHANGE_STATUS_RENAMED\x10\x03\x12\x1e\n" +
+	"\x1aFILE_CHANGE_STATUS_DELETED\x10\x04*b\n" +
+	"\rArchiveFormat\x12\x1e\n" +
+	"\x1aARCHIVE_FORMAT_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15ARCHIVE_FORMAT_TAR_GZ\x10\x01\x12\x16\n" +
+	"\x12ARCHIVE_FORMAT_ZIP\x10\x022\x84\n" +
+	"\n" +
 	"\aGitData\x12Y\n" +
 	"\x10ListRepositories\x12!.mono.git.RequestListRepositories\x1a\".mono.git.ResponseListRepositories\x12S\n" +
 	"\x0eListReferences\x12\x1f.mono.git.RequestListReferences\x1a .mono.git.ResponseListReferences\x12P\n" +
 	"\rGetRepository\x12\x1e.mono.git.RequestGetRepository\x1a\x1f.mono.git.ResponseGetRepository\x12M\n" +
 	"\fGetReference\x12\x1d.mono.git.RequestGetReference\x1a\x1e.mono.git.ResponseGetReference\x12D\n" +
-	"\tGetCommit\x12\x1a.mono.git.RequestGetCommit\x1a\x1b.mono.git.ResponseGetCommit\x12>\n" +
+	"\tGetCommit\x12\x1a.mono.git.RequestGetCommit\x1a\x1b.mono.git.ResponseGetCommit\x12J\n" +
+	"\vListCommits\x12\x1c.mono.git.RequestListCommits\x1a\x1d.mono.git.ResponseListCommits\x12>\n" +
+	"\aCompare\x12\x18.mono.git.RequestCompare\x1a\x19.mono.git.ResponseCompare\x12>\n" +
 	"\aGetTree\x12\x18.mono.git.RequestGetTree\x1a\x19.mono.git.ResponseGetTree\x12>\n" +
 	"\aGetBlob\x12\x18.mono.git.RequestGetBlob\x1a\x19.mono.git.ResponseGetBlob\x12>\n" +
-	"\aGetFile\x12\x18.mono.git.RequestGetFile\x1a\x19.mono.git.ResponseGetFile\x125\n" +
+	"\aGetFile\x12\x18.mono.git.RequestGetFile\x1a\x19.mono.git.ResponseGetFile\x12A\n" +
+	"\bGetBlame\x12\x19.mono.git.RequestGetBlame\x1a\x1a.mono.git.ResponseGetBlame\x125\n" +
 	"\x04Stat\x12\x15.mono.git.RequestStat\x1a\x16.mono.git.ResponseStat\x12>\n" +
 	"\aListTag\x12\x18.mono.git.RequestListTag\x1a\x19.mono.git.ResponseListTag\x12G\n" +
 	"\n" +
 	"ListBranch\x12\x1b.mono.git.RequestListBranch\x1a\x1c.mono.git.ResponseListBranch\x12n\n" +
-	"\x17GetRepositoryStatistics\x12(.mono.git.RequestGetRepositoryStatistics\x1a).mono.git.ResponseGetRepositoryStatisticsB\x19Z\x17go.f110.dev/mono/go/gitb\x06proto3"
+	"\x17GetRepositoryStatistics\x12(.mono.git.RequestGetRepositoryStatistics\x1a).mono.git.ResponseGetRepositoryStatistics\x12X\n" +
+	"\x0fWatchReferences\x12 .mono.git.RequestWatchReferences\x1a!.mono.git.ResponseWatchReferences0\x01\x12I\n" +
+	"\n" +
+	"GetArchive\x12\x1b.mono.git.RequestGetArchive\x1a\x1c.mono.git.ResponseGetArchive0\x01B\x19Z\x17go.f110.dev/mono/go/gitb\x06proto3"
 
 var (
 	file_proto_git_data_proto_rawDescOnce sync.Once
@@ -1718,80 +2882,115 @@ func file_proto_git_data_proto_rawDescGZIP() []byte {
 	return file_proto_git_data_proto_rawDescData
 }
 
-var file_proto_git_data_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_proto_git_data_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_git_data_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
 var file_proto_git_data_proto_goTypes = []any{
-	(*Reference)(nil),                       // 0: mono.git.Reference
-	(*TreeEntry)(nil),                       // 1: mono.git.TreeEntry
-	(*Commit)(nil),                          // 2: mono.git.Commit
-	(*Signature)(nil),                       // 3: mono.git.Signature
-	(*Repository)(nil),                      // 4: mono.git.Repository
-	(*RequestListRepositories)(nil),         // 5: mono.git.RequestListRepositories
-	(*ResponseListRepositories)(nil),        // 6: mono.git.ResponseListRepositories
-	(*RequestListReferences)(nil),           // 7: mono.git.RequestListReferences
-	(*ResponseListReferences)(nil),          // 8: mono.git.ResponseListReferences
-	(*RequestGetRepository)(nil),            // 9: mono.git.RequestGetRepository
-	(*ResponseGetRepository)(nil),           // 10: mono.git.ResponseGetRepository
-	(*RequestGetReference)(nil),             // 11: mono.git.RequestGetReference
-	(*ResponseGetReference)(nil),            // 12: mono.git.ResponseGetReference
-	(*RequestGetCommit)(nil),                // 13: mono.git.RequestGetCommit
-	(*ResponseGetCommit)(nil),               // 14: mono.git.ResponseGetCommit
-	(*RequestGetTree)(nil),                  // 15: mono.git.RequestGetTree
-	(*ResponseGetTree)(nil),                 // 16: mono.git.ResponseGetTree
-	(*RequestGetBlob)(nil),                  // 17: mono.git.RequestGetBlob
-	(*ResponseGetBlob)(nil),                 // 18: mono.git.ResponseGetBlob
-	(*RequestGetFile)(nil),                  // 19: mono.git.RequestGetFile
-	(*ResponseGetFile)(nil),                 // 20: mono.git.ResponseGetFile
-	(*RequestStat)(nil),                     // 21: mono.git.RequestStat
-	(*ResponseStat)(nil),                    // 22: mono.git.ResponseStat
-	(*RequestListTag)(nil),                  // 23: mono.git.RequestListTag
-	(*ResponseListTag)(nil),                 // 24: mono.git.ResponseListTag
-	(*RequestListBranch)(nil),               // 25: mono.git.RequestListBranch
-	(*ResponseListBranch)(nil),              // 26: mono.git.ResponseListBranch
-	(*RequestGetRepositoryStatistics)(nil),  // 27: mono.git.RequestGetRepositoryStatistics
-	(*ResponseGetRepositoryStatistics)(nil), // 28: mono.git.ResponseGetRepositoryStatistics
-	(*timestamppb.Timestamp)(nil),           // 29: google.protobuf.Timestamp
+	(FileChangeStatus)(0),                   // 0: mono.git.FileChangeStatus
+	(ArchiveFormat)(0),                      // 1: mono.git.ArchiveFormat
+	(*Reference)(nil),                       // 2: mono.git.Reference
+	(*TreeEntry)(nil),                       // 3: mono.git.TreeEntry
+	(*Commit)(nil),                          // 4: mono.git.Commit
+	(*Signature)(nil),                       // 5: mono.git.Signature
+	(*FileChange)(nil),                      // 6: mono.git.FileChange
+	(*Repository)(nil),                      // 7: mono.git.Repository
+	(*RequestListRepositories)(nil),         // 8: mono.git.RequestListRepositories
+	(*ResponseListRepositories)(nil),        // 9: mono.git.ResponseListRepositories
+	(*RequestListReferences)(nil),           // 10: mono.git.RequestListReferences
+	(*ResponseListReferences)(nil),          // 11: mono.git.ResponseListReferences
+	(*RequestGetRepository)(nil),            // 12: mono.git.RequestGetRepository
+	(*ResponseGetRepository)(nil),           // 13: mono.git.ResponseGetRepository
+	(*RequestGetReference)(nil),             // 14: mono.git.RequestGetReference
+	(*ResponseGetReference)(nil),            // 15: mono.git.ResponseGetReference
+	(*RequestGetCommit)(nil),                // 16: mono.git.RequestGetCommit
+	(*ResponseGetCommit)(nil),               // 17: mono.git.ResponseGetCommit
+	(*RequestListCommits)(nil),              // 18: mono.git.RequestListCommits
+	(*ResponseListCommits)(nil),             // 19: mono.git.ResponseListCommits
+	(*RequestCompare)(nil),                  // 20: mono.git.RequestCompare
+	(*ResponseCompare)(nil),                 // 21: mono.git.ResponseCompare
+	(*RequestGetTree)(nil),                  // 22: mono.git.RequestGetTree
+	(*ResponseGetTree)(nil),                 // 23: mono.git.ResponseGetTree
+	(*RequestGetBlob)(nil),                  // 24: mono.git.RequestGetBlob
+	(*ResponseGetBlob)(nil),                 // 25: mono.git.ResponseGetBlob
+	(*RequestGetFile)(nil),                  // 26: mono.git.RequestGetFile
+	(*ResponseGetFile)(nil),                 // 27: mono.git.ResponseGetFile
+	(*RequestGetBlame)(nil),                 // 28: mono.git.RequestGetBlame
+	(*ResponseGetBlame)(nil),                // 29: mono.git.ResponseGetBlame
+	(*BlameRange)(nil),                      // 30: mono.git.BlameRange
+	(*RequestWatchReferences)(nil),          // 31: mono.git.RequestWatchReferences
+	(*ResponseWatchReferences)(nil),         // 32: mono.git.ResponseWatchReferences
+	(*ReferenceEvent)(nil),                  // 33: mono.git.ReferenceEvent
+	(*RequestGetArchive)(nil),               // 34: mono.git.RequestGetArchive
+	(*ResponseGetArchive)(nil),              // 35: mono.git.ResponseGetArchive
+	(*RequestStat)(nil),                     // 36: mono.git.RequestStat
+	(*ResponseStat)(nil),                    // 37: mono.git.ResponseStat
+	(*RequestListTag)(nil),                  // 38: mono.git.RequestListTag
+	(*ResponseListTag)(nil),                 // 39: mono.git.ResponseListTag
+	(*RequestListBranch)(nil),               // 40: mono.git.RequestListBranch
+	(*ResponseListBranch)(nil),              // 41: mono.git.ResponseListBranch
+	(*RequestGetRepositoryStatistics)(nil),  // 42: mono.git.RequestGetRepositoryStatistics
+	(*ResponseGetRepositoryStatistics)(nil), // 43: mono.git.ResponseGetRepositoryStatistics
+	(*timestamppb.Timestamp)(nil),           // 44: google.protobuf.Timestamp
 }
 var file_proto_git_data_proto_depIdxs = []int32{
-	3,  // 0: mono.git.Commit.author:type_name -> mono.git.Signature
-	3,  // 1: mono.git.Commit.committer:type_name -> mono.git.Signature
-	29, // 2: mono.git.Signature.when:type_name -> google.protobuf.Timestamp
-	4,  // 3: mono.git.ResponseListRepositories.repositories:type_name -> mono.git.Repository
-	0,  // 4: mono.git.ResponseListReferences.refs:type_name -> mono.git.Reference
-	0,  // 5: mono.git.ResponseGetReference.ref:type_name -> mono.git.Reference
-	2,  // 6: mono.git.ResponseGetCommit.commit:type_name -> mono.git.Commit
-	1,  // 7: mono.git.ResponseGetTree.tree:type_name -> mono.git.TreeEntry
-	0,  // 8: mono.git.ResponseListTag.tags:type_name -> mono.git.Reference
-	0,  // 9: mono.git.ResponseListBranch.branches:type_name -> mono.git.Reference
-	2,  // 10: mono.git.ResponseGetRepositoryStatistics.head_commit:type_name -> mono.git.Commit
-	5,  // 11: mono.git.GitData.ListRepositories:input_type -> mono.git.RequestListRepositories
-	7,  // 12: mono.git.GitData.ListReferences:input_type -> mono.git.RequestListReferences
-	9,  // 13: mono.git.GitData.GetRepository:input_type -> mono.git.RequestGetRepository
-	11, // 14: mono.git.GitData.GetReference:input_type -> mono.git.RequestGetReference
-	13, // 15: mono.git.GitData.GetCommit:input_type -> mono.git.RequestGetCommit
-	15, // 16: mono.git.GitData.GetTree:input_type -> mono.git.RequestGetTree
-	17, // 17: mono.git.GitData.GetBlob:input_type -> mono.git.RequestGetBlob
-	19, // 18: mono.git.GitData.GetFile:input_type -> mono.git.RequestGetFile
-	21, // 19: mono.git.GitData.Stat:input_type -> mono.git.RequestStat
-	23, // 20: mono.git.GitData.ListTag:input_type -> mono.git.RequestListTag
-	25, // 21: mono.git.GitData.ListBranch:input_type -> mono.git.RequestListBranch
-	27, // 22: mono.git.GitData.GetRepositoryStatistics:input_type -> mono.git.RequestGetRepositoryStatistics
-	6,  // 23: mono.git.GitData.ListRepositories:output_type -> mono.git.ResponseListRepositories
-	8,  // 24: mono.git.GitData.ListReferences:output_type -> mono.git.ResponseListReferences
-	10, // 25: mono.git.GitData.GetRepository:output_type -> mono.git.ResponseGetRepository
-	12, // 26: mono.git.GitData.GetReference:output_type -> mono.git.ResponseGetReference
-	14, // 27: mono.git.GitData.GetCommit:output_type -> mono.git.ResponseGetCommit
-	16, // 28: mono.git.GitData.GetTree:output_type -> mono.git.ResponseGetTree
-	18, // 29: mono.git.GitData.GetBlob:output_type -> mono.git.ResponseGetBlob
-	20, // 30: mono.git.GitData.GetFile:output_type -> mono.git.ResponseGetFile
-	22, // 31: mono.git.GitData.Stat:output_type -> mono.git.ResponseStat
-	24, // 32: mono.git.GitData.ListTag:output_type -> mono.git.ResponseListTag
-	26, // 33: mono.git.GitData.ListBranch:output_type -> mono.git.ResponseListBranch
-	28, // 34: mono.git.GitData.GetRepositoryStatistics:output_type -> mono.git.ResponseGetRepositoryStatistics
-	23, // [23:35] is the sub-list for method output_type
-	11, // [11:23] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	5,  // 0: mono.git.Commit.author:type_name -> mono.git.Signature
+	5,  // 1: mono.git.Commit.committer:type_name -> mono.git.Signature
+	44, // 2: mono.git.Signature.when:type_name -> google.protobuf.Timestamp
+	0,  // 3: mono.git.FileChange.status:type_name -> mono.git.FileChangeStatus
+	7,  // 4: mono.git.ResponseListRepositories.repositories:type_name -> mono.git.Repository
+	2,  // 5: mono.git.ResponseListReferences.refs:type_name -> mono.git.Reference
+	2,  // 6: mono.git.ResponseGetReference.ref:type_name -> mono.git.Reference
+	4,  // 7: mono.git.ResponseGetCommit.commit:type_name -> mono.git.Commit
+	44, // 8: mono.git.RequestListCommits.since:type_name -> google.protobuf.Timestamp
+	44, // 9: mono.git.RequestListCommits.until:type_name -> google.protobuf.Timestamp
+	4,  // 10: mono.git.ResponseListCommits.commits:type_name -> mono.git.Commit
+	6,  // 11: mono.git.ResponseCompare.files:type_name -> mono.git.FileChange
+	3,  // 12: mono.git.ResponseGetTree.tree:type_name -> mono.git.TreeEntry
+	30, // 13: mono.git.ResponseGetBlame.ranges:type_name -> mono.git.BlameRange
+	5,  // 14: mono.git.BlameRange.author:type_name -> mono.git.Signature
+	33, // 15: mono.git.ResponseWatchReferences.events:type_name -> mono.git.ReferenceEvent
+	1,  // 16: mono.git.RequestGetArchive.format:type_name -> mono.git.ArchiveFormat
+	2,  // 17: mono.git.ResponseListTag.tags:type_name -> mono.git.Reference
+	2,  // 18: mono.git.ResponseListBranch.branches:type_name -> mono.git.Reference
+	4,  // 19: mono.git.ResponseGetRepositoryStatistics.head_commit:type_name -> mono.git.Commit
+	8,  // 20: mono.git.GitData.ListRepositories:input_type -> mono.git.RequestListRepositories
+	10, // 21: mono.git.GitData.ListReferences:input_type -> mono.git.RequestListReferences
+	12, // 22: mono.git.GitData.GetRepository:input_type -> mono.git.RequestGetRepository
+	14, // 23: mono.git.GitData.GetReference:input_type -> mono.git.RequestGetReference
+	16, // 24: mono.git.GitData.GetCommit:input_type -> mono.git.RequestGetCommit
+	18, // 25: mono.git.GitData.ListCommits:input_type -> mono.git.RequestListCommits
+	20, // 26: mono.git.GitData.Compare:input_type -> mono.git.RequestCompare
+	22, // 27: mono.git.GitData.GetTree:input_type -> mono.git.RequestGetTree
+	24, // 28: mono.git.GitData.GetBlob:input_type -> mono.git.RequestGetBlob
+	26, // 29: mono.git.GitData.GetFile:input_type -> mono.git.RequestGetFile
+	28, // 30: mono.git.GitData.GetBlame:input_type -> mono.git.RequestGetBlame
+	36, // 31: mono.git.GitData.Stat:input_type -> mono.git.RequestStat
+	38, // 32: mono.git.GitData.ListTag:input_type -> mono.git.RequestListTag
+	40, // 33: mono.git.GitData.ListBranch:input_type -> mono.git.RequestListBranch
+	42, // 34: mono.git.GitData.GetRepositoryStatistics:input_type -> mono.git.RequestGetRepositoryStatistics
+	31, // 35: mono.git.GitData.WatchReferences:input_type -> mono.git.RequestWatchReferences
+	34, // 36: mono.git.GitData.GetArchive:input_type -> mono.git.RequestGetArchive
+	9,  // 37: mono.git.GitData.ListRepositories:output_type -> mono.git.ResponseListRepositories
+	11, // 38: mono.git.GitData.ListReferences:output_type -> mono.git.ResponseListReferences
+	13, // 39: mono.git.GitData.GetRepository:output_type -> mono.git.ResponseGetRepository
+	15, // 40: mono.git.GitData.GetReference:output_type -> mono.git.ResponseGetReference
+	17, // 41: mono.git.GitData.GetCommit:output_type -> mono.git.ResponseGetCommit
+	19, // 42: mono.git.GitData.ListCommits:output_type -> mono.git.ResponseListCommits
+	21, // 43: mono.git.GitData.Compare:output_type -> mono.git.ResponseCompare
+	23, // 44: mono.git.GitData.GetTree:output_type -> mono.git.ResponseGetTree
+	25, // 45: mono.git.GitData.GetBlob:output_type -> mono.git.ResponseGetBlob
+	27, // 46: mono.git.GitData.GetFile:output_type -> mono.git.ResponseGetFile
+	29, // 47: mono.git.GitData.GetBlame:output_type -> mono.git.ResponseGetBlame
+	37, // 48: mono.git.GitData.Stat:output_type -> mono.git.ResponseStat
+	39, // 49: mono.git.GitData.ListTag:output_type -> mono.git.ResponseListTag
+	41, // 50: mono.git.GitData.ListBranch:output_type -> mono.git.ResponseListBranch
+	43, // 51: mono.git.GitData.GetRepositoryStatistics:output_type -> mono.git.ResponseGetRepositoryStatistics
+	32, // 52: mono.git.GitData.WatchReferences:output_type -> mono.git.ResponseWatchReferences
+	35, // 53: mono.git.GitData.GetArchive:output_type -> mono.git.ResponseGetArchive
+	37, // [37:54] is the sub-list for method output_type
+	20, // [20:37] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_proto_git_data_proto_init() }
@@ -1804,13 +3003,14 @@ func file_proto_git_data_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_git_data_proto_rawDesc), len(file_proto_git_data_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   29,
+			NumEnums:      2,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_git_data_proto_goTypes,
 		DependencyIndexes: file_proto_git_data_proto_depIdxs,
+		EnumInfos:         file_proto_git_data_proto_enumTypes,
 		MessageInfos:      file_proto_git_data_proto_msgTypes,
 	}.Build()
 	File_proto_git_data_proto = out.File
@@ -1835,13 +3035,18 @@ type GitDataClient interface {
 	GetRepository(ctx context.Context, in *RequestGetRepository, opts ...grpc.CallOption) (*ResponseGetRepository, error)
 	GetReference(ctx context.Context, in *RequestGetReference, opts ...grpc.CallOption) (*ResponseGetReference, error)
 	GetCommit(ctx context.Context, in *RequestGetCommit, opts ...grpc.CallOption) (*ResponseGetCommit, error)
+	ListCommits(ctx context.Context, in *RequestListCommits, opts ...grpc.CallOption) (*ResponseListCommits, error)
+	Compare(ctx context.Context, in *RequestCompare, opts ...grpc.CallOption) (*ResponseCompare, error)
 	GetTree(ctx context.Context, in *RequestGetTree, opts ...grpc.CallOption) (*ResponseGetTree, error)
 	GetBlob(ctx context.Context, in *RequestGetBlob, opts ...grpc.CallOption) (*ResponseGetBlob, error)
 	GetFile(ctx context.Context, in *RequestGetFile, opts ...grpc.CallOption) (*ResponseGetFile, error)
+	GetBlame(ctx context.Context, in *RequestGetBlame, opts ...grpc.CallOption) (*ResponseGetBlame, error)
 	Stat(ctx context.Context, in *RequestStat, opts ...grpc.CallOption) (*ResponseStat, error)
 	ListTag(ctx context.Context, in *RequestListTag, opts ...grpc.CallOption) (*ResponseListTag, error)
 	ListBranch(ctx context.Context, in *RequestListBranch, opts ...grpc.CallOption) (*ResponseListBranch, error)
 	GetRepositoryStatistics(ctx context.Context, in *RequestGetRepositoryStatistics, opts ...grpc.CallOption) (*ResponseGetRepositoryStatistics, error)
+	WatchReferences(ctx context.Context, in *RequestWatchReferences, opts ...grpc.CallOption) (GitData_WatchReferencesClient, error)
+	GetArchive(ctx context.Context, in *RequestGetArchive, opts ...grpc.CallOption) (GitData_GetArchiveClient, error)
 }
 
 type gitDataClient struct {
@@ -1897,6 +3102,24 @@ func (c *gitDataClient) GetCommit(ctx context.Context, in *RequestGetCommit, opt
 	return out, nil
 }
 
+func (c *gitDataClient) ListCommits(ctx context.Context, in *RequestListCommits, opts ...grpc.CallOption) (*ResponseListCommits, error) {
+	out := new(ResponseListCommits)
+	err := c.cc.Invoke(ctx, "/mono.git.GitData/ListCommits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gitDataClient) Compare(ctx context.Context, in *RequestCompare, opts ...grpc.CallOption) (*ResponseCompare, error) {
+	out := new(ResponseCompare)
+	err := c.cc.Invoke(ctx, "/mono.git.GitData/Compare", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gitDataClient) GetTree(ctx context.Context, in *RequestGetTree, opts ...grpc.CallOption) (*ResponseGetTree, error) {
 	out := new(ResponseGetTree)
 	err := c.cc.Invoke(ctx, "/mono.git.GitData/GetTree", in, out, opts...)
@@ -1924,6 +3147,15 @@ func (c *gitDataClient) GetFile(ctx context.Context, in *RequestGetFile, opts ..
 	return out, nil
 }
 
+func (c *gitDataClient) GetBlame(ctx context.Context, in *RequestGetBlame, opts ...grpc.CallOption) (*ResponseGetBlame, error) {
+	out := new(ResponseGetBlame)
+	err := c.cc.Invoke(ctx, "/mono.git.GitData/GetBlame", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gitDataClient) Stat(ctx context.Context, in *RequestStat, opts ...grpc.CallOption) (*ResponseStat, error) {
 	out := new(ResponseStat)
 	err := c.cc.Invoke(ctx, "/mono.git.GitData/Stat", in, out, opts...)
@@ -1960,6 +3192,70 @@ func (c *gitDataClient) GetRepositoryStatistics(ctx context.Context, in *Request
 	return out, nil
 }
 
+func (c *gitDataClient) WatchReferences(ctx context.Context, in *RequestWatchReferences, opts ...grpc.CallOption) (GitData_WatchReferencesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_GitData_serviceDesc.Streams[0], "/mono.git.GitData/WatchReferences", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gitDataWatchReferencesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GitData_WatchReferencesClient interface {
+	Recv() (*ResponseWatchReferences, error)
+	grpc.ClientStream
+}
+
+type gitDataWatchReferencesClient struct {
+	grpc.ClientStream
+}
+
+func (x *gitDataWatchReferencesClient) Recv() (*ResponseWatchReferences, error) {
+	m := new(ResponseWatchReferences)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gitDataClient) GetArchive(ctx context.Context, in *RequestGetArchive, opts ...grpc.CallOption) (GitData_GetArchiveClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_GitData_serviceDesc.Streams[1], "/mono.git.GitData/GetArchive", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gitDataGetArchiveClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GitData_GetArchiveClient interface {
+	Recv() (*ResponseGetArchive, error)
+	grpc.ClientStream
+}
+
+type gitDataGetArchiveClient struct {
+	grpc.ClientStream
+}
+
+func (x *gitDataGetArchiveClient) Recv() (*ResponseGetArchive, error) {
+	m := new(ResponseGetArchive)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // GitDataServer is the server API for GitData service.
 type GitDataServer interface {
 	ListRepositories(context.Context, *RequestListRepositories) (*ResponseListRepositories, error)
@@ -1967,13 +3263,18 @@ type GitDataServer interface {
 	GetRepository(context.Context, *RequestGetRepository) (*ResponseGetRepository, error)
 	GetReference(context.Context, *RequestGetReference) (*ResponseGetReference, error)
 	GetCommit(context.Context, *RequestGetCommit) (*ResponseGetCommit, error)
+	ListCommits(context.Context, *RequestListCommits) (*ResponseListCommits, error)
+	Compare(context.Context, *RequestCompare) (*ResponseCompare, error)
 	GetTree(context.Context, *RequestGetTree) (*ResponseGetTree, error)
 	GetBlob(context.Context, *RequestGetBlob) (*ResponseGetBlob, error)
 	GetFile(context.Context, *RequestGetFile) (*ResponseGetFile, error)
+	GetBlame(context.Context, *RequestGetBlame) (*ResponseGetBlame, error)
 	Stat(context.Context, *RequestStat) (*ResponseStat, error)
 	ListTag(context.Context, *RequestListTag) (*ResponseListTag, error)
 	ListBranch(context.Context, *RequestListBranch) (*ResponseListBranch, error)
 	GetRepositoryStatistics(context.Context, *RequestGetRepositoryStatistics) (*ResponseGetRepositoryStatistics, error)
+	WatchReferences(*RequestWatchReferences, GitData_WatchReferencesServer) error
+	GetArchive(*RequestGetArchive, GitData_GetArchiveServer) error
 }
 
 // UnimplementedGitDataServer can be embedded to have forward compatible implementations.
@@ -1995,6 +3296,12 @@ func (*UnimplementedGitDataServer) GetReference(context.Context, *RequestGetRefe
 func (*UnimplementedGitDataServer) GetCommit(context.Context, *RequestGetCommit) (*ResponseGetCommit, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCommit not implemented")
 }
+func (*UnimplementedGitDataServer) ListCommits(context.Context, *RequestListCommits) (*ResponseListCommits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCommits not implemented")
+}
+func (*UnimplementedGitDataServer) Compare(context.Context, *RequestCompare) (*ResponseCompare, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Compare not implemented")
+}
 func (*UnimplementedGitDataServer) GetTree(context.Context, *RequestGetTree) (*ResponseGetTree, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTree not implemented")
 }
@@ -2004,6 +3311,9 @@ func (*UnimplementedGitDataServer) GetBlob(context.Context, *RequestGetBlob) (*R
 func (*UnimplementedGitDataServer) GetFile(context.Context, *RequestGetFile) (*ResponseGetFile, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFile not implemented")
 }
+func (*UnimplementedGitDataServer) GetBlame(context.Context, *RequestGetBlame) (*ResponseGetBlame, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlame not implemented")
+}
 func (*UnimplementedGitDataServer) Stat(context.Context, *RequestStat) (*ResponseStat, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stat not implemented")
 }
@@ -2016,6 +3326,12 @@ func (*UnimplementedGitDataServer) ListBranch(context.Context, *RequestListBranc
 func (*UnimplementedGitDataServer) GetRepositoryStatistics(context.Context, *RequestGetRepositoryStatistics) (*ResponseGetRepositoryStatistics, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRepositoryStatistics not implemented")
 }
+func (*UnimplementedGitDataServer) WatchReferences(*RequestWatchReferences, GitData_WatchReferencesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchReferences not implemented")
+}
+func (*UnimplementedGitDataServer) GetArchive(*RequestGetArchive, GitData_GetArchiveServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetArchive not implemented")
+}
 
 func RegisterGitDataServer(s *grpc.Server, srv GitDataServer) {
 	s.RegisterService(&_GitData_serviceDesc, srv)
@@ -2111,6 +3427,42 @@ func _GitData_GetCommit_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GitData_ListCommits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestListCommits)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitDataServer).ListCommits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mono.git.GitData/ListCommits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitDataServer).ListCommits(ctx, req.(*RequestListCommits))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GitData_Compare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestCompare)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitDataServer).Compare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mono.git.GitData/Compare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitDataServer).Compare(ctx, req.(*RequestCompare))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GitData_GetTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RequestGetTree)
 	if err := dec(in); err != nil {
@@ -2165,6 +3517,24 @@ func _GitData_GetFile_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GitData_GetBlame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestGetBlame)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GitDataServer).GetBlame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mono.git.GitData/GetBlame",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GitDataServer).GetBlame(ctx, req.(*RequestGetBlame))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GitData_Stat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RequestStat)
 	if err := dec(in); err != nil {
@@ -2237,6 +3607,48 @@ func _GitData_GetRepositoryStatistics_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GitData_WatchReferences_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RequestWatchReferences)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GitDataServer).WatchReferences(m, &gitDataWatchReferencesServer{stream})
+}
+
+type GitData_WatchReferencesServer interface {
+	Send(*ResponseWatchReferences) error
+	grpc.ServerStream
+}
+
+type gitDataWatchReferencesServer struct {
+	grpc.ServerStream
+}
+
+func (x *gitDataWatchReferencesServer) Send(m *ResponseWatchReferences) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GitData_GetArchive_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RequestGetArchive)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GitDataServer).GetArchive(m, &gitDataGetArchiveServer{stream})
+}
+
+type GitData_GetArchiveServer interface {
+	Send(*ResponseGetArchive) error
+	grpc.ServerStream
+}
+
+type gitDataGetArchiveServer struct {
+	grpc.ServerStream
+}
+
+func (x *gitDataGetArchiveServer) Send(m *ResponseGetArchive) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _GitData_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "mono.git.GitData",
 	HandlerType: (*GitDataServer)(nil),
@@ -2261,6 +3673,14 @@ var _GitData_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetCommit",
 			Handler:    _GitData_GetCommit_Handler,
 		},
+		{
+			MethodName: "ListCommits",
+			Handler:    _GitData_ListCommits_Handler,
+		},
+		{
+			MethodName: "Compare",
+			Handler:    _GitData_Compare_Handler,
+		},
 		{
 			MethodName: "GetTree",
 			Handler:    _GitData_GetTree_Handler,
@@ -2273,6 +3693,10 @@ var _GitData_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetFile",
 			Handler:    _GitData_GetFile_Handler,
 		},
+		{
+			MethodName: "GetBlame",
+			Handler:    _GitData_GetBlame_Handler,
+		},
 		{
 			MethodName: "Stat",
 			Handler:    _GitData_Stat_Handler,
@@ -2290,6 +3714,17 @@ var _GitData_serviceDesc = grpc.ServiceDesc{
 			Handler:    _GitData_GetRepositoryStatistics_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchReferences",
+			Handler:       _GitData_WatchReferences_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetArchive",
+			Handler:       _GitData_GetArchive_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "proto/git/data.proto",
 }
@@ -0,0 +1,270 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"go.f110.dev/xerrors"
+
+	"go.f110.dev/mono/go/logger/slogger"
+)
+
+const (
+	// DefaultPruneGracePeriod is the same as the default of gc.pruneExpire of git.
+	DefaultPruneGracePeriod = 14 * 24 * time.Hour
+	DefaultSmallPackSize    = 32 * 1024 * 1024
+	// maintenancePackWindow is the number of the objects which are compared for finding the delta base.
+	maintenancePackWindow = 10
+)
+
+type MaintenanceOptions struct {
+	// PruneGracePeriod is the duration for which the unreachable objects are kept.
+	// The objects may be written by the fetch which hasn't updated the refs yet.
+	PruneGracePeriod time.Duration
+	// SmallPackSize is the size of the packfile which is consolidated. The larger packfiles
+	// are kept as it is, and the unreachable objects in them are never pruned.
+	SmallPackSize int64
+}
+
+type MaintenanceResult struct {
+	// Pack is the consolidated packfile. It's zero if no packfile is written.
+	Pack plumbing.Hash
+	// LooseObjects is the number of the loose objects which are moved into Pack or pruned.
+	LooseObjects int
+	// Packs is the number of the packfiles which are merged into Pack.
+	Packs int
+	// Objects is the number of the objects in Pack.
+	Objects int
+	// Pruned is the number of the unreachable objects which are deleted.
+	Pruned int
+}
+
+// Maintain consolidates the loose objects and the small packfiles into one packfile, prunes
+// the unreachable objects older than the grace period and moves the loose refs to packed-refs.
+//
+// The new packfile is written before the old objects are deleted, so the readers can find
+// every object during the maintenance. But the writers must not run concurrently because
+// the objects which are written after listing are not reachable from the refs yet.
+// The caller has to hold the lock of Updater.
+func (b *ObjectStorageStorer) Maintain(ctx context.Context, opt MaintenanceOptions) (*MaintenanceResult, error) {
+	if opt.PruneGracePeriod < 0 {
+		opt.PruneGracePeriod = 0
+	}
+	if opt.SmallPackSize <= 0 {
+		opt.SmallPackSize = DefaultSmallPackSize
+	}
+	pruneBefore := time.Now().Add(-opt.PruneGracePeriod)
+
+	reachable, err := b.reachableObjects()
+	if err != nil {
+		return nil, err
+	}
+
+	objs, err := b.backend.List(ctx, path.Join(b.rootPath, "objects"))
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	keep := make(map[plumbing.Hash]struct{})
+	pruned := make(map[plumbing.Hash]struct{})
+	result := &MaintenanceResult{}
+	var looseObjects []plumbing.Hash
+	for _, v := range objs {
+		h, ok := b.looseObjectHash(v.Name)
+		if !ok {
+			continue
+		}
+		looseObjects = append(looseObjects, h)
+		if _, ok := reachable[h]; ok || !v.LastModified.Before(pruneBefore) {
+			keep[h] = struct{}{}
+		} else {
+			pruned[h] = struct{}{}
+		}
+	}
+
+	packs, err := b.listPacks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var smallPacks []*storedPack
+	for _, v := range packs {
+		if v.Size < opt.SmallPackSize {
+			smallPacks = append(smallPacks, v)
+		}
+	}
+	for _, v := range smallPacks {
+		idx, err := b.loadPackIndex(v.Hash)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		entries, err := idx.Entries()
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		for {
+			entry, err := entries.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, xerrors.WithStack(err)
+			}
+			if _, ok := reachable[entry.Hash]; ok || !v.LastModified.Before(pruneBefore) {
+				keep[entry.Hash] = struct{}{}
+			} else {
+				pruned[entry.Hash] = struct{}{}
+			}
+		}
+		if err := entries.Close(); err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+	}
+	// The object which is kept by another copy is not pruned.
+	for h := range keep {
+		delete(pruned, h)
+	}
+	if len(looseObjects) == 0 && len(smallPacks) < 2 && len(pruned) == 0 {
+		// There are no objects to consolidate or prune.
+		return result, b.PackRefs()
+	}
+
+	if len(keep) > 0 {
+		hashes := make([]plumbing.Hash, 0, len(keep))
+		for h := range keep {
+			hashes = append(hashes, h)
+		}
+		w, err := b.PackfileWriter()
+		if err != nil {
+			return nil, err
+		}
+		checksum, err := packfile.NewEncoder(w, b, false).Encode(hashes, maintenancePackWindow)
+		if err != nil {
+			_ = w.Close()
+			return nil, xerrors.WithStack(err)
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		result.Pack = checksum
+		result.Objects = len(hashes)
+	}
+
+	// Delete the old objects after the new packfile is stored.
+	for _, h := range looseObjects {
+		if err := b.backend.Delete(ctx, path.Join(b.rootPath, "objects", h.String()[0:2], h.String()[2:40])); err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+	}
+	result.LooseObjects = len(looseObjects)
+	for _, v := range smallPacks {
+		if v.Hash == result.Pack {
+			continue
+		}
+		// The index is deleted first because the packfile without the index is ignored.
+		base := path.Join(b.rootPath, "objects/pack", fmt.Sprintf("pack-%s", v.Hash.String()))
+		if err := b.backend.Delete(ctx, base+".idx"); err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		if err := b.backend.Delete(ctx, base+".pack"); err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		result.Packs++
+	}
+	result.Pruned = len(pruned)
+
+	if err := b.PackRefs(); err != nil {
+		return nil, err
+	}
+	slogger.Log.Info("Maintained repository",
+		slog.String("path", b.rootPath),
+		slog.String("pack", result.Pack.String()),
+		slog.Int("objects", result.Objects),
+		slog.Int("loose_objects", result.LooseObjects),
+		slog.Int("packs", result.Packs),
+		slog.Int("pruned", result.Pruned),
+	)
+	return result, nil
+}
+
+// reachableObjects returns all objects which are reachable from the refs. The parents of
+// the shallow commits are not walked.
+func (b *ObjectStorageStorer) reachableObjects() (map[plumbing.Hash]struct{}, error) {
+	iter, err := b.IterReferences()
+	if err != nil {
+		return nil, err
+	}
+	var stack []plumbing.Hash
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		if ref.Type() == plumbing.HashReference {
+			stack = append(stack, ref.Hash())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	shallow, err := b.Shallow()
+	if err != nil {
+		return nil, err
+	}
+	shallowCommits := make(map[plumbing.Hash]struct{})
+	for _, v := range shallow {
+		shallowCommits[v] = struct{}{}
+	}
+
+	reachable := make(map[plumbing.Hash]struct{})
+	for len(stack) > 0 {
+		h := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, ok := reachable[h]; ok {
+			continue
+		}
+		obj, err := b.EncodedObject(plumbing.AnyObject, h)
+		if err != nil {
+			return nil, xerrors.WithMessagef(err, "failed to read object %s", h)
+		}
+		reachable[h] = struct{}{}
+
+		switch obj.Type() {
+		case plumbing.CommitObject:
+			c, err := object.DecodeCommit(b, obj)
+			if err != nil {
+				return nil, xerrors.WithStack(err)
+			}
+			stack = append(stack, c.TreeHash)
+			if _, ok := shallowCommits[h]; !ok {
+				stack = append(stack, c.ParentHashes...)
+			}
+		case plumbing.TreeObject:
+			t, err := object.DecodeTree(b, obj)
+			if err != nil {
+				return nil, xerrors.WithStack(err)
+			}
+			for _, e := range t.Entries {
+				switch e.Mode {
+				case filemode.Dir:
+					stack = append(stack, e.Hash)
+				case filemode.Submodule:
+					// The commit of the submodule is in another repository.
+				default:
+					// The blob doesn't refer the other objects, so it isn't read.
+					reachable[e.Hash] = struct{}{}
+				}
+			}
+		case plumbing.TagObject:
+			t, err := object.DecodeTag(b, obj)
+			if err != nil {
+				return nil, xerrors.WithStack(err)
+			}
+			stack = append(stack, t.Target)
+		}
+	}
+	return reachable, nil
+}
@@ -0,0 +1,130 @@
+package git
+
+import (
+	"context"
+	"path"
+	"testing"
+	"time"
+
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.f110.dev/mono/go/logger"
+	"go.f110.dev/mono/go/logger/slogger"
+	"go.f110.dev/mono/go/storage"
+)
+
+func TestObjectStorageStorerMaintain(t *testing.T) {
+	logger.SetLogLevel("debug")
+	logger.Init()
+	slogger.Init()
+
+	sourceRepo := makeSourceRepository(t)
+	head := addCommit(t, sourceRepo, "CHANGELOG.md", "v2")
+	mockStorage := storage.NewMock()
+	repoPath := sourceRepo.Storer.(*filesystem.Storage).Filesystem().Root()
+	_, err := InitObjectStorageRepository(context.Background(), mockStorage, repoPath, "test", nil)
+	require.NoError(t, err)
+
+	s := NewObjectStorageStorer(mockStorage, "test", nil, nil)
+	unreachable := setBlob(t, s, "unreachable")
+
+	res, err := s.Maintain(context.Background(), MaintenanceOptions{PruneGracePeriod: time.Hour})
+	require.NoError(t, err)
+	assert.False(t, res.Pack.IsZero())
+	assert.NotZero(t, res.LooseObjects)
+	// The unreachable object is kept in the grace period.
+	assert.Equal(t, 0, res.Pruned)
+	assert.NoError(t, s.HasEncodedObject(unreachable))
+	assertNoLooseObjects(t, mockStorage, s)
+	assertRepository(t, s, head)
+
+	// The loose ref was moved to packed-refs.
+	_, err = mockStorage.Get(context.Background(), "test/refs/heads/master")
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+	ref, err := s.Reference(plumbing.NewBranchReferenceName("master"))
+	require.NoError(t, err)
+	assert.Equal(t, head, ref.Hash())
+
+	t.Run("Prune", func(t *testing.T) {
+		other := setBlob(t, s, "other")
+		res, err := s.Maintain(context.Background(), MaintenanceOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, res.LooseObjects)
+		assert.Equal(t, 1, res.Packs)
+		assert.Equal(t, 2, res.Pruned)
+		assert.Error(t, s.HasEncodedObject(unreachable))
+		assert.Error(t, s.HasEncodedObject(other))
+		assertRepository(t, s, head)
+
+		packs, err := s.listPacks(context.Background())
+		require.NoError(t, err)
+		require.Len(t, packs, 1)
+		assert.Equal(t, res.Pack, packs[0].Hash)
+	})
+
+	t.Run("PruneWithoutConsolidation", func(t *testing.T) {
+		another := setBlob(t, s, "another")
+		_, err := s.Maintain(context.Background(), MaintenanceOptions{PruneGracePeriod: time.Hour})
+		require.NoError(t, err)
+		require.NoError(t, s.HasEncodedObject(another))
+
+		// There is only one packfile and no loose objects, but the unreachable object in the packfile is pruned.
+		res, err := s.Maintain(context.Background(), MaintenanceOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 0, res.LooseObjects)
+		assert.Equal(t, 1, res.Pruned)
+		assert.Error(t, s.HasEncodedObject(another))
+		assertRepository(t, s, head)
+
+		// Nothing is written if there are no objects to prune.
+		res, err = s.Maintain(context.Background(), MaintenanceOptions{})
+		require.NoError(t, err)
+		assert.True(t, res.Pack.IsZero())
+	})
+}
+
+func setBlob(t *testing.T, s *ObjectStorageStorer, content string) plumbing.Hash {
+	obj := &plumbing.MemoryObject{}
+	obj.SetType(plumbing.BlobObject)
+	_, err := obj.Write([]byte(content))
+	require.NoError(t, err)
+	h, err := s.SetEncodedObject(obj)
+	require.NoError(t, err)
+	return h
+}
+
+func assertNoLooseObjects(t *testing.T, st *storage.Mock, s *ObjectStorageStorer) {
+	objs, err := st.List(context.Background(), path.Join(s.rootPath, "objects"))
+	require.NoError(t, err)
+	for _, v := range objs {
+		_, ok := s.looseObjectHash(v.Name)
+		assert.False(t, ok, "%s is the loose object", v.Name)
+	}
+}
+
+// assertRepository checks all commits and files are readable from the head.
+func assertRepository(t *testing.T, s *ObjectStorageStorer, head plumbing.Hash) {
+	repo, err := goGit.Open(s, nil)
+	require.NoError(t, err)
+	commits, err := repo.Log(&goGit.LogOptions{From: head})
+	require.NoError(t, err)
+	var n int
+	err = commits.ForEach(func(c *object.Commit) error {
+		n++
+		files, err := c.Files()
+		if err != nil {
+			return err
+		}
+		return files.ForEach(func(f *object.File) error {
+			_, err := f.Contents()
+			return err
+		})
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
@@ -14,37 +14,32 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-git/go-billy/v5"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	commitgraphFormat "github.com/go-git/go-git/v5/plumbing/format/commitgraph/v2"
 	"github.com/go-git/go-git/v5/plumbing/format/idxfile"
 	"github.com/go-git/go-git/v5/plumbing/format/index"
 	"github.com/go-git/go-git/v5/plumbing/format/objfile"
 	"github.com/go-git/go-git/v5/plumbing/format/packfile"
 	"github.com/go-git/go-git/v5/plumbing/storer"
-	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	gitStorage "github.com/go-git/go-git/v5/storage"
 	"go.f110.dev/go-memcached/client"
 	"go.f110.dev/xerrors"
 
 	"go.f110.dev/mono/go/collections/dict"
-	"go.f110.dev/mono/go/collections/set"
 	"go.f110.dev/mono/go/logger/slogger"
 	"go.f110.dev/mono/go/storage"
 )
 
-type ObjectStorageInterface interface {
-	PutReader(ctx context.Context, name string, data io.Reader) error
-	Delete(ctx context.Context, name string) error
-	Get(ctx context.Context, name string) (*storage.Object, error)
-	List(ctx context.Context, prefix string) ([]*storage.Object, error)
-}
-
-func InitObjectStorageRepository(ctx context.Context, b ObjectStorageInterface, url, prefix string, auth *http.BasicAuth) (*git.Repository, error) {
+func InitObjectStorageRepository(ctx context.Context, b storage.Backend, url, prefix string, auth transport.AuthMethod) (*git.Repository, error) {
 	tmpDir, err := os.MkdirTemp("", "")
 	if err != nil {
 		return nil, err
@@ -95,7 +90,7 @@ func InitObjectStorageRepository(ctx context.Context, b ObjectStorageInterface,
 	return repo, nil
 }
 
-func InflatePackFile(ctx context.Context, st ObjectStorageInterface, rootPath string, repo *git.Repository) error {
+func InflatePackFile(ctx context.Context, st storage.Backend, rootPath string, repo *git.Repository) error {
 	packFiles, err := st.List(ctx, path.Join(rootPath, "objects/pack"))
 	if err != nil {
 		return err
@@ -167,40 +162,57 @@ func InflatePackFile(ctx context.Context, st ObjectStorageInterface, rootPath st
 }
 
 type ObjectStorageStorer struct {
-	backend   ObjectStorageInterface
+	backend   storage.Backend
 	rootPath  string
 	cachePool *client.SinglePool
-	// packCache keeps fetched packfiles (idx + raw pack bytes) in process memory so
-	// that resolving many objects out of the same pack does not re-download it from
+	// packCache keeps fetched indexes and blocks of packfiles in process memory so
+	// that resolving many objects out of the same pack does not re-download them from
 	// the backend for every single object. It is shared across the storers created
 	// for sub-modules. It may be nil, in which case packs are fetched every time.
 	packCache *PackfileCache
+
+	commitGraphMu       sync.Mutex
+	commitGraph         commitgraphFormat.Index
+	commitGraphLoadedAt time.Time
 }
 
-// packEntry is a fetched packfile held in a PackfileCache. pack is the raw .pack
-// bytes and bounds the cached size; idx is its decoded index.
+// packBlockSize is the size of the range which is read from the packfile at once.
+const packBlockSize = 256 * 1024
+
+// packEntry is a fetched part of a packfile held in a PackfileCache. Either idx,
+// the decoded index, or pack, a block of the raw .pack bytes, is set.
 type packEntry struct {
 	idx  *idxfile.MemoryIndex
 	pack []byte
 }
 
-func packEntrySize(e *packEntry) int64 { return int64(len(e.pack)) }
+func packEntrySize(e *packEntry) int64 {
+	size := int64(len(e.pack))
+	if e.idx != nil {
+		for i := range e.idx.Names {
+			size += int64(len(e.idx.Names[i]) + len(e.idx.Offset32[i]) + len(e.idx.CRC32[i]))
+		}
+		size += int64(len(e.idx.Offset64))
+	}
+	return size
+}
 
 // PackfileCache is the process-level cache of fetched packfiles shared across the
 // ObjectStorageStorer instances of one process.
 type PackfileCache = dict.TTLCache[string, *packEntry]
 
 // NewPackfileCache creates a PackfileCache. maxBytes bounds the total size of the
-// cached pack bytes, which is what lets the caller cap the memory it uses. A
-// positive sweepInterval starts a background goroutine that reclaims expired
+// cached indexes and pack blocks, which is what lets the caller cap the memory it
+// uses. A positive sweepInterval starts a background goroutine that reclaims expired
 // packs; call Close to stop it.
 func NewPackfileCache(ttl, sweepInterval time.Duration, maxBytes int64) *PackfileCache {
 	return dict.NewTTLCache[string, *packEntry](ttl, sweepInterval, maxBytes, packEntrySize)
 }
 
 var _ gitStorage.Storer = &ObjectStorageStorer{}
+var _ commitGraphStorer = &ObjectStorageStorer{}
 
-func NewObjectStorageStorer(b ObjectStorageInterface, rootPath string, cachePool *client.SinglePool, packCache *PackfileCache) *ObjectStorageStorer {
+func NewObjectStorageStorer(b storage.Backend, rootPath string, cachePool *client.SinglePool, packCache *PackfileCache) *ObjectStorageStorer {
 	return &ObjectStorageStorer{backend: b, rootPath: rootPath, cachePool: cachePool, packCache: packCache}
 }
 
@@ -332,6 +344,58 @@ func (b *ObjectStorageStorer) Shallow() ([]plumbing.Hash, error) {
 	return hash, nil
 }
 
+// CommitGraph returns the commit-graph of the repository. nil is returned if the
+// repository doesn't have it yet. The loaded commit-graph is reused for commitGraphTTL.
+func (b *ObjectStorageStorer) CommitGraph() (commitgraphFormat.Index, error) {
+	b.commitGraphMu.Lock()
+	defer b.commitGraphMu.Unlock()
+	if !b.commitGraphLoadedAt.IsZero() && time.Since(b.commitGraphLoadedAt) < commitGraphTTL {
+		return b.commitGraph, nil
+	}
+
+	file, err := b.backend.Get(context.Background(), path.Join(b.rootPath, commitGraphPath))
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			b.commitGraph, b.commitGraphLoadedAt = nil, time.Now()
+			return nil, nil
+		}
+		return nil, xerrors.WithStack(err)
+	}
+	buf, err := io.ReadAll(file.Body)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	if err := file.Body.Close(); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	idx, err := decodeCommitGraph(buf)
+	if err != nil {
+		return nil, err
+	}
+	b.commitGraph, b.commitGraphLoadedAt = idx, time.Now()
+	return idx, nil
+}
+
+func (b *ObjectStorageStorer) SetCommitGraph(idx commitgraphFormat.Index) error {
+	buf, err := encodeCommitGraph(idx)
+	if err != nil {
+		return err
+	}
+	// Keep the decoded form so that the readers in this process see the same data as the other replicas.
+	loaded, err := decodeCommitGraph(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	if err := b.backend.PutReader(context.Background(), path.Join(b.rootPath, commitGraphPath), buf); err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	b.commitGraphMu.Lock()
+	b.commitGraph, b.commitGraphLoadedAt = loaded, time.Now()
+	b.commitGraphMu.Unlock()
+	return nil
+}
+
 func (b *ObjectStorageStorer) SetReference(ref *plumbing.Reference) error {
 	buf := new(bytes.Buffer)
 	switch ref.Type() {
@@ -377,7 +441,7 @@ func (b *ObjectStorageStorer) Reference(name plumbing.ReferenceName) (*plumbing.
 	file, err := b.backend.Get(context.Background(), path.Join(b.rootPath, name.String()))
 	if err != nil {
 		if errors.Is(err, storage.ErrObjectNotFound) {
-			return nil, plumbing.ErrReferenceNotFound
+			return b.packedReference(name)
 		}
 		return nil, xerrors.WithStack(err)
 	}
@@ -388,6 +452,20 @@ func (b *ObjectStorageStorer) Reference(name plumbing.ReferenceName) (*plumbing.
 	return ref, nil
 }
 
+// packedReference finds name from packed-refs. The loose ref is moved to packed-refs by PackRefs.
+func (b *ObjectStorageStorer) packedReference(name plumbing.ReferenceName) (*plumbing.Reference, error) {
+	refs, err := b.readPackedRefs()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range refs {
+		if v.Name() == name {
+			return v, nil
+		}
+	}
+	return nil, plumbing.ErrReferenceNotFound
+}
+
 func (b *ObjectStorageStorer) readReference(f io.ReadCloser, name string) (*plumbing.Reference, error) {
 	buf, err := io.ReadAll(f)
 	if err != nil {
@@ -476,7 +554,7 @@ func (b *ObjectStorageStorer) readPackedRefs() ([]*plumbing.Reference, error) {
 		if err != nil {
 			return nil, xerrors.WithStack(err)
 		}
-		if refs != nil {
+		if ref != nil {
 			refs = append(refs, ref)
 		}
 	}
@@ -541,7 +619,7 @@ func (b *ObjectStorageStorer) RemoveReference(name plumbing.ReferenceName) error
 				continue
 			}
 		}
-		if _, err := newPackedRefs.WriteString(line); err != nil {
+		if _, err := fmt.Fprintln(newPackedRefs, line); err != nil {
 			return xerrors.WithStack(err)
 		}
 	}
@@ -581,32 +659,43 @@ func (b *ObjectStorageStorer) CountLooseRefs() (int, error) {
 	return count, nil
 }
 
+// PackRefs moves the loose refs to packed-refs. The symbolic refs are kept as the loose ref.
 func (b *ObjectStorageStorer) PackRefs() error {
-	r, err := b.readRefs()
+	loose, err := b.readRefs()
 	if err != nil {
 		return xerrors.WithStack(err)
 	}
-	if len(r) == 0 {
-		return nil
+	var hashRefs []*plumbing.Reference
+	for _, v := range loose {
+		if v.Type() == plumbing.HashReference {
+			hashRefs = append(hashRefs, v)
+		}
 	}
-	refSet := set.New[*plumbing.Reference]()
-	for _, v := range r {
-		refSet.Add(v)
+	if len(hashRefs) == 0 {
+		return nil
 	}
 
 	packedRefs, err := b.readPackedRefs()
 	if err != nil {
 		return xerrors.WithStack(err)
 	}
-	packedRefsSet := set.New[*plumbing.Reference]()
+	refs := make(map[plumbing.ReferenceName]*plumbing.Reference)
 	for _, v := range packedRefs {
-		refSet.Add(v)
-		packedRefsSet.Add(v)
+		refs[v.Name()] = v
+	}
+	// The loose ref is newer than the packed one.
+	for _, v := range hashRefs {
+		refs[v.Name()] = v
 	}
+	names := make([]string, 0, len(refs))
+	for k := range refs {
+		names = append(names, k.String())
+	}
+	sort.Strings(names)
 
 	buf := new(bytes.Buffer)
-	for _, ref := range refSet.ToSlice() {
-		if _, err := fmt.Fprintln(buf, ref.String()); err != nil {
+	for _, name := range names {
+		if _, err := fmt.Fprintln(buf, refs[plumbing.ReferenceName(name)].String()); err != nil {
 			return xerrors.WithStack(err)
 		}
 	}
@@ -615,9 +704,8 @@ func (b *ObjectStorageStorer) PackRefs() error {
 		return xerrors.WithStack(err)
 	}
 
-	// Delete all loose refs.
-	looseRefs := refSet.RightOuter(packedRefsSet)
-	for _, ref := range looseRefs.ToSlice() {
+	// Delete the loose refs after packed-refs has them.
+	for _, ref := range hashRefs {
 		err := b.backend.Delete(context.Background(), path.Join(b.rootPath, ref.Name().String()))
 		if err != nil {
 			return xerrors.WithStack(err)
@@ -677,7 +765,7 @@ func (b *ObjectStorageStorer) PackfileWriter() (io.WriteCloser, error) {
 // packfileWriter buffers the incoming packfile on local disk, then on Close
 // builds its index and uploads the pack/idx pair to the backend.
 type packfileWriter struct {
-	backend  ObjectStorageInterface
+	backend  storage.Backend
 	rootPath string
 	tmp      *os.File
 }
@@ -767,43 +855,30 @@ func (b *ObjectStorageStorer) getEncodedObjectFromPackFile(h plumbing.Hash) (plu
 		}
 	}
 
-	packFiles, err := b.backend.List(context.Background(), path.Join(b.rootPath, "objects/pack"))
+	packs, err := b.listPacks(context.Background())
 	if err != nil {
 		return nil, err
 	}
-	var packs []plumbing.Hash
-	for _, f := range packFiles {
-		n := filepath.Base(f.Name)
-		if filepath.Ext(n) != ".pack" || !strings.HasPrefix(n, "pack-") {
-			continue
-		}
-		h := plumbing.NewHash(n[5 : len(n)-5])
-		if h.IsZero() {
-			continue
-		}
-		packs = append(packs, h)
-	}
 
-	var entry *packEntry
-	var packHash plumbing.Hash
+	var pack *storedPack
+	var idx *idxfile.MemoryIndex
 	// Find the pack that contains the object via its index.
 	for _, v := range packs {
-		e, err := b.loadPack(v)
+		i, err := b.loadPackIndex(v.Hash)
 		if err != nil {
 			return nil, err
 		}
-		if _, err := e.idx.FindOffset(h); err == nil {
-			entry = e
-			packHash = v
+		if _, err := i.FindOffset(h); err == nil {
+			pack, idx = v, i
 			break
 		}
 	}
-	if entry == nil {
+	if pack == nil {
 		return nil, plumbing.ErrObjectNotFound
 	}
 
-	// Fetch the object from the (cached) packfile bytes.
-	packfileReader := packfile.NewPackfile(entry.idx, nil, newBufferedFile(fmt.Sprintf("objects/pack/pack-%s.pack", packHash.String()), entry.pack), 0)
+	// Only the blocks which contain the object (and its delta bases) are fetched.
+	packfileReader := packfile.NewPackfile(idx, nil, b.newPackBlockFile(pack), 0)
 	obj, err := packfileReader.Get(h)
 	_ = packfileReader.Close()
 	if err == nil {
@@ -828,41 +903,84 @@ func (b *ObjectStorageStorer) getEncodedObjectFromPackFile(h plumbing.Hash) (plu
 	return nil, plumbing.ErrObjectNotFound
 }
 
-// loadPack fetches a packfile (its index and raw bytes) from the backend. When a
-// packCache is configured the result is cached in process memory and concurrent
-// loads of the same pack are coalesced into a single fetch.
-func (b *ObjectStorageStorer) loadPack(v plumbing.Hash) (*packEntry, error) {
+// loadPackIndex fetches the index of a packfile from the backend. When a packCache
+// is configured the index is cached in process memory and concurrent loads of the
+// same index are coalesced into a single fetch.
+func (b *ObjectStorageStorer) loadPackIndex(v plumbing.Hash) (*idxfile.MemoryIndex, error) {
+	name := filepath.Join(b.rootPath, fmt.Sprintf("objects/pack/pack-%s.idx", v.String()))
 	load := func() (*packEntry, error) {
-		idxFile, err := b.backend.Get(context.Background(), filepath.Join(b.rootPath, fmt.Sprintf("objects/pack/pack-%s.idx", v.String())))
+		idxFile, err := b.backend.Get(context.Background(), name)
 		if err != nil {
 			return nil, err
 		}
 		idx := idxfile.NewMemoryIndex()
 		if err := idxfile.NewDecoder(idxFile.Body).Decode(idx); err != nil {
+			idxFile.Body.Close()
 			return nil, err
 		}
 		if err := idxFile.Body.Close(); err != nil {
 			return nil, err
 		}
+		return &packEntry{idx: idx}, nil
+	}
 
-		packFile, err := b.backend.Get(context.Background(), filepath.Join(b.rootPath, fmt.Sprintf("objects/pack/pack-%s.pack", v.String())))
+	var e *packEntry
+	var err error
+	if b.packCache == nil {
+		e, err = load()
+	} else {
+		e, err = b.packCache.GetOrLoad(name, load)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e.idx, nil
+}
+
+// loadPackBlock fetches the i-th block of the packfile by the ranged request. size is the
+// size of the whole packfile.
+func (b *ObjectStorageStorer) loadPackBlock(name string, i, size int64) ([]byte, error) {
+	load := func() (*packEntry, error) {
+		offset := i * packBlockSize
+		length := min(packBlockSize, size-offset)
+		obj, err := b.backend.GetRange(context.Background(), name, offset, length)
 		if err != nil {
 			return nil, err
 		}
-		buf, err := io.ReadAll(packFile.Body)
+		buf, err := io.ReadAll(obj.Body)
 		if err != nil {
-			return nil, err
+			obj.Body.Close()
+			return nil, xerrors.WithStack(err)
 		}
-		if err := packFile.Body.Close(); err != nil {
-			return nil, err
+		if err := obj.Body.Close(); err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		if int64(len(buf)) != length {
+			return nil, xerrors.Definef("short read of %s: expected %d bytes at %d, got %d bytes", name, length, offset, len(buf)).WithStack()
 		}
-		return &packEntry{idx: idx, pack: buf}, nil
+		return &packEntry{pack: buf}, nil
 	}
 
+	var e *packEntry
+	var err error
 	if b.packCache == nil {
-		return load()
+		e, err = load()
+	} else {
+		e, err = b.packCache.GetOrLoad(fmt.Sprintf("%s@%d", name, i), load)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e.pack, nil
+}
+
+func (b *ObjectStorageStorer) newPackBlockFile(v *storedPack) *packBlockFile {
+	return &packBlockFile{
+		storer:     b,
+		name:       filepath.Join(b.rootPath, fmt.Sprintf("objects/pack/pack-%s.pack", v.Hash.String())),
+		size:       v.Size,
+		blockIndex: -1,
 	}
-	return b.packCache.GetOrLoad(filepath.Join(b.rootPath, fmt.Sprintf("objects/pack/pack-%s", v.String())), load)
 }
 
 func (b *ObjectStorageStorer) getUnpackedEncodedObject(h plumbing.Hash) (plumbing.EncodedObject, error) {
@@ -924,16 +1042,18 @@ func (b *ObjectStorageStorer) readUnpackedEncodedObject(f io.ReadCloser, h plumb
 	return obj, nil
 }
 
+// IterEncodedObjects returns the loose objects and the objects in the packfiles.
 func (b *ObjectStorageStorer) IterEncodedObjects(objectType plumbing.ObjectType) (storer.EncodedObjectIter, error) {
 	objs, err := b.backend.List(context.Background(), path.Join(b.rootPath, "objects"))
 	if err != nil {
 		return nil, xerrors.WithStack(err)
 	}
 
+	seen := make(map[plumbing.Hash]struct{})
 	var encodedObjs []plumbing.EncodedObject
 	for _, v := range objs {
-		s := strings.Split(strings.TrimPrefix(v.Name, b.rootPath), "/")
-		if len(s[2]) != 2 || len(s[3]) != 38 {
+		h, ok := b.looseObjectHash(v.Name)
+		if !ok {
 			continue
 		}
 		file, err := b.backend.Get(context.Background(), v.Name)
@@ -941,26 +1061,170 @@ func (b *ObjectStorageStorer) IterEncodedObjects(objectType plumbing.ObjectType)
 			return nil, xerrors.WithStack(err)
 		}
 
-		obj, err := b.readUnpackedEncodedObject(file.Body, plumbing.NewHash(s[2]+s[3]))
+		obj, err := b.readUnpackedEncodedObject(file.Body, h)
 		if err != nil {
 			return nil, xerrors.WithStack(err)
 		}
+		if objectType != plumbing.AnyObject && obj.Type() != objectType {
+			continue
+		}
+		seen[h] = struct{}{}
 		encodedObjs = append(encodedObjs, obj)
 	}
+
+	packs, err := b.listPacks(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range packs {
+		idx, err := b.loadPackIndex(v.Hash)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		packfileReader := packfile.NewPackfile(idx, nil, b.newPackBlockFile(v), 0)
+		iter, err := packfileReader.GetByType(objectType)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		err = iter.ForEach(func(obj plumbing.EncodedObject) error {
+			if _, ok := seen[obj.Hash()]; ok {
+				return nil
+			}
+			seen[obj.Hash()] = struct{}{}
+			encodedObjs = append(encodedObjs, obj)
+			return nil
+		})
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+	}
 	return storer.NewEncodedObjectSliceIter(encodedObjs), nil
 }
 
+// looseObjectHash returns the hash of the loose object stored at name.
+func (b *ObjectStorageStorer) looseObjectHash(name string) (plumbing.Hash, bool) {
+	s := strings.Split(strings.TrimPrefix(name, b.rootPath), "/")
+	if len(s) != 4 || len(s[2]) != 2 || len(s[3]) != 38 {
+		return plumbing.ZeroHash, false
+	}
+	if !plumbing.IsHash(s[2] + s[3]) {
+		return plumbing.ZeroHash, false
+	}
+	return plumbing.NewHash(s[2] + s[3]), true
+}
+
+// storedPack is a packfile in the object storage.
+type storedPack struct {
+	Hash         plumbing.Hash
+	Size         int64
+	LastModified time.Time
+}
+
+// listPacks returns the packfiles which have the index. The packfile without the index is
+// ignored since the index is written after the packfile.
+func (b *ObjectStorageStorer) listPacks(ctx context.Context) ([]*storedPack, error) {
+	files, err := b.backend.List(ctx, path.Join(b.rootPath, "objects/pack"))
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	packs := make(map[string]*storedPack)
+	indexes := make(map[string]struct{})
+	for _, f := range files {
+		n := filepath.Base(f.Name)
+		if !strings.HasPrefix(n, "pack-") {
+			continue
+		}
+		switch filepath.Ext(n) {
+		case ".pack":
+			h := plumbing.NewHash(n[5 : len(n)-5])
+			if h.IsZero() {
+				continue
+			}
+			packs[h.String()] = &storedPack{Hash: h, Size: f.Size, LastModified: f.LastModified}
+		case ".idx":
+			indexes[n[5:len(n)-4]] = struct{}{}
+		}
+	}
+
+	var result []*storedPack
+	for k, v := range packs {
+		if _, ok := indexes[k]; ok {
+			result = append(result, v)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Hash.String() < result[j].Hash.String() })
+	return result, nil
+}
+
 func (b *ObjectStorageStorer) HasEncodedObject(hash plumbing.Hash) error {
-	_, err := b.getUnpackedEncodedObject(hash)
+	_, err := b.EncodedObject(plumbing.AnyObject, hash)
 	return err
 }
 
+// EncodedObjectSize returns the size of the object without reading its content.
+// Only the header of the loose object, or the header of the object in the packfile
+// which is located by the pack index, is read.
 func (b *ObjectStorageStorer) EncodedObjectSize(hash plumbing.Hash) (int64, error) {
-	obj, err := b.getUnpackedEncodedObject(hash)
+	size, err := b.getUnpackedEncodedObjectSize(hash)
+	if errors.Is(err, plumbing.ErrObjectNotFound) {
+		size, err = b.getEncodedObjectSizeFromPackFile(hash)
+	}
 	if err != nil {
 		return -1, xerrors.WithStack(err)
 	}
-	return obj.Size(), nil
+	return size, nil
+}
+
+func (b *ObjectStorageStorer) getUnpackedEncodedObjectSize(h plumbing.Hash) (int64, error) {
+	file, err := b.backend.Get(context.Background(), path.Join(b.rootPath, "objects", h.String()[0:2], h.String()[2:40]))
+	if err != nil && errors.Is(err, storage.ErrObjectNotFound) {
+		return -1, plumbing.ErrObjectNotFound
+	}
+	if err != nil {
+		return -1, err
+	}
+	defer file.Body.Close()
+
+	r, err := objfile.NewReader(file.Body)
+	if err != nil {
+		return -1, xerrors.WithStack(err)
+	}
+	defer r.Close()
+	_, size, err := r.Header()
+	if err != nil {
+		return -1, xerrors.WithStack(err)
+	}
+	return size, nil
+}
+
+func (b *ObjectStorageStorer) getEncodedObjectSizeFromPackFile(h plumbing.Hash) (int64, error) {
+	packs, err := b.listPacks(context.Background())
+	if err != nil {
+		return -1, err
+	}
+
+	for _, v := range packs {
+		idx, err := b.loadPackIndex(v.Hash)
+		if err != nil {
+			return -1, err
+		}
+		offset, err := idx.FindOffset(h)
+		if err != nil {
+			continue
+		}
+
+		// Only the block which contains the object header is fetched. The size of the
+		// deltified object is read from the header of the delta.
+		packfileReader := packfile.NewPackfile(idx, nil, b.newPackBlockFile(v), 0)
+		size, err := packfileReader.GetSizeByOffset(offset)
+		_ = packfileReader.Close()
+		if err != nil {
+			return -1, xerrors.WithStack(err)
+		}
+		return size, nil
+	}
+
+	return -1, plumbing.ErrObjectNotFound
 }
 
 func (b *ObjectStorageStorer) AddAlternate(remote string) error {
@@ -973,6 +1237,9 @@ type EncodedObject struct {
 	size int64
 	r    io.ReadCloser
 	w    io.WriteCloser
+	// buf is the content which is read from r. The packfile encoder reads the object
+	// more than once for the delta compression.
+	buf []byte
 }
 
 var _ plumbing.EncodedObject = &EncodedObject{}
@@ -998,10 +1265,21 @@ func (e *EncodedObject) SetSize(i int64) {
 }
 
 func (e *EncodedObject) Reader() (io.ReadCloser, error) {
-	if e.r == nil {
-		return nil, xerrors.New("this object is not readable")
+	if e.buf == nil {
+		if e.r == nil {
+			return nil, xerrors.New("this object is not readable")
+		}
+		b, err := io.ReadAll(e.r)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		if err := e.r.Close(); err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		e.r = nil
+		e.buf = b
 	}
-	return e.r, nil
+	return io.NopCloser(bytes.NewReader(e.buf)), nil
 }
 
 func (e *EncodedObject) Writer() (io.WriteCloser, error) {
@@ -1013,6 +1291,7 @@ func (e *EncodedObject) Writer() (io.WriteCloser, error) {
 
 func (e *EncodedObject) SetReader(r io.ReadCloser) {
 	e.r = r
+	e.buf = nil
 }
 
 func (e *EncodedObject) SetWriter(w *objfile.Writer) {
@@ -1021,13 +1300,11 @@ func (e *EncodedObject) SetWriter(w *objfile.Writer) {
 
 func (e *EncodedObject) MarshalJSON() ([]byte, error) {
 	var buf []byte
-	if e.r != nil {
-		b, err := io.ReadAll(e.r)
-		if err != nil {
+	if e.r != nil || e.buf != nil {
+		if _, err := e.Reader(); err != nil {
 			return nil, err
 		}
-		e.r = io.NopCloser(bytes.NewReader(b))
-		buf = b
+		buf = e.buf
 	}
 
 	j := new(bytes.Buffer)
@@ -1063,7 +1340,7 @@ func (e *EncodedObject) UnmarshalJSON(b []byte) error {
 		if err != nil {
 			return err
 		}
-		e.r = io.NopCloser(bytes.NewReader(buf))
+		e.buf = buf
 		e.size = int64(len(buf))
 	}
 	return nil
@@ -1105,3 +1382,90 @@ func (f *bufferedFile) Truncate(size int64) error {
 	f.stub.Truncate(int(size))
 	return nil
 }
+
+// packBlockFile is the read-only packfile in the object storage. The file is read by
+// the blocks of packBlockSize, so the reader fetches only the blocks which it reads.
+type packBlockFile struct {
+	storer *ObjectStorageStorer
+	name   string
+	size   int64
+	offset int64
+
+	// block is the last read block. The scanner of the packfile reads the object
+	// sequentially, so the block is used repeatedly.
+	blockIndex int64
+	block      []byte
+}
+
+var _ billy.File = (*packBlockFile)(nil)
+
+func (f *packBlockFile) Name() string {
+	return f.name
+}
+
+func (f *packBlockFile) Read(p []byte) (int, error) {
+	n, err := f.ReadAt(p, f.offset)
+	f.offset += int64(n)
+	if n > 0 && err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
+
+func (f *packBlockFile) ReadAt(p []byte, off int64) (int, error) {
+	n := 0
+	for n < len(p) && off < f.size {
+		i := off / packBlockSize
+		if f.block == nil || f.blockIndex != i {
+			block, err := f.storer.loadPackBlock(f.name, i, f.size)
+			if err != nil {
+				return n, err
+			}
+			f.block, f.blockIndex = block, i
+		}
+		c := copy(p[n:], f.block[off-i*packBlockSize:])
+		n += c
+		off += int64(c)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (f *packBlockFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.size
+	default:
+		return 0, xerrors.Definef("invalid whence: %d", whence).WithStack()
+	}
+	if offset < 0 {
+		return 0, xerrors.Define("negative position").WithStack()
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *packBlockFile) Write(_ []byte) (int, error) {
+	return 0, xerrors.Define("packfile is read-only").WithStack()
+}
+
+func (f *packBlockFile) Truncate(_ int64) error {
+	return xerrors.Define("packfile is read-only").WithStack()
+}
+
+func (f *packBlockFile) Close() error {
+	return nil
+}
+
+func (f *packBlockFile) Lock() error {
+	return nil
+}
+
+func (f *packBlockFile) Unlock() error {
+	return nil
+}
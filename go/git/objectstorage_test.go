@@ -2,6 +2,7 @@ package git
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"io"
 	"io/fs"
@@ -101,7 +102,7 @@ func TestObjectStorageStorerReadDeltaObject(t *testing.T) {
 
 func TestObjectStorageStorerPackCache(t *testing.T) {
 	// With a PackfileCache configured, resolving the same packed object multiple
-	// times must download the .pack from the backend only once.
+	// times must read the blocks of the .pack from the backend only once.
 	const (
 		prefix   = "repo"
 		packName = "pack-b6ae1dd35be667fe13654be48e9c17e8e6c4aad6"
@@ -115,7 +116,7 @@ func TestObjectStorageStorerPackCache(t *testing.T) {
 	mockStorage := storage.NewMock()
 	mockStorage.AddTree(path.Join(prefix, "objects/pack", packName+".pack"), packData)
 	mockStorage.AddTree(path.Join(prefix, "objects/pack", packName+".idx"), idxData)
-	counter := &countingBackend{ObjectStorageInterface: mockStorage, count: make(map[string]int)}
+	counter := &countingBackend{Backend: mockStorage, count: make(map[string]int)}
 
 	cache := NewPackfileCache(time.Minute, 0, 1<<20)
 	defer cache.Close()
@@ -128,22 +129,100 @@ func TestObjectStorageStorerPackCache(t *testing.T) {
 	}
 
 	packPath := path.Join(prefix, "objects/pack", packName+".pack")
-	assert.Equal(t, 1, counter.getCount(packPath))
+	assert.Equal(t, 0, counter.getCount(packPath))
+	assert.EqualValues(t, len(packData), counter.getReadBytes(packPath))
 }
 
-// countingBackend records how many times Get is called for each name.
+func TestObjectStorageStorerRangedPackRead(t *testing.T) {
+	// Resolving a small object in a large pack must fetch only the blocks which contain the object
+	// instead of downloading the whole .pack.
+	repo := makeSourceRepository(t)
+	large := make([]byte, 4*packBlockSize)
+	_, err := rand.Read(large)
+	require.NoError(t, err)
+	addCommit(t, repo, "large.bin", string(large))
+	require.NoError(t, repo.RepackObjects(&git.RepackConfig{}))
+
+	// Register only the pack so that the object can't be read from the loose objects.
+	const prefix = "repo"
+	mockStorage := storage.NewMock()
+	packDir := filepath.Join(repo.Storer.(*filesystem.Storage).Filesystem().Root(), "objects/pack")
+	entries, err := os.ReadDir(packDir)
+	require.NoError(t, err)
+	var packPath string
+	var packSize int64
+	for _, v := range entries {
+		data, err := os.ReadFile(filepath.Join(packDir, v.Name()))
+		require.NoError(t, err)
+		mockStorage.AddTree(path.Join(prefix, "objects/pack", v.Name()), data)
+		if strings.HasSuffix(v.Name(), ".pack") {
+			packPath = path.Join(prefix, "objects/pack", v.Name())
+			packSize = int64(len(data))
+		}
+	}
+	require.NotEmpty(t, packPath)
+	counter := &countingBackend{Backend: mockStorage, count: make(map[string]int)}
+
+	s := NewObjectStorageStorer(counter, prefix, nil, nil)
+	blobHash := plumbing.ComputeHash(plumbing.BlobObject, []byte("Hello"))
+	obj, err := s.EncodedObject(plumbing.BlobObject, blobHash)
+	require.NoError(t, err)
+	r, err := obj.Reader()
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", string(content))
+
+	assert.Equal(t, 0, counter.getCount(packPath))
+	assert.Less(t, counter.getReadBytes(packPath), packSize/2)
+
+	// The size of the large blob is read from the header of the object without fetching its body.
+	before := counter.getReadBytes(packPath)
+	size, err := s.EncodedObjectSize(plumbing.ComputeHash(plumbing.BlobObject, large))
+	require.NoError(t, err)
+	assert.EqualValues(t, len(large), size)
+	assert.Equal(t, 0, counter.getCount(packPath))
+	assert.LessOrEqual(t, counter.getReadBytes(packPath)-before, int64(2*packBlockSize))
+}
+
+// countingBackend records how many times Get or GetRange is called and how many bytes are requested
+// for each name.
 type countingBackend struct {
-	ObjectStorageInterface
+	storage.Backend
 
-	mu    sync.Mutex
-	count map[string]int
+	mu        sync.Mutex
+	count     map[string]int
+	readBytes map[string]int64
 }
 
 func (b *countingBackend) Get(ctx context.Context, name string) (*storage.Object, error) {
 	b.mu.Lock()
 	b.count[name]++
 	b.mu.Unlock()
-	return b.ObjectStorageInterface.Get(ctx, name)
+	return b.Backend.Get(ctx, name)
+}
+
+func (b *countingBackend) GetRange(ctx context.Context, name string, offset, length int64) (*storage.Object, error) {
+	obj, err := b.Backend.GetRange(ctx, name, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	if b.readBytes == nil {
+		b.readBytes = make(map[string]int64)
+	}
+	if length < 0 {
+		length = obj.Size - offset
+	}
+	b.readBytes[name] += length
+	b.mu.Unlock()
+	return obj, nil
+}
+
+func (b *countingBackend) getReadBytes(name string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.readBytes[name]
 }
 
 func (b *countingBackend) getCount(name string) int {
@@ -265,6 +344,27 @@ func makeSourceRepository(t *testing.T) *git.Repository {
 	return repo
 }
 
+func addCommit(t *testing.T, repo *git.Repository, name, content string) plumbing.Hash {
+	return addCommitAt(t, repo, name, content, t.Name(), time.Now())
+}
+
+func addCommitAt(t *testing.T, repo *git.Repository, name, content, author string, when time.Time) plumbing.Hash {
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	p := filepath.Join(wt.Filesystem.Root(), name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0755))
+	err = os.WriteFile(p, []byte(content), 0644)
+	require.NoError(t, err)
+	_, err = wt.Add(name)
+	require.NoError(t, err)
+	h, err := wt.Commit("Update "+name, &git.CommitOptions{
+		Author:    &object.Signature{Name: author, When: when, Email: strings.ToLower(author) + "@localhost"},
+		Committer: &object.Signature{Name: author, When: when, Email: strings.ToLower(author) + "@localhost"},
+	})
+	require.NoError(t, err)
+	return h
+}
+
 func registerToStorage(t *testing.T, s *storage.Mock, repo *git.Repository, prefix string) {
 	gitDir := repo.Storer.(*filesystem.Storage).Filesystem().Root()
 	err := filepath.Walk(gitDir, func(path string, info fs.FileInfo, err error) error {
@@ -0,0 +1,296 @@
+package git
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"go.f110.dev/xerrors"
+
+	"go.f110.dev/mono/go/storage"
+)
+
+// referenceJournalSize is the number of the events which are kept for resuming.
+const referenceJournalSize = 10000
+
+// referenceJournalObjectName is the name of the object which has the journal in the object storage.
+const referenceJournalObjectName = "reference_journal.json"
+
+// DefaultReferenceJournalSyncInterval is the interval for reading the journal which is written by the other replica.
+const DefaultReferenceJournalSyncInterval = 10 * time.Second
+
+type journaledEvent struct {
+	seq   uint64
+	event *ReferenceEvent
+}
+
+// referenceJournalObject is the persisted form of referenceJournal.
+type referenceJournalObject struct {
+	ID     string                   `json:"id"`
+	Seq    uint64                   `json:"seq"`
+	Events []*referenceJournalEntry `json:"events"`
+}
+
+type referenceJournalEntry struct {
+	Seq    uint64 `json:"seq"`
+	Repo   string `json:"repo"`
+	Ref    string `json:"ref"`
+	OldSha string `json:"old_sha,omitempty"`
+	NewSha string `json:"new_sha,omitempty"`
+}
+
+// referenceJournal keeps the recent updates of the references. The cursor is "<id>:<seq>".
+// If the journal has the backend, the journal is persisted in the object storage so that the
+// cursor is resumed by the other replica and after restarting. Otherwise id is changed every
+// time the process starts, so the cursor of another process is not resumed.
+type referenceJournal struct {
+	mu     sync.Mutex
+	id     string
+	seq    uint64
+	events []journaledEvent
+	// updated is closed when the events are appended.
+	updated chan struct{}
+
+	backend storage.Backend
+	// etag is the ETag of the object which is read last time.
+	etag string
+}
+
+func newReferenceJournal() *referenceJournal {
+	buf := make([]byte, 8)
+	_, _ = rand.Read(buf)
+	return &referenceJournal{id: hex.EncodeToString(buf), updated: make(chan struct{})}
+}
+
+// Load reads the journal from the backend. If the backend doesn't have the journal yet, Load writes
+// the journal so that all replicas issue the cursor of the same id.
+func (j *referenceJournal) Load(ctx context.Context) error {
+	if j.backend == nil {
+		return nil
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.load(ctx); err != nil {
+		return err
+	}
+	if j.etag != "" {
+		return nil
+	}
+
+	// The other replica may write the journal at the same time. In that case, the journal of the other
+	// replica is used.
+	err := j.save(ctx)
+	if errors.Is(err, storage.ErrPreconditionFailed) {
+		return j.load(ctx)
+	}
+	return err
+}
+
+// Append records events. The events are recorded in memory even if the journal can't be written to
+// the backend, and the error is returned.
+func (j *referenceJournal) Append(ctx context.Context, events []*ReferenceEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	defer j.notify()
+
+	if j.backend == nil {
+		j.append(events)
+		return nil
+	}
+
+	var err error
+	for range 3 {
+		if err = j.load(ctx); err != nil {
+			j.append(events)
+			return err
+		}
+		j.append(events)
+		err = j.save(ctx)
+		if !errors.Is(err, storage.ErrPreconditionFailed) {
+			return err
+		}
+		// The journal was written by the other replica. Read it again and retry.
+	}
+	return err
+}
+
+func (j *referenceJournal) append(events []*ReferenceEvent) {
+	for _, v := range events {
+		j.seq++
+		j.events = append(j.events, journaledEvent{seq: j.seq, event: v})
+	}
+	if len(j.events) > 2*referenceJournalSize {
+		j.events = append([]journaledEvent(nil), j.events[len(j.events)-referenceJournalSize:]...)
+	}
+}
+
+func (j *referenceJournal) notify() {
+	close(j.updated)
+	j.updated = make(chan struct{})
+}
+
+// load replaces the events by the journal in the backend if the journal was written after the last load or save.
+// Only the metadata is read if the journal is not changed.
+func (j *referenceJournal) load(ctx context.Context) error {
+	stat, err := j.backend.Stat(ctx, referenceJournalObjectName)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		j.etag = ""
+		return nil
+	}
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	if stat.ETag == j.etag {
+		return nil
+	}
+
+	obj, err := j.backend.Get(ctx, referenceJournalObjectName)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		j.etag = ""
+		return nil
+	}
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	defer obj.Body.Close()
+
+	journal := &referenceJournalObject{}
+	if err := json.NewDecoder(obj.Body).Decode(journal); err != nil {
+		return xerrors.WithStack(err)
+	}
+	j.etag = obj.ETag
+	j.id, j.seq = journal.ID, journal.Seq
+	j.events = make([]journaledEvent, 0, len(journal.Events))
+	for _, v := range journal.Events {
+		j.events = append(j.events, journaledEvent{
+			seq:   v.Seq,
+			event: &ReferenceEvent{Repo: v.Repo, Ref: v.Ref, OldSha: v.OldSha, NewSha: v.NewSha},
+		})
+	}
+	j.notify()
+	return nil
+}
+
+// save writes the journal if the journal in the backend is not changed after load.
+// save returns storage.ErrPreconditionFailed if the journal was written by the other replica.
+func (j *referenceJournal) save(ctx context.Context) error {
+	journal := &referenceJournalObject{ID: j.id, Seq: j.seq}
+	for _, v := range j.events[max(len(j.events)-referenceJournalSize, 0):] {
+		journal.Events = append(journal.Events, &referenceJournalEntry{
+			Seq:    v.seq,
+			Repo:   v.event.Repo,
+			Ref:    v.event.Ref,
+			OldSha: v.event.OldSha,
+			NewSha: v.event.NewSha,
+		})
+	}
+	buf, err := json.Marshal(journal)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	cond := storage.PutCondition{IfMatch: j.etag, IfNoneMatch: j.etag == ""}
+	etag, err := j.backend.PutIf(ctx, referenceJournalObjectName, buf, cond)
+	if err != nil {
+		return err
+	}
+	j.etag = etag
+	return nil
+}
+
+// Since returns the events after cursor and the cursor of the last event. resync is true
+// if some events after cursor were discarded or cursor is not issued by this journal.
+// The returned channel is closed when the next event is appended.
+func (j *referenceJournal) Since(cursor string) (events []*ReferenceEvent, next string, resync bool, updated <-chan struct{}) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	next = j.cursor()
+	updated = j.updated
+	if cursor == "" {
+		return nil, next, false, updated
+	}
+	id, s, ok := strings.Cut(cursor, ":")
+	seq, err := strconv.ParseUint(s, 10, 64)
+	if !ok || err != nil || id != j.id || seq > j.seq {
+		return nil, next, true, updated
+	}
+	if len(j.events) > 0 && seq+1 < j.events[0].seq {
+		return nil, next, true, updated
+	}
+	for _, v := range j.events {
+		if v.seq > seq {
+			events = append(events, v.event)
+		}
+	}
+	return events, next, false, updated
+}
+
+func (j *referenceJournal) cursor() string {
+	return fmt.Sprintf("%s:%d", j.id, j.seq)
+}
+
+// referenceSnapshot returns the hashes of the references except the remote-tracking branches.
+func referenceSnapshot(repo *goGit.Repository) (map[plumbing.ReferenceName]plumbing.Hash, error) {
+	iter, err := repo.References()
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	defer iter.Close()
+
+	refs := make(map[plumbing.ReferenceName]plumbing.Hash)
+	for {
+		ref, err := iter.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		if ref.Type() != plumbing.HashReference || ref.Name().IsRemote() {
+			continue
+		}
+		refs[ref.Name()] = ref.Hash()
+	}
+	return refs, nil
+}
+
+// diffReferences returns the events which change before to after.
+func diffReferences(repo string, before, after map[plumbing.ReferenceName]plumbing.Hash) []*ReferenceEvent {
+	var events []*ReferenceEvent
+	for name, h := range after {
+		old, ok := before[name]
+		if ok && old == h {
+			continue
+		}
+		e := &ReferenceEvent{Repo: repo, Ref: name.String(), NewSha: h.String()}
+		if ok {
+			e.OldSha = old.String()
+		}
+		events = append(events, e)
+	}
+	for name, h := range before {
+		if _, ok := after[name]; !ok {
+			events = append(events, &ReferenceEvent{Repo: repo, Ref: name.String(), OldSha: h.String()})
+		}
+	}
+	sort.Slice(events, func(i, k int) bool { return events[i].Ref < events[k].Ref })
+	return events
+}
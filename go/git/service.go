@@ -1,7 +1,10 @@
 package git
 
 import (
+	"bufio"
 	"context"
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"io"
 	"net/url"
@@ -9,23 +12,33 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	goGit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/object/commitgraph"
+	"go.f110.dev/go-memcached/client"
 	"go.f110.dev/xerrors"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"go.f110.dev/mono/go/enumerable"
+	"go.f110.dev/mono/go/logger/slogger"
+	"go.f110.dev/mono/go/storage"
 )
 
 type DataService struct {
 	mu   sync.RWMutex
 	repo map[string]*goGit.Repository
+	// cachePool caches the results which are expensive to compute. It may be nil.
+	cachePool *client.SinglePool
+	// journal has the updates of the references which are recorded by Updater.
+	journal *referenceJournal
 }
 
 var _ GitDataServer = &DataService{}
@@ -35,11 +48,46 @@ func NewDataService(repo map[string]*RepositoryConfig) (*DataService, error) {
 	for k, v := range repo {
 		repos[k] = v.goGit
 	}
-	return &DataService{repo: repos}, nil
+	return &DataService{repo: repos, journal: newReferenceJournal()}, nil
 }
 
 func NewDataServiceWithGoGit(repo map[string]*goGit.Repository) (*DataService, error) {
-	return &DataService{repo: repo}, nil
+	return &DataService{repo: repo, journal: newReferenceJournal()}, nil
+}
+
+func (g *DataService) SetCachePool(c *client.SinglePool) *DataService {
+	g.cachePool = c
+	return g
+}
+
+// LoadReferenceJournal reads the journal of the updates of the references from the object storage.
+// After that, the journal is persisted in the object storage so that WatchReferences of the other
+// replica and the restarted process can resume from the cursor.
+func (g *DataService) LoadReferenceJournal(ctx context.Context, b storage.Backend) error {
+	g.journal.backend = b
+	return g.journal.Load(ctx)
+}
+
+// SyncReferenceJournal reads the journal which is written by the other replica at each interval
+// until ctx is canceled. The replica which doesn't run the Updater sends the events of WatchReferences
+// after reading the journal.
+func (g *DataService) SyncReferenceJournal(ctx context.Context, interval time.Duration) {
+	if g.journal.backend == nil {
+		return
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := g.journal.Load(ctx); err != nil {
+				slogger.Log.Warn("Failed to read the reference journal", slogger.E(err))
+			}
+		}
+	}
 }
 
 // AddRepo registers a repository so it can be served by gRPC requests. Safe
@@ -123,6 +171,62 @@ func (g *DataService) ListReferences(_ context.Context, req *RequestListReferenc
 	return res, nil
 }
 
+// WatchReferences streams the updates of the references until the client cancels.
+// The first response is sent immediately with the events after the requested cursor,
+// so the client always has the cursor to resume from.
+// The updates are recorded by the Updater linked to the DataService. The other replicas send the
+// events after reading the journal by SyncReferenceJournal.
+func (g *DataService) WatchReferences(req *RequestWatchReferences, stream GitData_WatchReferencesServer) error {
+	repos := make(map[string]struct{}, len(req.Repos))
+	for _, v := range req.Repos {
+		if _, ok := g.lookup(v); !ok {
+			return status.Errorf(codes.NotFound, "repository %s is not found", v)
+		}
+		repos[v] = struct{}{}
+	}
+
+	events, cursor, resync, updated := g.journal.Since(req.Cursor)
+	if err := stream.Send(&ResponseWatchReferences{Events: filterReferenceEvents(events, repos), Cursor: cursor, Resync: resync}); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-updated:
+		}
+
+		events, cursor, resync, updated = g.journal.Since(cursor)
+		events = filterReferenceEvents(events, repos)
+		if len(events) == 0 && !resync {
+			continue
+		}
+		if err := stream.Send(&ResponseWatchReferences{Events: events, Cursor: cursor, Resync: resync}); err != nil {
+			return err
+		}
+	}
+}
+
+// recordReferenceUpdates records the updates of the references for WatchReferences.
+func (g *DataService) recordReferenceUpdates(ctx context.Context, events []*ReferenceEvent) {
+	if err := g.journal.Append(ctx, events); err != nil {
+		slogger.Log.Warn("Failed to write the reference journal", slogger.E(err))
+	}
+}
+
+func filterReferenceEvents(events []*ReferenceEvent, repos map[string]struct{}) []*ReferenceEvent {
+	if len(repos) == 0 {
+		return events
+	}
+	var filtered []*ReferenceEvent
+	for _, v := range events {
+		if _, ok := repos[v.Repo]; ok {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
 func (g *DataService) GetRepository(_ context.Context, req *RequestGetRepository) (*ResponseGetRepository, error) {
 	repo, ok := g.lookup(req.Repo)
 	if !ok {
@@ -194,30 +298,424 @@ func (g *DataService) GetCommit(_ context.Context, req *RequestGetCommit) (*Resp
 		return nil, err
 	}
 
-	res := &ResponseGetCommit{
-		Commit: &Commit{
-			Sha:     commit.Hash.String(),
-			Message: commit.Message,
-			Committer: &Signature{
-				Name:  commit.Committer.Name,
-				Email: commit.Committer.Email,
-				When:  timestamppb.New(commit.Committer.When),
-			},
-			Author: &Signature{
-				Name:  commit.Author.Name,
-				Email: commit.Author.Email,
-				When:  timestamppb.New(commit.Author.When),
-			},
-			Tree: commit.TreeHash.String(),
-		},
+	return &ResponseGetCommit{Commit: newCommit(commit)}, nil
+}
+
+const (
+	defaultListCommitsPageSize = 30
+	maxListCommitsPageSize     = 100
+)
+
+// ListCommits walks the history from the commit in the commit time order like git log.
+// The next_page_token has the start commit, so the following pages are not changed even if
+// the ref is moved.
+func (g *DataService) ListCommits(_ context.Context, req *RequestListCommits) (*ResponseListCommits, error) {
+	repo, ok := g.lookup(req.Repo)
+	if !ok {
+		return nil, xerrors.New("repository not found")
 	}
-	if len(commit.ParentHashes) > 0 {
-		res.Commit.Parents = enumerable.Map(commit.ParentHashes, func(t plumbing.Hash) string { return t.String() })
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultListCommitsPageSize
+	}
+	pageSize = min(pageSize, maxListCommitsPageSize)
+
+	var start []plumbing.Hash
+	switch {
+	case req.PageToken != "":
+		hashes, err := parseListCommitsPageToken(req.PageToken)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		start = hashes
+	case req.Sha != "":
+		start = []plumbing.Hash{plumbing.NewHash(req.Sha)}
+	case req.Ref != "":
+		ref, err := repo.Reference(plumbing.ReferenceName(req.Ref), true)
+		if err != nil {
+			return nil, xerrors.New("ref is not found")
+		}
+		start = []plumbing.Hash{ref.Hash()}
+	default:
+		return nil, xerrors.New("SHA or ref field is required")
+	}
+
+	index := commitNodeIndex(repo)
+	frontier := make([]commitgraph.CommitNode, len(start))
+	for i, h := range start {
+		node, err := index.Get(h)
+		if err != nil {
+			return nil, xerrors.New("commit is not found")
+		}
+		frontier[i] = node
+	}
+	filter := &commitFilter{
+		path:      strings.Trim(req.Path, "/"),
+		author:    strings.ToLower(req.Author),
+		pathEntry: make(map[plumbing.Hash]plumbing.Hash),
+	}
+	if req.Since != nil {
+		filter.since = req.Since.AsTime()
+	}
+	if req.Until != nil {
+		filter.until = req.Until.AsTime()
+	}
+
+	walker := newCommitWalker(frontier...)
+	res := &ResponseListCommits{}
+	for node := walker.Peek(); node != nil; node = walker.Peek() {
+		// The walker returns the newest commit first. The rest of the commits are older than since.
+		if !filter.since.IsZero() && node.CommitTime().Before(filter.since) {
+			break
+		}
+		commit, ok, err := filter.match(node)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			if len(res.Commits) == pageSize {
+				// The next page resumes the walk from the frontier which begins with this commit.
+				res.NextPageToken = listCommitsPageToken(walker.Frontier())
+				break
+			}
+			res.Commits = append(res.Commits, newCommit(commit))
+		}
+		if err := walker.Pop(); err != nil {
+			return nil, err
+		}
 	}
 
 	return res, nil
 }
 
+// listCommitsPageToken encodes the frontier of the walk of ListCommits.
+func listCommitsPageToken(frontier []plumbing.Hash) string {
+	hashes := make([]string, len(frontier))
+	for i, v := range frontier {
+		hashes[i] = v.String()
+	}
+	return strings.Join(hashes, ",")
+}
+
+func parseListCommitsPageToken(token string) ([]plumbing.Hash, error) {
+	var hashes []plumbing.Hash
+	for v := range strings.SplitSeq(token, ",") {
+		if !plumbing.IsHash(v) {
+			return nil, xerrors.Newf("malformed page token: %s", token)
+		}
+		hashes = append(hashes, plumbing.NewHash(v))
+	}
+	return hashes, nil
+}
+
+type commitFilter struct {
+	path   string
+	author string
+	since  time.Time
+	until  time.Time
+
+	// pathEntry is the hash of the entry of path in the tree of the commit.
+	pathEntry map[plumbing.Hash]plumbing.Hash
+}
+
+// match reports whether node is listed. The commit object is read only if node passes
+// the filters which can be evaluated by the commit-graph.
+func (f *commitFilter) match(node commitgraph.CommitNode) (*object.Commit, bool, error) {
+	if !f.until.IsZero() && node.CommitTime().After(f.until) {
+		return nil, false, nil
+	}
+	if f.path != "" {
+		changed, err := f.changedPath(node)
+		if err != nil {
+			return nil, false, err
+		}
+		if !changed {
+			return nil, false, nil
+		}
+	}
+
+	commit, err := node.Commit()
+	if err != nil {
+		return nil, false, xerrors.WithStack(err)
+	}
+	if f.author != "" {
+		author := strings.ToLower(fmt.Sprintf("%s <%s>", commit.Author.Name, commit.Author.Email))
+		if !strings.Contains(author, f.author) {
+			return nil, false, nil
+		}
+	}
+	return commit, true, nil
+}
+
+// changedPath reports whether node changed the path. Like git log, a merge commit is not
+// listed if the path is the same as one of the parents.
+func (f *commitFilter) changedPath(node commitgraph.CommitNode) (bool, error) {
+	h, err := f.pathHash(node)
+	if err != nil {
+		return false, err
+	}
+	if node.NumParents() == 0 {
+		return !h.IsZero(), nil
+	}
+	for i := range node.NumParents() {
+		parent, err := node.ParentNode(i)
+		if err != nil {
+			return false, xerrors.WithStack(err)
+		}
+		ph, err := f.pathHash(parent)
+		if err != nil {
+			return false, err
+		}
+		if ph == h {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (f *commitFilter) pathHash(node commitgraph.CommitNode) (plumbing.Hash, error) {
+	if h, ok := f.pathEntry[node.ID()]; ok {
+		return h, nil
+	}
+	tree, err := node.Tree()
+	if err != nil {
+		return plumbing.ZeroHash, xerrors.WithStack(err)
+	}
+	var h plumbing.Hash
+	e, err := tree.FindEntry(f.path)
+	switch {
+	case err == nil:
+		h = e.Hash
+	case errors.Is(err, object.ErrEntryNotFound), errors.Is(err, object.ErrDirectoryNotFound):
+	default:
+		return plumbing.ZeroHash, xerrors.WithStack(err)
+	}
+	f.pathEntry[node.ID()] = h
+	return h, nil
+}
+
+const (
+	defaultComparePatchBytes      = 64 * 1024
+	defaultCompareTotalPatchBytes = 1024 * 1024
+	// maxCompareBlobSize is the size of the largest blob which the patch is computed for.
+	maxCompareBlobSize = 1024 * 1024
+	// compareRenameLimit is the number of the added or deleted files up to which the renames are detected.
+	compareRenameLimit = 1000
+)
+
+// Compare returns the files which differ between the tree of base and the tree of head.
+// The renamed files are detected like git diff -M.
+func (g *DataService) Compare(ctx context.Context, req *RequestCompare) (*ResponseCompare, error) {
+	repo, ok := g.lookup(req.Repo)
+	if !ok {
+		return nil, xerrors.New("repository not found")
+	}
+	if req.Base == "" || req.Head == "" {
+		return nil, xerrors.New("base and head are required")
+	}
+	base, err := resolveCommit(repo, req.Base)
+	if err != nil {
+		return nil, err
+	}
+	head, err := resolveCommit(repo, req.Head)
+	if err != nil {
+		return nil, err
+	}
+	baseTree, err := base.Tree()
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	headTree, err := head.Tree()
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	changes, err := object.DiffTreeWithOptions(ctx, baseTree, headTree, &object.DiffTreeOptions{
+		DetectRenames: true,
+		RenameScore:   object.DefaultDiffTreeOptions.RenameScore,
+		RenameLimit:   compareRenameLimit,
+	})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	maxPatchBytes := req.MaxPatchBytes
+	if maxPatchBytes <= 0 {
+		maxPatchBytes = defaultComparePatchBytes
+	}
+	totalPatchBytes := req.MaxTotalPatchBytes
+	if totalPatchBytes <= 0 {
+		totalPatchBytes = defaultCompareTotalPatchBytes
+	}
+	res := &ResponseCompare{Base: base.Hash.String(), Head: head.Hash.String()}
+	for _, c := range changes {
+		f := &FileChange{}
+		switch {
+		case c.From.Name == "":
+			f.Status = FileChangeStatus_FILE_CHANGE_STATUS_ADDED
+			f.Path = c.To.Name
+		case c.To.Name == "":
+			f.Status = FileChangeStatus_FILE_CHANGE_STATUS_DELETED
+			f.Path = c.From.Name
+		case c.From.Name != c.To.Name:
+			f.Status = FileChangeStatus_FILE_CHANGE_STATUS_RENAMED
+			f.Path = c.To.Name
+			f.PreviousPath = c.From.Name
+		default:
+			f.Status = FileChangeStatus_FILE_CHANGE_STATUS_MODIFIED
+			f.Path = c.To.Name
+		}
+		if c.From.Name != "" {
+			f.PreviousSha = c.From.TreeEntry.Hash.String()
+		}
+		if c.To.Name != "" {
+			f.Sha = c.To.TreeEntry.Hash.String()
+		}
+		res.Files = append(res.Files, f)
+
+		if !req.IncludePatch {
+			continue
+		}
+		if totalPatchBytes <= 0 {
+			f.PatchTruncated = true
+			continue
+		}
+		if err := comparePatch(ctx, repo, c, f, min(maxPatchBytes, totalPatchBytes)); err != nil {
+			return nil, err
+		}
+		totalPatchBytes -= int64(len(f.Patch))
+	}
+
+	return res, nil
+}
+
+// comparePatch sets the unified diff of c to f. The patch longer than limit is truncated.
+func comparePatch(ctx context.Context, repo *goGit.Repository, c *object.Change, f *FileChange, limit int64) error {
+	for _, e := range []object.ChangeEntry{c.From, c.To} {
+		if e.Name == "" {
+			continue
+		}
+		if !e.TreeEntry.Mode.IsFile() {
+			// The submodule doesn't have the content.
+			return nil
+		}
+		blob, err := object.GetBlob(repo.Storer, e.TreeEntry.Hash)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		if blob.Size > maxCompareBlobSize {
+			f.PatchTruncated = true
+			return nil
+		}
+	}
+
+	patch, err := c.PatchContext(ctx)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	for _, fp := range patch.FilePatches() {
+		if fp.IsBinary() {
+			f.Binary = true
+		}
+	}
+	for _, s := range patch.Stats() {
+		f.Additions += int32(s.Addition)
+		f.Deletions += int32(s.Deletion)
+	}
+	// The string field of protobuf has to be valid UTF-8.
+	p := strings.ToValidUTF8(patch.String(), "\uFFFD")
+	if int64(len(p)) > limit {
+		// Cut at the end of the line so that the patch doesn't have a partial line.
+		p = p[:limit]
+		if i := strings.LastIndexByte(p, '\n'); i >= 0 {
+			p = p[:i+1]
+		} else {
+			p = strings.ToValidUTF8(p, "")
+		}
+		f.PatchTruncated = true
+	}
+	f.Patch = p
+	return nil
+}
+
+// resolveCommit returns the commit of rev. rev is a commit hash, a ref name, or a short
+// name of the branch or the tag. The annotated tag is peeled.
+func resolveCommit(repo *goGit.Repository, rev string) (*object.Commit, error) {
+	h := plumbing.NewHash(rev)
+	if !plumbing.IsHash(rev) {
+		names := []plumbing.ReferenceName{plumbing.ReferenceName(rev)}
+		if !strings.HasPrefix(rev, "refs/") {
+			names = append(names, plumbing.NewBranchReferenceName(rev), plumbing.NewTagReferenceName(rev))
+		}
+		var found bool
+		for _, v := range names {
+			ref, err := repo.Reference(v, true)
+			if err == nil {
+				h, found = ref.Hash(), true
+				break
+			}
+		}
+		if !found {
+			return nil, xerrors.WithMessagef(ErrRevisionNotFound.WithStack(), "%s", rev)
+		}
+	}
+	if tag, err := repo.TagObject(h); err == nil {
+		c, err := tag.Commit()
+		if err != nil {
+			return nil, xerrors.WithMessagef(ErrRevisionNotFound.WithStack(), "tag %s doesn't point to the commit", rev)
+		}
+		return c, nil
+	}
+	commit, err := repo.CommitObject(h)
+	if err != nil {
+		return nil, xerrors.WithMessagef(ErrRevisionNotFound.WithStack(), "%s", rev)
+	}
+	return commit, nil
+}
+
+// archiveChunkSize is the size of the data in a response of GetArchive.
+const archiveChunkSize = 64 * 1024
+
+// GetArchive streams the archive of the tree at the ref. The archive is written while the
+// objects are read, so the error after the first response means the archive is broken.
+func (g *DataService) GetArchive(req *RequestGetArchive, stream GitData_GetArchiveServer) error {
+	repo, ok := g.lookup(req.Repo)
+	if !ok {
+		return status.Error(codes.NotFound, "repository not found")
+	}
+	commit, err := resolveCommit(repo, req.Ref)
+	if err != nil {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	a, err := newArchive(commit, archiveOptions{Format: req.Format, Path: req.Path, Excludes: req.Excludes, Prefix: req.Prefix})
+	if err != nil {
+		if errors.Is(err, ErrArchivePathNotFound) {
+			return status.Error(codes.NotFound, err.Error())
+		}
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	w := bufio.NewWriterSize(&archiveStreamWriter{stream: stream, sha: commit.Hash.String()}, archiveChunkSize)
+	if err := a.Write(w); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+type archiveStreamWriter struct {
+	stream GitData_GetArchiveServer
+	sha    string
+}
+
+func (w *archiveStreamWriter) Write(p []byte) (int, error) {
+	// The message is marshaled in Send, so p is not retained.
+	if err := w.stream.Send(&ResponseGetArchive{Sha: w.sha, Data: p}); err != nil {
+		return 0, err
+	}
+	w.sha = ""
+	return len(p), nil
+}
+
 func (g *DataService) GetTree(_ context.Context, req *RequestGetTree) (*ResponseGetTree, error) {
 	repo, ok := g.lookup(req.Repo)
 	if !ok {
@@ -413,6 +911,79 @@ func (g *DataService) GetFile(_ context.Context, req *RequestGetFile) (*Response
 	return nil, xerrors.Newf("unsupported object: %s", treeEntry.Mode.String())
 }
 
+const (
+	// blameCacheExpiration is the expiration of the cached blame in seconds. The blame of a
+	// blob at a commit never changes, so it is evicted only for the memory.
+	blameCacheExpiration = 60 * 60 * 24 * 7
+	// maxBlameCacheSize is the max size of the item of memcached.
+	maxBlameCacheSize = 1024 * 1024
+)
+
+// GetBlame returns the commit which modified each line of the file last. The consecutive
+// lines of the same commit are grouped as a range.
+func (g *DataService) GetBlame(_ context.Context, req *RequestGetBlame) (*ResponseGetBlame, error) {
+	repo, ok := g.lookup(req.Repo)
+	if !ok {
+		return nil, xerrors.New("repository not found")
+	}
+	filePath := strings.Trim(req.Path, "/")
+	if req.Ref == "" || filePath == "" {
+		return nil, xerrors.New("ref and path are required")
+	}
+	commit, err := resolveCommit(repo, req.Ref)
+	if err != nil {
+		return nil, err
+	}
+	file, err := commit.File(filePath)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s is not found", filePath)
+	}
+
+	// The blame depends on the path too because the history of the same blob may differ by the path.
+	key := fmt.Sprintf("blame/%s/%s/%x", commit.Hash, file.Hash, sha256.Sum256([]byte(filePath)))
+	if g.cachePool != nil {
+		if item, err := g.cachePool.Get(key); err == nil {
+			res := &ResponseGetBlame{}
+			if err := proto.Unmarshal(item.Value, res); err == nil {
+				return res, nil
+			}
+		}
+	}
+
+	result, err := goGit.Blame(commit, filePath)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	res := &ResponseGetBlame{Sha: commit.Hash.String(), BlobSha: file.Hash.String()}
+	var last *BlameRange
+	for i, line := range result.Lines {
+		if last != nil && last.Sha == line.Hash.String() {
+			last.LineCount++
+			continue
+		}
+		last = &BlameRange{
+			StartLine: int32(i + 1),
+			LineCount: 1,
+			Sha:       line.Hash.String(),
+			Author: &Signature{
+				Name:  line.AuthorName,
+				Email: line.Author,
+				When:  timestamppb.New(line.Date),
+			},
+		}
+		res.Ranges = append(res.Ranges, last)
+	}
+
+	if g.cachePool != nil {
+		if buf, err := proto.Marshal(res); err == nil && len(buf) < maxBlameCacheSize {
+			if err := g.cachePool.Set(&client.Item{Key: key, Value: buf, Expiration: blameCacheExpiration}); err != nil {
+				slogger.Log.Debug("Failed to cache blame", slogger.E(err))
+			}
+		}
+	}
+	return res, nil
+}
+
 func (g *DataService) Stat(_ context.Context, req *RequestStat) (*ResponseStat, error) {
 	repo, ok := g.lookup(req.Repo)
 	if !ok {
@@ -470,26 +1041,7 @@ func (g *DataService) GetRepositoryStatistics(_ context.Context, req *RequestGet
 		return nil, err
 	}
 
-	headCommit := &Commit{
-		Sha:     commit.Hash.String(),
-		Message: commit.Message,
-		Committer: &Signature{
-			Name:  commit.Committer.Name,
-			Email: commit.Committer.Email,
-			When:  timestamppb.New(commit.Committer.When),
-		},
-		Author: &Signature{
-			Name:  commit.Author.Name,
-			Email: commit.Author.Email,
-			When:  timestamppb.New(commit.Author.When),
-		},
-		Tree: commit.TreeHash.String(),
-	}
-	if len(commit.ParentHashes) > 0 {
-		headCommit.Parents = enumerable.Map(commit.ParentHashes, func(t plumbing.Hash) string { return t.String() })
-	}
-
-	return &ResponseGetRepositoryStatistics{HeadCommit: headCommit}, nil
+	return &ResponseGetRepositoryStatistics{HeadCommit: newCommit(commit)}, nil
 }
 
 func (g *DataService) ListTag(_ context.Context, req *RequestListTag) (*ResponseListTag, error) {
@@ -551,3 +1103,25 @@ func (g *DataService) ListBranch(_ context.Context, req *RequestListBranch) (*Re
 
 	return res, nil
 }
+
+func newCommit(commit *object.Commit) *Commit {
+	c := &Commit{
+		Sha:     commit.Hash.String(),
+		Message: commit.Message,
+		Committer: &Signature{
+			Name:  commit.Committer.Name,
+			Email: commit.Committer.Email,
+			When:  timestamppb.New(commit.Committer.When),
+		},
+		Author: &Signature{
+			Name:  commit.Author.Name,
+			Email: commit.Author.Email,
+			When:  timestamppb.New(commit.Author.When),
+		},
+		Tree: commit.TreeHash.String(),
+	}
+	if len(commit.ParentHashes) > 0 {
+		c.Parents = enumerable.Map(commit.ParentHashes, func(t plumbing.Hash) string { return t.String() })
+	}
+	return c
+}
@@ -3,8 +3,11 @@ package git
 import (
 	"context"
 	"net"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	goGit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -14,8 +17,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"go.f110.dev/mono/go/storage"
 )
@@ -77,6 +83,196 @@ func TestGetCommit(t *testing.T) {
 	assert.NotNil(t, commit.Commit.Committer)
 }
 
+func TestListCommits(t *testing.T) {
+	mockStorage := storage.NewMock()
+	repo := makeSourceRepository(t)
+	head, err := repo.Head()
+	require.NoError(t, err)
+	initCommit := head.Hash().String()
+	now := time.Now()
+	first := addCommitAt(t, repo, "CHANGELOG.md", "v1", "Alice", now.Add(1*time.Hour)).String()
+	second := addCommitAt(t, repo, "docs/README.md", "v2", "Bob", now.Add(2*time.Hour)).String()
+	third := addCommitAt(t, repo, "CHANGELOG.md", "v3", "Alice", now.Add(3*time.Hour)).String()
+	conn := startServer(t, mockStorage, map[string]*goGit.Repository{"test/test1": repo})
+	gitData := NewGitDataClient(conn)
+
+	shas := func(res *ResponseListCommits) []string {
+		var s []string
+		for _, v := range res.Commits {
+			s = append(s, v.Sha)
+		}
+		return s
+	}
+
+	cases := []struct {
+		Name   string
+		Req    *RequestListCommits
+		Expect []string
+	}{
+		{Name: "All", Req: &RequestListCommits{Ref: "refs/heads/master"}, Expect: []string{third, second, first, initCommit}},
+		{Name: "SHA", Req: &RequestListCommits{Sha: second}, Expect: []string{second, first, initCommit}},
+		{Name: "File", Req: &RequestListCommits{Ref: "HEAD", Path: "CHANGELOG.md"}, Expect: []string{third, first}},
+		{Name: "Directory", Req: &RequestListCommits{Ref: "HEAD", Path: "/docs/"}, Expect: []string{second, initCommit}},
+		{Name: "Author", Req: &RequestListCommits{Ref: "HEAD", Author: "ALICE"}, Expect: []string{third, first}},
+		{Name: "Since", Req: &RequestListCommits{Ref: "HEAD", Since: timestamppb.New(now.Add(90 * time.Minute))}, Expect: []string{third, second}},
+		{Name: "Until", Req: &RequestListCommits{Ref: "HEAD", Until: timestamppb.New(now.Add(150 * time.Minute))}, Expect: []string{second, first, initCommit}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			tc.Req.Repo = "test1"
+			res, err := gitData.ListCommits(context.Background(), tc.Req)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expect, shas(res))
+			assert.Empty(t, res.NextPageToken)
+		})
+	}
+
+	t.Run("Pagination", func(t *testing.T) {
+		res, err := gitData.ListCommits(context.Background(), &RequestListCommits{Repo: "test1", Ref: "HEAD", PageSize: 3})
+		require.NoError(t, err)
+		assert.Equal(t, []string{third, second, first}, shas(res))
+		require.NotEmpty(t, res.NextPageToken)
+
+		// The next page is not affected by the new commit.
+		addCommitAt(t, repo, "CHANGELOG.md", "v4", "Alice", now.Add(4*time.Hour))
+		res, err = gitData.ListCommits(context.Background(), &RequestListCommits{Repo: "test1", Ref: "HEAD", PageSize: 3, PageToken: res.NextPageToken})
+		require.NoError(t, err)
+		assert.Equal(t, []string{initCommit}, shas(res))
+		assert.Empty(t, res.NextPageToken)
+
+		_, err = gitData.ListCommits(context.Background(), &RequestListCommits{Repo: "test1", PageToken: "foobar"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestListCommits_PaginationWithMerge(t *testing.T) {
+	mockStorage := storage.NewMock()
+	repo := makeSourceRepository(t)
+	head, err := repo.Head()
+	require.NoError(t, err)
+	now := time.Now()
+	// Make the history which has two branches merged.
+	//   base - a1 - a2 ---- merge
+	//       \- b1 - b2 -/
+	newCommit := func(msg string, when time.Time, parents ...plumbing.Hash) plumbing.Hash {
+		parent, err := repo.CommitObject(parents[0])
+		require.NoError(t, err)
+		sig := object.Signature{Name: t.Name(), Email: "test@localhost", When: when}
+		commit := &object.Commit{Author: sig, Committer: sig, Message: msg, TreeHash: parent.TreeHash, ParentHashes: parents}
+		obj := repo.Storer.NewEncodedObject()
+		require.NoError(t, commit.Encode(obj))
+		h, err := repo.Storer.SetEncodedObject(obj)
+		require.NoError(t, err)
+		return h
+	}
+	base := head.Hash()
+	a1 := newCommit("a1", now.Add(1*time.Hour), base)
+	b1 := newCommit("b1", now.Add(2*time.Hour), base)
+	a2 := newCommit("a2", now.Add(3*time.Hour), a1)
+	b2 := newCommit("b2", now.Add(4*time.Hour), b1)
+	merge := newCommit("merge", now.Add(5*time.Hour), a2, b2)
+	conn := startServer(t, mockStorage, map[string]*goGit.Repository{"test/test1": repo})
+	gitData := NewGitDataClient(conn)
+
+	var shas []string
+	var pageToken string
+	for range 10 {
+		res, err := gitData.ListCommits(context.Background(), &RequestListCommits{Repo: "test1", Sha: merge.String(), PageSize: 2, PageToken: pageToken})
+		require.NoError(t, err)
+		for _, v := range res.Commits {
+			shas = append(shas, v.Sha)
+		}
+		if res.NextPageToken == "" {
+			break
+		}
+		pageToken = res.NextPageToken
+	}
+	expect := []string{merge.String(), b2.String(), a2.String(), b1.String(), a1.String(), base.String()}
+	assert.Equal(t, expect, shas)
+}
+
+func TestCompare(t *testing.T) {
+	mockStorage := storage.NewMock()
+	repo := makeSourceRepository(t)
+	head, err := repo.Head()
+	require.NoError(t, err)
+	base := head.Hash().String()
+
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	root := wt.Filesystem.Root()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "README.md"), []byte("Hello\nWorld\n"), 0644))
+	require.NoError(t, os.Remove(filepath.Join(root, "docs/README.md")))
+	require.NoError(t, os.Rename(filepath.Join(root, "docs/design/README.md"), filepath.Join(root, "docs/design/index.md")))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "CHANGELOG.md"), []byte(strings.Repeat("change\n", 100)), 0644))
+	require.NoError(t, wt.AddWithOptions(&goGit.AddOptions{All: true}))
+	h, err := wt.Commit("Update", &goGit.CommitOptions{
+		Author: &object.Signature{Name: t.Name(), When: time.Now(), Email: "test@localhost"},
+	})
+	require.NoError(t, err)
+	conn := startServer(t, mockStorage, map[string]*goGit.Repository{"test/test1": repo})
+	gitData := NewGitDataClient(conn)
+
+	res, err := gitData.Compare(context.Background(), &RequestCompare{Repo: "test1", Base: base, Head: "refs/heads/master"})
+	require.NoError(t, err)
+	assert.Equal(t, base, res.Base)
+	assert.Equal(t, h.String(), res.Head)
+	files := make(map[string]*FileChange)
+	for _, v := range res.Files {
+		files[v.Path] = v
+		assert.Empty(t, v.Patch)
+	}
+	require.Len(t, files, 4)
+	assert.Equal(t, FileChangeStatus_FILE_CHANGE_STATUS_ADDED, files["CHANGELOG.md"].Status)
+	assert.Empty(t, files["CHANGELOG.md"].PreviousSha)
+	assert.Equal(t, FileChangeStatus_FILE_CHANGE_STATUS_MODIFIED, files["README.md"].Status)
+	assert.Equal(t, FileChangeStatus_FILE_CHANGE_STATUS_DELETED, files["docs/README.md"].Status)
+	assert.Empty(t, files["docs/README.md"].Sha)
+	assert.Equal(t, FileChangeStatus_FILE_CHANGE_STATUS_RENAMED, files["docs/design/index.md"].Status)
+	assert.Equal(t, "docs/design/README.md", files["docs/design/index.md"].PreviousPath)
+	assert.Equal(t, files["docs/design/index.md"].PreviousSha, files["docs/design/index.md"].Sha)
+
+	t.Run("Patch", func(t *testing.T) {
+		res, err := gitData.Compare(context.Background(), &RequestCompare{Repo: "test1", Base: base, Head: h.String(), IncludePatch: true, MaxPatchBytes: 256})
+		require.NoError(t, err)
+		files := make(map[string]*FileChange)
+		for _, v := range res.Files {
+			files[v.Path] = v
+		}
+		readme := files["README.md"]
+		assert.Contains(t, readme.Patch, "-Hello\n\\ No newline at end of file\n+Hello\n+World\n")
+		assert.Equal(t, int32(2), readme.Additions)
+		assert.Equal(t, int32(1), readme.Deletions)
+		assert.False(t, readme.PatchTruncated)
+
+		changelog := files["CHANGELOG.md"]
+		assert.Equal(t, int32(100), changelog.Additions)
+		assert.True(t, changelog.PatchTruncated)
+		assert.LessOrEqual(t, len(changelog.Patch), 256)
+		assert.True(t, strings.HasSuffix(changelog.Patch, "\n"))
+	})
+
+	t.Run("ShortName", func(t *testing.T) {
+		// The annotated tag is peeled to the commit.
+		_, err := repo.CreateTag("v1.0", plumbing.NewHash(base), &goGit.CreateTagOptions{
+			Tagger:  &object.Signature{Name: t.Name(), When: time.Now(), Email: "test@localhost"},
+			Message: "v1.0",
+		})
+		require.NoError(t, err)
+
+		res, err := gitData.Compare(context.Background(), &RequestCompare{Repo: "test1", Base: "v1.0", Head: "master"})
+		require.NoError(t, err)
+		assert.Equal(t, base, res.Base)
+		assert.Equal(t, h.String(), res.Head)
+		assert.Len(t, res.Files, 4)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := gitData.Compare(context.Background(), &RequestCompare{Repo: "test1", Base: base, Head: "refs/heads/unknown"})
+		assert.Error(t, err)
+	})
+}
+
 func TestGetRepositoryStatistics(t *testing.T) {
 	mockStorage := storage.NewMock()
 	repo := makeSourceRepository(t)
@@ -219,6 +415,36 @@ func TestGetFile(t *testing.T) {
 	assert.NotEmpty(t, file.Sha)
 }
 
+func TestGetBlame(t *testing.T) {
+	mockStorage := storage.NewMock()
+	repo := makeSourceRepository(t)
+	first := addCommitAt(t, repo, "README.md", "Hello\nWorld\n", "Alice", time.Now()).String()
+	second := addCommitAt(t, repo, "README.md", "Hello\nWorld\nfoo\nbar\n", "Bob", time.Now()).String()
+	conn := startServer(t, mockStorage, map[string]*goGit.Repository{"test/test1": repo})
+	gitData := NewGitDataClient(conn)
+
+	res, err := gitData.GetBlame(context.Background(), &RequestGetBlame{Repo: "test1", Ref: "refs/heads/master", Path: "/README.md"})
+	require.NoError(t, err)
+	assert.Equal(t, second, res.Sha)
+	require.Len(t, res.Ranges, 2)
+	assert.Equal(t, first, res.Ranges[0].Sha)
+	assert.Equal(t, int32(1), res.Ranges[0].StartLine)
+	assert.Equal(t, int32(2), res.Ranges[0].LineCount)
+	assert.Equal(t, "Alice", res.Ranges[0].Author.Name)
+	assert.Equal(t, second, res.Ranges[1].Sha)
+	assert.Equal(t, int32(3), res.Ranges[1].StartLine)
+	assert.Equal(t, int32(2), res.Ranges[1].LineCount)
+	assert.Equal(t, "bob@localhost", res.Ranges[1].Author.Email)
+
+	res, err = gitData.GetBlame(context.Background(), &RequestGetBlame{Repo: "test1", Ref: first, Path: "README.md"})
+	require.NoError(t, err)
+	require.Len(t, res.Ranges, 1)
+	assert.Equal(t, first, res.Ranges[0].Sha)
+
+	_, err = gitData.GetBlame(context.Background(), &RequestGetBlame{Repo: "test1", Ref: first, Path: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
 func TestStat(t *testing.T) {
 	mockStorage := storage.NewMock()
 	repo := makeSourceRepository(t)
@@ -0,0 +1,1007 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/format/pktline"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/capability"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/sideband"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	gitStorage "github.com/go-git/go-git/v5/storage"
+	"go.f110.dev/xerrors"
+
+	"go.f110.dev/mono/go/logger/slogger"
+)
+
+const (
+	uploadPackService = "git-upload-pack"
+	// defaultPackWindow is the window size of the delta compression of the packfile.
+	defaultPackWindow = 10
+)
+
+// SmartHTTPServer serves the repositories of DataService over git's smart HTTP protocol.
+// Only upload-pack (clone and fetch) is supported. Both of the protocol v0 and v2 are
+// supported, and the shallow clone (deepen) and the partial clone (blob:none and blob:limit)
+// are available in both versions. deepen-since, deepen-not and deepen-relative are not supported.
+//
+// The repositories are read through the storer of DataService, so the packfiles are
+// shared with PackfileCache.
+//
+// The archive of the tree is also served at /<repo>/archive/<ref>.tar.gz and /<repo>/archive/<ref>.zip.
+type SmartHTTPServer struct {
+	service    *DataService
+	packWindow uint
+}
+
+func NewSmartHTTPServer(service *DataService) *SmartHTTPServer {
+	return &SmartHTTPServer{service: service, packWindow: defaultPackWindow}
+}
+
+// ServeHTTP handles /<repo>/info/refs and /<repo>/git-upload-pack. The repository name may
+// have the ".git" suffix.
+func (s *SmartHTTPServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if name, rev, format, ok := splitArchivePath(req.URL.Path); ok {
+		s.serveArchive(w, req, name, rev, format)
+		return
+	}
+	name, endpoint, ok := splitSmartHTTPPath(req.URL.Path)
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	switch endpoint {
+	case "info/refs", uploadPackService:
+	case "git-receive-pack":
+		http.Error(w, "push is not supported", http.StatusForbidden)
+		return
+	default:
+		http.NotFound(w, req)
+		return
+	}
+	repo, ok := s.service.lookup(name)
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	version := protocolVersion(req)
+	switch endpoint {
+	case "info/refs":
+		if req.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if req.URL.Query().Get("service") != uploadPackService {
+			http.Error(w, "dumb protocol is not supported", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-git-upload-pack-advertisement")
+		w.Header().Set("Cache-Control", "no-cache")
+		var err error
+		if version == 2 {
+			err = s.advertiseCapabilities(w)
+		} else {
+			err = s.advertiseRefs(w, repo)
+		}
+		if err != nil {
+			slogger.Log.Info("Failed to advertise", slog.String("repo", name), slogger.E(err))
+		}
+	case uploadPackService:
+		if req.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		body := io.Reader(req.Body)
+		if req.Header.Get("Content-Encoding") == "gzip" {
+			r, err := gzip.NewReader(req.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			defer r.Close()
+			body = r
+		}
+		w.Header().Set("Content-Type", "application/x-git-upload-pack-result")
+		w.Header().Set("Cache-Control", "no-cache")
+		var err error
+		if version == 2 {
+			err = s.serveCommand(w, repo, body)
+		} else {
+			err = s.uploadPack(w, repo, body)
+		}
+		if err != nil {
+			slogger.Log.Info("Failed to upload pack", slog.String("repo", name), slogger.E(err))
+		}
+	}
+}
+
+func splitSmartHTTPPath(p string) (string, string, bool) {
+	p = strings.TrimPrefix(p, "/")
+	for _, endpoint := range []string{"info/refs", uploadPackService, "git-receive-pack"} {
+		if name, ok := strings.CutSuffix(p, "/"+endpoint); ok && name != "" {
+			return strings.TrimSuffix(name, ".git"), endpoint, true
+		}
+	}
+	return "", "", false
+}
+
+// protocolVersion returns the version which is requested by Git-Protocol header.
+func protocolVersion(req *http.Request) int {
+	for _, v := range strings.Split(req.Header.Get("Git-Protocol"), ":") {
+		if v == "version=2" {
+			return 2
+		}
+	}
+	return 0
+}
+
+// advertisedRef is a reference which is advertised to the client.
+type advertisedRef struct {
+	Name plumbing.ReferenceName
+	Hash plumbing.Hash
+	// Target is the target of the symbolic reference.
+	Target plumbing.ReferenceName
+	// Peeled is the object which is pointed by the annotated tag.
+	Peeled plumbing.Hash
+}
+
+// listAdvertisedRefs returns the references of repo. HEAD is the first if exists.
+func listAdvertisedRefs(repo *goGit.Repository) ([]*advertisedRef, error) {
+	iter, err := repo.Storer.IterReferences()
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	seen := make(map[plumbing.ReferenceName]struct{})
+	var refs []*advertisedRef
+	err = iter.ForEach(func(ref *plumbing.Reference) error {
+		if _, ok := seen[ref.Name()]; ok {
+			return nil
+		}
+		seen[ref.Name()] = struct{}{}
+
+		r := &advertisedRef{Name: ref.Name(), Hash: ref.Hash()}
+		if ref.Type() == plumbing.SymbolicReference {
+			r.Target = ref.Target()
+			resolved, err := storer.ResolveReference(repo.Storer, ref.Name())
+			if err != nil {
+				// The target doesn't exist yet (unborn branch).
+				r.Hash = plumbing.ZeroHash
+			} else {
+				r.Hash = resolved.Hash()
+			}
+		}
+		if ref.Name().IsTag() {
+			if h, ok := peel(repo.Storer, r.Hash); ok {
+				r.Peeled = h
+			}
+		}
+		refs = append(refs, r)
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Name == plumbing.HEAD {
+			return true
+		}
+		if refs[j].Name == plumbing.HEAD {
+			return false
+		}
+		return refs[i].Name < refs[j].Name
+	})
+	return refs, nil
+}
+
+// peel returns the object which is pointed by the annotated tag h.
+// The second value is false if h is not an annotated tag.
+func peel(st storer.EncodedObjectStorer, h plumbing.Hash) (plumbing.Hash, bool) {
+	tag, err := object.GetTag(st, h)
+	if err != nil {
+		return plumbing.ZeroHash, false
+	}
+	for {
+		next, err := object.GetTag(st, tag.Target)
+		if err != nil {
+			return tag.Target, true
+		}
+		tag = next
+	}
+}
+
+// advertiseRefs writes the reference advertisement of the protocol v0.
+func (s *SmartHTTPServer) advertiseRefs(w io.Writer, repo *goGit.Repository) error {
+	refs, err := listAdvertisedRefs(repo)
+	if err != nil {
+		return err
+	}
+
+	caps := []string{
+		"ofs-delta", "side-band", "side-band-64k", "shallow", "no-progress", "include-tag", "filter",
+		"allow-tip-sha1-in-want", "allow-reachable-sha1-in-want",
+	}
+	var lines []string
+	for _, v := range refs {
+		if v.Hash.IsZero() {
+			continue
+		}
+		if v.Target != "" {
+			caps = append(caps, fmt.Sprintf("symref=%s:%s", v.Name, v.Target))
+		}
+		lines = append(lines, fmt.Sprintf("%s %s\n", v.Hash, v.Name))
+		if !v.Peeled.IsZero() {
+			lines = append(lines, fmt.Sprintf("%s %s^{}\n", v.Peeled, v.Name))
+		}
+	}
+	caps = append(caps, "agent="+capability.DefaultAgent())
+	if len(lines) == 0 {
+		// An empty repository advertises the capabilities with the dummy reference.
+		lines = append(lines, fmt.Sprintf("%s capabilities^{}\n", plumbing.ZeroHash))
+	}
+	lines[0] = strings.TrimSuffix(lines[0], "\n") + "\x00" + strings.Join(caps, " ") + "\n"
+
+	enc := pktline.NewEncoder(w)
+	if err := enc.EncodeString("# service=" + uploadPackService + "\n"); err != nil {
+		return xerrors.WithStack(err)
+	}
+	if err := enc.Flush(); err != nil {
+		return xerrors.WithStack(err)
+	}
+	if err := enc.EncodeString(lines...); err != nil {
+		return xerrors.WithStack(err)
+	}
+	if err := enc.Flush(); err != nil {
+		return xerrors.WithStack(err)
+	}
+	return nil
+}
+
+// advertiseCapabilities writes the capability advertisement of the protocol v2.
+func (s *SmartHTTPServer) advertiseCapabilities(w io.Writer) error {
+	enc := pktline.NewEncoder(w)
+	err := enc.EncodeString(
+		"version 2\n",
+		"agent="+capability.DefaultAgent()+"\n",
+		"ls-refs=unborn\n",
+		"fetch=shallow filter\n",
+		"object-format=sha1\n",
+	)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	if err := enc.Flush(); err != nil {
+		return xerrors.WithStack(err)
+	}
+	return nil
+}
+
+// packRequest is the parsed request of upload-pack.
+type packRequest struct {
+	Wants    []plumbing.Hash
+	Haves    []plumbing.Hash
+	Shallows []plumbing.Hash
+	// Depth is the value of deepen. Zero means the full history.
+	Depth  int
+	Filter string
+	Done   bool
+
+	IncludeTag bool
+	OFSDelta   bool
+	SideBand   bool
+	SideBand64 bool
+}
+
+// parseArgument parses the line of want, have, shallow, deepen, filter and done.
+// The second value is false if the line is not the argument of the fetch.
+func (r *packRequest) parseArgument(line string) (bool, error) {
+	cmd, arg, _ := strings.Cut(line, " ")
+	switch cmd {
+	case "want", "have", "shallow":
+		if !plumbing.IsHash(arg) {
+			return true, xerrors.Definef("invalid object id: %s", arg).WithStack()
+		}
+		h := plumbing.NewHash(arg)
+		switch cmd {
+		case "want":
+			r.Wants = append(r.Wants, h)
+		case "have":
+			r.Haves = append(r.Haves, h)
+		case "shallow":
+			r.Shallows = append(r.Shallows, h)
+		}
+	case "deepen":
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 {
+			return true, xerrors.Definef("invalid deepen: %s", arg).WithStack()
+		}
+		r.Depth = n
+	case "deepen-since", "deepen-not", "deepen-relative":
+		return true, xerrors.Definef("%s is not supported", cmd).WithStack()
+	case "filter":
+		r.Filter = arg
+	case "done":
+		r.Done = true
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// uploadPack handles the request of the protocol v0.
+// In the stateless HTTP transport, each request has all wants and the haves of the round.
+func (s *SmartHTTPServer) uploadPack(w io.Writer, repo *goGit.Repository, body io.Reader) error {
+	r := newPktReader(body)
+	req := &packRequest{}
+	first := true
+	var flushes int
+	for {
+		kind, line, err := r.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if kind == pktFlush {
+			flushes++
+		}
+		if kind != pktData {
+			continue
+		}
+		if first && strings.HasPrefix(line, "want ") {
+			first = false
+			// The first want line has the capabilities.
+			if fields := strings.Fields(line); len(fields) > 2 {
+				line = fields[0] + " " + fields[1]
+				for _, c := range fields[2:] {
+					switch c {
+					case "ofs-delta":
+						req.OFSDelta = true
+					case "side-band":
+						req.SideBand = true
+					case "side-band-64k":
+						req.SideBand64 = true
+					case "include-tag":
+						req.IncludeTag = true
+					}
+				}
+			}
+		}
+		if _, err := req.parseArgument(line); err != nil {
+			return writeErrorLine(w, err)
+		}
+	}
+	if len(req.Wants) == 0 {
+		return nil
+	}
+
+	plan, err := planPack(repo.Storer, req)
+	if err != nil {
+		return writeErrorLine(w, err)
+	}
+
+	enc := pktline.NewEncoder(w)
+	if req.Depth > 0 {
+		for _, v := range plan.Shallow {
+			if err := enc.Encodef("shallow %s\n", v); err != nil {
+				return xerrors.WithStack(err)
+			}
+		}
+		for _, v := range plan.Unshallow {
+			if err := enc.Encodef("unshallow %s\n", v); err != nil {
+				return xerrors.WithStack(err)
+			}
+		}
+		if err := enc.Flush(); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+	// The first request of a shallow fetch ends right after the wants. It is
+	// answered by the shallow list only.
+	if flushes < 2 && !req.Done {
+		return nil
+	}
+	if len(plan.Common) > 0 {
+		if err := enc.Encodef("ACK %s\n", plan.Common[0]); err != nil {
+			return xerrors.WithStack(err)
+		}
+	} else if err := enc.EncodeString("NAK\n"); err != nil {
+		return xerrors.WithStack(err)
+	}
+	if !req.Done {
+		return nil
+	}
+
+	switch {
+	case req.SideBand64:
+		return s.writePack(sideband.NewMuxer(sideband.Sideband64k, w), w, repo, plan, req)
+	case req.SideBand:
+		return s.writePack(sideband.NewMuxer(sideband.Sideband, w), w, repo, plan, req)
+	default:
+		return s.writePack(nil, w, repo, plan, req)
+	}
+}
+
+// serveCommand handles the command of the protocol v2.
+func (s *SmartHTTPServer) serveCommand(w io.Writer, repo *goGit.Repository, body io.Reader) error {
+	r := newPktReader(body)
+	var command string
+	var args []string
+	inArgs := false
+	for {
+		kind, line, err := r.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if kind == pktFlush {
+			break
+		}
+		if kind == pktDelim {
+			inArgs = true
+			continue
+		}
+		if kind != pktData {
+			continue
+		}
+		if !inArgs {
+			if v, ok := strings.CutPrefix(line, "command="); ok {
+				command = v
+			}
+			continue
+		}
+		args = append(args, line)
+	}
+
+	switch command {
+	case "ls-refs":
+		return s.lsRefs(w, repo, args)
+	case "fetch":
+		return s.fetch(w, repo, args)
+	default:
+		return writeErrorLine(w, xerrors.Definef("unknown command: %s", command).WithStack())
+	}
+}
+
+func (s *SmartHTTPServer) lsRefs(w io.Writer, repo *goGit.Repository, args []string) error {
+	var symrefs, peeled, unborn bool
+	var prefixes []string
+	for _, v := range args {
+		switch {
+		case v == "symrefs":
+			symrefs = true
+		case v == "peel":
+			peeled = true
+		case v == "unborn":
+			unborn = true
+		case strings.HasPrefix(v, "ref-prefix "):
+			prefixes = append(prefixes, strings.TrimPrefix(v, "ref-prefix "))
+		}
+	}
+
+	refs, err := listAdvertisedRefs(repo)
+	if err != nil {
+		return writeErrorLine(w, err)
+	}
+	enc := pktline.NewEncoder(w)
+	for _, v := range refs {
+		if len(prefixes) > 0 && !hasAnyPrefix(v.Name.String(), prefixes) {
+			continue
+		}
+		var line string
+		switch {
+		case !v.Hash.IsZero():
+			line = fmt.Sprintf("%s %s", v.Hash, v.Name)
+		case unborn && v.Name == plumbing.HEAD:
+			line = "unborn " + v.Name.String()
+		default:
+			continue
+		}
+		if symrefs && v.Target != "" {
+			line += " symref-target:" + v.Target.String()
+		}
+		if peeled && !v.Peeled.IsZero() {
+			line += " peeled:" + v.Peeled.String()
+		}
+		if err := enc.EncodeString(line + "\n"); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		return xerrors.WithStack(err)
+	}
+	return nil
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, v := range prefixes {
+		if strings.HasPrefix(s, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetch handles the fetch command of the protocol v2.
+// The server is always ready to send the packfile after the first round of the negotiation.
+func (s *SmartHTTPServer) fetch(w io.Writer, repo *goGit.Repository, args []string) error {
+	req := &packRequest{SideBand64: true}
+	for _, v := range args {
+		ok, err := req.parseArgument(v)
+		if err != nil {
+			return writeErrorLine(w, err)
+		}
+		if ok {
+			continue
+		}
+		switch v {
+		case "ofs-delta":
+			req.OFSDelta = true
+		case "include-tag":
+			req.IncludeTag = true
+		}
+	}
+	if len(req.Wants) == 0 {
+		return writeErrorLine(w, xerrors.Define("no want").WithStack())
+	}
+
+	plan, err := planPack(repo.Storer, req)
+	if err != nil {
+		return writeErrorLine(w, err)
+	}
+
+	enc := pktline.NewEncoder(w)
+	if !req.Done {
+		lines := []string{"acknowledgments\n"}
+		if len(plan.Common) == 0 {
+			lines = append(lines, "NAK\n")
+		}
+		for _, v := range plan.Common {
+			lines = append(lines, fmt.Sprintf("ACK %s\n", v))
+		}
+		lines = append(lines, "ready\n")
+		if err := enc.EncodeString(lines...); err != nil {
+			return xerrors.WithStack(err)
+		}
+		if err := writeDelim(w); err != nil {
+			return err
+		}
+	}
+	if req.Depth > 0 || len(req.Shallows) > 0 {
+		lines := []string{"shallow-info\n"}
+		for _, v := range plan.Shallow {
+			lines = append(lines, fmt.Sprintf("shallow %s\n", v))
+		}
+		for _, v := range plan.Unshallow {
+			lines = append(lines, fmt.Sprintf("unshallow %s\n", v))
+		}
+		if err := enc.EncodeString(lines...); err != nil {
+			return xerrors.WithStack(err)
+		}
+		if err := writeDelim(w); err != nil {
+			return err
+		}
+	}
+	if err := enc.EncodeString("packfile\n"); err != nil {
+		return xerrors.WithStack(err)
+	}
+	return s.writePack(sideband.NewMuxer(sideband.Sideband64k, w), w, repo, plan, req)
+}
+
+// writePack encodes the packfile of plan. If mux is not nil, the packfile is multiplexed
+// and terminated by the flush-pkt.
+func (s *SmartHTTPServer) writePack(mux *sideband.Muxer, w io.Writer, repo *goGit.Repository, plan *packPlan, req *packRequest) error {
+	var dst io.Writer = w
+	if mux != nil {
+		dst = mux
+	}
+	enc := packfile.NewEncoder(dst, repo.Storer, !req.OFSDelta)
+	if _, err := enc.Encode(plan.Objects, s.packWindow); err != nil {
+		if mux != nil {
+			_, _ = mux.WriteChannel(sideband.ErrorMessage, []byte(err.Error()))
+		}
+		return xerrors.WithStack(err)
+	}
+	if mux != nil {
+		if err := pktline.NewEncoder(w).Flush(); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// packPlan is the result of the negotiation.
+type packPlan struct {
+	// Objects is the list of the objects which are sent to the client.
+	Objects []plumbing.Hash
+	// Common is the list of the haves which the server has.
+	Common []plumbing.Hash
+	// Shallow is the list of the commits which become the new shallow boundary of the client.
+	Shallow []plumbing.Hash
+	// Unshallow is the list of the shallow commits of the client whose parents are sent.
+	Unshallow []plumbing.Hash
+}
+
+// planPack decides the objects which are sent for req.
+//
+// The commits which are reachable from the haves are not sent. The trees and the blobs
+// which are reachable from the parents of the sent commits are not sent either.
+func planPack(st gitStorage.Storer, req *packRequest) (*packPlan, error) {
+	plan := &packPlan{}
+	for _, v := range req.Haves {
+		if st.HasEncodedObject(v) == nil {
+			plan.Common = append(plan.Common, v)
+		}
+	}
+
+	clientShallow := make(map[plumbing.Hash]struct{})
+	for _, v := range req.Shallows {
+		clientShallow[v] = struct{}{}
+	}
+	// uninteresting is the set of the commits which the client has.
+	uninteresting := make(map[plumbing.Hash]struct{})
+	queue := append([]plumbing.Hash{}, plan.Common...)
+	for len(queue) > 0 {
+		h := queue[0]
+		queue = queue[1:]
+		if _, ok := uninteresting[h]; ok {
+			continue
+		}
+		c, err := object.GetCommit(st, h)
+		if err != nil {
+			continue
+		}
+		uninteresting[h] = struct{}{}
+		if _, ok := clientShallow[h]; ok {
+			continue
+		}
+		queue = append(queue, c.ParentHashes...)
+	}
+	for v := range clientShallow {
+		uninteresting[v] = struct{}{}
+	}
+
+	b := newPackBuilder(st, req.Filter)
+	if err := b.validateFilter(); err != nil {
+		return nil, err
+	}
+	type entry struct {
+		hash  plumbing.Hash
+		depth int
+	}
+	var commits []entry
+	for _, v := range req.Wants {
+		h, err := b.addWant(v)
+		if err != nil {
+			return nil, err
+		}
+		if !h.IsZero() {
+			commits = append(commits, entry{hash: h, depth: 1})
+		}
+	}
+
+	visited := make(map[plumbing.Hash]struct{})
+	var sent []*object.Commit
+	for len(commits) > 0 {
+		e := commits[0]
+		commits = commits[1:]
+		if _, ok := visited[e.hash]; ok {
+			continue
+		}
+		visited[e.hash] = struct{}{}
+		c, err := object.GetCommit(st, e.hash)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		canDeepen := req.Depth == 0 || e.depth < req.Depth
+		_, have := uninteresting[e.hash]
+		_, shallow := clientShallow[e.hash]
+		if have && req.Depth == 0 {
+			continue
+		}
+		// With the depth, the walk goes through the commits which the client
+		// has because the new shallow boundary is counted from the wants.
+		if !have {
+			b.add(e.hash)
+			sent = append(sent, c)
+		}
+		if !canDeepen {
+			if len(c.ParentHashes) > 0 && !shallow {
+				plan.Shallow = append(plan.Shallow, e.hash)
+			}
+			continue
+		}
+		if shallow && len(c.ParentHashes) > 0 {
+			plan.Unshallow = append(plan.Unshallow, e.hash)
+		}
+		for _, p := range c.ParentHashes {
+			commits = append(commits, entry{hash: p, depth: e.depth + 1})
+		}
+	}
+
+	// The client has the trees of the parents of the sent commits if it has the parents.
+	for _, c := range sent {
+		for _, p := range c.ParentHashes {
+			if _, ok := uninteresting[p]; !ok {
+				continue
+			}
+			parent, err := object.GetCommit(st, p)
+			if err != nil {
+				continue
+			}
+			if err := b.exclude(parent.TreeHash); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, c := range sent {
+		if err := b.addTree(c.TreeHash); err != nil {
+			return nil, err
+		}
+	}
+	if req.IncludeTag {
+		if err := b.addTags(); err != nil {
+			return nil, err
+		}
+	}
+
+	plan.Objects = b.objects
+	return plan, nil
+}
+
+// packBuilder collects the objects of the packfile.
+type packBuilder struct {
+	st     gitStorage.Storer
+	filter string
+
+	objects  []plumbing.Hash
+	added    map[plumbing.Hash]struct{}
+	excluded map[plumbing.Hash]struct{}
+	// blobLimit is the maximum size of the blob which is sent. -1 means no limit.
+	blobLimit int64
+}
+
+func newPackBuilder(st gitStorage.Storer, filter string) *packBuilder {
+	return &packBuilder{
+		st:        st,
+		filter:    filter,
+		added:     make(map[plumbing.Hash]struct{}),
+		excluded:  make(map[plumbing.Hash]struct{}),
+		blobLimit: -1,
+	}
+}
+
+func (b *packBuilder) validateFilter() error {
+	switch {
+	case b.filter == "":
+	case b.filter == "blob:none":
+		b.blobLimit = 0
+	case strings.HasPrefix(b.filter, "blob:limit="):
+		n, err := parseBlobLimit(strings.TrimPrefix(b.filter, "blob:limit="))
+		if err != nil {
+			return err
+		}
+		b.blobLimit = n
+	default:
+		return xerrors.Definef("unsupported filter: %s", b.filter).WithStack()
+	}
+	return nil
+}
+
+func parseBlobLimit(s string) (int64, error) {
+	unit := int64(1)
+	switch {
+	case strings.HasSuffix(s, "k"):
+		unit = 1024
+	case strings.HasSuffix(s, "m"):
+		unit = 1024 * 1024
+	case strings.HasSuffix(s, "g"):
+		unit = 1024 * 1024 * 1024
+	}
+	n, err := strconv.ParseInt(strings.TrimRight(s, "kmg"), 10, 64)
+	if err != nil {
+		return 0, xerrors.Definef("invalid blob:limit: %s", s).WithStack()
+	}
+	return n * unit, nil
+}
+
+func (b *packBuilder) add(h plumbing.Hash) {
+	if _, ok := b.added[h]; ok {
+		return
+	}
+	b.added[h] = struct{}{}
+	b.objects = append(b.objects, h)
+}
+
+// addWant adds the wanted object and returns the commit which has to be walked.
+// The annotated tags are peeled.
+func (b *packBuilder) addWant(h plumbing.Hash) (plumbing.Hash, error) {
+	for {
+		obj, err := b.st.EncodedObject(plumbing.AnyObject, h)
+		if err != nil {
+			return plumbing.ZeroHash, xerrors.Definef("not our ref %s", h).WithStack()
+		}
+		switch obj.Type() {
+		case plumbing.CommitObject:
+			return h, nil
+		case plumbing.TagObject:
+			b.add(h)
+			tag, err := object.DecodeTag(b.st, obj)
+			if err != nil {
+				return plumbing.ZeroHash, xerrors.WithStack(err)
+			}
+			h = tag.Target
+		case plumbing.TreeObject:
+			return plumbing.ZeroHash, b.addTree(h)
+		default:
+			b.add(h)
+			return plumbing.ZeroHash, nil
+		}
+	}
+}
+
+// exclude marks the tree h and its entries as the objects which the client has.
+func (b *packBuilder) exclude(h plumbing.Hash) error {
+	if _, ok := b.excluded[h]; ok {
+		return nil
+	}
+	b.excluded[h] = struct{}{}
+	tree, err := object.GetTree(b.st, h)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	for _, e := range tree.Entries {
+		switch e.Mode {
+		case filemode.Submodule:
+		case filemode.Dir:
+			if err := b.exclude(e.Hash); err != nil {
+				return err
+			}
+		default:
+			b.excluded[e.Hash] = struct{}{}
+		}
+	}
+	return nil
+}
+
+func (b *packBuilder) addTree(h plumbing.Hash) error {
+	if _, ok := b.excluded[h]; ok {
+		return nil
+	}
+	if _, ok := b.added[h]; ok {
+		return nil
+	}
+	b.add(h)
+	tree, err := object.GetTree(b.st, h)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	for _, e := range tree.Entries {
+		switch e.Mode {
+		case filemode.Submodule:
+			// The commit of the submodule is not in this repository.
+		case filemode.Dir:
+			if err := b.addTree(e.Hash); err != nil {
+				return err
+			}
+		default:
+			if _, ok := b.excluded[e.Hash]; ok {
+				continue
+			}
+			if b.blobLimit == 0 {
+				// blob:none doesn't need to look up the blob at all.
+				continue
+			}
+			if b.blobLimit > 0 {
+				size, err := b.st.EncodedObjectSize(e.Hash)
+				if err != nil {
+					return xerrors.WithStack(err)
+				}
+				if size >= b.blobLimit {
+					continue
+				}
+			}
+			b.add(e.Hash)
+		}
+	}
+	return nil
+}
+
+// addTags adds the annotated tags which point to the sent objects.
+func (b *packBuilder) addTags() error {
+	iter, err := b.st.IterReferences()
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	return iter.ForEach(func(ref *plumbing.Reference) error {
+		if !ref.Name().IsTag() || ref.Type() != plumbing.HashReference {
+			return nil
+		}
+		target, ok := peel(b.st, ref.Hash())
+		if !ok {
+			return nil
+		}
+		if _, ok := b.added[target]; ok {
+			b.add(ref.Hash())
+		}
+		return nil
+	})
+}
+
+const (
+	pktData = iota
+	pktFlush
+	pktDelim
+	pktResponseEnd
+)
+
+// pktReader reads pkt-lines including the delim-pkt of the protocol v2 which
+// pktline.Scanner doesn't support.
+type pktReader struct {
+	r *bufio.Reader
+}
+
+func newPktReader(r io.Reader) *pktReader {
+	return &pktReader{r: bufio.NewReader(r)}
+}
+
+func (p *pktReader) next() (int, string, error) {
+	var l [4]byte
+	if _, err := io.ReadFull(p.r, l[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, "", xerrors.WithStack(err)
+		}
+		return 0, "", err
+	}
+	n, err := strconv.ParseUint(string(l[:]), 16, 16)
+	if err != nil {
+		return 0, "", xerrors.Definef("invalid pkt-len: %q", l[:]).WithStack()
+	}
+	switch n {
+	case 0:
+		return pktFlush, "", nil
+	case 1:
+		return pktDelim, "", nil
+	case 2:
+		return pktResponseEnd, "", nil
+	case 3:
+		return 0, "", xerrors.Definef("invalid pkt-len: %q", l[:]).WithStack()
+	}
+	buf := make([]byte, n-4)
+	if _, err := io.ReadFull(p.r, buf); err != nil {
+		return 0, "", xerrors.WithStack(err)
+	}
+	return pktData, string(bytes.TrimSuffix(buf, []byte("\n"))), nil
+}
+
+func writeDelim(w io.Writer) error {
+	if _, err := w.Write([]byte("0001")); err != nil {
+		return xerrors.WithStack(err)
+	}
+	return nil
+}
+
+// writeErrorLine reports err to the client by the ERR packet. err is returned for logging.
+func writeErrorLine(w io.Writer, err error) error {
+	if werr := pktline.NewEncoder(w).EncodeString("ERR " + err.Error() + "\n"); werr != nil {
+		return xerrors.WithStack(werr)
+	}
+	return err
+}
@@ -0,0 +1,189 @@
+package git
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	goGit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/packfile"
+	"github.com/go-git/go-git/v5/plumbing/format/pktline"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/protocol/packp/sideband"
+	gitStorage "github.com/go-git/go-git/v5/storage"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.f110.dev/mono/go/logger"
+	"go.f110.dev/mono/go/logger/slogger"
+)
+
+func TestSmartHTTPServer(t *testing.T) {
+	logger.SetLogLevel("debug")
+	logger.Init()
+	slogger.Init()
+
+	repo := makeSourceRepository(t)
+	second := addCommit(t, repo, "CHANGELOG.md", "v2")
+	head, err := repo.Head()
+	require.NoError(t, err)
+	require.Equal(t, second, head.Hash())
+
+	svc, err := NewDataServiceWithGoGit(map[string]*goGit.Repository{"test1": repo})
+	require.NoError(t, err)
+	s := httptest.NewServer(NewSmartHTTPServer(svc))
+	t.Cleanup(s.Close)
+
+	t.Run("Clone", func(t *testing.T) {
+		cloned, err := goGit.Clone(memory.NewStorage(), nil, &goGit.CloneOptions{URL: s.URL + "/test1.git", NoCheckout: true})
+		require.NoError(t, err)
+		ref, err := cloned.Head()
+		require.NoError(t, err)
+		assert.Equal(t, second, ref.Hash())
+		commits, err := cloned.Log(&goGit.LogOptions{From: ref.Hash()})
+		require.NoError(t, err)
+		n := 0
+		require.NoError(t, commits.ForEach(func(*object.Commit) error { n++; return nil }))
+		assert.Equal(t, 2, n)
+	})
+
+	t.Run("ShallowClone", func(t *testing.T) {
+		cloned, err := goGit.Clone(memory.NewStorage(), nil, &goGit.CloneOptions{URL: s.URL + "/test1", Depth: 1, NoCheckout: true})
+		require.NoError(t, err)
+		shallow, err := cloned.Storer.Shallow()
+		require.NoError(t, err)
+		assert.Equal(t, []plumbing.Hash{second}, shallow)
+		c, err := cloned.CommitObject(second)
+		require.NoError(t, err)
+		_, err = cloned.CommitObject(c.ParentHashes[0])
+		assert.Error(t, err)
+	})
+
+	t.Run("Deepen", func(t *testing.T) {
+		first, err := repo.CommitObject(second)
+		require.NoError(t, err)
+		// The client has only the tip as a shallow commit.
+		plan, err := planPack(repo.Storer, &packRequest{
+			Wants:    []plumbing.Hash{second},
+			Haves:    []plumbing.Hash{second},
+			Shallows: []plumbing.Hash{second},
+			Depth:    2,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []plumbing.Hash{second}, plan.Unshallow)
+		assert.Empty(t, plan.Shallow)
+		assert.Contains(t, plan.Objects, first.ParentHashes[0])
+		assert.NotContains(t, plan.Objects, second)
+	})
+
+	t.Run("FetchV2WithFilter", func(t *testing.T) {
+		body := new(bytes.Buffer)
+		enc := pktline.NewEncoder(body)
+		require.NoError(t, enc.EncodeString("command=fetch\n"))
+		body.WriteString("0001")
+		require.NoError(t, enc.EncodeString("ofs-delta\n", "deepen 1\n", "filter blob:none\n", "want "+second.String()+"\n", "done\n"))
+		require.NoError(t, enc.Flush())
+
+		req, err := http.NewRequest(http.MethodPost, s.URL+"/test1/git-upload-pack", body)
+		require.NoError(t, err)
+		req.Header.Set("Git-Protocol", "version=2")
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		r := newPktReader(res.Body)
+		var sections []string
+		for {
+			kind, line, err := r.next()
+			require.NoError(t, err)
+			if kind == pktData {
+				sections = append(sections, line)
+			}
+			if line == "packfile" {
+				break
+			}
+		}
+		assert.Equal(t, []string{"shallow-info", "shallow " + second.String(), "packfile"}, sections)
+
+		pack, err := io.ReadAll(sideband.NewDemuxer(sideband.Sideband64k, r.r))
+		require.NoError(t, err)
+		scanner := packfile.NewScanner(bytes.NewReader(pack))
+		_, n, err := scanner.Header()
+		require.NoError(t, err)
+		types := make(map[plumbing.ObjectType]int)
+		for range n {
+			h, err := scanner.NextObjectHeader()
+			require.NoError(t, err)
+			types[h.Type]++
+			_, _, err = scanner.NextObject(io.Discard)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 0, types[plumbing.BlobObject])
+		assert.Equal(t, 1, types[plumbing.CommitObject])
+	})
+
+	t.Run("FilterBlobNone", func(t *testing.T) {
+		// blob:none must not look up the blobs at all.
+		st := &blobRecordingStorer{Storer: repo.Storer, blobs: make(map[plumbing.Hash]struct{})}
+		plan, err := planPack(st, &packRequest{Wants: []plumbing.Hash{second}, Filter: "blob:none"})
+		require.NoError(t, err)
+		assert.NotEmpty(t, plan.Objects)
+		assert.Empty(t, st.blobs)
+	})
+
+	t.Run("LsRefsV2", func(t *testing.T) {
+		body := new(bytes.Buffer)
+		enc := pktline.NewEncoder(body)
+		require.NoError(t, enc.EncodeString("command=ls-refs\n"))
+		body.WriteString("0001")
+		require.NoError(t, enc.EncodeString("symrefs\n", "ref-prefix HEAD\n"))
+		require.NoError(t, enc.Flush())
+
+		req, err := http.NewRequest(http.MethodPost, s.URL+"/test1/git-upload-pack", body)
+		require.NoError(t, err)
+		req.Header.Set("Git-Protocol", "version=2")
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		r := newPktReader(res.Body)
+		kind, line, err := r.next()
+		require.NoError(t, err)
+		assert.Equal(t, pktData, kind)
+		assert.Equal(t, second.String()+" HEAD symref-target:refs/heads/master", line)
+		kind, _, err = r.next()
+		require.NoError(t, err)
+		assert.Equal(t, pktFlush, kind)
+	})
+
+	t.Run("ReceivePack", func(t *testing.T) {
+		res, err := http.Get(s.URL + "/test1/info/refs?service=git-receive-pack")
+		require.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	})
+}
+
+// blobRecordingStorer records the blobs which are read or whose size is looked up.
+type blobRecordingStorer struct {
+	gitStorage.Storer
+
+	blobs map[plumbing.Hash]struct{}
+}
+
+func (s *blobRecordingStorer) EncodedObject(typ plumbing.ObjectType, h plumbing.Hash) (plumbing.EncodedObject, error) {
+	obj, err := s.Storer.EncodedObject(typ, h)
+	if err == nil && obj.Type() == plumbing.BlobObject {
+		s.blobs[h] = struct{}{}
+	}
+	return obj, err
+}
+
+func (s *blobRecordingStorer) EncodedObjectSize(h plumbing.Hash) (int64, error) {
+	s.blobs[h] = struct{}{}
+	return s.Storer.EncodedObjectSize(h)
+}
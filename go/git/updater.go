@@ -9,14 +9,14 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
-	gitHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
-	"github.com/google/go-github/v85/github"
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"go.f110.dev/go-memcached/client"
 	"go.f110.dev/xerrors"
 
@@ -35,23 +35,70 @@ type RepositoryConfig struct {
 	Name   string
 	URL    string
 	Prefix string
+	// Credential is used for fetching from URL. If nil, the token of GitHub is used for the repository
+	// hosted on github.com and no credential is sent to the other host.
+	// The repository on GitHub Enterprise Server should have GitHubAppCredential without TokenProvider
+	// so that the token of GitHub is used.
+	Credential CredentialProvider
 
 	goGit *git.Repository
 }
 
+// authMethod returns the credential of the upstream. The failure of getting the token of GitHub
+// is ignored because the public repository can be fetched without the credential.
+func (r *RepositoryConfig) authMethod(ctx context.Context, tokenProvider *githubutil.TokenProvider) (transport.AuthMethod, error) {
+	cred := r.Credential
+	switch v := cred.(type) {
+	case nil:
+		if !isGitHubURL(r.URL) {
+			return AnonymousCredential{}.AuthMethod(ctx)
+		}
+		cred = &GitHubAppCredential{TokenProvider: tokenProvider}
+	case *GitHubAppCredential:
+		if v.TokenProvider == nil {
+			cred = &GitHubAppCredential{TokenProvider: tokenProvider}
+		}
+	default:
+		auth, err := cred.AuthMethod(ctx)
+		if err != nil {
+			return nil, xerrors.WithMessagef(err, "failed to get the credential of %s", r.Name)
+		}
+		return auth, nil
+	}
+
+	auth, err := cred.AuthMethod(ctx)
+	if err != nil {
+		slogger.Log.Warn("Failed to get the token", slog.String("name", r.Name), slogger.E(err))
+		return nil, nil
+	}
+	return auth, nil
+}
+
+// isGitHubURL returns true if the repository of u is hosted on github.com.
+// The URL of scp-like syntax (e.g. git@github.com:f110/mono.git) is also accepted.
+func isGitHubURL(u string) bool {
+	if parsed, err := url.Parse(u); err == nil && parsed.Host != "" {
+		return parsed.Hostname() == "github.com"
+	}
+	if _, rest, ok := strings.Cut(u, "@"); ok {
+		host, _, _ := strings.Cut(rest, ":")
+		return host == "github.com"
+	}
+	return false
+}
+
 // Open opens the repository from object storage, cloning from the configured URL if it does not yet exist.
-func (r *RepositoryConfig) Open(ctx context.Context, stClient *storage.S3, cachePool *client.SinglePool, packCache *PackfileCache, tokenProvider *githubutil.TokenProvider, timeout time.Duration, disableInflatePackFile bool) error {
+func (r *RepositoryConfig) Open(ctx context.Context, stClient storage.Backend, cachePool *client.SinglePool, packCache *PackfileCache, tokenProvider *githubutil.TokenProvider, timeout time.Duration, disableInflatePackFile bool) error {
 	storer := NewObjectStorageStorer(stClient, r.Prefix, cachePool, packCache)
 
 	if ok, err := storer.Exist(); !ok && err == nil {
 		initCtx, cancel := ctxutil.WithTimeout(ctx, timeout)
 
 		slogger.Log.Info("Init repository", slog.String("name", r.Name), slog.String("url", r.URL), slog.String("prefix", r.Prefix))
-		var auth *gitHttp.BasicAuth
-		if tokenProvider != nil {
-			if v, err := tokenProvider.Token(initCtx); err == nil {
-				auth = &gitHttp.BasicAuth{Username: "octocat", Password: v}
-			}
+		auth, err := r.authMethod(initCtx, tokenProvider)
+		if err != nil {
+			cancel()
+			return err
 		}
 		if _, err := InitObjectStorageRepository(initCtx, stClient, r.URL, r.Prefix, auth); err != nil {
 			cancel()
@@ -74,6 +121,10 @@ func (r *RepositoryConfig) Open(ctx context.Context, stClient *storage.S3, cache
 			return err
 		}
 	}
+	// The repositories created before the commit-graph was introduced don't have it.
+	if err := UpdateCommitGraph(gitRepo); err != nil {
+		slogger.Log.Warn("Failed to update commit-graph", slog.String("name", r.Name), slogger.E(err))
+	}
 
 	return nil
 }
@@ -89,7 +140,7 @@ type Updater struct {
 	parallel int
 
 	id                     string
-	storageClient          *storage.S3
+	storageClient          storage.Backend
 	cachePool              *client.SinglePool
 	packCache              *dict.TTLCache[string, *packEntry]
 	lockFilePath           string
@@ -97,24 +148,33 @@ type Updater struct {
 	initTimeout            time.Duration
 	disableInflatePackFile bool
 	dataService            *DataService
+	maintenanceInterval    time.Duration
+	maintenanceOptions     MaintenanceOptions
 	running                bool
+
+	// repoLocks serializes the fetch and the maintenance of each repository.
+	repoLocks map[*git.Repository]*sync.Mutex
+	// webhookSecrets is keyed by the hostname of the repository.
+	webhookSecrets map[string]*webhookSecret
 }
 
-func NewUpdater(stClient *storage.S3, tokenProvider *githubutil.TokenProvider, repos []*RepositoryConfig, lockFilePath string, workers int) (*Updater, error) {
+func NewUpdater(stClient storage.Backend, tokenProvider *githubutil.TokenProvider, repos []*RepositoryConfig, lockFilePath string, workers int) (*Updater, error) {
 	buf := make([]byte, 16)
 	if _, err := rand.Read(buf); err != nil {
 		return nil, xerrors.WithStack(err)
 	}
 	return &Updater{
-		id:            hex.EncodeToString(buf),
-		storageClient: stClient,
-		repo:          repos,
-		interval:      1 * time.Hour,
-		timeout:       1 * time.Minute,
-		initTimeout:   5 * time.Minute,
-		lockFilePath:  lockFilePath,
-		parallel:      workers,
-		tokenProvider: tokenProvider,
+		id:             hex.EncodeToString(buf),
+		storageClient:  stClient,
+		repo:           repos,
+		interval:       1 * time.Hour,
+		timeout:        1 * time.Minute,
+		initTimeout:    5 * time.Minute,
+		lockFilePath:   lockFilePath,
+		parallel:       workers,
+		tokenProvider:  tokenProvider,
+		repoLocks:      make(map[*git.Repository]*sync.Mutex),
+		webhookSecrets: make(map[string]*webhookSecret),
 	}, nil
 }
 
@@ -158,6 +218,25 @@ func (u *Updater) SetDataService(s *DataService) *Updater {
 	return u
 }
 
+// SetMaintenance enables the periodic maintenance of the repositories. If interval is zero,
+// the maintenance is disabled.
+func (u *Updater) SetMaintenance(interval time.Duration, opt MaintenanceOptions) *Updater {
+	if u.running != false {
+		panic("cannot set maintenance. Updater is already running")
+	}
+	u.maintenanceInterval = interval
+	u.maintenanceOptions = opt
+	return u
+}
+
+// AddWebhookSecret registers the secret for verifying the webhook of the repositories on host.
+// The webhook of host must be sent by provider. The webhook of the host which doesn't have
+// the secret is accepted without the verification.
+func (u *Updater) AddWebhookSecret(host string, provider WebhookProvider, secret []byte) *Updater {
+	u.webhookSecrets[strings.ToLower(host)] = &webhookSecret{Provider: provider, Secret: secret}
+	return u
+}
+
 // Run executes the periodic refresh loop. It blocks until ctx is cancelled.
 func (u *Updater) Run(ctx context.Context) {
 	if err := u.acquireLock(ctx); err != nil {
@@ -165,14 +244,22 @@ func (u *Updater) Run(ctx context.Context) {
 		return
 	}
 
-	slogger.Log.Info("Start updater", slog.Duration("refresh_interval", u.interval), slog.Int("workers", u.parallel))
+	slogger.Log.Info("Start updater", slog.Duration("refresh_interval", u.interval), slog.Int("workers", u.parallel), slog.Duration("maintenance_interval", u.maintenanceInterval))
 	u.running = true
 	timer := time.NewTicker(u.interval)
 	defer timer.Stop()
+	var maintenanceCh <-chan time.Time
+	if u.maintenanceInterval > 0 {
+		maintenanceTimer := time.NewTicker(u.maintenanceInterval)
+		defer maintenanceTimer.Stop()
+		maintenanceCh = maintenanceTimer.C
+	}
 	for {
 		select {
 		case <-timer.C:
 			u.update(ctx)
+		case <-maintenanceCh:
+			u.maintain(ctx)
 		case <-ctx.Done():
 			return
 		}
@@ -193,7 +280,7 @@ func (u *Updater) AddRepo(ctx context.Context, repo *RepositoryConfig) error {
 	if u.dataService != nil {
 		u.dataService.AddRepo(repo.Name, repo.goGit)
 	}
-	go u.updateRepo(context.Background(), repo.goGit)
+	go u.updateRepo(context.Background(), repo)
 	return nil
 }
 
@@ -211,14 +298,15 @@ func (u *Updater) Sync(ctx context.Context, url string) error {
 	for _, v := range repos {
 		if v.URL == url {
 			slogger.Log.Info("Sync repository triggered", slog.String("repo", v.Name))
-			return u.updateRepo(ctx, v.goGit)
+			return u.updateRepo(ctx, v)
 		}
 	}
 	return ErrRepositoryNotTracked.WithStack()
 }
 
-// ServeHTTP handles GitHub webhook events. A push event triggers an
-// immediate fetch of the matching repository.
+// ServeHTTP handles the webhook events of GitHub, Gitea and GitLab. A push event triggers an
+// immediate fetch of the matching repository. If the secret of the host of the repository is
+// registered by AddWebhookSecret, the request which fails the verification is rejected.
 func (u *Updater) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	payload, err := io.ReadAll(req.Body)
 	if err != nil {
@@ -226,21 +314,82 @@ func (u *Updater) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		http.Error(w, "", http.StatusInternalServerError)
 		return
 	}
-	e, err := github.ParseWebHook(github.WebHookType(req), payload)
+	event, err := parseWebhookPushEvent(req, payload)
 	if err != nil {
 		slogger.Log.Warn("Failed to parse request", slogger.E(err))
 		http.Error(w, "", http.StatusBadRequest)
 		return
 	}
+	if event == nil {
+		return
+	}
+	if err := u.verifyWebhook(req, payload, event); err != nil {
+		slogger.Log.Warn("Failed to verify webhook", slog.String("provider", string(event.Provider)), slogger.E(err))
+		http.Error(w, "", http.StatusUnauthorized)
+		return
+	}
 
-	switch event := e.(type) {
-	case *github.PushEvent:
-		go func() {
-			if err := u.Sync(context.Background(), event.Repo.GetGitURL()); err != nil && errors.Is(err, ErrRepositoryNotTracked) {
-				_ = u.Sync(context.Background(), event.Repo.GetCloneURL())
+	go func() {
+		for _, v := range event.URLs {
+			if err := u.Sync(context.Background(), v); err == nil || !errors.Is(err, ErrRepositoryNotTracked) {
+				return
 			}
-		}()
+		}
+	}()
+}
+
+// ErrLockHeld is returned by Maintain if another process holds the lock of the updater.
+var ErrLockHeld = xerrors.Define("git: the lock of the updater is held by another process")
+
+// Maintain runs the maintenance of all repositories once, and releases the lock after the maintenance.
+// The running updater holds the lock as long as it runs, so Maintain doesn't wait for the lock and returns
+// ErrLockHeld if another process has it. The running updater maintains the repositories by itself if
+// the maintenance interval is set.
+func (u *Updater) Maintain(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if err := u.tryLock(ctx); err != nil {
+		return err
 	}
+	u.maintain(ctx)
+	cancel()
+	return u.releaseLock(context.WithoutCancel(ctx))
+}
+
+func (u *Updater) maintain(ctx context.Context) {
+	u.mu.Lock()
+	repos := append([]*RepositoryConfig(nil), u.repo...)
+	u.mu.Unlock()
+
+	for _, v := range repos {
+		if ctx.Err() != nil {
+			return
+		}
+		st, ok := v.goGit.Storer.(*ObjectStorageStorer)
+		if !ok {
+			continue
+		}
+		slogger.Log.Info("Maintaining repository", slog.String("repo", v.Name))
+		unlock := u.lockRepo(v.goGit)
+		_, err := st.Maintain(ctx, u.maintenanceOptions)
+		unlock()
+		if err != nil {
+			slogger.Log.Warn("Failed to maintain repository", slog.String("repo", v.Name), slogger.E(err))
+		}
+	}
+}
+
+func (u *Updater) lockRepo(repo *git.Repository) func() {
+	u.mu.Lock()
+	l, ok := u.repoLocks[repo]
+	if !ok {
+		l = &sync.Mutex{}
+		u.repoLocks[repo] = l
+	}
+	u.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
 }
 
 func (u *Updater) acquireLock(ctx context.Context) error {
@@ -251,15 +400,13 @@ func (u *Updater) acquireLock(ctx context.Context) error {
 	slogger.Log.Info("Acquiring the lock...", slog.String("id", u.id))
 	lock, err := u.getLock(ctx)
 	if errors.Is(err, storage.ErrObjectNotFound) {
-		if err := u.setLock(ctx); err != nil {
-			return err
-		}
-		return nil
+		return u.setLock(ctx)
+	}
+	if err != nil {
+		return err
 	}
 	if time.Now().After(lock.Expire) {
-		if err := u.setLock(ctx); err != nil {
-			return err
-		}
+		return u.setLock(ctx)
 	}
 	slogger.Log.Debug("Other process is running", slog.String("id", u.id), slog.Time("expire", lock.Expire))
 
@@ -271,18 +418,32 @@ func (u *Updater) acquireLock(ctx context.Context) error {
 			return ctx.Err()
 		case <-t.C:
 			lock, err := u.getLock(ctx)
-			if err != nil {
+			if err != nil && !errors.Is(err, storage.ErrObjectNotFound) {
 				continue
 			}
-			if time.Now().After(lock.Expire) {
-				if err := u.setLock(ctx); err != nil {
-					return err
-				}
+			if lock == nil || time.Now().After(lock.Expire) {
+				return u.setLock(ctx)
 			}
 		}
 	}
 }
 
+// tryLock gets the lock if no other process has it. Unlike acquireLock, it doesn't wait for the lock.
+func (u *Updater) tryLock(ctx context.Context) error {
+	if u.lockFilePath == "" {
+		return nil
+	}
+
+	lock, err := u.getLock(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotFound) {
+		return xerrors.WithStack(err)
+	}
+	if lock != nil && lock.Id != u.id && time.Now().Before(lock.Expire) {
+		return xerrors.WithMessagef(ErrLockHeld.WithStack(), "%s holds the lock until %s", lock.Id, lock.Expire.Format(time.RFC3339))
+	}
+	return u.setLock(ctx)
+}
+
 type updaterLock struct {
 	Id     string
 	Expire time.Time
@@ -321,12 +482,35 @@ func (u *Updater) setLock(ctx context.Context) error {
 			if err := u.setLock(ctx); err != nil {
 				return
 			}
+		case <-ctx.Done():
 		}
 	}()
 
 	return nil
 }
 
+// releaseLock deletes the lock file if this process has it.
+func (u *Updater) releaseLock(ctx context.Context) error {
+	if u.lockFilePath == "" {
+		return nil
+	}
+	lock, err := u.getLock(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil
+		}
+		return xerrors.WithStack(err)
+	}
+	if lock.Id != u.id {
+		return nil
+	}
+	if err := u.storageClient.Delete(ctx, u.lockFilePath); err != nil {
+		return xerrors.WithStack(err)
+	}
+	slogger.Log.Debug("Released lock", slog.String("id", u.id))
+	return nil
+}
+
 func (u *Updater) update(ctx context.Context) {
 	u.mu.Lock()
 	repos := append([]*RepositoryConfig(nil), u.repo...)
@@ -336,14 +520,14 @@ func (u *Updater) update(ctx context.Context) {
 	doneCh := make(chan struct{})
 	for _, v := range repos {
 		slogger.Log.Info("Updating repository", slog.String("repo", v.Name))
-		go func(repo *git.Repository) {
+		go func(repo *RepositoryConfig) {
 			sem <- struct{}{}
 			defer func() { <-sem }()
 
 			_ = u.updateRepo(ctx, repo)
 
 			doneCh <- struct{}{}
-		}(v.goGit)
+		}(v)
 	}
 
 	done := 0
@@ -355,20 +539,23 @@ func (u *Updater) update(ctx context.Context) {
 	}
 }
 
-func (u *Updater) updateRepo(ctx context.Context, repo *git.Repository) error {
+func (u *Updater) updateRepo(ctx context.Context, conf *RepositoryConfig) error {
+	repo := conf.goGit
+	defer u.lockRepo(repo)()
 	timeoutCtx, stop := ctxutil.WithTimeout(ctx, u.timeout)
 	defer stop()
 
-	var auth *gitHttp.BasicAuth
-	if u.tokenProvider != nil {
-		if v, err := u.tokenProvider.Token(ctx); err == nil {
-			auth = &gitHttp.BasicAuth{
-				Username: "octocat",
-				Password: v,
-			}
-		}
+	auth, err := conf.authMethod(ctx, u.tokenProvider)
+	if err != nil {
+		slogger.Log.Warn("Failed to get the credential", slog.String("repo", conf.Name), slogger.E(err))
+		return err
 	}
-	err := repo.FetchContext(timeoutCtx, &git.FetchOptions{
+	// The failure of the snapshot doesn't fail the update. The updates of the references are not recorded instead.
+	before, err := referenceSnapshot(repo)
+	if err != nil {
+		slogger.Log.Warn("Failed to get the references before fetching", slog.String("repo", conf.Name), slogger.E(err))
+	}
+	err = repo.FetchContext(timeoutCtx, &git.FetchOptions{
 		Auth:       auth,
 		RemoteName: upstreamRemoteName,
 	})
@@ -415,5 +602,19 @@ func (u *Updater) updateRepo(ctx context.Context, repo *git.Repository) error {
 			return xerrors.WithStack(err)
 		}
 	}
+
+	// ListCommits works without the commit-graph, so the failure doesn't fail the update.
+	if err := UpdateCommitGraph(repo); err != nil {
+		slogger.Log.Warn("Failed to update commit-graph", slogger.E(err))
+	}
+
+	if u.dataService != nil && before != nil {
+		after, err := referenceSnapshot(repo)
+		if err != nil {
+			slogger.Log.Warn("Failed to get the references after fetching", slog.String("repo", conf.Name), slogger.E(err))
+			return nil
+		}
+		u.dataService.recordReferenceUpdates(ctx, diffReferences(conf.Name, before, after))
+	}
 	return nil
 }
@@ -1,8 +1,17 @@
 package git
 
 import (
+	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 	"time"
@@ -10,10 +19,16 @@ import (
 	goGit "github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	gitHttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/storage/filesystem"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
 
+	"go.f110.dev/mono/go/githubutil"
 	"go.f110.dev/mono/go/logger"
 	"go.f110.dev/mono/go/logger/slogger"
 	"go.f110.dev/mono/go/storage"
@@ -44,7 +59,7 @@ func TestUpdater_UpdateRepo(t *testing.T) {
 	repo, err := goGit.Open(s, nil)
 	require.NoError(t, err)
 
-	updater.updateRepo(context.Background(), repo)
+	updater.updateRepo(context.Background(), &RepositoryConfig{Name: "test", URL: repoPath, goGit: repo})
 
 	n, err := repo.Reference(plumbing.NewBranchReferenceName("foobar"), false)
 	require.NoError(t, err)
@@ -55,6 +70,329 @@ func TestUpdater_UpdateRepo(t *testing.T) {
 	assert.Equal(t, masterRef.Hash(), n.Hash())
 }
 
+func TestUpdater_Maintain(t *testing.T) {
+	logger.SetLogLevel("debug")
+	logger.Init()
+	slogger.Init()
+
+	mockStorage := storage.NewMock()
+	running, err := NewUpdater(mockStorage, nil, nil, "lock", 1)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, running.acquireLock(ctx))
+
+	// The maintenance fails without waiting while the running updater holds the lock.
+	updater, err := NewUpdater(mockStorage, nil, nil, "lock", 1)
+	require.NoError(t, err)
+	err = updater.Maintain(context.Background())
+	assert.ErrorIs(t, err, ErrLockHeld)
+
+	require.NoError(t, running.releaseLock(context.Background()))
+	require.NoError(t, updater.Maintain(context.Background()))
+	_, err = mockStorage.Get(context.Background(), "lock")
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+}
+
+func TestUpdater_Credential(t *testing.T) {
+	logger.SetLogLevel("debug")
+	logger.Init()
+	slogger.Init()
+
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		t.Skip("git is not found")
+	}
+	sourceRepo := makeUpdaterSourceRepository(t)
+	repoPath := sourceRepo.Storer.(*filesystem.Storage).Filesystem().Root()
+	// git http-backend serves the repository like Gitea or GitLab.
+	backend := &cgi.Handler{
+		Path: gitPath,
+		Args: []string{"http-backend"},
+		Env:  []string{"GIT_PROJECT_ROOT=" + filepath.Dir(repoPath), "GIT_HTTP_EXPORT_ALL=1"},
+	}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if user, pass, ok := req.BasicAuth(); !ok || user != "oauth2" || pass != "token" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="git"`)
+			http.Error(w, "", http.StatusUnauthorized)
+			return
+		}
+		backend.ServeHTTP(w, req)
+	}))
+	t.Cleanup(s.Close)
+	repoURL := s.URL + "/.git"
+
+	t.Run("InvalidCredential", func(t *testing.T) {
+		auth, err := (&TokenCredential{Username: "oauth2", Token: "invalid"}).AuthMethod(context.Background())
+		require.NoError(t, err)
+		_, err = InitObjectStorageRepository(context.Background(), storage.NewMock(), repoURL, "test", auth)
+		assert.Error(t, err)
+	})
+
+	credentialFile := filepath.Join(t.TempDir(), "credential")
+	require.NoError(t, os.WriteFile(credentialFile, []byte("oauth2:token\n"), 0600))
+	conf := &RepositoryConfig{Name: "test", URL: repoURL, Prefix: "test", Credential: &BasicAuthFileCredential{Path: credentialFile}}
+	auth, err := conf.authMethod(context.Background(), nil)
+	require.NoError(t, err)
+	mockStorage := storage.NewMock()
+	_, err = InitObjectStorageRepository(context.Background(), mockStorage, repoURL, "test", auth)
+	require.NoError(t, err)
+
+	wt, err := sourceRepo.Worktree()
+	require.NoError(t, err)
+	head, err := wt.Commit("Second", &goGit.CommitOptions{
+		Author:            &object.Signature{Name: t.Name(), When: time.Now(), Email: "test@localhost"},
+		AllowEmptyCommits: true,
+	})
+	require.NoError(t, err)
+
+	conf.goGit, err = goGit.Open(NewObjectStorageStorer(mockStorage, "test", nil, nil), nil)
+	require.NoError(t, err)
+	updater, err := NewUpdater(nil, nil, nil, "", 1)
+	require.NoError(t, err)
+	require.NoError(t, updater.updateRepo(context.Background(), conf))
+	ref, err := conf.goGit.Reference(plumbing.NewBranchReferenceName("master"), false)
+	require.NoError(t, err)
+	assert.Equal(t, head, ref.Hash())
+}
+
+func TestRepositoryConfig_AuthMethod(t *testing.T) {
+	slogger.Init()
+	t.Setenv("GITHUB_TOKEN", "")
+	github := githubutil.NewGitHubClientFactory("test", false)
+	github.Token = "secret"
+	require.NoError(t, github.Init())
+
+	cases := []struct {
+		Name       string
+		URL        string
+		Credential CredentialProvider
+		Token      string
+	}{
+		{Name: "GitHub", URL: "https://github.com/f110/mono.git", Token: "secret"},
+		{Name: "GitHubSSH", URL: "git@github.com:f110/mono.git", Token: "secret"},
+		{Name: "Gitea", URL: "https://gitea.example.com/f110/mono.git"},
+		{Name: "GitLabSSH", URL: "git@gitlab.example.com:f110/mono.git"},
+		{Name: "GitHubEnterprise", URL: "https://ghe.example.com/f110/mono.git", Credential: &GitHubAppCredential{}, Token: "secret"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			conf := &RepositoryConfig{Name: "test", URL: tc.URL, Credential: tc.Credential}
+			auth, err := conf.authMethod(context.Background(), github.TokenProvider)
+			require.NoError(t, err)
+			if tc.Token == "" {
+				assert.Nil(t, auth)
+				return
+			}
+			if assert.IsType(t, &gitHttp.BasicAuth{}, auth) {
+				assert.Equal(t, tc.Token, auth.(*gitHttp.BasicAuth).Password)
+			}
+		})
+	}
+}
+
+func TestUpdater_WatchReferences(t *testing.T) {
+	logger.SetLogLevel("debug")
+	logger.Init()
+	slogger.Init()
+
+	sourceRepo := makeUpdaterSourceRepository(t)
+	mockStorage := storage.NewMock()
+	repoPath := sourceRepo.Storer.(*filesystem.Storage).Filesystem().Root()
+	_, err := InitObjectStorageRepository(context.Background(), mockStorage, repoPath, "test", nil)
+	require.NoError(t, err)
+	repo, err := goGit.Open(NewObjectStorageStorer(mockStorage, "test", nil, nil), nil)
+	require.NoError(t, err)
+	oldHead, err := repo.Reference(plumbing.NewBranchReferenceName("master"), false)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	newDataService := func(t *testing.T) (*DataService, GitDataClient) {
+		svc, err := NewDataServiceWithGoGit(map[string]*goGit.Repository{"test": repo})
+		require.NoError(t, err)
+		require.NoError(t, svc.LoadReferenceJournal(ctx, mockStorage))
+
+		lis := bufconn.Listen(1024 * 1024)
+		s := grpc.NewServer()
+		RegisterGitDataServer(s, svc)
+		go s.Serve(lis)
+		t.Cleanup(s.Stop)
+		conn, err := grpc.Dial("bufnet", grpc.WithContextDialer(func(_ context.Context, _ string) (net.Conn, error) {
+			return lis.Dial()
+		}), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		return svc, NewGitDataClient(conn)
+	}
+
+	svc, client := newDataService(t)
+	updater, err := NewUpdater(nil, nil, nil, "", 1)
+	require.NoError(t, err)
+	updater.SetDataService(svc)
+
+	stream, err := client.WatchReferences(ctx, &RequestWatchReferences{Repos: []string{"test"}})
+	require.NoError(t, err)
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Empty(t, res.Events)
+	assert.False(t, res.Resync)
+	firstCursor := res.Cursor
+
+	// The replica doesn't run the Updater. The events are sent after reading the journal.
+	replica, replicaClient := newDataService(t)
+	go replica.SyncReferenceJournal(ctx, 10*time.Millisecond)
+	replicaStream, err := replicaClient.WatchReferences(ctx, &RequestWatchReferences{Cursor: firstCursor})
+	require.NoError(t, err)
+	replicaRes, err := replicaStream.Recv()
+	require.NoError(t, err)
+	assert.Empty(t, replicaRes.Events)
+	assert.False(t, replicaRes.Resync)
+	assert.Equal(t, firstCursor, replicaRes.Cursor)
+
+	wt, err := sourceRepo.Worktree()
+	require.NoError(t, err)
+	newHead, err := wt.Commit("Second", &goGit.CommitOptions{
+		Author:            &object.Signature{Name: t.Name(), When: time.Now(), Email: "test@localhost"},
+		AllowEmptyCommits: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, updater.updateRepo(context.Background(), &RepositoryConfig{Name: "test", URL: repoPath, goGit: repo}))
+
+	expect := []*ReferenceEvent{{Repo: "test", Ref: "refs/heads/master", OldSha: oldHead.Hash().String(), NewSha: newHead.String()}}
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	assertReferenceEvents(t, expect, res.Events)
+	assert.NotEqual(t, firstCursor, res.Cursor)
+
+	t.Run("Replica", func(t *testing.T) {
+		replicaRes, err := replicaStream.Recv()
+		require.NoError(t, err)
+		assertReferenceEvents(t, expect, replicaRes.Events)
+		assert.Equal(t, res.Cursor, replicaRes.Cursor)
+	})
+
+	t.Run("Restart", func(t *testing.T) {
+		_, client := newDataService(t)
+		stream, err := client.WatchReferences(ctx, &RequestWatchReferences{Cursor: firstCursor})
+		require.NoError(t, err)
+		resumed, err := stream.Recv()
+		require.NoError(t, err)
+		assert.False(t, resumed.Resync)
+		assertReferenceEvents(t, expect, resumed.Events)
+		assert.Equal(t, res.Cursor, resumed.Cursor)
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		// The journal is downloaded only when it was written after the last load.
+		counter := &countingBackend{Backend: mockStorage, count: make(map[string]int)}
+		svc, err := NewDataServiceWithGoGit(map[string]*goGit.Repository{"test": repo})
+		require.NoError(t, err)
+		require.NoError(t, svc.LoadReferenceJournal(ctx, counter))
+		assert.Equal(t, 1, counter.getCount(referenceJournalObjectName))
+		require.NoError(t, svc.journal.Load(ctx))
+		assert.Equal(t, 1, counter.getCount(referenceJournalObjectName))
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		stream, err := client.WatchReferences(ctx, &RequestWatchReferences{Cursor: firstCursor})
+		require.NoError(t, err)
+		resumed, err := stream.Recv()
+		require.NoError(t, err)
+		assertReferenceEvents(t, expect, resumed.Events)
+		assert.Equal(t, res.Cursor, resumed.Cursor)
+	})
+
+	t.Run("UnknownCursor", func(t *testing.T) {
+		stream, err := client.WatchReferences(ctx, &RequestWatchReferences{Cursor: "unknown:1"})
+		require.NoError(t, err)
+		res, err := stream.Recv()
+		require.NoError(t, err)
+		assert.True(t, res.Resync)
+		assert.Empty(t, res.Events)
+	})
+}
+
+func assertReferenceEvents(t *testing.T, expect, actual []*ReferenceEvent) {
+	t.Helper()
+	require.Len(t, actual, len(expect))
+	for i := range expect {
+		assert.True(t, proto.Equal(expect[i], actual[i]), "expected %v, got %v", expect[i], actual[i])
+	}
+}
+
+func TestUpdater_ServeHTTP(t *testing.T) {
+	logger.SetLogLevel("debug")
+	logger.Init()
+	slogger.Init()
+
+	updater, err := NewUpdater(nil, nil, nil, "", 1)
+	require.NoError(t, err)
+	updater.AddWebhookSecret("gitea.example.com", WebhookProviderGitea, []byte("gitea-secret")).
+		AddWebhookSecret("gitlab.example.com", WebhookProviderGitLab, []byte("gitlab-secret"))
+
+	giteaPayload := []byte(`{"repository":{"clone_url":"https://gitea.example.com/f110/mono.git","ssh_url":"git@gitea.example.com:f110/mono.git"}}`)
+	mac := hmac.New(sha256.New, []byte("gitea-secret"))
+	mac.Write(giteaPayload)
+	giteaSignature := hex.EncodeToString(mac.Sum(nil))
+	gitlabPayload := []byte(`{"object_kind":"push","project":{"git_http_url":"https://gitlab.example.com/f110/mono.git","git_ssh_url":"git@gitlab.example.com:f110/mono.git"}}`)
+
+	cases := []struct {
+		Name    string
+		Header  map[string]string
+		Payload []byte
+		Status  int
+	}{
+		{
+			Name:    "Gitea",
+			Header:  map[string]string{"X-Gitea-Event": "push", "X-Gitea-Signature": giteaSignature},
+			Payload: giteaPayload,
+			Status:  http.StatusOK,
+		},
+		{
+			Name:    "GiteaInvalidSignature",
+			Header:  map[string]string{"X-Gitea-Event": "push", "X-Gitea-Signature": hex.EncodeToString([]byte("invalid"))},
+			Payload: giteaPayload,
+			Status:  http.StatusUnauthorized,
+		},
+		{
+			Name:    "GitLab",
+			Header:  map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": "gitlab-secret"},
+			Payload: gitlabPayload,
+			Status:  http.StatusOK,
+		},
+		{
+			Name:    "GitLabInvalidToken",
+			Header:  map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": "gitea-secret"},
+			Payload: gitlabPayload,
+			Status:  http.StatusUnauthorized,
+		},
+		{
+			// The payload of Gitea is sent as GitLab's one with the secret of GitLab.
+			Name:    "ProviderMismatch",
+			Header:  map[string]string{"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": "gitlab-secret"},
+			Payload: []byte(`{"project":{"git_http_url":"https://gitea.example.com/f110/mono.git"}}`),
+			Status:  http.StatusUnauthorized,
+		},
+		{
+			Name:    "NoSecret",
+			Header:  map[string]string{"X-Gitea-Event": "push"},
+			Payload: []byte(`{"repository":{"clone_url":"https://git.example.com/f110/mono.git"}}`),
+			Status:  http.StatusOK,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tc.Payload))
+			for k, v := range tc.Header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			updater.ServeHTTP(rec, req)
+			assert.Equal(t, tc.Status, rec.Code)
+		})
+	}
+}
+
 func makeUpdaterSourceRepository(t *testing.T) *goGit.Repository {
 	repoDir := t.TempDir()
 	repo, err := goGit.PlainInit(repoDir, false)
@@ -0,0 +1,170 @@
+package git
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/v85/github"
+	"go.f110.dev/xerrors"
+)
+
+type WebhookProvider string
+
+const (
+	WebhookProviderGitHub WebhookProvider = "github"
+	WebhookProviderGitea  WebhookProvider = "gitea"
+	WebhookProviderGitLab WebhookProvider = "gitlab"
+)
+
+const (
+	giteaEventHeader     = "X-Gitea-Event"
+	gogsEventHeader      = "X-Gogs-Event"
+	giteaSignatureHeader = "X-Gitea-Signature"
+	gitlabEventHeader    = "X-Gitlab-Event"
+	gitlabTokenHeader    = "X-Gitlab-Token"
+)
+
+type webhookSecret struct {
+	Provider WebhookProvider
+	Secret   []byte
+}
+
+type webhookPushEvent struct {
+	Provider WebhookProvider
+	// URLs is the URLs of the pushed repository. The repository is looked up in this order.
+	URLs []string
+}
+
+type giteaPushPayload struct {
+	Repository struct {
+		CloneURL string `json:"clone_url"`
+		SSHURL   string `json:"ssh_url"`
+	} `json:"repository"`
+}
+
+type gitlabPushPayload struct {
+	Project struct {
+		GitHTTPURL string `json:"git_http_url"`
+		GitSSHURL  string `json:"git_ssh_url"`
+	} `json:"project"`
+}
+
+// parseWebhookPushEvent returns the push event in the request. It returns nil if the request
+// is not a push event.
+// Gitea also sends the headers of GitHub for the compatibility, so the headers of Gitea are checked first.
+func parseWebhookPushEvent(req *http.Request, payload []byte) (*webhookPushEvent, error) {
+	event := req.Header.Get(giteaEventHeader)
+	if event == "" {
+		event = req.Header.Get(gogsEventHeader)
+	}
+	if event != "" {
+		if event != "push" {
+			return nil, nil
+		}
+		p := &giteaPushPayload{}
+		if err := json.Unmarshal(payload, p); err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		return &webhookPushEvent{Provider: WebhookProviderGitea, URLs: nonEmpty(p.Repository.CloneURL, p.Repository.SSHURL)}, nil
+	}
+	if event := req.Header.Get(gitlabEventHeader); event != "" {
+		if event != "Push Hook" && event != "Tag Push Hook" {
+			return nil, nil
+		}
+		p := &gitlabPushPayload{}
+		if err := json.Unmarshal(payload, p); err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		return &webhookPushEvent{Provider: WebhookProviderGitLab, URLs: nonEmpty(p.Project.GitHTTPURL, p.Project.GitSSHURL)}, nil
+	}
+
+	e, err := github.ParseWebHook(github.WebHookType(req), payload)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	push, ok := e.(*github.PushEvent)
+	if !ok {
+		return nil, nil
+	}
+	return &webhookPushEvent{
+		Provider: WebhookProviderGitHub,
+		URLs:     nonEmpty(push.Repo.GetGitURL(), push.Repo.GetCloneURL(), push.Repo.GetSSHURL()),
+	}, nil
+}
+
+// verifyWebhook verifies the request with the secrets of the hosts of all URLs in the event.
+// All hosts are checked because any URL may match the repository.
+func (u *Updater) verifyWebhook(req *http.Request, payload []byte, event *webhookPushEvent) error {
+	for _, v := range event.URLs {
+		host := repositoryHost(v)
+		secret, ok := u.webhookSecrets[host]
+		if !ok {
+			continue
+		}
+		if secret.Provider != event.Provider {
+			return xerrors.Definef("the webhook of %s must be sent by %s", host, secret.Provider).WithStack()
+		}
+
+		switch event.Provider {
+		case WebhookProviderGitHub:
+			signature := req.Header.Get(github.SHA256SignatureHeader)
+			if signature == "" {
+				signature = req.Header.Get(github.SHA1SignatureHeader)
+			}
+			if err := github.ValidateSignature(signature, payload, secret.Secret); err != nil {
+				return xerrors.WithStack(err)
+			}
+		case WebhookProviderGitea:
+			signature, err := hex.DecodeString(req.Header.Get(giteaSignatureHeader))
+			if err != nil {
+				return xerrors.WithStack(err)
+			}
+			mac := hmac.New(sha256.New, secret.Secret)
+			mac.Write(payload)
+			if !hmac.Equal(signature, mac.Sum(nil)) {
+				return xerrors.Define("signature mismatch").WithStack()
+			}
+		case WebhookProviderGitLab:
+			if subtle.ConstantTimeCompare([]byte(req.Header.Get(gitlabTokenHeader)), secret.Secret) != 1 {
+				return xerrors.Define("token mismatch").WithStack()
+			}
+		}
+	}
+	return nil
+}
+
+// repositoryHost returns the lower-cased hostname of the repository URL. The scp-like
+// syntax (e.g. git@example.com:owner/repo.git) is also accepted.
+func repositoryHost(rawURL string) string {
+	if !strings.Contains(rawURL, "://") {
+		h, _, ok := strings.Cut(rawURL, ":")
+		if !ok {
+			return ""
+		}
+		if i := strings.LastIndex(h, "@"); i >= 0 {
+			h = h[i+1:]
+		}
+		return strings.ToLower(h)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+func nonEmpty(v ...string) []string {
+	var s []string
+	for _, x := range v {
+		if x != "" {
+			s = append(s, x)
+		}
+	}
+	return s
+}
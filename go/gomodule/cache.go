@@ -3,6 +3,7 @@ package gomodule
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -24,7 +25,7 @@ var CacheMiss = xerrors.Define("gomodule: cache hit miss")
 
 type ModuleCache struct {
 	cachePool     *client.SinglePool
-	objectStorage *storage.S3
+	objectStorage storage.Backend
 }
 
 func NewModuleCache(cachePool *client.SinglePool, endpoint, region, bucket, accessKey, secretAccessKey, caCertFile string) *ModuleCache {
@@ -331,6 +332,76 @@ func (c *ModuleCache) SaveArchive(ctx context.Context, module, version string, d
 	return nil
 }
 
+// GetMirror returns the file of the upstream which is stored by SaveMirror. The caller must close the returned reader.
+func (c *ModuleCache) GetMirror(ctx context.Context, path string) (io.ReadCloser, error) {
+	if c == nil || c.objectStorage == nil {
+		return nil, CacheMiss
+	}
+
+	obj, err := c.objectStorage.Get(ctx, mirrorObjectName(path))
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return nil, CacheMiss
+	} else if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	return obj.Body, nil
+}
+
+func (c *ModuleCache) SaveMirror(ctx context.Context, path string, r io.Reader) error {
+	if c == nil || c.objectStorage == nil {
+		return nil
+	}
+
+	if err := c.objectStorage.PutReader(ctx, mirrorObjectName(path), r); err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	return nil
+}
+
+// mirrorObjectName returns the name of the object. path is the path of the module proxy protocol.
+// (e.g. /github.com/!f110/mono/@v/v1.0.0.zip)
+func mirrorObjectName(path string) string {
+	return "mirror" + path
+}
+
+// GetSumDB returns the cached response of the checksum database.
+func (c *ModuleCache) GetSumDB(path string) ([]byte, error) {
+	if c == nil {
+		return nil, CacheMiss
+	}
+
+	item, err := c.cachePool.Get(sumDBCacheKey(path))
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	return item.Value, nil
+}
+
+func (c *ModuleCache) SetSumDB(path string, data []byte, expiration int) error {
+	if c == nil {
+		return nil
+	}
+
+	err := c.cachePool.Set(&client.Item{
+		Key:        sumDBCacheKey(path),
+		Value:      data,
+		Expiration: expiration,
+	})
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	return nil
+}
+
+// sumDBCacheKey returns the key of the cache. The path may be longer than the limit of the key.
+func sumDBCacheKey(path string) string {
+	return fmt.Sprintf("sumdb/%x", sha256.Sum256([]byte(path)))
+}
+
 func (c *ModuleCache) Ping() error {
 	_, err := c.cachePool.Version()
 	if err != nil {
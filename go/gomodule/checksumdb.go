@@ -0,0 +1,380 @@
+package gomodule
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"path"
+	"slices"
+	"strconv"
+	"sync"
+
+	"go.f110.dev/xerrors"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb"
+	"golang.org/x/mod/sumdb/note"
+	"golang.org/x/mod/sumdb/tlog"
+
+	"go.f110.dev/mono/go/logger/slogger"
+	"go.f110.dev/mono/go/storage"
+)
+
+// GoSumSource returns the lines of go.sum for the module version.
+// If the source doesn't know the module, GoSum returns an error which wraps fs.ErrNotExist.
+type GoSumSource interface {
+	GoSum(ctx context.Context, path, version string) ([]byte, error)
+}
+
+// ChecksumDatabase is the checksum database which is served at /sumdb/<name>.
+// The tree is signed by the configured key, so go command can verify the module by GOSUMDB.
+// All records and the tree are persisted to the object storage. The tree is updated by the conditional write,
+// so the multiple replicas can share one storage.
+type ChecksumDatabase struct {
+	signer  note.Signer
+	backend storage.Backend
+	prefix  string
+
+	// addMu serializes the writes in this process.
+	addMu sync.Mutex
+
+	mu      sync.Mutex
+	sources []GoSumSource
+	tree    *checksumTree
+	records map[int64][]byte
+}
+
+var _ sumdb.ServerOps = &ChecksumDatabase{}
+
+func NewChecksumDatabase(signer note.Signer, backend storage.Backend) *ChecksumDatabase {
+	return &ChecksumDatabase{
+		signer:  signer,
+		backend: backend,
+		prefix:  path.Join("sumdb", signer.Name()),
+		records: make(map[int64][]byte),
+	}
+}
+
+func (db *ChecksumDatabase) Name() string {
+	return db.signer.Name()
+}
+
+// AddSource adds the source of the record. When the record of the module is not found, the sources are
+// asked in the order of addition.
+func (db *ChecksumDatabase) AddSource(s GoSumSource) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.sources = append(db.sources, s)
+}
+
+func (db *ChecksumDatabase) Signed(ctx context.Context) ([]byte, error) {
+	tree, err := db.loadTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	h, err := tlog.TreeHash(tree.N, tree)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	signed, err := note.Sign(&note.Note{Text: string(tlog.FormatTree(tlog.Tree{N: tree.N, Hash: h}))}, db.signer)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	return signed, nil
+}
+
+func (db *ChecksumDatabase) ReadRecords(ctx context.Context, id, n int64) ([][]byte, error) {
+	tree, err := db.loadTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if id < 0 || id+n > tree.N {
+		return nil, &fs.PathError{Op: "read", Path: fmt.Sprintf("records/%d", id+n-1), Err: fs.ErrNotExist}
+	}
+
+	records := make([][]byte, 0, n)
+	for i := id; i < id+n; i++ {
+		data, err := db.readRecord(ctx, tree, i)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, data)
+	}
+	return records, nil
+}
+
+func (db *ChecksumDatabase) Lookup(ctx context.Context, m module.Version) (int64, error) {
+	if id, err := db.lookupID(ctx, m); err == nil {
+		return id, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return 0, err
+	}
+
+	db.mu.Lock()
+	sources := db.sources
+	db.mu.Unlock()
+	for _, s := range sources {
+		data, err := s.GoSum(ctx, m.Path, m.Version)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return 0, err
+		}
+
+		id, _, err := db.add(ctx, m, data)
+		return id, err
+	}
+	// sumdb.Server returns 404 only if os.IsNotExist is satisfied. The error must not be wrapped.
+	return 0, &fs.PathError{Op: "lookup", Path: m.String(), Err: fs.ErrNotExist}
+}
+
+func (db *ChecksumDatabase) ReadTileData(ctx context.Context, t tlog.Tile) ([]byte, error) {
+	tree, err := db.loadTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return tlog.ReadTileData(t, tree)
+}
+
+// Record returns the record of the module. If the module is not recorded yet, Record returns
+// an error which wraps fs.ErrNotExist.
+func (db *ChecksumDatabase) Record(ctx context.Context, m module.Version) ([]byte, error) {
+	id, err := db.lookupID(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := db.loadTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return db.readRecord(ctx, tree, id)
+}
+
+// Add records data as the record of the module. If the module is recorded already, Add returns the record
+// which is recorded first instead of data.
+func (db *ChecksumDatabase) Add(ctx context.Context, m module.Version, data []byte) ([]byte, error) {
+	_, record, err := db.add(ctx, m, data)
+	return record, err
+}
+
+func (db *ChecksumDatabase) add(ctx context.Context, m module.Version, data []byte) (int64, []byte, error) {
+	if _, err := tlog.FormatRecord(0, data); err != nil {
+		return 0, nil, xerrors.WithStack(err)
+	}
+
+	db.addMu.Lock()
+	defer db.addMu.Unlock()
+
+	for range 10 {
+		if id, err := db.lookupID(ctx, m); err == nil {
+			tree, err := db.loadTree(ctx)
+			if err != nil {
+				return 0, nil, err
+			}
+			record, err := db.readRecord(ctx, tree, id)
+			return id, record, err
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return 0, nil, err
+		}
+
+		tree, err := db.loadTree(ctx)
+		if err != nil {
+			return 0, nil, err
+		}
+		id := tree.N
+		recordHash := tlog.RecordHash(data)
+		// The name of the record contains the hash. Even if the other replica writes the record of the same id
+		// at the same time, the record which is committed by the tree is never overwritten.
+		if err := db.backend.Put(ctx, db.recordName(id, recordHash), data); err != nil {
+			return 0, nil, xerrors.WithStack(err)
+		}
+		hashes, err := tlog.StoredHashesForRecordHash(id, recordHash, tree)
+		if err != nil {
+			return 0, nil, xerrors.WithStack(err)
+		}
+		newTree := &checksumTree{N: id + 1, Hashes: append(tree.Hashes[:len(tree.Hashes):len(tree.Hashes)], hashes...)}
+		cond := storage.PutCondition{IfMatch: tree.etag}
+		if tree.etag == "" {
+			cond = storage.PutCondition{IfNoneMatch: true}
+		}
+		if _, err := db.backend.PutIf(ctx, path.Join(db.prefix, "tree"), newTree.encode(), cond); err != nil {
+			if errors.Is(err, storage.ErrPreconditionFailed) {
+				slogger.Log.Debug("The tree of the checksum database was updated concurrently", slog.String("module", m.String()))
+				continue
+			}
+			return 0, nil, xerrors.WithStack(err)
+		}
+
+		// If the other replica recorded the same module at the same time, the first index wins.
+		// The record which is committed later remains in the tree but it is never referred.
+		_, err = db.backend.PutIf(ctx, db.lookupName(m), []byte(strconv.FormatInt(id, 10)), storage.PutCondition{IfNoneMatch: true})
+		if errors.Is(err, storage.ErrPreconditionFailed) {
+			continue
+		} else if err != nil {
+			return 0, nil, xerrors.WithStack(err)
+		}
+		slogger.Log.Info("Add the record to the checksum database", slog.String("module", m.String()), slog.Int64("id", id))
+		return id, data, nil
+	}
+
+	return 0, nil, xerrors.Definef("failed to add %s to the checksum database: too many conflicts", m.String()).WithStack()
+}
+
+func (db *ChecksumDatabase) lookupID(ctx context.Context, m module.Version) (int64, error) {
+	obj, err := db.backend.Get(ctx, db.lookupName(m))
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return 0, &fs.PathError{Op: "lookup", Path: m.String(), Err: fs.ErrNotExist}
+		}
+		return 0, xerrors.WithStack(err)
+	}
+	defer obj.Body.Close()
+	buf, err := io.ReadAll(obj.Body)
+	if err != nil {
+		return 0, xerrors.WithStack(err)
+	}
+	id, err := strconv.ParseInt(string(buf), 10, 64)
+	if err != nil {
+		return 0, xerrors.WithStack(err)
+	}
+	return id, nil
+}
+
+func (db *ChecksumDatabase) readRecord(ctx context.Context, tree *checksumTree, id int64) ([]byte, error) {
+	db.mu.Lock()
+	data, ok := db.records[id]
+	db.mu.Unlock()
+	if ok {
+		return data, nil
+	}
+
+	// The leaf of the tree is the hash of the record.
+	hashes, err := tree.ReadHashes([]int64{tlog.StoredHashIndex(0, id)})
+	if err != nil {
+		return nil, err
+	}
+	obj, err := db.backend.Get(ctx, db.recordName(id, hashes[0]))
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	defer obj.Body.Close()
+	data, err = io.ReadAll(obj.Body)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	if tlog.RecordHash(data) != hashes[0] {
+		return nil, xerrors.Definef("the record %d of the checksum database is broken", id).WithStack()
+	}
+
+	db.mu.Lock()
+	db.records[id] = data
+	db.mu.Unlock()
+	return data, nil
+}
+
+// loadTree returns the latest tree. The tree is read from the storage only if it is changed.
+func (db *ChecksumDatabase) loadTree(ctx context.Context) (*checksumTree, error) {
+	name := path.Join(db.prefix, "tree")
+	stat, err := db.backend.Stat(ctx, name)
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		return &checksumTree{}, nil
+	} else if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	db.mu.Lock()
+	cached := db.tree
+	db.mu.Unlock()
+	if cached != nil && cached.etag == stat.ETag {
+		return cached, nil
+	}
+
+	obj, err := db.backend.Get(ctx, name)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	defer obj.Body.Close()
+	buf, err := io.ReadAll(obj.Body)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	tree, err := decodeChecksumTree(buf)
+	if err != nil {
+		return nil, err
+	}
+	tree.etag = obj.ETag
+
+	db.mu.Lock()
+	if db.tree == nil || db.tree.N <= tree.N {
+		db.tree = tree
+	}
+	db.mu.Unlock()
+	return tree, nil
+}
+
+func (db *ChecksumDatabase) recordName(id int64, h tlog.Hash) string {
+	return path.Join(db.prefix, "records", strconv.FormatInt(id, 10), hex.EncodeToString(h[:]))
+}
+
+func (db *ChecksumDatabase) lookupName(m module.Version) string {
+	// The escaped path doesn't have upper case letters, so the name is safe on the case-insensitive filesystem.
+	p, err := module.EscapePath(m.Path)
+	if err != nil {
+		p = m.Path
+	}
+	v, err := module.EscapeVersion(m.Version)
+	if err != nil {
+		v = m.Version
+	}
+	return path.Join(db.prefix, "lookup", p+"@"+v)
+}
+
+// checksumTree is the stored hashes of the tree which has N records.
+type checksumTree struct {
+	N      int64
+	Hashes []tlog.Hash
+
+	etag string
+}
+
+var _ tlog.HashReader = &checksumTree{}
+
+func (t *checksumTree) ReadHashes(indexes []int64) ([]tlog.Hash, error) {
+	hashes := make([]tlog.Hash, 0, len(indexes))
+	for _, v := range indexes {
+		if v < 0 || v >= int64(len(t.Hashes)) {
+			return nil, &fs.PathError{Op: "read", Path: fmt.Sprintf("hash/%d", v), Err: fs.ErrNotExist}
+		}
+		hashes = append(hashes, t.Hashes[v])
+	}
+	return hashes, nil
+}
+
+// encode returns the binary representation of the tree. The first 8 bytes are the number of the records,
+// and the stored hashes follow it.
+func (t *checksumTree) encode() []byte {
+	buf := make([]byte, 8, 8+len(t.Hashes)*tlog.HashSize)
+	binary.BigEndian.PutUint64(buf, uint64(t.N))
+	for _, v := range t.Hashes {
+		buf = append(buf, v[:]...)
+	}
+	return buf
+}
+
+func decodeChecksumTree(buf []byte) (*checksumTree, error) {
+	if len(buf) < 8 || (len(buf)-8)%tlog.HashSize != 0 {
+		return nil, xerrors.Define("malformed tree of the checksum database").WithStack()
+	}
+	t := &checksumTree{N: int64(binary.BigEndian.Uint64(buf))}
+	for v := range slices.Chunk(buf[8:], tlog.HashSize) {
+		t.Hashes = append(t.Hashes, tlog.Hash(v))
+	}
+	if int64(len(t.Hashes)) != tlog.StoredHashCount(t.N) {
+		return nil, xerrors.Define("malformed tree of the checksum database").WithStack()
+	}
+	return t, nil
+}
@@ -0,0 +1,252 @@
+package gomodule
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb"
+	"golang.org/x/mod/sumdb/note"
+
+	"go.f110.dev/mono/go/logger/slogger"
+	"go.f110.dev/mono/go/storage"
+)
+
+func TestChecksumDatabase(t *testing.T) {
+	slogger.Init()
+	skey, vkey, err := note.GenerateKey(rand.Reader, "sum.example.com")
+	require.NoError(t, err)
+	signer, err := note.NewSigner(skey)
+	require.NoError(t, err)
+	backend := storage.NewMock()
+
+	db := NewChecksumDatabase(signer, backend)
+	db.AddSource(fakeGoSumSource{"go.f110.dev/private": true})
+	srv := httptest.NewServer(sumdb.NewServer(db))
+	t.Cleanup(srv.Close)
+
+	// go command can verify the records by the signed tree.
+	client := sumdb.NewClient(&memoryClientOps{url: srv.URL, key: vkey, config: make(map[string][]byte)})
+	for i := range 5 {
+		version := fmt.Sprintf("v1.0.%d", i)
+		lines, err := client.Lookup("go.f110.dev/private", version)
+		require.NoError(t, err)
+		assert.Equal(t, []string{fakeGoSumLines("go.f110.dev/private", version)[0]}, lines)
+	}
+	_, err = client.Lookup("go.f110.dev/unknown", "v1.0.0")
+	assert.Error(t, err)
+
+	// The record which is added first is never replaced.
+	mv := module.Version{Path: "go.f110.dev/private", Version: "v1.0.0"}
+	record, err := db.Add(context.Background(), mv, []byte("go.f110.dev/private v1.0.0 h1:other=\n"))
+	require.NoError(t, err)
+	assert.Equal(t, fakeGoSumLines("go.f110.dev/private", "v1.0.0")[0]+"\n"+fakeGoSumLines("go.f110.dev/private", "v1.0.0")[1]+"\n", string(record))
+
+	// The tree is persisted to the storage.
+	db = NewChecksumDatabase(signer, backend)
+	record, err = db.Record(context.Background(), mv)
+	require.NoError(t, err)
+	assert.Contains(t, string(record), "go.f110.dev/private v1.0.0 h1:")
+	_, err = db.Record(context.Background(), module.Version{Path: "go.f110.dev/private", Version: "v2.0.0"})
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestChecksumDatabaseConcurrentAdd(t *testing.T) {
+	slogger.Init()
+	skey, _, err := note.GenerateKey(rand.Reader, "sum.example.com")
+	require.NoError(t, err)
+	signer, err := note.NewSigner(skey)
+	require.NoError(t, err)
+	backend := storage.NewMock()
+
+	// Each database is the replica which shares the storage.
+	var wg sync.WaitGroup
+	for i := range 4 {
+		db := NewChecksumDatabase(signer, backend)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 5 {
+				version := fmt.Sprintf("v%d.0.%d", i, j)
+				lines := fakeGoSumLines("go.f110.dev/private", version)
+				_, err := db.Add(context.Background(), module.Version{Path: "go.f110.dev/private", Version: version}, []byte(lines[0]+"\n"+lines[1]+"\n"))
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	db := NewChecksumDatabase(signer, backend)
+	tree, err := db.loadTree(context.Background())
+	require.NoError(t, err)
+	assert.EqualValues(t, 20, tree.N)
+	for i := range 4 {
+		for j := range 5 {
+			version := fmt.Sprintf("v%d.0.%d", i, j)
+			record, err := db.Record(context.Background(), module.Version{Path: "go.f110.dev/private", Version: version})
+			require.NoError(t, err)
+			assert.Contains(t, string(record), fakeGoSumLines("go.f110.dev/private", version)[0])
+		}
+	}
+}
+
+func TestModuleProxyVerifyZip(t *testing.T) {
+	slogger.Init()
+	skey, _, err := note.GenerateKey(rand.Reader, "sum.example.com")
+	require.NoError(t, err)
+	signer, err := note.NewSigner(skey)
+	require.NoError(t, err)
+	db := NewChecksumDatabase(signer, storage.NewMock())
+	lines := fakeGoSumLines("go.f110.dev/private", "v1.0.0")
+	_, err = db.Add(context.Background(), module.Version{Path: "go.f110.dev/private", Version: "v1.0.0"}, []byte(lines[0]+"\n"+lines[1]+"\n"))
+	require.NoError(t, err)
+
+	p := &ModuleProxy{checksumDB: db}
+	err = p.verifyZip(context.Background(), "go.f110.dev/private", "v1.0.0", "h1:go.f110.dev/private@v1.0.0=")
+	assert.NoError(t, err)
+	err = p.verifyZip(context.Background(), "go.f110.dev/private", "v1.0.0", "h1:rebuilt=")
+	assert.ErrorIs(t, err, ErrChecksumMismatch)
+}
+
+func TestSumDBProxy(t *testing.T) {
+	slogger.Init()
+	skey, vkey, err := note.GenerateKey(rand.Reader, "sum.example.com")
+	require.NoError(t, err)
+	upstream := httptest.NewServer(sumdb.NewServer(sumdb.NewTestServer(skey, func(path, version string) ([]byte, error) {
+		if path != "github.com/f110/public" {
+			return nil, fs.ErrNotExist
+		}
+		lines := fakeGoSumLines(path, version)
+		return []byte(lines[0] + "\n" + lines[1] + "\n"), nil
+	})))
+	t.Cleanup(upstream.Close)
+	u, err := url.Parse(upstream.URL)
+	require.NoError(t, err)
+
+	p := NewSumDBProxy(u, vkey, nil)
+	assert.Equal(t, "sum.example.com", p.Name())
+
+	buf, err := p.GoSum(context.Background(), "github.com/f110/public", "v1.0.0")
+	require.NoError(t, err)
+	lines := fakeGoSumLines("github.com/f110/public", "v1.0.0")
+	assert.Equal(t, lines[0]+"\n"+lines[1]+"\n", string(buf))
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lookup/github.com/f110/public@v1.0.1", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), fakeGoSumLines("github.com/f110/public", "v1.0.1")[0])
+	rec = httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/lookup/github.com/f110/unknown@v1.0.0", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/other", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestProxyServerSumDB(t *testing.T) {
+	slogger.Init()
+	skey, _, err := note.GenerateKey(rand.Reader, "sum.example.com")
+	require.NoError(t, err)
+	signer, err := note.NewSigner(skey)
+	require.NoError(t, err)
+	db := NewChecksumDatabase(signer, storage.NewMock())
+	upstream, err := url.Parse("https://sum.golang.org")
+	require.NoError(t, err)
+	s := NewProxyServer(":0", upstream, &ModuleProxy{checksumDB: db}, nil, WithSumDBProxy(NewSumDBProxy(upstream, DefaultSumDBKey, nil)))
+
+	cases := []struct {
+		Path string
+		Code int
+	}{
+		{Path: "/sumdb/sum.example.com/supported", Code: http.StatusOK},
+		{Path: "/sumdb/sum.golang.org/supported", Code: http.StatusOK},
+		{Path: "/sumdb/unknown.example.com/supported", Code: http.StatusNotFound},
+		{Path: "/sumdb/sum.example.com/latest", Code: http.StatusOK},
+		{Path: "/sumdb/sum.example.com/lookup/go.f110.dev/unknown@v1.0.0", Code: http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		rec := httptest.NewRecorder()
+		s.r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.Path, nil))
+		assert.Equal(t, tc.Code, rec.Code, tc.Path)
+	}
+}
+
+// fakeGoSumSource returns the lines of go.sum for the module which is true in the map.
+type fakeGoSumSource map[string]bool
+
+func (s fakeGoSumSource) GoSum(_ context.Context, path, version string) ([]byte, error) {
+	if !s[path] {
+		return nil, &fs.PathError{Op: "gosum", Path: path, Err: fs.ErrNotExist}
+	}
+	lines := fakeGoSumLines(path, version)
+	return []byte(lines[0] + "\n" + lines[1] + "\n"), nil
+}
+
+func fakeGoSumLines(path, version string) []string {
+	return []string{
+		fmt.Sprintf("%s %s h1:%s@%s=", path, version, path, version),
+		fmt.Sprintf("%s %s/go.mod h1:%s@%s/go.mod=", path, version, path, version),
+	}
+}
+
+// memoryClientOps is sumdb.ClientOps which doesn't have the cache.
+type memoryClientOps struct {
+	url string
+	key string
+
+	mu     sync.Mutex
+	config map[string][]byte
+}
+
+func (o *memoryClientOps) ReadRemote(path string) ([]byte, error) {
+	res, err := http.Get(o.url + path)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", path, res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
+
+func (o *memoryClientOps) ReadConfig(file string) ([]byte, error) {
+	if file == "key" {
+		return []byte(o.key), nil
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.config[file], nil
+}
+
+func (o *memoryClientOps) WriteConfig(file string, old, new []byte) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if string(o.config[file]) != string(old) {
+		return sumdb.ErrWriteConflict
+	}
+	o.config[file] = new
+	return nil
+}
+
+func (o *memoryClientOps) ReadCache(file string) ([]byte, error) {
+	return nil, fs.ErrNotExist
+}
+
+func (o *memoryClientOps) WriteCache(string, []byte) {}
+
+func (o *memoryClientOps) Log(string) {}
+
+func (o *memoryClientOps) SecurityError(msg string) {
+	panic(msg)
+}
@@ -3,6 +3,7 @@ package gomodule
 import (
 	"os"
 	"regexp"
+	"strings"
 
 	"go.f110.dev/xerrors"
 	"gopkg.in/yaml.v3"
@@ -10,40 +11,95 @@ import (
 	"go.f110.dev/mono/go/regexp/regexputil"
 )
 
+const (
+	HostGitHub = "github"
+	HostGitea  = "gitea"
+	HostGitLab = "gitlab"
+	// HostGit is the git server which doesn't have the API. The local clone is used instead of the API.
+	HostGit = "git"
+)
+
 type ModuleSetting struct {
 	ModuleName string `yaml:"module_name"`
 	URLReplace string `yaml:"url_replace"`
+	// Host is the hosting service of the repository. If Host is empty, Host is decided by the URL of the repository.
+	Host string `yaml:"host"`
+	// APIURL is the base URL of the API of Gitea or GitLab (e.g. https://gitea.example.com/api/v1).
+	// If APIURL is empty, it's made from the URL of the repository.
+	APIURL     string      `yaml:"api_url"`
+	Credential *Credential `yaml:"credential"`
 
 	match         *regexp.Regexp
 	replaceRegexp *regexputil.RegexpLiteral
 }
 
-type Config []*ModuleSetting
+// Credential is used for cloning the repository and calling the API of the hosting service.
+type Credential struct {
+	Username string `yaml:"username"`
+	// PasswordFile is the path of the file which contains the password or the access token.
+	PasswordFile string `yaml:"password_file"`
+
+	password string
+}
+
+type Config struct {
+	Modules []*ModuleSetting `yaml:"modules"`
+	Policy  *Policy          `yaml:"policy"`
+}
+
+// UnmarshalYAML decodes the config. The config which is the list of ModuleSetting is also accepted for compatibility.
+func (c *Config) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.SequenceNode {
+		return node.Decode(&c.Modules)
+	}
+
+	type config Config
+	return node.Decode((*config)(c))
+}
 
 func ReadConfig(path string) (Config, error) {
 	f, err := os.Open(path)
 	if err != nil {
-		return nil, xerrors.WithStack(err)
+		return Config{}, xerrors.WithStack(err)
 	}
+	defer f.Close()
 
 	var conf Config
 	if err := yaml.NewDecoder(f).Decode(&conf); err != nil {
-		return nil, xerrors.WithStack(err)
+		return Config{}, xerrors.WithStack(err)
 	}
-	for _, v := range conf {
+	for _, v := range conf.Modules {
 		re, err := regexp.Compile(v.ModuleName)
 		if err != nil {
-			return nil, xerrors.WithStack(err)
+			return Config{}, xerrors.WithStack(err)
 		}
 		v.match = re
 
 		if v.URLReplace != "" {
 			regexpLiteral, err := regexputil.ParseRegexpLiteral(v.URLReplace)
 			if err != nil {
-				return nil, xerrors.WithStack(err)
+				return Config{}, xerrors.WithStack(err)
 			}
 			v.replaceRegexp = regexpLiteral
 		}
+
+		switch v.Host {
+		case "", HostGitHub, HostGitea, HostGitLab, HostGit:
+		default:
+			return Config{}, xerrors.Definef("unknown host of %s: %s", v.ModuleName, v.Host).WithStack()
+		}
+		if v.Credential != nil && v.Credential.PasswordFile != "" {
+			buf, err := os.ReadFile(v.Credential.PasswordFile)
+			if err != nil {
+				return Config{}, xerrors.WithStack(err)
+			}
+			v.Credential.password = strings.TrimSpace(string(buf))
+		}
+	}
+	if conf.Policy != nil {
+		if err := conf.Policy.init(); err != nil {
+			return Config{}, err
+		}
 	}
 
 	return conf, nil
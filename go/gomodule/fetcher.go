@@ -105,6 +105,7 @@ func (f *ModuleFetcher) Get(ctx context.Context, importPath string, setting *Mod
 		u = setting.replaceRegexp.Match.ReplaceAllString(u, setting.replaceRegexp.Replace)
 	}
 	vcsRepo := NewVCS("git", u, repoRoot.Repo, f.tokenProvider, f.caBundle)
+	vcsRepo.credential = setting.Credential
 	var moduleRoot *ModuleRoot
 	if f.cache != nil {
 		if mr, err := f.cache.GetModuleRoot(repoRoot.Root, f.baseDir, vcsRepo); err == nil {
@@ -207,14 +208,6 @@ func (m *ModuleRoot) Archive(ctx context.Context, w io.Writer, module, version s
 			break
 		}
 	}
-	excludeDirs := make(map[string]struct{})
-	for _, v := range m.Modules {
-		if v == mod {
-			continue
-		}
-		excludeDirs[filepath.Dir(v.ModFilePath)+"/"] = struct{}{}
-	}
-
 	if isTag {
 		if m.cache != nil {
 			if err := m.cache.Archive(ctx, module, version, w); err == nil {
@@ -242,53 +235,114 @@ func (m *ModuleRoot) Archive(ctx context.Context, w io.Writer, module, version s
 		}
 
 		buf := new(bytes.Buffer)
-		zipWriter := zip.NewWriter(buf)
-		modDir := mod.Path + "@" + version
-		goModFileDir := filepath.Dir(mod.ModFilePath)
-		foundLicenseFile := false
-		walker := object.NewTreeWalker(tree, true, make(map[plumbing.Hash]bool))
-	Walk:
-		for {
-			name, te, err := walker.Next()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
+		if err := m.packTree(buf, mod, tree, version, removeBazelFile); err != nil {
+			return err
+		}
+		data := buf.Bytes()
+		if _, err := io.Copy(w, bytes.NewReader(data)); err != nil {
+			return xerrors.WithStack(err)
+		}
+		if m.cache != nil {
+			if err := m.cache.SaveArchive(ctx, module, version, data); err != nil {
 				return xerrors.WithStack(err)
 			}
+		}
+		return nil
+	}
 
-			if te.Mode&filemode.Dir == filemode.Dir {
-				continue Walk
-			}
-			for k := range excludeDirs {
-				if strings.HasPrefix(name, k) {
-					continue Walk
-				}
-			}
-			if goModFileDir != "." && !strings.HasPrefix(name, goModFileDir) {
-				continue Walk
-			}
+	return xerrors.Define("specified commit is not support").WithStack()
+}
 
-			if filepath.Join(filepath.Dir(mod.ModFilePath), "LICENSE") == name {
-				foundLicenseFile = true
-			}
-			if removeBazelFile && (name == "BUILD" || name == "BUILD.bazel") {
+// packTree writes the zip archive of the module from tree. The files of the other modules in tree are excluded.
+func (m *ModuleRoot) packTree(w io.Writer, mod *Module, tree *object.Tree, version string, removeBazelFile bool) error {
+	excludeDirs := make(map[string]struct{})
+	for _, v := range m.Modules {
+		if v == mod {
+			continue
+		}
+		excludeDirs[filepath.Dir(v.ModFilePath)+"/"] = struct{}{}
+	}
+
+	zipWriter := zip.NewWriter(w)
+	modDir := mod.Path + "@" + version
+	goModFileDir := filepath.Dir(mod.ModFilePath)
+	foundLicenseFile := false
+	walker := object.NewTreeWalker(tree, true, make(map[plumbing.Hash]bool))
+Walk:
+	for {
+		name, te, err := walker.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+
+		if te.Mode&filemode.Dir == filemode.Dir {
+			continue Walk
+		}
+		for k := range excludeDirs {
+			if strings.HasPrefix(name, k) {
 				continue Walk
 			}
+		}
+		if goModFileDir != "." && !strings.HasPrefix(name, goModFileDir) {
+			continue Walk
+		}
 
-			p := name
-			if filepath.Dir(mod.ModFilePath) != "." {
-				p = strings.TrimPrefix(name, filepath.Dir(mod.ModFilePath))
+		if filepath.Join(filepath.Dir(mod.ModFilePath), "LICENSE") == name {
+			foundLicenseFile = true
+		}
+		if removeBazelFile && (name == "BUILD" || name == "BUILD.bazel") {
+			continue Walk
+		}
+
+		p := name
+		if filepath.Dir(mod.ModFilePath) != "." {
+			p = strings.TrimPrefix(name, filepath.Dir(mod.ModFilePath))
+		}
+		fileWriter, err := zipWriter.Create(filepath.Join(modDir, p))
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		blob, err := m.vcs.gitRepo.BlobObject(te.Hash)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		fileReader, err := blob.Reader()
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		_, err = io.Copy(fileWriter, fileReader)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		if err := fileReader.Close(); err != nil {
+			return xerrors.WithStack(err)
+		}
+	}
+
+	// Find and pack LICENSE file
+	if !foundLicenseFile {
+		d := goModFileDir
+		for {
+			if _, err := tree.File(filepath.Join(d, "LICENSE")); err == object.ErrFileNotFound {
+				if d == "." {
+					break
+				}
+				d = filepath.Dir(d)
+				continue
 			}
-			fileWriter, err := zipWriter.Create(filepath.Join(modDir, p))
+
+			fileWriter, err := zipWriter.Create(filepath.Join(modDir, "LICENSE"))
 			if err != nil {
 				return xerrors.WithStack(err)
 			}
-			blob, err := m.vcs.gitRepo.BlobObject(te.Hash)
+			f, err := tree.File(filepath.Join(d, "LICENSE"))
 			if err != nil {
 				return xerrors.WithStack(err)
 			}
-			fileReader, err := blob.Reader()
+			fileReader, err := f.Reader()
 			if err != nil {
 				return xerrors.WithStack(err)
 			}
@@ -299,59 +353,15 @@ func (m *ModuleRoot) Archive(ctx context.Context, w io.Writer, module, version s
 			if err := fileReader.Close(); err != nil {
 				return xerrors.WithStack(err)
 			}
+			break
 		}
+	}
 
-		// Find and pack LICENSE file
-		if !foundLicenseFile {
-			d := goModFileDir
-			for {
-				if _, err := tree.File(filepath.Join(d, "LICENSE")); err == object.ErrFileNotFound {
-					if d == "." {
-						break
-					}
-					d = filepath.Dir(d)
-					continue
-				}
-
-				fileWriter, err := zipWriter.Create(filepath.Join(modDir, "LICENSE"))
-				if err != nil {
-					return xerrors.WithStack(err)
-				}
-				f, err := tree.File(filepath.Join(d, "LICENSE"))
-				if err != nil {
-					return xerrors.WithStack(err)
-				}
-				fileReader, err := f.Reader()
-				if err != nil {
-					return xerrors.WithStack(err)
-				}
-				_, err = io.Copy(fileWriter, fileReader)
-				if err != nil {
-					return xerrors.WithStack(err)
-				}
-				if err := fileReader.Close(); err != nil {
-					return xerrors.WithStack(err)
-				}
-				break
-			}
-		}
-
-		if err := zipWriter.Close(); err != nil {
-			return xerrors.WithStack(err)
-		}
-		data := buf.Bytes()
-		if _, err := io.Copy(w, bytes.NewReader(data)); err != nil {
-			return xerrors.WithStack(err)
-		}
-		if m.cache != nil {
-			if err := m.cache.SaveArchive(ctx, module, version, data); err != nil {
-				return xerrors.WithStack(err)
-			}
-		}
-		return nil
+	if err := zipWriter.Close(); err != nil {
+		return xerrors.WithStack(err)
 	}
 
-	return xerrors.Define("specified commit is not support").WithStack()
+	return nil
 }
 
 func (m *ModuleRoot) findModules(ctx context.Context) ([]*Module, error) {
@@ -609,6 +619,8 @@ type VCS struct {
 
 	tokenProvider *githubutil.TokenProvider
 	caBundle      []byte
+	// credential is used instead of tokenProvider if it's set.
+	credential *Credential
 
 	gitRepo           *git.Repository
 	defaultBranchName string
@@ -675,6 +687,9 @@ func (vcs *VCS) Download(ctx context.Context, dir string) error {
 }
 
 func (vcs *VCS) getAuthMethod(ctx context.Context) *gogitHttp.BasicAuth {
+	if vcs.credential != nil {
+		return &gogitHttp.BasicAuth{Username: vcs.credential.Username, Password: vcs.credential.password}
+	}
 	if vcs.tokenProvider == nil {
 		return nil
 	}
@@ -0,0 +1,233 @@
+package gomodule
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"go.f110.dev/xerrors"
+
+	"go.f110.dev/mono/go/logger/slogger"
+)
+
+// ModuleHost provides the module of the version which is not tagged (e.g. the branch or the commit).
+type ModuleHost interface {
+	GetInfo(ctx context.Context, moduleRoot *ModuleRoot, module, version string) (Info, error)
+	GetInfoRevision(ctx context.Context, moduleRoot *ModuleRoot, module string, pseudoVersion *PseudoVersion) (Info, error)
+	GetGoMod(ctx context.Context, moduleRoot *ModuleRoot, module *Module, version string) (string, error)
+	GetGoModRevision(ctx context.Context, moduleRoot *ModuleRoot, module *Module, pseudoVersion *PseudoVersion) (string, error)
+	Archive(ctx context.Context, w io.Writer, moduleRoot *ModuleRoot, moduleName, version string, removeBazelFile bool) error
+	ArchiveRevision(ctx context.Context, w io.Writer, moduleRoot *ModuleRoot, moduleName, version string, removeBazelFile bool) error
+}
+
+var (
+	_ ModuleHost = &GitHubProxy{}
+	_ ModuleHost = &GitProxy{}
+)
+
+// HostingAPI is the API of the hosting service which provides the commit and the file without the local clone.
+type HostingAPI interface {
+	// Commit returns the hash and the commit time of rev. rev is the branch, the tag or the commit hash.
+	Commit(ctx context.Context, repoURL, rev string) (string, time.Time, error)
+	// File returns the content of the file at rev.
+	File(ctx context.Context, repoURL, rev, path string) ([]byte, error)
+}
+
+// GitProxy is ModuleHost for the git server which is not GitHub.
+// The commit and go.mod are got through HostingAPI if it's available. Otherwise, the local clone is used.
+// The archive is always made from the local clone.
+type GitProxy struct {
+	cache *ModuleCache
+	api   HostingAPI
+}
+
+// NewGitProxy returns GitProxy. api can be nil.
+func NewGitProxy(cache *ModuleCache, api HostingAPI) *GitProxy {
+	return &GitProxy{cache: cache, api: api}
+}
+
+func (g *GitProxy) GetInfo(ctx context.Context, moduleRoot *ModuleRoot, module, version string) (Info, error) {
+	sha, t, err := g.commit(ctx, moduleRoot, version)
+	if err != nil {
+		return Info{}, err
+	}
+	if g.cache != nil {
+		if err := g.cache.SetModInfo(module, sha, t); err != nil {
+			slogger.Log.Warn("Failed set cache", slogger.E(err))
+		}
+	}
+	return Info{Version: fmt.Sprintf("v0.0.0-%s-%s", t.Format("20060102150405"), sha[:12]), Time: t}, nil
+}
+
+func (g *GitProxy) GetInfoRevision(ctx context.Context, moduleRoot *ModuleRoot, module string, pseudoVersion *PseudoVersion) (Info, error) {
+	if g.cache != nil && len(pseudoVersion.Revision) > 11 {
+		t, err := g.cache.GetModInfo(module, pseudoVersion.Revision)
+		if err == nil {
+			slogger.Log.Debug("The mod info was found in cache", slog.String("module", module), slog.String("revision", pseudoVersion.Revision))
+			return Info{Version: fmt.Sprintf("v0.0.0-%s-%s", t.Format("20060102150405"), pseudoVersion.Revision[:12]), Time: t}, nil
+		}
+	}
+
+	return g.GetInfo(ctx, moduleRoot, module, pseudoVersion.Revision)
+}
+
+func (g *GitProxy) GetGoMod(ctx context.Context, moduleRoot *ModuleRoot, module *Module, version string) (string, error) {
+	if g.api != nil {
+		buf, err := g.api.File(ctx, hostingRepositoryURL(moduleRoot), version, module.ModFilePath)
+		if err == nil {
+			return string(buf), nil
+		}
+		slogger.Log.Info("Failed to get go.mod through API. Fallback to the local clone", slog.String("module", module.Path), slogger.E(err))
+	}
+
+	commit, err := g.resolveCommit(ctx, moduleRoot, version)
+	if err != nil {
+		return "", err
+	}
+	f, err := commit.File(module.ModFilePath)
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	buf, err := f.Contents()
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	return buf, nil
+}
+
+func (g *GitProxy) GetGoModRevision(ctx context.Context, moduleRoot *ModuleRoot, module *Module, pseudoVersion *PseudoVersion) (string, error) {
+	if g.cache != nil && len(pseudoVersion.Revision) > 11 {
+		modFile, err := g.cache.GetModFile(module.Path, pseudoVersion.Revision)
+		if err == nil {
+			slogger.Log.Debug("The module file was found in cache",
+				slog.String("module", module.Path),
+				slog.String("version", pseudoVersion.Revision),
+			)
+			return string(modFile), nil
+		}
+	}
+
+	buf, err := g.GetGoMod(ctx, moduleRoot, module, pseudoVersion.Revision)
+	if err != nil {
+		return "", err
+	}
+	if g.cache != nil {
+		if err := g.cache.SetModFile(module.Path, pseudoVersion.Revision, []byte(buf)); err != nil {
+			slogger.Log.Warn("Failed set the module file", slogger.E(err))
+		}
+	}
+	return buf, nil
+}
+
+func (g *GitProxy) Archive(ctx context.Context, w io.Writer, moduleRoot *ModuleRoot, moduleName, version string, removeBazelFile bool) error {
+	mod := moduleRoot.FindModule(moduleName)
+	if mod == nil {
+		return xerrors.Definef("%s module is not found", moduleName).WithStack()
+	}
+	commit, err := g.resolveCommit(ctx, moduleRoot, version)
+	if err != nil {
+		return err
+	}
+
+	slogger.Log.Debug("Make the archive file from the local clone", slog.String("url", moduleRoot.RepositoryURL), slog.String("commit", commit.Hash.String()))
+	tree, err := commit.Tree()
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	return moduleRoot.packTree(w, mod, tree, version, removeBazelFile)
+}
+
+func (g *GitProxy) ArchiveRevision(ctx context.Context, w io.Writer, moduleRoot *ModuleRoot, moduleName, version string, removeBazelFile bool) error {
+	mod := moduleRoot.FindModule(moduleName)
+	if mod == nil {
+		return xerrors.Definef("%s module is not found", moduleName).WithStack()
+	}
+	pseudoVersion, err := ParsePseudoVersion(version)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	commit, err := g.resolveCommit(ctx, moduleRoot, pseudoVersion.Revision)
+	if err != nil {
+		return err
+	}
+	revision := commit.Hash.String()[:12]
+	if err := g.cache.Archive(ctx, moduleName, revision, w); err == nil {
+		slogger.Log.Debug("An archive file of module was found in cache",
+			slog.String("module", moduleName),
+			slog.String("revision", revision),
+		)
+		return nil
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	buf := new(bytes.Buffer)
+	if err := moduleRoot.packTree(buf, mod, tree, version, removeBazelFile); err != nil {
+		return err
+	}
+	data := buf.Bytes()
+	if err := g.cache.SaveArchive(ctx, moduleName, revision, data); err != nil {
+		return xerrors.WithStack(err)
+	}
+	if _, err := io.Copy(w, bytes.NewReader(data)); err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	return nil
+}
+
+// commit returns the hash and the commit time of rev.
+func (g *GitProxy) commit(ctx context.Context, moduleRoot *ModuleRoot, rev string) (string, time.Time, error) {
+	if g.api != nil {
+		sha, t, err := g.api.Commit(ctx, hostingRepositoryURL(moduleRoot), rev)
+		if err == nil {
+			return sha, t.UTC(), nil
+		}
+		slogger.Log.Info("Failed to get the commit through API. Fallback to the local clone", slog.String("rev", rev), slogger.E(err))
+	}
+
+	commit, err := g.resolveCommit(ctx, moduleRoot, rev)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return commit.Hash.String(), commit.Committer.When.UTC(), nil
+}
+
+// resolveCommit finds the commit of rev from the local clone. The branch is looked up from the remote branches
+// because the clone doesn't have the local branches except the default branch.
+func (g *GitProxy) resolveCommit(ctx context.Context, moduleRoot *ModuleRoot, rev string) (*object.Commit, error) {
+	if err := moduleRoot.vcs.Sync(ctx, moduleRoot.dir); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	repo := moduleRoot.vcs.gitRepo
+	for _, v := range []string{rev, "origin/" + rev} {
+		hash, err := repo.ResolveRevision(plumbing.Revision(v))
+		if err != nil {
+			continue
+		}
+		commit, err := repo.CommitObject(*hash)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		return commit, nil
+	}
+
+	return nil, xerrors.Definef("%s is not found in %s", rev, moduleRoot.RepositoryURL).WithStack()
+}
+
+// hostingRepositoryURL returns the URL of the repository. If the URL is replaced by ModuleSetting, the replaced URL
+// is the location of the repository.
+func hostingRepositoryURL(moduleRoot *ModuleRoot) string {
+	if moduleRoot.vcs != nil && moduleRoot.vcs.CloneURL != "" {
+		return moduleRoot.vcs.CloneURL
+	}
+	return moduleRoot.RepositoryURL
+}
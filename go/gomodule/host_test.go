@@ -0,0 +1,161 @@
+package gomodule
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.f110.dev/mono/go/logger/slogger"
+)
+
+func TestGitProxy(t *testing.T) {
+	slogger.Init()
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+
+	addFile(t, wt, dir, "go.mod", []byte("module git.example.com/f110/test"))
+	addFile(t, wt, dir, "const.go", []byte("package test\n\nconst Foo = \"bar\""))
+	addFile(t, wt, dir, "sub/go.mod", []byte("module git.example.com/f110/test/sub"))
+	addFile(t, wt, dir, "sub/const.go", []byte("package sub"))
+	when := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	commitHash, err := wt.Commit("init", &git.CommitOptions{
+		Author:    &object.Signature{Email: "test@example.com", When: when},
+		Committer: &object.Signature{Email: "test@example.com", When: when},
+	})
+	require.NoError(t, err)
+
+	vcsRepo := NewVCS("git", "", "https://git.example.com/f110/test", nil, nil)
+	vcsRepo.synced = true
+	require.NoError(t, vcsRepo.Open(dir))
+	moduleRoot := &ModuleRoot{dir: dir, RootPath: "git.example.com/f110/test", RepositoryURL: vcsRepo.URL, vcs: vcsRepo}
+	modules, err := moduleRoot.findModules(context.Background())
+	require.NoError(t, err)
+	moduleRoot.Modules = modules
+	mod := moduleRoot.FindModule("git.example.com/f110/test")
+	require.NotNil(t, mod)
+
+	g := NewGitProxy(nil, nil)
+	pseudoVersion := "v0.0.0-20240102030405-" + commitHash.String()[:12]
+	info, err := g.GetInfo(context.Background(), moduleRoot, mod.Path, "master")
+	require.NoError(t, err)
+	assert.Equal(t, pseudoVersion, info.Version)
+	assert.Equal(t, when, info.Time)
+	pv, err := ParsePseudoVersion(pseudoVersion)
+	require.NoError(t, err)
+	info, err = g.GetInfoRevision(context.Background(), moduleRoot, mod.Path, pv)
+	require.NoError(t, err)
+	assert.Equal(t, pseudoVersion, info.Version)
+	_, err = g.GetInfo(context.Background(), moduleRoot, mod.Path, "unknown")
+	assert.Error(t, err)
+
+	goMod, err := g.GetGoModRevision(context.Background(), moduleRoot, mod, pv)
+	require.NoError(t, err)
+	assert.Equal(t, "module git.example.com/f110/test", goMod)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, g.ArchiveRevision(context.Background(), buf, moduleRoot, mod.Path, pseudoVersion, false))
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	var files []string
+	for _, v := range zipReader.File {
+		files = append(files, v.Name)
+	}
+	assert.ElementsMatch(t, []string{
+		"git.example.com/f110/test@" + pseudoVersion + "/go.mod",
+		"git.example.com/f110/test@" + pseudoVersion + "/const.go",
+	}, files)
+}
+
+func TestHostingAPI(t *testing.T) {
+	slogger.Init()
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.URL.EscapedPath()+"?"+req.URL.RawQuery)
+		switch {
+		case req.Header.Get("Authorization") == "token secret":
+		case req.Header.Get("PRIVATE-TOKEN") == "secret":
+		default:
+			http.Error(w, "", http.StatusUnauthorized)
+			return
+		}
+
+		switch req.URL.EscapedPath() {
+		case "/gitea/api/v1/repos/f110/test/git/commits/main":
+			w.Write([]byte(`{"sha":"0123456789abcdef0123456789abcdef01234567","commit":{"committer":{"date":"2024-01-02T12:04:05+09:00"}}}`))
+		case "/gitea/api/v1/repos/f110/test/raw/sub/go.mod":
+			w.Write([]byte("module git.example.com/f110/test/sub"))
+		case "/api/v4/projects/group%2Fsub%2Ftest/repository/commits/main":
+			w.Write([]byte(`{"id":"0123456789abcdef0123456789abcdef01234567","committed_date":"2024-01-02T03:04:05Z"}`))
+		case "/api/v4/projects/group%2Fsub%2Ftest/repository/files/sub%2Fgo.mod/raw":
+			w.Write([]byte("module git.example.com/f110/test/sub"))
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	credential := &Credential{password: "secret"}
+	moduleRoot := &ModuleRoot{RootPath: "git.example.com/f110/test"}
+	mod := &Module{Path: "git.example.com/f110/test/sub", ModFilePath: "sub/go.mod"}
+
+	cases := []struct {
+		Name    string
+		API     HostingAPI
+		RepoURL string
+	}{
+		{Name: "Gitea", API: NewGiteaAPI("", credential), RepoURL: srv.URL + "/gitea/f110/test.git"},
+		{Name: "GitLab", API: NewGitLabAPI(srv.URL+"/api/v4", credential), RepoURL: "https://gitlab.example.com/group/sub/test"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			moduleRoot.RepositoryURL = tc.RepoURL
+			g := NewGitProxy(nil, tc.API)
+
+			info, err := g.GetInfo(context.Background(), moduleRoot, mod.Path, "main")
+			require.NoError(t, err)
+			assert.Equal(t, "v0.0.0-20240102030405-0123456789ab", info.Version)
+			goMod, err := g.GetGoMod(context.Background(), moduleRoot, mod, "main")
+			require.NoError(t, err)
+			assert.Equal(t, "module git.example.com/f110/test/sub", goMod)
+		})
+	}
+	assert.Contains(t, requests, "/api/v4/projects/group%2Fsub%2Ftest/repository/files/sub%2Fgo.mod/raw?ref=main")
+}
+
+func TestReadConfigHost(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte("secret\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(`- module_name: gitea.example.com/.*
+  host: gitea
+  credential:
+    username: bot
+    password_file: `+filepath.Join(dir, "token")+`
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unknown.yaml"), []byte("- module_name: example.com/.*\n  host: svn\n"), 0644))
+
+	conf, err := ReadConfig(filepath.Join(dir, "config.yaml"))
+	require.NoError(t, err)
+	require.Len(t, conf.Modules, 1)
+	assert.Equal(t, HostGitea, conf.Modules[0].Host)
+	assert.Equal(t, "secret", conf.Modules[0].Credential.password)
+
+	p := NewModuleProxy(conf, dir, nil, nil, nil, nil)
+	assert.IsType(t, &GitProxy{}, p.moduleHost("gitea.example.com/f110/test", &ModuleRoot{}))
+	assert.NotNil(t, p.moduleHost("gitea.example.com/f110/test", &ModuleRoot{}).(*GitProxy).api)
+
+	_, err = ReadConfig(filepath.Join(dir, "unknown.yaml"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,183 @@
+package gomodule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.f110.dev/xerrors"
+)
+
+// GiteaAPI is HostingAPI for Gitea.
+type GiteaAPI struct {
+	apiURL     string
+	credential *Credential
+	client     *http.Client
+}
+
+var _ HostingAPI = &GiteaAPI{}
+
+// NewGiteaAPI returns GiteaAPI. If apiURL is empty, the URL is made from the URL of the repository.
+func NewGiteaAPI(apiURL string, credential *Credential) *GiteaAPI {
+	return &GiteaAPI{apiURL: apiURL, credential: credential, client: &http.Client{Transport: &httpTransport{}}}
+}
+
+func (g *GiteaAPI) Commit(ctx context.Context, repoURL, rev string) (string, time.Time, error) {
+	base, err := g.repository(repoURL)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	buf, err := hostingAPIGet(ctx, g.client, base+"/git/commits/"+url.PathEscape(rev), g.setAuth)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	var commit struct {
+		SHA    string `json:"sha"`
+		Commit struct {
+			Committer struct {
+				Date time.Time `json:"date"`
+			} `json:"committer"`
+		} `json:"commit"`
+	}
+	if err := json.Unmarshal(buf, &commit); err != nil {
+		return "", time.Time{}, xerrors.WithStack(err)
+	}
+	return commit.SHA, commit.Commit.Committer.Date, nil
+}
+
+func (g *GiteaAPI) File(ctx context.Context, repoURL, rev, path string) ([]byte, error) {
+	base, err := g.repository(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	return hostingAPIGet(ctx, g.client, base+"/raw/"+escapeFilePath(path)+"?ref="+url.QueryEscape(rev), g.setAuth)
+}
+
+// repository returns the API URL of the repository. Gitea may be served under the sub path, so the owner and the
+// name of the repository are the last two elements of the path.
+func (g *GiteaAPI) repository(repoURL string) (string, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	s := strings.Split(strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git"), "/")
+	if len(s) < 2 {
+		return "", xerrors.Definef("%s is not the URL of the repository", repoURL).WithStack()
+	}
+	owner, repo := s[len(s)-2], s[len(s)-1]
+
+	apiURL := g.apiURL
+	if apiURL == "" {
+		apiURL = fmt.Sprintf("%s://%s/%s", u.Scheme, u.Host, strings.Join(append(s[:len(s)-2], "api", "v1"), "/"))
+	}
+	return fmt.Sprintf("%s/repos/%s/%s", strings.TrimSuffix(apiURL, "/"), url.PathEscape(owner), url.PathEscape(repo)), nil
+}
+
+func (g *GiteaAPI) setAuth(req *http.Request) {
+	if g.credential != nil && g.credential.password != "" {
+		req.Header.Set("Authorization", "token "+g.credential.password)
+	}
+}
+
+// GitLabAPI is HostingAPI for GitLab.
+type GitLabAPI struct {
+	apiURL     string
+	credential *Credential
+	client     *http.Client
+}
+
+var _ HostingAPI = &GitLabAPI{}
+
+// NewGitLabAPI returns GitLabAPI. If apiURL is empty, the URL is made from the URL of the repository.
+func NewGitLabAPI(apiURL string, credential *Credential) *GitLabAPI {
+	return &GitLabAPI{apiURL: apiURL, credential: credential, client: &http.Client{Transport: &httpTransport{}}}
+}
+
+func (g *GitLabAPI) Commit(ctx context.Context, repoURL, rev string) (string, time.Time, error) {
+	base, err := g.project(repoURL)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	buf, err := hostingAPIGet(ctx, g.client, base+"/repository/commits/"+url.PathEscape(rev), g.setAuth)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	var commit struct {
+		ID            string    `json:"id"`
+		CommittedDate time.Time `json:"committed_date"`
+	}
+	if err := json.Unmarshal(buf, &commit); err != nil {
+		return "", time.Time{}, xerrors.WithStack(err)
+	}
+	return commit.ID, commit.CommittedDate, nil
+}
+
+func (g *GitLabAPI) File(ctx context.Context, repoURL, rev, path string) ([]byte, error) {
+	base, err := g.project(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	return hostingAPIGet(ctx, g.client, base+"/repository/files/"+url.PathEscape(path)+"/raw?ref="+url.QueryEscape(rev), g.setAuth)
+}
+
+// project returns the API URL of the project. The project may be in the nested group, so the whole path is the ID.
+func (g *GitLabAPI) project(repoURL string) (string, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	p := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	if !strings.Contains(p, "/") {
+		return "", xerrors.Definef("%s is not the URL of the project", repoURL).WithStack()
+	}
+
+	apiURL := g.apiURL
+	if apiURL == "" {
+		apiURL = fmt.Sprintf("%s://%s/api/v4", u.Scheme, u.Host)
+	}
+	return fmt.Sprintf("%s/projects/%s", strings.TrimSuffix(apiURL, "/"), url.PathEscape(p)), nil
+}
+
+func (g *GitLabAPI) setAuth(req *http.Request) {
+	if g.credential != nil && g.credential.password != "" {
+		req.Header.Set("PRIVATE-TOKEN", g.credential.password)
+	}
+}
+
+func hostingAPIGet(ctx context.Context, client *http.Client, u string, setAuth func(*http.Request)) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	setAuth(req)
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, xerrors.Definef("%s returned %d", req.URL.Path, res.StatusCode).WithStack()
+	}
+
+	buf, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	return buf, nil
+}
+
+// escapeFilePath escapes each element of the path.
+func escapeFilePath(p string) string {
+	s := strings.Split(p, "/")
+	for i := range s {
+		s[i] = url.PathEscape(s[i])
+	}
+	return strings.Join(s, "/")
+}
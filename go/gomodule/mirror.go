@@ -0,0 +1,414 @@
+package gomodule
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"go.f110.dev/xerrors"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+
+	"go.f110.dev/mono/go/logger/slogger"
+)
+
+// ModuleMirror proxies the requests of the public modules to the upstream and stores the responses to the object
+// storage of ModuleCache. If the upstream is unavailable, ModuleMirror serves the stored files.
+//
+// .info, .mod and .zip of the canonical version are never changed, so these files are served from the storage
+// without asking the upstream. The list of versions and @latest are always asked to the upstream first.
+type ModuleMirror struct {
+	upstream *url.URL
+	cache    *ModuleCache
+	client   *http.Client
+}
+
+func NewModuleMirror(upstream *url.URL, cache *ModuleCache) *ModuleMirror {
+	return &ModuleMirror{
+		upstream: upstream,
+		cache:    cache,
+		client:   &http.Client{Transport: &httpTransport{}},
+	}
+}
+
+func (m *ModuleMirror) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	contentType := mirrorContentType(req.URL.Path)
+	if contentType == "" {
+		http.NotFound(w, req)
+		return
+	}
+
+	r, err := m.open(req.Context(), req.URL.Path)
+	if err != nil {
+		var statusErr *upstreamStatusError
+		if errors.As(err, &statusErr) {
+			http.Error(w, "", statusErr.StatusCode)
+			return
+		}
+		slogger.Log.Info("Failed to fetch from the upstream", slog.String("path", req.URL.Path), slogger.E(err))
+		http.Error(w, "", http.StatusBadGateway)
+		return
+	}
+	defer r.Close()
+
+	w.Header().Set("Content-Type", contentType)
+	if _, err := io.Copy(w, r); err != nil {
+		slogger.Log.Info("Failed to write a buffer to ResponseWriter", slogger.E(err))
+	}
+}
+
+// PrewarmResult is the result of prewarming. Modules are the modules which the archive is downloaded.
+type PrewarmResult struct {
+	Modules []string `json:"modules"`
+	GoMods  int      `json:"go_mods"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// PrewarmGoSum downloads all modules which are listed in go.sum.
+// The module which exclude returns true is skipped.
+func (m *ModuleMirror) PrewarmGoSum(ctx context.Context, goSum []byte, exclude func(string) bool) (*PrewarmResult, error) {
+	var mods, zips []module.Version
+	s := bufio.NewScanner(bytes.NewReader(goSum))
+	for s.Scan() {
+		f := strings.Fields(s.Text())
+		if len(f) == 0 {
+			continue
+		}
+		if len(f) != 3 {
+			return nil, xerrors.Definef("malformed go.sum line: %s", s.Text()).WithStack()
+		}
+		if v, ok := strings.CutSuffix(f[1], "/go.mod"); ok {
+			mods = append(mods, module.Version{Path: f[0], Version: v})
+		} else {
+			zips = append(zips, module.Version{Path: f[0], Version: f[1]})
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	return m.prewarm(ctx, mods, zips, exclude), nil
+}
+
+// PrewarmGoMod downloads the dependency closure of go.mod.
+// The module which exclude returns true is skipped and its dependencies are not followed.
+func (m *ModuleMirror) PrewarmGoMod(ctx context.Context, goMod []byte, exclude func(string) bool) (*PrewarmResult, error) {
+	f, err := modfile.Parse("go.mod", goMod, nil)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	return m.prewarmGoMod(ctx, f, nil, exclude), nil
+}
+
+// PrewarmModule downloads the module and its dependency closure.
+func (m *ModuleMirror) PrewarmModule(ctx context.Context, mv module.Version, exclude func(string) bool) (*PrewarmResult, error) {
+	if err := module.Check(mv.Path, mv.Version); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	p, err := mirrorPath(mv, ".mod")
+	if err != nil {
+		return nil, err
+	}
+	goMod, err := m.readAll(ctx, p)
+	if err != nil {
+		return &PrewarmResult{Errors: []string{fmt.Sprintf("%s: %v", mv, err)}}, nil
+	}
+	// The replace directives in the go.mod of the dependency are ignored by go command.
+	f, err := modfile.ParseLax("go.mod", goMod, nil)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	f.Replace = nil
+
+	return m.prewarmGoMod(ctx, f, &mv, exclude), nil
+}
+
+// prewarmGoMod walks the module graph from f. The go.mod files of all modules in the graph are downloaded.
+// If the main module has the pruned module graph (go 1.17 or later), go.mod lists the all modules which are
+// needed to build the main module. Otherwise, the archive of the version which is selected by MVS is downloaded.
+func (m *ModuleMirror) prewarmGoMod(ctx context.Context, f *modfile.File, main *module.Version, exclude func(string) bool) *PrewarmResult {
+	result := &PrewarmResult{}
+	var roots []module.Version
+	for _, r := range f.Require {
+		mv, ok := replaceModule(f, r.Mod)
+		if !ok {
+			continue
+		}
+		roots = append(roots, mv)
+	}
+
+	visited := make(map[module.Version]struct{})
+	selected := make(map[string]string)
+	queue := slices.Clone(roots)
+	for len(queue) > 0 {
+		mv := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[mv]; ok {
+			continue
+		}
+		visited[mv] = struct{}{}
+		if exclude != nil && exclude(mv.Path) {
+			continue
+		}
+		if v, ok := selected[mv.Path]; !ok || semver.Compare(mv.Version, v) > 0 {
+			selected[mv.Path] = mv.Version
+		}
+
+		p, err := mirrorPath(mv, ".mod")
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			continue
+		}
+		goMod, err := m.readAll(ctx, p)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", mv, err))
+			continue
+		}
+		result.GoMods++
+		dep, err := modfile.ParseLax(p, goMod, nil)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", mv, err))
+			continue
+		}
+		for _, r := range dep.Require {
+			queue = append(queue, r.Mod)
+		}
+	}
+
+	var zips []module.Version
+	if main != nil {
+		zips = append(zips, *main)
+	}
+	if f.Go != nil && semver.Compare("v"+f.Go.Version, "v1.17") >= 0 {
+		zips = append(zips, roots...)
+	} else {
+		for p, v := range selected {
+			zips = append(zips, module.Version{Path: p, Version: v})
+		}
+	}
+	zipResult := m.prewarm(ctx, nil, zips, exclude)
+	result.Modules = zipResult.Modules
+	result.Errors = append(result.Errors, zipResult.Errors...)
+	return result
+}
+
+// prewarm downloads go.mod of mods and the archive of zips.
+func (m *ModuleMirror) prewarm(ctx context.Context, mods, zips []module.Version, exclude func(string) bool) *PrewarmResult {
+	result := &PrewarmResult{}
+	download := func(mv module.Version, suffix string) bool {
+		if exclude != nil && exclude(mv.Path) {
+			return false
+		}
+		p, err := mirrorPath(mv, suffix)
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+			return false
+		}
+		r, err := m.open(ctx, p)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", p, err))
+			return false
+		}
+		defer r.Close()
+		if _, err := io.Copy(io.Discard, r); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", p, err))
+			return false
+		}
+		return true
+	}
+
+	for _, mv := range mods {
+		if download(mv, ".mod") {
+			result.GoMods++
+		}
+	}
+	slices.SortFunc(zips, func(a, b module.Version) int {
+		if c := strings.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		return semver.Compare(a.Version, b.Version)
+	})
+	for _, mv := range slices.Compact(zips) {
+		if download(mv, ".info") && download(mv, ".mod") && download(mv, ".zip") {
+			result.Modules = append(result.Modules, mv.String())
+		}
+	}
+	return result
+}
+
+func (m *ModuleMirror) readAll(ctx context.Context, p string) ([]byte, error) {
+	r, err := m.open(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	return buf, nil
+}
+
+// open returns the file of the module proxy protocol. p is the path of the protocol.
+func (m *ModuleMirror) open(ctx context.Context, p string) (io.ReadCloser, error) {
+	immutable := isImmutableMirrorPath(p)
+	if immutable {
+		if r, err := m.cache.GetMirror(ctx, p); err == nil {
+			return r, nil
+		}
+	}
+
+	f, err := m.download(ctx, p)
+	if err != nil {
+		var statusErr *upstreamStatusError
+		if errors.As(err, &statusErr) && !statusErr.Unavailable() {
+			return nil, err
+		}
+		if !immutable {
+			if r, cacheErr := m.cache.GetMirror(ctx, p); cacheErr == nil {
+				slogger.Log.Info("Serve the stored file because the upstream is unavailable", slog.String("path", p), slogger.E(err))
+				return r, nil
+			}
+		}
+		return nil, err
+	}
+
+	if err := m.cache.SaveMirror(ctx, p, f); err != nil {
+		slogger.Log.Warn("Failed to store the file of the upstream", slog.String("path", p), slogger.E(err))
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		f.Close()
+		return nil, xerrors.WithStack(err)
+	}
+	return f, nil
+}
+
+// download downloads the file from the upstream to the temporary file.
+// The archive may be large, so the body is not kept in memory.
+func (m *ModuleMirror) download(ctx context.Context, p string) (*tempFile, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, m.upstream.JoinPath(p).String(), nil)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	res, err := m.client.Do(req)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, xerrors.WithStack(&upstreamStatusError{StatusCode: res.StatusCode})
+	}
+
+	f, err := os.CreateTemp("", "gomodule-mirror-")
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	tf := &tempFile{File: f}
+	if _, err := io.Copy(tf, res.Body); err != nil {
+		tf.Close()
+		return nil, xerrors.WithStack(err)
+	}
+	if _, err := tf.Seek(0, io.SeekStart); err != nil {
+		tf.Close()
+		return nil, xerrors.WithStack(err)
+	}
+	return tf, nil
+}
+
+type upstreamStatusError struct {
+	StatusCode int
+}
+
+func (e *upstreamStatusError) Error() string {
+	return fmt.Sprintf("upstream returned %d", e.StatusCode)
+}
+
+// Unavailable returns true if the upstream can't answer the request now.
+// Otherwise, the status code is the answer of the upstream (e.g. the module is not found).
+func (e *upstreamStatusError) Unavailable() bool {
+	return e.StatusCode >= 500 || e.StatusCode == http.StatusTooManyRequests
+}
+
+// tempFile is the temporary file which is removed when it's closed.
+type tempFile struct {
+	*os.File
+}
+
+func (f *tempFile) Close() error {
+	err := f.File.Close()
+	os.Remove(f.Name())
+	return err
+}
+
+// mirrorPath returns the path of the module proxy protocol.
+func mirrorPath(mv module.Version, suffix string) (string, error) {
+	escapedPath, err := module.EscapePath(mv.Path)
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	escapedVersion, err := module.EscapeVersion(mv.Version)
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	return "/" + escapedPath + "/@v/" + escapedVersion + suffix, nil
+}
+
+// mirrorContentType returns the content type of the file. If p is not a file of the module proxy protocol,
+// mirrorContentType returns an empty string.
+func mirrorContentType(p string) string {
+	switch {
+	case strings.HasSuffix(p, "/@latest"), strings.Contains(p, "/@v/") && path.Ext(p) == ".info":
+		return "application/json"
+	case strings.HasSuffix(p, "/@v/list"), strings.Contains(p, "/@v/") && path.Ext(p) == ".mod":
+		return "text/plain; charset=UTF-8"
+	case strings.Contains(p, "/@v/") && path.Ext(p) == ".zip":
+		return "application/zip"
+	}
+	return ""
+}
+
+// isImmutableMirrorPath returns true if p is .info, .mod or .zip of the canonical version.
+// The version which is not canonical is the query (e.g. master) and the result may be changed.
+func isImmutableMirrorPath(p string) bool {
+	_, file, ok := strings.Cut(p, "/@v/")
+	if !ok {
+		return false
+	}
+	ext := path.Ext(file)
+	if ext != ".info" && ext != ".mod" && ext != ".zip" {
+		return false
+	}
+	v, err := module.UnescapeVersion(strings.TrimSuffix(file, ext))
+	if err != nil {
+		return false
+	}
+	return module.CanonicalVersion(v) == v
+}
+
+// replaceModule applies the replace directives of f to mv.
+// If mv is replaced by the directory, replaceModule returns false because it isn't downloaded from the upstream.
+func replaceModule(f *modfile.File, mv module.Version) (module.Version, bool) {
+	for _, r := range f.Replace {
+		if r.Old.Path != mv.Path || (r.Old.Version != "" && r.Old.Version != mv.Version) {
+			continue
+		}
+		if r.New.Version == "" {
+			return module.Version{}, false
+		}
+		return r.New, true
+	}
+	return mv, true
+}
@@ -0,0 +1,253 @@
+package gomodule
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/module"
+
+	"go.f110.dev/mono/go/logger/slogger"
+	"go.f110.dev/mono/go/storage"
+)
+
+func TestModuleMirror(t *testing.T) {
+	slogger.Init()
+	upstream := newFakeUpstreamProxy(map[string]string{
+		"example.com/a@v1.0.0": "",
+	})
+	m, backend := newTestModuleMirror(t, upstream)
+
+	get := func(p string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		m.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, p, nil))
+		return rec
+	}
+
+	rec := get("/example.com/a/@v/v1.0.0.zip")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "zip example.com/a@v1.0.0", rec.Body.String())
+	assert.Equal(t, "application/zip", rec.Header().Get("Content-Type"))
+	_, err := backend.Stat(context.Background(), "mirror/example.com/a/@v/v1.0.0.zip")
+	assert.NoError(t, err)
+	// The archive of the canonical version is served from the storage.
+	rec = get("/example.com/a/@v/v1.0.0.zip")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 1, upstream.requests("/example.com/a/@v/v1.0.0.zip"))
+
+	rec = get("/example.com/a/@v/list")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "v1.0.0\n", rec.Body.String())
+	rec = get("/example.com/unknown/@v/list")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = get("/example.com/a/@v/other")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// The upstream is down.
+	upstream.down.Store(true)
+	rec = get("/example.com/a/@v/list")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "v1.0.0\n", rec.Body.String())
+	assert.Equal(t, 2, upstream.requests("/example.com/a/@v/list"))
+	rec = get("/example.com/a/@v/v1.0.0.zip")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = get("/example.com/a/@latest")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	rec = get("/example.com/a/@v/v1.0.0.info")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestModuleMirrorPrewarm(t *testing.T) {
+	slogger.Init()
+	modules := map[string]string{
+		"example.com/a@v1.0.0": "require example.com/b v1.1.0\n",
+		"example.com/b@v1.0.0": "",
+		"example.com/b@v1.1.0": "require example.com/c v1.0.0\n",
+		"example.com/c@v1.0.0": "",
+	}
+	isPrivate := func(p string) bool { return p == "example.com/private" }
+
+	t.Run("GoMod", func(t *testing.T) {
+		upstream := newFakeUpstreamProxy(modules)
+		m, backend := newTestModuleMirror(t, upstream)
+
+		result, err := m.PrewarmGoMod(context.Background(), []byte(`module example.com/main
+
+go 1.21
+
+require (
+	example.com/a v1.0.0
+	example.com/b v1.1.0
+	example.com/c v1.0.0
+	example.com/local v1.0.0
+	example.com/private v1.0.0
+)
+
+replace example.com/local => ./local
+`), isPrivate)
+		require.NoError(t, err)
+		assert.Empty(t, result.Errors)
+		assert.Equal(t, []string{"example.com/a@v1.0.0", "example.com/b@v1.1.0", "example.com/c@v1.0.0"}, result.Modules)
+		assert.Equal(t, 3, result.GoMods)
+		for _, v := range []string{"example.com/a/@v/v1.0.0.zip", "example.com/b/@v/v1.1.0.info", "example.com/c/@v/v1.0.0.mod"} {
+			_, err := backend.Stat(context.Background(), "mirror/"+v)
+			assert.NoError(t, err, v)
+		}
+		assert.Equal(t, 0, upstream.requests("/example.com/private/@v/v1.0.0.mod"))
+	})
+
+	t.Run("UnprunedGoMod", func(t *testing.T) {
+		upstream := newFakeUpstreamProxy(modules)
+		m, _ := newTestModuleMirror(t, upstream)
+
+		result, err := m.PrewarmGoMod(context.Background(), []byte("module example.com/main\n\ngo 1.16\n\nrequire (\n\texample.com/a v1.0.0\n\texample.com/b v1.0.0\n)\n"), isPrivate)
+		require.NoError(t, err)
+		assert.Empty(t, result.Errors)
+		// example.com/b v1.1.0 is selected by MVS.
+		assert.Equal(t, []string{"example.com/a@v1.0.0", "example.com/b@v1.1.0", "example.com/c@v1.0.0"}, result.Modules)
+		assert.Equal(t, 4, result.GoMods)
+		assert.Equal(t, 0, upstream.requests("/example.com/b/@v/v1.0.0.zip"))
+	})
+
+	t.Run("GoSum", func(t *testing.T) {
+		upstream := newFakeUpstreamProxy(modules)
+		m, _ := newTestModuleMirror(t, upstream)
+
+		result, err := m.PrewarmGoSum(context.Background(), []byte(`example.com/a v1.0.0 h1:a=
+example.com/a v1.0.0/go.mod h1:a=
+example.com/b v1.0.0/go.mod h1:b=
+example.com/unknown v1.0.0/go.mod h1:unknown=
+`), isPrivate)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"example.com/a@v1.0.0"}, result.Modules)
+		assert.Equal(t, 2, result.GoMods)
+		assert.Len(t, result.Errors, 1)
+		assert.Equal(t, 1, upstream.requests("/example.com/b/@v/v1.0.0.mod"))
+		assert.Equal(t, 0, upstream.requests("/example.com/b/@v/v1.0.0.zip"))
+
+		_, err = m.PrewarmGoSum(context.Background(), []byte("example.com/a v1.0.0\n"), isPrivate)
+		assert.Error(t, err)
+	})
+
+	t.Run("Module", func(t *testing.T) {
+		upstream := newFakeUpstreamProxy(modules)
+		m, _ := newTestModuleMirror(t, upstream)
+
+		result, err := m.PrewarmModule(context.Background(), module.Version{Path: "example.com/a", Version: "v1.0.0"}, isPrivate)
+		require.NoError(t, err)
+		assert.Empty(t, result.Errors)
+		assert.Equal(t, []string{"example.com/a@v1.0.0", "example.com/b@v1.1.0", "example.com/c@v1.0.0"}, result.Modules)
+	})
+
+	t.Run("Endpoint", func(t *testing.T) {
+		upstream := newFakeUpstreamProxy(modules)
+		m, _ := newTestModuleMirror(t, upstream)
+		u, err := url.Parse("https://proxy.golang.org")
+		require.NoError(t, err)
+		s := NewProxyServer(":0", u, &ModuleProxy{}, nil, WithModuleMirror(m))
+
+		rec := httptest.NewRecorder()
+		s.r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/prewarm?module=example.com/b@v1.1.0", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var result PrewarmResult
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&result))
+		assert.Equal(t, []string{"example.com/b@v1.1.0", "example.com/c@v1.0.0"}, result.Modules)
+
+		rec = httptest.NewRecorder()
+		s.r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/prewarm?file=go.sum", strings.NewReader("example.com/unknown v1.0.0 h1:unknown=\n")))
+		assert.Equal(t, http.StatusBadGateway, rec.Code)
+		rec = httptest.NewRecorder()
+		s.r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/prewarm?file=other", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func newTestModuleMirror(t *testing.T, upstream *fakeUpstreamProxy) (*ModuleMirror, *storage.Mock) {
+	srv := httptest.NewServer(upstream)
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	backend := storage.NewMock()
+
+	return NewModuleMirror(u, &ModuleCache{objectStorage: backend}), backend
+}
+
+// fakeUpstreamProxy is the module proxy which serves the modules.
+// The key of modules is path@version and the value is the content of go.mod except the module directive.
+type fakeUpstreamProxy struct {
+	modules map[string]string
+	down    atomic.Bool
+
+	mu  sync.Mutex
+	req map[string]int
+}
+
+func newFakeUpstreamProxy(modules map[string]string) *fakeUpstreamProxy {
+	return &fakeUpstreamProxy{modules: modules, req: make(map[string]int)}
+}
+
+func (f *fakeUpstreamProxy) requests(p string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.req[p]
+}
+
+func (f *fakeUpstreamProxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	f.req[req.URL.Path]++
+	f.mu.Unlock()
+	if f.down.Load() {
+		http.Error(w, "", http.StatusServiceUnavailable)
+		return
+	}
+
+	modPath, file, ok := strings.Cut(strings.TrimPrefix(req.URL.Path, "/"), "/@v/")
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	if file == "list" {
+		var versions []string
+		for k := range f.modules {
+			if p, v, _ := strings.Cut(k, "@"); p == modPath {
+				versions = append(versions, v+"\n")
+			}
+		}
+		if len(versions) == 0 {
+			http.NotFound(w, req)
+			return
+		}
+		w.Write([]byte(strings.Join(versions, "")))
+		return
+	}
+
+	i := strings.LastIndex(file, ".")
+	if i < 0 {
+		http.NotFound(w, req)
+		return
+	}
+	mv := modPath + "@" + file[:i]
+	goMod, ok := f.modules[mv]
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+	switch file[i:] {
+	case ".info":
+		w.Write([]byte(`{"Version":"` + file[:i] + `"}`))
+	case ".mod":
+		w.Write([]byte("module " + modPath + "\n\n" + goMod))
+	case ".zip":
+		w.Write([]byte("zip " + mv))
+	default:
+		http.NotFound(w, req)
+	}
+}
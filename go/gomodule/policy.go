@@ -0,0 +1,171 @@
+package gomodule
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.f110.dev/xerrors"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// Policy decides whether the version of the module can be served.
+type Policy struct {
+	Deny          []*DenyRule          `yaml:"deny"`
+	Vulnerability *VulnerabilityPolicy `yaml:"vulnerability"`
+
+	vulnDB *VulnDB
+}
+
+// DenyRule denies the versions of the module.
+type DenyRule struct {
+	// Module is the regular expression of the module path.
+	Module string `yaml:"module"`
+	// Versions are the versions which are denied. The version can have the comparison operator
+	// (e.g. "<v1.2.0", ">=v2.0.0"). If Versions is empty, all versions are denied.
+	Versions []string `yaml:"versions"`
+	Reason   string   `yaml:"reason"`
+
+	match *regexp.Regexp
+}
+
+type VulnerabilityPolicy struct {
+	// DatabaseDir is the directory of the Go vulnerability database. The directory contains the OSV JSON files.
+	DatabaseDir string `yaml:"database_dir"`
+	// Ignore is the list of ID or alias of the vulnerability which is not considered.
+	Ignore []string `yaml:"ignore"`
+}
+
+// PolicyViolation is returned when the version of the module is not allowed by Policy.
+type PolicyViolation struct {
+	Module  string
+	Version string
+	Reason  string
+}
+
+func (e *PolicyViolation) Error() string {
+	return fmt.Sprintf("%s@%s is not allowed by the policy of the proxy: %s", e.Module, e.Version, e.Reason)
+}
+
+func (p *Policy) init() error {
+	for _, v := range p.Deny {
+		re, err := regexp.Compile(v.Module)
+		if err != nil {
+			return xerrors.WithStack(err)
+		}
+		v.match = re
+		for _, ver := range v.Versions {
+			if _, cmp := cutVersionOperator(ver); !semver.IsValid(cmp) {
+				return xerrors.Definef("invalid version in the deny rule of %s: %s", v.Module, ver).WithStack()
+			}
+		}
+	}
+
+	if p.Vulnerability != nil && p.Vulnerability.DatabaseDir != "" {
+		db, err := LoadVulnDB(p.Vulnerability.DatabaseDir)
+		if err != nil {
+			return err
+		}
+		p.vulnDB = db
+	}
+	return nil
+}
+
+// Check returns *PolicyViolation if the version of the module is not allowed.
+func (p *Policy) Check(mv module.Version) error {
+	if p == nil {
+		return nil
+	}
+
+	for _, rule := range p.Deny {
+		if !rule.match.MatchString(mv.Path) || !rule.matchVersion(mv.Version) {
+			continue
+		}
+		reason := rule.Reason
+		if reason == "" {
+			reason = "denied"
+		}
+		return xerrors.WithStack(&PolicyViolation{Module: mv.Path, Version: mv.Version, Reason: reason})
+	}
+
+	// The version which is not semver is the query (e.g. master). The query is checked after it is resolved.
+	if p.vulnDB != nil && semver.IsValid(mv.Version) {
+		for _, v := range p.vulnDB.Affected(mv) {
+			if p.Vulnerability.ignored(v) {
+				continue
+			}
+			reason := "vulnerable to " + v.ID
+			if v.Summary != "" {
+				reason += " (" + v.Summary + ")"
+			}
+			if fixed := v.FixedVersion(mv.Path); fixed != "" {
+				reason += ", fixed in " + fixed
+			}
+			return xerrors.WithStack(&PolicyViolation{Module: mv.Path, Version: mv.Version, Reason: reason})
+		}
+	}
+
+	return nil
+}
+
+func (r *DenyRule) matchVersion(version string) bool {
+	if len(r.Versions) == 0 {
+		return true
+	}
+
+	for _, v := range r.Versions {
+		op, target := cutVersionOperator(v)
+		if op != "" && !semver.IsValid(version) {
+			continue
+		}
+		cmp := semver.Compare(version, target)
+		switch op {
+		case "<":
+			if cmp < 0 {
+				return true
+			}
+		case "<=":
+			if cmp <= 0 {
+				return true
+			}
+		case ">":
+			if cmp > 0 {
+				return true
+			}
+		case ">=":
+			if cmp >= 0 {
+				return true
+			}
+		default:
+			if version == target {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (v *VulnerabilityPolicy) ignored(entry *OSVEntry) bool {
+	for _, id := range v.Ignore {
+		if entry.ID == id {
+			return true
+		}
+		for _, alias := range entry.Aliases {
+			if alias == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// cutVersionOperator splits the version into the comparison operator and the version.
+func cutVersionOperator(v string) (string, string) {
+	for _, op := range []string{"<=", ">=", "<", ">"} {
+		if after, ok := strings.CutPrefix(v, op); ok {
+			return op, strings.TrimSpace(after)
+		}
+	}
+	return "", v
+}
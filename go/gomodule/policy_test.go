@@ -0,0 +1,158 @@
+package gomodule
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/mod/module"
+
+	"go.f110.dev/mono/go/logger/slogger"
+)
+
+func TestReadConfig(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "old.yaml"), []byte("- module_name: go.f110.dev/private\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new.yaml"), []byte(`modules:
+  - module_name: go.f110.dev/private
+policy:
+  deny:
+    - module: github.com/example/bad
+      versions: ["<v1.2.0"]
+      reason: use v1.2.0 or later
+`), 0644))
+
+	conf, err := ReadConfig(filepath.Join(dir, "old.yaml"))
+	require.NoError(t, err)
+	require.Len(t, conf.Modules, 1)
+	assert.Equal(t, "go.f110.dev/private", conf.Modules[0].ModuleName)
+	assert.Nil(t, conf.Policy)
+
+	conf, err = ReadConfig(filepath.Join(dir, "new.yaml"))
+	require.NoError(t, err)
+	require.Len(t, conf.Modules, 1)
+	require.NotNil(t, conf.Policy)
+	assert.Error(t, conf.Policy.Check(module.Version{Path: "github.com/example/bad", Version: "v1.1.0"}))
+}
+
+func TestPolicy(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "ID"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "index"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index", "modules.json"), []byte(`[{"path":"github.com/example/vuln"}]`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ID", "GO-2024-0001.json"), []byte(`{
+  "id": "GO-2024-0001",
+  "aliases": ["CVE-2024-0001"],
+  "summary": "Remote code execution",
+  "affected": [{
+    "package": {"name": "github.com/example/vuln", "ecosystem": "Go"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}, {"fixed": "1.0.2"}, {"introduced": "1.1.0"}, {"fixed": "1.1.3"}]}]
+  }]
+}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ID", "GO-2024-0002.json"), []byte(`{
+  "id": "GO-2024-0002",
+  "aliases": ["CVE-2024-0002"],
+  "affected": [{
+    "package": {"name": "github.com/example/ignored", "ecosystem": "Go"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "0"}]}]
+  }]
+}`), 0644))
+
+	p := &Policy{
+		Deny: []*DenyRule{
+			{Module: "^github.com/example/bad$", Versions: []string{"v1.0.0", ">=v2.0.0"}, Reason: "broken"},
+			{Module: "^github.com/example/all$"},
+		},
+		Vulnerability: &VulnerabilityPolicy{DatabaseDir: dir, Ignore: []string{"CVE-2024-0002"}},
+	}
+	require.NoError(t, p.init())
+
+	cases := []struct {
+		Module  string
+		Version string
+		Reason  string
+	}{
+		{Module: "github.com/example/bad", Version: "v1.0.0", Reason: "broken"},
+		{Module: "github.com/example/bad", Version: "v1.0.1"},
+		{Module: "github.com/example/bad", Version: "v2.1.0", Reason: "broken"},
+		{Module: "github.com/example/bad", Version: "master"},
+		{Module: "github.com/example/all", Version: "v0.1.0", Reason: "denied"},
+		{Module: "github.com/example/vuln", Version: "v1.0.1", Reason: "vulnerable to GO-2024-0001 (Remote code execution), fixed in v1.1.3"},
+		{Module: "github.com/example/vuln", Version: "v1.0.2"},
+		{Module: "github.com/example/vuln", Version: "v1.1.2", Reason: "vulnerable to GO-2024-0001 (Remote code execution), fixed in v1.1.3"},
+		{Module: "github.com/example/vuln", Version: "v1.1.3"},
+		{Module: "github.com/example/ignored", Version: "v1.0.0"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.Module+"@"+tc.Version, func(t *testing.T) {
+			err := p.Check(module.Version{Path: tc.Module, Version: tc.Version})
+			if tc.Reason == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var violation *PolicyViolation
+			require.ErrorAs(t, err, &violation)
+			assert.Equal(t, tc.Reason, violation.Reason)
+		})
+	}
+
+	var nilPolicy *Policy
+	assert.NoError(t, nilPolicy.Check(module.Version{Path: "github.com/example/bad", Version: "v1.0.0"}))
+}
+
+func TestLatestAllowedVersion(t *testing.T) {
+	versions := []*ModuleVersion{
+		{Version: "v1.0.0", Semver: "v1.0.0"},
+		{Version: "v1.1.0", Semver: "v1.1.0"},
+		{Version: "v1.2.0", Semver: "v1.2.0"},
+		{Version: "v1.3.0", Semver: "v1.3.0"},
+	}
+	goMod := []byte("module github.com/example/mod\n\nretract (\n\tv1.3.0 // broken\n\t[v1.1.0, v1.2.0]\n)\n")
+	p := &Policy{Deny: []*DenyRule{{Module: "^github.com/example/mod$", Versions: []string{"v1.0.0"}}}}
+	require.NoError(t, p.init())
+
+	v := latestAllowedVersion("github.com/example/mod", versions, nil, nil)
+	assert.Equal(t, "v1.3.0", v.Version)
+	v = latestAllowedVersion("github.com/example/mod", versions, goMod, nil)
+	assert.Equal(t, "v1.0.0", v.Version)
+	// All versions are retracted or denied. The retracted version is chosen.
+	v = latestAllowedVersion("github.com/example/mod", versions, goMod, p)
+	assert.Equal(t, "v1.3.0", v.Version)
+	v = latestAllowedVersion("github.com/example/mod", versions[:1], goMod, p)
+	assert.Nil(t, v)
+}
+
+func TestProxyServerEnforcePolicy(t *testing.T) {
+	slogger.Init()
+	p := &Policy{Deny: []*DenyRule{{Module: "^example.com/a$", Versions: []string{"v1.0.0"}, Reason: "broken"}}}
+	require.NoError(t, p.init())
+	upstream := newFakeUpstreamProxy(map[string]string{
+		"example.com/a@v1.0.0": "",
+		"example.com/a@v1.0.1": "",
+	})
+	m, _ := newTestModuleMirror(t, upstream)
+	s := NewProxyServer(":0", m.upstream, &ModuleProxy{conf: Config{Policy: p}}, nil, WithModuleMirror(m))
+
+	cases := []struct {
+		Path string
+		Code int
+		Body string
+	}{
+		{Path: "/example.com/a/@v/v1.0.0.zip", Code: http.StatusForbidden, Body: "example.com/a@v1.0.0 is not allowed by the policy of the proxy: broken\n"},
+		{Path: "/example.com/a/@v/v1.0.0.info", Code: http.StatusForbidden},
+		{Path: "/example.com/a/@v/v1.0.0.mod", Code: http.StatusOK},
+		{Path: "/example.com/a/@v/v1.0.1.zip", Code: http.StatusOK},
+	}
+	for _, tc := range cases {
+		rec := httptest.NewRecorder()
+		s.r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.Path, nil))
+		assert.Equal(t, tc.Code, rec.Code, tc.Path)
+		if tc.Body != "" {
+			assert.Equal(t, tc.Body, rec.Body.String())
+		}
+	}
+}
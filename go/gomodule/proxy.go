@@ -4,19 +4,25 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/go-github/v85/github"
 	"go.f110.dev/xerrors"
+	"golang.org/x/mod/modfile"
 	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+	"golang.org/x/mod/sumdb/dirhash"
 	modzip "golang.org/x/mod/zip"
 
 	"go.f110.dev/mono/go/githubutil"
@@ -27,13 +33,19 @@ const (
 	moduleProxyUserAgent = "gomodule-proxy/v0.1 github.com/f110/gomodule-proxy"
 )
 
+// ErrChecksumMismatch is returned when the archive of the module is different from the first one.
+var ErrChecksumMismatch = xerrors.Define("gomodule: checksum mismatch")
+
 type ModuleProxy struct {
 	conf Config
 
 	fetcher         *ModuleFetcher
 	ghProxy         *GitHubProxy
+	gitProxy        *GitProxy
+	hosts           map[*ModuleSetting]ModuleHost
 	cache           *ModuleCache
 	removeBazelFile bool
+	checksumDB      *ChecksumDatabase
 
 	mu              sync.Mutex
 	confLookupCache map[string]*ModuleSetting
@@ -47,14 +59,37 @@ func RemoveBazelFiles(v bool) ModuleProxyOption {
 	}
 }
 
+// WithChecksumDatabase records the hash of the module to db at the first serve, and refuses to serve the module
+// which is different from the record. The proxy is also added to db as the source of the record.
+func WithChecksumDatabase(db *ChecksumDatabase) ModuleProxyOption {
+	return func(p *ModuleProxy) {
+		p.checksumDB = db
+		db.AddSource(p)
+	}
+}
+
 func NewModuleProxy(conf Config, moduleDir string, cache *ModuleCache, ghClient *github.Client, tokenProvider *githubutil.TokenProvider, caBundle []byte, opts ...ModuleProxyOption) *ModuleProxy {
 	p := &ModuleProxy{
 		conf:            conf,
 		fetcher:         NewModuleFetcher(moduleDir, cache, tokenProvider, caBundle),
 		ghProxy:         NewGitHubProxy(cache, ghClient),
+		gitProxy:        NewGitProxy(cache, nil),
+		hosts:           make(map[*ModuleSetting]ModuleHost),
 		cache:           cache,
 		confLookupCache: make(map[string]*ModuleSetting),
 	}
+	for _, v := range conf.Modules {
+		switch v.Host {
+		case HostGitHub:
+			p.hosts[v] = p.ghProxy
+		case HostGitea:
+			p.hosts[v] = NewGitProxy(cache, NewGiteaAPI(v.APIURL, v.Credential))
+		case HostGitLab:
+			p.hosts[v] = NewGitProxy(cache, NewGitLabAPI(v.APIURL, v.Credential))
+		case HostGit:
+			p.hosts[v] = p.gitProxy
+		}
+	}
 	for _, v := range opts {
 		v(p)
 	}
@@ -69,7 +104,7 @@ func (m *ModuleProxy) GetConfig(module string) *ModuleSetting {
 	}
 	m.mu.Unlock()
 
-	for _, v := range m.conf {
+	for _, v := range m.conf.Modules {
 		if v.match.MatchString(module) {
 			m.mu.Lock()
 			m.confLookupCache[module] = v
@@ -98,18 +133,21 @@ type Info struct {
 	Time    time.Time
 }
 
-func (m *ModuleProxy) Versions(ctx context.Context, module string) ([]string, error) {
-	moduleRoot, err := m.fetcher.Get(ctx, module, m.GetConfig(module))
+func (m *ModuleProxy) Versions(ctx context.Context, moduleName string) ([]string, error) {
+	moduleRoot, err := m.fetcher.Get(ctx, moduleName, m.GetConfig(moduleName))
 	if err != nil {
 		return nil, xerrors.WithStack(err)
 	}
-	mod := moduleRoot.FindModule(module)
+	mod := moduleRoot.FindModule(moduleName)
 	if mod == nil {
-		return nil, xerrors.Definef("%s is not found", module).WithStack()
+		return nil, xerrors.Definef("%s is not found", moduleName).WithStack()
 	}
 
 	var versions []string
 	for _, v := range mod.Versions {
+		if m.conf.Policy.Check(module.Version{Path: moduleName, Version: v.Semver}) != nil {
+			continue
+		}
 		versions = append(versions, v.Semver)
 	}
 	return versions, nil
@@ -131,42 +169,47 @@ func (m *ModuleProxy) GetInfo(ctx context.Context, moduleName, version string) (
 		}
 	}
 
-	if moduleRoot.IsGitHub {
-		if module.IsPseudoVersion(version) {
-			pseudoVersion, err := ParsePseudoVersion(version)
-			if err != nil {
-				return Info{}, xerrors.WithStack(err)
-			}
-			i, err := m.ghProxy.GetInfoRevision(ctx, moduleRoot, moduleName, pseudoVersion)
-			if err != nil {
-				return i, xerrors.WithStack(err)
-			}
-			return i, nil
-		} else {
-			i, err := m.ghProxy.GetInfo(ctx, moduleRoot, moduleName, version)
-			if err != nil {
-				return i, xerrors.WithStack(err)
-			}
-			return i, nil
+	host := m.moduleHost(moduleName, moduleRoot)
+	if module.IsPseudoVersion(version) {
+		pseudoVersion, err := ParsePseudoVersion(version)
+		if err != nil {
+			return Info{}, xerrors.WithStack(err)
+		}
+		i, err := host.GetInfoRevision(ctx, moduleRoot, moduleName, pseudoVersion)
+		if err != nil {
+			return i, xerrors.WithStack(err)
 		}
+		return i, nil
 	}
-
-	return Info{}, xerrors.Definef("%s is not found in %s", version, moduleName).WithStack()
+	i, err := host.GetInfo(ctx, moduleRoot, moduleName, version)
+	if err != nil {
+		return i, xerrors.WithStack(err)
+	}
+	return i, nil
 }
 
-func (m *ModuleProxy) GetLatestVersion(ctx context.Context, module string) (Info, error) {
-	moduleRoot, err := m.fetcher.Get(ctx, module, m.GetConfig(module))
+// GetLatestVersion returns the latest version of the module. The version which is retracted by go.mod of the
+// highest version or is not allowed by the policy is not chosen.
+func (m *ModuleProxy) GetLatestVersion(ctx context.Context, moduleName string) (Info, error) {
+	moduleRoot, err := m.fetcher.Get(ctx, moduleName, m.GetConfig(moduleName))
 	if err != nil {
 		return Info{}, xerrors.WithStack(err)
 	}
 
-	mod := moduleRoot.FindModule(module)
+	mod := moduleRoot.FindModule(moduleName)
 	if mod == nil {
-		return Info{}, xerrors.Definef("%s is not found", module).WithStack()
+		return Info{}, xerrors.Definef("%s is not found", moduleName).WithStack()
 	}
 	if len(mod.Versions) > 0 {
-		modVer := mod.Versions[len(mod.Versions)-1]
-		return Info{Version: modVer.Version, Time: modVer.Time}, nil
+		var goMod []byte
+		if v, err := m.GetGoMod(ctx, moduleName, mod.Versions[len(mod.Versions)-1].Semver); err != nil {
+			slogger.Log.Info("Failed to get go.mod for retractions", slog.String("module", moduleName), slogger.E(err))
+		} else {
+			goMod = []byte(v)
+		}
+		if modVer := latestAllowedVersion(moduleName, mod.Versions, goMod, m.conf.Policy); modVer != nil {
+			return Info{Version: modVer.Version, Time: modVer.Time}, nil
+		}
 	}
 
 	moduleVer, err := mod.LatestVersion(ctx)
@@ -191,30 +234,130 @@ func (m *ModuleProxy) GetGoMod(ctx context.Context, moduleName, version string)
 	if err == nil {
 		return string(goModFile), nil
 	}
-	if moduleRoot.IsGitHub {
-		if module.IsPseudoVersion(version) {
-			pseudoVersion, err := ParsePseudoVersion(version)
-			if err != nil {
-				return "", xerrors.WithStack(err)
-			}
-			modFile, err := m.ghProxy.GetGoModRevision(ctx, moduleRoot, goMod, pseudoVersion)
-			if err != nil {
-				return "", xerrors.WithStack(err)
-			}
-			return modFile, nil
-		} else {
-			modFile, err := m.ghProxy.GetGoMod(ctx, moduleRoot, goMod, version)
-			if err != nil {
-				return "", xerrors.WithStack(err)
+	host := m.moduleHost(moduleName, moduleRoot)
+	if module.IsPseudoVersion(version) {
+		pseudoVersion, err := ParsePseudoVersion(version)
+		if err != nil {
+			return "", xerrors.WithStack(err)
+		}
+		modFile, err := host.GetGoModRevision(ctx, moduleRoot, goMod, pseudoVersion)
+		if err != nil {
+			return "", xerrors.WithStack(err)
+		}
+		return modFile, nil
+	}
+	modFile, err := host.GetGoMod(ctx, moduleRoot, goMod, version)
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	return modFile, nil
+}
+
+func (m *ModuleProxy) GetZip(ctx context.Context, w io.Writer, moduleName, version string) error {
+	if m.checksumDB == nil {
+		return m.writeZip(ctx, w, moduleName, version)
+	}
+
+	// The zip has to be verified before sending.
+	f, err := os.CreateTemp("", "module-*.zip")
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if err := m.writeZip(ctx, f, moduleName, version); err != nil {
+		return err
+	}
+	zipHash, err := dirhash.HashZip(f.Name(), dirhash.Hash1)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	if err := m.verifyZip(ctx, moduleName, version, zipHash); err != nil {
+		return err
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return xerrors.WithStack(err)
+	}
+	if _, err := io.Copy(w, f); err != nil {
+		return xerrors.WithStack(err)
+	}
+	return nil
+}
+
+// GoSum returns the lines of go.sum for the module which is served by the proxy.
+func (m *ModuleProxy) GoSum(ctx context.Context, moduleName, version string) ([]byte, error) {
+	if !m.IsProxy(moduleName) {
+		return nil, &fs.PathError{Op: "gosum", Path: moduleName + "@" + version, Err: fs.ErrNotExist}
+	}
+
+	f, err := os.CreateTemp("", "module-*.zip")
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if err := m.writeZip(ctx, f, moduleName, version); err != nil {
+		return nil, err
+	}
+	zipHash, err := dirhash.HashZip(f.Name(), dirhash.Hash1)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	return m.goSumLines(ctx, moduleName, version, zipHash)
+}
+
+// verifyZip compares zipHash with the record of the checksum database. If the module is not recorded yet,
+// zipHash is recorded.
+func (m *ModuleProxy) verifyZip(ctx context.Context, moduleName, version, zipHash string) error {
+	mv := module.Version{Path: moduleName, Version: version}
+	record, err := m.checksumDB.Record(ctx, mv)
+	if errors.Is(err, fs.ErrNotExist) {
+		lines, err := m.goSumLines(ctx, moduleName, version, zipHash)
+		if err != nil {
+			return err
+		}
+		record, err = m.checksumDB.Add(ctx, mv, lines)
+		if err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	prefix := moduleName + " " + version + " "
+	for line := range strings.Lines(string(record)) {
+		if h, ok := strings.CutPrefix(strings.TrimSuffix(line, "\n"), prefix); ok {
+			if h != zipHash {
+				slogger.Log.Warn("The archive is different from the record",
+					slog.String("module", moduleName),
+					slog.String("version", version),
+					slog.String("recorded", h),
+					slog.String("actual", zipHash),
+				)
+				return xerrors.WithMessagef(ErrChecksumMismatch.WithStack(), "%s@%s: recorded %s, got %s", moduleName, version, h, zipHash)
 			}
-			return modFile, nil
+			return nil
 		}
 	}
+	return xerrors.WithMessagef(ErrChecksumMismatch.WithStack(), "%s@%s is not found in the record", moduleName, version)
+}
 
-	return "", xerrors.Definef("%s is not found", version).WithStack()
+func (m *ModuleProxy) goSumLines(ctx context.Context, moduleName, version, zipHash string) ([]byte, error) {
+	mod, err := m.GetGoMod(ctx, moduleName, version)
+	if err != nil {
+		return nil, err
+	}
+	modHash, err := dirhash.Hash1([]string{"go.mod"}, func(string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(mod)), nil
+	})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	return fmt.Appendf(nil, "%s %s %s\n%s %s/go.mod %s\n", moduleName, version, zipHash, moduleName, version, modHash), nil
 }
 
-func (m *ModuleProxy) GetZip(ctx context.Context, w io.Writer, moduleName, version string) error {
+func (m *ModuleProxy) writeZip(ctx context.Context, w io.Writer, moduleName, version string) error {
 	moduleRoot, err := m.fetcher.Get(ctx, moduleName, m.GetConfig(moduleName))
 	if err != nil {
 		return err
@@ -224,21 +367,72 @@ func (m *ModuleProxy) GetZip(ctx context.Context, w io.Writer, moduleName, versi
 	if err == nil {
 		return nil
 	}
+	host := m.moduleHost(moduleName, moduleRoot)
+	if module.IsPseudoVersion(version) {
+		return host.ArchiveRevision(ctx, w, moduleRoot, moduleName, version, m.removeBazelFile)
+	}
+	return host.Archive(ctx, w, moduleRoot, moduleName, version, m.removeBazelFile)
+}
+
+// moduleHost returns ModuleHost of the module. If the host is not specified by ModuleSetting, GitHub is used for
+// the repository on github.com and the local clone is used for others.
+func (m *ModuleProxy) moduleHost(moduleName string, moduleRoot *ModuleRoot) ModuleHost {
+	if setting := m.GetConfig(moduleName); setting != nil {
+		if h, ok := m.hosts[setting]; ok {
+			return h
+		}
+	}
 	if moduleRoot.IsGitHub {
-		slogger.Log.Debug("The module root is hosted by GitHub", slog.String("module", moduleName), slog.String("version", version))
-		if module.IsPseudoVersion(version) {
-			if err := m.ghProxy.ArchiveRevision(ctx, w, moduleRoot, moduleName, version, m.removeBazelFile); err != nil {
-				return err
-			}
+		slogger.Log.Debug("The module root is hosted by GitHub", slog.String("module", moduleName))
+		return m.ghProxy
+	}
+	return m.gitProxy
+}
+
+// latestAllowedVersion returns the highest version which is not retracted and is allowed by policy.
+// versions must be sorted in ascending order. goMod is go.mod of the highest version which has retract directives.
+// If all versions are retracted, the highest version which is allowed by policy is returned because the retracted
+// version is still available.
+func latestAllowedVersion(moduleName string, versions []*ModuleVersion, goMod []byte, policy *Policy) *ModuleVersion {
+	var retractions []*modfile.Retract
+	if goMod != nil {
+		if f, err := modfile.ParseLax("go.mod", goMod, nil); err == nil {
+			retractions = f.Retract
 		} else {
-			if err := m.ghProxy.Archive(ctx, w, moduleRoot, moduleName, version, m.removeBazelFile); err != nil {
-				return err
+			slogger.Log.Info("Failed to parse go.mod", slog.String("module", moduleName), slogger.E(err))
+		}
+	}
+	isRetracted := func(v string) bool {
+		for _, r := range retractions {
+			if semver.Compare(r.Low, v) <= 0 && semver.Compare(v, r.High) <= 0 {
+				return true
 			}
 		}
-		return nil
+		return false
 	}
 
-	return xerrors.Definef("%s is not found", version).WithStack()
+	var allowed *ModuleVersion
+	for _, v := range slices.Backward(versions) {
+		if policy.Check(module.Version{Path: moduleName, Version: v.Semver}) != nil {
+			continue
+		}
+		if !isRetracted(v.Semver) {
+			return v
+		}
+		if allowed == nil {
+			allowed = v
+		}
+	}
+	return allowed
+}
+
+// Policy returns the policy of the versions. Policy may be nil.
+func (m *ModuleProxy) Policy() *Policy {
+	return m.conf.Policy
+}
+
+func (m *ModuleProxy) ChecksumDatabase() *ChecksumDatabase {
+	return m.checksumDB
 }
 
 func (m *ModuleProxy) CachedModuleRoots() ([]*ModuleRoot, error) {
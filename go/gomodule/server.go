@@ -10,10 +10,13 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 	"go.f110.dev/xerrors"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/sumdb"
 
 	"go.f110.dev/mono/go/githubutil"
 	"go.f110.dev/mono/go/logger/slogger"
@@ -27,6 +30,8 @@ type ProxyServer struct {
 	ghClientFactory *githubutil.GitHubClientFactory
 
 	githubUserAuthentication *UserAuthentication
+	sumDBProxy               *SumDBProxy
+	mirror                   *ModuleMirror
 }
 
 type ProxyServerOption func(*ProxyServer)
@@ -37,6 +42,20 @@ func WithGitHubUserAuthentication(module *UserAuthentication) ProxyServerOption
 	}
 }
 
+// WithSumDBProxy serves the upstream checksum database at /sumdb/<name>.
+func WithSumDBProxy(p *SumDBProxy) ProxyServerOption {
+	return func(s *ProxyServer) {
+		s.sumDBProxy = p
+	}
+}
+
+// WithModuleMirror serves the public modules through ModuleMirror instead of forwarding to the upstream.
+func WithModuleMirror(m *ModuleMirror) ProxyServerOption {
+	return func(s *ProxyServer) {
+		s.mirror = m
+	}
+}
+
 func NewProxyServer(addr string, upstream *url.URL, proxy *ModuleProxy, ghClientFactory *githubutil.GitHubClientFactory, opts ...ProxyServerOption) *ProxyServer {
 	targetQuery := upstream.RawQuery
 	director := func(req *http.Request) {
@@ -68,17 +87,26 @@ func NewProxyServer(addr string, upstream *url.URL, proxy *ModuleProxy, ghClient
 		Handler: s.r,
 	}
 
+	// Endpoints for the checksum database
+	s.r.Methods(http.MethodGet).Path("/sumdb/{name}/supported").HandlerFunc(s.sumDBSupported)
+	s.r.Methods(http.MethodGet).PathPrefix("/sumdb/{name}/").HandlerFunc(s.sumDB)
+
 	// Endpoints for Go module proxy
 	s.r.Methods(http.MethodGet).Path("/{module:.+}/@v/list").HandlerFunc(s.handle(s.list))
-	s.r.Methods(http.MethodGet).Path("/{module:.+}/@v/{version}.info").HandlerFunc(s.handle(s.info))
+	s.r.Methods(http.MethodGet).Path("/{module:.+}/@v/{version}.info").HandlerFunc(s.enforcePolicy(s.handle(s.info)))
+	// go.mod of the denied version is still served. go command reads go.mod of the version which isn't selected
+	// to build the module graph.
 	s.r.Methods(http.MethodGet).Path("/{module:.+}/@v/{version}.mod").HandlerFunc(s.handle(s.mod))
-	s.r.Methods(http.MethodGet).Path("/{module:.+}/@v/{version}.zip").HandlerFunc(s.handle(s.zip))
+	s.r.Methods(http.MethodGet).Path("/{module:.+}/@v/{version}.zip").HandlerFunc(s.enforcePolicy(s.handle(s.zip)))
 	s.r.Methods(http.MethodGet).Path("/{module:.+}/@latest").HandlerFunc(s.handle(s.latest))
 
 	// Endpoints for frontend
 	s.r.Methods(http.MethodGet).Path("/").HandlerFunc(s.index)
 	s.r.Methods(http.MethodGet).Path("/{module:.+}/@v/invalidate").HandlerFunc(s.handle(s.invalidate))
 	s.r.Methods(http.MethodPost).Path("/flush_all").HandlerFunc(s.flushAll) // This endpoint is hidden.
+	if s.mirror != nil {
+		s.r.Methods(http.MethodPost).Path("/prewarm").HandlerFunc(s.prewarm) // This endpoint is hidden.
+	}
 	if s.githubUserAuthentication != nil {
 		s.githubUserAuthentication.RegisterHttpMux(s.r)
 	}
@@ -122,10 +150,40 @@ func (s *ProxyServer) handle(h func(w http.ResponseWriter, req *http.Request, mo
 			return
 		}
 
+		if s.mirror != nil {
+			s.mirror.ServeHTTP(w, req)
+			return
+		}
 		s.rr.ServeHTTP(w, req)
 	}
 }
 
+// enforcePolicy refuses the version which is not allowed by the policy. go command prints the body of the response.
+// 403 is used because go command falls back to the next entry of GOPROXY (e.g. direct) if the proxy returns 404 or 410.
+func (s *ProxyServer) enforcePolicy(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		vars := mux.Vars(req)
+		modulePath, err := module.UnescapePath(vars["module"])
+		if err != nil {
+			h(w, req)
+			return
+		}
+		version, err := module.UnescapeVersion(vars["version"])
+		if err != nil {
+			h(w, req)
+			return
+		}
+
+		var violation *PolicyViolation
+		if err := s.proxy.Policy().Check(module.Version{Path: modulePath, Version: version}); errors.As(err, &violation) {
+			slogger.Log.Info("Refuse the module", slog.String("module", modulePath), slog.String("version", version), slog.String("reason", violation.Reason))
+			http.Error(w, violation.Error(), http.StatusForbidden)
+			return
+		}
+		h(w, req)
+	}
+}
+
 func (s *ProxyServer) index(w http.ResponseWriter, _ *http.Request) {
 	cachedModuleRoots, err := s.proxy.CachedModuleRoots()
 	if err != nil {
@@ -166,6 +224,49 @@ func (s *ProxyServer) flushAll(w http.ResponseWriter, _ *http.Request) {
 	}
 }
 
+// prewarm downloads the modules from the upstream ahead of time.
+// The modules are specified by the query "module" (e.g. module=github.com/f110/mono@v1.0.0) or
+// the body which is go.mod or go.sum. The type of the body is specified by the query "file".
+func (s *ProxyServer) prewarm(w http.ResponseWriter, req *http.Request) {
+	var result *PrewarmResult
+	var err error
+	if v := req.URL.Query().Get("module"); v != "" {
+		p, version, ok := strings.Cut(v, "@")
+		if !ok {
+			http.Error(w, "module must be path@version", http.StatusBadRequest)
+			return
+		}
+		result, err = s.mirror.PrewarmModule(req.Context(), module.Version{Path: p, Version: version}, s.proxy.IsProxy)
+	} else {
+		buf, readErr := io.ReadAll(io.LimitReader(req.Body, 10*1024*1024))
+		if readErr != nil {
+			http.Error(w, "failed to read the body", http.StatusBadRequest)
+			return
+		}
+		switch req.URL.Query().Get("file") {
+		case "", "go.mod":
+			result, err = s.mirror.PrewarmGoMod(req.Context(), buf, s.proxy.IsProxy)
+		case "go.sum":
+			result, err = s.mirror.PrewarmGoSum(req.Context(), buf, s.proxy.IsProxy)
+		default:
+			http.Error(w, "file must be go.mod or go.sum", http.StatusBadRequest)
+			return
+		}
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if len(result.Errors) > 0 {
+		w.WriteHeader(http.StatusBadGateway)
+	}
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slogger.Log.Info("Failed to encode to json", slogger.E(err))
+	}
+}
+
 func (s *ProxyServer) list(w http.ResponseWriter, req *http.Request, module, _ string) {
 	vers, err := s.proxy.Versions(req.Context(), module)
 	if err != nil {
@@ -207,6 +308,10 @@ func (s *ProxyServer) mod(w http.ResponseWriter, req *http.Request, module, vers
 
 func (s *ProxyServer) zip(w http.ResponseWriter, req *http.Request, module, version string) {
 	err := s.proxy.GetZip(req.Context(), w, module, version)
+	if errors.Is(err, ErrChecksumMismatch) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
 	if err != nil {
 		slogger.Log.Info("Failed to create zip", slogger.E(err))
 		http.Error(w, "", http.StatusInternalServerError)
@@ -227,6 +332,35 @@ func (s *ProxyServer) latest(w http.ResponseWriter, req *http.Request, module, _
 	}
 }
 
+// sumDBHandler returns the handler for the checksum database. If the proxy doesn't serve the database,
+// sumDBHandler returns nil.
+func (s *ProxyServer) sumDBHandler(name string) http.Handler {
+	if db := s.proxy.ChecksumDatabase(); db != nil && db.Name() == name {
+		return sumdb.NewServer(db)
+	}
+	if s.sumDBProxy != nil && s.sumDBProxy.Name() == name {
+		return s.sumDBProxy
+	}
+	return nil
+}
+
+func (s *ProxyServer) sumDBSupported(w http.ResponseWriter, req *http.Request) {
+	if s.sumDBHandler(mux.Vars(req)["name"]) == nil {
+		http.NotFound(w, req)
+		return
+	}
+}
+
+func (s *ProxyServer) sumDB(w http.ResponseWriter, req *http.Request) {
+	name := mux.Vars(req)["name"]
+	h := s.sumDBHandler(name)
+	if h == nil {
+		http.NotFound(w, req)
+		return
+	}
+	http.StripPrefix("/sumdb/"+name, h).ServeHTTP(w, req)
+}
+
 func (s *ProxyServer) readiness(w http.ResponseWriter, req *http.Request) {
 	if !s.proxy.Ready() {
 		http.Error(w, "Proxy is not ready", http.StatusServiceUnavailable)
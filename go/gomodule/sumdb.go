@@ -0,0 +1,198 @@
+package gomodule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"go.f110.dev/xerrors"
+	"golang.org/x/mod/sumdb"
+
+	"go.f110.dev/mono/go/logger/slogger"
+)
+
+const (
+	DefaultSumDBURL = "https://sum.golang.org"
+	// DefaultSumDBKey is the verifier key of sum.golang.org.
+	DefaultSumDBKey = "sum.golang.org+033de0ae+Ac4zctda0e5eza+HJyk9SxEdh+s3Ze6jbPCmQ6Y+ej/"
+)
+
+// SumDBProxy proxies the requests to the upstream checksum database.
+// The tiles and the lookup results are immutable, so they are cached. The latest signed tree is never cached.
+type SumDBProxy struct {
+	name     string
+	key      string
+	upstream *url.URL
+	cache    *ModuleCache
+	client   *http.Client
+
+	verifier *sumdb.Client
+	mu       sync.Mutex
+	latest   []byte
+}
+
+var _ GoSumSource = &SumDBProxy{}
+
+// NewSumDBProxy returns SumDBProxy for the checksum database at upstream. key is the verifier key of
+// the database and the name of the database is taken from it.
+func NewSumDBProxy(upstream *url.URL, key string, cache *ModuleCache) *SumDBProxy {
+	name, _, _ := strings.Cut(key, "+")
+	p := &SumDBProxy{
+		name:     name,
+		key:      key,
+		upstream: upstream,
+		cache:    cache,
+		client:   &http.Client{Transport: &httpTransport{}},
+	}
+	p.verifier = sumdb.NewClient(&sumDBClientOps{proxy: p})
+	return p
+}
+
+func (p *SumDBProxy) Name() string {
+	return p.name
+}
+
+// ServeHTTP serves the request which the prefix /sumdb/<name> is stripped.
+func (p *SumDBProxy) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != "/latest" && !strings.HasPrefix(req.URL.Path, "/lookup/") && !strings.HasPrefix(req.URL.Path, "/tile/") {
+		http.NotFound(w, req)
+		return
+	}
+
+	data, err := p.fetch(req.Context(), req.URL.Path)
+	if err != nil {
+		var statusErr *sumDBStatusError
+		if errors.As(err, &statusErr) {
+			http.Error(w, "", statusErr.StatusCode)
+			return
+		}
+		slogger.Log.Info("Failed to fetch from the checksum database", slog.String("path", req.URL.Path), slogger.E(err))
+		http.Error(w, "", http.StatusBadGateway)
+		return
+	}
+	if strings.HasPrefix(req.URL.Path, "/tile/") && !strings.Contains(req.URL.Path, "/data/") {
+		w.Header().Set("Content-Type", "application/octet-stream")
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=UTF-8")
+	}
+	w.Write(data)
+}
+
+// GoSum returns the lines of go.sum from the upstream. The lines are verified by the signed tree.
+func (p *SumDBProxy) GoSum(_ context.Context, path, version string) ([]byte, error) {
+	var buf strings.Builder
+	for _, v := range []string{version, version + "/go.mod"} {
+		lines, err := p.verifier.Lookup(path, v)
+		if err != nil {
+			return nil, xerrors.WithStack(err)
+		}
+		for _, line := range lines {
+			buf.WriteString(line)
+			buf.WriteByte('\n')
+		}
+	}
+	return []byte(buf.String()), nil
+}
+
+func (p *SumDBProxy) fetch(ctx context.Context, path string) ([]byte, error) {
+	// The latest signed tree is changed frequently.
+	if path != "/latest" {
+		if data, err := p.cache.GetSumDB(p.name + path); err == nil {
+			return data, nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.upstream.JoinPath(path).String(), nil)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	res, err := p.client.Do(req)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, xerrors.WithStack(&sumDBStatusError{StatusCode: res.StatusCode})
+	}
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	if path != "/latest" {
+		expiration := 60 * 60 * 24 * 7 // 1 week
+		if strings.Contains(path, ".p/") {
+			// The partial tile is replaced by the full tile.
+			expiration = 60 * 60 // 1 hour
+		}
+		if err := p.cache.SetSumDB(p.name+path, data, expiration); err != nil {
+			slogger.Log.Warn("Failed set the cache of the checksum database", slog.String("path", path), slogger.E(err))
+		}
+	}
+	return data, nil
+}
+
+type sumDBStatusError struct {
+	StatusCode int
+}
+
+func (e *sumDBStatusError) Error() string {
+	return fmt.Sprintf("checksum database returned %d", e.StatusCode)
+}
+
+// sumDBClientOps is sumdb.ClientOps for verifying the records of the upstream.
+// The latest signed tree is kept in memory, so the client trusts the first tree after starting the process.
+type sumDBClientOps struct {
+	proxy *SumDBProxy
+}
+
+var _ sumdb.ClientOps = &sumDBClientOps{}
+
+func (o *sumDBClientOps) ReadRemote(path string) ([]byte, error) {
+	return o.proxy.fetch(context.Background(), path)
+}
+
+func (o *sumDBClientOps) ReadConfig(file string) ([]byte, error) {
+	if file == "key" {
+		return []byte(o.proxy.key), nil
+	}
+	if strings.HasSuffix(file, "/latest") {
+		o.proxy.mu.Lock()
+		defer o.proxy.mu.Unlock()
+		return o.proxy.latest, nil
+	}
+	return nil, &fs.PathError{Op: "read", Path: file, Err: fs.ErrNotExist}
+}
+
+func (o *sumDBClientOps) WriteConfig(file string, old, new []byte) error {
+	o.proxy.mu.Lock()
+	defer o.proxy.mu.Unlock()
+
+	if string(o.proxy.latest) != string(old) {
+		return sumdb.ErrWriteConflict
+	}
+	o.proxy.latest = new
+	return nil
+}
+
+func (o *sumDBClientOps) ReadCache(file string) ([]byte, error) {
+	// ReadRemote has the cache already.
+	return nil, &fs.PathError{Op: "read", Path: file, Err: fs.ErrNotExist}
+}
+
+func (o *sumDBClientOps) WriteCache(_ string, _ []byte) {}
+
+func (o *sumDBClientOps) Log(msg string) {
+	slogger.Log.Debug(msg)
+}
+
+func (o *sumDBClientOps) SecurityError(msg string) {
+	slogger.Log.Error("The checksum database is misbehaving", slog.String("msg", msg))
+}
@@ -0,0 +1,174 @@
+package gomodule
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"go.f110.dev/xerrors"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// OSVEntry is the entry of the vulnerability in OSV format. Only the fields which are needed by Policy are decoded.
+type OSVEntry struct {
+	ID        string        `json:"id"`
+	Aliases   []string      `json:"aliases"`
+	Summary   string        `json:"summary"`
+	Withdrawn string        `json:"withdrawn"`
+	Affected  []OSVAffected `json:"affected"`
+}
+
+type OSVAffected struct {
+	Package struct {
+		Name      string `json:"name"`
+		Ecosystem string `json:"ecosystem"`
+	} `json:"package"`
+	Ranges []OSVRange `json:"ranges"`
+}
+
+type OSVRange struct {
+	Type   string     `json:"type"`
+	Events []OSVEvent `json:"events"`
+}
+
+// OSVEvent is the event of the range. The version in the Go vulnerability database doesn't have the prefix "v".
+type OSVEvent struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+}
+
+// affects returns true if version is in the range. The events are sorted by the version.
+func (r OSVRange) affects(version string) bool {
+	if r.Type != "SEMVER" {
+		return false
+	}
+
+	affected := false
+	for _, e := range r.Events {
+		switch {
+		case e.Introduced != "":
+			if e.Introduced == "0" || semver.Compare(version, "v"+e.Introduced) >= 0 {
+				affected = true
+			}
+		case e.Fixed != "":
+			if semver.Compare(version, "v"+e.Fixed) >= 0 {
+				affected = false
+			}
+		case e.LastAffected != "":
+			if semver.Compare(version, "v"+e.LastAffected) > 0 {
+				affected = false
+			}
+		}
+	}
+	return affected
+}
+
+// FixedVersion returns the highest fixed version of the module. If the vulnerability is not fixed yet,
+// FixedVersion returns an empty string.
+func (e *OSVEntry) FixedVersion(modulePath string) string {
+	var fixed string
+	for _, a := range e.Affected {
+		if a.Package.Name != modulePath {
+			continue
+		}
+		for _, r := range a.Ranges {
+			for _, ev := range r.Events {
+				if ev.Fixed != "" && semver.Compare("v"+ev.Fixed, fixed) > 0 {
+					fixed = "v" + ev.Fixed
+				}
+			}
+		}
+	}
+	return fixed
+}
+
+// VulnDB is the local copy of the Go vulnerability database.
+type VulnDB struct {
+	// entries is the map of the module path and the entries which affect the module.
+	entries map[string][]*OSVEntry
+}
+
+// LoadVulnDB reads all OSV JSON files in dir. The files in the index directory are not the entry, so these are skipped.
+func LoadVulnDB(dir string) (*VulnDB, error) {
+	db := &VulnDB{entries: make(map[string][]*OSVEntry)}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == "index" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		entry := &OSVEntry{}
+		if err := json.Unmarshal(buf, entry); err != nil {
+			return xerrors.WithMessagef(err, "failed to parse %s", path)
+		}
+		db.add(entry)
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	return db, nil
+}
+
+func (db *VulnDB) add(entry *OSVEntry) {
+	if entry.Withdrawn != "" {
+		return
+	}
+
+	seen := make(map[string]struct{})
+	for _, a := range entry.Affected {
+		if a.Package.Ecosystem != "" && a.Package.Ecosystem != "Go" {
+			continue
+		}
+		if _, ok := seen[a.Package.Name]; ok {
+			continue
+		}
+		seen[a.Package.Name] = struct{}{}
+		db.entries[a.Package.Name] = append(db.entries[a.Package.Name], entry)
+	}
+}
+
+// Affected returns the entries which affect the version of the module.
+func (db *VulnDB) Affected(mv module.Version) []*OSVEntry {
+	var affected []*OSVEntry
+	for _, entry := range db.entries[mv.Path] {
+		if entry.affects(mv) {
+			affected = append(affected, entry)
+		}
+	}
+	return affected
+}
+
+func (e *OSVEntry) affects(mv module.Version) bool {
+	for _, a := range e.Affected {
+		if a.Package.Name != mv.Path {
+			continue
+		}
+		if len(a.Ranges) == 0 {
+			// All versions are affected if the range is not specified.
+			return true
+		}
+		for _, r := range a.Ranges {
+			if r.affects(mv.Version) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackend(t *testing.T) {
+	backends := map[string]func(t *testing.T) Backend{
+		"Mock": func(_ *testing.T) Backend { return NewMock() },
+		"Local": func(t *testing.T) Backend {
+			l, err := NewLocal(t.TempDir())
+			require.NoError(t, err)
+			return l
+		},
+	}
+
+	for name, newBackend := range backends {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			t.Run("GetRange", func(t *testing.T) {
+				b := newBackend(t)
+				require.NoError(t, b.Put(ctx, "dir/object", []byte("0123456789")))
+
+				cases := []struct {
+					Offset, Length int64
+					Expect         string
+				}{
+					{Offset: 0, Length: -1, Expect: "0123456789"},
+					{Offset: 2, Length: 3, Expect: "234"},
+					{Offset: 7, Length: -1, Expect: "789"},
+					{Offset: 8, Length: 10, Expect: "89"},
+					{Offset: 3, Length: 0, Expect: ""},
+				}
+				for _, tc := range cases {
+					obj, err := b.GetRange(ctx, "dir/object", tc.Offset, tc.Length)
+					require.NoError(t, err)
+					buf, err := io.ReadAll(obj.Body)
+					require.NoError(t, err)
+					require.NoError(t, obj.Body.Close())
+					assert.Equal(t, tc.Expect, string(buf))
+					assert.EqualValues(t, 10, obj.Size)
+				}
+
+				_, err := b.GetRange(ctx, "dir/unknown", 0, 1)
+				assert.ErrorIs(t, err, ErrObjectNotFound)
+			})
+
+			t.Run("Stat", func(t *testing.T) {
+				b := newBackend(t)
+				require.NoError(t, b.Put(ctx, "dir/object", []byte("foobar")))
+
+				obj, err := b.Stat(ctx, "dir/object")
+				require.NoError(t, err)
+				assert.Equal(t, "dir/object", obj.Name)
+				assert.EqualValues(t, 6, obj.Size)
+				assert.NotEmpty(t, obj.ETag)
+				assert.Nil(t, obj.Body)
+
+				_, err = b.Stat(ctx, "dir")
+				assert.ErrorIs(t, err, ErrObjectNotFound)
+				_, err = b.Stat(ctx, "unknown")
+				assert.ErrorIs(t, err, ErrObjectNotFound)
+			})
+
+			t.Run("Copy", func(t *testing.T) {
+				b := newBackend(t)
+				require.NoError(t, b.Put(ctx, "src", []byte("foobar")))
+				require.NoError(t, b.Copy(ctx, "src", "dir/dst"))
+
+				obj, err := b.Get(ctx, "dir/dst")
+				require.NoError(t, err)
+				buf, err := io.ReadAll(obj.Body)
+				require.NoError(t, err)
+				obj.Body.Close()
+				assert.Equal(t, "foobar", string(buf))
+
+				err = b.Copy(ctx, "unknown", "dst")
+				assert.ErrorIs(t, err, ErrObjectNotFound)
+			})
+
+			t.Run("PutIf", func(t *testing.T) {
+				b := newBackend(t)
+				etag, err := b.PutIf(ctx, "lock", []byte("1"), PutCondition{IfNoneMatch: true})
+				require.NoError(t, err)
+				_, err = b.PutIf(ctx, "lock", []byte("2"), PutCondition{IfNoneMatch: true})
+				assert.ErrorIs(t, err, ErrPreconditionFailed)
+
+				obj, err := b.Stat(ctx, "lock")
+				require.NoError(t, err)
+				assert.Equal(t, obj.ETag, etag)
+				_, err = b.PutIf(ctx, "lock", []byte("22"), PutCondition{IfMatch: "unknown"})
+				assert.ErrorIs(t, err, ErrPreconditionFailed)
+				newETag, err := b.PutIf(ctx, "lock", []byte("22"), PutCondition{IfMatch: etag})
+				require.NoError(t, err)
+				assert.NotEqual(t, etag, newETag)
+				_, err = b.PutIf(ctx, "lock", []byte("333"), PutCondition{IfMatch: etag})
+				assert.ErrorIs(t, err, ErrPreconditionFailed)
+				_, err = b.PutIf(ctx, "not-found", []byte("1"), PutCondition{IfMatch: etag})
+				assert.ErrorIs(t, err, ErrPreconditionFailed)
+
+				obj, err = b.Stat(ctx, "lock")
+				require.NoError(t, err)
+				assert.Equal(t, obj.ETag, newETag)
+
+				obj, err = b.Get(ctx, "lock")
+				require.NoError(t, err)
+				buf, err := io.ReadAll(obj.Body)
+				require.NoError(t, err)
+				obj.Body.Close()
+				assert.Equal(t, "22", string(buf))
+			})
+
+			t.Run("ConcurrentPutIf", func(t *testing.T) {
+				b := newBackend(t)
+				var wg sync.WaitGroup
+				var mu sync.Mutex
+				succeeded := 0
+				for i := 0; i < 10; i++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						if _, err := b.PutIf(ctx, "lock", []byte("1"), PutCondition{IfNoneMatch: true}); err == nil {
+							mu.Lock()
+							succeeded++
+							mu.Unlock()
+						}
+					}()
+				}
+				wg.Wait()
+				assert.Equal(t, 1, succeeded)
+			})
+
+			t.Run("ListAndDelete", func(t *testing.T) {
+				b := newBackend(t)
+				for _, v := range []string{"foo/bar", "foo/baz/qux", "foobar", "other"} {
+					require.NoError(t, b.Put(ctx, v, []byte(v)))
+				}
+
+				objs, err := b.List(ctx, "foo/")
+				require.NoError(t, err)
+				assert.ElementsMatch(t, []string{"foo/bar", "foo/baz/qux"}, objectNames(objs))
+
+				require.NoError(t, b.Delete(ctx, "foo/baz/qux"))
+				require.NoError(t, b.Delete(ctx, "foo/baz/qux"))
+				objs, err = b.List(ctx, "")
+				require.NoError(t, err)
+				assert.ElementsMatch(t, []string{"foo/bar", "foobar", "other"}, objectNames(objs))
+			})
+		})
+	}
+}
+
+func TestLocal(t *testing.T) {
+	ctx := context.Background()
+	l, err := NewLocal(t.TempDir())
+	require.NoError(t, err)
+
+	for _, v := range []string{"", "/etc/passwd", "../outside", "foo/../../outside", ".tmp/object", "."} {
+		err := l.Put(ctx, v, []byte("foo"))
+		assert.ErrorIs(t, err, ErrInvalidObjectName, v)
+	}
+
+	// Prefix of List doesn't have to be a directory.
+	require.NoError(t, l.Put(ctx, "foo/bar", []byte("foo")))
+	require.NoError(t, l.Put(ctx, "foo/baz", []byte("foo")))
+	objs, err := l.List(ctx, "foo/ba")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"foo/bar", "foo/baz"}, objectNames(objs))
+	objs, err = l.List(ctx, "unknown/")
+	require.NoError(t, err)
+	assert.Empty(t, objs)
+}
+
+func objectNames(objs []*Object) []string {
+	names := make([]string, 0, len(objs))
+	for _, v := range objs {
+		names = append(names, v.Name)
+	}
+	return names
+}
@@ -3,11 +3,16 @@ package storage
 import (
 	"bytes"
 	"context"
+	"errors"
+	"hash/crc32"
 	"io"
 	"log/slog"
+	"net/http"
+	"strconv"
 
 	"cloud.google.com/go/storage"
 	"go.f110.dev/xerrors"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 
@@ -16,6 +21,9 @@ import (
 
 type GCSOptions struct {
 	Retries int
+	// PartSize is the size of each chunk of the resumable upload. The failed chunk is retried
+	// without sending the chunks which are already uploaded.
+	PartSize int
 }
 
 type Google struct {
@@ -24,7 +32,7 @@ type Google struct {
 	opt            GCSOptions
 }
 
-var _ storageInterface = &Google{}
+var _ Backend = &Google{}
 
 func NewGCS(creds []byte, bucket string, opt GCSOptions) *Google {
 	return &Google{credentialJSON: creds, bucket: bucket, opt: opt}
@@ -45,24 +53,31 @@ func (g *Google) PutReader(ctx context.Context, name string, data io.Reader) err
 	}
 
 	obj := client.Bucket(g.bucket).Object(name)
-	retryCount := 1
-	for {
-		w := obj.NewWriter(ctx)
-		if _, err := io.Copy(w, data); err != nil {
-			if g.opt.Retries > 0 && retryCount < g.opt.Retries {
-				slogger.Log.Info("Retrying to write a object", slog.Int("retryCount", retryCount), slog.String("key", name))
-				retryCount++
-				continue
-			}
-			return xerrors.WithStack(err)
-		}
-		if err := w.Close(); err != nil {
-			return xerrors.WithStack(err)
+	if g.opt.Retries > 0 {
+		// The upload can't be retried from the beginning because data is consumed. Instead, each chunk is retried.
+		obj = obj.Retryer(storage.WithMaxAttempts(g.opt.Retries), storage.WithPolicy(storage.RetryAlways))
+	}
+	w := obj.NewWriter(ctx)
+	if g.opt.PartSize > 0 {
+		w.ChunkSize = g.opt.PartSize
+	}
+	h := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	if _, err := io.Copy(io.MultiWriter(w, h), data); err != nil {
+		w.Close()
+		return xerrors.WithStack(err)
+	}
+	if err := w.Close(); err != nil {
+		return xerrors.WithStack(err)
+	}
+	if w.Attrs().CRC32C != h.Sum32() {
+		if err := obj.Delete(ctx); err != nil {
+			slogger.Log.Warn("Failed to delete the broken object", slog.String("key", name), slogger.E(err))
 		}
-
-		slogger.Log.Info("Succeeded upload", slog.String("object_name", obj.ObjectName()), slog.String("bucket", obj.BucketName()))
-		return nil
+		return xerrors.WithMessagef(ErrChecksumMismatch, "%s", name)
 	}
+
+	slogger.Log.Info("Succeeded upload", slog.String("object_name", obj.ObjectName()), slog.String("bucket", obj.BucketName()))
+	return nil
 }
 
 func (g *Google) List(ctx context.Context, prefix string) ([]*Object, error) {
@@ -84,6 +99,7 @@ func (g *Google) List(ctx context.Context, prefix string) ([]*Object, error) {
 			Name:         objAttr.Name,
 			LastModified: objAttr.Updated,
 			Size:         objAttr.Size,
+			ETag:         generationETag(objAttr),
 		})
 	}
 
@@ -113,6 +129,10 @@ func (g *Google) Delete(ctx context.Context, key string) error {
 }
 
 func (g *Google) Get(ctx context.Context, name string) (*Object, error) {
+	return g.GetRange(ctx, name, 0, -1)
+}
+
+func (g *Google) GetRange(ctx context.Context, name string, offset, length int64) (*Object, error) {
 	client, err := storage.NewClient(ctx, option.WithCredentialsJSON(g.credentialJSON))
 	if err != nil {
 		return nil, xerrors.WithStack(err)
@@ -121,11 +141,16 @@ func (g *Google) Get(ctx context.Context, name string) (*Object, error) {
 	obj := client.Bucket(g.bucket).Object(name)
 	attrs, err := obj.Attrs(ctx)
 	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, ErrObjectNotFound
+		}
 		return nil, xerrors.WithStack(err)
 	}
+	// Read the generation which is returned by Attrs even if the object is overwritten.
+	obj = obj.Generation(attrs.Generation)
 	retryCount := 1
 	for {
-		r, err := obj.NewReader(ctx)
+		r, err := obj.NewRangeReader(ctx, offset, length)
 		if err != nil {
 			if g.opt.Retries > 0 && retryCount < g.opt.Retries {
 				slogger.Log.Info("Retrying to get a object", slog.Int("retryCount", retryCount), slog.String("key", name))
@@ -139,7 +164,91 @@ func (g *Google) Get(ctx context.Context, name string) (*Object, error) {
 			Name:         obj.ObjectName(),
 			Size:         attrs.Size,
 			LastModified: attrs.Updated,
+			ETag:         generationETag(attrs),
 			Body:         r,
 		}, nil
 	}
 }
+
+func (g *Google) Stat(ctx context.Context, name string) (*Object, error) {
+	client, err := storage.NewClient(ctx, option.WithCredentialsJSON(g.credentialJSON))
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	attrs, err := client.Bucket(g.bucket).Object(name).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, xerrors.WithStack(err)
+	}
+	return &Object{
+		Name:         attrs.Name,
+		Size:         attrs.Size,
+		LastModified: attrs.Updated,
+		ETag:         generationETag(attrs),
+	}, nil
+}
+
+// PutIf writes the object with the preconditions of the generation. IfMatch is the value of ETag of Object.
+func (g *Google) PutIf(ctx context.Context, name string, data []byte, cond PutCondition) (string, error) {
+	client, err := storage.NewClient(ctx, option.WithCredentialsJSON(g.credentialJSON))
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+
+	var c storage.Conditions
+	if cond.IfNoneMatch {
+		c.DoesNotExist = true
+	}
+	if cond.IfMatch != "" {
+		gen, err := strconv.ParseInt(cond.IfMatch, 10, 64)
+		if err != nil {
+			return "", xerrors.WithMessagef(ErrPreconditionFailed, "invalid generation: %s", cond.IfMatch)
+		}
+		c.GenerationMatch = gen
+	}
+	w := client.Bucket(g.bucket).Object(name).If(c).NewWriter(ctx)
+	if _, err := w.Write(data); err != nil {
+		w.Close()
+		return "", xerrors.WithStack(err)
+	}
+	if err := w.Close(); err != nil {
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusPreconditionFailed {
+			return "", ErrPreconditionFailed
+		}
+		return "", xerrors.WithStack(err)
+	}
+	return generationETag(w.Attrs()), nil
+}
+
+func (g *Google) Copy(ctx context.Context, src, dst string) error {
+	client, err := storage.NewClient(ctx, option.WithCredentialsJSON(g.credentialJSON))
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	bucket := client.Bucket(g.bucket)
+	retryCount := 1
+	for {
+		if _, err := bucket.Object(dst).CopierFrom(bucket.Object(src)).Run(ctx); err != nil {
+			if errors.Is(err, storage.ErrObjectNotExist) {
+				return ErrObjectNotFound
+			}
+			if g.opt.Retries > 0 && retryCount < g.opt.Retries {
+				slogger.Log.Info("Retrying to copy a object", slog.Int("retryCount", retryCount), slog.String("src", src), slog.String("dst", dst))
+				retryCount++
+				continue
+			}
+			return xerrors.WithStack(err)
+		}
+		return nil
+	}
+}
+
+// generationETag returns the generation as ETag. The precondition of GCS is specified by the generation.
+func generationETag(attrs *storage.ObjectAttrs) string {
+	return strconv.FormatInt(attrs.Generation, 10)
+}
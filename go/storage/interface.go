@@ -10,23 +10,45 @@ import (
 
 var (
 	ErrObjectNotFound = xerrors.New("storage: object not found")
+	// ErrPreconditionFailed is returned by PutIf when the condition is not satisfied.
+	ErrPreconditionFailed = xerrors.New("storage: precondition failed")
 )
 
-// storageInterface defines common interface for the object storage.
-// This interface is intended to type check not intended to used by other package.
-type storageInterface interface {
+// Backend defines common interface for the object storage.
+type Backend interface {
 	Name() string
 	Put(ctx context.Context, name string, data []byte) error
 	PutReader(ctx context.Context, name string, data io.Reader) error
+	// PutIf writes the object only if cond is satisfied and returns ETag of the written object.
+	// If cond is not satisfied, PutIf returns ErrPreconditionFailed.
+	PutIf(ctx context.Context, name string, data []byte, cond PutCondition) (string, error)
 	Delete(ctx context.Context, name string) error
+	// Get returns the object. The caller must close Body.
 	Get(ctx context.Context, name string) (*Object, error)
+	// GetRange returns the object which is sliced from offset to offset+length.
+	// If length is a negative value, Body is read until the end of the object.
+	// Size of the returned value is the size of the whole object.
+	GetRange(ctx context.Context, name string, offset, length int64) (*Object, error)
+	// Stat returns the metadata of the object. Body is nil.
+	Stat(ctx context.Context, name string) (*Object, error)
 	List(ctx context.Context, prefix string) ([]*Object, error)
+	Copy(ctx context.Context, src, dst string) error
+}
+
+// PutCondition is the condition for PutIf.
+type PutCondition struct {
+	// IfNoneMatch is true if the object must not exist.
+	IfNoneMatch bool
+	// IfMatch is the ETag of the object which must be overwritten.
+	IfMatch string
 }
 
 type Object struct {
 	Name         string
 	Size         int64
 	LastModified time.Time
-	Body         io.ReadCloser
-	Err          error
+	// ETag is the opaque value which is changed when the object is overwritten.
+	ETag string
+	Body io.ReadCloser
+	Err  error
 }
@@ -0,0 +1,350 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.f110.dev/xerrors"
+)
+
+var ErrInvalidObjectName = xerrors.New("storage: invalid object name")
+
+// localTempDir is the directory for writing the objects. The object is renamed after all data is written.
+// The name under this directory is not accepted as the name of the object.
+const localTempDir = ".tmp"
+
+// Local is the object storage on the local filesystem. The name of the object is the relative path
+// from the root directory.
+// The object is written to the temporary file and renamed, so the reader never sees the partial object.
+type Local struct {
+	root string
+
+	// mu serializes PutIf with IfMatch in this process.
+	mu sync.Mutex
+}
+
+var _ Backend = &Local{}
+
+func NewLocal(root string) (*Local, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	return &Local{root: root}, nil
+}
+
+func (l *Local) Name() string {
+	return "local"
+}
+
+func (l *Local) Put(ctx context.Context, name string, data []byte) error {
+	return l.PutReader(ctx, name, bytes.NewReader(data))
+}
+
+func (l *Local) PutReader(_ context.Context, name string, data io.Reader) error {
+	p, err := l.path(name)
+	if err != nil {
+		return err
+	}
+	tmp, err := l.writeTemp(data)
+	if err != nil {
+		return err
+	}
+	return l.rename(tmp, p)
+}
+
+// PutIf writes the object with the condition. IfNoneMatch is atomic even if the other process writes
+// the same object. IfMatch is atomic only among the callers of PutIf in this process.
+func (l *Local) PutIf(_ context.Context, name string, data []byte, cond PutCondition) (string, error) {
+	p, err := l.path(name)
+	if err != nil {
+		return "", err
+	}
+	tmp, err := l.writeTemp(bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	// The written object has the same ETag as the temporary file because both link(2) and rename(2)
+	// keep the modification time.
+	info, err := os.Stat(tmp)
+	if err != nil {
+		os.Remove(tmp)
+		return "", xerrors.WithStack(err)
+	}
+	etag := localETag(info)
+
+	if cond.IfNoneMatch {
+		defer os.Remove(tmp)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			return "", xerrors.WithStack(err)
+		}
+		// link(2) fails if the destination exists.
+		if err := os.Link(tmp, p); err != nil {
+			if errors.Is(err, fs.ErrExist) {
+				return "", xerrors.WithStack(ErrPreconditionFailed)
+			}
+			return "", xerrors.WithStack(err)
+		}
+		return etag, nil
+	}
+	if cond.IfMatch != "" {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		info, err := os.Stat(p)
+		if err != nil || localETag(info) != cond.IfMatch {
+			os.Remove(tmp)
+			return "", xerrors.WithStack(ErrPreconditionFailed)
+		}
+	}
+	if err := l.rename(tmp, p); err != nil {
+		return "", err
+	}
+	return etag, nil
+}
+
+func (l *Local) Delete(_ context.Context, name string) error {
+	p, err := l.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return xerrors.WithStack(err)
+	}
+
+	// Remove the empty parent directories. os.Remove fails if the directory is not empty.
+	for dir := filepath.Dir(p); dir != l.root; dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+func (l *Local) Get(ctx context.Context, name string) (*Object, error) {
+	return l.GetRange(ctx, name, 0, -1)
+}
+
+func (l *Local) GetRange(_ context.Context, name string, offset, length int64) (*Object, error) {
+	p, err := l.path(name)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, xerrors.WithStack(ErrObjectNotFound)
+		}
+		return nil, xerrors.WithStack(err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, xerrors.WithStack(err)
+	}
+	if info.IsDir() {
+		f.Close()
+		return nil, xerrors.WithStack(ErrObjectNotFound)
+	}
+	if offset > 0 {
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			f.Close()
+			return nil, xerrors.WithStack(err)
+		}
+	}
+
+	obj := localObject(name, info)
+	obj.Body = f
+	if length >= 0 {
+		obj.Body = &limitedReadCloser{Reader: io.LimitReader(f, length), Closer: f}
+	}
+	return obj, nil
+}
+
+func (l *Local) Stat(_ context.Context, name string) (*Object, error) {
+	p, err := l.path(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(p)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, xerrors.WithStack(ErrObjectNotFound)
+		}
+		return nil, xerrors.WithStack(err)
+	}
+	if info.IsDir() {
+		return nil, xerrors.WithStack(ErrObjectNotFound)
+	}
+	return localObject(name, info), nil
+}
+
+// List returns the objects which have prefix in the order of the name. prefix is not the directory
+// like S3, so "foo" matches "foo/bar" and "foobar".
+func (l *Local) List(_ context.Context, prefix string) ([]*Object, error) {
+	dir := l.root
+	if i := strings.LastIndex(prefix, "/"); i > 0 {
+		p, err := l.path(prefix[:i])
+		if err != nil {
+			return nil, err
+		}
+		dir = p
+	}
+
+	var objs []*Object
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		rel, err := filepath.Rel(l.root, p)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if d.IsDir() {
+			if name == localTempDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasPrefix(name, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				// The object is deleted while walking.
+				return nil
+			}
+			return err
+		}
+		objs = append(objs, localObject(name, info))
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	sort.Slice(objs, func(i, j int) bool { return objs[i].Name < objs[j].Name })
+	return objs, nil
+}
+
+func (l *Local) Copy(_ context.Context, src, dst string) error {
+	srcPath, err := l.path(src)
+	if err != nil {
+		return err
+	}
+	dstPath, err := l.path(dst)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(srcPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return xerrors.WithStack(ErrObjectNotFound)
+		}
+		return xerrors.WithStack(err)
+	}
+	defer f.Close()
+
+	tmp, err := l.writeTemp(f)
+	if err != nil {
+		return err
+	}
+	return l.rename(tmp, dstPath)
+}
+
+// path returns the file path of the object. The name which points outside of the root is rejected.
+func (l *Local) path(name string) (string, error) {
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains(name, "\\") {
+		return "", xerrors.WithMessagef(ErrInvalidObjectName, "%q", name)
+	}
+	for _, v := range strings.Split(name, "/") {
+		if v == ".." {
+			return "", xerrors.WithMessagef(ErrInvalidObjectName, "%q", name)
+		}
+	}
+	p := path.Clean(name)
+	if p == "." || p == localTempDir || strings.HasPrefix(p, localTempDir+"/") {
+		return "", xerrors.WithMessagef(ErrInvalidObjectName, "%q", name)
+	}
+	return filepath.Join(l.root, filepath.FromSlash(p)), nil
+}
+
+// writeTemp writes data to the temporary file and returns the path of it.
+func (l *Local) writeTemp(data io.Reader) (string, error) {
+	dir := filepath.Join(l.root, localTempDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	f, err := os.CreateTemp(dir, "object-")
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+	if _, err := io.Copy(f, data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", xerrors.WithStack(err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", xerrors.WithStack(err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", xerrors.WithStack(err)
+	}
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		os.Remove(f.Name())
+		return "", xerrors.WithStack(err)
+	}
+	return f.Name(), nil
+}
+
+func (l *Local) rename(tmp, p string) error {
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		os.Remove(tmp)
+		return xerrors.WithStack(err)
+	}
+	if err := os.Rename(tmp, p); err != nil {
+		os.Remove(tmp)
+		return xerrors.WithStack(err)
+	}
+	return nil
+}
+
+func localObject(name string, info fs.FileInfo) *Object {
+	return &Object{
+		Name:         name,
+		Size:         info.Size(),
+		LastModified: info.ModTime(),
+		ETag:         localETag(info),
+	}
+}
+
+// localETag returns ETag from the modification time and the size. The object is always replaced by
+// the new file, so ETag is changed when the object is overwritten.
+func localETag(info fs.FileInfo) string {
+	return fmt.Sprintf("%x-%x", info.ModTime().UnixNano(), info.Size())
+}
+
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
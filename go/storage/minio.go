@@ -32,6 +32,10 @@ type MinIOOptions struct {
 	AccessKey       string
 	SecretAccessKey string
 	Retries         int
+	// PartSize is the size of each part of the multipart upload.
+	PartSize uint64
+	// Concurrency is the number of parts which are uploaded at the same time.
+	Concurrency int
 
 	Dev bool
 
@@ -188,7 +192,7 @@ type MinIO struct {
 	opt    MinIOOptions
 }
 
-var _ storageInterface = &MinIO{}
+var _ Backend = &MinIO{}
 
 // NewMinIOStorage returns the client for MinIO
 // Deprecated: Use the client for S3 instead.
@@ -214,9 +218,18 @@ func (m *MinIO) PutReader(ctx context.Context, name string, r io.Reader) error {
 		return xerrors.WithStack(err)
 	}
 
+	opt := minio.PutObjectOptions{
+		PartSize: m.opt.PartSize,
+		// MinIO verifies the checksum of each part and the whole object.
+		Checksum: minio.ChecksumSHA256,
+	}
+	if m.opt.Concurrency > 0 {
+		opt.NumThreads = uint(m.opt.Concurrency)
+		opt.ConcurrentStreamParts = true
+	}
 	retryCount := 1
 	for {
-		_, err = mc.PutObject(ctx, m.bucket, name, r, -1, minio.PutObjectOptions{})
+		_, err = mc.PutObject(ctx, m.bucket, name, r, -1, opt)
 		if err != nil {
 			if m.opt.Retries > 0 && retryCount < m.opt.Retries {
 				slogger.Log.Info("Retrying put a object", slog.Int("retryCount", retryCount), slog.String("key", name))
@@ -240,6 +253,7 @@ func (m *MinIO) List(ctx context.Context, prefix string) ([]*Object, error) {
 			Name:         v.Key,
 			LastModified: v.LastModified,
 			Size:         v.Size,
+			ETag:         v.ETag,
 		})
 	}
 	return objs, nil
@@ -277,6 +291,37 @@ ListObjects:
 }
 
 func (m *MinIO) Get(ctx context.Context, name string) (*Object, error) {
+	return m.getObject(ctx, name, minio.GetObjectOptions{})
+}
+
+func (m *MinIO) GetRange(ctx context.Context, name string, offset, length int64) (*Object, error) {
+	// The response of the ranged request doesn't have the size of the whole object.
+	info, err := m.Stat(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if length == 0 || offset >= info.Size {
+		info.Body = io.NopCloser(bytes.NewReader(nil))
+		return info, nil
+	}
+
+	end := info.Size - 1
+	if length > 0 && offset+length-1 < end {
+		end = offset + length - 1
+	}
+	var opt minio.GetObjectOptions
+	if err := opt.SetRange(offset, end); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	obj, err := m.getObject(ctx, name, opt)
+	if err != nil {
+		return nil, err
+	}
+	obj.Size = info.Size
+	return obj, nil
+}
+
+func (m *MinIO) getObject(ctx context.Context, name string, opt minio.GetObjectOptions) (*Object, error) {
 	mc, err := m.opt.Client(ctx)
 	if err != nil {
 		return nil, xerrors.WithStack(err)
@@ -284,7 +329,7 @@ func (m *MinIO) Get(ctx context.Context, name string) (*Object, error) {
 
 	retryCount := 1
 	for {
-		obj, err := mc.GetObject(ctx, m.bucket, name, minio.GetObjectOptions{})
+		obj, err := mc.GetObject(ctx, m.bucket, name, opt)
 		if err != nil {
 			if m.opt.Retries > 0 && retryCount < m.opt.Retries {
 				slogger.Log.Info("Retrying get a object", slog.Int("retryCount", retryCount), slog.String("key", name))
@@ -296,17 +341,98 @@ func (m *MinIO) Get(ctx context.Context, name string) (*Object, error) {
 
 		info, err := obj.Stat()
 		if err != nil {
+			obj.Close()
+			if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+				return nil, ErrObjectNotFound
+			}
 			return nil, xerrors.WithStack(err)
 		}
 		return &Object{
 			Name:         name,
 			Size:         info.Size,
 			LastModified: info.LastModified,
+			ETag:         info.ETag,
 			Body:         obj,
 		}, nil
 	}
 }
 
+func (m *MinIO) Stat(ctx context.Context, name string) (*Object, error) {
+	mc, err := m.opt.Client(ctx)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	retryCount := 1
+	for {
+		info, err := mc.StatObject(ctx, m.bucket, name, minio.StatObjectOptions{})
+		if err != nil {
+			if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+				return nil, ErrObjectNotFound
+			}
+			if m.opt.Retries > 0 && retryCount < m.opt.Retries {
+				slogger.Log.Info("Retrying stat a object", slog.Int("retryCount", retryCount), slog.String("key", name))
+				retryCount++
+				continue
+			}
+			return nil, xerrors.WithStack(err)
+		}
+		return &Object{
+			Name:         name,
+			Size:         info.Size,
+			LastModified: info.LastModified,
+			ETag:         info.ETag,
+		}, nil
+	}
+}
+
+func (m *MinIO) PutIf(ctx context.Context, name string, data []byte, cond PutCondition) (string, error) {
+	mc, err := m.opt.Client(ctx)
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+
+	var opt minio.PutObjectOptions
+	if cond.IfNoneMatch {
+		opt.SetMatchETagExcept("*")
+	}
+	if cond.IfMatch != "" {
+		opt.SetMatchETag(cond.IfMatch)
+	}
+	info, err := mc.PutObject(ctx, m.bucket, name, bytes.NewReader(data), int64(len(data)), opt)
+	if err != nil {
+		if minio.ToErrorResponse(err).StatusCode == http.StatusPreconditionFailed {
+			return "", ErrPreconditionFailed
+		}
+		return "", xerrors.WithStack(err)
+	}
+	return info.ETag, nil
+}
+
+func (m *MinIO) Copy(ctx context.Context, src, dst string) error {
+	mc, err := m.opt.Client(ctx)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	retryCount := 1
+	for {
+		_, err := mc.CopyObject(ctx, minio.CopyDestOptions{Bucket: m.bucket, Object: dst}, minio.CopySrcOptions{Bucket: m.bucket, Object: src})
+		if err != nil {
+			if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+				return ErrObjectNotFound
+			}
+			if m.opt.Retries > 0 && retryCount < m.opt.Retries {
+				slogger.Log.Info("Retrying copy a object", slog.Int("retryCount", retryCount), slog.String("src", src), slog.String("dst", dst))
+				retryCount++
+				continue
+			}
+			return xerrors.WithStack(err)
+		}
+		return nil
+	}
+}
+
 func (m *MinIO) Delete(ctx context.Context, name string) error {
 	mc, err := m.opt.Client(ctx)
 	if err != nil {
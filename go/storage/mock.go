@@ -4,13 +4,19 @@ import (
 	"bytes"
 	"container/list"
 	"context"
+	"crypto/md5"
+	"encoding/hex"
 	"io"
 	"strings"
+	"sync"
+	"time"
 
 	"go.f110.dev/xerrors"
 )
 
+// Mock is the in-memory object storage. It is safe for concurrent use.
 type Mock struct {
+	mu   sync.Mutex
 	root *objectNode
 }
 
@@ -19,6 +25,9 @@ type objectNode struct {
 
 	Data     []byte
 	Children []*objectNode
+	// ModTime is the time when Data is written last.
+	ModTime time.Time
+	ETag    string
 
 	parent *objectNode
 }
@@ -35,7 +44,22 @@ func (n *objectNode) FullPath() string {
 	return p
 }
 
-var _ storageInterface = &Mock{}
+func (n *objectNode) setData(data []byte) {
+	if data == nil {
+		// nil Data means the node is a directory.
+		data = []byte{}
+	}
+	n.Data = data
+	n.ModTime = time.Now()
+	h := md5.Sum(data)
+	n.ETag = hex.EncodeToString(h[:])
+}
+
+func (n *objectNode) object() *Object {
+	return &Object{Name: n.FullPath(), Size: int64(len(n.Data)), LastModified: n.ModTime, ETag: n.ETag}
+}
+
+var _ Backend = &Mock{}
 
 func NewMock() *Mock {
 	return &Mock{root: &objectNode{}}
@@ -46,6 +70,8 @@ func (m *Mock) Name() string {
 }
 
 func (m *Mock) AddTree(name string, data []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.addNode(name, data)
 }
 
@@ -57,16 +83,14 @@ func (m *Mock) addNode(name string, data []byte) {
 	if len(p) == 1 {
 		for _, v := range m.root.Children {
 			if v.Name == p[0] {
-				v.Data = data
+				v.setData(data)
 				return
 			}
 		}
 
-		m.root.Children = append(m.root.Children, &objectNode{
-			Name:   p[0],
-			Data:   data,
-			parent: m.root,
-		})
+		n := &objectNode{Name: p[0], parent: m.root}
+		n.setData(data)
+		m.root.Children = append(m.root.Children, n)
 	}
 
 	nodeName := p[0]
@@ -79,7 +103,7 @@ NextChild:
 				if len(p) == 0 {
 					// This is end node.
 					// The node is already exists. So update data.
-					v.Data = data
+					v.setData(data)
 					break NextChild
 				}
 				curr = v
@@ -99,7 +123,7 @@ NextChild:
 			p = p[1:]
 		} else {
 			// This is end node
-			newNode.Data = data
+			newNode.setData(data)
 			break
 		}
 	}
@@ -151,21 +175,39 @@ func (m *Mock) deleteNode(name string) {
 	}
 }
 
-func (m *Mock) Get(_ context.Context, name string) (*Object, error) {
+func (m *Mock) Get(ctx context.Context, name string) (*Object, error) {
+	return m.GetRange(ctx, name, 0, -1)
+}
+
+func (m *Mock) GetRange(_ context.Context, name string, offset, length int64) (*Object, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	n := m.findNode(name)
-	if n != nil {
-		return &Object{
-			Name: name,
-			Size: int64(len(n.Data)),
-			Body: io.NopCloser(bytes.NewReader(n.Data)),
-		}, nil
+	if n == nil || n.Data == nil {
+		return nil, xerrors.WithStack(ErrObjectNotFound)
 	}
+	obj := n.object()
+	obj.Body = io.NopCloser(bytes.NewReader(sliceRange(n.Data, offset, length)))
+	return obj, nil
+}
+
+func (m *Mock) Stat(_ context.Context, name string) (*Object, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	return nil, xerrors.WithStack(ErrObjectNotFound)
+	n := m.findNode(name)
+	if n == nil || n.Data == nil {
+		return nil, xerrors.WithStack(ErrObjectNotFound)
+	}
+	return n.object(), nil
 }
 
 func (m *Mock) List(_ context.Context, prefix string) ([]*Object, error) {
-	n := m.findNode(prefix)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := m.findNode(strings.TrimSuffix(prefix, "/"))
 	if n == nil {
 		return nil, nil
 	}
@@ -180,7 +222,7 @@ func (m *Mock) List(_ context.Context, prefix string) ([]*Object, error) {
 
 		obj := e.Value.(*objectNode)
 		if obj.Data != nil {
-			objs = append(objs, &Object{Name: obj.FullPath(), Size: int64(len(obj.Data))})
+			objs = append(objs, obj.object())
 		}
 		for _, v := range obj.Children {
 			stack.PushBack(v)
@@ -190,6 +232,9 @@ func (m *Mock) List(_ context.Context, prefix string) ([]*Object, error) {
 }
 
 func (m *Mock) Put(_ context.Context, name string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.addNode(name, data)
 	return nil
 }
@@ -202,7 +247,51 @@ func (m *Mock) PutReader(ctx context.Context, name string, data io.Reader) error
 	return m.Put(ctx, name, buf)
 }
 
+func (m *Mock) PutIf(_ context.Context, name string, data []byte, cond PutCondition) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := m.findNode(name)
+	exists := n != nil && n.Data != nil
+	if cond.IfNoneMatch && exists {
+		return "", xerrors.WithStack(ErrPreconditionFailed)
+	}
+	if cond.IfMatch != "" && (!exists || n.ETag != cond.IfMatch) {
+		return "", xerrors.WithStack(ErrPreconditionFailed)
+	}
+	m.addNode(name, data)
+	return m.findNode(name).ETag, nil
+}
+
+func (m *Mock) Copy(_ context.Context, src, dst string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := m.findNode(src)
+	if n == nil || n.Data == nil {
+		return xerrors.WithStack(ErrObjectNotFound)
+	}
+	m.addNode(dst, append([]byte{}, n.Data...))
+	return nil
+}
+
 func (m *Mock) Delete(_ context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.deleteNode(name)
 	return nil
 }
+
+// sliceRange returns the range of GetRange from data.
+func sliceRange(data []byte, offset, length int64) []byte {
+	size := int64(len(data))
+	if offset > size {
+		offset = size
+	}
+	end := size
+	if length >= 0 && offset+length < size {
+		end = offset + length
+	}
+	return data[offset:end]
+}
@@ -11,11 +11,13 @@ import (
 	"iter"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/credentials"
-	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
@@ -36,8 +38,12 @@ type S3Options struct {
 	Endpoint        string
 	PathStyle       bool // This is important option if you want to use with MinIO.
 	CACertFile      string
-	PartSize        uint64
-	Retries         int
+	// PartSize is the size of each part of the multipart upload. The object which is smaller than PartSize
+	// is uploaded by a single request.
+	PartSize uint64
+	// Concurrency is the number of parts which are uploaded at the same time.
+	Concurrency int
+	Retries     int
 
 	Name      string
 	Namespace string
@@ -160,25 +166,12 @@ func (s *S3Options) getS3Endpoint(ctx context.Context, name, namespace string, p
 	return endpoint, forwarder, nil
 }
 
-func (s *S3Options) Uploader(ctx context.Context) (*manager.Uploader, error) {
-	c, err := s.Client(ctx)
-	if err != nil {
-		return nil, xerrors.WithStack(err)
-	}
-
-	u := manager.NewUploader(c)
-	if s.PartSize > 0 {
-		u.PartSize = int64(s.PartSize)
-	}
-	return u, nil
-}
-
 type S3 struct {
 	bucket string
 	opt    S3Options
 }
 
-var _ storageInterface = &S3{}
+var _ Backend = &S3{}
 
 func NewS3(bucket string, opt S3Options) *S3 {
 	return &S3{bucket: bucket, opt: opt}
@@ -193,6 +186,18 @@ func (s *S3) Endpoint() string {
 }
 
 func (s *S3) Get(ctx context.Context, name string) (*Object, error) {
+	return s.getObject(ctx, name, nil)
+}
+
+func (s *S3) GetRange(ctx context.Context, name string, offset, length int64) (*Object, error) {
+	r := fmt.Sprintf("bytes=%d-", offset)
+	if length >= 0 {
+		r += strconv.FormatInt(offset+length-1, 10)
+	}
+	return s.getObject(ctx, name, aws.String(r))
+}
+
+func (s *S3) getObject(ctx context.Context, name string, rangeHeader *string) (*Object, error) {
 	c, err := s.opt.Client(ctx)
 	if err != nil {
 		return nil, xerrors.WithStack(err)
@@ -203,6 +208,7 @@ func (s *S3) Get(ctx context.Context, name string) (*Object, error) {
 		obj, err := c.GetObject(ctx, &s3.GetObjectInput{
 			Bucket: aws.String(s.bucket),
 			Key:    aws.String(name),
+			Range:  rangeHeader,
 		})
 		if err != nil {
 			if s.opt.Retries > 0 && retryCount < s.opt.Retries {
@@ -221,15 +227,60 @@ func (s *S3) Get(ctx context.Context, name string) (*Object, error) {
 			return nil, xerrors.WithStack(err)
 		}
 
+		size := aws.ToInt64(obj.ContentLength)
+		if rangeHeader != nil {
+			// Content-Range is "bytes <start>-<end>/<size>".
+			if _, total, ok := strings.Cut(aws.ToString(obj.ContentRange), "/"); ok {
+				if v, err := strconv.ParseInt(total, 10, 64); err == nil {
+					size = v
+				}
+			}
+		}
 		return &Object{
 			Name:         name,
-			Size:         aws.ToInt64(obj.ContentLength),
-			LastModified: *obj.LastModified,
+			Size:         size,
+			LastModified: aws.ToTime(obj.LastModified),
+			ETag:         aws.ToString(obj.ETag),
 			Body:         obj.Body,
 		}, nil
 	}
 }
 
+func (s *S3) Stat(ctx context.Context, name string) (*Object, error) {
+	c, err := s.opt.Client(ctx)
+	if err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+
+	retryCount := 1
+	for {
+		obj, err := c.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(name),
+		})
+		if err != nil {
+			// HEAD doesn't have the body. So the error code is not NoSuchKey.
+			var notFound *types.NotFound
+			if errors.As(err, &notFound) {
+				return nil, ErrObjectNotFound
+			}
+			if s.opt.Retries > 0 && retryCount < s.opt.Retries {
+				slogger.Log.Info("Retrying head a object", slog.Int("retryCount", retryCount), slog.String("key", name))
+				retryCount++
+				continue
+			}
+			return nil, xerrors.WithStack(err)
+		}
+
+		return &Object{
+			Name:         name,
+			Size:         aws.ToInt64(obj.ContentLength),
+			LastModified: aws.ToTime(obj.LastModified),
+			ETag:         aws.ToString(obj.ETag),
+		}, nil
+	}
+}
+
 func (s *S3) List(ctx context.Context, prefix string) ([]*Object, error) {
 	c, err := s.opt.Client(ctx)
 	if err != nil {
@@ -264,6 +315,7 @@ func (s *S3) List(ctx context.Context, prefix string) ([]*Object, error) {
 				Name:         aws.ToString(v.Key),
 				Size:         aws.ToInt64(v.Size),
 				LastModified: aws.ToTime(v.LastModified),
+				ETag:         aws.ToString(v.ETag),
 			})
 		}
 	}
@@ -307,6 +359,7 @@ func (s *S3) ListIter(ctx context.Context, prefix string) (iter.Seq[*Object], er
 					Name:         aws.ToString(v.Key),
 					Size:         aws.ToInt64(v.Size),
 					LastModified: aws.ToTime(v.LastModified),
+					ETag:         aws.ToString(v.ETag),
 				}
 				if !yield(obj) {
 					return
@@ -320,21 +373,72 @@ func (s *S3) Put(ctx context.Context, name string, data []byte) error {
 	return s.PutReader(ctx, name, bytes.NewReader(data))
 }
 
+// PutReader uploads the object by the multipart upload. Each part is retried individually, so the source
+// doesn't have to be seekable.
 func (s *S3) PutReader(ctx context.Context, name string, r io.Reader) error {
-	uploader, err := s.opt.Uploader(ctx)
+	c, err := s.opt.Client(ctx)
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+
+	return newS3MultipartUpload(c, s.bucket, name, s.opt).Upload(ctx, r)
+}
+
+func (s *S3) PutIf(ctx context.Context, name string, data []byte, cond PutCondition) (string, error) {
+	c, err := s.opt.Client(ctx)
+	if err != nil {
+		return "", xerrors.WithStack(err)
+	}
+
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(name),
+		Body:   bytes.NewReader(data),
+	}
+	if cond.IfNoneMatch {
+		input.IfNoneMatch = aws.String("*")
+	}
+	if cond.IfMatch != "" {
+		input.IfMatch = aws.String(cond.IfMatch)
+	}
+	// PutIf is not retried. The retried request may fail by the object which is written by the first request.
+	out, err := c.PutObject(ctx, input)
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) {
+			switch apiErr.ErrorCode() {
+			case "PreconditionFailed", "ConditionalRequestConflict":
+				return "", ErrPreconditionFailed
+			}
+		}
+		return "", xerrors.WithStack(err)
+	}
+	return aws.ToString(out.ETag), nil
+}
+
+func (s *S3) Copy(ctx context.Context, src, dst string) error {
+	c, err := s.opt.Client(ctx)
 	if err != nil {
 		return xerrors.WithStack(err)
 	}
+
 	retryCount := 1
 	for {
-		_, err = uploader.Upload(ctx, &s3.PutObjectInput{
-			Bucket: aws.String(s.bucket),
-			Key:    aws.String(name),
-			Body:   r,
+		_, err = c.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:     aws.String(s.bucket),
+			Key:        aws.String(dst),
+			CopySource: aws.String(copySource(s.bucket, src)),
 		})
 		if err != nil {
+			var apiErr smithy.APIError
+			if errors.As(err, &apiErr) {
+				switch apiErr.(type) {
+				case *types.NoSuchKey:
+					return ErrObjectNotFound
+				}
+			}
 			if s.opt.Retries > 0 && retryCount < s.opt.Retries {
-				slogger.Log.Info("Retrying put a object", slog.Int("retryCount", retryCount), slog.String("key", name))
+				slogger.Log.Info("Retrying copy a object", slog.Int("retryCount", retryCount), slog.String("src", src), slog.String("dst", dst))
 				retryCount++
 				continue
 			}
@@ -345,6 +449,15 @@ func (s *S3) PutReader(ctx context.Context, name string, r io.Reader) error {
 	}
 }
 
+// copySource returns the value of x-amz-copy-source. Each segment of the key is escaped.
+func copySource(bucket, key string) string {
+	p := strings.Split(bucket+"/"+key, "/")
+	for i := range p {
+		p[i] = url.PathEscape(p[i])
+	}
+	return strings.Join(p, "/")
+}
+
 func (s *S3) Delete(ctx context.Context, name string) error {
 	c, err := s.opt.Client(ctx)
 	if err != nil {
@@ -0,0 +1,337 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"go.f110.dev/xerrors"
+
+	"go.f110.dev/mono/go/logger/slogger"
+)
+
+// ErrChecksumMismatch is returned when the checksum which is computed by the server doesn't match the data.
+var ErrChecksumMismatch = xerrors.New("storage: checksum mismatch")
+
+const (
+	// s3MinPartSize is the minimum size of the part except the last part.
+	s3MinPartSize        = 5 * 1024 * 1024
+	s3DefaultPartSize    = 16 * 1024 * 1024
+	s3DefaultConcurrency = 4
+	// s3RetryBaseInterval is the interval before the first retry. The interval is doubled at each retry
+	// up to s3RetryMaxInterval.
+	s3RetryBaseInterval = 100 * time.Millisecond
+	s3RetryMaxInterval  = 10 * time.Second
+)
+
+// s3MultipartUpload uploads the object by the multipart upload.
+// Each part is kept in memory until it is uploaded, so the failed part is retried without reading the source
+// again and the other parts which are already uploaded are not sent again.
+// All parts are sent with SHA-256 checksum, and the server verifies it.
+type s3MultipartUpload struct {
+	client      *s3.Client
+	bucket      string
+	key         string
+	partSize    int64
+	concurrency int
+	retries     int
+}
+
+func newS3MultipartUpload(client *s3.Client, bucket, key string, opt S3Options) *s3MultipartUpload {
+	partSize := int64(opt.PartSize)
+	if partSize == 0 {
+		partSize = s3DefaultPartSize
+	}
+	partSize = max(partSize, s3MinPartSize)
+	concurrency := opt.Concurrency
+	if concurrency <= 0 {
+		concurrency = s3DefaultConcurrency
+	}
+
+	return &s3MultipartUpload{
+		client:      client,
+		bucket:      bucket,
+		key:         key,
+		partSize:    partSize,
+		concurrency: concurrency,
+		retries:     opt.Retries,
+	}
+}
+
+type s3Part struct {
+	Number int32
+	Data   []byte
+	Sum    []byte
+}
+
+func (u *s3MultipartUpload) Upload(ctx context.Context, r io.Reader) error {
+	first, eof, err := u.readPart(r, 1)
+	if err != nil {
+		return err
+	}
+	if eof {
+		// The object is smaller than the part. The multipart upload is not necessary.
+		return u.putObject(ctx, first)
+	}
+
+	created, err := u.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:            aws.String(u.bucket),
+		Key:               aws.String(u.key),
+		ChecksumAlgorithm: types.ChecksumAlgorithmSha256,
+	})
+	if err != nil {
+		return xerrors.WithStack(err)
+	}
+	uploadId := aws.ToString(created.UploadId)
+
+	parts, err := u.uploadParts(ctx, uploadId, first, r)
+	if err != nil {
+		u.abort(ctx, uploadId)
+		return err
+	}
+	return u.complete(ctx, uploadId, parts)
+}
+
+func (u *s3MultipartUpload) abort(ctx context.Context, uploadId string) {
+	// The context may be canceled already. The upload has to be aborted even if it, otherwise the uploaded parts
+	// are left in the bucket.
+	_, err := u.client.AbortMultipartUpload(context.WithoutCancel(ctx), &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(u.bucket),
+		Key:      aws.String(u.key),
+		UploadId: aws.String(uploadId),
+	})
+	if err != nil {
+		slogger.Log.Warn("Failed to abort the multipart upload", slog.String("key", u.key), slogger.E(err))
+	}
+}
+
+// readPart reads the data of the part. eof is true if r doesn't have any more data.
+func (u *s3MultipartUpload) readPart(r io.Reader, num int32) (*s3Part, bool, error) {
+	buf := make([]byte, u.partSize)
+	n, err := io.ReadFull(r, buf)
+	eof := false
+	switch {
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		eof = true
+	case err != nil:
+		return nil, false, xerrors.WithStack(err)
+	}
+	sum := sha256.Sum256(buf[:n])
+	return &s3Part{Number: num, Data: buf[:n], Sum: sum[:]}, eof, nil
+}
+
+func (u *s3MultipartUpload) uploadParts(ctx context.Context, uploadId string, first *s3Part, r io.Reader) ([]types.CompletedPart, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var completed []types.CompletedPart
+	var firstErr error
+	setErr := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		mu.Unlock()
+		cancel()
+	}
+
+	queue := make(chan *s3Part)
+	var wg sync.WaitGroup
+	for range u.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := range queue {
+				part, err := u.uploadPart(ctx, uploadId, p)
+				if err != nil {
+					setErr(err)
+					continue
+				}
+				mu.Lock()
+				completed = append(completed, part)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	// The number of the parts which are held in memory is bounded by the concurrency because queue is not buffered.
+	part, eof := first, false
+	for {
+		select {
+		case queue <- part:
+		case <-ctx.Done():
+		}
+		if eof || ctx.Err() != nil {
+			break
+		}
+		p, e, err := u.readPart(r, part.Number+1)
+		if err != nil {
+			setErr(err)
+			break
+		}
+		if len(p.Data) == 0 {
+			break
+		}
+		part, eof = p, e
+	}
+	close(queue)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, xerrors.WithStack(err)
+	}
+	sort.Slice(completed, func(i, j int) bool {
+		return aws.ToInt32(completed[i].PartNumber) < aws.ToInt32(completed[j].PartNumber)
+	})
+	return completed, nil
+}
+
+// uploadPart uploads the part. The part is retried until the number of Retries.
+func (u *s3MultipartUpload) uploadPart(ctx context.Context, uploadId string, p *s3Part) (types.CompletedPart, error) {
+	checksum := base64.StdEncoding.EncodeToString(p.Sum)
+	retryCount := 1
+	for {
+		res, err := u.client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:            aws.String(u.bucket),
+			Key:               aws.String(u.key),
+			UploadId:          aws.String(uploadId),
+			PartNumber:        aws.Int32(p.Number),
+			Body:              bytes.NewReader(p.Data),
+			ContentLength:     aws.Int64(int64(len(p.Data))),
+			ChecksumAlgorithm: types.ChecksumAlgorithmSha256,
+			ChecksumSHA256:    aws.String(checksum),
+		})
+		if err == nil && aws.ToString(res.ChecksumSHA256) != "" && aws.ToString(res.ChecksumSHA256) != checksum {
+			err = xerrors.WithMessagef(ErrChecksumMismatch, "part %d of %s", p.Number, u.key)
+		}
+		if err != nil {
+			if ctx.Err() == nil && u.retries > 0 && retryCount < u.retries {
+				slogger.Log.Info("Retrying upload a part", slog.Int("retryCount", retryCount), slog.String("key", u.key), slog.Int("part", int(p.Number)))
+				if err := waitRetry(ctx, retryCount); err != nil {
+					return types.CompletedPart{}, err
+				}
+				retryCount++
+				continue
+			}
+			return types.CompletedPart{}, xerrors.WithStack(err)
+		}
+
+		return types.CompletedPart{
+			PartNumber:     aws.Int32(p.Number),
+			ETag:           res.ETag,
+			ChecksumSHA256: aws.String(checksum),
+		}, nil
+	}
+}
+
+func (u *s3MultipartUpload) complete(ctx context.Context, uploadId string, parts []types.CompletedPart) error {
+	res, err := u.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(u.bucket),
+		Key:             aws.String(u.key),
+		UploadId:        aws.String(uploadId),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	})
+	if err != nil {
+		u.abort(ctx, uploadId)
+		return xerrors.WithStack(err)
+	}
+
+	// The checksum of the object which is uploaded by the multipart upload is the checksum of the checksums of
+	// each part. The server may append the number of parts as a suffix.
+	if v := aws.ToString(res.ChecksumSHA256); v != "" {
+		if expect := compositeChecksum(parts); strings.TrimSuffix(v, fmt.Sprintf("-%d", len(parts))) != expect {
+			// The object is already created. The broken object must not be read by anyone.
+			_, err := u.client.DeleteObject(context.WithoutCancel(ctx), &s3.DeleteObjectInput{
+				Bucket: aws.String(u.bucket),
+				Key:    aws.String(u.key),
+			})
+			if err != nil {
+				slogger.Log.Warn("Failed to delete the broken object", slog.String("key", u.key), slogger.E(err))
+			}
+			return xerrors.WithMessagef(ErrChecksumMismatch, "%s: expected %s, got %s", u.key, expect, v)
+		}
+	}
+	return nil
+}
+
+func (u *s3MultipartUpload) putObject(ctx context.Context, p *s3Part) error {
+	checksum := base64.StdEncoding.EncodeToString(p.Sum)
+	retryCount := 1
+	for {
+		res, err := u.client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:            aws.String(u.bucket),
+			Key:               aws.String(u.key),
+			Body:              bytes.NewReader(p.Data),
+			ContentLength:     aws.Int64(int64(len(p.Data))),
+			ChecksumAlgorithm: types.ChecksumAlgorithmSha256,
+			ChecksumSHA256:    aws.String(checksum),
+		})
+		if err == nil && aws.ToString(res.ChecksumSHA256) != "" && aws.ToString(res.ChecksumSHA256) != checksum {
+			err = xerrors.WithMessagef(ErrChecksumMismatch, "%s", u.key)
+		}
+		if err != nil {
+			if u.retries > 0 && retryCount < u.retries {
+				slogger.Log.Info("Retrying put a object", slog.Int("retryCount", retryCount), slog.String("key", u.key))
+				if err := waitRetry(ctx, retryCount); err != nil {
+					return err
+				}
+				retryCount++
+				continue
+			}
+			return xerrors.WithStack(err)
+		}
+
+		return nil
+	}
+}
+
+// retryInterval returns the interval before the retryCount-th retry. The interval grows exponentially and
+// has the jitter so that the parts which failed at the same time are not sent again at the same time.
+func retryInterval(retryCount int) time.Duration {
+	d := s3RetryMaxInterval
+	if shift := retryCount - 1; shift < 16 {
+		d = min(s3RetryBaseInterval<<shift, s3RetryMaxInterval)
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// waitRetry waits for the interval before the retryCount-th retry. waitRetry returns the error if ctx is canceled.
+func waitRetry(ctx context.Context, retryCount int) error {
+	t := time.NewTimer(retryInterval(retryCount))
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return xerrors.WithStack(ctx.Err())
+	case <-t.C:
+		return nil
+	}
+}
+
+func compositeChecksum(parts []types.CompletedPart) string {
+	h := sha256.New()
+	for _, v := range parts {
+		sum, err := base64.StdEncoding.DecodeString(aws.ToString(v.ChecksumSHA256))
+		if err != nil {
+			return ""
+		}
+		h.Write(sum)
+	}
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,225 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.f110.dev/mono/go/logger/slogger"
+)
+
+func TestS3PutReader(t *testing.T) {
+	slogger.Init()
+
+	newS3 := func(t *testing.T) (*S3, *fakeS3) {
+		f := newFakeS3()
+		srv := httptest.NewServer(f)
+		t.Cleanup(srv.Close)
+
+		opt := NewS3OptionToExternal(srv.URL, "us-east-1", "accesskey", "secretkey")
+		opt.PathStyle = true
+		opt.PartSize = s3MinPartSize
+		opt.Concurrency = 2
+		opt.Retries = 3
+		return NewS3("bucket", opt), f
+	}
+	ctx := context.Background()
+
+	t.Run("SinglePart", func(t *testing.T) {
+		s, f := newS3(t)
+		require.NoError(t, s.PutReader(ctx, "small", strings.NewReader("foobar")))
+		assert.Equal(t, "foobar", string(f.object("small")))
+		assert.Empty(t, f.uploads)
+	})
+
+	t.Run("Multipart", func(t *testing.T) {
+		s, f := newS3(t)
+		data := make([]byte, 2*s3MinPartSize+1024)
+		_, err := rand.Read(data)
+		require.NoError(t, err)
+		// The second part fails once. Only the failed part is sent again.
+		f.failPart[2] = 1
+
+		// The reader is not seekable.
+		require.NoError(t, s.PutReader(ctx, "large", struct{ io.Reader }{bytes.NewReader(data)}))
+		assert.Equal(t, data, f.object("large"))
+		assert.Equal(t, map[int32]int{1: 1, 2: 2, 3: 1}, f.partRequests)
+		assert.Empty(t, f.aborted)
+	})
+
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		s, f := newS3(t)
+		data := make([]byte, s3MinPartSize+1024)
+		f.corruptChecksum = true
+
+		err := s.PutReader(ctx, "large", bytes.NewReader(data))
+		assert.ErrorIs(t, err, ErrChecksumMismatch)
+		// The completed object is deleted.
+		assert.Nil(t, f.object("large"))
+	})
+
+	t.Run("PartFailed", func(t *testing.T) {
+		s, f := newS3(t)
+		data := make([]byte, s3MinPartSize+1024)
+		f.failPart[2] = 3
+
+		err := s.PutReader(ctx, "large", bytes.NewReader(data))
+		assert.Error(t, err)
+		assert.Len(t, f.aborted, 1)
+		assert.Nil(t, f.object("large"))
+	})
+}
+
+func TestRetryInterval(t *testing.T) {
+	for retryCount, expect := range map[int]time.Duration{
+		1:   s3RetryBaseInterval,
+		2:   2 * s3RetryBaseInterval,
+		3:   4 * s3RetryBaseInterval,
+		10:  s3RetryMaxInterval,
+		100: s3RetryMaxInterval,
+	} {
+		for range 100 {
+			d := retryInterval(retryCount)
+			assert.GreaterOrEqual(t, d, expect/2, "retryCount=%d", retryCount)
+			assert.LessOrEqual(t, d, expect, "retryCount=%d", retryCount)
+		}
+	}
+}
+
+// fakeS3 implements the subset of S3 API for the upload.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	uploads map[string]map[int32][]byte
+	aborted []string
+	// failPart is the number of times which the part fails.
+	failPart        map[int32]int
+	partRequests    map[int32]int
+	corruptChecksum bool
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{
+		objects:      make(map[string][]byte),
+		uploads:      make(map[string]map[int32][]byte),
+		failPart:     make(map[int32]int),
+		partRequests: make(map[int32]int),
+	}
+}
+
+func (f *fakeS3) object(key string) []byte {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.objects[key]
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := strings.TrimPrefix(req.URL.Path, "/bucket/")
+	q := req.URL.Query()
+	switch {
+	case req.Method == http.MethodPost && q.Has("uploads"):
+		uploadId := fmt.Sprintf("upload-%d", len(f.uploads)+1)
+		f.uploads[uploadId] = make(map[int32][]byte)
+		fmt.Fprintf(w, "<InitiateMultipartUploadResult><Bucket>bucket</Bucket><Key>%s</Key><UploadId>%s</UploadId></InitiateMultipartUploadResult>", key, uploadId)
+	case req.Method == http.MethodPut && q.Has("partNumber"):
+		n, _ := strconv.Atoi(q.Get("partNumber"))
+		num := int32(n)
+		f.partRequests[num]++
+		if f.failPart[num] > 0 {
+			f.failPart[num]--
+			writeFakeS3Error(w, http.StatusBadRequest, "InvalidRequest")
+			return
+		}
+		data, ok := readFakeS3Body(w, req)
+		if !ok {
+			return
+		}
+		f.uploads[q.Get("uploadId")][num] = data
+		w.Header().Set("ETag", fmt.Sprintf("%q", fmt.Sprintf("%x", md5.Sum(data))))
+		w.Header().Set("x-amz-checksum-sha256", req.Header.Get("x-amz-checksum-sha256"))
+	case req.Method == http.MethodPost && q.Has("uploadId"):
+		var body struct {
+			Parts []struct {
+				PartNumber     int32
+				ChecksumSHA256 string
+			} `xml:"Part"`
+		}
+		if err := xml.NewDecoder(req.Body).Decode(&body); err != nil {
+			writeFakeS3Error(w, http.StatusBadRequest, "MalformedXML")
+			return
+		}
+		parts := f.uploads[q.Get("uploadId")]
+		sort.Slice(body.Parts, func(i, j int) bool { return body.Parts[i].PartNumber < body.Parts[j].PartNumber })
+		var data []byte
+		h := sha256.New()
+		for _, v := range body.Parts {
+			data = append(data, parts[v.PartNumber]...)
+			sum := sha256.Sum256(parts[v.PartNumber])
+			h.Write(sum[:])
+		}
+		checksum := fmt.Sprintf("%s-%d", base64.StdEncoding.EncodeToString(h.Sum(nil)), len(body.Parts))
+		if f.corruptChecksum {
+			checksum = base64.StdEncoding.EncodeToString(make([]byte, sha256.Size))
+		}
+		f.objects[key] = data
+		delete(f.uploads, q.Get("uploadId"))
+		fmt.Fprintf(w, "<CompleteMultipartUploadResult><Bucket>bucket</Bucket><Key>%s</Key><ETag>\"etag\"</ETag><ChecksumSHA256>%s</ChecksumSHA256></CompleteMultipartUploadResult>", key, checksum)
+	case req.Method == http.MethodDelete && q.Has("uploadId"):
+		f.aborted = append(f.aborted, q.Get("uploadId"))
+		delete(f.uploads, q.Get("uploadId"))
+		w.WriteHeader(http.StatusNoContent)
+	case req.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	case req.Method == http.MethodPut:
+		data, ok := readFakeS3Body(w, req)
+		if !ok {
+			return
+		}
+		f.objects[key] = data
+		w.Header().Set("ETag", fmt.Sprintf("%q", fmt.Sprintf("%x", md5.Sum(data))))
+		w.Header().Set("x-amz-checksum-sha256", req.Header.Get("x-amz-checksum-sha256"))
+	default:
+		writeFakeS3Error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+// readFakeS3Body reads the body and verifies the checksum like S3.
+func readFakeS3Body(w http.ResponseWriter, req *http.Request) ([]byte, bool) {
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		writeFakeS3Error(w, http.StatusBadRequest, "IncompleteBody")
+		return nil, false
+	}
+	sum := sha256.Sum256(data)
+	if req.Header.Get("x-amz-checksum-sha256") != base64.StdEncoding.EncodeToString(sum[:]) {
+		writeFakeS3Error(w, http.StatusBadRequest, "BadDigest")
+		return nil, false
+	}
+	return data, true
+}
+
+func writeFakeS3Error(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<Error><Code>%s</Code><Message>%s</Message></Error>", code, code)
+}
@@ -4,7 +4,7 @@
 
 import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
 import type { Message } from "@bufbuild/protobuf";
-import type { ExternalReleaseTrigger, GithubEvent, Job, Repository, TestReport } from "../model/msg_pb";
+import type { ActionFailure, Artifact, BuildMetrics, ExternalReleaseTrigger, FlakyTest, GithubEvent, Job, Repository, TargetResult, TestReport } from "../model/msg_pb";
 import type { Duration, Timestamp } from "@bufbuild/protobuf/wkt";
 
 /**
@@ -518,6 +518,215 @@ export declare type ResponseListGithubEvents = Message<"mono.build.bff.ResponseL
  */
 export declare const ResponseListGithubEventsSchema: GenMessage<ResponseListGithubEvents>;
 
+/**
+ * @generated from message mono.build.bff.RequestGetTaskBuildResult
+ */
+export declare type RequestGetTaskBuildResult = Message<"mono.build.bff.RequestGetTaskBuildResult"> & {
+  /**
+   * @generated from field: int32 task_id = 1;
+   */
+  taskId: number;
+};
+
+/**
+ * Describes the message mono.build.bff.RequestGetTaskBuildResult.
+ * Use `create(RequestGetTaskBuildResultSchema)` to create a new message.
+ */
+export declare const RequestGetTaskBuildResultSchema: GenMessage<RequestGetTaskBuildResult>;
+
+/**
+ * @generated from message mono.build.bff.ResponseGetTaskBuildResult
+ */
+export declare type ResponseGetTaskBuildResult = Message<"mono.build.bff.ResponseGetTaskBuildResult"> & {
+  /**
+   * @generated from field: repeated mono.build.model.TargetResult targets = 1;
+   */
+  targets: TargetResult[];
+
+  /**
+   * @generated from field: repeated mono.build.model.ActionFailure action_failures = 2;
+   */
+  actionFailures: ActionFailure[];
+
+  /**
+   * metrics is not set if the task has not reported the metrics.
+   *
+   * @generated from field: mono.build.model.BuildMetrics metrics = 3;
+   */
+  metrics?: BuildMetrics;
+};
+
+/**
+ * Describes the message mono.build.bff.ResponseGetTaskBuildResult.
+ * Use `create(ResponseGetTaskBuildResultSchema)` to create a new message.
+ */
+export declare const ResponseGetTaskBuildResultSchema: GenMessage<ResponseGetTaskBuildResult>;
+
+/**
+ * @generated from message mono.build.bff.RequestListFlakyTests
+ */
+export declare type RequestListFlakyTests = Message<"mono.build.bff.RequestListFlakyTests"> & {
+  /**
+   * @generated from field: int32 repository_id = 1;
+   */
+  repositoryId: number;
+
+  /**
+   * If true, only the quarantined tests are returned.
+   *
+   * @generated from field: bool quarantined_only = 2;
+   */
+  quarantinedOnly: boolean;
+};
+
+/**
+ * Describes the message mono.build.bff.RequestListFlakyTests.
+ * Use `create(RequestListFlakyTestsSchema)` to create a new message.
+ */
+export declare const RequestListFlakyTestsSchema: GenMessage<RequestListFlakyTests>;
+
+/**
+ * @generated from message mono.build.bff.ResponseListFlakyTests
+ */
+export declare type ResponseListFlakyTests = Message<"mono.build.bff.ResponseListFlakyTests"> & {
+  /**
+   * @generated from field: repeated mono.build.model.FlakyTest tests = 1;
+   */
+  tests: FlakyTest[];
+};
+
+/**
+ * Describes the message mono.build.bff.ResponseListFlakyTests.
+ * Use `create(ResponseListFlakyTestsSchema)` to create a new message.
+ */
+export declare const ResponseListFlakyTestsSchema: GenMessage<ResponseListFlakyTests>;
+
+/**
+ * @generated from message mono.build.bff.RequestTailLogs
+ */
+export declare type RequestTailLogs = Message<"mono.build.bff.RequestTailLogs"> & {
+  /**
+   * @generated from field: int32 task_id = 1;
+   */
+  taskId: number;
+
+  /**
+   * offset is the byte offset of the log to resume from.
+   *
+   * @generated from field: int64 offset = 2;
+   */
+  offset: bigint;
+};
+
+/**
+ * Describes the message mono.build.bff.RequestTailLogs.
+ * Use `create(RequestTailLogsSchema)` to create a new message.
+ */
+export declare const RequestTailLogsSchema: GenMessage<RequestTailLogs>;
+
+/**
+ * @generated from message mono.build.bff.ResponseTailLogs
+ */
+export declare type ResponseTailLogs = Message<"mono.build.bff.ResponseTailLogs"> & {
+  /**
+   * @generated from field: bytes body = 1;
+   */
+  body: Uint8Array;
+
+  /**
+   * offset is the byte offset of body in the log.
+   *
+   * @generated from field: int64 offset = 2;
+   */
+  offset: bigint;
+
+  /**
+   * finished is true if the task has finished and the whole log has been sent.
+   *
+   * @generated from field: bool finished = 3;
+   */
+  finished: boolean;
+};
+
+/**
+ * Describes the message mono.build.bff.ResponseTailLogs.
+ * Use `create(ResponseTailLogsSchema)` to create a new message.
+ */
+export declare const ResponseTailLogsSchema: GenMessage<ResponseTailLogs>;
+
+/**
+ * @generated from message mono.build.bff.RequestListArtifacts
+ */
+export declare type RequestListArtifacts = Message<"mono.build.bff.RequestListArtifacts"> & {
+  /**
+   * @generated from field: int32 task_id = 1;
+   */
+  taskId: number;
+};
+
+/**
+ * Describes the message mono.build.bff.RequestListArtifacts.
+ * Use `create(RequestListArtifactsSchema)` to create a new message.
+ */
+export declare const RequestListArtifactsSchema: GenMessage<RequestListArtifacts>;
+
+/**
+ * @generated from message mono.build.bff.ResponseListArtifacts
+ */
+export declare type ResponseListArtifacts = Message<"mono.build.bff.ResponseListArtifacts"> & {
+  /**
+   * @generated from field: repeated mono.build.model.Artifact artifacts = 1;
+   */
+  artifacts: Artifact[];
+};
+
+/**
+ * Describes the message mono.build.bff.ResponseListArtifacts.
+ * Use `create(ResponseListArtifactsSchema)` to create a new message.
+ */
+export declare const ResponseListArtifactsSchema: GenMessage<ResponseListArtifacts>;
+
+/**
+ * @generated from message mono.build.bff.RequestDownloadArtifact
+ */
+export declare type RequestDownloadArtifact = Message<"mono.build.bff.RequestDownloadArtifact"> & {
+  /**
+   * @generated from field: int32 task_id = 1;
+   */
+  taskId: number;
+
+  /**
+   * path is the relative path of the artifact from bazel-bin.
+   *
+   * @generated from field: string path = 2;
+   */
+  path: string;
+};
+
+/**
+ * Describes the message mono.build.bff.RequestDownloadArtifact.
+ * Use `create(RequestDownloadArtifactSchema)` to create a new message.
+ */
+export declare const RequestDownloadArtifactSchema: GenMessage<RequestDownloadArtifact>;
+
+/**
+ * ResponseDownloadArtifact is a chunk of the artifact.
+ *
+ * @generated from message mono.build.bff.ResponseDownloadArtifact
+ */
+export declare type ResponseDownloadArtifact = Message<"mono.build.bff.ResponseDownloadArtifact"> & {
+  /**
+   * @generated from field: bytes body = 1;
+   */
+  body: Uint8Array;
+};
+
+/**
+ * Describes the message mono.build.bff.ResponseDownloadArtifact.
+ * Use `create(ResponseDownloadArtifactSchema)` to create a new message.
+ */
+export declare const ResponseDownloadArtifactSchema: GenMessage<ResponseDownloadArtifact>;
+
 /**
  * GitDataRepository is a lightweight view of a repository served by the
  * git-data-service, used for the list on the Git Data page. Heavier per-repo
@@ -775,6 +984,36 @@ export declare type BFFTask = Message<"mono.build.bff.BFFTask"> & {
    * @generated from field: google.protobuf.Duration duration = 29;
    */
   duration?: Duration;
+
+  /**
+   * @generated from field: bool skipped = 30;
+   */
+  skipped: boolean;
+
+  /**
+   * @generated from field: repeated string needs = 31;
+   */
+  needs: string[];
+
+  /**
+   * @generated from field: int32 attempt = 32;
+   */
+  attempt: number;
+
+  /**
+   * @generated from field: int32 parent_task_id = 33;
+   */
+  parentTaskId: number;
+
+  /**
+   * @generated from field: string cancel_reason = 34;
+   */
+  cancelReason: string;
+
+  /**
+   * @generated from field: map<string, string> matrix = 35;
+   */
+  matrix: { [key: string]: string };
 };
 
 /**
@@ -883,6 +1122,46 @@ export declare const BFF: GenService<{
     input: typeof RequestListGithubEventsSchema;
     output: typeof ResponseListGithubEventsSchema;
   },
+  /**
+   * @generated from rpc mono.build.bff.BFF.GetTaskBuildResult
+   */
+  getTaskBuildResult: {
+    methodKind: "unary";
+    input: typeof RequestGetTaskBuildResultSchema;
+    output: typeof ResponseGetTaskBuildResultSchema;
+  },
+  /**
+   * @generated from rpc mono.build.bff.BFF.ListFlakyTests
+   */
+  listFlakyTests: {
+    methodKind: "unary";
+    input: typeof RequestListFlakyTestsSchema;
+    output: typeof ResponseListFlakyTestsSchema;
+  },
+  /**
+   * @generated from rpc mono.build.bff.BFF.TailLogs
+   */
+  tailLogs: {
+    methodKind: "server_streaming";
+    input: typeof RequestTailLogsSchema;
+    output: typeof ResponseTailLogsSchema;
+  },
+  /**
+   * @generated from rpc mono.build.bff.BFF.ListArtifacts
+   */
+  listArtifacts: {
+    methodKind: "unary";
+    input: typeof RequestListArtifactsSchema;
+    output: typeof ResponseListArtifactsSchema;
+  },
+  /**
+   * @generated from rpc mono.build.bff.BFF.DownloadArtifact
+   */
+  downloadArtifact: {
+    methodKind: "server_streaming";
+    input: typeof RequestDownloadArtifactSchema;
+    output: typeof ResponseDownloadArtifactSchema;
+  },
   /**
    * @generated from rpc mono.build.bff.BFF.ListGitData
    */
@@ -199,6 +199,38 @@ export declare type Task = Message<"mono.build.model.Task"> & {
    * @generated from field: repeated mono.build.model.TestReport test_reports = 28;
    */
   testReports: TestReport[];
+
+  /**
+   * @generated from field: bool skipped = 29;
+   */
+  skipped: boolean;
+
+  /**
+   * @generated from field: repeated string needs = 30;
+   */
+  needs: string[];
+
+  /**
+   * @generated from field: int32 attempt = 31;
+   */
+  attempt: number;
+
+  /**
+   * @generated from field: int32 parent_task_id = 32;
+   */
+  parentTaskId: number;
+
+  /**
+   * @generated from field: string cancel_reason = 33;
+   */
+  cancelReason: string;
+
+  /**
+   * matrix is the values of the axes if the task is expanded by the matrix of the job.
+   *
+   * @generated from field: map<string, string> matrix = 34;
+   */
+  matrix: { [key: string]: string };
 };
 
 /**
@@ -396,6 +428,253 @@ export declare type ExternalReleaseTrigger = Message<"mono.build.model.ExternalR
  */
 export declare const ExternalReleaseTriggerSchema: GenMessage<ExternalReleaseTrigger>;
 
+/**
+ * TargetResult is the outcome of a target reported by the build event stream.
+ * failure_category is "<category>.<code>" of Bazel's FailureDetail (e.g. "spawn.NON_ZERO_EXIT").
+ *
+ * @generated from message mono.build.model.TargetResult
+ */
+export declare type TargetResult = Message<"mono.build.model.TargetResult"> & {
+  /**
+   * @generated from field: string label = 1;
+   */
+  label: string;
+
+  /**
+   * @generated from field: bool success = 2;
+   */
+  success: boolean;
+
+  /**
+   * @generated from field: string failure_category = 3;
+   */
+  failureCategory: string;
+
+  /**
+   * @generated from field: string failure_message = 4;
+   */
+  failureMessage: string;
+};
+
+/**
+ * Describes the message mono.build.model.TargetResult.
+ * Use `create(TargetResultSchema)` to create a new message.
+ */
+export declare const TargetResultSchema: GenMessage<TargetResult>;
+
+/**
+ * @generated from message mono.build.model.ActionFailure
+ */
+export declare type ActionFailure = Message<"mono.build.model.ActionFailure"> & {
+  /**
+   * @generated from field: string label = 1;
+   */
+  label: string;
+
+  /**
+   * @generated from field: string mnemonic = 2;
+   */
+  mnemonic: string;
+
+  /**
+   * @generated from field: int32 exit_code = 3;
+   */
+  exitCode: number;
+
+  /**
+   * @generated from field: string failure_category = 4;
+   */
+  failureCategory: string;
+
+  /**
+   * @generated from field: string failure_message = 5;
+   */
+  failureMessage: string;
+
+  /**
+   * @generated from field: string primary_output = 6;
+   */
+  primaryOutput: string;
+};
+
+/**
+ * Describes the message mono.build.model.ActionFailure.
+ * Use `create(ActionFailureSchema)` to create a new message.
+ */
+export declare const ActionFailureSchema: GenMessage<ActionFailure>;
+
+/**
+ * BuildMetrics is the metrics of the build reported by the build event stream.
+ * The durations are in milliseconds.
+ *
+ * @generated from message mono.build.model.BuildMetrics
+ */
+export declare type BuildMetrics = Message<"mono.build.model.BuildMetrics"> & {
+  /**
+   * @generated from field: string exit_code_name = 1;
+   */
+  exitCodeName: string;
+
+  /**
+   * @generated from field: int64 actions_created = 2;
+   */
+  actionsCreated: bigint;
+
+  /**
+   * @generated from field: int64 actions_executed = 3;
+   */
+  actionsExecuted: bigint;
+
+  /**
+   * @generated from field: int64 remote_cache_hits = 4;
+   */
+  remoteCacheHits: bigint;
+
+  /**
+   * @generated from field: int32 action_cache_hits = 5;
+   */
+  actionCacheHits: number;
+
+  /**
+   * @generated from field: int32 action_cache_misses = 6;
+   */
+  actionCacheMisses: number;
+
+  /**
+   * @generated from field: int64 targets_configured = 7;
+   */
+  targetsConfigured: bigint;
+
+  /**
+   * @generated from field: int64 wall_time_ms = 8;
+   */
+  wallTimeMs: bigint;
+
+  /**
+   * @generated from field: int64 cpu_time_ms = 9;
+   */
+  cpuTimeMs: bigint;
+
+  /**
+   * @generated from field: int64 analysis_phase_time_ms = 10;
+   */
+  analysisPhaseTimeMs: bigint;
+
+  /**
+   * @generated from field: int64 execution_phase_time_ms = 11;
+   */
+  executionPhaseTimeMs: bigint;
+
+  /**
+   * remote_cache_hit_ratio is the ratio of the executed actions which are served by the remote cache.
+   *
+   * @generated from field: double remote_cache_hit_ratio = 12;
+   */
+  remoteCacheHitRatio: number;
+
+  /**
+   * action_cache_hit_ratio is the hit ratio of the local action cache.
+   *
+   * @generated from field: double action_cache_hit_ratio = 13;
+   */
+  actionCacheHitRatio: number;
+};
+
+/**
+ * Describes the message mono.build.model.BuildMetrics.
+ * Use `create(BuildMetricsSchema)` to create a new message.
+ */
+export declare const BuildMetricsSchema: GenMessage<BuildMetrics>;
+
+/**
+ * FlakyTest is the flakiness of a test computed from the test reports of the repository.
+ * The score is the ratio of the flaky revisions to the revisions at which the test ran.
+ *
+ * @generated from message mono.build.model.FlakyTest
+ */
+export declare type FlakyTest = Message<"mono.build.model.FlakyTest"> & {
+  /**
+   * @generated from field: int32 repository_id = 1;
+   */
+  repositoryId: number;
+
+  /**
+   * @generated from field: string label = 2;
+   */
+  label: string;
+
+  /**
+   * @generated from field: int32 runs = 3;
+   */
+  runs: number;
+
+  /**
+   * @generated from field: int32 flaky_runs = 4;
+   */
+  flakyRuns: number;
+
+  /**
+   * @generated from field: double score = 5;
+   */
+  score: number;
+
+  /**
+   * @generated from field: bool quarantined = 6;
+   */
+  quarantined: boolean;
+
+  /**
+   * @generated from field: int32 last_flaky_task_id = 7;
+   */
+  lastFlakyTaskId: number;
+};
+
+/**
+ * Describes the message mono.build.model.FlakyTest.
+ * Use `create(FlakyTestSchema)` to create a new message.
+ */
+export declare const FlakyTestSchema: GenMessage<FlakyTest>;
+
+/**
+ * Artifact is an output of the task which is published to the object storage.
+ *
+ * @generated from message mono.build.model.Artifact
+ */
+export declare type Artifact = Message<"mono.build.model.Artifact"> & {
+  /**
+   * @generated from field: int32 task_id = 1;
+   */
+  taskId: number;
+
+  /**
+   * @generated from field: string label = 2;
+   */
+  label: string;
+
+  /**
+   * path is the relative path from bazel-bin.
+   *
+   * @generated from field: string path = 3;
+   */
+  path: string;
+
+  /**
+   * @generated from field: string object_name = 4;
+   */
+  objectName: string;
+
+  /**
+   * @generated from field: int64 size = 5;
+   */
+  size: bigint;
+};
+
+/**
+ * Describes the message mono.build.model.Artifact.
+ * Use `create(ArtifactSchema)` to create a new message.
+ */
+export declare const ArtifactSchema: GenMessage<Artifact>;
+
 /**
  * @generated from enum mono.build.model.TestStatus
  */
@@ -1,7 +1,6 @@
-import { useQuery } from '@connectrpc/connect-query'
 import * as React from 'react'
 import { Modal, Container, Typography, Box, Stack, Paper } from '@mui/material'
-import { BFF } from '../connect/bff_pb'
+import { useTailLogs } from '../hooks/useTailLogs'
 
 const modalStyle = {
   position: 'absolute',
@@ -26,12 +25,7 @@ export const LogModal: React.FC<Props> = ({ open, onClose, taskId }) => {
     return null
   }
 
-  const { data: logs, isSuccess } = useQuery(BFF.method.getLogs, {
-    taskId: taskId,
-  })
-  if (!isSuccess) {
-    return null
-  }
+  const logs = useTailLogs(taskId)
 
   return (
     <Modal
@@ -46,7 +40,7 @@ export const LogModal: React.FC<Props> = ({ open, onClose, taskId }) => {
             <Typography variant="h6">Log</Typography>
             <Box sx={{ overflow: 'auto' }}>
               <Paper sx={{ fontFamily: 'monospace' }}>
-                <pre>{logs?.body}</pre>
+                <pre>{logs.body}</pre>
               </Paper>
             </Box>
           </Stack>
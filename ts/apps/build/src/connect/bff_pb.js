@@ -10,7 +10,7 @@ import { file_proto_build_model_msg } from "../model/msg_pb";
  * Describes the file proto/build/bff/bff.proto.
  */
 export const file_proto_build_bff_bff = /*@__PURE__*/
-  fileDesc("Chlwcm90by9idWlsZC9iZmYvYmZmLnByb3RvEg5tb25vLmJ1aWxkLmJmZiIZChdSZXF1ZXN0TGlzdFJlcG9zaXRvcmllcyJOChhSZXNwb25zZUxpc3RSZXBvc2l0b3JpZXMSMgoMcmVwb3NpdG9yaWVzGAEgAygLMhwubW9uby5idWlsZC5tb2RlbC5SZXBvc2l0b3J5ImEKEFJlcXVlc3RMaXN0VGFza3MSFQoNcmVwb3NpdG9yeV9pZBgBIAEoBRIPCgd0YXNrX2lkGAIgASgFEhEKCXBhZ2Vfc2l6ZRgDIAEoBRISCgpwYWdlX3Rva2VuGAQgASgJIlQKEVJlc3BvbnNlTGlzdFRhc2tzEiYKBXRhc2tzGAEgAygLMhcubW9uby5idWlsZC5iZmYuQkZGVGFzaxIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAkiIQoOUmVxdWVzdEdldExvZ3MSDwoHdGFza19pZBgBIAEoBSIfCg9SZXNwb25zZUdldExvZ3MSDAoEYm9keRgBIAEoCSIWChRSZXF1ZXN0R2V0U2VydmVySW5mbyJ/ChVSZXNwb25zZUdldFNlcnZlckluZm8SIAoYc3VwcG9ydGVkX2JhemVsX3ZlcnNpb25zGAEgAygJEhYKDnNjaGVtYV92ZXJzaW9uGAIgASgJEiwKBmNvbmZpZxgDIAEoCzIcLm1vbm8uYnVpbGQuYmZmLlNlcnZlckNvbmZpZyLpAwoMU2VydmVyQ29uZmlnEgsKA2RldhgBIAEoCBIXCg9sZWFkZXJfZWxlY3Rpb24YAiABKAgSEQoJbmFtZXNwYWNlGAMgASgJEhQKDHVzZV9iYXplbGlzaxgEIAEoCBIdChVkZWZhdWx0X2JhemVsX3ZlcnNpb24YBSABKAkSFAoMcmVtb3RlX2NhY2hlGAYgASgJEhYKDnRhc2tfY3B1X2xpbWl0GAcgASgJEhkKEXRhc2tfbWVtb3J5X2xpbWl0GAggASgJEhIKCmdjX2VuYWJsZWQYCSABKAgSHwoXZ2l0X2RhdGFfc2VydmljZV9saXN0ZW4YCiABKAkSHAoUZ2l0X2RhdGFfc2VydmljZV91cmwYCyABKAkSIQoZZ2l0X2RhdGFfcmVmcmVzaF9pbnRlcnZhbBgMIAEoCRIgChhnaXRfZGF0YV9yZWZyZXNoX3dvcmtlcnMYDSABKAUSJgoeZXh0ZXJuYWxfcmVsZWFzZV9wb2xsX2ludGVydmFsGA4gASgJEiAKGGV2ZW50X3JlY29uY2lsZV9pbnRlcnZhbBgPIAEoCRIVCg1naXRodWJfYXBwX2lkGBAgASgDEhIKCnZhdWx0X2FkZHIYESABKAkSFQoNZGFzaGJvYXJkX3VybBgSIAEoCSIoCg9SZXF1ZXN0TGlzdEpvYnMSFQoNcmVwb3NpdG9yeV9pZBgBIAEoBSI3ChBSZXNwb25zZUxpc3RKb2JzEiMKBGpvYnMYASADKAsyFS5tb25vLmJ1aWxkLm1vZGVsLkpvYiI7ChBSZXF1ZXN0SW52b2tlSm9iEhUKDXJlcG9zaXRvcnlfaWQYASABKAUSEAoIam9iX25hbWUYAiABKAkiEwoRUmVzcG9uc2VJbnZva2VKb2IiSQoVUmVxdWVzdFNhdmVSZXBvc2l0b3J5EjAKCnJlcG9zaXRvcnkYASABKAsyHC5tb25vLmJ1aWxkLm1vZGVsLlJlcG9zaXRvcnkiSgoWUmVzcG9uc2VTYXZlUmVwb3NpdG9yeRIwCgpyZXBvc2l0b3J5GAEgASgLMhwubW9uby5idWlsZC5tb2RlbC5SZXBvc2l0b3J5IjAKF1JlcXVlc3RSZW1vdmVSZXBvc2l0b3J5EhUKDXJlcG9zaXRvcnlfaWQYASABKAUiGgoYUmVzcG9uc2VSZW1vdmVSZXBvc2l0b3J5IiUKElJlcXVlc3RSZXN0YXJ0VGFzaxIPCgd0YXNrX2lkGAEgASgFIhUKE1Jlc3BvbnNlUmVzdGFydFRhc2siJwoUUmVxdWVzdEZvcmNlU3RvcFRhc2sSDwoHdGFza19pZBgBIAEoBSIXChVSZXNwb25zZUZvcmNlU3RvcFRhc2siOwoiUmVxdWVzdExpc3RFeHRlcm5hbFJlbGVhc2VUcmlnZ2VycxIVCg1yZXBvc2l0b3J5X2lkGAEgASgFImEKI1Jlc3BvbnNlTGlzdEV4dGVybmFsUmVsZWFzZVRyaWdnZXJzEjoKCHRyaWdnZXJzGAEgAygLMigubW9uby5idWlsZC5tb2RlbC5FeHRlcm5hbFJlbGVhc2VUcmlnZ2VyIisKF1JlcXVlc3RMaXN0R2l0aHViRXZlbnRzEhAKCGV2ZW50X2lkGAEgASgFIkkKGFJlc3BvbnNlTGlzdEdpdGh1YkV2ZW50cxItCgZldmVudHMYASADKAsyHS5tb25vLmJ1aWxkLm1vZGVsLkdpdGh1YkV2ZW50IkYKEUdpdERhdGFSZXBvc2l0b3J5EgwKBG5hbWUYASABKAkSFgoOZGVmYXVsdF9icmFuY2gYAiABKAkSCwoDdXJsGAMgASgJIhQKElJlcXVlc3RMaXN0R2l0RGF0YSJOChNSZXNwb25zZUxpc3RHaXREYXRhEjcKDHJlcG9zaXRvcmllcxgBIAMoCzIhLm1vbm8uYnVpbGQuYmZmLkdpdERhdGFSZXBvc2l0b3J5IisKG1JlcXVlc3RHZXRHaXREYXRhU3RhdGlzdGljcxIMCgRyZXBvGAEgASgJIqYBChxSZXNwb25zZUdldEdpdERhdGFTdGF0aXN0aWNzEhcKD2hlYWRfY29tbWl0X3NoYRgBIAEoCRIbChNoZWFkX2NvbW1pdF9tZXNzYWdlGAIgASgJEhoKEmhlYWRfY29tbWl0X2F1dGhvchgDIAEoCRI0ChBoZWFkX2NvbW1pdF93aGVuGAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCKWBgoHQkZGVGFzaxIKCgJpZBgBIAEoBRIwCgpyZXBvc2l0b3J5GAIgASgLMhwubW9uby5idWlsZC5tb2RlbC5SZXBvc2l0b3J5EhAKCGpvYl9uYW1lGAMgASgJEiAKGHBhcnNlZF9qb2JfY29uZmlndXJhdGlvbhgEIAEoCRIQCghyZXZpc2lvbhgFIAEoCRIVCg1iYXplbF92ZXJzaW9uGAYgASgJEg8KB2NvbW1hbmQYByABKAkSEAoIaXNfdHJ1bmsYCCABKAgSDwoHc3VjY2VzcxgJIAEoCBIQCghsb2dfZmlsZRgKIAEoCRIPCgd0YXJnZXRzGAsgAygJEhAKCHBsYXRmb3JtGAwgASgJEgsKA3ZpYRgNIAEoCRITCgtjb25maWdfbmFtZRgOIAEoCRIMCgRub2RlGA8gASgJEhAKCG1hbmlmZXN0GBAgASgJEhEKCWNvbnRhaW5lchgRIAEoCRIcChRleGVjdXRlZF90ZXN0c19jb3VudBgSIAEoBRIdChVzdWNjZWVkZWRfdGVzdHNfY291bnQYEyABKAUSLAoIc3RhcnRfYXQYFCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi8KC2ZpbmlzaGVkX2F0GBUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgpjcmVhdGVkX2F0GBYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GBcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIWCg5yZXBvc2l0b3J5X3VybBgYIAEoCRIUCgxyZXZpc2lvbl91cmwYGSABKAkSEQoJY3B1X2xpbWl0GBogASgJEhQKDG1lbW9yeV9saW1pdBgbIAEoCRIyCgx0ZXN0X3JlcG9ydHMYHCADKAsyHC5tb25vLmJ1aWxkLm1vZGVsLlRlc3RSZXBvcnQSKwoIZHVyYXRpb24YHSABKAsyGS5nb29nbGUucHJvdG9idWYuRHVyYXRpb24ywgoKA0JGRhJlChBMaXN0UmVwb3NpdG9yaWVzEicubW9uby5idWlsZC5iZmYuUmVxdWVzdExpc3RSZXBvc2l0b3JpZXMaKC5tb25vLmJ1aWxkLmJmZi5SZXNwb25zZUxpc3RSZXBvc2l0b3JpZXMSUAoJTGlzdFRhc2tzEiAubW9uby5idWlsZC5iZmYuUmVxdWVzdExpc3RUYXNrcxohLm1vbm8uYnVpbGQuYmZmLlJlc3BvbnNlTGlzdFRhc2tzEkoKB0dldExvZ3MSHi5tb25vLmJ1aWxkLmJmZi5SZXF1ZXN0R2V0TG9ncxofLm1vbm8uYnVpbGQuYmZmLlJlc3BvbnNlR2V0TG9ncxJcCg1HZXRTZXJ2ZXJJbmZvEiQubW9uby5idWlsZC5iZmYuUmVxdWVzdEdldFNlcnZlckluZm8aJS5tb25vLmJ1aWxkLmJmZi5SZXNwb25zZUdldFNlcnZlckluZm8STQoITGlzdEpvYnMSHy5tb25vLmJ1aWxkLmJmZi5SZXF1ZXN0TGlzdEpvYnMaIC5tb25vLmJ1aWxkLmJmZi5SZXNwb25zZUxpc3RKb2JzElAKCUludm9rZUpvYhIgLm1vbm8uYnVpbGQuYmZmLlJlcXVlc3RJbnZva2VKb2IaIS5tb25vLmJ1aWxkLmJmZi5SZXNwb25zZUludm9rZUpvYhJfCg5TYXZlUmVwb3NpdG9yeRIlLm1vbm8uYnVpbGQuYmZmLlJlcXVlc3RTYXZlUmVwb3NpdG9yeRomLm1vbm8uYnVpbGQuYmZmLlJlc3BvbnNlU2F2ZVJlcG9zaXRvcnkSZQoQUmVtb3ZlUmVwb3NpdG9yeRInLm1vbm8uYnVpbGQuYmZmLlJlcXVlc3RSZW1vdmVSZXBvc2l0b3J5GigubW9uby5idWlsZC5iZmYuUmVzcG9uc2VSZW1vdmVSZXBvc2l0b3J5ElYKC1Jlc3RhcnRUYXNrEiIubW9uby5idWlsZC5iZmYuUmVxdWVzdFJlc3RhcnRUYXNrGiMubW9uby5idWlsZC5iZmYuUmVzcG9uc2VSZXN0YXJ0VGFzaxJcCg1Gb3JjZVN0b3BUYXNrEiQubW9uby5idWlsZC5iZmYuUmVxdWVzdEZvcmNlU3RvcFRhc2saJS5tb25vLmJ1aWxkLmJmZi5SZXNwb25zZUZvcmNlU3RvcFRhc2sShgEKG0xpc3RFeHRlcm5hbFJlbGVhc2VUcmlnZ2VycxIyLm1vbm8uYnVpbGQuYmZmLlJlcXVlc3RMaXN0RXh0ZXJuYWxSZWxlYXNlVHJpZ2dlcnMaMy5tb25vLmJ1aWxkLmJmZi5SZXNwb25zZUxpc3RFeHRlcm5hbFJlbGVhc2VUcmlnZ2VycxJlChBMaXN0R2l0aHViRXZlbnRzEicubW9uby5idWlsZC5iZmYuUmVxdWVzdExpc3RHaXRodWJFdmVudHMaKC5tb25vLmJ1aWxkLmJmZi5SZXNwb25zZUxpc3RHaXRodWJFdmVudHMSVgoLTGlzdEdpdERhdGESIi5tb25vLmJ1aWxkLmJmZi5SZXF1ZXN0TGlzdEdpdERhdGEaIy5tb25vLmJ1aWxkLmJmZi5SZXNwb25zZUxpc3RHaXREYXRhEnEKFEdldEdpdERhdGFTdGF0aXN0aWNzEisubW9uby5idWlsZC5iZmYuUmVxdWVzdEdldEdpdERhdGFTdGF0aXN0aWNzGiwubW9uby5idWlsZC5iZmYuUmVzcG9uc2VHZXRHaXREYXRhU3RhdGlzdGljc0InWh1nby5mMTEwLmRldi9tb25vL2dvL2J1aWxkL2JmZpIDBdI+AhADYghlZGl0aW9uc3DoBw", [file_google_protobuf_go_features, file_google_protobuf_timestamp, file_google_protobuf_duration, file_proto_build_model_msg]);
+  fileDesc("Chlwcm90by9idWlsZC9iZmYvYmZmLnByb3RvEg5tb25vLmJ1aWxkLmJmZiIZChdSZXF1ZXN0TGlzdFJlcG9zaXRvcmllcyJOChhSZXNwb25zZUxpc3RSZXBvc2l0b3JpZXMSMgoMcmVwb3NpdG9yaWVzGAEgAygLMhwubW9uby5idWlsZC5tb2RlbC5SZXBvc2l0b3J5ImEKEFJlcXVlc3RMaXN0VGFza3MSFQoNcmVwb3NpdG9yeV9pZBgBIAEoBRIPCgd0YXNrX2lkGAIgASgFEhEKCXBhZ2Vfc2l6ZRgDIAEoBRISCgpwYWdlX3Rva2VuGAQgASgJIlQKEVJlc3BvbnNlTGlzdFRhc2tzEiYKBXRhc2tzGAEgAygLMhcubW9uby5idWlsZC5iZmYuQkZGVGFzaxIXCg9uZXh0X3BhZ2VfdG9rZW4YAiABKAkiIQoOUmVxdWVzdEdldExvZ3MSDwoHdGFza19pZBgBIAEoBSIfCg9SZXNwb25zZUdldExvZ3MSDAoEYm9keRgBIAEoCSIWChRSZXF1ZXN0R2V0U2VydmVySW5mbyJ/ChVSZXNwb25zZUdldFNlcnZlckluZm8SIAoYc3VwcG9ydGVkX2JhemVsX3ZlcnNpb25zGAEgAygJEhYKDnNjaGVtYV92ZXJzaW9uGAIgASgJEiwKBmNvbmZpZxgDIAEoCzIcLm1vbm8uYnVpbGQuYmZmLlNlcnZlckNvbmZpZyLpAwoMU2VydmVyQ29uZmlnEgsKA2RldhgBIAEoCBIXCg9sZWFkZXJfZWxlY3Rpb24YAiABKAgSEQoJbmFtZXNwYWNlGAMgASgJEhQKDHVzZV9iYXplbGlzaxgEIAEoCBIdChVkZWZhdWx0X2JhemVsX3ZlcnNpb24YBSABKAkSFAoMcmVtb3RlX2NhY2hlGAYgASgJEhYKDnRhc2tfY3B1X2xpbWl0GAcgASgJEhkKEXRhc2tfbWVtb3J5X2xpbWl0GAggASgJEhIKCmdjX2VuYWJsZWQYCSABKAgSHwoXZ2l0X2RhdGFfc2VydmljZV9saXN0ZW4YCiABKAkSHAoUZ2l0X2RhdGFfc2VydmljZV91cmwYCyABKAkSIQoZZ2l0X2RhdGFfcmVmcmVzaF9pbnRlcnZhbBgMIAEoCRIgChhnaXRfZGF0YV9yZWZyZXNoX3dvcmtlcnMYDSABKAUSJgoeZXh0ZXJuYWxfcmVsZWFzZV9wb2xsX2ludGVydmFsGA4gASgJEiAKGGV2ZW50X3JlY29uY2lsZV9pbnRlcnZhbBgPIAEoCRIVCg1naXRodWJfYXBwX2lkGBAgASgDEhIKCnZhdWx0X2FkZHIYESABKAkSFQoNZGFzaGJvYXJkX3VybBgSIAEoCSIoCg9SZXF1ZXN0TGlzdEpvYnMSFQoNcmVwb3NpdG9yeV9pZBgBIAEoBSI3ChBSZXNwb25zZUxpc3RKb2JzEiMKBGpvYnMYASADKAsyFS5tb25vLmJ1aWxkLm1vZGVsLkpvYiI7ChBSZXF1ZXN0SW52b2tlSm9iEhUKDXJlcG9zaXRvcnlfaWQYASABKAUSEAoIam9iX25hbWUYAiABKAkiEwoRUmVzcG9uc2VJbnZva2VKb2IiSQoVUmVxdWVzdFNhdmVSZXBvc2l0b3J5EjAKCnJlcG9zaXRvcnkYASABKAsyHC5tb25vLmJ1aWxkLm1vZGVsLlJlcG9zaXRvcnkiSgoWUmVzcG9uc2VTYXZlUmVwb3NpdG9yeRIwCgpyZXBvc2l0b3J5GAEgASgLMhwubW9uby5idWlsZC5tb2RlbC5SZXBvc2l0b3J5IjAKF1JlcXVlc3RSZW1vdmVSZXBvc2l0b3J5EhUKDXJlcG9zaXRvcnlfaWQYASABKAUiGgoYUmVzcG9uc2VSZW1vdmVSZXBvc2l0b3J5IiUKElJlcXVlc3RSZXN0YXJ0VGFzaxIPCgd0YXNrX2lkGAEgASgFIhUKE1Jlc3BvbnNlUmVzdGFydFRhc2siJwoUUmVxdWVzdEZvcmNlU3RvcFRhc2sSDwoHdGFza19pZBgBIAEoBSIXChVSZXNwb25zZUZvcmNlU3RvcFRhc2siOwoiUmVxdWVzdExpc3RFeHRlcm5hbFJlbGVhc2VUcmlnZ2VycxIVCg1yZXBvc2l0b3J5X2lkGAEgASgFImEKI1Jlc3BvbnNlTGlzdEV4dGVybmFsUmVsZWFzZVRyaWdnZXJzEjoKCHRyaWdnZXJzGAEgAygLMigubW9uby5idWlsZC5tb2RlbC5FeHRlcm5hbFJlbGVhc2VUcmlnZ2VyIisKF1JlcXVlc3RMaXN0R2l0aHViRXZlbnRzEhAKCGV2ZW50X2lkGAEgASgFIkkKGFJlc3BvbnNlTGlzdEdpdGh1YkV2ZW50cxItCgZldmVudHMYASADKAsyHS5tb25vLmJ1aWxkLm1vZGVsLkdpdGh1YkV2ZW50IiwKGVJlcXVlc3RHZXRUYXNrQnVpbGRSZXN1bHQSDwoHdGFza19pZBgBIAEoBSK4AQoaUmVzcG9uc2VHZXRUYXNrQnVpbGRSZXN1bHQSLwoHdGFyZ2V0cxgBIAMoCzIeLm1vbm8uYnVpbGQubW9kZWwuVGFyZ2V0UmVzdWx0EjgKD2FjdGlvbl9mYWlsdXJlcxgCIAMoCzIfLm1vbm8uYnVpbGQubW9kZWwuQWN0aW9uRmFpbHVyZRIvCgdtZXRyaWNzGAMgASgLMh4ubW9uby5idWlsZC5tb2RlbC5CdWlsZE1ldHJpY3MiSAoVUmVxdWVzdExpc3RGbGFreVRlc3RzEhUKDXJlcG9zaXRvcnlfaWQYASABKAUSGAoQcXVhcmFudGluZWRfb25seRgCIAEoCCJEChZSZXNwb25zZUxpc3RGbGFreVRlc3RzEioKBXRlc3RzGAEgAygLMhsubW9uby5idWlsZC5tb2RlbC5GbGFreVRlc3QiMgoPUmVxdWVzdFRhaWxMb2dzEg8KB3Rhc2tfaWQYASABKAUSDgoGb2Zmc2V0GAIgASgDIkIKEFJlc3BvbnNlVGFpbExvZ3MSDAoEYm9keRgBIAEoDBIOCgZvZmZzZXQYAiABKAMSEAoIZmluaXNoZWQYAyABKAgiJwoUUmVxdWVzdExpc3RBcnRpZmFjdHMSDwoHdGFza19pZBgBIAEoBSJGChVSZXNwb25zZUxpc3RBcnRpZmFjdHMSLQoJYXJ0aWZhY3RzGAEgAygLMhoubW9uby5idWlsZC5tb2RlbC5BcnRpZmFjdCI4ChdSZXF1ZXN0RG93bmxvYWRBcnRpZmFjdBIPCgd0YXNrX2lkGAEgASgFEgwKBHBhdGgYAiABKAkiKAoYUmVzcG9uc2VEb3dubG9hZEFydGlmYWN0EgwKBGJvZHkYASABKAwiRgoRR2l0RGF0YVJlcG9zaXRvcnkSDAoEbmFtZRgBIAEoCRIWCg5kZWZhdWx0X2JyYW5jaBgCIAEoCRILCgN1cmwYAyABKAkiFAoSUmVxdWVzdExpc3RHaXREYXRhIk4KE1Jlc3BvbnNlTGlzdEdpdERhdGESNwoMcmVwb3NpdG9yaWVzGAEgAygLMiEubW9uby5idWlsZC5iZmYuR2l0RGF0YVJlcG9zaXRvcnkiKwobUmVxdWVzdEdldEdpdERhdGFTdGF0aXN0aWNzEgwKBHJlcG8YASABKAkipgEKHFJlc3BvbnNlR2V0R2l0RGF0YVN0YXRpc3RpY3MSFwoPaGVhZF9jb21taXRfc2hhGAEgASgJEhsKE2hlYWRfY29tbWl0X21lc3NhZ2UYAiABKAkSGgoSaGVhZF9jb21taXRfYXV0aG9yGAMgASgJEjQKEGhlYWRfY29tbWl0X3doZW4YBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wItoHCgdCRkZUYXNrEgoKAmlkGAEgASgFEjAKCnJlcG9zaXRvcnkYAiABKAsyHC5tb25vLmJ1aWxkLm1vZGVsLlJlcG9zaXRvcnkSEAoIam9iX25hbWUYAyABKAkSIAoYcGFyc2VkX2pvYl9jb25maWd1cmF0aW9uGAQgASgJEhAKCHJldmlzaW9uGAUgASgJEhUKDWJhemVsX3ZlcnNpb24YBiABKAkSDwoHY29tbWFuZBgHIAEoCRIQCghpc190cnVuaxgIIAEoCBIPCgdzdWNjZXNzGAkgASgIEhAKCGxvZ19maWxlGAogASgJEg8KB3RhcmdldHMYCyADKAkSEAoIcGxhdGZvcm0YDCABKAkSCwoDdmlhGA0gASgJEhMKC2NvbmZpZ19uYW1lGA4gASgJEgwKBG5vZGUYDyABKAkSEAoIbWFuaWZlc3QYECABKAkSEQoJY29udGFpbmVyGBEgASgJEhwKFGV4ZWN1dGVkX3Rlc3RzX2NvdW50GBIgASgFEh0KFXN1Y2NlZWRlZF90ZXN0c19jb3VudBgTIAEoBRIsCghzdGFydF9hdBgUIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLwoLZmluaXNoZWRfYXQYFSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCmNyZWF0ZWRfYXQYFiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCnVwZGF0ZWRfYXQYFyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhYKDnJlcG9zaXRvcnlfdXJsGBggASgJEhQKDHJldmlzaW9uX3VybBgZIAEoCRIRCgljcHVfbGltaXQYGiABKAkSFAoMbWVtb3J5X2xpbWl0GBsgASgJEjIKDHRlc3RfcmVwb3J0cxgcIAMoCzIcLm1vbm8uYnVpbGQubW9kZWwuVGVzdFJlcG9ydBIrCghkdXJhdGlvbhgdIAEoCzIZLmdvb2dsZS5wcm90b2J1Zi5EdXJhdGlvbhIPCgdza2lwcGVkGB4gASgIEg0KBW5lZWRzGB8gAygJEg8KB2F0dGVtcHQYICABKAUSFgoOcGFyZW50X3Rhc2tfaWQYISABKAUSFQoNY2FuY2VsX3JlYXNvbhgiIAEoCRIzCgZtYXRyaXgYIyADKAsyIy5tb25vLmJ1aWxkLmJmZi5CRkZUYXNrLk1hdHJpeEVudHJ5Gi0KC01hdHJpeEVudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEyqA4KA0JGRhJlChBMaXN0UmVwb3NpdG9yaWVzEicubW9uby5idWlsZC5iZmYuUmVxdWVzdExpc3RSZXBvc2l0b3JpZXMaKC5tb25vLmJ1aWxkLmJmZi5SZXNwb25zZUxpc3RSZXBvc2l0b3JpZXMSUAoJTGlzdFRhc2tzEiAubW9uby5idWlsZC5iZmYuUmVxdWVzdExpc3RUYXNrcxohLm1vbm8uYnVpbGQuYmZmLlJlc3BvbnNlTGlzdFRhc2tzEkoKB0dldExvZ3MSHi5tb25vLmJ1aWxkLmJmZi5SZXF1ZXN0R2V0TG9ncxofLm1vbm8uYnVpbGQuYmZmLlJlc3BvbnNlR2V0TG9ncxJcCg1HZXRTZXJ2ZXJJbmZvEiQubW9uby5idWlsZC5iZmYuUmVxdWVzdEdldFNlcnZlckluZm8aJS5tb25vLmJ1aWxkLmJmZi5SZXNwb25zZUdldFNlcnZlckluZm8STQoITGlzdEpvYnMSHy5tb25vLmJ1aWxkLmJmZi5SZXF1ZXN0TGlzdEpvYnMaIC5tb25vLmJ1aWxkLmJmZi5SZXNwb25zZUxpc3RKb2JzElAKCUludm9rZUpvYhIgLm1vbm8uYnVpbGQuYmZmLlJlcXVlc3RJbnZva2VKb2IaIS5tb25vLmJ1aWxkLmJmZi5SZXNwb25zZUludm9rZUpvYhJfCg5TYXZlUmVwb3NpdG9yeRIlLm1vbm8uYnVpbGQuYmZmLlJlcXVlc3RTYXZlUmVwb3NpdG9yeRomLm1vbm8uYnVpbGQuYmZmLlJlc3BvbnNlU2F2ZVJlcG9zaXRvcnkSZQoQUmVtb3ZlUmVwb3NpdG9yeRInLm1vbm8uYnVpbGQuYmZmLlJlcXVlc3RSZW1vdmVSZXBvc2l0b3J5GigubW9uby5idWlsZC5iZmYuUmVzcG9uc2VSZW1vdmVSZXBvc2l0b3J5ElYKC1Jlc3RhcnRUYXNrEiIubW9uby5idWlsZC5iZmYuUmVxdWVzdFJlc3RhcnRUYXNrGiMubW9uby5idWlsZC5iZmYuUmVzcG9uc2VSZXN0YXJ0VGFzaxJcCg1Gb3JjZVN0b3BUYXNrEiQubW9uby5idWlsZC5iZmYuUmVxdWVzdEZvcmNlU3RvcFRhc2saJS5tb25vLmJ1aWxkLmJmZi5SZXNwb25zZUZvcmNlU3RvcFRhc2sShgEKG0xpc3RFeHRlcm5hbFJlbGVhc2VUcmlnZ2VycxIyLm1vbm8uYnVpbGQuYmZmLlJlcXVlc3RMaXN0RXh0ZXJuYWxSZWxlYXNlVHJpZ2dlcnMaMy5tb25vLmJ1aWxkLmJmZi5SZXNwb25zZUxpc3RFeHRlcm5hbFJlbGVhc2VUcmlnZ2VycxJlChBMaXN0R2l0aHViRXZlbnRzEicubW9uby5idWlsZC5iZmYuUmVxdWVzdExpc3RHaXRodWJFdmVudHMaKC5tb25vLmJ1aWxkLmJmZi5SZXNwb25zZUxpc3RHaXRodWJFdmVudHMSawoSR2V0VGFza0J1aWxkUmVzdWx0EikubW9uby5idWlsZC5iZmYuUmVxdWVzdEdldFRhc2tCdWlsZFJlc3VsdBoqLm1vbm8uYnVpbGQuYmZmLlJlc3BvbnNlR2V0VGFza0J1aWxkUmVzdWx0El8KDkxpc3RGbGFreVRlc3RzEiUubW9uby5idWlsZC5iZmYuUmVxdWVzdExpc3RGbGFreVRlc3RzGiYubW9uby5idWlsZC5iZmYuUmVzcG9uc2VMaXN0Rmxha3lUZXN0cxJPCghUYWlsTG9ncxIfLm1vbm8uYnVpbGQuYmZmLlJlcXVlc3RUYWlsTG9ncxogLm1vbm8uYnVpbGQuYmZmLlJlc3BvbnNlVGFpbExvZ3MwARJcCg1MaXN0QXJ0aWZhY3RzEiQubW9uby5idWlsZC5iZmYuUmVxdWVzdExpc3RBcnRpZmFjdHMaJS5tb25vLmJ1aWxkLmJmZi5SZXNwb25zZUxpc3RBcnRpZmFjdHMSZwoQRG93bmxvYWRBcnRpZmFjdBInLm1vbm8uYnVpbGQuYmZmLlJlcXVlc3REb3dubG9hZEFydGlmYWN0GigubW9uby5idWlsZC5iZmYuUmVzcG9uc2VEb3dubG9hZEFydGlmYWN0MAESVgoLTGlzdEdpdERhdGESIi5tb25vLmJ1aWxkLmJmZi5SZXF1ZXN0TGlzdEdpdERhdGEaIy5tb25vLmJ1aWxkLmJmZi5SZXNwb25zZUxpc3RHaXREYXRhEnEKFEdldEdpdERhdGFTdGF0aXN0aWNzEisubW9uby5idWlsZC5iZmYuUmVxdWVzdEdldEdpdERhdGFTdGF0aXN0aWNzGiwubW9uby5idWlsZC5iZmYuUmVzcG9uc2VHZXRHaXREYXRhU3RhdGlzdGljc0InWh1nby5mMTEwLmRldi9tb25vL2dvL2J1aWxkL2JmZpIDBdI+AhADYghlZGl0aW9uc3DoBw", [file_google_protobuf_go_features, file_google_protobuf_timestamp, file_google_protobuf_duration, file_proto_build_model_msg]);
 
 /**
  * Describes the message mono.build.bff.RequestListRepositories.
@@ -187,47 +187,117 @@ export const RequestListGithubEventsSchema = /*@__PURE__*/
 export const ResponseListGithubEventsSchema = /*@__PURE__*/
   messageDesc(file_proto_build_bff_bff, 24);
 
+/**
+ * Describes the message mono.build.bff.RequestGetTaskBuildResult.
+ * Use `create(RequestGetTaskBuildResultSchema)` to create a new message.
+ */
+export const RequestGetTaskBuildResultSchema = /*@__PURE__*/
+  messageDesc(file_proto_build_bff_bff, 25);
+
+/**
+ * Describes the message mono.build.bff.ResponseGetTaskBuildResult.
+ * Use `create(ResponseGetTaskBuildResultSchema)` to create a new message.
+ */
+export const ResponseGetTaskBuildResultSchema = /*@__PURE__*/
+  messageDesc(file_proto_build_bff_bff, 26);
+
+/**
+ * Describes the message mono.build.bff.RequestListFlakyTests.
+ * Use `create(RequestListFlakyTestsSchema)` to create a new message.
+ */
+export const RequestListFlakyTestsSchema = /*@__PURE__*/
+  messageDesc(file_proto_build_bff_bff, 27);
+
+/**
+ * Describes the message mono.build.bff.ResponseListFlakyTests.
+ * Use `create(ResponseListFlakyTestsSchema)` to create a new message.
+ */
+export const ResponseListFlakyTestsSchema = /*@__PURE__*/
+  messageDesc(file_proto_build_bff_bff, 28);
+
+/**
+ * Describes the message mono.build.bff.RequestTailLogs.
+ * Use `create(RequestTailLogsSchema)` to create a new message.
+ */
+export const RequestTailLogsSchema = /*@__PURE__*/
+  messageDesc(file_proto_build_bff_bff, 29);
+
+/**
+ * Describes the message mono.build.bff.ResponseTailLogs.
+ * Use `create(ResponseTailLogsSchema)` to create a new message.
+ */
+export const ResponseTailLogsSchema = /*@__PURE__*/
+  messageDesc(file_proto_build_bff_bff, 30);
+
+/**
+ * Describes the message mono.build.bff.RequestListArtifacts.
+ * Use `create(RequestListArtifactsSchema)` to create a new message.
+ */
+export const RequestListArtifactsSchema = /*@__PURE__*/
+  messageDesc(file_proto_build_bff_bff, 31);
+
+/**
+ * Describes the message mono.build.bff.ResponseListArtifacts.
+ * Use `create(ResponseListArtifactsSchema)` to create a new message.
+ */
+export const ResponseListArtifactsSchema = /*@__PURE__*/
+  messageDesc(file_proto_build_bff_bff, 32);
+
+/**
+ * Describes the message mono.build.bff.RequestDownloadArtifact.
+ * Use `create(RequestDownloadArtifactSchema)` to create a new message.
+ */
+export const RequestDownloadArtifactSchema = /*@__PURE__*/
+  messageDesc(file_proto_build_bff_bff, 33);
+
+/**
+ * Describes the message mono.build.bff.ResponseDownloadArtifact.
+ * Use `create(ResponseDownloadArtifactSchema)` to create a new message.
+ */
+export const ResponseDownloadArtifactSchema = /*@__PURE__*/
+  messageDesc(file_proto_build_bff_bff, 34);
+
 /**
  * Describes the message mono.build.bff.GitDataRepository.
  * Use `create(GitDataRepositorySchema)` to create a new message.
  */
 export const GitDataRepositorySchema = /*@__PURE__*/
-  messageDesc(file_proto_build_bff_bff, 25);
+  messageDesc(file_proto_build_bff_bff, 35);
 
 /**
  * Describes the message mono.build.bff.RequestListGitData.
  * Use `create(RequestListGitDataSchema)` to create a new message.
  */
 export const RequestListGitDataSchema = /*@__PURE__*/
-  messageDesc(file_proto_build_bff_bff, 26);
+  messageDesc(file_proto_build_bff_bff, 36);
 
 /**
  * Describes the message mono.build.bff.ResponseListGitData.
  * Use `create(ResponseListGitDataSchema)` to create a new message.
  */
 export const ResponseListGitDataSchema = /*@__PURE__*/
-  messageDesc(file_proto_build_bff_bff, 27);
+  messageDesc(file_proto_build_bff_bff, 37);
 
 /**
  * Describes the message mono.build.bff.RequestGetGitDataStatistics.
  * Use `create(RequestGetGitDataStatisticsSchema)` to create a new message.
  */
 export const RequestGetGitDataStatisticsSchema = /*@__PURE__*/
-  messageDesc(file_proto_build_bff_bff, 28);
+  messageDesc(file_proto_build_bff_bff, 38);
 
 /**
  * Describes the message mono.build.bff.ResponseGetGitDataStatistics.
  * Use `create(ResponseGetGitDataStatisticsSchema)` to create a new message.
  */
 export const ResponseGetGitDataStatisticsSchema = /*@__PURE__*/
-  messageDesc(file_proto_build_bff_bff, 29);
+  messageDesc(file_proto_build_bff_bff, 39);
 
 /**
  * Describes the message mono.build.bff.BFFTask.
  * Use `create(BFFTaskSchema)` to create a new message.
  */
 export const BFFTaskSchema = /*@__PURE__*/
-  messageDesc(file_proto_build_bff_bff, 30);
+  messageDesc(file_proto_build_bff_bff, 40);
 
 /**
  * @generated from service mono.build.bff.BFF
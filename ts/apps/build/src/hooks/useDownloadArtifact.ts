@@ -0,0 +1,32 @@
+import { createClient } from '@connectrpc/connect'
+import { useTransport } from '@connectrpc/connect-query'
+import { useCallback } from 'react'
+import { BFF } from '../connect/bff_pb'
+
+// useDownloadArtifact returns the function which downloads the artifact of
+// the task. The artifact is streamed in chunks and saved as a file by the
+// browser.
+export function useDownloadArtifact(): (
+  taskId: number,
+  path: string,
+) => Promise<void> {
+  const transport = useTransport()
+
+  return useCallback(
+    async (taskId: number, path: string) => {
+      const client = createClient(BFF, transport)
+      const chunks: Uint8Array[] = []
+      for await (const res of client.downloadArtifact({ taskId, path })) {
+        chunks.push(res.body)
+      }
+
+      const url = URL.createObjectURL(new Blob(chunks))
+      const a = document.createElement('a')
+      a.href = url
+      a.download = path.split('/').pop() ?? path
+      a.click()
+      URL.revokeObjectURL(url)
+    },
+    [transport],
+  )
+}
@@ -0,0 +1,11 @@
+import { useQuery } from '@connectrpc/connect-query'
+import { BFF, type ResponseGetTaskBuildResult } from '../connect/bff_pb'
+
+// useGetTaskBuildResult fetches the result of targets, the failed actions and
+// the metrics which are collected from the build event stream of the task.
+export function useGetTaskBuildResult(
+  taskId: number,
+): ResponseGetTaskBuildResult | undefined {
+  const res = useQuery(BFF.method.getTaskBuildResult, { taskId })
+  return res.data
+}
@@ -0,0 +1,9 @@
+import { useQuery } from '@connectrpc/connect-query'
+import { BFF } from '../connect/bff_pb'
+import type { Artifact } from '../model/msg_pb'
+
+// useListArtifacts fetches the artifacts which are published by the task.
+export function useListArtifacts(taskId: number): Artifact[] {
+  const res = useQuery(BFF.method.listArtifacts, { taskId })
+  return res.data?.artifacts ?? []
+}
@@ -0,0 +1,60 @@
+import { createClient } from '@connectrpc/connect'
+import { useTransport } from '@connectrpc/connect-query'
+import { useEffect, useState } from 'react'
+import { BFF } from '../connect/bff_pb'
+
+// reconnectDelayMs is the delay before resuming the stream after it was
+// disconnected.
+const reconnectDelayMs = 3000
+
+type Logs = {
+  body: string
+  finished: boolean
+}
+
+// useTailLogs streams the log of the task while it is running. When the
+// stream is disconnected, it is resumed from the offset of the received log
+// so that no part of the log is lost or duplicated.
+export function useTailLogs(taskId: number): Logs {
+  const transport = useTransport()
+  const [logs, setLogs] = useState<Logs>({ body: '', finished: false })
+
+  useEffect(() => {
+    const client = createClient(BFF, transport)
+    const abort = new AbortController()
+    const decoder = new TextDecoder()
+    let offset = 0n
+    let body = ''
+    setLogs({ body, finished: false })
+
+    const tail = async () => {
+      while (!abort.signal.aborted) {
+        try {
+          for await (const res of client.tailLogs(
+            { taskId, offset },
+            { signal: abort.signal },
+          )) {
+            body += decoder.decode(res.body, { stream: true })
+            offset = res.offset + BigInt(res.body.length)
+            if (res.finished) {
+              body += decoder.decode()
+              setLogs({ body, finished: true })
+              return
+            }
+            setLogs({ body, finished: false })
+          }
+        } catch {
+          if (abort.signal.aborted) {
+            return
+          }
+        }
+        await new Promise((resolve) => setTimeout(resolve, reconnectDelayMs))
+      }
+    }
+    void tail()
+
+    return () => abort.abort()
+  }, [taskId, transport])
+
+  return logs
+}
@@ -9,7 +9,7 @@ import { file_google_protobuf_go_features, file_google_protobuf_timestamp } from
  * Describes the file proto/build/model/msg.proto.
  */
 export const file_proto_build_model_msg = /*@__PURE__*/
-  fileDesc("Chtwcm90by9idWlsZC9tb2RlbC9tc2cucHJvdG8SEG1vbm8uYnVpbGQubW9kZWwibgoKUmVwb3NpdG9yeRIKCgJpZBgBIAEoBRIMCgRuYW1lGAIgASgJEgsKA3VybBgDIAEoCRIRCgljbG9uZV91cmwYBCABKAkSDwoHcHJpdmF0ZRgFIAEoCBIVCg1oZWFkX3JldmlzaW9uGAcgASgJIssFCgRUYXNrEgoKAmlkGAEgASgFEhUKDXJlcG9zaXRvcnlfaWQYAiABKAUSEAoIam9iX25hbWUYAyABKAkSIAoYcGFyc2VkX2pvYl9jb25maWd1cmF0aW9uGAQgASgJEhAKCHJldmlzaW9uGAUgASgJEhUKDWJhemVsX3ZlcnNpb24YBiABKAkSDwoHY29tbWFuZBgHIAEoCRIQCghpc190cnVuaxgIIAEoCBIPCgdzdWNjZXNzGAkgASgIEhAKCGxvZ19maWxlGAogASgJEg8KB3RhcmdldHMYCyADKAkSEAoIcGxhdGZvcm0YDCABKAkSCwoDdmlhGA0gASgJEhMKC2NvbmZpZ19uYW1lGA4gASgJEgwKBG5vZGUYDyABKAkSEAoIbWFuaWZlc3QYECABKAkSEQoJY29udGFpbmVyGBEgASgJEhwKFGV4ZWN1dGVkX3Rlc3RzX2NvdW50GBIgASgFEh0KFXN1Y2NlZWRlZF90ZXN0c19jb3VudBgTIAEoBRIsCghzdGFydF9hdBgUIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLwoLZmluaXNoZWRfYXQYFSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCmNyZWF0ZWRfYXQYFiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCnVwZGF0ZWRfYXQYFyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhYKDnJlcG9zaXRvcnlfdXJsGBggASgJEhQKDHJldmlzaW9uX3VybBgZIAEoCRIRCgljcHVfbGltaXQYGiABKAkSFAoMbWVtb3J5X2xpbWl0GBsgASgJEjIKDHRlc3RfcmVwb3J0cxgcIAMoCzIcLm1vbm8uYnVpbGQubW9kZWwuVGVzdFJlcG9ydCIqCgNKb2ISDAoEbmFtZRgBIAEoCRIVCg1yZXBvc2l0b3J5X2lkGAIgASgFIlsKClRlc3RSZXBvcnQSDQoFbGFiZWwYASABKAkSLAoGc3RhdHVzGAIgASgOMhwubW9uby5idWlsZC5tb2RlbC5UZXN0U3RhdHVzEhAKCGR1cmF0aW9uGAMgASgDIpECCgtHaXRodWJFdmVudBIKCgJpZBgBIAEoBRITCgtkZWxpdmVyeV9pZBgCIAEoCRISCgpldmVudF90eXBlGAMgASgJEg4KBmFjdGlvbhgEIAEoCRINCgVzdGF0ZRgFIAEoCRIOCgZzdGF0dXMYBiABKAkSEgoKbGFzdF9lcnJvchgHIAEoCRIuCgpjcmVhdGVkX2F0GAggASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GAkgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBISCgpyZXBvc2l0b3J5GAogASgJEhYKDnJlcG9zaXRvcnlfdXJsGAsgASgJIoECChZFeHRlcm5hbFJlbGVhc2VUcmlnZ2VyEgoKAmlkGAEgASgFEhUKDXJlcG9zaXRvcnlfaWQYAiABKAUSFwoPcmVwb3NpdG9yeV9uYW1lGAMgASgJEhYKDnJlcG9zaXRvcnlfdXJsGAQgASgJEhAKCGpvYl9uYW1lGAUgASgJEhAKCHByb3ZpZGVyGAYgASgJEhUKDWV4dGVybmFsX3JlcG8YByABKAkSGQoRZXh0ZXJuYWxfcmVwb191cmwYCCABKAkSDAoEa2luZBgJIAEoCRITCgt0YWdfcGF0dGVybhgKIAEoCRIaChJpbmNsdWRlX3ByZXJlbGVhc2UYCyABKAgqUwoKVGVzdFN0YXR1cxIWChJURVNUX1NUQVRVU19QQVNTRUQQABIVChFURVNUX1NUQVRVU19GTEFLWRABEhYKElRFU1RfU1RBVFVTX0ZBSUxFRBACQilaH2dvLmYxMTAuZGV2L21vbm8vZ28vYnVpbGQvbW9kZWySAwXSPgIQA2IIZWRpdGlvbnNw6Ac", [file_google_protobuf_go_features, file_google_protobuf_timestamp]);
+  fileDesc("Chtwcm90by9idWlsZC9tb2RlbC9tc2cucHJvdG8SEG1vbm8uYnVpbGQubW9kZWwibgoKUmVwb3NpdG9yeRIKCgJpZBgBIAEoBRIMCgRuYW1lGAIgASgJEgsKA3VybBgDIAEoCRIRCgljbG9uZV91cmwYBCABKAkSDwoHcHJpdmF0ZRgFIAEoCBIVCg1oZWFkX3JldmlzaW9uGAcgASgJIo4HCgRUYXNrEgoKAmlkGAEgASgFEhUKDXJlcG9zaXRvcnlfaWQYAiABKAUSEAoIam9iX25hbWUYAyABKAkSIAoYcGFyc2VkX2pvYl9jb25maWd1cmF0aW9uGAQgASgJEhAKCHJldmlzaW9uGAUgASgJEhUKDWJhemVsX3ZlcnNpb24YBiABKAkSDwoHY29tbWFuZBgHIAEoCRIQCghpc190cnVuaxgIIAEoCBIPCgdzdWNjZXNzGAkgASgIEhAKCGxvZ19maWxlGAogASgJEg8KB3RhcmdldHMYCyADKAkSEAoIcGxhdGZvcm0YDCABKAkSCwoDdmlhGA0gASgJEhMKC2NvbmZpZ19uYW1lGA4gASgJEgwKBG5vZGUYDyABKAkSEAoIbWFuaWZlc3QYECABKAkSEQoJY29udGFpbmVyGBEgASgJEhwKFGV4ZWN1dGVkX3Rlc3RzX2NvdW50GBIgASgFEh0KFXN1Y2NlZWRlZF90ZXN0c19jb3VudBgTIAEoBRIsCghzdGFydF9hdBgUIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASLwoLZmluaXNoZWRfYXQYFSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCmNyZWF0ZWRfYXQYFiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCnVwZGF0ZWRfYXQYFyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhYKDnJlcG9zaXRvcnlfdXJsGBggASgJEhQKDHJldmlzaW9uX3VybBgZIAEoCRIRCgljcHVfbGltaXQYGiABKAkSFAoMbWVtb3J5X2xpbWl0GBsgASgJEjIKDHRlc3RfcmVwb3J0cxgcIAMoCzIcLm1vbm8uYnVpbGQubW9kZWwuVGVzdFJlcG9ydBIPCgdza2lwcGVkGB0gASgIEg0KBW5lZWRzGB4gAygJEg8KB2F0dGVtcHQYHyABKAUSFgoOcGFyZW50X3Rhc2tfaWQYICABKAUSFQoNY2FuY2VsX3JlYXNvbhghIAEoCRIyCgZtYXRyaXgYIiADKAsyIi5tb25vLmJ1aWxkLm1vZGVsLlRhc2suTWF0cml4RW50cnkaLQoLTWF0cml4RW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASIqCgNKb2ISDAoEbmFtZRgBIAEoCRIVCg1yZXBvc2l0b3J5X2lkGAIgASgFIlsKClRlc3RSZXBvcnQSDQoFbGFiZWwYASABKAkSLAoGc3RhdHVzGAIgASgOMhwubW9uby5idWlsZC5tb2RlbC5UZXN0U3RhdHVzEhAKCGR1cmF0aW9uGAMgASgDIpECCgtHaXRodWJFdmVudBIKCgJpZBgBIAEoBRITCgtkZWxpdmVyeV9pZBgCIAEoCRISCgpldmVudF90eXBlGAMgASgJEg4KBmFjdGlvbhgEIAEoCRINCgVzdGF0ZRgFIAEoCRIOCgZzdGF0dXMYBiABKAkSEgoKbGFzdF9lcnJvchgHIAEoCRIuCgpjcmVhdGVkX2F0GAggASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GAkgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBISCgpyZXBvc2l0b3J5GAogASgJEhYKDnJlcG9zaXRvcnlfdXJsGAsgASgJIoECChZFeHRlcm5hbFJlbGVhc2VUcmlnZ2VyEgoKAmlkGAEgASgFEhUKDXJlcG9zaXRvcnlfaWQYAiABKAUSFwoPcmVwb3NpdG9yeV9uYW1lGAMgASgJEhYKDnJlcG9zaXRvcnlfdXJsGAQgASgJEhAKCGpvYl9uYW1lGAUgASgJEhAKCHByb3ZpZGVyGAYgASgJEhUKDWV4dGVybmFsX3JlcG8YByABKAkSGQoRZXh0ZXJuYWxfcmVwb191cmwYCCABKAkSDAoEa2luZBgJIAEoCRITCgt0YWdfcGF0dGVybhgKIAEoCRIaChJpbmNsdWRlX3ByZXJlbGVhc2UYCyABKAgiYQoMVGFyZ2V0UmVzdWx0Eg0KBWxhYmVsGAEgASgJEg8KB3N1Y2Nlc3MYAiABKAgSGAoQZmFpbHVyZV9jYXRlZ29yeRgDIAEoCRIXCg9mYWlsdXJlX21lc3NhZ2UYBCABKAkijgEKDUFjdGlvbkZhaWx1cmUSDQoFbGFiZWwYASABKAkSEAoIbW5lbW9uaWMYAiABKAkSEQoJZXhpdF9jb2RlGAMgASgFEhgKEGZhaWx1cmVfY2F0ZWdvcnkYBCABKAkSFwoPZmFpbHVyZV9tZXNzYWdlGAUgASgJEhYKDnByaW1hcnlfb3V0cHV0GAYgASgJIvQCCgxCdWlsZE1ldHJpY3MSFgoOZXhpdF9jb2RlX25hbWUYASABKAkSFwoPYWN0aW9uc19jcmVhdGVkGAIgASgDEhgKEGFjdGlvbnNfZXhlY3V0ZWQYAyABKAMSGQoRcmVtb3RlX2NhY2hlX2hpdHMYBCABKAMSGQoRYWN0aW9uX2NhY2hlX2hpdHMYBSABKAUSGwoTYWN0aW9uX2NhY2hlX21pc3NlcxgGIAEoBRIaChJ0YXJnZXRzX2NvbmZpZ3VyZWQYByABKAMSFAoMd2FsbF90aW1lX21zGAggASgDEhMKC2NwdV90aW1lX21zGAkgASgDEh4KFmFuYWx5c2lzX3BoYXNlX3RpbWVfbXMYCiABKAMSHwoXZXhlY3V0aW9uX3BoYXNlX3RpbWVfbXMYCyABKAMSHgoWcmVtb3RlX2NhY2hlX2hpdF9yYXRpbxgMIAEoARIeChZhY3Rpb25fY2FjaGVfaGl0X3JhdGlvGA0gASgBIpMBCglGbGFreVRlc3QSFQoNcmVwb3NpdG9yeV9pZBgBIAEoBRINCgVsYWJlbBgCIAEoCRIMCgRydW5zGAMgASgFEhIKCmZsYWt5X3J1bnMYBCABKAUSDQoFc2NvcmUYBSABKAESEwoLcXVhcmFudGluZWQYBiABKAgSGgoSbGFzdF9mbGFreV90YXNrX2lkGAcgASgFIlsKCEFydGlmYWN0Eg8KB3Rhc2tfaWQYASABKAUSDQoFbGFiZWwYAiABKAkSDAoEcGF0aBgDIAEoCRITCgtvYmplY3RfbmFtZRgEIAEoCRIMCgRzaXplGAUgASgDKlMKClRlc3RTdGF0dXMSFgoSVEVTVF9TVEFUVVNfUEFTU0VEEAASFQoRVEVTVF9TVEFUVVNfRkxBS1kQARIWChJURVNUX1NUQVRVU19GQUlMRUQQAkIpWh9nby5mMTEwLmRldi9tb25vL2dvL2J1aWxkL21vZGVskgMF0j4CEANiCGVkaXRpb25zcOgH", [file_google_protobuf_go_features, file_google_protobuf_timestamp]);
 
 /**
  * Describes the message mono.build.model.Repository.
@@ -53,6 +53,41 @@ export const GithubEventSchema = /*@__PURE__*/
 export const ExternalReleaseTriggerSchema = /*@__PURE__*/
   messageDesc(file_proto_build_model_msg, 5);
 
+/**
+ * Describes the message mono.build.model.TargetResult.
+ * Use `create(TargetResultSchema)` to create a new message.
+ */
+export const TargetResultSchema = /*@__PURE__*/
+  messageDesc(file_proto_build_model_msg, 6);
+
+/**
+ * Describes the message mono.build.model.ActionFailure.
+ * Use `create(ActionFailureSchema)` to create a new message.
+ */
+export const ActionFailureSchema = /*@__PURE__*/
+  messageDesc(file_proto_build_model_msg, 7);
+
+/**
+ * Describes the message mono.build.model.BuildMetrics.
+ * Use `create(BuildMetricsSchema)` to create a new message.
+ */
+export const BuildMetricsSchema = /*@__PURE__*/
+  messageDesc(file_proto_build_model_msg, 8);
+
+/**
+ * Describes the message mono.build.model.FlakyTest.
+ * Use `create(FlakyTestSchema)` to create a new message.
+ */
+export const FlakyTestSchema = /*@__PURE__*/
+  messageDesc(file_proto_build_model_msg, 9);
+
+/**
+ * Describes the message mono.build.model.Artifact.
+ * Use `create(ArtifactSchema)` to create a new message.
+ */
+export const ArtifactSchema = /*@__PURE__*/
+  messageDesc(file_proto_build_model_msg, 10);
+
 /**
  * Describes the enum mono.build.model.TestStatus.
  */
@@ -29,6 +29,7 @@ import CheckIcon from '@mui/icons-material/Check'
 import RefreshIcon from '@mui/icons-material/Refresh'
 import ErrorIcon from '@mui/icons-material/Error'
 import PlayArrowIcon from '@mui/icons-material/PlayArrow'
+import SkipNextIcon from '@mui/icons-material/SkipNext'
 import { LogModal } from '../../components/LogModal.tsx'
 import { ManifestModal } from '../../components/ManifestModal.tsx'
 import { RunTaskModal } from '../../components/RunTaskModal.tsx'
@@ -68,6 +69,8 @@ const TaskResultRow: React.FC<TaskResultRowProps> = ({
       <StyledTableCell align="center">
         {task.success ? (
           <CheckIcon color="success" />
+        ) : task.skipped ? (
+          <SkipNextIcon color="disabled" />
         ) : task.finishedAt ? (
           <ErrorIcon color="error" />
         ) : (
@@ -95,7 +98,10 @@ const TaskResultRow: React.FC<TaskResultRowProps> = ({
             : task.revision}
         </Link>
       </StyledTableCell>
-      <StyledTableCell>{task.jobName}</StyledTableCell>
+      <StyledTableCell>
+        {task.jobName}
+        {task.attempt > 1 && ` (attempt ${task.attempt})`}
+      </StyledTableCell>
       <StyledTableCell>{task.command}</StyledTableCell>
       <StyledTableCell>
         {task.finishedAt && (
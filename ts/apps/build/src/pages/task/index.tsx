@@ -5,6 +5,7 @@ import CheckIcon from '@mui/icons-material/Check'
 import ErrorIcon from '@mui/icons-material/Error'
 import ExpandMoreIcon from '@mui/icons-material/ExpandMore'
 import NavigateNextIcon from '@mui/icons-material/NavigateNext'
+import SkipNextIcon from '@mui/icons-material/SkipNext'
 import SyncIcon from '@mui/icons-material/Sync'
 import {
   Accordion,
@@ -35,7 +36,10 @@ import { useState } from 'react'
 import { LogModal } from '../../components/LogModal.tsx'
 import { ManifestModal } from '../../components/ManifestModal.tsx'
 import { BFF } from '../../connect/bff_pb'
+import { useDownloadArtifact } from '../../hooks/useDownloadArtifact.ts'
 import { useForceStopTask } from '../../hooks/useForceStopTask.ts'
+import { useGetTaskBuildResult } from '../../hooks/useGetTaskBuildResult.ts'
+import { useListArtifacts } from '../../hooks/useListArtifacts.ts'
 import { useLiveDuration } from '../../hooks/useLiveDuration.ts'
 import { useRestartTask } from '../../hooks/useRestartTask.ts'
 import { TestStatus } from '../../model/msg_pb'
@@ -46,6 +50,20 @@ const DefinitionTableCell = styled(TableCell)(({ theme }) => ({
   },
 }))
 
+const failureText = (category: string, message: string): string =>
+  [category, message].filter((v) => v).join(': ')
+
+const humanizeBytes = (size: bigint): string => {
+  const units = ['B', 'KiB', 'MiB', 'GiB']
+  let v = Number(size)
+  let i = 0
+  while (v >= 1024 && i < units.length - 1) {
+    v /= 1024
+    i++
+  }
+  return `${v.toFixed(i == 0 ? 0 : 1)} ${units[i]}`
+}
+
 export const TaskPage: React.FC = () => {
   const { taskId } = useParams({ strict: false })
   const {
@@ -89,6 +107,10 @@ export const TaskPage: React.FC = () => {
     setManifestModal(true)
   }
   const [logModal, setLogModal] = useState(false)
+  const buildResult = useGetTaskBuildResult(Number(taskId))
+  const failedTargets = buildResult?.targets.filter((v) => !v.success) ?? []
+  const artifacts = useListArtifacts(Number(taskId))
+  const downloadArtifact = useDownloadArtifact()
 
   const { mutate: restartTask } = useRestartTask()
   const handleRerun = () => {
@@ -131,6 +153,8 @@ export const TaskPage: React.FC = () => {
                   <DefinitionTableCell>
                     {task?.success ? (
                       <CheckIcon color="success" />
+                    ) : task?.skipped ? (
+                      <SkipNextIcon color="disabled" />
                     ) : task?.finishedAt ? (
                       <ErrorIcon color="error" />
                     ) : (
@@ -142,6 +166,35 @@ export const TaskPage: React.FC = () => {
                   <DefinitionTableCell>Job</DefinitionTableCell>
                   <DefinitionTableCell>{task?.jobName}</DefinitionTableCell>
                 </TableRow>
+                {task?.needs && task.needs.length > 0 && (
+                  <TableRow>
+                    <DefinitionTableCell>Needs</DefinitionTableCell>
+                    <DefinitionTableCell>
+                      {task.needs.join(', ')}
+                    </DefinitionTableCell>
+                  </TableRow>
+                )}
+                {task?.parentTaskId > 0 && (
+                  <TableRow>
+                    <DefinitionTableCell>Attempt</DefinitionTableCell>
+                    <DefinitionTableCell>
+                      {task.attempt} (retry of{' '}
+                      <Link
+                        to={'/task/$taskId'}
+                        params={{ taskId: String(task.parentTaskId) }}
+                      >
+                        {task.parentTaskId}
+                      </Link>
+                      )
+                    </DefinitionTableCell>
+                  </TableRow>
+                )}
+                {task?.cancelReason && (
+                  <TableRow>
+                    <DefinitionTableCell>Canceled</DefinitionTableCell>
+                    <DefinitionTableCell>{task.cancelReason}</DefinitionTableCell>
+                  </TableRow>
+                )}
                 <TableRow>
                   <DefinitionTableCell>Revision</DefinitionTableCell>
                   <DefinitionTableCell>
@@ -188,6 +241,90 @@ export const TaskPage: React.FC = () => {
                     )}
                   </DefinitionTableCell>
                 </TableRow>
+                {buildResult?.metrics && (
+                  <TableRow>
+                    <DefinitionTableCell>Cache hit</DefinitionTableCell>
+                    <DefinitionTableCell>
+                      {`Remote ${(buildResult.metrics.remoteCacheHitRatio * 100).toFixed(1)}% / Action ${(buildResult.metrics.actionCacheHitRatio * 100).toFixed(1)}% (${buildResult.metrics.actionsExecuted} actions executed)`}
+                    </DefinitionTableCell>
+                  </TableRow>
+                )}
+                {(failedTargets.length > 0 ||
+                  (buildResult?.actionFailures.length ?? 0) > 0) && (
+                  <TableRow>
+                    <DefinitionTableCell>Failures</DefinitionTableCell>
+                    <DefinitionTableCell>
+                      <Accordion>
+                        <AccordionSummary expandIcon={<ExpandMoreIcon />}>
+                          {`${failedTargets.length} targets / ${buildResult?.actionFailures.length} actions`}
+                        </AccordionSummary>
+                        <AccordionDetails>
+                          <List>
+                            {failedTargets.map((v) => (
+                              <ListItem key={`target-${v.label}`}>
+                                <ListItemIcon>
+                                  <ErrorIcon color="error" />
+                                </ListItemIcon>
+                                <ListItemText
+                                  primary={v.label}
+                                  secondary={failureText(
+                                    v.failureCategory,
+                                    v.failureMessage,
+                                  )}
+                                />
+                              </ListItem>
+                            ))}
+                            {buildResult?.actionFailures.map((v) => (
+                              <ListItem
+                                key={`action-${v.label}-${v.primaryOutput}`}
+                              >
+                                <ListItemIcon>
+                                  <ErrorIcon color="warning" />
+                                </ListItemIcon>
+                                <ListItemText
+                                  primary={`${v.mnemonic} ${v.label} (exit ${v.exitCode})`}
+                                  secondary={failureText(
+                                    v.failureCategory,
+                                    v.failureMessage,
+                                  )}
+                                />
+                              </ListItem>
+                            ))}
+                          </List>
+                        </AccordionDetails>
+                      </Accordion>
+                    </DefinitionTableCell>
+                  </TableRow>
+                )}
+                {artifacts.length > 0 && (
+                  <TableRow>
+                    <DefinitionTableCell>Artifacts</DefinitionTableCell>
+                    <DefinitionTableCell>
+                      <List>
+                        {artifacts.map((v) => (
+                          <ListItem key={v.path}>
+                            <ListItemText
+                              primary={
+                                <Link
+                                  to="."
+                                  onClick={() =>
+                                    void downloadArtifact(
+                                      Number(taskId),
+                                      v.path,
+                                    )
+                                  }
+                                >
+                                  {v.path}
+                                </Link>
+                              }
+                              secondary={`${v.label} (${humanizeBytes(v.size)})`}
+                            />
+                          </ListItem>
+                        ))}
+                      </List>
+                    </DefinitionTableCell>
+                  </TableRow>
+                )}
                 <TableRow>
                   <DefinitionTableCell>Duration</DefinitionTableCell>
                   <DefinitionTableCell>{duration}</DefinitionTableCell>
@@ -206,6 +343,17 @@ export const TaskPage: React.FC = () => {
                     {task?.bazelVersion}
                   </DefinitionTableCell>
                 </TableRow>
+                {task && Object.keys(task.matrix).length > 0 && (
+                  <TableRow>
+                    <DefinitionTableCell>Matrix</DefinitionTableCell>
+                    <DefinitionTableCell>
+                      {Object.entries(task.matrix)
+                        .sort(([a], [b]) => a.localeCompare(b))
+                        .map(([k, v]) => `${k}=${v}`)
+                        .join(", ")}
+                    </DefinitionTableCell>
+                  </TableRow>
+                )}
                 <TableRow>
                   <DefinitionTableCell>Container</DefinitionTableCell>
                   <DefinitionTableCell>{task?.container}</DefinitionTableCell>